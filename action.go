@@ -15,7 +15,13 @@
 package ctk
 
 import (
+	"fmt"
+
+	"github.com/gofrs/uuid"
+
 	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+
 	"github.com/go-curses/ctk/lib/enums"
 )
 
@@ -60,6 +66,7 @@ type Action interface {
 	GetVisible() (value bool)
 	SetVisible(visible bool)
 	Activate()
+	BlockActivate()
 	CreateMenuItem() (value Widget)
 	CreateToolItem() (value Widget)
 	CreateMenu() (value Widget)
@@ -73,6 +80,8 @@ type Action interface {
 	SetAccelPath(accelPath string)
 	GetAccelClosure() (value enums.GClosure)
 	SetAccelGroup(accelGroup AccelGroup)
+	GetActionGroup() (value ActionGroup)
+	SetActionGroup(actionGroup ActionGroup)
 	SetLabel(label string)
 	GetLabel() (value string)
 	SetShortLabel(shortLabel string)
@@ -99,6 +108,25 @@ type Action interface {
 // of interacting with Action objects
 type CAction struct {
 	CObject
+
+	proxies     []Widget
+	actionGroup ActionGroup
+	accelGroup  AccelGroup
+	accelId     uuid.UUID
+	accelCount  int
+	blocked     int
+}
+
+// actionProxySync is implemented by all CAction derivatives and is used by
+// the ActionGroup to push state changes out to the proxy widgets.
+type actionProxySync interface {
+	syncProxies()
+}
+
+// actionProxyDisconnect is implemented by all CAction derivatives and is used
+// by the UIManager to release proxy widgets it no longer displays.
+type actionProxyDisconnect interface {
+	disconnectProxy(proxy Widget)
 }
 
 // Default constructor for Action objects
@@ -110,6 +138,7 @@ func MakeAction() Action {
 func NewAction(name string, label string, tooltip string, stockId string) (value Action) {
 	a := new(CAction)
 	a.Init()
+	a.setup(name, label, tooltip, stockId)
 	return a
 }
 
@@ -122,6 +151,13 @@ func (a *CAction) Init() (already bool) {
 		return true
 	}
 	a.CObject.Init()
+	a.proxies = make([]Widget, 0)
+	a.actionGroup = nil
+	a.accelGroup = nil
+	a.accelCount = 0
+	a.blocked = 0
+	_ = a.InstallProperty(PropertyAccelPath, cdk.StringProperty, true, "")
+	_ = a.InstallProperty(PropertyActionGroup, cdk.StructProperty, true, nil)
 	_ = a.InstallProperty(PropertyAlwaysShowImage, cdk.BoolProperty, true, false)
 	_ = a.InstallProperty(PropertyIcon, cdk.StructProperty, true, nil)
 	_ = a.InstallProperty(PropertyHideIfEmpty, cdk.BoolProperty, true, true)
@@ -140,6 +176,23 @@ func (a *CAction) Init() (already bool) {
 	return false
 }
 
+// setup applies the constructor arguments to the Action properties.
+func (a *CAction) setup(name, label, tooltip, stockId string) {
+	if err := a.SetStringProperty(PropertyName, name); err != nil {
+		a.LogErr(err)
+	}
+	a.SetLabel(label)
+	a.SetTooltip(tooltip)
+	if stockId != "" {
+		a.SetStockId(StockID(stockId))
+		if label == "" {
+			if item := LookupStockItem(StockID(stockId)); item != nil {
+				a.SetLabel(item.Label)
+			}
+		}
+	}
+}
+
 // Returns the name of the action.
 // Parameters:
 // 	action	the action object
@@ -161,7 +214,12 @@ func (a *CAction) GetName() (value string) {
 // 	TRUE if the action and its associated action group are both
 // 	sensitive.
 func (a *CAction) IsSensitive() (value bool) {
-	return false
+	if value = a.GetSensitive(); value {
+		if group := a.GetActionGroup(); group != nil {
+			value = group.GetSensitive()
+		}
+	}
+	return
 }
 
 // Returns whether the action itself is sensitive. Note that this doesn't
@@ -189,6 +247,7 @@ func (a *CAction) SetSensitive(sensitive bool) {
 	if err := a.SetBoolProperty(PropertySensitive, sensitive); err != nil {
 		a.LogErr(err)
 	}
+	a.syncProxies()
 }
 
 // Returns whether the action is effectively visible.
@@ -198,7 +257,12 @@ func (a *CAction) SetSensitive(sensitive bool) {
 // 	TRUE if the action and its associated action group are both
 // 	visible.
 func (a *CAction) IsVisible() (value bool) {
-	return false
+	if value = a.GetVisible(); value {
+		if group := a.GetActionGroup(); group != nil {
+			value = group.GetVisible()
+		}
+	}
+	return
 }
 
 // Returns whether the action itself is visible. Note that this doesn't
@@ -226,6 +290,7 @@ func (a *CAction) SetVisible(visible bool) {
 	if err := a.SetBoolProperty(PropertyVisible, visible); err != nil {
 		a.LogErr(err)
 	}
+	a.syncProxies()
 }
 
 // Emits the "activate" signal on the specified action, if it isn't
@@ -233,7 +298,37 @@ func (a *CAction) SetVisible(visible bool) {
 // activated. It can also be used to manually activate an action.
 // Parameters:
 // 	action	the action object
-func (a *CAction) Activate() {}
+func (a *CAction) Activate() {
+	if !a.canActivate() {
+		return
+	}
+	group := a.GetActionGroup()
+	if group != nil {
+		group.Emit(SignalPreActivate, group, a.Self())
+	}
+	a.Emit(SignalActionActivate, a.Self())
+	if group != nil {
+		group.Emit(SignalPostActivate, group, a.Self())
+	}
+}
+
+// canActivate returns TRUE if the action is effectively sensitive and the
+// activation signals are not blocked.
+func (a *CAction) canActivate() bool {
+	a.RLock()
+	blocked := a.blocked > 0
+	a.RUnlock()
+	return !blocked && a.IsSensitive()
+}
+
+// Disable activation signals from the action. This is needed when updating
+// the state of proxy widgets would otherwise cause them to activate the
+// action again. See UnblockActivate.
+func (a *CAction) BlockActivate() {
+	a.Lock()
+	a.blocked += 1
+	a.Unlock()
+}
 
 // Creates a menu item widget that proxies for the given action.
 // Parameters:
@@ -242,7 +337,20 @@ func (a *CAction) Activate() {}
 // 	a menu item connected to the action.
 // 	[transfer none]
 func (a *CAction) CreateMenuItem() (value Widget) {
-	return nil
+	var item MenuItem
+	if toggle, ok := a.Self().(ToggleAction); ok {
+		check := NewCheckMenuItemWithMnemonic(a.GetLabel())
+		check.SetActive(toggle.GetActive())
+		check.SetDrawAsRadio(toggle.GetDrawAsRadio())
+		item = check
+	} else {
+		item = NewMenuItemWithMnemonic(a.GetLabel())
+	}
+	if accelPath := a.GetAccelPath(); accelPath != "" {
+		item.SetAccelPath(accelPath, nil)
+	}
+	a.connectProxy(item)
+	return item
 }
 
 // Creates a toolbar item widget that proxies for the given action.
@@ -252,7 +360,75 @@ func (a *CAction) CreateMenuItem() (value Widget) {
 // 	a toolbar item connected to the action.
 // 	[transfer none]
 func (a *CAction) CreateToolItem() (value Widget) {
-	return nil
+	var button ToolButton
+	if toggle, ok := a.Self().(ToggleAction); ok {
+		toggleButton := NewToggleToolButton(a.GetLabel())
+		toggleButton.SetActive(toggle.GetActive())
+		button = toggleButton
+	} else {
+		button = NewToolButton(a.GetLabel())
+	}
+	button.SetUseUnderline(true)
+	if stockId := a.GetStockId(); stockId != "" {
+		button.SetStockId(stockId)
+	}
+	button.SetIsImportant(a.GetIsImportant())
+	a.connectProxy(button)
+	return button
+}
+
+// connectProxy synchronizes the state of the proxy widget with the action,
+// activates the action when the proxy is activated and adds the proxy to the
+// list of proxies.
+func (a *CAction) connectProxy(proxy Widget) {
+	proxy.SetSensitive(a.IsSensitive())
+	if a.IsVisible() {
+		proxy.Show()
+	} else {
+		proxy.Hide()
+	}
+	proxy.Connect(actionProxySignal(proxy), fmt.Sprintf("%v-%v", ActionProxyActivateHandle, a.ObjectID()), func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+		if action, ok := a.Self().(Action); ok {
+			action.Activate()
+		}
+		return cenums.EVENT_PASS
+	})
+	a.Lock()
+	a.proxies = append(a.proxies, proxy)
+	a.Unlock()
+	if group := a.GetActionGroup(); group != nil {
+		group.Emit(SignalConnectProxy, group, a.Self(), proxy)
+	}
+}
+
+// disconnectProxy removes the proxy widget from the list of proxies and stops
+// the proxy from activating the action.
+func (a *CAction) disconnectProxy(proxy Widget) {
+	found := false
+	a.Lock()
+	for idx, known := range a.proxies {
+		if known.ObjectID() == proxy.ObjectID() {
+			a.proxies = append(a.proxies[:idx], a.proxies[idx+1:]...)
+			found = true
+			break
+		}
+	}
+	a.Unlock()
+	if !found {
+		return
+	}
+	_ = proxy.Disconnect(actionProxySignal(proxy), fmt.Sprintf("%v-%v", ActionProxyActivateHandle, a.ObjectID()))
+	if group := a.GetActionGroup(); group != nil {
+		group.Emit(SignalDisconnectProxy, group, a.Self(), proxy)
+	}
+}
+
+// actionProxySignal returns the signal a proxy widget emits when activated.
+func actionProxySignal(proxy Widget) cdk.Signal {
+	if _, ok := proxy.Self().(ToolButton); ok {
+		return SignalClicked
+	}
+	return SignalActivate
 }
 
 // If action provides a Menu widget as a submenu for the menu item or the
@@ -261,7 +437,7 @@ func (a *CAction) CreateToolItem() (value Widget) {
 // 	the menu item provided by the action, or NULL.
 // 	[transfer none]
 func (a *CAction) CreateMenu() (value Widget) {
-	return nil
+	return NewMenu()
 }
 
 // Returns the proxy widgets for an action. See also WidgetGetAction.
@@ -272,7 +448,41 @@ func (a *CAction) CreateMenu() (value Widget) {
 // 	not be modified.
 // 	[element-type Widget][transfer none]
 func (a *CAction) GetProxies() (value []interface{}) {
-	return nil
+	a.RLock()
+	defer a.RUnlock()
+	for _, proxy := range a.proxies {
+		value = append(value, proxy)
+	}
+	return
+}
+
+// syncProxies updates the sensitivity, visibility and active state of all
+// proxy widgets to match the effective state of the action.
+func (a *CAction) syncProxies() {
+	a.RLock()
+	proxies := append([]Widget{}, a.proxies...)
+	a.RUnlock()
+	if len(proxies) == 0 {
+		return
+	}
+	sensitive, visible := a.IsSensitive(), a.IsVisible()
+	toggle, isToggle := a.Self().(ToggleAction)
+	for _, proxy := range proxies {
+		proxy.SetSensitive(sensitive)
+		if visible {
+			proxy.Show()
+		} else {
+			proxy.Hide()
+		}
+		if isToggle {
+			switch p := proxy.(type) {
+			case CheckMenuItem:
+				p.SetActive(toggle.GetActive())
+			case ToggleToolButton:
+				p.SetActive(toggle.GetActive())
+			}
+		}
+	}
 }
 
 // Installs the accelerator for action if action has an accel path and group.
@@ -281,13 +491,68 @@ func (a *CAction) GetProxies() (value []interface{}) {
 // accelerator, the action counts the number of times this function has been
 // called and doesn't remove the accelerator until
 // DisconnectAccelerator has been called as many times.
-func (a *CAction) ConnectAccelerator() {}
+func (a *CAction) ConnectAccelerator() {
+	accelPath := a.GetAccelPath()
+	a.Lock()
+	accelGroup := a.accelGroup
+	if accelPath == "" || accelGroup == nil {
+		a.Unlock()
+		return
+	}
+	if a.accelCount > 0 {
+		a.accelCount += 1
+		a.Unlock()
+		return
+	}
+	a.Unlock()
+	accelMap := GetAccelMap()
+	if accelMap == nil {
+		a.LogError("accelmap not found for current application thread")
+		return
+	}
+	accelerator, ok := accelMap.LookupEntry(accelPath)
+	if !ok {
+		a.LogError("accelerator path not found: %v", accelPath)
+		return
+	}
+	id := accelGroup.AccelConnect(
+		accelerator.Key(),
+		accelerator.Mods(),
+		enums.ACCEL_VISIBLE,
+		fmt.Sprintf("%v-%v", ActionAccelHandle, a.ObjectID()),
+		a.accelActivate,
+	)
+	a.Lock()
+	a.accelId = id
+	a.accelCount = 1
+	a.Unlock()
+}
 
 // Undoes the effect of one call to ConnectAccelerator.
-func (a *CAction) DisconnectAccelerator() {}
+func (a *CAction) DisconnectAccelerator() {
+	a.Lock()
+	if a.accelGroup == nil || a.accelCount == 0 {
+		a.Unlock()
+		return
+	}
+	accelGroup, id := a.accelGroup, a.accelId
+	if a.accelCount -= 1; a.accelCount > 0 {
+		a.Unlock()
+		return
+	}
+	a.accelId = uuid.Nil
+	a.Unlock()
+	accelGroup.AccelDisconnect(id)
+}
 
 // Reenable activation signals from the action
-func (a *CAction) UnblockActivate() {}
+func (a *CAction) UnblockActivate() {
+	a.Lock()
+	if a.blocked > 0 {
+		a.blocked -= 1
+	}
+	a.Unlock()
+}
 
 // Returns whether action 's menu item proxies will ignore the
 // “gtk-menu-images” setting and always show their image, if available.
@@ -321,7 +586,11 @@ func (a *CAction) SetAlwaysShowImage(alwaysShow bool) {
 // 	returned string is owned by CTK and must not be freed or
 // 	modified.
 func (a *CAction) GetAccelPath() (value string) {
-	return ""
+	var err error
+	if value, err = a.GetStringProperty(PropertyAccelPath); err != nil {
+		a.LogErr(err)
+	}
+	return
 }
 
 // Sets the accel path for this action. All proxy widgets associated with the
@@ -330,7 +599,19 @@ func (a *CAction) GetAccelPath() (value string) {
 // Parameters:
 // 	action	the action object
 // 	accelPath	the accelerator path
-func (a *CAction) SetAccelPath(accelPath string) {}
+func (a *CAction) SetAccelPath(accelPath string) {
+	if err := a.SetStringProperty(PropertyAccelPath, accelPath); err != nil {
+		a.LogErr(err)
+	}
+	a.RLock()
+	proxies := append([]Widget{}, a.proxies...)
+	a.RUnlock()
+	for _, proxy := range proxies {
+		if item, ok := proxy.(MenuItem); ok {
+			item.SetAccelPath(accelPath, nil)
+		}
+	}
+}
 
 // Returns the accel closure for this action.
 // Parameters:
@@ -338,7 +619,15 @@ func (a *CAction) SetAccelPath(accelPath string) {}
 // Returns:
 // 	the accel closure for this action.
 func (a *CAction) GetAccelClosure() (value enums.GClosure) {
-	return nil
+	return a.accelActivate
+}
+
+func (a *CAction) accelActivate(argv ...interface{}) (handled bool) {
+	if action, ok := a.Self().(Action); ok && action.IsSensitive() {
+		action.Activate()
+		return true
+	}
+	return false
 }
 
 // Sets the AccelGroup in which the accelerator for this action will be
@@ -346,8 +635,42 @@ func (a *CAction) GetAccelClosure() (value enums.GClosure) {
 // Parameters:
 // 	action	the action object
 // 	accelGroup	a AccelGroup or NULL.
-func (a *CAction) SetAccelGroup(accelGroup AccelGroup) {}
+func (a *CAction) SetAccelGroup(accelGroup AccelGroup) {
+	a.Lock()
+	a.accelGroup = accelGroup
+	a.Unlock()
+}
 
+// GetActionGroup returns the ActionGroup the action was added to, or nil if
+// the action is not part of any group.
+func (a *CAction) GetActionGroup() (value ActionGroup) {
+	var ok bool
+	if v, err := a.GetStructProperty(PropertyActionGroup); err != nil {
+		a.LogErr(err)
+	} else if v != nil {
+		if value, ok = v.(ActionGroup); !ok {
+			a.LogError("value stored in %v property is not of ActionGroup type: %v (%T)", PropertyActionGroup, v, v)
+		}
+	}
+	return
+}
+
+// SetActionGroup updates the ActionGroup the action belongs to. This is
+// called by ActionGroup.AddAction and ActionGroup.RemoveAction and there
+// should be no need to call it directly.
+//
+// Parameters:
+// 	actionGroup	the ActionGroup or nil
+func (a *CAction) SetActionGroup(actionGroup ActionGroup) {
+	var value interface{}
+	if actionGroup != nil {
+		value = actionGroup
+	}
+	if err := a.SetStructProperty(PropertyActionGroup, value); err != nil {
+		a.LogErr(err)
+	}
+	a.syncProxies()
+}
 // Sets the label of action .
 // Parameters:
 // 	label	the label text to set
@@ -428,7 +751,7 @@ func (a *CAction) GetStockId() (value StockID) {
 	var v interface{}
 	if v, err = a.GetStructProperty(PropertyStockId); err != nil {
 		a.LogErr(err)
-	} else {
+	} else if v != nil {
 		if val, ok := v.(StockID); ok {
 			value = val
 		} else {
@@ -537,6 +860,16 @@ func (a *CAction) GetIsImportant() (value bool) {
 	return
 }
 
+// The accelerator path of the action, see SetAccelPath.
+// Flags: Read / Write
+// Default value: NULL
+// const PropertyAccelPath cdk.Property = "accel-path"
+
+// The ActionGroup this Action is associated with, or NULL (for internal
+// use).
+// Flags: Read / Write
+const PropertyActionGroup cdk.Property = "action-group"
+
 // If TRUE, the action's menu item proxies will ignore the
 // “gtk-menu-images” setting and always show their image, if available.
 // Use this property if the menu item would be useless or hard to use without
@@ -632,4 +965,8 @@ const PropertyVisibleOverflown cdk.Property = "visible-overflown"
 const PropertyVisibleVertical cdk.Property = "visible-vertical"
 
 // The "activate" signal is emitted when the action is activated.
-const SignalActionActivate cdk.Signal = "activate"
\ No newline at end of file
+const SignalActionActivate cdk.Signal = "activate"
+
+const ActionProxyActivateHandle = "action-proxy-activate-handler"
+
+const ActionAccelHandle = "action-accel-handler"
@@ -15,7 +15,11 @@
 package ctk
 
 import (
+	"fmt"
+
 	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+
 	"github.com/go-curses/ctk/lib/enums"
 )
 
@@ -60,6 +64,12 @@ type ActionGroup interface {
 // of interacting with ActionGroup objects.
 type CActionGroup struct {
 	CObject
+
+	actions         []Action
+	notifies        []func()
+	translateFn     TranslateFunc
+	translateData   interface{}
+	translateNotify GDestroyNotify
 }
 
 // MakeActionGroup is used by the Buildable system to construct a new ActionGroup.
@@ -71,6 +81,9 @@ func MakeActionGroup() ActionGroup {
 func NewActionGroup(name string) (value ActionGroup) {
 	a := new(CActionGroup)
 	a.Init()
+	if err := a.SetStringProperty(PropertyName, name); err != nil {
+		a.LogErr(err)
+	}
 	return a
 }
 
@@ -85,6 +98,12 @@ func (a *CActionGroup) Init() (already bool) {
 		return true
 	}
 	a.CObject.Init()
+	a.actions = make([]Action, 0)
+	a.notifies = make([]func(), 0)
+	_ = a.InstallProperty(PropertyName, cdk.StringProperty, true, "")
+	_ = a.InstallProperty(PropertySensitive, cdk.BoolProperty, true, true)
+	_ = a.InstallProperty(PropertyVisible, cdk.BoolProperty, true, true)
+	a.Connect(cdk.SignalDestroy, ActionGroupDestroyHandle, a.destroy)
 	return false
 }
 
@@ -124,6 +143,7 @@ func (a *CActionGroup) SetSensitive(sensitive bool) {
 	if err := a.SetBoolProperty(PropertySensitive, sensitive); err != nil {
 		a.LogErr(err)
 	}
+	a.syncActionProxies()
 }
 
 // Returns TRUE if the group is visible. The constituent actions can only be
@@ -149,6 +169,17 @@ func (a *CActionGroup) SetVisible(visible bool) {
 	if err := a.SetBoolProperty(PropertyVisible, visible); err != nil {
 		a.LogErr(err)
 	}
+	a.syncActionProxies()
+}
+
+// syncActionProxies updates the proxies of all actions in the group to
+// reflect the effective sensitivity and visibility of each action.
+func (a *CActionGroup) syncActionProxies() {
+	for _, action := range a.ListActions() {
+		if proxySync, ok := action.(actionProxySync); ok {
+			proxySync.syncProxies()
+		}
+	}
 }
 
 // Looks up an action in the action group by name.
@@ -159,6 +190,13 @@ func (a *CActionGroup) SetVisible(visible bool) {
 // 	the action, or NULL if no action by that name exists.
 // 	[transfer none]
 func (a *CActionGroup) GetAction(actionName string) (value Action) {
+	a.RLock()
+	defer a.RUnlock()
+	for _, action := range a.actions {
+		if action.GetName() == actionName {
+			return action
+		}
+	}
 	return nil
 }
 
@@ -169,7 +207,10 @@ func (a *CActionGroup) GetAction(actionName string) (value Action) {
 // 	an allocated list of the action objects in the action group.
 // 	[element-type Action][transfer container]
 func (a *CActionGroup) ListActions() (value []Action) {
-	return nil
+	a.RLock()
+	defer a.RUnlock()
+	value = append(value, a.actions...)
+	return
 }
 
 // Adds an action object to the action group. Note that this function does
@@ -181,25 +222,104 @@ func (a *CActionGroup) ListActions() (value []Action) {
 // Parameters:
 // 	actionGroup	the action group
 // 	action	an action
-func (a *CActionGroup) AddAction(action Action) {}
+func (a *CActionGroup) AddAction(action Action) {
+	if action == nil {
+		return
+	}
+	name := action.GetName()
+	if a.GetAction(name) != nil {
+		a.LogError("action %q already exists in action group %q", name, a.GetName())
+		return
+	}
+	a.Lock()
+	a.actions = append(a.actions, action)
+	a.Unlock()
+	action.SetActionGroup(a)
+}
 
 // Adds an action object to the action group and sets up the accelerator. If
-// accelerator is NULL, attempts to use the accelerator associated with the
+// accelerator is empty, attempts to use the accelerator associated with the
 // stock_id of the action. Accel paths are set to
-// <Actions>/group-name/action-name.
+// <Actions>/group-name/action-name and the accelerator is registered with the
+// AccelMap of the current Application under that path.
 // Parameters:
 // 	actionGroup	the action group
 // 	action	the action to add
 // 	accelerator	the accelerator for the action, in
-// the format understood by AcceleratorParse, or "" for no accelerator, or
-// NULL to use the stock accelerator.
-func (a *CActionGroup) AddActionWithAccel(action Action, accelerator string) {}
+// the format understood by cdk.ParseKeyMods (ie: "<Control>q"), or "" to
+// use the stock accelerator, if any.
+func (a *CActionGroup) AddActionWithAccel(action Action, accelerator string) {
+	if action == nil {
+		return
+	}
+	accelPath := fmt.Sprintf("<Actions>/%v/%v", a.GetName(), action.GetName())
+	var key cdk.Key
+	var mods cdk.ModMask
+	found := false
+	if accelerator != "" {
+		var err error
+		if key, mods, err = cdk.ParseKeyMods(accelerator); err != nil {
+			a.LogErr(err)
+		} else {
+			found = true
+		}
+	} else if stockId := action.GetStockId(); stockId != "" {
+		if item := LookupStockItem(stockId); item != nil && item.Key != 0 {
+			key, mods, found = item.Key, stockModMask(item.Mods), true
+		}
+	}
+	if found {
+		if accelMap := GetAccelMap(); accelMap != nil {
+			if _, ok := accelMap.LookupEntry(accelPath); ok {
+				accelMap.ChangeEntry(accelPath, key, mods, true)
+			} else {
+				accelMap.AddEntry(accelPath, key, mods)
+			}
+		}
+	}
+	action.SetAccelPath(accelPath)
+	a.AddAction(action)
+}
+
+// stockModMask translates the modifiers of a StockItem to a cdk.ModMask.
+func stockModMask(mods enums.ModifierType) (mask cdk.ModMask) {
+	if mods.HasBit(enums.ShiftMask) {
+		mask |= cdk.ModShift
+	}
+	if mods.HasBit(enums.ControlMask) {
+		mask |= cdk.ModCtrl
+	}
+	if mods.HasBit(enums.Mod1Mask) {
+		mask |= cdk.ModAlt
+	}
+	if mods.HasBit(enums.MetaMask) {
+		mask |= cdk.ModMeta
+	}
+	return
+}
 
 // Removes an action object from the action group.
 // Parameters:
 // 	actionGroup	the action group
 // 	action	an action
-func (a *CActionGroup) RemoveAction(action Action) {}
+func (a *CActionGroup) RemoveAction(action Action) {
+	if action == nil {
+		return
+	}
+	removed := false
+	a.Lock()
+	for idx, known := range a.actions {
+		if known.ObjectID() == action.ObjectID() {
+			a.actions = append(a.actions[:idx], a.actions[idx+1:]...)
+			removed = true
+			break
+		}
+	}
+	a.Unlock()
+	if removed {
+		action.SetActionGroup(nil)
+	}
+}
 
 // This is a convenience function to create a number of actions and add them
 // to the action group. The "activate" signals of the actions are connected
@@ -210,7 +330,9 @@ func (a *CActionGroup) RemoveAction(action Action) {}
 // 	entries	an array of action descriptions
 // 	nEntries	the number of entries
 // 	userData	data to pass to the action callbacks
-func (a *CActionGroup) AddActions(entries []ActionEntry, nEntries int, userData interface{}) {}
+func (a *CActionGroup) AddActions(entries []ActionEntry, nEntries int, userData interface{}) {
+	a.AddActionsFull(entries, nEntries, userData, nil)
+}
 
 // This variant of AddActions adds a GDestroyNotify
 // callback for user_data .
@@ -222,6 +344,12 @@ func (a *CActionGroup) AddActions(entries []ActionEntry, nEntries int, userData
 // 	destroy	destroy notification callback for user_data
 //
 func (a *CActionGroup) AddActionsFull(entries []ActionEntry, nEntries int, userData interface{}, destroy GDestroyNotify) {
+	for _, entry := range entries[:entryCount(nEntries, len(entries))] {
+		action := NewAction(entry.Name, a.translate(entry.Label), a.translate(entry.Tooltip), entry.StockId)
+		a.connectCallback(action, entry.Callback)
+		a.AddActionWithAccel(action, entry.Accelerator)
+	}
+	a.addDestroyNotify(userData, destroy)
 }
 
 // This is a convenience function to create a number of toggle actions and
@@ -234,6 +362,7 @@ func (a *CActionGroup) AddActionsFull(entries []ActionEntry, nEntries int, userD
 // 	nEntries	the number of entries
 // 	userData	data to pass to the action callbacks
 func (a *CActionGroup) AddToggleActions(entries []ToggleActionEntry, nEntries int, userData interface{}) {
+	a.AddToggleActionsFull(entries, nEntries, userData, nil)
 }
 
 // This variant of AddToggleActions adds a
@@ -246,6 +375,13 @@ func (a *CActionGroup) AddToggleActions(entries []ToggleActionEntry, nEntries in
 // 	destroy	destroy notification callback for user_data
 //
 func (a *CActionGroup) AddToggleActionsFull(entries []ToggleActionEntry, nEntries int, userData interface{}, destroy GDestroyNotify) {
+	for _, entry := range entries[:entryCount(nEntries, len(entries))] {
+		action := NewToggleAction(entry.Name, a.translate(entry.Label), a.translate(entry.Tooltip), entry.StockId)
+		action.SetActive(entry.IsActive)
+		a.connectCallback(action, entry.Callback)
+		a.AddActionWithAccel(action, entry.Accelerator)
+	}
+	a.addDestroyNotify(userData, destroy)
 }
 
 // This is a convenience routine to create a group of radio actions and add
@@ -261,6 +397,7 @@ func (a *CActionGroup) AddToggleActionsFull(entries []ToggleActionEntry, nEntrie
 // 	onChange	the callback to connect to the changed signal
 // 	userData	data to pass to the action callbacks
 func (a *CActionGroup) AddRadioActions(entries []RadioActionEntry, nEntries int, value int, onChange enums.GCallback, userData interface{}) {
+	a.AddRadioActionsFull(entries, nEntries, value, onChange, userData, nil)
 }
 
 // This variant of AddRadioActions adds a GDestroyNotify
@@ -276,6 +413,62 @@ func (a *CActionGroup) AddRadioActions(entries []RadioActionEntry, nEntries int,
 // 	destroy	destroy notification callback for user_data
 //
 func (a *CActionGroup) AddRadioActionsFull(entries []RadioActionEntry, nEntries int, value int, onChange enums.GCallback, userData interface{}, destroy GDestroyNotify) {
+	var first RadioAction
+	for _, entry := range entries[:entryCount(nEntries, len(entries))] {
+		action := NewRadioAction(entry.Name, a.translate(entry.Label), a.translate(entry.Tooltip), entry.StockId, entry.Value)
+		if first == nil {
+			first = action
+		} else {
+			action.JoinGroup(first)
+		}
+		if entry.Value == value {
+			action.SetActive(true)
+		}
+		a.AddActionWithAccel(action, entry.Accelerator)
+	}
+	if first != nil && onChange != nil {
+		first.Connect(SignalRadioActionChanged, ActionGroupCallbackHandle, func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+			onChange()
+			return cenums.EVENT_PASS
+		})
+	}
+	a.addDestroyNotify(userData, destroy)
+}
+
+// entryCount returns the number of entries to use, nEntries clamped to the
+// length of the given entries. A negative nEntries uses all entries.
+func entryCount(nEntries, length int) int {
+	if nEntries < 0 || nEntries > length {
+		return length
+	}
+	return nEntries
+}
+
+func (a *CActionGroup) connectCallback(action Action, callback enums.GCallback) {
+	if callback == nil {
+		return
+	}
+	action.Connect(SignalActionActivate, ActionGroupCallbackHandle, func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+		callback()
+		return cenums.EVENT_PASS
+	})
+}
+
+func (a *CActionGroup) addDestroyNotify(userData interface{}, destroy GDestroyNotify) {
+	if destroy == nil {
+		return
+	}
+	a.Lock()
+	a.notifies = append(a.notifies, func() { destroy(userData) })
+	a.Unlock()
+}
+
+// translate passes non-empty strings through TranslateString.
+func (a *CActionGroup) translate(message string) string {
+	if message == "" {
+		return message
+	}
+	return a.TranslateString(message)
 }
 
 // Sets a function to be used for translating the label and tooltip of
@@ -290,7 +483,15 @@ func (a *CActionGroup) AddRadioActionsFull(entries []RadioActionEntry, nEntries
 // 	notify	a GDestroyNotify function to be called when action_group
 // is
 // destroyed and when the translation function is changed again
-func (a *CActionGroup) SetTranslateFunc(fn TranslateFunc, data interface{}, notify GDestroyNotify) {}
+func (a *CActionGroup) SetTranslateFunc(fn TranslateFunc, data interface{}, notify GDestroyNotify) {
+	a.Lock()
+	previousData, previousNotify := a.translateData, a.translateNotify
+	a.translateFn, a.translateData, a.translateNotify = fn, data, notify
+	a.Unlock()
+	if previousNotify != nil {
+		previousNotify(previousData)
+	}
+}
 
 // Sets the translation domain and uses g_dgettext for translating the
 // label and tooltip of ActionEntrys added by
@@ -307,7 +508,29 @@ func (a *CActionGroup) SetTranslationDomain(domain string) {}
 // Returns:
 // 	the translation of string
 func (a *CActionGroup) TranslateString(string string) (value string) {
-	return ""
+	a.RLock()
+	fn := a.translateFn
+	a.RUnlock()
+	if fn != nil {
+		return fn(string)
+	}
+	return string
+}
+
+func (a *CActionGroup) destroy(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	a.Lock()
+	notifies := a.notifies
+	a.notifies = make([]func(), 0)
+	translateData, translateNotify := a.translateData, a.translateNotify
+	a.translateFn, a.translateData, a.translateNotify = nil, nil, nil
+	a.Unlock()
+	for _, notify := range notifies {
+		notify()
+	}
+	if translateNotify != nil {
+		translateNotify(translateData)
+	}
+	return cenums.EVENT_PASS
 }
 
 // A name for the action group.
@@ -361,6 +584,10 @@ const SignalPostActivate cdk.Signal = "post-activate"
 // 	action Action	the action
 const SignalPreActivate cdk.Signal = "pre-activate"
 
+const ActionGroupDestroyHandle = "action-group-destroy-handler"
+
+const ActionGroupCallbackHandle = "action-group-callback-handler"
+
 type GDestroyNotify = func(data interface{})
 
 type TranslateFunc = func(messageId string) (translated string)
\ No newline at end of file
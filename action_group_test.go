@@ -0,0 +1,215 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"testing"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestActionGroup(t *testing.T) {
+	Convey("Testing Action Groups", t, func() {
+		Convey("Basics", func() {
+			ag := &CActionGroup{}
+			So(ag.Init(), ShouldEqual, false)
+			So(ag.Init(), ShouldEqual, true)
+			group := NewActionGroup("main")
+			So(group.GetName(), ShouldEqual, "main")
+			So(group.GetSensitive(), ShouldEqual, true)
+			So(group.GetVisible(), ShouldEqual, true)
+			quit := NewAction("quit", "_Quit", "", "")
+			group.AddAction(quit)
+			So(group.ListActions(), ShouldHaveLength, 1)
+			So(group.GetAction("quit"), ShouldEqual, quit)
+			So(quit.GetActionGroup(), ShouldEqual, group)
+			group.AddAction(NewAction("quit", "", "", ""))
+			So(group.ListActions(), ShouldHaveLength, 1)
+			group.RemoveAction(quit)
+			So(group.ListActions(), ShouldHaveLength, 0)
+			So(group.GetAction("quit"), ShouldBeNil)
+			So(quit.GetActionGroup(), ShouldBeNil)
+		})
+		Convey("Activation", func() {
+			group := NewActionGroup("main")
+			action := NewAction("open", "_Open", "", "")
+			group.AddAction(action)
+			var order []string
+			group.Connect(SignalPreActivate, "test-pre", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				order = append(order, "pre")
+				return cenums.EVENT_PASS
+			})
+			action.Connect(SignalActionActivate, "test-activate", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				order = append(order, "activate")
+				return cenums.EVENT_PASS
+			})
+			group.Connect(SignalPostActivate, "test-post", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				order = append(order, "post")
+				return cenums.EVENT_PASS
+			})
+			action.Activate()
+			So(order, ShouldResemble, []string{"pre", "activate", "post"})
+			order = nil
+			action.BlockActivate()
+			action.Activate()
+			So(order, ShouldBeEmpty)
+			action.UnblockActivate()
+			group.SetSensitive(false)
+			So(action.GetSensitive(), ShouldEqual, true)
+			So(action.IsSensitive(), ShouldEqual, false)
+			action.Activate()
+			So(order, ShouldBeEmpty)
+			group.SetSensitive(true)
+			action.Activate()
+			So(order, ShouldResemble, []string{"pre", "activate", "post"})
+		})
+		Convey("Proxies", func() {
+			group := NewActionGroup("main")
+			action := NewAction("save", "_Save", "", "")
+			group.AddAction(action)
+			item := action.CreateMenuItem()
+			So(item.IsSensitive(), ShouldEqual, true)
+			So(item.IsVisible(), ShouldEqual, true)
+			group.SetSensitive(false)
+			So(item.IsSensitive(), ShouldEqual, false)
+			group.SetSensitive(true)
+			So(item.IsSensitive(), ShouldEqual, true)
+			group.SetVisible(false)
+			So(item.IsVisible(), ShouldEqual, false)
+			group.SetVisible(true)
+			So(item.IsVisible(), ShouldEqual, true)
+			action.SetSensitive(false)
+			So(item.IsSensitive(), ShouldEqual, false)
+			activated := false
+			action.SetSensitive(true)
+			action.Connect(SignalActionActivate, "test-activate", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				activated = true
+				return cenums.EVENT_PASS
+			})
+			So(item.(MenuItem).Activate(), ShouldEqual, true)
+			So(activated, ShouldEqual, true)
+			toggle := NewToggleAction("wrap", "_Wrap", "", "")
+			group.AddAction(toggle)
+			check := toggle.CreateMenuItem().(CheckMenuItem)
+			So(check.GetActive(), ShouldEqual, false)
+			toggle.Activate()
+			So(toggle.GetActive(), ShouldEqual, true)
+			So(check.GetActive(), ShouldEqual, true)
+			check.Activate()
+			So(check.GetActive(), ShouldEqual, false)
+			So(toggle.GetActive(), ShouldEqual, false)
+		})
+		Convey("Entries", func() {
+			group := NewActionGroup("main")
+			group.SetTranslateFunc(func(messageId string) string {
+				return "T:" + messageId
+			}, nil, nil)
+			called := 0
+			destroyed := ""
+			group.AddActionsFull([]ActionEntry{
+				{Name: "new", Label: "_New", Callback: func() { called += 1 }},
+				{Name: "open", StockId: string(StockOpen)},
+				{Name: "skipped"},
+			}, 2, "data", func(data interface{}) {
+				destroyed = data.(string)
+			})
+			So(group.ListActions(), ShouldHaveLength, 2)
+			So(group.GetAction("new").GetLabel(), ShouldEqual, "T:_New")
+			So(group.GetAction("open").GetLabel(), ShouldEqual, "_Open")
+			So(group.GetAction("new").GetAccelPath(), ShouldEqual, "<Actions>/main/new")
+			group.GetAction("new").Activate()
+			So(called, ShouldEqual, 1)
+			group.AddToggleActions([]ToggleActionEntry{
+				{Name: "wrap", IsActive: true},
+				{Name: "bold"},
+			}, -1, nil)
+			So(group.GetAction("wrap").(ToggleAction).GetActive(), ShouldEqual, true)
+			So(group.GetAction("bold").(ToggleAction).GetActive(), ShouldEqual, false)
+			changes := 0
+			group.AddRadioActions([]RadioActionEntry{
+				{Name: "small", Value: 1},
+				{Name: "medium", Value: 2},
+				{Name: "large", Value: 3},
+			}, 3, 2, func() { changes += 1 }, nil)
+			small := group.GetAction("small").(RadioAction)
+			medium := group.GetAction("medium").(RadioAction)
+			large := group.GetAction("large").(RadioAction)
+			So(small.GetGroupMembers(), ShouldHaveLength, 3)
+			So(medium.GetActive(), ShouldEqual, true)
+			So(small.GetCurrentValue(), ShouldEqual, 2)
+			So(changes, ShouldEqual, 0)
+			large.Activate()
+			So(changes, ShouldEqual, 1)
+			So(medium.GetActive(), ShouldEqual, false)
+			So(large.GetActive(), ShouldEqual, true)
+			So(small.GetCurrentValue(), ShouldEqual, 3)
+			large.SetActive(false)
+			So(large.GetActive(), ShouldEqual, true)
+			small.SetCurrentValue(1)
+			So(small.GetActive(), ShouldEqual, true)
+			So(large.GetActive(), ShouldEqual, false)
+			So(changes, ShouldEqual, 2)
+			So(destroyed, ShouldEqual, "")
+			group.Destroy()
+			So(destroyed, ShouldEqual, "data")
+		})
+		Convey("Accelerators", WithApp(
+			TestingWithCtkWindow,
+			func(app Application) {
+				cdk.GoWithMainContext("", "localhost", app.Display(), app.Self(), func() {
+					group := NewActionGroup("main")
+					group.AddActions([]ActionEntry{
+						{Name: "quit", Label: "_Quit", Accelerator: "<Control>q"},
+						{Name: "copy", StockId: string(StockCopy)},
+						{Name: "plain", Label: "_Plain"},
+					}, 3, nil)
+					accelerator, ok := app.AccelMap().LookupEntry("<Actions>/main/quit")
+					So(ok, ShouldEqual, true)
+					So(accelerator.Key(), ShouldEqual, cdk.KeySmallQ)
+					So(accelerator.Mods(), ShouldEqual, cdk.ModCtrl)
+					accelerator, ok = app.AccelMap().LookupEntry("<Actions>/main/copy")
+					So(ok, ShouldEqual, true)
+					So(accelerator.Key(), ShouldEqual, cdk.KeySmallC)
+					So(accelerator.Mods(), ShouldEqual, cdk.ModCtrl)
+					_, ok = app.AccelMap().LookupEntry("<Actions>/main/plain")
+					So(ok, ShouldEqual, false)
+					item := group.GetAction("quit").CreateMenuItem().(MenuItem)
+					So(item.GetAccelPath(), ShouldEqual, "<Actions>/main/quit")
+
+					quit := group.GetAction("quit")
+					activated := 0
+					quit.Connect(SignalActionActivate, "test-activate", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+						activated += 1
+						return cenums.EVENT_PASS
+					})
+					accelGroup := NewAccelGroup()
+					quit.SetAccelGroup(accelGroup)
+					quit.ConnectAccelerator()
+					quit.ConnectAccelerator()
+					So(accelGroup.Query(cdk.KeySmallQ, cdk.ModCtrl), ShouldHaveLength, 1)
+					accelGroup.AccelGroupActivate(cdk.KeySmallQ, cdk.ModCtrl)
+					So(activated, ShouldEqual, 1)
+					quit.DisconnectAccelerator()
+					So(accelGroup.Query(cdk.KeySmallQ, cdk.ModCtrl), ShouldHaveLength, 1)
+					quit.DisconnectAccelerator()
+					So(accelGroup.Query(cdk.KeySmallQ, cdk.ModCtrl), ShouldHaveLength, 0)
+					So(quit.GetAccelClosure(), ShouldNotBeNil)
+				})
+			},
+		))
+	})
+}
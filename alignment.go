@@ -261,7 +261,7 @@ func (a *CAlignment) resize(data []interface{}, argv ...interface{}) cenums.Even
 		xAlign, yAlign, xScale, yScale := a.Get()
 		origin := a.GetOrigin()
 		a.Lock()
-		size := ptypes.NewRectangle(child.GetSizeRequest())
+		size := ptypes.NewRectangle(GetGroupedSizeRequest(child))
 		if size.W <= -1 || size.W > alloc.W {
 			size.W = alloc.W
 		}
@@ -0,0 +1,884 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	cstrings "github.com/go-curses/cdk/lib/strings"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeAssistant cdk.CTypeTag = "ctk-assistant"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeAssistant, func() interface{} { return MakeAssistant() })
+	ctkBuilderTranslators[TypeAssistant] = func(builder Builder, widget Widget, name, value string) error {
+		if fn, ok := ctkBuilderTranslators[TypeWindow]; ok {
+			return fn(builder, widget, name, value)
+		}
+		return fmt.Errorf("assistant property translator not implemented")
+	}
+}
+
+// AssistantPageFunc is the function used by Assistant to compute the page
+// number of the next page to show when the user presses the forward button.
+// The function is given the current page number and returns the next page
+// number, or -1 when there is no next page.
+type AssistantPageFunc = func(currentPage int) (nextPage int)
+
+// Assistant Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Bin
+//	        +- Window
+//	          +- Assistant
+//
+// The Assistant Widget is a Window used to guide the user through a multi-step
+// operation, one page at a time. The titles of all pages are listed in a
+// sidebar and the current page is shown beside it. The action area along the
+// bottom of the Assistant presents the Back, Forward, Apply, Cancel and Close
+// Buttons appropriate for the AssistantPageType of the current page.
+//
+// Each page can be marked as complete, which is how the Assistant knows when
+// the user may advance past it. By default, the forward button moves to the
+// next visible page; use SetForwardPageFunc to branch to other pages based on
+// the user's input.
+type Assistant interface {
+	Window
+	Buildable
+
+	Init() (already bool)
+	Build(builder Builder, element *CBuilderElement) error
+	GetCurrentPage() (value int)
+	SetCurrentPage(pageNum int)
+	GetNPages() (value int)
+	GetNthPage(pageNum int) (value Widget)
+	PageNum(page Widget) (value int)
+	PrependPage(page Widget) (value int)
+	AppendPage(page Widget) (value int)
+	InsertPage(page Widget, position int) (value int)
+	RemovePage(pageNum int)
+	NextPage()
+	PreviousPage()
+	SetForwardPageFunc(fn AssistantPageFunc)
+	SetPageType(page Widget, pageType enums.AssistantPageType)
+	GetPageType(page Widget) (value enums.AssistantPageType)
+	SetPageTitle(page Widget, title string)
+	GetPageTitle(page Widget) (value string)
+	SetPageComplete(page Widget, complete bool)
+	GetPageComplete(page Widget) (value bool)
+	AddActionWidget(child Widget)
+	RemoveActionWidget(child Widget)
+	UpdateButtonsState()
+	Commit()
+	GetActionArea() (value HButtonBox)
+	GetBackButton() (value Button)
+	GetForwardButton() (value Button)
+	GetApplyButton() (value Button)
+	GetCancelButton() (value Button)
+	GetCloseButton() (value Button)
+	Show()
+}
+
+var _ Assistant = (*CAssistant)(nil)
+
+// The CAssistant structure implements the Assistant interface and is exported
+// to facilitate type embedding with custom implementations. No member variables
+// are exported as the interface methods are the only intended means of
+// interacting with Assistant objects.
+type CAssistant struct {
+	CWindow
+
+	pages     []*cAssistantPage
+	current   int
+	visited   []Widget
+	forward   AssistantPageFunc
+	committed bool
+
+	sidebar VBox
+	content VBox
+	action  HButtonBox
+	back    Button
+	next    Button
+	apply   Button
+	cancel  Button
+	close   Button
+}
+
+// cAssistantPage tracks the title, type and completion state of an Assistant
+// page, along with the Label listing the page in the sidebar.
+type cAssistantPage struct {
+	child    Widget
+	title    string
+	pageType enums.AssistantPageType
+	complete bool
+	label    Label
+}
+
+// MakeAssistant is used by the Buildable system to construct a new Assistant.
+func MakeAssistant() Assistant {
+	return NewAssistant()
+}
+
+// NewAssistant is the constructor for new Assistant instances.
+func NewAssistant() Assistant {
+	a := new(CAssistant)
+	a.Init()
+	return a
+}
+
+// Init initializes an Assistant object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the Assistant instance. Init is used in the
+// NewAssistant constructor and only necessary when implementing a derivative
+// Assistant type.
+func (a *CAssistant) Init() (already bool) {
+	if a.InitTypeItem(TypeAssistant, a) {
+		return true
+	}
+	a.CWindow.Init()
+	a.pages = make([]*cAssistantPage, 0)
+	a.current = -1
+	a.visited = make([]Widget, 0)
+	a.forward = nil
+	a.committed = false
+
+	vbox := a.GetVBox()
+	vbox.SetSpacing(1)
+
+	hbox := NewHBox(false, 1)
+	hbox.Show()
+	vbox.PackStart(hbox, true, true, 0)
+
+	a.sidebar = NewVBox(false, 0)
+	a.sidebar.Show()
+	hbox.PackStart(a.sidebar, false, false, 0)
+
+	a.content = NewVBox(false, 0)
+	a.content.Show()
+	hbox.PackStart(a.content, true, true, 0)
+
+	a.action = NewHButtonBox(false, 1)
+	a.action.Show()
+	vbox.PackEnd(a.action, false, true, 0)
+
+	a.close = a.makeButton(StockClose, func() {
+		a.Emit(SignalClose, a)
+	})
+	a.apply = a.makeButton(StockApply, func() {
+		a.Emit(SignalApply, a)
+		if !a.computeNextStep() {
+			a.Emit(SignalClose, a)
+		}
+	})
+	a.next = a.makeButton(StockGoForward, func() {
+		a.computeNextStep()
+	})
+	a.back = a.makeButton(StockGoBack, func() {
+		a.PreviousPage()
+	})
+	a.cancel = a.makeButton(StockCancel, func() {
+		a.Emit(SignalCancel, a)
+	})
+	a.action.PackEnd(a.cancel, false, false, 0)
+	for _, button := range []Button{a.back, a.next, a.apply, a.close} {
+		a.action.PackStart(button, false, false, 0)
+	}
+	a.UpdateButtonsState()
+
+	a.Connect(SignalCdkEvent, AssistantEventHandle, a.event)
+	return false
+}
+
+// Build provides customizations to the Buildable system for Assistant Widgets.
+// Each child is appended as a page and the "page-type", "title" and "complete"
+// packing properties of the child are applied to the new page.
+func (a *CAssistant) Build(builder Builder, element *CBuilderElement) error {
+	a.Freeze()
+	defer a.Thaw()
+	if err := a.CObject.Build(builder, element); err != nil {
+		return err
+	}
+	for _, child := range element.Children {
+		if newChild := builder.Build(child); newChild != nil {
+			child.Instance = newChild
+			page, ok := newChild.(Widget)
+			if !ok {
+				a.LogError("new child object is not a Widget type: %v (%T)", newChild, newChild)
+				continue
+			}
+			page.Show()
+			a.AppendPage(page)
+			for k, v := range child.Packing {
+				switch strings.ReplaceAll(k, "_", "-") {
+				case "page-type":
+					if pageType, err := enums.ASSISTANT_PAGE_CONTENT.FromString(v); err != nil {
+						a.LogErr(err)
+					} else {
+						a.SetPageType(page, pageType.(enums.AssistantPageType))
+					}
+				case "title":
+					a.SetPageTitle(page, v)
+				case "complete":
+					a.SetPageComplete(page, cstrings.IsTrue(v))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// GetCurrentPage returns the page number of the current page.
+//
+// Returns:
+//
+//	the index (starting from 0) of the current page in the Assistant, or -1
+//	if the Assistant has no pages or no page has been set yet.
+//
+// Locking: read
+func (a *CAssistant) GetCurrentPage() (value int) {
+	a.RLock()
+	defer a.RUnlock()
+	return a.current
+}
+
+// SetCurrentPage switches the page to pageNum. Note that this will only be
+// necessary in custom buttons, as the Assistant flow can be set with
+// SetForwardPageFunc. The page being left is remembered so that the back
+// button returns to it.
+//
+// Parameters:
+//
+//	pageNum	index of the page to switch to, starting from 0. If negative, the
+//	        last page will be used. If greater than the number of pages in
+//	        the assistant, nothing will be done.
+//
+// Emits: SignalPrepare, Argv=[Assistant instance, page Widget]
+func (a *CAssistant) SetCurrentPage(pageNum int) {
+	count := a.GetNPages()
+	if pageNum < 0 {
+		pageNum = count - 1
+	}
+	if pageNum < 0 || pageNum >= count {
+		return
+	}
+	current := a.GetCurrentPage()
+	if pageNum == current {
+		return
+	}
+	if previous := a.GetNthPage(current); previous != nil {
+		a.Lock()
+		a.visited = append(a.visited, previous)
+		a.Unlock()
+	}
+	a.setCurrentPage(pageNum)
+}
+
+// GetNPages returns the number of pages in the Assistant.
+//
+// Locking: read
+func (a *CAssistant) GetNPages() (value int) {
+	a.RLock()
+	defer a.RUnlock()
+	return len(a.pages)
+}
+
+// GetNthPage returns the child Widget contained in page number pageNum.
+//
+// Parameters:
+//
+//	pageNum	the index of a page in the assistant, or -1 to get the last page.
+//
+// Returns:
+//
+//	the child widget, or nil if pageNum is out of bounds.
+//
+// Locking: read
+func (a *CAssistant) GetNthPage(pageNum int) (value Widget) {
+	a.RLock()
+	defer a.RUnlock()
+	if pageNum < 0 {
+		pageNum = len(a.pages) - 1
+	}
+	if pageNum >= 0 && pageNum < len(a.pages) {
+		value = a.pages[pageNum].child
+	}
+	return
+}
+
+// PageNum finds the index of the given page Widget.
+//
+// Returns:
+//
+//	the index of the page, or -1 if page is not in the assistant.
+//
+// Locking: read
+func (a *CAssistant) PageNum(page Widget) (value int) {
+	if page == nil {
+		return -1
+	}
+	a.RLock()
+	defer a.RUnlock()
+	for idx, info := range a.pages {
+		if info.child.ObjectID() == page.ObjectID() {
+			return idx
+		}
+	}
+	return -1
+}
+
+// PrependPage prepends a page to the Assistant.
+//
+// Returns:
+//
+//	the index (starting at 0) of the inserted page, or -1 if the page is
+//	already within the assistant
+func (a *CAssistant) PrependPage(page Widget) (value int) {
+	return a.InsertPage(page, 0)
+}
+
+// AppendPage appends a page to the Assistant.
+//
+// Returns:
+//
+//	the index (starting at 0) of the inserted page, or -1 if the page is
+//	already within the assistant
+func (a *CAssistant) AppendPage(page Widget) (value int) {
+	return a.InsertPage(page, -1)
+}
+
+// InsertPage inserts a page in the Assistant at the given position. New pages
+// are of the ASSISTANT_PAGE_CONTENT type, are not complete and have no title.
+//
+// Parameters:
+//
+//	page	a Widget
+//	position	the index (starting at 0) at which to insert the page, or -1 to
+//	            append the page to the assistant
+//
+// Returns:
+//
+//	the index (starting from 0) of the inserted page, or -1 if the page is
+//	already within the assistant
+func (a *CAssistant) InsertPage(page Widget, position int) (value int) {
+	if page == nil || a.PageNum(page) > -1 {
+		return -1
+	}
+	label := NewLabel("")
+	label.SetSingleLineMode(true)
+	label.SetLineWrap(false)
+	label.SetLineWrapMode(cenums.WRAP_NONE)
+	label.Show()
+	info := &cAssistantPage{
+		child:    page,
+		pageType: enums.ASSISTANT_PAGE_CONTENT,
+		label:    label,
+	}
+	a.Lock()
+	if position < 0 || position > len(a.pages) {
+		position = len(a.pages)
+	}
+	a.pages = append(a.pages, nil)
+	copy(a.pages[position+1:], a.pages[position:])
+	a.pages[position] = info
+	if a.current >= position {
+		a.current += 1
+	}
+	a.Unlock()
+	a.sidebar.PackStart(label, false, false, 0)
+	a.sidebar.ReorderChild(label, position)
+	a.updateSidebar()
+	a.UpdateButtonsState()
+	a.Resize()
+	return position
+}
+
+// RemovePage removes the page with the given page number from the Assistant.
+// If the current page is removed, the next visible page (or the last one
+// visited when there is none) becomes the current page.
+//
+// Parameters:
+//
+//	pageNum	the index of a page in the assistant, or -1 to remove the last
+//	        page
+func (a *CAssistant) RemovePage(pageNum int) {
+	count := a.GetNPages()
+	if pageNum < 0 {
+		pageNum = count - 1
+	}
+	if pageNum < 0 || pageNum >= count {
+		return
+	}
+	current := a.GetCurrentPage()
+	if pageNum == current {
+		if !a.computeNextStep() {
+			a.PreviousPage()
+		}
+		current = a.GetCurrentPage()
+	}
+	a.Lock()
+	info := a.pages[pageNum]
+	a.pages = append(a.pages[:pageNum], a.pages[pageNum+1:]...)
+	visited := make([]Widget, 0, len(a.visited))
+	for _, page := range a.visited {
+		if page.ObjectID() != info.child.ObjectID() {
+			visited = append(visited, page)
+		}
+	}
+	a.visited = visited
+	switch {
+	case current == pageNum:
+		a.current = -1
+	case current > pageNum:
+		a.current = current - 1
+	}
+	a.Unlock()
+	if current == pageNum {
+		a.content.Remove(info.child)
+	}
+	a.sidebar.Remove(info.label)
+	a.updateSidebar()
+	a.UpdateButtonsState()
+	a.Resize()
+}
+
+// NextPage navigates to the next page, as determined by the forward page
+// function, as if the forward button was pressed. It is a programming error
+// to call this method when there is no next page.
+func (a *CAssistant) NextPage() {
+	if !a.computeNextStep() {
+		a.LogError("page flow is broken, there is no next page")
+	}
+}
+
+// PreviousPage navigates to the previously visited page, as if the back button
+// was pressed. Pages which are no longer visible are skipped. Nothing happens
+// when there are no previously visited pages.
+func (a *CAssistant) PreviousPage() {
+	for {
+		a.Lock()
+		last := len(a.visited) - 1
+		if last < 0 {
+			a.Unlock()
+			return
+		}
+		page := a.visited[last]
+		a.visited = a.visited[:last]
+		a.Unlock()
+		if pageNum := a.PageNum(page); pageNum > -1 && page.IsVisible() {
+			a.setCurrentPage(pageNum)
+			return
+		}
+	}
+}
+
+// SetForwardPageFunc sets the page forwarding function to be fn. This function
+// will be used to determine what will be the next page when the user presses
+// the forward button. Setting fn to nil will make the assistant use the default
+// forward function, which just goes to the next visible page.
+//
+// Parameters:
+//
+//	fn	the AssistantPageFunc, or nil to use the default one
+func (a *CAssistant) SetForwardPageFunc(fn AssistantPageFunc) {
+	a.Lock()
+	a.forward = fn
+	a.Unlock()
+	a.UpdateButtonsState()
+}
+
+// SetPageType sets the page type for page. The page type determines the page
+// behavior in the Assistant.
+//
+// Parameters:
+//
+//	page	a page of the assistant
+//	pageType	the new type for page
+func (a *CAssistant) SetPageType(page Widget, pageType enums.AssistantPageType) {
+	if info := a.getPage(page); info != nil {
+		a.Lock()
+		info.pageType = pageType
+		a.Unlock()
+		a.UpdateButtonsState()
+	}
+}
+
+// GetPageType returns the page type of page.
+//
+// Parameters:
+//
+//	page	a page of the assistant
+//
+// Locking: read
+func (a *CAssistant) GetPageType(page Widget) (value enums.AssistantPageType) {
+	if info := a.getPage(page); info != nil {
+		a.RLock()
+		defer a.RUnlock()
+		value = info.pageType
+	}
+	return
+}
+
+// SetPageTitle sets a title for page. The title is displayed in the sidebar of
+// the Assistant, highlighted when the page is the current page.
+//
+// Parameters:
+//
+//	page	a page of the assistant
+//	title	the new title for page
+func (a *CAssistant) SetPageTitle(page Widget, title string) {
+	if info := a.getPage(page); info != nil {
+		a.Lock()
+		info.title = title
+		a.Unlock()
+		a.updateSidebar()
+		a.Resize()
+	}
+}
+
+// GetPageTitle returns the title for page.
+//
+// Parameters:
+//
+//	page	a page of the assistant
+//
+// Locking: read
+func (a *CAssistant) GetPageTitle(page Widget) (value string) {
+	if info := a.getPage(page); info != nil {
+		a.RLock()
+		defer a.RUnlock()
+		value = info.title
+	}
+	return
+}
+
+// SetPageComplete sets whether page contents are complete. This will make the
+// Assistant update the buttons state to be able to continue the task.
+//
+// Parameters:
+//
+//	page	a page of the assistant
+//	complete	the completeness status of the page
+func (a *CAssistant) SetPageComplete(page Widget, complete bool) {
+	if info := a.getPage(page); info != nil {
+		a.Lock()
+		info.complete = complete
+		a.Unlock()
+		a.UpdateButtonsState()
+	}
+}
+
+// GetPageComplete returns whether page is complete.
+//
+// Parameters:
+//
+//	page	a page of the assistant
+//
+// Locking: read
+func (a *CAssistant) GetPageComplete(page Widget) (value bool) {
+	if info := a.getPage(page); info != nil {
+		a.RLock()
+		defer a.RUnlock()
+		value = info.complete
+	}
+	return
+}
+
+// AddActionWidget adds a Widget to the action area of the Assistant, before
+// the Buttons managed by the Assistant itself.
+//
+// Parameters:
+//
+//	child	a Widget
+func (a *CAssistant) AddActionWidget(child Widget) {
+	a.action.PackStart(child, false, false, 0)
+}
+
+// RemoveActionWidget removes a Widget from the action area of the Assistant.
+//
+// Parameters:
+//
+//	child	a Widget
+func (a *CAssistant) RemoveActionWidget(child Widget) {
+	a.action.Remove(child)
+}
+
+// UpdateButtonsState forces the Assistant to recompute the buttons state.
+// The Assistant does this automatically when the current page, or the type or
+// completion of any page changes. This method is useful when the visibility
+// of the pages changes in ways the forward page function depends upon.
+func (a *CAssistant) UpdateButtonsState() {
+	current := a.GetCurrentPage()
+	var pageType enums.AssistantPageType
+	var complete bool
+	if info := a.getPageAt(current); info != nil {
+		a.RLock()
+		pageType, complete = info.pageType, info.complete
+		a.RUnlock()
+	}
+	a.RLock()
+	hasVisited := len(a.visited) > 0
+	committed := a.committed
+	a.RUnlock()
+	show := map[Button]bool{}
+	sensitive := map[Button]bool{}
+	switch pageType {
+	case enums.ASSISTANT_PAGE_INTRO:
+		show[a.cancel], sensitive[a.cancel] = true, true
+		show[a.next], sensitive[a.next] = true, complete
+	case enums.ASSISTANT_PAGE_CONFIRM:
+		show[a.cancel], sensitive[a.cancel] = true, true
+		show[a.back], sensitive[a.back] = true, true
+		show[a.apply], sensitive[a.apply] = true, complete
+	case enums.ASSISTANT_PAGE_SUMMARY:
+		show[a.close], sensitive[a.close] = true, true
+	case enums.ASSISTANT_PAGE_PROGRESS:
+		show[a.cancel], sensitive[a.cancel] = true, true
+		show[a.back], sensitive[a.back] = true, complete
+		show[a.next], sensitive[a.next] = true, complete
+	default:
+		show[a.cancel], sensitive[a.cancel] = true, true
+		show[a.back], sensitive[a.back] = true, true
+		show[a.next], sensitive[a.next] = true, complete
+	}
+	if !hasVisited {
+		show[a.back] = false
+	}
+	if committed {
+		show[a.cancel] = false
+	}
+	if show[a.next] && current > -1 && a.getNextPage(current) < 0 {
+		sensitive[a.next] = false
+	}
+	for _, button := range []Button{a.back, a.next, a.apply, a.cancel, a.close} {
+		button.SetSensitive(sensitive[button])
+		if show[button] {
+			button.Show()
+		} else {
+			button.Hide()
+		}
+	}
+}
+
+// Commit erases the visited page history so the back button is not shown on
+// the current page, and removes the cancel button from subsequent pages. Use
+// this when the information provided up to the current page is hereafter
+// deemed permanent and cannot be modified or undone. For example, showing a
+// progress page to track a long-running, unreversible operation after the
+// user has clicked apply on a confirmation page.
+func (a *CAssistant) Commit() {
+	a.Lock()
+	a.visited = make([]Widget, 0)
+	a.committed = true
+	a.Unlock()
+	a.UpdateButtonsState()
+}
+
+// GetActionArea returns the action area HButtonBox of the Assistant.
+func (a *CAssistant) GetActionArea() (value HButtonBox) {
+	a.RLock()
+	defer a.RUnlock()
+	return a.action
+}
+
+// GetBackButton returns the Button used to return to the previous page.
+func (a *CAssistant) GetBackButton() (value Button) {
+	a.RLock()
+	defer a.RUnlock()
+	return a.back
+}
+
+// GetForwardButton returns the Button used to advance to the next page.
+func (a *CAssistant) GetForwardButton() (value Button) {
+	a.RLock()
+	defer a.RUnlock()
+	return a.next
+}
+
+// GetApplyButton returns the Button shown on ASSISTANT_PAGE_CONFIRM pages.
+func (a *CAssistant) GetApplyButton() (value Button) {
+	a.RLock()
+	defer a.RUnlock()
+	return a.apply
+}
+
+// GetCancelButton returns the Button used to cancel the Assistant.
+func (a *CAssistant) GetCancelButton() (value Button) {
+	a.RLock()
+	defer a.RUnlock()
+	return a.cancel
+}
+
+// GetCloseButton returns the Button shown on ASSISTANT_PAGE_SUMMARY pages.
+func (a *CAssistant) GetCloseButton() (value Button) {
+	a.RLock()
+	defer a.RUnlock()
+	return a.close
+}
+
+// Show sets the Assistant as VISIBLE and if no page is current yet, the first
+// visible page becomes the current page.
+func (a *CAssistant) Show() {
+	a.CWindow.Show()
+	if a.GetCurrentPage() < 0 {
+		for idx := 0; idx < a.GetNPages(); idx++ {
+			if page := a.GetNthPage(idx); page != nil && page.IsVisible() {
+				a.setCurrentPage(idx)
+				break
+			}
+		}
+	}
+}
+
+func (a *CAssistant) makeButton(stockId StockID, fn func()) (button Button) {
+	button = NewButtonFromStock(stockId)
+	button.SetUseUnderline(true)
+	button.SetSizeRequest(-1, 1)
+	button.Connect(SignalActivate, AssistantActivateHandle, func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+		fn()
+		return cenums.EVENT_STOP
+	})
+	return
+}
+
+func (a *CAssistant) getPage(page Widget) *cAssistantPage {
+	return a.getPageAt(a.PageNum(page))
+}
+
+func (a *CAssistant) getPageAt(pageNum int) *cAssistantPage {
+	a.RLock()
+	defer a.RUnlock()
+	if pageNum >= 0 && pageNum < len(a.pages) {
+		return a.pages[pageNum]
+	}
+	return nil
+}
+
+// getNextPage returns the page number the forward button moves to from the
+// given page, or -1 if there is none.
+func (a *CAssistant) getNextPage(pageNum int) (next int) {
+	a.RLock()
+	fn := a.forward
+	a.RUnlock()
+	count := a.GetNPages()
+	if fn != nil {
+		if next = fn(pageNum); next < 0 || next >= count {
+			next = -1
+		}
+		return
+	}
+	for next = pageNum + 1; next < count; next++ {
+		if page := a.GetNthPage(next); page != nil && page.IsVisible() {
+			return
+		}
+	}
+	return -1
+}
+
+// computeNextStep moves to the next page, remembering the current one as
+// visited, and returns true if there was a next page to move to.
+func (a *CAssistant) computeNextStep() bool {
+	current := a.GetCurrentPage()
+	if current < 0 {
+		return false
+	}
+	next := a.getNextPage(current)
+	if next < 0 || next == current {
+		return false
+	}
+	a.SetCurrentPage(next)
+	return true
+}
+
+// setCurrentPage swaps the content area over to the given page, updating the
+// sidebar and buttons before emitting the prepare signal for the new page.
+func (a *CAssistant) setCurrentPage(pageNum int) {
+	info := a.getPageAt(pageNum)
+	if info == nil {
+		return
+	}
+	if previous := a.getPageAt(a.GetCurrentPage()); previous != nil {
+		a.content.Remove(previous.child)
+	}
+	a.Lock()
+	a.current = pageNum
+	a.Unlock()
+	a.content.PackStart(info.child, true, true, 0)
+	a.updateSidebar()
+	a.UpdateButtonsState()
+	a.Emit(SignalPrepare, a, info.child)
+	a.Resize()
+}
+
+// updateSidebar refreshes the sidebar Labels, marking the current page title.
+func (a *CAssistant) updateSidebar() {
+	a.RLock()
+	current := a.current
+	pages := append([]*cAssistantPage{}, a.pages...)
+	a.RUnlock()
+	for idx, info := range pages {
+		if idx == current {
+			info.label.SetText("> " + info.title)
+		} else {
+			info.label.SetText("  " + info.title)
+		}
+	}
+}
+
+func (a *CAssistant) event(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if evt, ok := argv[1].(cdk.Event); ok {
+		switch e := evt.(type) {
+		case *cdk.EventKey:
+			switch e.Key() {
+			case cdk.KeyEscape:
+				if a.cancel.IsVisible() && a.cancel.IsSensitive() {
+					a.Emit(SignalCancel, a)
+					return cenums.EVENT_STOP
+				}
+			}
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+// The ::apply signal is emitted when the apply button is clicked. The default
+// behavior of the Assistant is to switch to the page after the current page,
+// unless the current page is the last one, in which case the close signal is
+// emitted instead.
+// Listener function arguments:
+//
+//	assistant Assistant	the Assistant instance
+const SignalApply cdk.Signal = "apply"
+
+// The ::prepare signal is emitted when a new page is set as the assistant's
+// current page, before making the new page visible. A handler for this
+// signal can do any preparations which are necessary before showing page.
+// Listener function arguments:
+//
+//	assistant Assistant	the Assistant instance
+//	page Widget	the current page
+const SignalPrepare cdk.Signal = "prepare"
+
+const AssistantEventHandle = "assistant-event-handler"
+
+const AssistantActivateHandle = "assistant-activate-handler"
@@ -0,0 +1,239 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"testing"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+func TestAssistant(t *testing.T) {
+	Convey("Testing Assistants", t, func() {
+		makePages := func(a Assistant, titles ...string) (pages []Widget) {
+			for _, title := range titles {
+				page := NewLabel(title)
+				page.Show()
+				a.AppendPage(page)
+				a.SetPageTitle(page, title)
+				pages = append(pages, page)
+			}
+			return
+		}
+
+		Convey("Pages", func() {
+			a := NewAssistant()
+			So(a, ShouldNotBeNil)
+			So(a.GetCurrentPage(), ShouldEqual, -1)
+			pages := makePages(a, "Intro", "Options", "Confirm")
+			So(a.GetNPages(), ShouldEqual, 3)
+			So(a.GetNthPage(-1), ShouldEqual, pages[2])
+			So(a.GetNthPage(3), ShouldBeNil)
+			So(a.AppendPage(pages[0]), ShouldEqual, -1)
+			So(a.GetPageTitle(pages[1]), ShouldEqual, "Options")
+			So(a.GetPageType(pages[1]), ShouldEqual, enums.ASSISTANT_PAGE_CONTENT)
+			So(a.GetPageComplete(pages[1]), ShouldEqual, false)
+			first := NewLabel("First")
+			first.Show()
+			So(a.PrependPage(first), ShouldEqual, 0)
+			So(a.PageNum(pages[0]), ShouldEqual, 1)
+
+			prepared := make([]Widget, 0)
+			a.Connect(SignalPrepare, "test-assistant-prepare", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				if page, ok := argv[1].(Widget); ok {
+					prepared = append(prepared, page)
+				}
+				return cenums.EVENT_PASS
+			})
+			a.Show()
+			So(a.GetCurrentPage(), ShouldEqual, 0)
+			So(prepared, ShouldHaveLength, 1)
+			So(prepared[0], ShouldEqual, first)
+			So(first.GetParent(), ShouldNotBeNil)
+
+			a.RemovePage(0)
+			So(a.GetNPages(), ShouldEqual, 3)
+			So(a.GetCurrentPage(), ShouldEqual, 0)
+			So(a.GetNthPage(0), ShouldEqual, pages[0])
+			So(first.GetParent(), ShouldBeNil)
+			So(pages[0].GetParent(), ShouldNotBeNil)
+		})
+
+		Convey("Buttons", func() {
+			a := NewAssistant()
+			pages := makePages(a, "Intro", "Options", "Confirm", "Summary")
+			a.SetPageType(pages[0], enums.ASSISTANT_PAGE_INTRO)
+			a.SetPageType(pages[2], enums.ASSISTANT_PAGE_CONFIRM)
+			a.SetPageType(pages[3], enums.ASSISTANT_PAGE_SUMMARY)
+			a.Show()
+			back, next, apply := a.GetBackButton(), a.GetForwardButton(), a.GetApplyButton()
+			cancel, closeButton := a.GetCancelButton(), a.GetCloseButton()
+
+			So(back.IsVisible(), ShouldEqual, false)
+			So(next.IsVisible(), ShouldEqual, true)
+			So(next.IsSensitive(), ShouldEqual, false)
+			So(apply.IsVisible(), ShouldEqual, false)
+			So(cancel.IsVisible(), ShouldEqual, true)
+			So(closeButton.IsVisible(), ShouldEqual, false)
+			So(next.Activate(), ShouldEqual, false)
+			So(a.GetCurrentPage(), ShouldEqual, 0)
+
+			a.SetPageComplete(pages[0], true)
+			So(next.IsSensitive(), ShouldEqual, true)
+			So(next.Activate(), ShouldEqual, true)
+			So(a.GetCurrentPage(), ShouldEqual, 1)
+			So(back.IsVisible(), ShouldEqual, true)
+			So(back.IsSensitive(), ShouldEqual, true)
+			So(next.IsSensitive(), ShouldEqual, false)
+
+			So(back.Activate(), ShouldEqual, true)
+			So(a.GetCurrentPage(), ShouldEqual, 0)
+			So(back.IsVisible(), ShouldEqual, false)
+			a.NextPage()
+			a.SetPageComplete(pages[1], true)
+			a.NextPage()
+			So(a.GetCurrentPage(), ShouldEqual, 2)
+			So(next.IsVisible(), ShouldEqual, false)
+			So(apply.IsVisible(), ShouldEqual, true)
+			So(apply.IsSensitive(), ShouldEqual, false)
+			a.SetPageComplete(pages[2], true)
+
+			applied, closed, cancelled := 0, 0, 0
+			a.Connect(SignalApply, "test-assistant-apply", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				applied += 1
+				return cenums.EVENT_PASS
+			})
+			a.Connect(SignalClose, "test-assistant-close", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				closed += 1
+				return cenums.EVENT_PASS
+			})
+			a.Connect(SignalCancel, "test-assistant-cancel", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				cancelled += 1
+				return cenums.EVENT_PASS
+			})
+			So(apply.Activate(), ShouldEqual, true)
+			So(applied, ShouldEqual, 1)
+			So(closed, ShouldEqual, 0)
+			So(a.GetCurrentPage(), ShouldEqual, 3)
+			So(closeButton.IsVisible(), ShouldEqual, true)
+			So(cancel.IsVisible(), ShouldEqual, false)
+			So(next.IsVisible(), ShouldEqual, false)
+			So(back.IsVisible(), ShouldEqual, false)
+			So(closeButton.Activate(), ShouldEqual, true)
+			So(closed, ShouldEqual, 1)
+
+			a.SetPageType(pages[3], enums.ASSISTANT_PAGE_CONFIRM)
+			a.SetPageComplete(pages[3], true)
+			So(apply.Activate(), ShouldEqual, true)
+			So(applied, ShouldEqual, 2)
+			So(closed, ShouldEqual, 2)
+
+			So(cancel.Activate(), ShouldEqual, true)
+			So(cancelled, ShouldEqual, 1)
+			a.ProcessEvent(cdk.NewEventKey(cdk.KeyEscape, 0, cdk.ModNone))
+			So(cancelled, ShouldEqual, 2)
+
+			a.Commit()
+			So(back.IsVisible(), ShouldEqual, false)
+			So(cancel.IsVisible(), ShouldEqual, false)
+		})
+
+		Convey("Flow", func() {
+			a := NewAssistant()
+			pages := makePages(a, "Choose", "Express", "Custom", "Done")
+			for _, page := range pages {
+				a.SetPageComplete(page, true)
+			}
+			custom := false
+			a.SetForwardPageFunc(func(currentPage int) (nextPage int) {
+				switch currentPage {
+				case 0:
+					if custom {
+						return 2
+					}
+					return 1
+				case 1, 2:
+					return 3
+				}
+				return -1
+			})
+			a.Show()
+			a.NextPage()
+			So(a.GetCurrentPage(), ShouldEqual, 1)
+			a.NextPage()
+			So(a.GetCurrentPage(), ShouldEqual, 3)
+			So(a.GetForwardButton().IsSensitive(), ShouldEqual, false)
+			a.PreviousPage()
+			So(a.GetCurrentPage(), ShouldEqual, 1)
+			a.PreviousPage()
+			So(a.GetCurrentPage(), ShouldEqual, 0)
+			custom = true
+			a.NextPage()
+			So(a.GetCurrentPage(), ShouldEqual, 2)
+
+			a.SetForwardPageFunc(nil)
+			pages[3].Hide()
+			a.UpdateButtonsState()
+			So(a.GetForwardButton().IsSensitive(), ShouldEqual, false)
+			pages[3].Show()
+			a.UpdateButtonsState()
+			So(a.GetForwardButton().IsSensitive(), ShouldEqual, true)
+			a.SetCurrentPage(0)
+			pages[1].Hide()
+			a.NextPage()
+			So(a.GetCurrentPage(), ShouldEqual, 2)
+		})
+
+		Convey("Builder", func() {
+			builder := NewBuilder()
+			_, err := builder.LoadFromString(`<interface>
+  <object class="GtkAssistant" id="test-assistant">
+    <child>
+      <object class="GtkLabel" id="test-assistant-intro">
+        <property name="label">Welcome</property>
+      </object>
+      <packing>
+        <property name="page_type">GTK_ASSISTANT_PAGE_INTRO</property>
+        <property name="title">Welcome</property>
+        <property name="complete">True</property>
+      </packing>
+    </child>
+    <child>
+      <object class="GtkLabel" id="test-assistant-summary">
+        <property name="label">Finished</property>
+      </object>
+      <packing>
+        <property name="page_type">GTK_ASSISTANT_PAGE_SUMMARY</property>
+        <property name="title">Finished</property>
+      </packing>
+    </child>
+  </object>
+</interface>`)
+			So(err, ShouldBeNil)
+			a, ok := builder.GetWidget("test-assistant").(Assistant)
+			So(ok, ShouldEqual, true)
+			So(a.GetNPages(), ShouldEqual, 2)
+			intro := a.GetNthPage(0)
+			So(a.GetPageType(intro), ShouldEqual, enums.ASSISTANT_PAGE_INTRO)
+			So(a.GetPageTitle(intro), ShouldEqual, "Welcome")
+			So(a.GetPageComplete(intro), ShouldEqual, true)
+			So(a.GetPageType(a.GetNthPage(1)), ShouldEqual, enums.ASSISTANT_PAGE_SUMMARY)
+		})
+	})
+}
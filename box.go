@@ -554,7 +554,7 @@ func (b *CBox) GetSizeRequest() (width, height int) {
 	rw, rh := b.CContainer.GetSizeRequest()
 	mrw, mrh := -1, -1
 	for _, child := range children {
-		crw, crh := child.widget.GetSizeRequest()
+		crw, crh := GetGroupedSizeRequest(child.widget)
 		if crw > mrw {
 			mrw = crw
 		}
@@ -621,7 +621,7 @@ func (b *CBox) resizeHomogeneous(isVertical bool, gaps []int, increment, numChil
 	// first: build up tracking dataset
 
 	for idx, child := range children {
-		req := ptypes.NewRectangle(child.widget.GetSizeRequest())
+		req := ptypes.NewRectangle(GetGroupedSizeRequest(child.widget))
 		if child.fill {
 			if isVertical {
 				tracking[idx].w = alloc.W
@@ -757,7 +757,7 @@ func (b *CBox) resizeDynamicAlloc(isVertical bool, spacing, numChildren int, ori
 			tracking[idx].rw = -1
 			tracking[idx].rh = -1
 		} else {
-			rw, rh := child.widget.GetSizeRequest()
+			rw, rh := GetGroupedSizeRequest(child.widget)
 			if isVertical {
 				if rh > -1 {
 					totalSpace -= rh
@@ -30,11 +30,10 @@ import (
 
 type BuilderTranslationFn = func(builder Builder, widget Widget, name, value string) error
 
-var ctkBuilderTranslators map[cdk.TypeTag]BuilderTranslationFn
-
-func init() {
-	ctkBuilderTranslators = make(map[cdk.TypeTag]BuilderTranslationFn)
-}
+// ctkBuilderTranslators is initialized with the package variables so that the
+// init functions of any file may register translators, regardless of the order
+// in which the files are initialized.
+var ctkBuilderTranslators = make(map[cdk.TypeTag]BuilderTranslationFn)
 
 func BuilderRegisterConstructor(tag cdk.TypeTag, fn BuilderTranslationFn) {
 	if _, ok := ctkBuilderTranslators[tag]; ok {
@@ -59,6 +58,7 @@ type Builder interface {
 	GetWidget(name string) (w interface{})
 	GetWidgetsBuiltByType(tag cdk.CTypeTag) (widgets []interface{})
 	ParsePacking(packing *CBuilderElement) (expand, fill bool, padding int, packType enums.PackType)
+	ParseString(raw string) (topElement *CBuilderElement, err error)
 	LoadFromString(raw string) (topElement *CBuilderElement, err error)
 	Build(element *CBuilderElement) (newObject interface{})
 }
@@ -153,8 +153,10 @@ func (b *CBuilder) ParsePacking(packing *CBuilderElement) (expand, fill bool, pa
 	return
 }
 
-func (b *CBuilder) LoadFromString(raw string) (topElement *CBuilderElement, err error) {
-	b.LogDebug("known buildable types: %v", b.buildable)
+// ParseString decodes the given XML into a tree of CBuilderElement instances
+// without building any of the objects described. This is used by
+// LoadFromString and by other XML consumers, like the UIManager.
+func (b *CBuilder) ParseString(raw string) (topElement *CBuilderElement, err error) {
 	r := strings.NewReader(raw)
 	parser := xml.NewDecoder(r)
 	var n BuilderNode
@@ -162,6 +164,14 @@ func (b *CBuilder) LoadFromString(raw string) (topElement *CBuilderElement, err
 		return nil, err
 	}
 	topElement = b.walkElements(n)
+	return
+}
+
+func (b *CBuilder) LoadFromString(raw string) (topElement *CBuilderElement, err error) {
+	b.LogDebug("known buildable types: %v", b.buildable)
+	if topElement, err = b.ParseString(raw); err != nil {
+		return nil, err
+	}
 	b.LogDebug("see report:\n[report]\n%v[/report]", b.report(0, topElement))
 	_ = b.Build(topElement)
 	return topElement, nil
@@ -183,10 +193,10 @@ func (b *CBuilder) Build(element *CBuilderElement) (newObject interface{}) {
 				ct, _ := cdk.TypesManager.GetType(tt)
 				newObject = ct.New()
 				b.built = append(b.built, newObject)
-				var newBuildable Buildable
+				var newBuildable Object
 				var ok bool
-				if newBuildable, ok = newObject.(Buildable); !ok {
-					b.LogError("new object is not a Buildable type: %v (%T)", newObject, newObject)
+				if newBuildable, ok = newObject.(Object); !ok {
+					b.LogError("new object is not an Object type: %v (%T)", newObject, newObject)
 					newObject = nil
 					break
 				}
@@ -312,7 +322,10 @@ func (b *CBuilder) walkObject(n BuilderNode) (be *CBuilderElement) {
 		case "child":
 			b.walkObjectChild(cn, be)
 		default:
-			b.LogError("ignoring unexpected tag: %v", cn.XMLName.Local)
+			// type-specific tags, ie: <columns> and <data> for GtkListStore
+			if cbe := b.walkElements(cn); cbe != nil {
+				be.Custom = append(be.Custom, cbe)
+			}
 		}
 	}
 	return
@@ -320,7 +333,12 @@ func (b *CBuilder) walkObject(n BuilderNode) (be *CBuilderElement) {
 
 func (b *CBuilder) walkObjectChild(n BuilderNode, parentElement *CBuilderElement) {
 	var object *CBuilderElement
+	var custom []*CBuilderElement
 	packing := make(map[string]string)
+	// internal children, ie: <child type="tab"> for notebook tab labels
+	if v, ok := b.parseTagAttributes(n.Attrs)["type"]; ok {
+		packing["type"] = v
+	}
 	for _, cn := range n.Nodes {
 		switch cn.XMLName.Local {
 		case "object":
@@ -340,6 +358,11 @@ func (b *CBuilder) walkObjectChild(n BuilderNode, parentElement *CBuilderElement
 			}
 		case "placeholder":
 			return
+		case "attributes":
+			// cell renderer attributes, ie: <attribute name="text">0</attribute>
+			if cbe := b.walkElements(cn); cbe != nil {
+				custom = append(custom, cbe)
+			}
 		default:
 			b.LogError("ignoring unexpected tag: %v", cn.XMLName.Local)
 		}
@@ -348,6 +371,7 @@ func (b *CBuilder) walkObjectChild(n BuilderNode, parentElement *CBuilderElement
 		for k, v := range packing {
 			object.Packing[k] = v
 		}
+		object.Custom = append(object.Custom, custom...)
 	} else {
 		b.LogError("object not found in child tag children")
 	}
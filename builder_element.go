@@ -42,6 +42,7 @@ type CBuilderElement struct {
 	Signals    map[string]string
 	Packing    map[string]string
 	Children   []*CBuilderElement
+	Custom     []*CBuilderElement
 }
 
 func newBuilderElement(tagName string, builder Builder) *CBuilderElement {
@@ -60,6 +61,7 @@ func (b *CBuilderElement) init() {
 	b.Signals = make(map[string]string)
 	b.Packing = make(map[string]string)
 	b.Children = make([]*CBuilderElement, 0)
+	b.Custom = make([]*CBuilderElement, 0)
 }
 
 func (b *CBuilderElement) String() string {
@@ -80,7 +82,7 @@ func (b *CBuilderElement) ApplySignals() {
 }
 
 func (b *CBuilderElement) ApplySignal(k, v string) {
-	if buildable, ok := b.Instance.(Buildable); ok {
+	if buildable, ok := b.Instance.(Object); ok {
 		ks := cdk.Signal(k)
 		if fn := b.Builder.LookupNamedSignalHandler(v); fn != nil {
 			buildable.Connect(ks, v, fn)
@@ -163,6 +165,11 @@ func (b *CBuilderElement) ApplyProperty(k, v string) (set bool) {
 				return false
 			}
 		}
+	} else if buildableObject, ok := b.Instance.(Object); ok {
+		if err := buildableObject.SetPropertyFromString(cdk.Property(k), v); err != nil {
+			log.WarnF("%v: %v", err, buildableObject.ObjectName())
+			return false
+		}
 	}
 	return true
 }
\ No newline at end of file
@@ -184,7 +184,14 @@ func NewButton() Button {
 // 	label	the text of the button
 func NewButtonWithLabel(text string) (b Button) {
 	b = NewButton()
-	label := NewLabel(text)
+	buttonAddLabel(b, text)
+	return b
+}
+
+// buttonAddLabel creates a new Label with the given text, configured for use
+// as the child of the given Button, and adds it to the Button.
+func buttonAddLabel(b Button, text string) (label Label) {
+	label = NewLabel(text)
 	label.Show()
 	label.SetTheme(b.GetTheme())
 	label.UnsetFlags(enums.CAN_FOCUS)
@@ -196,7 +203,7 @@ func NewButtonWithLabel(text string) (b Button) {
 	label.SetAlignment(0.5, 0.5)
 	label.SetSingleLineMode(true)
 	b.Add(label)
-	return b
+	return
 }
 
 // NewButtonWithMnemonic creates a NewButtonWithLabel. If the characters in the
@@ -0,0 +1,835 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/go-curses/cdk/lib/ptypes"
+	"github.com/go-curses/cdk/memphis"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeCalendar cdk.CTypeTag = "ctk-calendar"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeCalendar, func() interface{} { return MakeCalendar() })
+}
+
+// Calendar Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Calendar
+//
+// Calendar is a Widget that displays a calendar, one month at a time, as a
+// grid of six weeks with an optional heading showing the month and year, an
+// optional line of day names and an optional column of ISO week numbers.
+//
+// The selected date is changed with the arrow keys, moving by a day or by a
+// week, PgUp and PgDn change the month, Ctrl+PgUp and Ctrl+PgDn change the
+// year and Home and End jump to the first and last days of the month. The
+// mouse can be used to click on a day and on the arrows of the heading, while
+// the mouse wheel changes the month. Pressing Enter or Space, or
+// double-clicking a day, emits the day-selected-double-click signal.
+//
+// Individual days of the month can be marked with MarkDay, and are displayed
+// in bold. Marks are not tied to a month and are typically updated by a
+// month-changed signal handler.
+//
+// The first day of the week defaults to the convention of the territory of
+// the current locale (from the LC_ALL, LC_TIME or LANG environment
+// variables) and can be changed with SetFirstDayOfWeek.
+type Calendar interface {
+	Widget
+	Buildable
+
+	Init() (already bool)
+	SelectMonth(month time.Month, year int) (ok bool)
+	SelectDay(day int)
+	SetDate(date time.Time)
+	GetDate() (year int, month time.Month, day int)
+	MarkDay(day int) (ok bool)
+	UnmarkDay(day int) (ok bool)
+	ClearMarks()
+	GetDayIsMarked(day int) (marked bool)
+	SetDisplayOptions(flags enums.CalendarDisplayOptions)
+	GetDisplayOptions() (flags enums.CalendarDisplayOptions)
+	SetFirstDayOfWeek(weekday time.Weekday)
+	GetFirstDayOfWeek() (weekday time.Weekday)
+	CancelEvent()
+	GetSizeRequest() (width, height int)
+}
+
+var _ Calendar = (*CCalendar)(nil)
+
+// The CCalendar structure implements the Calendar interface and is exported
+// to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with Calendar objects.
+type CCalendar struct {
+	CWidget
+
+	marks     map[int]bool
+	lastClick time.Time
+	lastDay   int
+}
+
+// MakeCalendar is used by the Buildable system to construct a new Calendar.
+func MakeCalendar() Calendar {
+	return NewCalendar()
+}
+
+// NewCalendar is the constructor for new Calendar instances. The current date
+// is selected initially.
+func NewCalendar() Calendar {
+	c := new(CCalendar)
+	c.Init()
+	return c
+}
+
+// Init initializes a Calendar object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the Calendar instance. Init is used in the
+// NewCalendar constructor and only necessary when implementing a derivative
+// Calendar type.
+func (c *CCalendar) Init() (already bool) {
+	if c.InitTypeItem(TypeCalendar, c) {
+		return true
+	}
+	c.CWidget.Init()
+	c.flags = enums.NULL_WIDGET_FLAG
+	c.SetFlags(enums.SENSITIVE | enums.PARENT_SENSITIVE | enums.CAN_FOCUS | enums.APP_PAINTABLE)
+	now := time.Now()
+	_ = c.InstallBuildableProperty(PropertyYear, cdk.IntProperty, true, now.Year())
+	_ = c.InstallBuildableProperty(PropertyMonth, cdk.IntProperty, true, int(now.Month())-1)
+	_ = c.InstallBuildableProperty(PropertyDay, cdk.IntProperty, true, now.Day())
+	_ = c.InstallBuildableProperty(PropertyShowHeading, cdk.BoolProperty, true, true)
+	_ = c.InstallBuildableProperty(PropertyShowDayNames, cdk.BoolProperty, true, true)
+	_ = c.InstallBuildableProperty(PropertyNoMonthChange, cdk.BoolProperty, true, false)
+	_ = c.InstallBuildableProperty(PropertyShowWeekNumbers, cdk.BoolProperty, true, false)
+	_ = c.InstallBuildableProperty(PropertyFirstDayOfWeek, cdk.IntProperty, true, int(calendarLocaleFirstDay(calendarLocale())))
+	c.marks = make(map[int]bool)
+	c.lastClick = time.Time{}
+	c.lastDay = 0
+	c.Connect(SignalCdkEvent, CalendarEventHandle, c.event)
+	c.Connect(SignalResize, CalendarResizeHandle, c.resize)
+	c.Connect(SignalDraw, CalendarDrawHandle, c.draw)
+	return false
+}
+
+// SelectMonth shifts the calendar to a different month, clamping the selected
+// day to the number of days in the new month. Returns FALSE if the month is
+// not within January and December.
+//
+// Emits: SignalMonthChanged, Argv=[Calendar instance]
+// Emits: SignalDaySelected, Argv=[Calendar instance]
+//
+// Parameters:
+//
+//	month	a month number between time.January and time.December
+//	year	the year the month is in
+func (c *CCalendar) SelectMonth(month time.Month, year int) (ok bool) {
+	if month < time.January || month > time.December {
+		c.LogError("invalid month: %v", int(month))
+		return false
+	}
+	_, _, day := c.GetDate()
+	c.setDate(year, month, day)
+	return true
+}
+
+// SelectDay selects a day from the current month. The day is clamped to the
+// number of days in the month and a day of 0 unselects the currently selected
+// day.
+//
+// Emits: SignalDaySelected, Argv=[Calendar instance]
+//
+// Parameters:
+//
+//	day	the day number between 1 and 31, or 0 to unselect the currently
+//	    selected day
+func (c *CCalendar) SelectDay(day int) {
+	year, month, _ := c.GetDate()
+	c.setDate(year, month, day)
+}
+
+// SetDate is a convenience method to select both the month and the day of the
+// given date.
+//
+// Emits: SignalMonthChanged, Argv=[Calendar instance]
+// Emits: SignalDaySelected, Argv=[Calendar instance]
+//
+// Parameters:
+//
+//	date	the date to select
+func (c *CCalendar) SetDate(date time.Time) {
+	c.setDate(date.Year(), date.Month(), date.Day())
+}
+
+// GetDate returns the year, month and day currently selected. The day is 0
+// when no day is selected.
+func (c *CCalendar) GetDate() (year int, month time.Month, day int) {
+	var err error
+	var m int
+	if year, err = c.GetIntProperty(PropertyYear); err != nil {
+		c.LogErr(err)
+	}
+	if m, err = c.GetIntProperty(PropertyMonth); err != nil {
+		c.LogErr(err)
+	}
+	if day, err = c.GetIntProperty(PropertyDay); err != nil {
+		c.LogErr(err)
+	}
+	month = time.Month(m + 1)
+	return
+}
+
+// MarkDay places a visual marker on a particular day of the month. Returns
+// FALSE if the day is not between 1 and 31.
+//
+// Parameters:
+//
+//	day	the day number to mark between 1 and 31
+func (c *CCalendar) MarkDay(day int) (ok bool) {
+	if day < 1 || day > 31 {
+		return false
+	}
+	c.Lock()
+	c.marks[day] = true
+	c.Unlock()
+	c.Invalidate()
+	return true
+}
+
+// UnmarkDay removes the visual marker from a particular day. Returns FALSE if
+// the day is not between 1 and 31.
+//
+// Parameters:
+//
+//	day	the day number to unmark between 1 and 31
+func (c *CCalendar) UnmarkDay(day int) (ok bool) {
+	if day < 1 || day > 31 {
+		return false
+	}
+	c.Lock()
+	delete(c.marks, day)
+	c.Unlock()
+	c.Invalidate()
+	return true
+}
+
+// ClearMarks removes all visual markers.
+func (c *CCalendar) ClearMarks() {
+	c.Lock()
+	c.marks = make(map[int]bool)
+	c.Unlock()
+	c.Invalidate()
+}
+
+// GetDayIsMarked returns if the day of the calendar is already marked.
+//
+// Parameters:
+//
+//	day	the day number between 1 and 31
+func (c *CCalendar) GetDayIsMarked(day int) (marked bool) {
+	c.RLock()
+	defer c.RUnlock()
+	return c.marks[day]
+}
+
+// SetDisplayOptions sets display options (whether to display the heading and
+// the month headings). The CALENDAR_WEEK_START_MONDAY flag sets the first day
+// of the week to Monday, while leaving it unset keeps the current first day of
+// the week. CALENDAR_SHOW_DETAILS is not supported and ignored.
+//
+// Parameters:
+//
+//	flags	the display options to set
+func (c *CCalendar) SetDisplayOptions(flags enums.CalendarDisplayOptions) {
+	c.Freeze()
+	c.setBool(PropertyShowHeading, flags.Has(enums.CALENDAR_SHOW_HEADING))
+	c.setBool(PropertyShowDayNames, flags.Has(enums.CALENDAR_SHOW_DAY_NAMES))
+	c.setBool(PropertyNoMonthChange, flags.Has(enums.CALENDAR_NO_MONTH_CHANGE))
+	c.setBool(PropertyShowWeekNumbers, flags.Has(enums.CALENDAR_SHOW_WEEK_NUMBERS))
+	if flags.Has(enums.CALENDAR_WEEK_START_MONDAY) {
+		c.SetFirstDayOfWeek(time.Monday)
+	}
+	c.Thaw()
+	c.Resize()
+}
+
+// GetDisplayOptions returns the current display options of the Calendar.
+// See: SetDisplayOptions()
+func (c *CCalendar) GetDisplayOptions() (flags enums.CalendarDisplayOptions) {
+	if c.getBool(PropertyShowHeading) {
+		flags = flags.Set(enums.CALENDAR_SHOW_HEADING)
+	}
+	if c.getBool(PropertyShowDayNames) {
+		flags = flags.Set(enums.CALENDAR_SHOW_DAY_NAMES)
+	}
+	if c.getBool(PropertyNoMonthChange) {
+		flags = flags.Set(enums.CALENDAR_NO_MONTH_CHANGE)
+	}
+	if c.getBool(PropertyShowWeekNumbers) {
+		flags = flags.Set(enums.CALENDAR_SHOW_WEEK_NUMBERS)
+	}
+	if c.GetFirstDayOfWeek() == time.Monday {
+		flags = flags.Set(enums.CALENDAR_WEEK_START_MONDAY)
+	}
+	return
+}
+
+// SetFirstDayOfWeek sets the day displayed in the first column of the
+// Calendar, overriding the default of the current locale.
+//
+// Parameters:
+//
+//	weekday	the first day of the week
+func (c *CCalendar) SetFirstDayOfWeek(weekday time.Weekday) {
+	if weekday < time.Sunday || weekday > time.Saturday {
+		c.LogError("invalid weekday: %v", int(weekday))
+		return
+	}
+	if err := c.SetIntProperty(PropertyFirstDayOfWeek, int(weekday)); err != nil {
+		c.LogErr(err)
+	} else {
+		c.Invalidate()
+	}
+}
+
+// GetFirstDayOfWeek returns the day displayed in the first column of the
+// Calendar.
+// See: SetFirstDayOfWeek()
+func (c *CCalendar) GetFirstDayOfWeek() (weekday time.Weekday) {
+	value, err := c.GetIntProperty(PropertyFirstDayOfWeek)
+	if err != nil {
+		c.LogErr(err)
+	}
+	return time.Weekday(value)
+}
+
+// CancelEvent emits a cancel-event signal and if the signal handlers all
+// return EVENT_PASS, forgets any click pending a double-click.
+func (c *CCalendar) CancelEvent() {
+	if f := c.Emit(SignalCancelEvent, c); f == cenums.EVENT_PASS {
+		c.Lock()
+		c.lastClick, c.lastDay = time.Time{}, 0
+		c.Unlock()
+	}
+}
+
+// GetSizeRequest returns the requested size of the Calendar, which is enough
+// to display six weeks along with the optional heading, day names and week
+// numbers.
+func (c *CCalendar) GetSizeRequest() (width, height int) {
+	size := ptypes.NewRectangle(c.CWidget.GetSizeRequest())
+	if size.W <= -1 {
+		size.W = calendarGridWidth
+		if c.getBool(PropertyShowWeekNumbers) {
+			size.W += calendarCellWidth
+		}
+	}
+	if size.H <= -1 {
+		size.H = 6
+		if c.getBool(PropertyShowHeading) {
+			size.H += 1
+		}
+		if c.getBool(PropertyShowDayNames) {
+			size.H += 1
+		}
+	}
+	size.Floor(1, 1)
+	return size.W, size.H
+}
+
+func (c *CCalendar) setBool(property cdk.Property, value bool) {
+	if err := c.SetBoolProperty(property, value); err != nil {
+		c.LogErr(err)
+	} else {
+		c.Invalidate()
+	}
+}
+
+func (c *CCalendar) getBool(property cdk.Property) (value bool) {
+	var err error
+	if value, err = c.GetBoolProperty(property); err != nil {
+		c.LogErr(err)
+	}
+	return
+}
+
+// setDate updates the selected date, clamping the day to the month, and emits
+// the month-changed and day-selected signals as necessary.
+func (c *CCalendar) setDate(year int, month time.Month, day int) {
+	if days := calendarDaysIn(year, month); day > days {
+		day = days
+	} else if day < 0 {
+		day = 0
+	}
+	oldYear, oldMonth, oldDay := c.GetDate()
+	monthChanged := year != oldYear || month != oldMonth
+	if monthChanged {
+		if err := c.SetIntProperty(PropertyYear, year); err != nil {
+			c.LogErr(err)
+		}
+		if err := c.SetIntProperty(PropertyMonth, int(month)-1); err != nil {
+			c.LogErr(err)
+		}
+	}
+	if day != oldDay {
+		if err := c.SetIntProperty(PropertyDay, day); err != nil {
+			c.LogErr(err)
+		}
+	}
+	if monthChanged {
+		c.Emit(SignalMonthChanged, c)
+	}
+	if day != oldDay || (monthChanged && day > 0) {
+		c.Emit(SignalDaySelected, c)
+	}
+	c.Invalidate()
+}
+
+// moveDays moves the selected day by the given number of days, changing the
+// month unless the no-month-change property is set.
+func (c *CCalendar) moveDays(days int) {
+	year, month, day := c.GetDate()
+	if day == 0 {
+		c.SelectDay(1)
+		return
+	}
+	date := time.Date(year, month, day+days, 0, 0, 0, 0, time.UTC)
+	if date.Year() != year || date.Month() != month {
+		if c.getBool(PropertyNoMonthChange) {
+			if days < 0 {
+				c.SelectDay(1)
+			} else {
+				c.SelectDay(calendarDaysIn(year, month))
+			}
+			return
+		}
+	}
+	c.setDate(date.Year(), date.Month(), date.Day())
+}
+
+// moveMonths moves the Calendar by the given number of months, emitting the
+// matching navigation signal. Nothing happens when the no-month-change
+// property is set.
+func (c *CCalendar) moveMonths(months int, signal cdk.Signal) {
+	if c.getBool(PropertyNoMonthChange) {
+		return
+	}
+	year, month, day := c.GetDate()
+	date := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	c.setDate(date.Year(), date.Month(), day)
+	c.Emit(signal, c)
+}
+
+// getOffset returns the first column and line of the Calendar grid within the
+// allocation, which is centered horizontally.
+func (c *CCalendar) getOffset() (x, y int) {
+	alloc := c.GetAllocation()
+	w, _ := c.GetSizeRequest()
+	if alloc.W > w {
+		x = (alloc.W - w) / 2
+	}
+	if c.getBool(PropertyShowWeekNumbers) {
+		x += calendarCellWidth
+	}
+	if c.getBool(PropertyShowHeading) {
+		y += 1
+	}
+	if c.getBool(PropertyShowDayNames) {
+		y += 1
+	}
+	return
+}
+
+// getGridStart returns the date displayed in the first cell of the grid.
+func (c *CCalendar) getGridStart() (start time.Time) {
+	year, month, _ := c.GetDate()
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(first.Weekday()) - int(c.GetFirstDayOfWeek()) + 7) % 7
+	return first.AddDate(0, 0, -offset)
+}
+
+// getDateAt returns the date displayed at the given local point, if any.
+func (c *CCalendar) getDateAt(point ptypes.Point2I) (date time.Time, ok bool) {
+	x0, y0 := c.getOffset()
+	col, row := point.X-x0, point.Y-y0
+	if col < 0 || row < 0 || row >= 6 || col >= calendarGridWidth || col%calendarCellWidth == calendarCellWidth-1 {
+		return
+	}
+	return c.getGridStart().AddDate(0, 0, row*7+col/calendarCellWidth), true
+}
+
+func (c *CCalendar) event(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if !c.IsSensitive() {
+		return cenums.EVENT_PASS
+	}
+	if evt, ok := argv[1].(cdk.Event); ok {
+		switch e := evt.(type) {
+		case *cdk.EventKey:
+			if !c.HasFocus() {
+				return cenums.EVENT_PASS
+			}
+			return c.processKeyEvent(e)
+		case *cdk.EventMouse:
+			return c.processMouseEvent(e)
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (c *CCalendar) processKeyEvent(e *cdk.EventKey) cenums.EventFlag {
+	ctrl := e.Modifiers().Has(cdk.ModCtrl)
+	switch e.Key() {
+	case cdk.KeyLeft:
+		c.moveDays(-1)
+	case cdk.KeyRight:
+		c.moveDays(1)
+	case cdk.KeyUp:
+		c.moveDays(-7)
+	case cdk.KeyDown:
+		c.moveDays(7)
+	case cdk.KeyPgUp:
+		if ctrl {
+			c.moveMonths(-12, SignalPrevYear)
+		} else {
+			c.moveMonths(-1, SignalPrevMonth)
+		}
+	case cdk.KeyPgDn:
+		if ctrl {
+			c.moveMonths(12, SignalNextYear)
+		} else {
+			c.moveMonths(1, SignalNextMonth)
+		}
+	case cdk.KeyHome:
+		c.SelectDay(1)
+	case cdk.KeyEnd:
+		year, month, _ := c.GetDate()
+		c.SelectDay(calendarDaysIn(year, month))
+	default:
+		switch e.Rune() {
+		case 10, 13, ' ':
+			if _, _, day := c.GetDate(); day > 0 {
+				c.Emit(SignalDaySelectedDoubleClick, c)
+			}
+		default:
+			return cenums.EVENT_PASS
+		}
+	}
+	return cenums.EVENT_STOP
+}
+
+func (c *CCalendar) processMouseEvent(e *cdk.EventMouse) cenums.EventFlag {
+	pos := ptypes.NewPoint2I(e.Position())
+	if !c.HasPoint(pos) {
+		return cenums.EVENT_PASS
+	}
+	if e.IsWheelImpulse() {
+		switch e.WheelImpulse() {
+		case cdk.WheelUp:
+			c.moveMonths(-1, SignalPrevMonth)
+			return cenums.EVENT_STOP
+		case cdk.WheelDown:
+			c.moveMonths(1, SignalNextMonth)
+			return cenums.EVENT_STOP
+		}
+		return cenums.EVENT_PASS
+	}
+	if e.State() != cdk.BUTTON_PRESS || !e.Button().Has(cdk.Button1) {
+		return cenums.EVENT_PASS
+	}
+	if !c.HasFocus() && c.CanFocus() {
+		c.GrabFocus()
+	}
+	origin := c.GetOrigin()
+	local := ptypes.NewPoint2I(pos.X-origin.X, pos.Y-origin.Y)
+	if c.getBool(PropertyShowHeading) && local.Y == 0 {
+		x0, _ := c.getOffset()
+		switch local.X - x0 {
+		case calendarPrevMonthColumn:
+			c.moveMonths(-1, SignalPrevMonth)
+		case calendarNextMonthColumn:
+			c.moveMonths(1, SignalNextMonth)
+		case calendarPrevYearColumn:
+			c.moveMonths(-12, SignalPrevYear)
+		case calendarNextYearColumn:
+			c.moveMonths(12, SignalNextYear)
+		}
+		return cenums.EVENT_STOP
+	}
+	if date, ok := c.getDateAt(*local); ok {
+		year, month, _ := c.GetDate()
+		if date.Year() != year || date.Month() != month {
+			if c.getBool(PropertyNoMonthChange) {
+				return cenums.EVENT_STOP
+			}
+		}
+		c.setDate(date.Year(), date.Month(), date.Day())
+		now := time.Now()
+		c.Lock()
+		double := c.lastDay == date.Day() && now.Sub(c.lastClick) <= GetDefaultSettings().GetDoubleClickTime()
+		if double {
+			c.lastClick, c.lastDay = time.Time{}, 0
+		} else {
+			c.lastClick, c.lastDay = now, date.Day()
+		}
+		c.Unlock()
+		if double {
+			c.Emit(SignalDaySelectedDoubleClick, c)
+		}
+	}
+	return cenums.EVENT_STOP
+}
+
+func (c *CCalendar) resize(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	c.Invalidate()
+	return cenums.EVENT_STOP
+}
+
+func (c *CCalendar) draw(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if surface, ok := argv[1].(*memphis.CSurface); ok {
+		alloc := c.GetAllocation()
+		if !c.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+			c.LogTrace("not visible, zero width or zero height")
+			return cenums.EVENT_PASS
+		}
+		theme := c.GetThemeRequest()
+		style := theme.Content.Normal
+		if !c.IsSensitive() {
+			style = theme.Content.Insensitive
+		}
+		surface.Fill(theme)
+
+		year, month, day := c.GetDate()
+		x0, y := c.getOffset()
+		if c.getBool(PropertyShowHeading) {
+			heading := style.Bold(true)
+			calendarDrawText(surface, x0, 0, calendarMonthWidth, month.String(), heading)
+			calendarDrawText(surface, x0+calendarPrevYearColumn+1, 0, calendarNextYearColumn-calendarPrevYearColumn-1, fmt.Sprintf("%d", year), heading)
+			if !c.getBool(PropertyNoMonthChange) {
+				_ = surface.SetRune(x0+calendarPrevMonthColumn, 0, theme.Content.ArrowRunes.Left, style)
+				_ = surface.SetRune(x0+calendarNextMonthColumn, 0, theme.Content.ArrowRunes.Right, style)
+				_ = surface.SetRune(x0+calendarPrevYearColumn, 0, theme.Content.ArrowRunes.Left, style)
+				_ = surface.SetRune(x0+calendarNextYearColumn, 0, theme.Content.ArrowRunes.Right, style)
+			}
+		}
+		first := c.GetFirstDayOfWeek()
+		if c.getBool(PropertyShowDayNames) {
+			names := style.Underline(true)
+			for col := 0; col < 7; col++ {
+				name := time.Weekday((int(first) + col) % 7).String()[:2]
+				calendarDrawText(surface, x0+col*calendarCellWidth, y-1, 2, name, names)
+			}
+		}
+		c.RLock()
+		marks := make(map[int]bool, len(c.marks))
+		for k, v := range c.marks {
+			marks[k] = v
+		}
+		c.RUnlock()
+		start := c.getGridStart()
+		for row := 0; row < 6; row++ {
+			if c.getBool(PropertyShowWeekNumbers) {
+				// the ISO week of a row is the week of its Thursday
+				thursday := start.AddDate(0, 0, row*7+(int(time.Thursday)-int(first)+7)%7)
+				_, week := thursday.ISOWeek()
+				calendarDrawText(surface, x0-calendarCellWidth, y+row, 2, fmt.Sprintf("%2d", week), style.Dim(true))
+			}
+			for col := 0; col < 7; col++ {
+				date := start.AddDate(0, 0, row*7+col)
+				cell := style
+				if date.Month() != month {
+					cell = style.Dim(true)
+				} else {
+					if marks[date.Day()] {
+						cell = cell.Bold(true)
+					}
+					if date.Day() == day {
+						cell = cell.Reverse(true)
+					}
+				}
+				calendarDrawText(surface, x0+col*calendarCellWidth, y+row, 2, fmt.Sprintf("%2d", date.Day()), cell)
+			}
+		}
+		if debug, _ := c.GetBoolProperty(cdk.PropertyDebug); debug {
+			surface.DebugBox(paint.ColorSilver, c.ObjectInfo())
+		}
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+// calendarDrawText draws the given ASCII text centered within width cells.
+func calendarDrawText(surface *memphis.CSurface, x, y, width int, text string, style paint.Style) {
+	if len(text) > width {
+		text = text[:width]
+	}
+	x += (width - len(text)) / 2
+	for idx, r := range text {
+		_ = surface.SetRune(x+idx, y, r, style)
+	}
+}
+
+// calendarDaysIn returns the number of days in the given month.
+func calendarDaysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// calendarLocale returns the locale used for time formatting, from the LC_ALL,
+// LC_TIME and LANG environment variables.
+func calendarLocale() (locale string) {
+	for _, name := range []string{"LC_ALL", "LC_TIME", "LANG"} {
+		if locale = os.Getenv(name); locale != "" {
+			return
+		}
+	}
+	return
+}
+
+// calendarLocaleFirstDay returns the first day of the week for the territory
+// of the given POSIX locale name, such as "en_US.UTF-8". Locales without a
+// territory, including "C" and "POSIX", start the week on Sunday.
+func calendarLocaleFirstDay(locale string) time.Weekday {
+	if idx := strings.IndexAny(locale, ".@"); idx >= 0 {
+		locale = locale[:idx]
+	}
+	idx := strings.IndexAny(locale, "_-")
+	if idx < 0 {
+		return time.Sunday
+	}
+	territory := strings.ToUpper(locale[idx+1:])
+	for _, t := range calendarSundayTerritories {
+		if t == territory {
+			return time.Sunday
+		}
+	}
+	for _, t := range calendarSaturdayTerritories {
+		if t == territory {
+			return time.Saturday
+		}
+	}
+	return time.Monday
+}
+
+var calendarSundayTerritories = []string{
+	"AG", "AS", "BD", "BR", "BS", "BT", "BW", "BZ", "CA", "CO", "DM", "DO",
+	"ET", "GT", "GU", "HK", "HN", "ID", "IL", "IN", "JM", "JP", "KE", "KH",
+	"KR", "LA", "MH", "MM", "MO", "MT", "MX", "MZ", "NI", "NP", "PA", "PE",
+	"PH", "PK", "PR", "PT", "PY", "SA", "SG", "SV", "TH", "TT", "TW", "UM",
+	"US", "VE", "VI", "WS", "YE", "ZA", "ZW",
+}
+
+var calendarSaturdayTerritories = []string{
+	"AE", "AF", "BH", "DJ", "DZ", "EG", "IQ", "IR", "JO", "KW", "LY", "OM",
+	"QA", "SD", "SY",
+}
+
+const (
+	calendarCellWidth       = 3
+	calendarGridWidth       = 7*calendarCellWidth - 1
+	calendarMonthWidth      = 13
+	calendarPrevMonthColumn = 0
+	calendarNextMonthColumn = calendarMonthWidth - 1
+	calendarPrevYearColumn  = calendarMonthWidth + 1
+	calendarNextYearColumn  = calendarGridWidth - 1
+)
+
+// The selected year.
+// Flags: Read / Write
+// Default value: the current year
+const PropertyYear cdk.Property = "year"
+
+// The selected month (as a number between 0 and 11).
+// Flags: Read / Write
+// Allowed values: [0,11]
+// Default value: the current month
+const PropertyMonth cdk.Property = "month"
+
+// The selected day (as a number between 1 and 31, or 0 to unselect the
+// currently selected day). This property gets initially set to the current
+// day.
+// Flags: Read / Write
+// Allowed values: [0,31]
+// Default value: the current day
+const PropertyDay cdk.Property = "day"
+
+// Determines whether a heading is displayed.
+// Flags: Read / Write
+// Default value: TRUE
+const PropertyShowHeading cdk.Property = "show-heading"
+
+// Determines whether day names are displayed.
+// Flags: Read / Write
+// Default value: TRUE
+const PropertyShowDayNames cdk.Property = "show-day-names"
+
+// Determines whether the selected month can be changed.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyNoMonthChange cdk.Property = "no-month-change"
+
+// Determines whether week numbers are displayed.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyShowWeekNumbers cdk.Property = "show-week-numbers"
+
+// The day displayed in the first column, as a number between 0 (Sunday) and 6
+// (Saturday).
+// Flags: Read / Write
+// Allowed values: [0,6]
+// Default value: the first day of the week of the current locale
+const PropertyFirstDayOfWeek cdk.Property = "first-day-of-week"
+
+// The day-selected signal is emitted when the user selects a day.
+const SignalDaySelected cdk.Signal = "day-selected"
+
+// The day-selected-double-click signal is emitted when the user double-clicks
+// a day, or presses Enter or Space on the selected day.
+const SignalDaySelectedDoubleClick cdk.Signal = "day-selected-double-click"
+
+// The month-changed signal is emitted when the user clicks a button to change
+// the selected month on a calendar.
+const SignalMonthChanged cdk.Signal = "month-changed"
+
+// The next-month signal is emitted when the user switched to the next month.
+const SignalNextMonth cdk.Signal = "next-month"
+
+// The next-year signal is emitted when user switched to the next year.
+const SignalNextYear cdk.Signal = "next-year"
+
+// The prev-month signal is emitted when the user switched to the previous
+// month.
+const SignalPrevMonth cdk.Signal = "prev-month"
+
+// The prev-year signal is emitted when user switched to the previous year.
+const SignalPrevYear cdk.Signal = "prev-year"
+
+const CalendarEventHandle = "calendar-event-handler"
+
+const CalendarResizeHandle = "calendar-resize-handler"
+
+const CalendarDrawHandle = "calendar-draw-handler"
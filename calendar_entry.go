@@ -0,0 +1,430 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/ptypes"
+	"github.com/go-curses/cdk/memphis"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeCalendarEntry cdk.CTypeTag = "ctk-calendar-entry"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeCalendarEntry, func() interface{} { return MakeCalendarEntry() })
+}
+
+// CalendarEntry Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Misc
+//	      +- Entry
+//	        +- CalendarEntry
+//
+// A CalendarEntry is a compact date picker, an Entry displaying a date with an
+// arrow that pops up a Calendar below the Entry. The popup is opened by
+// clicking on the arrow or by pressing the Down key while the Entry has focus.
+// While the Calendar is popped up, all events of the Window of the Entry are
+// routed to the Calendar; double-clicking a day or pressing Enter selects the
+// day and closes the popup while Escape or clicking outside the Calendar
+// closes the popup without changing the date.
+//
+// The date is displayed using a Go time layout, "2006-01-02" by default, and
+// can also be typed in directly.
+type CalendarEntry interface {
+	Entry
+
+	Init() (already bool)
+	GetCalendar() (calendar Calendar)
+	SetDateFormat(format string)
+	GetDateFormat() (format string)
+	SetDate(date time.Time)
+	GetDate() (date time.Time, ok bool)
+	Popup()
+	Popdown()
+	IsPoppedUp() (poppedUp bool)
+	GetSizeRequest() (width, height int)
+}
+
+var _ CalendarEntry = (*CCalendarEntry)(nil)
+
+// The CCalendarEntry structure implements the CalendarEntry interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with CalendarEntry objects.
+type CCalendarEntry struct {
+	CEntry
+
+	calendar   Calendar
+	window     Window
+	grabWindow Window
+	grabHandle string
+	poppedUp   bool
+}
+
+// MakeCalendarEntry is used by the Buildable system to construct a new
+// CalendarEntry.
+func MakeCalendarEntry() CalendarEntry {
+	return NewCalendarEntry()
+}
+
+// NewCalendarEntry is the constructor for new CalendarEntry instances. The
+// Entry is initially empty and the Calendar shows the current date.
+func NewCalendarEntry() CalendarEntry {
+	e := new(CCalendarEntry)
+	e.Init()
+	return e
+}
+
+// Init initializes a CalendarEntry object. This must be called at least once
+// to set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the CalendarEntry instance. Init is used in the
+// NewCalendarEntry constructor and only necessary when implementing a
+// derivative CalendarEntry type.
+func (e *CCalendarEntry) Init() (already bool) {
+	if e.InitTypeItem(TypeCalendarEntry, e) {
+		return true
+	}
+	e.CEntry.Init()
+	e.tReserved = 1
+	e.SetSingleLineMode(true)
+	_ = e.InstallBuildableProperty(PropertyDateFormat, cdk.StringProperty, true, CalendarEntryDefaultDateFormat)
+	e.grabHandle = fmt.Sprintf("%v-%v", CalendarEntryGrabHandle, e.ObjectID())
+	e.poppedUp = false
+	e.calendar = NewCalendar()
+	e.calendar.Connect(SignalDaySelectedDoubleClick, e.grabHandle, e.calendarActivated)
+	e.Connect(SignalCdkEvent, CalendarEntryEventHandle, e.event)
+	e.Connect(SignalDraw, CalendarEntryDrawHandle, e.draw)
+	return false
+}
+
+// GetCalendar returns the Calendar displayed within the popup.
+func (e *CCalendarEntry) GetCalendar() (calendar Calendar) {
+	e.RLock()
+	defer e.RUnlock()
+	return e.calendar
+}
+
+// SetDateFormat sets the Go time layout used to display and parse the date,
+// reformatting the current date if it is valid.
+//
+// Parameters:
+//
+//	format	a time layout, such as "2006-01-02" or "Jan 2, 2006"
+func (e *CCalendarEntry) SetDateFormat(format string) {
+	date, ok := e.GetDate()
+	if err := e.SetStringProperty(PropertyDateFormat, format); err != nil {
+		e.LogErr(err)
+	} else if ok {
+		e.SetDate(date)
+	}
+}
+
+// GetDateFormat returns the Go time layout used to display and parse the date.
+// See: SetDateFormat()
+func (e *CCalendarEntry) GetDateFormat() (format string) {
+	var err error
+	if format, err = e.GetStringProperty(PropertyDateFormat); err != nil {
+		e.LogErr(err)
+	}
+	return
+}
+
+// SetDate displays the given date within the Entry and selects it within the
+// Calendar.
+//
+// Parameters:
+//
+//	date	the date to display
+func (e *CCalendarEntry) SetDate(date time.Time) {
+	text := date.Format(e.GetDateFormat())
+	e.SetText(text)
+	e.SetPosition(len(text))
+	e.GetCalendar().SetDate(date)
+}
+
+// GetDate parses the text of the Entry with the date format. Returns FALSE if
+// the text is not a valid date.
+func (e *CCalendarEntry) GetDate() (date time.Time, ok bool) {
+	text := strings.TrimSpace(e.GetText())
+	if text == "" {
+		return
+	}
+	var err error
+	if date, err = time.ParseInLocation(e.GetDateFormat(), text, time.Local); err != nil {
+		e.LogDebug("invalid date: %q", text)
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// Popup displays the Calendar below the Entry, or above it when there is not
+// enough room below, and grabs the events of the Window of the Entry. The date
+// of the Entry is selected within the Calendar, if valid.
+func (e *CCalendarEntry) Popup() {
+	if e.IsPoppedUp() {
+		return
+	}
+	calendar := e.GetCalendar()
+	if date, ok := e.GetDate(); ok {
+		calendar.SetDate(date)
+	}
+	window := e.getPopupWindow()
+	parent := e.GetWindow()
+	e.Lock()
+	e.poppedUp = true
+	e.grabWindow = parent
+	e.Unlock()
+	if parent != nil {
+		parent.Connect(SignalCdkEvent, e.grabHandle, e.grabEvent)
+	}
+	calendar.Show()
+	e.reposition()
+	window.Show()
+	calendar.GrabFocus()
+	calendar.Invalidate()
+	menuRequestDraw()
+}
+
+// Popdown hides the Calendar and releases the events of the Window of the
+// Entry.
+func (e *CCalendarEntry) Popdown() {
+	e.Lock()
+	poppedUp := e.poppedUp
+	window, parent := e.window, e.grabWindow
+	e.poppedUp = false
+	e.grabWindow = nil
+	e.Unlock()
+	if !poppedUp {
+		return
+	}
+	if parent != nil {
+		_ = parent.Disconnect(SignalCdkEvent, e.grabHandle)
+	}
+	if window != nil {
+		window.Hide()
+	}
+	e.Invalidate()
+	menuRequestDraw()
+}
+
+// IsPoppedUp returns TRUE if the Calendar is currently displayed.
+func (e *CCalendarEntry) IsPoppedUp() (poppedUp bool) {
+	e.RLock()
+	defer e.RUnlock()
+	return e.poppedUp
+}
+
+// GetSizeRequest returns the requested size of the CalendarEntry, which is
+// wide enough to display a date in the date format along with the arrow.
+func (e *CCalendarEntry) GetSizeRequest() (width, height int) {
+	size := ptypes.NewRectangle(e.CWidget.GetSizeRequest())
+	if size.W <= -1 {
+		size.W = runewidth.StringWidth(calendarEntryReferenceDate.Format(e.GetDateFormat())) + 1 + e.tReserved
+	}
+	if size.H <= -1 {
+		size.H = 1
+	}
+	size.Floor(1, 1)
+	return size.W, size.H
+}
+
+// getPopupWindow returns the popup Window the Calendar is displayed within,
+// creating it if necessary.
+func (e *CCalendarEntry) getPopupWindow() (window Window) {
+	e.RLock()
+	window = e.window
+	e.RUnlock()
+	if window == nil {
+		window = NewWindow()
+		window.SetWindowType(cenums.WINDOW_POPUP)
+		window.SetFlags(enums.TOPLEVEL)
+		window.SetDecorated(false)
+		window.SetTheme(e.GetTheme())
+		e.Lock()
+		e.window = window
+		e.Unlock()
+		window.GetVBox().PackStart(e.GetCalendar(), true, true, 0)
+	}
+	return
+}
+
+// reposition moves the popup Window below or above the Entry, within the
+// bounds of the screen.
+func (e *CCalendarEntry) reposition() {
+	window := e.getPopupWindow()
+	w, h := e.GetCalendar().GetSizeRequest()
+	bounds := e.getScreenBounds()
+	origin := e.GetOrigin()
+	alloc := e.GetAllocation()
+	x, y := origin.X, origin.Y+alloc.H
+	if bounds.H > 0 {
+		if y+h > bounds.Y+bounds.H && origin.Y-h >= bounds.Y {
+			y = origin.Y - h
+		}
+		if y+h > bounds.Y+bounds.H {
+			y = bounds.Y + bounds.H - h
+		}
+		if y < bounds.Y {
+			y = bounds.Y
+		}
+	}
+	if bounds.W > 0 {
+		if x+w > bounds.X+bounds.W {
+			x = bounds.X + bounds.W - w
+		}
+		if x < bounds.X {
+			x = bounds.X
+		}
+	}
+	window.Move(x, y)
+	window.SetAllocation(ptypes.MakeRectangle(w, h))
+	window.Resize()
+}
+
+// getScreenBounds returns the region the popup must fit within, which is the
+// whole screen when the display is running or the region of the Window of the
+// Entry otherwise.
+func (e *CCalendarEntry) getScreenBounds() (bounds ptypes.Region) {
+	if display := cdk.GetDefaultDisplay(); display != nil && display.IsRunning() {
+		alloc := ptypes.MakeRectangle(display.Screen().Size())
+		return ptypes.MakeRegion(0, 0, alloc.W, alloc.H)
+	}
+	if window := e.GetWindow(); window != nil {
+		origin := window.GetOrigin()
+		alloc := window.GetAllocation()
+		return ptypes.MakeRegion(origin.X, origin.Y, alloc.W, alloc.H)
+	}
+	return
+}
+
+// grabEvent is connected to the cdk-event signal of the Window of the Entry
+// while the Calendar is popped up, routing all events to the Calendar.
+func (e *CCalendarEntry) grabEvent(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if _, event, ok := ArgvSignalEvent(argv...); ok {
+		calendar := e.GetCalendar()
+		switch evt := event.(type) {
+		case *cdk.EventKey:
+			if evt.Key() == cdk.KeyEscape {
+				e.Popdown()
+				return cenums.EVENT_STOP
+			}
+			calendar.ProcessEvent(evt)
+			menuRequestDraw()
+			return cenums.EVENT_STOP
+		case *cdk.EventMouse:
+			if menuRegionHasPoint(calendar, *ptypes.NewPoint2I(evt.Position())) {
+				calendar.ProcessEvent(evt)
+				menuRequestDraw()
+				return cenums.EVENT_STOP
+			}
+			switch evt.State() {
+			case cdk.BUTTON_PRESS, cdk.DRAG_START:
+				e.Popdown()
+			}
+			return cenums.EVENT_STOP
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (e *CCalendarEntry) calendarActivated(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	year, month, day := e.GetCalendar().GetDate()
+	if day > 0 {
+		e.SetDate(time.Date(year, month, day, 0, 0, 0, 0, time.Local))
+		e.Popdown()
+		e.Emit(SignalDaySelected, e)
+	}
+	return cenums.EVENT_PASS
+}
+
+func (e *CCalendarEntry) event(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if !e.IsSensitive() {
+		return cenums.EVENT_PASS
+	}
+	if evt, ok := argv[1].(cdk.Event); ok {
+		switch ev := evt.(type) {
+		case *cdk.EventKey:
+			if e.HasFocus() && ev.Key() == cdk.KeyDown {
+				e.Popup()
+				return cenums.EVENT_STOP
+			}
+		case *cdk.EventMouse:
+			pos := ptypes.NewPoint2I(ev.Position())
+			if !e.HasPoint(pos) || ev.State() != cdk.BUTTON_PRESS || !ev.Button().Has(cdk.Button1) {
+				return cenums.EVENT_PASS
+			}
+			if pos.X-e.GetOrigin().X == e.GetAllocation().W-1 {
+				if !e.HasFocus() && e.CanFocus() {
+					e.GrabFocus()
+				}
+				e.Popup()
+				return cenums.EVENT_STOP
+			}
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (e *CCalendarEntry) draw(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if f := e.CEntry.draw(data, argv...); f != cenums.EVENT_STOP {
+		return f
+	}
+	if surface, ok := argv[1].(*memphis.CSurface); ok {
+		alloc := e.GetAllocation()
+		if alloc.W < e.tReserved {
+			return cenums.EVENT_STOP
+		}
+		theme := e.GetThemeRequest()
+		style := theme.Content.Normal
+		if !e.IsSensitive() {
+			style = theme.Content.Insensitive
+		}
+		_, yAlign := e.GetAlignment()
+		y := int(float64(alloc.H-1) * yAlign)
+		if err := surface.SetRune(alloc.W-1, y, theme.Content.ArrowRunes.Down, style); err != nil {
+			e.LogErr(err)
+		}
+	}
+	return cenums.EVENT_STOP
+}
+
+// calendarEntryReferenceDate is used to measure the width of the date format
+var calendarEntryReferenceDate = time.Date(2006, time.September, 30, 0, 0, 0, 0, time.UTC)
+
+// CalendarEntryDefaultDateFormat is the default time layout of CalendarEntry
+// widgets.
+const CalendarEntryDefaultDateFormat = "2006-01-02"
+
+// The Go time layout used to display and parse the date.
+// Flags: Read / Write
+// Default value: "2006-01-02"
+const PropertyDateFormat cdk.Property = "date-format"
+
+const CalendarEntryGrabHandle = "calendar-entry-grab-handler"
+
+const CalendarEntryEventHandle = "calendar-entry-event-handler"
+
+const CalendarEntryDrawHandle = "calendar-entry-draw-handler"
@@ -0,0 +1,238 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/ptypes"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+func TestCalendar(t *testing.T) {
+	Convey("Testing Calendars", t, func() {
+		c := NewCalendar()
+		So(c, ShouldNotBeNil)
+		signals := make(map[cdk.Signal]int)
+		for _, signal := range []cdk.Signal{SignalDaySelected, SignalDaySelectedDoubleClick, SignalMonthChanged, SignalPrevMonth, SignalNextMonth, SignalPrevYear, SignalNextYear} {
+			signal := signal
+			c.Connect(signal, "test-calendar-signal", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				signals[signal] += 1
+				return cenums.EVENT_PASS
+			})
+		}
+		c.SetFirstDayOfWeek(time.Monday)
+		c.SetDate(time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC))
+		signals = make(map[cdk.Signal]int)
+
+		Convey("selection", func() {
+			year, month, day := c.GetDate()
+			So(year, ShouldEqual, 2024)
+			So(month, ShouldEqual, time.February)
+			So(day, ShouldEqual, 10)
+			month0, _ := c.GetIntProperty(PropertyMonth)
+			So(month0, ShouldEqual, 1)
+			c.SelectDay(31)
+			_, _, day = c.GetDate()
+			So(day, ShouldEqual, 29)
+			So(signals[SignalDaySelected], ShouldEqual, 1)
+			So(c.SelectMonth(time.March, 2024), ShouldEqual, true)
+			So(signals[SignalMonthChanged], ShouldEqual, 1)
+			So(signals[SignalDaySelected], ShouldEqual, 2)
+			So(c.SelectMonth(13, 2024), ShouldEqual, false)
+			c.SelectDay(0)
+			_, month, day = c.GetDate()
+			So(month, ShouldEqual, time.March)
+			So(day, ShouldEqual, 0)
+			So(calendarDaysIn(2023, time.February), ShouldEqual, 28)
+		})
+
+		Convey("marks", func() {
+			So(c.MarkDay(3), ShouldEqual, true)
+			So(c.MarkDay(32), ShouldEqual, false)
+			So(c.GetDayIsMarked(3), ShouldEqual, true)
+			So(c.UnmarkDay(3), ShouldEqual, true)
+			So(c.GetDayIsMarked(3), ShouldEqual, false)
+			c.MarkDay(4)
+			c.MarkDay(5)
+			c.ClearMarks()
+			So(c.GetDayIsMarked(4), ShouldEqual, false)
+			So(c.GetDayIsMarked(5), ShouldEqual, false)
+		})
+
+		Convey("layout and display options", func() {
+			So(c.GetDisplayOptions(), ShouldEqual, enums.CALENDAR_SHOW_HEADING|enums.CALENDAR_SHOW_DAY_NAMES|enums.CALENDAR_WEEK_START_MONDAY)
+			w, h := c.GetSizeRequest()
+			So(w, ShouldEqual, 20)
+			So(h, ShouldEqual, 8)
+			So(c.(*CCalendar).getGridStart(), ShouldEqual, time.Date(2024, time.January, 29, 0, 0, 0, 0, time.UTC))
+			c.SetFirstDayOfWeek(time.Sunday)
+			So(c.(*CCalendar).getGridStart(), ShouldEqual, time.Date(2024, time.January, 28, 0, 0, 0, 0, time.UTC))
+			c.SetDisplayOptions(enums.CALENDAR_SHOW_WEEK_NUMBERS | enums.CALENDAR_WEEK_START_MONDAY)
+			So(c.GetFirstDayOfWeek(), ShouldEqual, time.Monday)
+			So(c.GetDisplayOptions(), ShouldEqual, enums.CALENDAR_SHOW_WEEK_NUMBERS|enums.CALENDAR_WEEK_START_MONDAY)
+			w, h = c.GetSizeRequest()
+			So(w, ShouldEqual, 23)
+			So(h, ShouldEqual, 6)
+			So(calendarLocaleFirstDay("en_US.UTF-8"), ShouldEqual, time.Sunday)
+			So(calendarLocaleFirstDay("en_GB.UTF-8"), ShouldEqual, time.Monday)
+			So(calendarLocaleFirstDay("de_DE@euro"), ShouldEqual, time.Monday)
+			So(calendarLocaleFirstDay("ar_EG"), ShouldEqual, time.Saturday)
+			So(calendarLocaleFirstDay("C"), ShouldEqual, time.Sunday)
+			So(calendarLocaleFirstDay(""), ShouldEqual, time.Sunday)
+		})
+
+		Convey("keyboard navigation", func() {
+			So(c.SetBoolProperty(PropertyHasFocus, true), ShouldBeNil)
+			date := func() time.Time {
+				year, month, day := c.GetDate()
+				return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+			}
+			c.ProcessEvent(cdk.NewEventKey(cdk.KeyRight, 0, cdk.ModNone))
+			So(date().Day(), ShouldEqual, 11)
+			c.ProcessEvent(cdk.NewEventKey(cdk.KeyDown, 0, cdk.ModNone))
+			c.ProcessEvent(cdk.NewEventKey(cdk.KeyDown, 0, cdk.ModNone))
+			So(date(), ShouldEqual, time.Date(2024, time.February, 25, 0, 0, 0, 0, time.UTC))
+			c.ProcessEvent(cdk.NewEventKey(cdk.KeyDown, 0, cdk.ModNone))
+			So(date(), ShouldEqual, time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC))
+			So(signals[SignalMonthChanged], ShouldEqual, 1)
+			c.ProcessEvent(cdk.NewEventKey(cdk.KeyPgUp, 0, cdk.ModNone))
+			So(date(), ShouldEqual, time.Date(2024, time.February, 3, 0, 0, 0, 0, time.UTC))
+			So(signals[SignalPrevMonth], ShouldEqual, 1)
+			c.ProcessEvent(cdk.NewEventKey(cdk.KeyPgDn, 0, cdk.ModCtrl))
+			So(date(), ShouldEqual, time.Date(2025, time.February, 3, 0, 0, 0, 0, time.UTC))
+			So(signals[SignalNextYear], ShouldEqual, 1)
+			c.ProcessEvent(cdk.NewEventKey(cdk.KeyEnd, 0, cdk.ModNone))
+			So(date().Day(), ShouldEqual, 28)
+			c.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, rune(cdk.KeyEnter), cdk.ModNone))
+			So(signals[SignalDaySelectedDoubleClick], ShouldEqual, 1)
+			c.SetDisplayOptions(c.GetDisplayOptions() | enums.CALENDAR_NO_MONTH_CHANGE)
+			c.ProcessEvent(cdk.NewEventKey(cdk.KeyRight, 0, cdk.ModNone))
+			So(date(), ShouldEqual, time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC))
+			c.ProcessEvent(cdk.NewEventKey(cdk.KeyPgDn, 0, cdk.ModNone))
+			So(date(), ShouldEqual, time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC))
+			c.ProcessEvent(cdk.NewEventKey(cdk.KeyHome, 0, cdk.ModNone))
+			So(date().Day(), ShouldEqual, 1)
+			So(c.SetBoolProperty(PropertyHasFocus, false), ShouldBeNil)
+			c.ProcessEvent(cdk.NewEventKey(cdk.KeyRight, 0, cdk.ModNone))
+			So(date().Day(), ShouldEqual, 1)
+		})
+
+		Convey("mouse", func() {
+			c.Show()
+			c.SetAllocation(ptypes.MakeRectangle(20, 8))
+			click := func(x, y int) {
+				c.ProcessEvent(cdk.NewEventMouse(x, y, cdk.Button1, cdk.ModNone))
+				c.ProcessEvent(cdk.NewEventMouse(x, y, cdk.ButtonNone, cdk.ModNone))
+			}
+			// heading arrows
+			click(0, 0)
+			_, month, _ := c.GetDate()
+			So(month, ShouldEqual, time.January)
+			click(19, 0)
+			year, month, _ := c.GetDate()
+			So(year, ShouldEqual, 2025)
+			So(month, ShouldEqual, time.January)
+			c.ProcessEvent(cdk.NewEventMouse(0, 0, cdk.WheelDown, cdk.ModNone))
+			So(signals[SignalNextMonth], ShouldEqual, 1)
+			c.SelectMonth(time.February, 2024)
+			// the first row starts on Monday the 29th of January
+			click(9, 2)
+			year, month, day := c.GetDate()
+			So(year, ShouldEqual, 2024)
+			So(month, ShouldEqual, time.February)
+			So(day, ShouldEqual, 1)
+			So(signals[SignalDaySelectedDoubleClick], ShouldEqual, 0)
+			click(10, 2)
+			So(signals[SignalDaySelectedDoubleClick], ShouldEqual, 1)
+			click(0, 2)
+			_, month, day = c.GetDate()
+			So(month, ShouldEqual, time.January)
+			So(day, ShouldEqual, 29)
+		})
+	})
+}
+
+func TestCalendarEntry(t *testing.T) {
+	Convey("Testing Calendar Entries", t, func() {
+		e := NewCalendarEntry()
+		So(e, ShouldNotBeNil)
+		_, ok := e.GetDate()
+		So(ok, ShouldEqual, false)
+		So(e.GetDateFormat(), ShouldEqual, CalendarEntryDefaultDateFormat)
+		e.SetDate(time.Date(2024, time.February, 10, 0, 0, 0, 0, time.Local))
+		So(e.GetText(), ShouldEqual, "2024-02-10")
+		date, ok := e.GetDate()
+		So(ok, ShouldEqual, true)
+		So(date.Equal(time.Date(2024, time.February, 10, 0, 0, 0, 0, time.Local)), ShouldEqual, true)
+		w, h := e.GetSizeRequest()
+		So(w, ShouldEqual, 12)
+		So(h, ShouldEqual, 1)
+		e.SetDateFormat("Jan 2, 2006")
+		So(e.GetText(), ShouldEqual, "Feb 10, 2024")
+		e.SetText("not a date")
+		_, ok = e.GetDate()
+		So(ok, ShouldEqual, false)
+		e.SetDateFormat(CalendarEntryDefaultDateFormat)
+		e.SetDate(time.Date(2024, time.February, 10, 0, 0, 0, 0, time.Local))
+
+		Convey("popup", func() {
+			window := NewWindow()
+			window.SetDecorated(false)
+			window.Show()
+			window.SetAllocation(ptypes.MakeRectangle(40, 20))
+			e.Show()
+			window.GetVBox().PackStart(e, false, false, 0)
+			window.Resize()
+			e.GrabFocus()
+			So(e.HasFocus(), ShouldEqual, true)
+			selected := 0
+			e.Connect(SignalDaySelected, "test-day-selected", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				selected += 1
+				return cenums.EVENT_PASS
+			})
+
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyDown, 0, cdk.ModNone))
+			So(e.IsPoppedUp(), ShouldEqual, true)
+			calendar := e.GetCalendar()
+			So(calendar.GetOrigin(), ShouldResemble, ptypes.MakePoint2I(0, 1))
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRight, 0, cdk.ModNone))
+			So(e.GetText(), ShouldEqual, "2024-02-10")
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, rune(cdk.KeyEnter), cdk.ModNone))
+			So(e.IsPoppedUp(), ShouldEqual, false)
+			So(e.GetText(), ShouldEqual, "2024-02-11")
+			So(selected, ShouldEqual, 1)
+
+			e.Popup()
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRight, 0, cdk.ModNone))
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyEscape, 0, cdk.ModNone))
+			So(e.IsPoppedUp(), ShouldEqual, false)
+			So(e.GetText(), ShouldEqual, "2024-02-11")
+
+			// clicking the arrow opens the popup, clicking outside closes it
+			window.ProcessEvent(cdk.NewEventMouse(39, 0, cdk.Button1, cdk.ModNone))
+			window.ProcessEvent(cdk.NewEventMouse(39, 0, cdk.ButtonNone, cdk.ModNone))
+			So(e.IsPoppedUp(), ShouldEqual, true)
+			window.ProcessEvent(cdk.NewEventMouse(30, 15, cdk.Button1, cdk.ModNone))
+			So(e.IsPoppedUp(), ShouldEqual, false)
+			So(selected, ShouldEqual, 1)
+		})
+	})
+}
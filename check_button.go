@@ -0,0 +1,108 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"github.com/go-curses/cdk"
+	"github.com/go-curses/cdk/lib/paint"
+)
+
+const TypeCheckButton cdk.CTypeTag = "ctk-check-button"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeCheckButton, func() interface{} { return MakeCheckButton() })
+}
+
+// CheckButton Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Bin
+//	        +- Button
+//	          +- ToggleButton
+//	            +- CheckButton
+//	              +- RadioButton
+//
+// A CheckButton places a discrete ToggleButton indicator next to a Label,
+// ie: "[x] Label" when active, "[ ] Label" when not and "[-] Label" when in
+// an inconsistent state.
+type CheckButton interface {
+	ToggleButton
+}
+
+var _ CheckButton = (*CCheckButton)(nil)
+
+// The CCheckButton structure implements the CheckButton interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with CheckButton objects.
+type CCheckButton struct {
+	CToggleButton
+}
+
+// MakeCheckButton is used by the Buildable system to construct a new
+// CheckButton with a default label that is empty.
+func MakeCheckButton() CheckButton {
+	return NewCheckButtonWithLabel("")
+}
+
+// NewCheckButton creates a new CheckButton without a label Widget.
+func NewCheckButton() CheckButton {
+	c := new(CCheckButton)
+	c.Init()
+	return c
+}
+
+// NewCheckButtonWithLabel creates a new CheckButton with a Label to the right
+// of the indicator.
+//
+// Parameters:
+//
+//	label	the text for the check button
+func NewCheckButtonWithLabel(label string) CheckButton {
+	c := NewCheckButton()
+	buttonAddLabel(c, label)
+	return c
+}
+
+// NewCheckButtonWithMnemonic creates a new CheckButton containing a label.
+// Underscores in the label indicate the mnemonic for the check button.
+//
+// Parameters:
+//
+//	label	the text of the button, with an underscore in front of the
+//	        mnemonic character
+func NewCheckButtonWithMnemonic(label string) CheckButton {
+	c := NewCheckButtonWithLabel(label)
+	c.SetUseUnderline(true)
+	return c
+}
+
+// Init initializes a CheckButton object. This must be called at least once
+// to set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the CheckButton instance. Init is used in the
+// NewCheckButton constructor and only necessary when implementing a
+// derivative CheckButton type.
+func (c *CCheckButton) Init() (already bool) {
+	if c.InitTypeItem(TypeCheckButton, c) {
+		return true
+	}
+	c.CToggleButton.Init()
+	c.SetTheme(paint.GetDefaultColorTheme())
+	c.SetMode(true)
+	return false
+}
@@ -0,0 +1,237 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"github.com/go-curses/cdk"
+)
+
+const TypeCheckMenuItem cdk.CTypeTag = "ctk-check-menu-item"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeCheckMenuItem, func() interface{} { return MakeCheckMenuItem() })
+}
+
+// CheckMenuItem Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Bin
+//	        +- Item
+//	          +- MenuItem
+//	            +- CheckMenuItem
+//	              +- RadioMenuItem
+//
+// A CheckMenuItem is a menu item that maintains the state of a boolean value
+// in addition to a MenuItem usual role in activating application code. A
+// check box indicating the state of the boolean value is displayed at the
+// left side of the MenuItem. Activating the MenuItem toggles the value.
+type CheckMenuItem interface {
+	MenuItem
+
+	GetActive() (value bool)
+	SetActive(isActive bool)
+	Toggled()
+	GetInconsistent() (value bool)
+	SetInconsistent(setting bool)
+	GetDrawAsRadio() (value bool)
+	SetDrawAsRadio(drawAsRadio bool)
+}
+
+var _ CheckMenuItem = (*CCheckMenuItem)(nil)
+
+// The CCheckMenuItem structure implements the CheckMenuItem interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with CheckMenuItem objects.
+type CCheckMenuItem struct {
+	CMenuItem
+}
+
+// MakeCheckMenuItem is used by the Buildable system to construct a new
+// CheckMenuItem.
+func MakeCheckMenuItem() CheckMenuItem {
+	return NewCheckMenuItem()
+}
+
+// NewCheckMenuItem is the constructor for new CheckMenuItem instances.
+func NewCheckMenuItem() CheckMenuItem {
+	c := new(CCheckMenuItem)
+	c.Init()
+	return c
+}
+
+// NewCheckMenuItemWithLabel creates a new CheckMenuItem with a label.
+//
+// Parameters:
+//
+//	label	the string to use for the label
+func NewCheckMenuItemWithLabel(label string) CheckMenuItem {
+	c := new(CCheckMenuItem)
+	c.Init()
+	c.SetLabel(label)
+	return c
+}
+
+// NewCheckMenuItemWithMnemonic creates a new CheckMenuItem containing a label.
+// The label will be created using NewLabelWithMnemonic, so underscores in
+// label indicate the mnemonic for the menu item.
+//
+// Parameters:
+//
+//	label	the text of the button, with an underscore in front of the
+//	        mnemonic character
+func NewCheckMenuItemWithMnemonic(label string) CheckMenuItem {
+	c := new(CCheckMenuItem)
+	c.Init()
+	c.SetUseUnderline(true)
+	c.SetLabel(label)
+	return c
+}
+
+// Init initializes a CheckMenuItem object. This must be called at least once
+// to set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the CheckMenuItem instance. Init is used in the
+// NewCheckMenuItem constructor and only necessary when implementing a
+// derivative CheckMenuItem type.
+func (c *CCheckMenuItem) Init() (already bool) {
+	if c.InitTypeItem(TypeCheckMenuItem, c) {
+		return true
+	}
+	c.CMenuItem.Init()
+	_ = c.InstallBuildableProperty(PropertyActive, cdk.BoolProperty, true, false)
+	_ = c.InstallBuildableProperty(PropertyDrawAsRadio, cdk.BoolProperty, true, false)
+	_ = c.InstallBuildableProperty(PropertyInconsistent, cdk.BoolProperty, true, false)
+	return false
+}
+
+// Activate toggles the state of the CheckMenuItem and then emits the activate
+// signal.
+func (c *CCheckMenuItem) Activate() (value bool) {
+	if !c.IsVisible() || !c.IsSensitive() {
+		return false
+	}
+	c.SetActive(!c.GetActive())
+	return c.CMenuItem.Activate()
+}
+
+// GetActive returns whether the check menu item is active. See SetActive.
+func (c *CCheckMenuItem) GetActive() (value bool) {
+	var err error
+	if value, err = c.GetBoolProperty(PropertyActive); err != nil {
+		c.LogErr(err)
+	}
+	return
+}
+
+// SetActive updates the active state of the menu item's check box, emitting
+// the toggled signal if the state changed.
+//
+// Parameters:
+//
+//	isActive	boolean value indicating whether the check box is active
+func (c *CCheckMenuItem) SetActive(isActive bool) {
+	if c.GetActive() == isActive {
+		return
+	}
+	if err := c.SetBoolProperty(PropertyActive, isActive); err != nil {
+		c.LogErr(err)
+		return
+	}
+	c.Toggled()
+}
+
+// Toggled emits the toggled signal.
+func (c *CCheckMenuItem) Toggled() {
+	c.Emit(SignalToggled, c, c.GetActive())
+	c.Invalidate()
+}
+
+// GetInconsistent retrieves the value set by SetInconsistent.
+func (c *CCheckMenuItem) GetInconsistent() (value bool) {
+	var err error
+	if value, err = c.GetBoolProperty(PropertyInconsistent); err != nil {
+		c.LogErr(err)
+	}
+	return
+}
+
+// SetInconsistent updates the inconsistent state. If the user has selected a
+// range of elements (such as some text or spreadsheet cells) that are affected
+// by a boolean setting, and the current values in that range are inconsistent,
+// you may want to display the check in an "in between" state. This function
+// turns on "in between" display. Normally you would turn off the inconsistent
+// state again if the user explicitly selects a setting. This has to be done
+// manually, SetInconsistent only affects visual appearance, it doesn't affect
+// the semantics of the widget.
+//
+// Parameters:
+//
+//	setting	TRUE to display an "inconsistent" third state check
+func (c *CCheckMenuItem) SetInconsistent(setting bool) {
+	if err := c.SetBoolProperty(PropertyInconsistent, setting); err != nil {
+		c.LogErr(err)
+	}
+	c.Invalidate()
+}
+
+// GetDrawAsRadio returns whether check_menu_item looks like a RadioMenuItem.
+func (c *CCheckMenuItem) GetDrawAsRadio() (value bool) {
+	var err error
+	if value, err = c.GetBoolProperty(PropertyDrawAsRadio); err != nil {
+		c.LogErr(err)
+	}
+	return
+}
+
+// SetDrawAsRadio updates whether check_menu_item is drawn like a
+// RadioMenuItem.
+//
+// Parameters:
+//
+//	drawAsRadio	whether check_menu_item is drawn like a RadioMenuItem
+func (c *CCheckMenuItem) SetDrawAsRadio(drawAsRadio bool) {
+	if err := c.SetBoolProperty(PropertyDrawAsRadio, drawAsRadio); err != nil {
+		c.LogErr(err)
+	}
+	c.Invalidate()
+}
+
+// ToggleSizeRequest returns the number of columns needed for the check box
+// indicator and a space.
+func (c *CCheckMenuItem) ToggleSizeRequest() (requisition int) {
+	return 4
+}
+
+// If the check menu item should be active (checked).
+// Flags: Read / Write
+// Default value: FALSE
+// const PropertyActive cdk.Property = "active"
+
+// Whether the menu item looks like a radio menu item.
+// Flags: Read / Write
+// Default value: FALSE
+// const PropertyDrawAsRadio cdk.Property = "draw-as-radio"
+
+// Whether to display an "inconsistent" state.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyInconsistent cdk.Property = "inconsistent"
+
+// This signal is emitted when the state of the check box is changed. A signal
+// handler can use GetActive to discover the new state.
+// const SignalToggled cdk.Signal = "toggled"
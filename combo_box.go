@@ -0,0 +1,1019 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/go-curses/cdk/lib/ptypes"
+	cstrings "github.com/go-curses/cdk/lib/strings"
+	"github.com/go-curses/cdk/memphis"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeComboBox cdk.CTypeTag = "ctk-combo-box"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeComboBox, func() interface{} { return MakeComboBox() })
+}
+
+// comboBoxMaxPopupRows is the largest number of rows displayed by the popup
+// list before it scrolls.
+const comboBoxMaxPopupRows = 10
+
+// ComboBox Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Bin
+//	        +- ComboBox
+//	          +- ComboBoxText
+//	          +- ComboBoxEntry
+//
+// A ComboBox is a widget that allows the user to choose from a list of valid
+// choices. The ComboBox displays the selected choice along with an arrow and
+// when activated, pops up a list of the choices below the ComboBox, or above
+// it when there is not enough room below.
+//
+// The choices are the rows of a TreeModel, displaying the text of the
+// entry-text-column. New ComboBox instances use a ListStore with a single
+// string column, which is managed with the AppendText, PrependText,
+// InsertText and RemoveText convenience methods.
+//
+// The popup is opened by clicking on the ComboBox, by pressing Space, Enter,
+// F4 or Alt+Down while the ComboBox has focus, or by typing the start of a
+// choice. While the popup is open, all events of the Window of the ComboBox
+// are routed to the list, where typing searches for the first choice starting
+// with the characters typed. Enter or clicking on a choice selects it and
+// Escape or clicking outside the list closes the popup. When the popup is
+// closed, the Up, Down, Home and End keys change the choice directly.
+//
+// When the ComboBox has an entry (see ComboBoxEntry), the Entry child allows
+// free text to be typed and completes the text with the first matching choice
+// as it is typed, selecting the completed part so that typing continues to
+// replace it.
+type ComboBox interface {
+	Bin
+	Buildable
+
+	Init() (already bool)
+	Build(builder Builder, element *CBuilderElement) error
+	GetModel() (model TreeModel)
+	SetModel(model TreeModel)
+	GetEntryTextColumn() (column int)
+	SetEntryTextColumn(column int)
+	GetHasEntry() (hasEntry bool)
+	GetEntry() (entry Entry)
+	GetActive() (index int)
+	SetActive(index int)
+	GetActiveText() (text string)
+	AppendText(text string)
+	PrependText(text string)
+	InsertText(position int, text string)
+	RemoveText(position int)
+	Popup()
+	Popdown()
+	IsPoppedUp() (poppedUp bool)
+	CancelEvent()
+	GetSizeRequest() (width, height int)
+	GetWidgetAt(p *ptypes.Point2I) Widget
+
+	setHasEntry(hasEntry bool)
+}
+
+var _ ComboBox = (*CComboBox)(nil)
+
+// The CComboBox structure implements the ComboBox interface and is exported to
+// facilitate type embedding with custom implementations. No member variables
+// are exported as the interface methods are the only intended means of
+// interacting with ComboBox objects.
+type CComboBox struct {
+	CBin
+
+	model      TreeModel
+	entry      Entry
+	view       TreeView
+	window     Window
+	grabWindow Window
+	grabHandle string
+	poppedUp   bool
+	lastText   string
+	completing bool
+}
+
+// MakeComboBox is used by the Buildable system to construct a new ComboBox.
+func MakeComboBox() ComboBox {
+	return NewComboBox()
+}
+
+// NewComboBox is the constructor for new ComboBox instances. The ComboBox uses
+// a ListStore with a single string column for its choices, managed with the
+// AppendText, PrependText, InsertText and RemoveText methods.
+func NewComboBox() ComboBox {
+	c := new(CComboBox)
+	c.Init()
+	return c
+}
+
+// NewComboBoxWithModel creates a new ComboBox displaying the choices of the
+// given TreeModel.
+//
+// Parameters:
+//
+//	model	a TreeModel
+//	column	the model column to display the text of the choices from
+func NewComboBoxWithModel(model TreeModel, column int) ComboBox {
+	c := new(CComboBox)
+	c.Init()
+	c.SetEntryTextColumn(column)
+	c.SetModel(model)
+	return c
+}
+
+// Init initializes a ComboBox object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the ComboBox instance. Init is used in the
+// NewComboBox constructor and only necessary when implementing a derivative
+// ComboBox type.
+func (c *CComboBox) Init() (already bool) {
+	if c.InitTypeItem(TypeComboBox, c) {
+		return true
+	}
+	c.CBin.Init()
+	c.flags = enums.NULL_WIDGET_FLAG
+	c.SetFlags(enums.SENSITIVE | enums.PARENT_SENSITIVE | enums.CAN_FOCUS | enums.APP_PAINTABLE)
+	_ = c.InstallBuildableProperty(PropertyActive, cdk.IntProperty, true, -1)
+	_ = c.InstallBuildableProperty(PropertyHasEntry, cdk.BoolProperty, true, false)
+	_ = c.InstallBuildableProperty(PropertyEntryTextColumn, cdk.IntProperty, true, 0)
+	c.grabHandle = fmt.Sprintf("%v-%v", ComboBoxGrabHandle, c.ObjectID())
+	c.poppedUp = false
+	c.entry = nil
+	c.window = nil
+
+	c.view = NewTreeView()
+	c.view.SetHeadersVisible(false)
+	c.view.Show()
+	c.view.Connect(SignalRowActivated, c.grabHandle, c.rowActivated)
+	c.SetModel(NewListStore(cdk.StringProperty))
+
+	c.Connect(SignalCdkEvent, ComboBoxEventHandle, c.event)
+	c.Connect(SignalResize, ComboBoxResizeHandle, c.resize)
+	c.Connect(SignalDraw, ComboBoxDrawHandle, c.draw)
+	return false
+}
+
+// Build provides customizations to the Buildable system for ComboBox Widgets.
+// The choices of a GtkComboBoxText are given with an <items> element
+// containing an <item> element per choice and a ListStore can be given with
+// the "model" property. The "has-entry" property adds an Entry to the
+// ComboBox.
+func (c *CComboBox) Build(builder Builder, element *CBuilderElement) error {
+	c.Freeze()
+	defer c.Thaw()
+	if name, ok := element.Attributes["id"]; ok {
+		c.SetName(name)
+	}
+	if v, ok := element.Properties[PropertyHasEntry.String()]; ok && cstrings.IsTrue(v) {
+		c.setHasEntry(true)
+	}
+	if v, ok := element.Properties[PropertyEntryTextColumn.String()]; ok {
+		if column, err := strconv.Atoi(v); err != nil {
+			c.LogErr(err)
+		} else {
+			c.SetEntryTextColumn(column)
+		}
+	}
+	if v, ok := element.Properties[PropertyModel.String()]; ok {
+		if model, ok := builder.GetWidget(v).(TreeModel); ok {
+			c.SetModel(model)
+		} else {
+			c.LogError("model not found or not a TreeModel: %v", v)
+		}
+	}
+	for _, custom := range element.Custom {
+		if custom.TagName != "items" {
+			continue
+		}
+		for _, item := range custom.Children {
+			if item.TagName == "item" {
+				c.AppendText(strings.TrimSpace(item.Content))
+			}
+		}
+	}
+	for k, v := range element.Properties {
+		switch cdk.Property(k) {
+		case PropertyHasEntry, PropertyEntryTextColumn, PropertyModel:
+		case PropertyActive:
+			if index, err := strconv.Atoi(v); err != nil {
+				c.LogErr(err)
+			} else {
+				c.SetActive(index)
+			}
+		default:
+			element.ApplyProperty(k, v)
+		}
+	}
+	element.ApplySignals()
+	return nil
+}
+
+// GetModel returns the TreeModel providing the choices of the ComboBox.
+//
+// Locking: read
+func (c *CComboBox) GetModel() (model TreeModel) {
+	c.RLock()
+	defer c.RUnlock()
+	return c.model
+}
+
+// SetModel sets the TreeModel providing the choices of the ComboBox, unsetting
+// the active choice.
+//
+// Parameters:
+//
+//	model	the TreeModel to use
+func (c *CComboBox) SetModel(model TreeModel) {
+	c.Lock()
+	c.model = model
+	c.Unlock()
+	if err := c.SetIntProperty(PropertyActive, -1); err != nil {
+		c.LogErr(err)
+	}
+	c.updateView()
+	c.Resize()
+}
+
+// GetEntryTextColumn returns the model column the text of the choices is
+// displayed from.
+// See: SetEntryTextColumn()
+func (c *CComboBox) GetEntryTextColumn() (column int) {
+	var err error
+	if column, err = c.GetIntProperty(PropertyEntryTextColumn); err != nil {
+		c.LogErr(err)
+	}
+	return
+}
+
+// SetEntryTextColumn sets the model column the text of the choices is
+// displayed from, which must be a string column.
+//
+// Parameters:
+//
+//	column	a column of the model
+func (c *CComboBox) SetEntryTextColumn(column int) {
+	if err := c.SetIntProperty(PropertyEntryTextColumn, column); err != nil {
+		c.LogErr(err)
+	}
+	c.updateView()
+	c.Resize()
+}
+
+// GetHasEntry returns TRUE if the ComboBox has an Entry for typing free text.
+func (c *CComboBox) GetHasEntry() (hasEntry bool) {
+	var err error
+	if hasEntry, err = c.GetBoolProperty(PropertyHasEntry); err != nil {
+		c.LogErr(err)
+	}
+	return
+}
+
+// GetEntry returns the Entry of the ComboBox, or nil if the ComboBox does not
+// have an entry.
+//
+// Locking: read
+func (c *CComboBox) GetEntry() (entry Entry) {
+	c.RLock()
+	defer c.RUnlock()
+	return c.entry
+}
+
+// GetActive returns the index of the active choice, or -1 if there is no
+// active choice.
+// See: SetActive()
+func (c *CComboBox) GetActive() (index int) {
+	var err error
+	if index, err = c.GetIntProperty(PropertyActive); err != nil {
+		c.LogErr(err)
+		return -1
+	}
+	if index >= c.getNItems() {
+		index = -1
+	}
+	return
+}
+
+// SetActive sets the active choice of the ComboBox. When the ComboBox has an
+// entry, the text of the Entry is updated to the text of the choice.
+//
+// Parameters:
+//
+//	index	the index of the choice to make active, or -1 for no active choice
+//
+// Emits: SignalChanged, Argv=[ComboBox instance]
+func (c *CComboBox) SetActive(index int) {
+	if index < -1 || index >= c.getNItems() {
+		c.LogError("active index out of range: %v", index)
+		return
+	}
+	if index == c.GetActive() {
+		return
+	}
+	if err := c.SetIntProperty(PropertyActive, index); err != nil {
+		c.LogErr(err)
+		return
+	}
+	if entry := c.GetEntry(); entry != nil && index > -1 {
+		c.setEntryText(c.getItemText(index))
+	}
+	c.Emit(SignalChanged, c)
+	c.Invalidate()
+}
+
+// GetActiveText returns the text of the active choice, or the text of the
+// Entry when the ComboBox has an entry.
+func (c *CComboBox) GetActiveText() (text string) {
+	if entry := c.GetEntry(); entry != nil {
+		return entry.GetText()
+	}
+	if index := c.GetActive(); index > -1 {
+		text = c.getItemText(index)
+	}
+	return
+}
+
+// AppendText appends a choice to the end of the list of choices. This can only
+// be used with ComboBox instances using a ListStore.
+//
+// Parameters:
+//
+//	text	the text of the choice
+func (c *CComboBox) AppendText(text string) {
+	c.InsertText(c.getNItems(), text)
+}
+
+// PrependText prepends a choice to the start of the list of choices. This can
+// only be used with ComboBox instances using a ListStore.
+//
+// Parameters:
+//
+//	text	the text of the choice
+func (c *CComboBox) PrependText(text string) {
+	c.InsertText(0, text)
+}
+
+// InsertText inserts a choice at the given position in the list of choices.
+// This can only be used with ComboBox instances using a ListStore.
+//
+// Parameters:
+//
+//	position	the index to insert the choice at
+//	text	the text of the choice
+func (c *CComboBox) InsertText(position int, text string) {
+	store, ok := c.GetModel().(ListStore)
+	if !ok {
+		c.LogError("text choices require a ListStore model")
+		return
+	}
+	active := c.GetActive()
+	if err := store.SetValue(store.Insert(position), c.GetEntryTextColumn(), text); err != nil {
+		c.LogErr(err)
+	}
+	if active > -1 && position <= active {
+		if err := c.SetIntProperty(PropertyActive, active+1); err != nil {
+			c.LogErr(err)
+		}
+	}
+	c.Resize()
+}
+
+// RemoveText removes the choice at the given position from the list of
+// choices. This can only be used with ComboBox instances using a ListStore.
+//
+// Parameters:
+//
+//	position	the index of the choice to remove
+//
+// Emits: SignalChanged, Argv=[ComboBox instance]
+func (c *CComboBox) RemoveText(position int) {
+	store, ok := c.GetModel().(ListStore)
+	if !ok {
+		c.LogError("text choices require a ListStore model")
+		return
+	}
+	iter, ok := store.IterNthChild(nil, position)
+	if !ok {
+		return
+	}
+	active := c.GetActive()
+	store.Remove(iter)
+	switch {
+	case position == active:
+		if err := c.SetIntProperty(PropertyActive, -1); err != nil {
+			c.LogErr(err)
+		}
+		c.Emit(SignalChanged, c)
+	case position < active:
+		if err := c.SetIntProperty(PropertyActive, active-1); err != nil {
+			c.LogErr(err)
+		}
+	}
+	c.Resize()
+}
+
+// Popup displays the list of choices below the ComboBox, or above it when
+// there is not enough room below, and grabs the events of the Window of the
+// ComboBox. The active choice, if any, is selected within the list.
+//
+// Emits: SignalPopup, Argv=[ComboBox instance]
+func (c *CComboBox) Popup() {
+	if c.IsPoppedUp() || c.getNItems() == 0 {
+		return
+	}
+	if f := c.Emit(SignalPopup, c); f == cenums.EVENT_STOP {
+		return
+	}
+	window := c.getPopupWindow()
+	parent := c.GetWindow()
+	c.Lock()
+	c.poppedUp = true
+	c.grabWindow = parent
+	c.Unlock()
+	if parent != nil {
+		parent.Connect(SignalCdkEvent, c.grabHandle, c.grabEvent)
+	}
+	active := c.GetActive()
+	if active < 0 {
+		active = 0
+	}
+	c.reposition()
+	window.Show()
+	c.view.SetCursor(NewTreePathFromIndices(active), nil)
+	c.view.GrabFocus()
+	c.view.Invalidate()
+	c.Invalidate()
+	menuRequestDraw()
+}
+
+// Popdown hides the list of choices and releases the events of the Window of
+// the ComboBox.
+//
+// Emits: SignalPopdown, Argv=[ComboBox instance]
+func (c *CComboBox) Popdown() {
+	c.Lock()
+	poppedUp := c.poppedUp
+	window, parent := c.window, c.grabWindow
+	c.poppedUp = false
+	c.grabWindow = nil
+	c.Unlock()
+	if !poppedUp {
+		return
+	}
+	if parent != nil {
+		_ = parent.Disconnect(SignalCdkEvent, c.grabHandle)
+	}
+	if window != nil {
+		window.Hide()
+	}
+	c.Emit(SignalPopdown, c)
+	c.Invalidate()
+	menuRequestDraw()
+}
+
+// IsPoppedUp returns TRUE if the list of choices is currently displayed.
+//
+// Locking: read
+func (c *CComboBox) IsPoppedUp() (poppedUp bool) {
+	c.RLock()
+	defer c.RUnlock()
+	return c.poppedUp
+}
+
+// CancelEvent emits a cancel-event signal and if the signal handlers all return
+// EVENT_PASS, then closes the list of choices.
+func (c *CComboBox) CancelEvent() {
+	if f := c.Emit(SignalCancelEvent, c); f == cenums.EVENT_PASS {
+		c.Popdown()
+	}
+}
+
+// GetSizeRequest returns the requested size of the ComboBox, which is wide
+// enough to display the longest choice along with the arrow.
+func (c *CComboBox) GetSizeRequest() (width, height int) {
+	size := ptypes.NewRectangle(c.CWidget.GetSizeRequest())
+	if size.W <= -1 {
+		size.W = c.getItemsWidth() + 1
+		if entry := c.GetEntry(); entry != nil {
+			if ew, _ := entry.GetSizeRequest(); ew+1 > size.W {
+				size.W = ew + 1
+			}
+		}
+	}
+	if size.H <= -1 {
+		size.H = 1
+	}
+	size.Floor(2, 1)
+	return size.W, size.H
+}
+
+// GetWidgetAt returns the Entry of the ComboBox if it has one and the given
+// point is within it, or the ComboBox itself.
+func (c *CComboBox) GetWidgetAt(p *ptypes.Point2I) Widget {
+	if !c.HasPoint(p) || !c.IsVisible() {
+		return nil
+	}
+	if entry := c.GetEntry(); entry != nil && entry.IsVisible() && entry.HasPoint(p) {
+		return entry
+	}
+	self, _ := c.Self().(Widget)
+	return self
+}
+
+// setHasEntry adds an Entry to the ComboBox, used by ComboBoxEntry and the
+// Buildable system.
+func (c *CComboBox) setHasEntry(hasEntry bool) {
+	if !hasEntry || c.GetEntry() != nil {
+		return
+	}
+	if err := c.SetBoolProperty(PropertyHasEntry, true); err != nil {
+		c.LogErr(err)
+	}
+	entry := NewEntry("")
+	entry.SetSingleLineMode(true)
+	entry.SetSelectable(true)
+	entry.Show()
+	entry.Connect(SignalChangedText, c.grabHandle, c.entryChanged)
+	entry.Connect(SignalCdkEvent, c.grabHandle, c.entryEvent)
+	c.Lock()
+	c.entry = entry
+	c.Unlock()
+	// the entry takes the focus instead of the combo box itself
+	c.UnsetFlags(enums.CAN_FOCUS)
+	c.CBin.Add(entry)
+	c.Resize()
+}
+
+func (c *CComboBox) getNItems() (count int) {
+	if model := c.GetModel(); model != nil {
+		count = model.IterNChildren(nil)
+	}
+	return
+}
+
+func (c *CComboBox) getItemText(index int) (text string) {
+	if model := c.GetModel(); model != nil {
+		if iter, ok := model.IterNthChild(nil, index); ok {
+			if v, ok := model.GetValue(iter, c.GetEntryTextColumn()).(string); ok {
+				text = v
+			}
+		}
+	}
+	return
+}
+
+func (c *CComboBox) getItemsWidth() (width int) {
+	for i := 0; i < c.getNItems(); i++ {
+		if w := runewidth.StringWidth(c.getItemText(i)); w > width {
+			width = w
+		}
+	}
+	return
+}
+
+// updateView sets the model of the popup list and replaces its column with one
+// displaying the entry-text-column.
+func (c *CComboBox) updateView() {
+	for _, column := range c.view.GetColumns() {
+		c.view.RemoveColumn(column)
+	}
+	c.view.SetModel(c.GetModel())
+	column := NewTreeViewColumnWithAttributes("", TreeViewColumnAttributeText, c.GetEntryTextColumn())
+	column.SetExpand(true)
+	c.view.AppendColumn(column)
+	c.view.SetSearchColumn(c.GetEntryTextColumn())
+}
+
+// setEntryText updates the text of the Entry without completing it.
+func (c *CComboBox) setEntryText(text string) {
+	if entry := c.GetEntry(); entry != nil {
+		c.Lock()
+		c.completing = true
+		c.lastText = text
+		c.Unlock()
+		entry.SetText(text)
+		entry.SetPosition(utf8.RuneCountInString(text))
+		c.Lock()
+		c.completing = false
+		c.Unlock()
+	}
+}
+
+// getPopupWindow returns the popup Window the list of choices is displayed
+// within, creating it if necessary.
+func (c *CComboBox) getPopupWindow() (window Window) {
+	c.RLock()
+	window = c.window
+	c.RUnlock()
+	if window == nil {
+		window = NewWindow()
+		window.SetWindowType(cenums.WINDOW_POPUP)
+		window.SetFlags(enums.TOPLEVEL)
+		window.SetDecorated(false)
+		window.SetTheme(c.GetTheme())
+		c.Lock()
+		c.window = window
+		c.Unlock()
+		window.GetVBox().PackStart(c.view, true, true, 0)
+	}
+	return
+}
+
+// reposition moves the popup Window below or above the ComboBox, within the
+// bounds of the screen, sized to display as many choices as possible up to
+// comboBoxMaxPopupRows.
+func (c *CComboBox) reposition() {
+	window := c.getPopupWindow()
+	bounds := c.getScreenBounds()
+	origin := c.GetOrigin()
+	alloc := c.GetAllocation()
+	w, h := c.getItemsWidth(), c.getNItems()
+	if w < alloc.W {
+		w = alloc.W
+	}
+	if h > comboBoxMaxPopupRows {
+		h = comboBoxMaxPopupRows
+	}
+	x, y := origin.X, origin.Y+alloc.H
+	if bounds.H > 0 {
+		below := bounds.Y + bounds.H - y
+		above := origin.Y - bounds.Y
+		if h > below && above > below {
+			if h > above {
+				h = above
+			}
+			y = origin.Y - h
+		} else if h > below {
+			h = below
+		}
+	}
+	if bounds.W > 0 {
+		if w > bounds.W {
+			w = bounds.W
+		}
+		if x+w > bounds.X+bounds.W {
+			x = bounds.X + bounds.W - w
+		}
+		if x < bounds.X {
+			x = bounds.X
+		}
+	}
+	window.Move(x, y)
+	window.SetAllocation(ptypes.MakeRectangle(w, h))
+	window.Resize()
+}
+
+// getScreenBounds returns the region the popup must fit within, which is the
+// whole screen when the display is running or the region of the Window of the
+// ComboBox otherwise.
+func (c *CComboBox) getScreenBounds() (bounds ptypes.Region) {
+	if display := cdk.GetDefaultDisplay(); display != nil && display.IsRunning() {
+		alloc := ptypes.MakeRectangle(display.Screen().Size())
+		return ptypes.MakeRegion(0, 0, alloc.W, alloc.H)
+	}
+	if window := c.GetWindow(); window != nil {
+		origin := window.GetOrigin()
+		alloc := window.GetAllocation()
+		return ptypes.MakeRegion(origin.X, origin.Y, alloc.W, alloc.H)
+	}
+	return
+}
+
+// grabEvent is connected to the cdk-event signal of the Window of the ComboBox
+// while the list of choices is popped up, routing all events to the list.
+func (c *CComboBox) grabEvent(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if _, event, ok := ArgvSignalEvent(argv...); ok {
+		switch evt := event.(type) {
+		case *cdk.EventKey:
+			if evt.Key() == cdk.KeyEscape {
+				c.Popdown()
+				return cenums.EVENT_STOP
+			}
+			c.view.ProcessEvent(evt)
+			menuRequestDraw()
+			return cenums.EVENT_STOP
+		case *cdk.EventMouse:
+			if menuRegionHasPoint(c.view, *ptypes.NewPoint2I(evt.Position())) {
+				c.view.ProcessEvent(evt)
+				if evt.State() == cdk.BUTTON_PRESS && evt.Button().Has(cdk.Button1) {
+					if cursor, _ := c.view.GetCursor(); cursor != nil {
+						c.choose(cursor)
+					}
+				}
+				menuRequestDraw()
+				return cenums.EVENT_STOP
+			}
+			switch evt.State() {
+			case cdk.BUTTON_PRESS, cdk.DRAG_START:
+				c.Popdown()
+			}
+			return cenums.EVENT_STOP
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+// choose makes the choice at the given path active and closes the popup.
+func (c *CComboBox) choose(path TreePath) {
+	if indices := path.GetIndices(); len(indices) > 0 {
+		c.Popdown()
+		if index := indices[0]; index == c.GetActive() {
+			// reset the entry text even when the choice is unchanged
+			if entry := c.GetEntry(); entry != nil {
+				c.setEntryText(c.getItemText(index))
+			}
+		} else {
+			c.SetActive(index)
+		}
+	}
+}
+
+func (c *CComboBox) rowActivated(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if len(argv) > 1 {
+		if path, ok := argv[1].(TreePath); ok && c.IsPoppedUp() {
+			c.choose(path)
+			return cenums.EVENT_STOP
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+// entryChanged updates the active choice to match the text typed into the
+// Entry and completes the text with the first choice starting with it.
+func (c *CComboBox) entryChanged(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	entry := c.GetEntry()
+	c.RLock()
+	completing, lastText := c.completing, c.lastText
+	c.RUnlock()
+	if entry == nil || completing {
+		return cenums.EVENT_PASS
+	}
+	text := entry.GetText()
+	c.Lock()
+	c.lastText = text
+	c.Unlock()
+	typed := utf8.RuneCountInString(text)
+	index := -1
+	if typed > utf8.RuneCountInString(lastText) && entry.GetPosition() >= typed-1 {
+		lower := strings.ToLower(text)
+		for i := 0; i < c.getNItems(); i++ {
+			item := c.getItemText(i)
+			if strings.HasPrefix(strings.ToLower(item), lower) {
+				completed := text + string([]rune(item)[typed:])
+				c.setEntryText(completed)
+				entry.SetPosition(typed)
+				entry.SelectRegion(typed, utf8.RuneCountInString(completed))
+				if completed == item {
+					index = i
+				}
+				break
+			}
+		}
+	}
+	if index < 0 {
+		for i := 0; i < c.getNItems(); i++ {
+			if c.getItemText(i) == entry.GetText() {
+				index = i
+				break
+			}
+		}
+	}
+	if index != c.GetActive() {
+		if err := c.SetIntProperty(PropertyActive, index); err != nil {
+			c.LogErr(err)
+		}
+		c.Emit(SignalChanged, c)
+	}
+	return cenums.EVENT_PASS
+}
+
+// entryEvent pops up the list of choices when Down, Alt+Down or F4 are pressed
+// within the Entry.
+func (c *CComboBox) entryEvent(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if _, event, ok := ArgvSignalEvent(argv...); ok {
+		if evt, ok := event.(*cdk.EventKey); ok && c.IsSensitive() {
+			if entry := c.GetEntry(); entry != nil && entry.HasFocus() {
+				switch evt.Key() {
+				case cdk.KeyDown, cdk.KeyF4:
+					c.Popup()
+					return cenums.EVENT_STOP
+				}
+			}
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (c *CComboBox) processKeyEvent(e *cdk.EventKey) cenums.EventFlag {
+	if c.GetEntry() != nil || !c.HasFocus() {
+		return cenums.EVENT_PASS
+	}
+	active, count := c.GetActive(), c.getNItems()
+	switch e.Key() {
+	case cdk.KeyDown:
+		if e.Modifiers().Has(cdk.ModAlt) {
+			c.Popup()
+		} else if active+1 < count {
+			c.SetActive(active + 1)
+		}
+		return cenums.EVENT_STOP
+	case cdk.KeyUp:
+		if active > 0 {
+			c.SetActive(active - 1)
+		}
+		return cenums.EVENT_STOP
+	case cdk.KeyHome:
+		if count > 0 {
+			c.SetActive(0)
+		}
+		return cenums.EVENT_STOP
+	case cdk.KeyEnd:
+		if count > 0 {
+			c.SetActive(count - 1)
+		}
+		return cenums.EVENT_STOP
+	case cdk.KeyF4:
+		c.Popup()
+		return cenums.EVENT_STOP
+	}
+	switch r := e.Rune(); {
+	case r == 10, r == 13, r == ' ':
+		c.Popup()
+		return cenums.EVENT_STOP
+	case e.Key() == cdk.KeyRune && unicode.IsPrint(r) && !e.Modifiers().Has(cdk.ModCtrl):
+		// start searching the list with the character typed
+		c.Popup()
+		if c.IsPoppedUp() {
+			c.view.ProcessEvent(e)
+		}
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+func (c *CComboBox) processMouseEvent(e *cdk.EventMouse) cenums.EventFlag {
+	pos := ptypes.NewPoint2I(e.Position())
+	if !c.HasPoint(pos) || e.State() != cdk.BUTTON_PRESS || !e.Button().Has(cdk.Button1) {
+		return cenums.EVENT_PASS
+	}
+	if entry := c.GetEntry(); entry != nil {
+		if entry.HasPoint(pos) {
+			return cenums.EVENT_PASS
+		}
+		if !entry.HasFocus() && entry.CanFocus() {
+			entry.GrabFocus()
+		}
+	} else if !c.HasFocus() && c.CanFocus() {
+		c.GrabFocus()
+	}
+	c.Popup()
+	return cenums.EVENT_STOP
+}
+
+func (c *CComboBox) event(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if !c.IsSensitive() {
+		return cenums.EVENT_PASS
+	}
+	if evt, ok := argv[1].(cdk.Event); ok {
+		switch e := evt.(type) {
+		case *cdk.EventKey:
+			return c.processKeyEvent(e)
+		case *cdk.EventMouse:
+			return c.processMouseEvent(e)
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (c *CComboBox) resize(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	alloc := c.GetAllocation()
+	origin := c.GetOrigin()
+	if entry := c.GetEntry(); entry != nil {
+		if alloc.W <= 1 || alloc.H <= 0 {
+			entry.SetAllocation(ptypes.MakeRectangle(0, 0))
+		} else {
+			entry.SetOrigin(origin.X, origin.Y)
+			entry.SetAllocation(ptypes.MakeRectangle(alloc.W-1, alloc.H))
+		}
+		entry.Resize()
+	}
+	if c.IsPoppedUp() {
+		c.reposition()
+	}
+	c.Invalidate()
+	return cenums.EVENT_STOP
+}
+
+func (c *CComboBox) draw(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if surface, ok := argv[1].(*memphis.CSurface); ok {
+		alloc := c.GetAllocation()
+		if !c.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+			c.LogTrace("not visible, zero width or zero height")
+			return cenums.EVENT_PASS
+		}
+		theme := c.GetThemeRequest()
+		style := theme.Content.Normal
+		if !c.IsSensitive() {
+			style = theme.Content.Insensitive
+		}
+		surface.Fill(theme)
+		y := (alloc.H - 1) / 2
+
+		if entry := c.GetEntry(); entry != nil {
+			if entry.IsVisible() {
+				entry.Draw()
+				entry.LockDraw()
+				if err := surface.Composite(entry.ObjectID()); err != nil {
+					c.LogError("composite error: %v", err)
+				}
+				entry.UnlockDraw()
+			}
+		} else {
+			x := 0
+			for _, r := range c.GetActiveText() {
+				w := runewidth.RuneWidth(r)
+				if x+w > alloc.W-1 {
+					break
+				}
+				_ = surface.SetRune(x, y, r, style)
+				x += w
+			}
+		}
+		arrow := theme.Content.ArrowRunes.Down
+		if c.IsPoppedUp() {
+			arrow = theme.Content.ArrowRunes.Up
+		}
+		_ = surface.SetRune(alloc.W-1, y, arrow, style)
+
+		if debug, _ := c.GetBoolProperty(cdk.PropertyDebug); debug {
+			surface.DebugBox(paint.ColorSilver, c.ObjectInfo())
+		}
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+// Whether the combo box has an entry.
+// Flags: Read / Write / Construct Only
+// Default value: FALSE
+const PropertyHasEntry cdk.Property = "has-entry"
+
+// The column in the combo box's model to associate with strings from the
+// entry if the combo was created with has-entry = TRUE.
+// Flags: Read / Write
+// Allowed values: >= -1
+// Default value: 0
+const PropertyEntryTextColumn cdk.Property = "entry-text-column"
+
+// The ::popdown signal is emitted when the popup list of the combo box is
+// closed.
+// Listener function arguments:
+//
+//	comboBox ComboBox	the ComboBox instance
+const SignalPopdown cdk.Signal = "popdown"
+
+// The ::popup signal is emitted before the popup list of the combo box is
+// displayed. Returning EVENT_STOP prevents the popup from being displayed.
+// Listener function arguments:
+//
+//	comboBox ComboBox	the ComboBox instance
+const SignalPopup cdk.Signal = "popup"
+
+const ComboBoxGrabHandle = "combo-box-grab-handler"
+
+const ComboBoxEventHandle = "combo-box-event-handler"
+
+const ComboBoxResizeHandle = "combo-box-resize-handler"
+
+const ComboBoxDrawHandle = "combo-box-draw-handler"
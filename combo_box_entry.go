@@ -0,0 +1,95 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"github.com/go-curses/cdk"
+)
+
+const TypeComboBoxEntry cdk.CTypeTag = "ctk-combo-box-entry"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeComboBoxEntry, func() interface{} { return MakeComboBoxEntry() })
+}
+
+// ComboBoxEntry Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Bin
+//	        +- ComboBox
+//	          +- ComboBoxEntry
+//
+// A ComboBoxEntry is a ComboBox with an Entry child for typing free text, which
+// is completed with the first choice starting with the text typed. The choices
+// are managed with the AppendText, PrependText, InsertText and RemoveText
+// methods and the text typed is returned by GetActiveText.
+type ComboBoxEntry interface {
+	ComboBox
+}
+
+var _ ComboBoxEntry = (*CComboBoxEntry)(nil)
+
+// The CComboBoxEntry structure implements the ComboBoxEntry interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with ComboBoxEntry objects.
+type CComboBoxEntry struct {
+	CComboBox
+}
+
+// MakeComboBoxEntry is used by the Buildable system to construct a new
+// ComboBoxEntry.
+func MakeComboBoxEntry() ComboBoxEntry {
+	return NewComboBoxEntry()
+}
+
+// NewComboBoxEntry is the constructor for new ComboBoxEntry instances.
+func NewComboBoxEntry() ComboBoxEntry {
+	c := new(CComboBoxEntry)
+	c.Init()
+	return c
+}
+
+// NewComboBoxEntryWithModel creates a new ComboBoxEntry completing the text
+// typed with the choices of the given TreeModel.
+//
+// Parameters:
+//
+//	model	a TreeModel
+//	column	the model column to display the text of the choices from
+func NewComboBoxEntryWithModel(model TreeModel, column int) ComboBoxEntry {
+	c := new(CComboBoxEntry)
+	c.Init()
+	c.SetEntryTextColumn(column)
+	c.SetModel(model)
+	return c
+}
+
+// Init initializes a ComboBoxEntry object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the ComboBoxEntry instance. Init is used in the
+// NewComboBoxEntry constructor and only necessary when implementing a
+// derivative ComboBoxEntry type.
+func (c *CComboBoxEntry) Init() (already bool) {
+	if c.InitTypeItem(TypeComboBoxEntry, c) {
+		return true
+	}
+	c.CComboBox.Init()
+	c.setHasEntry(true)
+	return false
+}
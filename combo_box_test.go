@@ -0,0 +1,201 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"testing"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/ptypes"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestComboBox(t *testing.T) {
+	Convey("Testing ComboBoxes", t, func() {
+		makeWindow := func(combo ComboBox) Window {
+			window := NewWindow()
+			window.SetDecorated(false)
+			window.Show()
+			window.SetAllocation(ptypes.MakeRectangle(20, 10))
+			combo.Show()
+			window.GetVBox().PackStart(combo, false, false, 0)
+			window.Resize()
+			return window
+		}
+
+		Convey("Basics", func() {
+			c := NewComboBoxText()
+			So(c, ShouldNotBeNil)
+			So(c.GetHasEntry(), ShouldEqual, false)
+			So(c.GetEntry(), ShouldBeNil)
+			So(c.GetActive(), ShouldEqual, -1)
+			So(c.GetActiveText(), ShouldEqual, "")
+			changed := 0
+			c.Connect(SignalChanged, "test-changed", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				changed += 1
+				return cenums.EVENT_PASS
+			})
+			c.AppendText("banana")
+			c.AppendText("cherry")
+			c.PrependText("apple")
+			c.InsertText(3, "date")
+			So(c.GetModel().IterNChildren(nil), ShouldEqual, 4)
+			w, h := c.GetSizeRequest()
+			So(w, ShouldEqual, 7)
+			So(h, ShouldEqual, 1)
+			c.SetActive(1)
+			So(c.GetActive(), ShouldEqual, 1)
+			So(c.GetActiveText(), ShouldEqual, "banana")
+			So(changed, ShouldEqual, 1)
+			c.SetActive(1)
+			So(changed, ShouldEqual, 1)
+			c.SetActive(9)
+			So(c.GetActive(), ShouldEqual, 1)
+			c.PrependText("apricot")
+			So(c.GetActiveText(), ShouldEqual, "banana")
+			c.RemoveText(0)
+			So(c.GetActiveText(), ShouldEqual, "banana")
+			c.RemoveText(1)
+			So(c.GetActive(), ShouldEqual, -1)
+			So(changed, ShouldEqual, 2)
+		})
+
+		Convey("Popup", func() {
+			c := NewComboBoxText()
+			for _, text := range []string{"red", "green", "blue", "black"} {
+				c.AppendText(text)
+			}
+			window := makeWindow(c)
+			c.GrabFocus()
+			So(c.HasFocus(), ShouldEqual, true)
+			So(c.GetAllocation().W, ShouldEqual, 20)
+
+			// closed, the arrow keys change the active item directly
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyDown, 0, cdk.ModNone))
+			So(c.GetActiveText(), ShouldEqual, "red")
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyEnd, 0, cdk.ModNone))
+			So(c.GetActiveText(), ShouldEqual, "black")
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyUp, 0, cdk.ModNone))
+			So(c.GetActiveText(), ShouldEqual, "blue")
+
+			// open, the list is placed below the combo box
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, ' ', cdk.ModNone))
+			So(c.IsPoppedUp(), ShouldEqual, true)
+			cc := c.(*CComboBoxText)
+			So(cc.view.GetOrigin(), ShouldResemble, ptypes.MakePoint2I(0, 1))
+			So(cc.view.GetAllocation().H, ShouldEqual, 4)
+			cursor, _ := cc.view.GetCursor()
+			So(cursor.GetIndices(), ShouldResemble, []int{2})
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyUp, 0, cdk.ModNone))
+			So(c.GetActiveText(), ShouldEqual, "blue")
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, rune(cdk.KeyEnter), cdk.ModNone))
+			So(c.IsPoppedUp(), ShouldEqual, false)
+			So(c.GetActiveText(), ShouldEqual, "green")
+
+			// type-ahead opens the list and searches it
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 'b', cdk.ModNone))
+			So(c.IsPoppedUp(), ShouldEqual, true)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 'l', cdk.ModNone))
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 'a', cdk.ModNone))
+			cursor, _ = cc.view.GetCursor()
+			So(cursor.GetIndices(), ShouldResemble, []int{3})
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyEscape, 0, cdk.ModNone))
+			So(c.IsPoppedUp(), ShouldEqual, false)
+			So(c.GetActiveText(), ShouldEqual, "green")
+
+			// clicking opens the list, clicking a row chooses it
+			window.ProcessEvent(cdk.NewEventMouse(5, 0, cdk.Button1, cdk.ModNone))
+			window.ProcessEvent(cdk.NewEventMouse(5, 0, cdk.ButtonNone, cdk.ModNone))
+			So(c.IsPoppedUp(), ShouldEqual, true)
+			window.ProcessEvent(cdk.NewEventMouse(2, 1, cdk.Button1, cdk.ModNone))
+			So(c.IsPoppedUp(), ShouldEqual, false)
+			So(c.GetActiveText(), ShouldEqual, "red")
+
+			// clicking outside closes the list
+			c.Popup()
+			So(c.IsPoppedUp(), ShouldEqual, true)
+			window.ProcessEvent(cdk.NewEventMouse(15, 8, cdk.Button1, cdk.ModNone))
+			So(c.IsPoppedUp(), ShouldEqual, false)
+			So(c.GetActiveText(), ShouldEqual, "red")
+		})
+
+		Convey("Entry", func() {
+			c := NewComboBoxEntry()
+			So(c.GetHasEntry(), ShouldEqual, true)
+			So(c.CanFocus(), ShouldEqual, false)
+			for _, text := range []string{"Monday", "Tuesday", "Wednesday"} {
+				c.AppendText(text)
+			}
+			window := makeWindow(c)
+			entry := c.GetEntry()
+			So(entry, ShouldNotBeNil)
+			So(entry.GetAllocation().W, ShouldEqual, 19)
+			entry.GrabFocus()
+			So(entry.HasFocus(), ShouldEqual, true)
+
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 't', cdk.ModNone))
+			So(entry.GetText(), ShouldEqual, "tuesday")
+			So(c.GetActive(), ShouldEqual, -1)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 'u', cdk.ModNone))
+			So(entry.GetText(), ShouldEqual, "tuesday")
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 'x', cdk.ModNone))
+			So(entry.GetText(), ShouldEqual, "tux")
+			So(c.GetActiveText(), ShouldEqual, "tux")
+
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyDown, 0, cdk.ModNone))
+			So(c.IsPoppedUp(), ShouldEqual, true)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyDown, 0, cdk.ModNone))
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, rune(cdk.KeyEnter), cdk.ModNone))
+			So(c.IsPoppedUp(), ShouldEqual, false)
+			So(c.GetActive(), ShouldEqual, 1)
+			So(entry.GetText(), ShouldEqual, "Tuesday")
+
+			c.SetActive(2)
+			So(c.GetActiveText(), ShouldEqual, "Wednesday")
+		})
+
+		Convey("Builder", func() {
+			builder := NewBuilder()
+			_, err := builder.LoadFromString(`<interface>
+  <object class="GtkComboBoxText" id="test-combo">
+    <property name="active">1</property>
+    <items>
+      <item>One</item>
+      <item translatable="yes">Two</item>
+      <item>Three</item>
+    </items>
+  </object>
+  <object class="GtkComboBoxText" id="test-combo-entry">
+    <property name="has_entry">True</property>
+    <items>
+      <item>Four</item>
+    </items>
+  </object>
+</interface>`)
+			So(err, ShouldBeNil)
+			c, ok := builder.GetWidget("test-combo").(ComboBox)
+			So(ok, ShouldEqual, true)
+			So(c.GetModel().IterNChildren(nil), ShouldEqual, 3)
+			So(c.GetActive(), ShouldEqual, 1)
+			So(c.GetActiveText(), ShouldEqual, "Two")
+			e, ok := builder.GetWidget("test-combo-entry").(ComboBox)
+			So(ok, ShouldEqual, true)
+			So(e.GetHasEntry(), ShouldEqual, true)
+			So(e.GetEntry(), ShouldNotBeNil)
+			So(e.GetModel().IterNChildren(nil), ShouldEqual, 1)
+		})
+	})
+}
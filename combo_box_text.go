@@ -0,0 +1,88 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"github.com/go-curses/cdk"
+)
+
+const TypeComboBoxText cdk.CTypeTag = "ctk-combo-box-text"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeComboBoxText, func() interface{} { return MakeComboBoxText() })
+}
+
+// ComboBoxText Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Bin
+//	        +- ComboBox
+//	          +- ComboBoxText
+//
+// A ComboBoxText is a ComboBox for choosing between a list of strings, managed
+// with the AppendText, PrependText, InsertText and RemoveText methods. Within
+// the Buildable system, the strings are given with an <items> element
+// containing an <item> element per string.
+type ComboBoxText interface {
+	ComboBox
+}
+
+var _ ComboBoxText = (*CComboBoxText)(nil)
+
+// The CComboBoxText structure implements the ComboBoxText interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with ComboBoxText objects.
+type CComboBoxText struct {
+	CComboBox
+}
+
+// MakeComboBoxText is used by the Buildable system to construct a new
+// ComboBoxText.
+func MakeComboBoxText() ComboBoxText {
+	return NewComboBoxText()
+}
+
+// NewComboBoxText is the constructor for new ComboBoxText instances.
+func NewComboBoxText() ComboBoxText {
+	c := new(CComboBoxText)
+	c.Init()
+	return c
+}
+
+// NewComboBoxTextWithEntry is the constructor for new ComboBoxText instances
+// with an Entry for typing free text.
+func NewComboBoxTextWithEntry() ComboBoxText {
+	c := new(CComboBoxText)
+	c.Init()
+	c.setHasEntry(true)
+	return c
+}
+
+// Init initializes a ComboBoxText object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the ComboBoxText instance. Init is used in the
+// NewComboBoxText constructor and only necessary when implementing a
+// derivative ComboBoxText type.
+func (c *CComboBoxText) Init() (already bool) {
+	if c.InitTypeItem(TypeComboBoxText, c) {
+		return true
+	}
+	c.CComboBox.Init()
+	return false
+}
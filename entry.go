@@ -178,6 +178,8 @@ type CEntry struct {
 
 	tid     uuid.UUID
 	tRegion ptypes.Region
+	// columns at the end of the line reserved by derived widgets
+	tReserved int
 
 	offset    *ptypes.Region
 	cursor    *ptypes.Point2I
@@ -237,6 +239,7 @@ func (l *CEntry) Init() (already bool) {
 	l.tBuffer = nil
 	l.tid, _ = uuid.NewV4()
 	l.tRegion = ptypes.MakeRegion(0, 0, 0, 0)
+	l.tReserved = 0
 	theme, _ := paint.GetTheme(EntryColorTheme)
 	if err := memphis.MakeSurface(l.tid, l.tRegion.Origin(), l.tRegion.Size(), theme.Content.Normal); err != nil {
 		l.LogErr(err)
@@ -792,6 +795,8 @@ func (l *CEntry) refreshTextBuffer() (err error) {
 
 	l.Lock()
 
+	alloc.W -= l.tReserved
+
 	posPoint := l.tProfile.GetPointFromPosition(pos)
 
 	// keep pos within alloc
@@ -859,6 +864,10 @@ func (l *CEntry) resize(data []interface{}, argv ...interface{}) cenums.EventFla
 	size.W = alloc.W - (xPad * 2)
 	size.H = alloc.H - (xPad * 2)
 
+	l.Lock()
+	size.W -= l.tReserved
+	l.Unlock()
+
 	if size.H < alloc.H {
 		delta := alloc.H - size.H
 		local.Y += int(float64(delta) * yAlign)
@@ -0,0 +1,727 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/go-curses/cdk/lib/ptypes"
+	cstrings "github.com/go-curses/cdk/lib/strings"
+	"github.com/go-curses/cdk/memphis"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeExpander cdk.CTypeTag = "ctk-expander"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeExpander, func() interface{} { return MakeExpander() })
+}
+
+// Expander Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Bin
+//	        +- Expander
+//
+// The Expander Widget allows the user to hide or show its child by clicking
+// on the expander header, or by pressing the space or enter keys when the
+// Expander has the focus. The header is a triangle glyph followed by a label
+// Widget, pointing right when collapsed and down when expanded.
+//
+// Normally you use an Expander as you would use any other descendant of Bin;
+// you create the child widget and use Add to add it to the Expander. When the
+// Expander is toggled, it will take care of showing and hiding the child
+// automatically and the parent Container is resized to make room for, or
+// reclaim the space of, the child.
+//
+// The label of the Expander supports mnemonics (see NewExpanderWithMnemonic)
+// and Tango markup (see SetUseMarkup).
+type Expander interface {
+	Bin
+	Buildable
+
+	Init() (already bool)
+	Build(builder Builder, element *CBuilderElement) error
+	SetWindow(w Window)
+	SetExpanded(expanded bool)
+	GetExpanded() (value bool)
+	SetSpacing(spacing int)
+	GetSpacing() (value int)
+	SetLabel(label string)
+	GetLabel() (value string)
+	SetUseUnderline(useUnderline bool)
+	GetUseUnderline() (value bool)
+	SetUseMarkup(useMarkup bool)
+	GetUseMarkup() (value bool)
+	SetLabelWidget(labelWidget Widget)
+	GetLabelWidget() (value Widget)
+	Activate() (value bool)
+	CancelEvent()
+	GetFocusChain() (focusableWidgets []Widget, explicitlySet bool)
+	GetWidgetAt(p *ptypes.Point2I) Widget
+	GetSizeRequest() (width, height int)
+}
+
+var _ Expander = (*CExpander)(nil)
+
+// The CExpander structure implements the Expander interface and is exported
+// to facilitate type embedding with custom implementations. No member variables
+// are exported as the interface methods are the only intended means of
+// interacting with Expander objects.
+type CExpander struct {
+	CBin
+}
+
+// MakeExpander is used by the Buildable system to construct a new Expander.
+func MakeExpander() Expander {
+	return NewExpander("")
+}
+
+// NewExpander is the constructor for new Expander instances, using the given
+// text for the label.
+//
+// Parameters:
+//
+//	label	the text of the label
+func NewExpander(label string) Expander {
+	e := new(CExpander)
+	e.Init()
+	e.SetLabelWidget(newExpanderLabel())
+	e.SetLabel(label)
+	return e
+}
+
+// NewExpanderWithMnemonic creates a new Expander using label as the text of the
+// label. If characters in label are preceded by an underscore, they are
+// underlined. If you need a literal underscore character in a label, use '__'
+// (two underscores). The first underlined character represents a keyboard
+// accelerator called a mnemonic. Pressing Alt and that key activates the
+// Expander.
+//
+// Parameters:
+//
+//	label	the text of the label with an underscore in front of the mnemonic
+//	        character
+func NewExpanderWithMnemonic(label string) Expander {
+	e := NewExpander(label)
+	e.SetUseUnderline(true)
+	return e
+}
+
+// NewExpanderWithWidget creates a new Expander using the given Widget as the
+// label instead of the default Label.
+func NewExpanderWithWidget(labelWidget Widget) Expander {
+	e := new(CExpander)
+	e.Init()
+	e.SetLabelWidget(labelWidget)
+	return e
+}
+
+func newExpanderLabel() Label {
+	label := NewLabel("")
+	label.SetSingleLineMode(true)
+	label.SetLineWrap(false)
+	label.SetLineWrapMode(cenums.WRAP_NONE)
+	label.SetJustify(cenums.JUSTIFY_LEFT)
+	label.SetAlignment(0.0, 0.5)
+	label.Show()
+	theme := label.GetTheme()
+	theme.Content.FillRune = paint.DefaultNilRune
+	theme.Border.FillRune = paint.DefaultNilRune
+	label.SetTheme(theme)
+	return label
+}
+
+// Init initializes an Expander object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the Expander instance. Init is used in the
+// NewExpander constructor and only necessary when implementing a derivative
+// Expander type.
+func (e *CExpander) Init() (already bool) {
+	if e.InitTypeItem(TypeExpander, e) {
+		return true
+	}
+	e.CBin.Init()
+	e.flags = enums.NULL_WIDGET_FLAG
+	e.SetFlags(enums.SENSITIVE | enums.PARENT_SENSITIVE | enums.CAN_FOCUS | enums.APP_PAINTABLE)
+	_ = e.InstallBuildableProperty(PropertyExpanded, cdk.BoolProperty, true, false)
+	_ = e.InstallBuildableProperty(PropertyLabel, cdk.StringProperty, true, "")
+	_ = e.InstallBuildableProperty(PropertyUseUnderline, cdk.BoolProperty, true, false)
+	_ = e.InstallBuildableProperty(PropertyUseMarkup, cdk.BoolProperty, true, false)
+	_ = e.InstallBuildableProperty(PropertySpacing, cdk.IntProperty, true, 0)
+	_ = e.InstallBuildableProperty(PropertyLabelWidget, cdk.StructProperty, true, nil)
+	e.Connect(SignalCdkEvent, ExpanderEventHandle, e.event)
+	e.Connect(SignalResize, ExpanderResizeHandle, e.resize)
+	e.Connect(SignalDraw, ExpanderDrawHandle, e.draw)
+	return false
+}
+
+// Build provides customizations to the Buildable system for Expander Widgets.
+// A child with a type attribute of "label" is used as the label widget.
+func (e *CExpander) Build(builder Builder, element *CBuilderElement) error {
+	e.Freeze()
+	defer e.Thaw()
+	if name, ok := element.Attributes["id"]; ok {
+		e.SetName(name)
+	}
+	for k, v := range element.Properties {
+		switch cdk.Property(k) {
+		case PropertyExpanded:
+			e.SetExpanded(cstrings.IsTrue(v))
+		case PropertyLabel:
+			e.SetLabel(v)
+		case PropertyUseUnderline:
+			e.SetUseUnderline(cstrings.IsTrue(v))
+		case PropertyUseMarkup:
+			e.SetUseMarkup(cstrings.IsTrue(v))
+		default:
+			element.ApplyProperty(k, v)
+		}
+	}
+	for _, child := range element.Children {
+		if newChild := builder.Build(child); newChild != nil {
+			child.Instance = newChild
+			newChildWidget, ok := newChild.(Widget)
+			if !ok {
+				e.LogError("new child object is not a Widget type: %v (%T)", newChild, newChild)
+				continue
+			}
+			newChildWidget.Show()
+			if child.Packing["type"] == "label" {
+				e.SetLabelWidget(newChildWidget)
+				continue
+			}
+			e.Add(newChildWidget)
+		}
+	}
+	element.ApplySignals()
+	return nil
+}
+
+// SetWindow updates the Window of the Expander, its label widget and child.
+func (e *CExpander) SetWindow(w Window) {
+	if widget := e.GetLabelWidget(); widget != nil {
+		WidgetRecurseSetWindow(widget, w)
+	}
+	e.CBin.SetWindow(w)
+}
+
+// SetExpanded updates the expanded state of the Expander, showing the child
+// if TRUE and hiding it otherwise. The parent of the Expander is resized so
+// that any sibling widgets are laid out again.
+//
+// Parameters:
+//
+//	expanded	whether the child widget is revealed
+//
+// Locking: write
+func (e *CExpander) SetExpanded(expanded bool) {
+	if e.GetExpanded() == expanded {
+		return
+	}
+	if err := e.SetBoolProperty(PropertyExpanded, expanded); err != nil {
+		e.LogErr(err)
+		return
+	}
+	if !expanded {
+		if window := e.GetWindow(); window != nil {
+			if focus := window.GetFocus(); focus != nil && focus.ObjectID() != e.ObjectID() && focus.IsAncestor(e) {
+				e.GrabFocus()
+			}
+		}
+	}
+	e.queueResize()
+}
+
+// GetExpanded returns TRUE if the child widget is revealed.
+// See: SetExpanded()
+//
+// Locking: read
+func (e *CExpander) GetExpanded() (value bool) {
+	var err error
+	if value, err = e.GetBoolProperty(PropertyExpanded); err != nil {
+		e.LogErr(err)
+	}
+	return
+}
+
+// SetSpacing updates the number of lines to place between the header and the
+// child when expanded.
+//
+// Parameters:
+//
+//	spacing	distance between the expander and child in lines
+//
+// Locking: write
+func (e *CExpander) SetSpacing(spacing int) {
+	if spacing < 0 {
+		spacing = 0
+	}
+	if err := e.SetIntProperty(PropertySpacing, spacing); err != nil {
+		e.LogErr(err)
+	} else {
+		e.queueResize()
+	}
+}
+
+// GetSpacing returns the value set by SetSpacing.
+//
+// Locking: read
+func (e *CExpander) GetSpacing() (value int) {
+	var err error
+	if value, err = e.GetIntProperty(PropertySpacing); err != nil {
+		e.LogErr(err)
+	}
+	return
+}
+
+// SetLabel updates the text of the label of the Expander. If the label widget
+// is not a Label, only the label property is updated.
+//
+// Parameters:
+//
+//	label	a string
+//
+// Locking: write
+func (e *CExpander) SetLabel(label string) {
+	if err := e.SetStringProperty(PropertyLabel, label); err != nil {
+		e.LogErr(err)
+		return
+	}
+	if w := e.GetLabelWidget(); w != nil {
+		if lw, ok := w.Self().(Label); ok {
+			lw.SetLabel(label)
+		}
+	}
+	e.queueResize()
+}
+
+// GetLabel returns the text of the label of the Expander, including any
+// mnemonic underscores or markup.
+// See: SetLabel()
+//
+// Locking: read
+func (e *CExpander) GetLabel() (value string) {
+	var err error
+	if value, err = e.GetStringProperty(PropertyLabel); err != nil {
+		e.LogErr(err)
+	}
+	return
+}
+
+// SetUseUnderline updates whether an underscore in the text of the label
+// indicates the next character should be used for the mnemonic accelerator
+// key.
+//
+// Parameters:
+//
+//	useUnderline	TRUE if underlines in the text indicate mnemonics
+//
+// Locking: write
+func (e *CExpander) SetUseUnderline(useUnderline bool) {
+	if err := e.SetBoolProperty(PropertyUseUnderline, useUnderline); err != nil {
+		e.LogErr(err)
+		return
+	}
+	if w := e.GetLabelWidget(); w != nil {
+		if lw, ok := w.Self().(Label); ok {
+			if useUnderline {
+				lw.SetMnemonicWidget(e)
+			} else {
+				lw.SetMnemonicWidget(nil)
+			}
+			lw.SetUseUnderline(useUnderline)
+		}
+	}
+	e.Invalidate()
+}
+
+// GetUseUnderline returns whether an embedded underline in the label indicates
+// a mnemonic.
+// See: SetUseUnderline()
+//
+// Locking: read
+func (e *CExpander) GetUseUnderline() (value bool) {
+	var err error
+	if value, err = e.GetBoolProperty(PropertyUseUnderline); err != nil {
+		e.LogErr(err)
+	}
+	return
+}
+
+// SetUseMarkup updates whether the text of the label contains markup in Tango's
+// text markup language.
+//
+// Parameters:
+//
+//	useMarkup	TRUE if the label's text should be parsed for markup
+//
+// Locking: write
+func (e *CExpander) SetUseMarkup(useMarkup bool) {
+	if err := e.SetBoolProperty(PropertyUseMarkup, useMarkup); err != nil {
+		e.LogErr(err)
+		return
+	}
+	if w := e.GetLabelWidget(); w != nil {
+		if lw, ok := w.Self().(Label); ok {
+			lw.SetUseMarkup(useMarkup)
+			lw.SetLabel(e.GetLabel())
+		}
+	}
+	e.queueResize()
+}
+
+// GetUseMarkup returns whether the label's text is interpreted as marked up
+// with Tango markup.
+// See: SetUseMarkup()
+//
+// Locking: read
+func (e *CExpander) GetUseMarkup() (value bool) {
+	var err error
+	if value, err = e.GetBoolProperty(PropertyUseMarkup); err != nil {
+		e.LogErr(err)
+	}
+	return
+}
+
+// SetLabelWidget removes any existing label widget and replaces it with the
+// given one. This is the widget that will appear after the expander glyph.
+//
+// Parameters:
+//
+//	labelWidget	the new label widget
+//
+// Locking: write
+func (e *CExpander) SetLabelWidget(labelWidget Widget) {
+	previous := e.GetLabelWidget()
+	if err := e.SetStructProperty(PropertyLabelWidget, labelWidget); err != nil {
+		e.LogErr(err)
+		return
+	}
+	if previous != nil {
+		e.PopCompositeChild(previous)
+	}
+	if labelWidget != nil {
+		e.PushCompositeChild(labelWidget)
+		if lw, ok := labelWidget.Self().(Label); ok && e.GetUseUnderline() {
+			lw.SetMnemonicWidget(e)
+			lw.SetUseUnderline(true)
+		}
+	}
+	e.queueResize()
+}
+
+// GetLabelWidget retrieves the label widget for the Expander.
+// See: SetLabelWidget()
+//
+// Locking: read
+func (e *CExpander) GetLabelWidget() (value Widget) {
+	if v, err := e.GetStructProperty(PropertyLabelWidget); err != nil {
+		e.LogErr(err)
+	} else if v != nil {
+		var ok bool
+		if value, ok = v.(Widget); !ok {
+			e.LogError("value stored in %v is not a Widget: %v (%T)", PropertyLabelWidget, v, v)
+		}
+	}
+	return
+}
+
+// Activate emits an activate signal and if the listeners all return
+// cenums.EVENT_PASS, toggles the expanded state of the Expander. Returns TRUE
+// if the Expander was toggled.
+//
+// Emits: SignalActivate, Argv=[Expander instance]
+func (e *CExpander) Activate() (value bool) {
+	if !e.IsVisible() || !e.IsSensitive() {
+		return false
+	}
+	if f := e.Emit(SignalActivate, e); f == cenums.EVENT_PASS {
+		e.SetExpanded(!e.GetExpanded())
+		return true
+	}
+	return false
+}
+
+// CancelEvent emits a cancel-event signal and if the signal handlers all return
+// cenums.EVENT_PASS, releases any event focus.
+func (e *CExpander) CancelEvent() {
+	if f := e.Emit(SignalCancelEvent, e); f == cenums.EVENT_PASS {
+		e.ReleaseEventFocus()
+	}
+}
+
+// GetFocusChain returns the Expander itself, followed by the focus chain of
+// the child when the Expander is expanded.
+func (e *CExpander) GetFocusChain() (focusableWidgets []Widget, explicitlySet bool) {
+	if e.CanFocus() && e.IsVisible() && e.IsSensitive() {
+		focusableWidgets = append(focusableWidgets, e)
+	}
+	if !e.GetExpanded() {
+		return
+	}
+	if child := e.GetChild(); child != nil {
+		if cc, ok := child.Self().(Container); ok {
+			fc, _ := cc.GetFocusChain()
+			for _, cChild := range fc {
+				if cChild.CanFocus() && cChild.IsVisible() && cChild.IsSensitive() {
+					focusableWidgets = append(focusableWidgets, cChild)
+				}
+			}
+		} else if child.CanFocus() && child.IsVisible() && child.IsSensitive() {
+			focusableWidgets = append(focusableWidgets, child)
+		}
+	}
+	return
+}
+
+// GetWidgetAt returns the Expander for any point within the header line and
+// defers to the child when expanded.
+func (e *CExpander) GetWidgetAt(p *ptypes.Point2I) Widget {
+	if e.HasPoint(p) && e.IsVisible() {
+		if e.GetExpanded() {
+			if child := e.GetChild(); child != nil && child.HasPoint(p) {
+				if found := child.GetWidgetAt(p); found != nil {
+					return found
+				}
+			}
+		}
+		return e
+	}
+	return nil
+}
+
+// GetSizeRequest returns the requested size of the Expander, which is one line
+// for the header plus the spacing and height of the child when expanded. If
+// the expanded child has no size request of its own, neither does the
+// Expander.
+func (e *CExpander) GetSizeRequest() (width, height int) {
+	size := ptypes.NewRectangle(e.CWidget.GetSizeRequest())
+	header := ptypes.MakeRectangle(2, 1)
+	if widget := e.GetLabelWidget(); widget != nil && widget.IsVisible() {
+		if label, ok := widget.Self().(Label); ok {
+			lw, _ := label.GetPlainTextInfo()
+			header.W += lw
+		} else if ww, _ := widget.GetSizeRequest(); ww > 0 {
+			header.W += ww
+		}
+	}
+	req := ptypes.MakeRectangle(header.W, header.H)
+	if child := e.GetChild(); child != nil && child.IsVisible() && e.GetExpanded() {
+		childSize := ptypes.NewRectangle(GetGroupedSizeRequest(child))
+		if childSize.W <= -1 {
+			req.W = -1
+		} else if childSize.W > req.W {
+			req.W = childSize.W
+		}
+		if childSize.H <= -1 {
+			req.H = -1
+		} else {
+			req.H += e.GetSpacing() + childSize.H
+		}
+	}
+	if size.W <= -1 {
+		size.W = req.W
+	}
+	if size.H <= -1 {
+		size.H = req.H
+	}
+	return size.W, size.H
+}
+
+func (e *CExpander) queueResize() {
+	if parent := e.GetParent(); parent != nil {
+		parent.Resize()
+	} else {
+		e.Resize()
+	}
+	e.Invalidate()
+}
+
+func (e *CExpander) event(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if !e.IsSensitive() {
+		return cenums.EVENT_PASS
+	}
+	if evt, ok := argv[1].(cdk.Event); ok {
+		switch ev := evt.(type) {
+		case *cdk.EventKey:
+			switch ev.Key() {
+			case cdk.KeyLeft:
+				if e.GetExpanded() {
+					e.SetExpanded(false)
+					return cenums.EVENT_STOP
+				}
+				return cenums.EVENT_PASS
+			case cdk.KeyRight:
+				if !e.GetExpanded() {
+					e.SetExpanded(true)
+					return cenums.EVENT_STOP
+				}
+				return cenums.EVENT_PASS
+			}
+			switch cdk.Key(ev.Rune()) {
+			case cdk.KeyEnter, cdk.KeyLF, cdk.KeySpace:
+				e.Activate()
+				return cenums.EVENT_STOP
+			}
+		case *cdk.EventMouse:
+			pos := ptypes.NewPoint2I(ev.Position())
+			if ev.State() == cdk.BUTTON_PRESS && e.HasPoint(pos) && pos.Y == e.GetOrigin().Y {
+				if e.CanFocus() && !e.HasFocus() {
+					e.GrabFocus()
+				}
+				e.Activate()
+				return cenums.EVENT_STOP
+			}
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (e *CExpander) resize(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	alloc := e.GetAllocation()
+	origin := e.GetOrigin()
+	widget := e.GetLabelWidget()
+	child := e.GetChild()
+
+	if alloc.W <= 0 || alloc.H <= 0 {
+		if widget != nil {
+			widget.SetAllocation(ptypes.MakeRectangle(0, 0))
+			widget.Resize()
+		}
+		if child != nil {
+			child.SetAllocation(ptypes.MakeRectangle(0, 0))
+			child.Resize()
+		}
+		return cenums.EVENT_PASS
+	}
+
+	if widget != nil {
+		labelAlloc := ptypes.MakeRectangle(alloc.W-2, 1)
+		labelAlloc.Floor(0, 0)
+		if label, ok := widget.Self().(Label); ok {
+			label.SetMaxWidthChars(labelAlloc.W)
+		}
+		widget.SetOrigin(origin.X+2, origin.Y)
+		widget.SetAllocation(labelAlloc)
+		widget.Resize()
+	}
+
+	if child != nil {
+		if e.GetExpanded() {
+			spacing := e.GetSpacing()
+			childAlloc := ptypes.MakeRectangle(alloc.W, alloc.H-1-spacing)
+			childAlloc.Floor(0, 0)
+			child.SetOrigin(origin.X, origin.Y+1+spacing)
+			child.SetAllocation(childAlloc)
+		} else {
+			child.SetOrigin(origin.X, origin.Y)
+			child.SetAllocation(ptypes.MakeRectangle(0, 0))
+		}
+		child.Resize()
+	}
+
+	e.Invalidate()
+	return cenums.EVENT_STOP
+}
+
+func (e *CExpander) draw(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if surface, ok := argv[1].(*memphis.CSurface); ok {
+		alloc := e.GetAllocation()
+		if !e.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+			e.LogTrace("not visible, zero width or zero height")
+			return cenums.EVENT_PASS
+		}
+		theme := e.GetThemeRequest()
+		surface.Fill(theme)
+
+		glyph := paint.RuneFilledRightPointingSmallTriangle
+		if e.GetExpanded() {
+			glyph = paint.RuneFilledDownPointingSmallTriangle
+		}
+		_ = surface.SetRune(0, 0, glyph, theme.Content.Normal)
+
+		if widget := e.GetLabelWidget(); widget != nil {
+			widget.Draw()
+			widget.LockDraw()
+			if err := surface.Composite(widget.ObjectID()); err != nil {
+				e.LogError("composite error: %v", err)
+			}
+			widget.UnlockDraw()
+		}
+
+		if child := e.GetChild(); child != nil && e.GetExpanded() {
+			child.Draw()
+			child.LockDraw()
+			if err := surface.Composite(child.ObjectID()); err != nil {
+				e.LogError("composite error: %v", err)
+			}
+			child.UnlockDraw()
+		}
+
+		if debug, _ := e.GetBoolProperty(cdk.PropertyDebug); debug {
+			surface.DebugBox(paint.ColorSilver, e.ObjectInfo())
+		}
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+// Whether the expander has been opened to reveal the child widget.
+// Flags: Read / Write / Construct
+// Default value: FALSE
+const PropertyExpanded cdk.Property = "expanded"
+
+// Text of the expander's label.
+// Flags: Read / Write / Construct
+// Default value: NULL
+// const PropertyLabel cdk.Property = "label"
+
+// A widget to display in place of the usual expander label.
+// Flags: Read / Write
+// const PropertyLabelWidget cdk.Property = "label-widget"
+
+// Space to put between the label and the child.
+// Flags: Read / Write
+// Allowed values: >= 0
+// Default value: 0
+// const PropertySpacing cdk.Property = "spacing"
+
+// The text of the label includes XML markup. See pango_parse_markup.
+// Flags: Read / Write / Construct
+// Default value: FALSE
+// const PropertyUseMarkup cdk.Property = "use-markup"
+
+// If set, an underline in the text indicates the next character should be
+// used for the mnemonic accelerator key.
+// Flags: Read / Write / Construct
+// Default value: FALSE
+// const PropertyUseUnderline cdk.Property = "use-underline"
+
+// The activate signal is emitted when the Expander is toggled, either by the
+// user or by calling Activate. Listeners returning cenums.EVENT_STOP prevent
+// the Expander from toggling.
+// const SignalActivate cdk.Signal = "activate"
+
+const ExpanderEventHandle = "expander-event-handler"
+
+const ExpanderResizeHandle = "expander-resize-handler"
+
+const ExpanderDrawHandle = "expander-draw-handler"
@@ -0,0 +1,197 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"testing"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/ptypes"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExpander(t *testing.T) {
+	Convey("Testing Expanders", t, func() {
+
+		makeWindow := func(expander Expander) (Window, Widget) {
+			window := NewWindow()
+			window.SetDecorated(false)
+			window.Show()
+			window.SetAllocation(ptypes.MakeRectangle(20, 10))
+			below := NewButtonWithLabel("Below")
+			below.Show()
+			expander.Show()
+			window.GetVBox().PackStart(expander, false, false, 0)
+			window.GetVBox().PackStart(below, false, false, 0)
+			window.Resize()
+			return window, below
+		}
+
+		Convey("Basics", func() {
+			e := &CExpander{}
+			So(e.Init(), ShouldEqual, false)
+			So(e.Init(), ShouldEqual, true)
+			expander := NewExpander("Details")
+			So(expander, ShouldNotBeNil)
+			expander.Show()
+			So(expander.GetLabel(), ShouldEqual, "Details")
+			So(expander.GetExpanded(), ShouldEqual, false)
+			So(expander.GetSpacing(), ShouldEqual, 0)
+			So(expander.CanFocus(), ShouldEqual, true)
+			child := NewLabel("one\ntwo")
+			child.Show()
+			expander.Add(child)
+			w, h := expander.GetSizeRequest()
+			So(w, ShouldEqual, 9)
+			So(h, ShouldEqual, 1)
+			expander.SetSpacing(1)
+			expander.SetExpanded(true)
+			_, h = expander.GetSizeRequest()
+			So(h, ShouldEqual, 4)
+			activated := 0
+			expander.Connect(SignalActivate, "test-activate", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				activated += 1
+				return cenums.EVENT_PASS
+			})
+			So(expander.Activate(), ShouldEqual, true)
+			So(expander.GetExpanded(), ShouldEqual, false)
+			So(activated, ShouldEqual, 1)
+			expander.Connect(SignalActivate, "test-activate-stop", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				return cenums.EVENT_STOP
+			})
+			So(expander.Activate(), ShouldEqual, false)
+			So(expander.GetExpanded(), ShouldEqual, false)
+			_ = expander.Disconnect(SignalActivate, "test-activate-stop")
+			expander.SetSensitive(false)
+			So(expander.Activate(), ShouldEqual, false)
+			So(activated, ShouldEqual, 1)
+		})
+
+		Convey("Markup", func() {
+			expander := NewExpander("<b>Bold</b>")
+			expander.SetUseMarkup(true)
+			So(expander.GetUseMarkup(), ShouldEqual, true)
+			So(expander.GetLabel(), ShouldEqual, "<b>Bold</b>")
+			label, ok := expander.GetLabelWidget().Self().(Label)
+			So(ok, ShouldEqual, true)
+			So(label.GetText(), ShouldEqual, "Bold")
+			w, _ := expander.GetSizeRequest()
+			So(w, ShouldEqual, 6)
+		})
+
+		Convey("Layout and Focus", func() {
+			expander := NewExpander("Advanced")
+			box := NewVBox(false, 0)
+			box.SetSizeRequest(-1, 1)
+			box.Show()
+			entry := NewEntry("")
+			entry.Show()
+			box.PackStart(entry, false, false, 0)
+			expander.Add(box)
+			window, below := makeWindow(expander)
+			So(below.GetOrigin().Y, ShouldEqual, 1)
+			fc, _ := window.GetVBox().GetFocusChain()
+			So(fc, ShouldHaveLength, 2)
+			So(fc[0].ObjectID(), ShouldEqual, expander.ObjectID())
+
+			// toggling with the keyboard re-lays out the parent box
+			expander.GrabFocus()
+			So(expander.HasFocus(), ShouldEqual, true)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, ' ', cdk.ModNone))
+			So(expander.GetExpanded(), ShouldEqual, true)
+			So(below.GetOrigin().Y, ShouldEqual, 2)
+			So(entry.GetOrigin().Y, ShouldEqual, 1)
+			fc, _ = window.GetVBox().GetFocusChain()
+			So(fc, ShouldHaveLength, 3)
+			So(fc[1].ObjectID(), ShouldEqual, entry.ObjectID())
+
+			// collapsing moves the focus out of the child
+			entry.GrabFocus()
+			So(entry.HasFocus(), ShouldEqual, true)
+			expander.SetExpanded(false)
+			So(expander.HasFocus(), ShouldEqual, true)
+			So(below.GetOrigin().Y, ShouldEqual, 1)
+			So(entry.GetAllocation().W, ShouldEqual, 0)
+
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRight, 0, cdk.ModNone))
+			So(expander.GetExpanded(), ShouldEqual, true)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyLeft, 0, cdk.ModNone))
+			So(expander.GetExpanded(), ShouldEqual, false)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, rune(cdk.KeyEnter), cdk.ModNone))
+			So(expander.GetExpanded(), ShouldEqual, true)
+
+			// clicking the header toggles, clicking the child does not
+			window.ProcessEvent(cdk.NewEventMouse(1, 1, cdk.Button1, cdk.ModNone))
+			window.ProcessEvent(cdk.NewEventMouse(1, 1, cdk.ButtonNone, cdk.ModNone))
+			So(expander.GetExpanded(), ShouldEqual, true)
+			So(window.GetWidgetAt(ptypes.NewPoint2I(1, 1)).ObjectID(), ShouldEqual, entry.ObjectID())
+			window.ProcessEvent(cdk.NewEventMouse(4, 0, cdk.Button1, cdk.ModNone))
+			window.ProcessEvent(cdk.NewEventMouse(4, 0, cdk.ButtonNone, cdk.ModNone))
+			So(expander.GetExpanded(), ShouldEqual, false)
+		})
+
+		Convey("Mnemonic", func() {
+			expander := NewExpanderWithMnemonic("_Options")
+			So(expander.GetUseUnderline(), ShouldEqual, true)
+			window, _ := makeWindow(expander)
+			So(window.ActivateMnemonic('o', cdk.ModAlt), ShouldEqual, true)
+			So(expander.GetExpanded(), ShouldEqual, true)
+			So(expander.HasFocus(), ShouldEqual, true)
+		})
+
+		Convey("Builder", func() {
+			builder := NewBuilder()
+			So(builder, ShouldNotBeNil)
+			builder.LoadFromString(testExpanderBuilderXML)
+			obj := builder.GetWidget("settings-expander")
+			So(obj, ShouldNotBeNil)
+			expander, ok := obj.(Expander)
+			So(ok, ShouldEqual, true)
+			So(expander.GetExpanded(), ShouldEqual, true)
+			So(expander.GetSpacing(), ShouldEqual, 1)
+			label, ok := expander.GetLabelWidget().(Label)
+			So(ok, ShouldEqual, true)
+			So(label.GetName(), ShouldEqual, "settings-label")
+			So(label.GetLabel(), ShouldEqual, "Settings")
+			child := expander.GetChild()
+			So(child, ShouldNotBeNil)
+			So(child.GetName(), ShouldEqual, "settings-check")
+		})
+	})
+}
+
+const testExpanderBuilderXML = `<?xml version="1.0" encoding="UTF-8"?>
+<interface>
+  <object class="GtkExpander" id="settings-expander">
+    <property name="visible">True</property>
+    <property name="can_focus">True</property>
+    <property name="expanded">True</property>
+    <property name="spacing">1</property>
+    <child>
+      <object class="GtkCheckButton" id="settings-check">
+        <property name="label">Enabled</property>
+        <property name="visible">True</property>
+      </object>
+    </child>
+    <child type="label">
+      <object class="GtkLabel" id="settings-label">
+        <property name="visible">True</property>
+        <property name="label">Settings</property>
+      </object>
+    </child>
+  </object>
+</interface>
+`
@@ -0,0 +1,154 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"strings"
+
+	"github.com/go-curses/cdk"
+	cstrings "github.com/go-curses/cdk/lib/strings"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+// FileChooser Hierarchy:
+//
+//	Interface
+//	  +- FileChooser
+//
+// FileChooser is an interface that can be implemented by file selection
+// widgets. In CTK, the main objects that implement this interface are
+// FileChooserWidget and FileChooserDialog. You do not need to write an object
+// that implements the FileChooser interface unless you are trying to adapt an
+// existing file selector to expose a standard programming interface.
+//
+// CTK FileChooser instances only ever browse the local filesystem, all file
+// names are absolute local paths.
+type FileChooser interface {
+	SetAction(action enums.FileChooserAction)
+	GetAction() (value enums.FileChooserAction)
+	SetSelectMultiple(selectMultiple bool)
+	GetSelectMultiple() (value bool)
+	SetShowHidden(showHidden bool)
+	GetShowHidden() (value bool)
+	SetDoOverwriteConfirmation(doOverwriteConfirmation bool)
+	GetDoOverwriteConfirmation() (value bool)
+	SetCurrentName(name string)
+	GetCurrentName() (name string)
+	GetFilename() (filename string)
+	SetFilename(filename string) (ok bool)
+	SelectFilename(filename string) (ok bool)
+	UnselectFilename(filename string)
+	SelectAll()
+	UnselectAll()
+	GetFilenames() (filenames []string)
+	SetCurrentFolder(filename string) (ok bool)
+	GetCurrentFolder() (folder string)
+	AddFilter(filter FileFilter)
+	RemoveFilter(filter FileFilter)
+	ListFilters() (filters []FileFilter)
+	SetFilter(filter FileFilter)
+	GetFilter() (filter FileFilter)
+}
+
+// fileChooserBuilderTranslator applies the FileChooser properties found in
+// Builder files, for any of the FileChooser implementations.
+func fileChooserBuilderTranslator(builder Builder, widget Widget, name, value string) error {
+	fc, ok := widget.Self().(FileChooser)
+	if !ok {
+		return ErrFallthrough
+	}
+	switch strings.ReplaceAll(strings.ToLower(name), "_", "-") {
+	case "action":
+		if action, err := enums.FileChooserAction(0).FromString(value); err != nil {
+			return err
+		} else {
+			fc.SetAction(action.(enums.FileChooserAction))
+		}
+	case "select-multiple":
+		fc.SetSelectMultiple(cstrings.IsTrue(value))
+	case "show-hidden":
+		fc.SetShowHidden(cstrings.IsTrue(value))
+	case "do-overwrite-confirmation":
+		fc.SetDoOverwriteConfirmation(cstrings.IsTrue(value))
+	default:
+		return ErrFallthrough
+	}
+	return nil
+}
+
+// The type of operation that the file selector is performing.
+// Flags: Read / Write
+// Default value: FILE_CHOOSER_ACTION_OPEN
+const PropertyFileChooserAction cdk.Property = "action"
+
+// The current filter for selecting which files are displayed.
+// Flags: Read / Write
+const PropertyFileChooserFilter cdk.Property = "filter"
+
+// Whether to allow multiple files to be selected.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertySelectMultiple cdk.Property = "select-multiple"
+
+// Whether the hidden files and folders should be displayed.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyShowHidden cdk.Property = "show-hidden"
+
+// Whether a file chooser in save mode will present an overwrite confirmation
+// dialog if the user selects a file name that already exists.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyDoOverwriteConfirmation cdk.Property = "do-overwrite-confirmation"
+
+// This signal is emitted when the current folder in a FileChooser changes.
+// This can happen due to the user performing some action that changes folders,
+// such as selecting a bookmark or visiting a folder on the file list. It can
+// also happen as a result of calling a function to explicitly change the
+// current folder in a file chooser.
+// Listener function arguments:
+//
+//	folder string	the new current folder
+const SignalCurrentFolderChanged cdk.Signal = "current-folder-changed"
+
+// This signal is emitted when there is a change in the set of selected files
+// in a FileChooser. This can happen when the user modifies the selection with
+// the mouse or the keyboard, or when explicitly calling functions to change
+// the selection.
+const SignalSelectionChanged cdk.Signal = "selection-changed"
+
+// This signal is emitted when the user "activates" a file in the file
+// chooser. This can happen by pressing Enter on one of the items in the file
+// list, or by pressing Enter on the location entry.
+// Listener function arguments:
+//
+//	filename string	the activated file
+const SignalFileActivated cdk.Signal = "file-activated"
+
+// This signal gets emitted whenever it is appropriate to present a
+// confirmation dialog when the user has selected a file name that already
+// exists. The signal only gets emitted when the file chooser is in
+// FILE_CHOOSER_ACTION_SAVE mode and do-overwrite-confirmation is enabled.
+//
+// Handlers store their decision in the confirmation argument:
+// FILE_CHOOSER_CONFIRMATION_CONFIRM presents the stock confirmation dialog,
+// FILE_CHOOSER_CONFIRMATION_ACCEPT_FILENAME accepts the file name as-is and
+// FILE_CHOOSER_CONFIRMATION_SELECT_AGAIN lets the user choose another file.
+// Listener function arguments:
+//
+//	filename string	the file about to be overwritten
+//	confirmation *enums.FileChooserConfirmation	the decision of the handler
+const SignalConfirmOverwrite cdk.Signal = "confirm-overwrite"
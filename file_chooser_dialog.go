@@ -0,0 +1,377 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeFileChooserDialog cdk.CTypeTag = "ctk-file-chooser-dialog"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeFileChooserDialog, func() interface{} { return MakeFileChooserDialog() })
+	ctkBuilderTranslators[TypeFileChooserDialog] = func(builder Builder, widget Widget, name, value string) error {
+		if err := fileChooserBuilderTranslator(builder, widget, name, value); err != ErrFallthrough {
+			return err
+		}
+		if fn, ok := ctkBuilderTranslators[TypeDialog]; ok {
+			return fn(builder, widget, name, value)
+		}
+		return ErrFallthrough
+	}
+}
+
+// FileChooserDialog Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Bin
+//	        +- Window
+//	          +- Dialog
+//	            +- FileChooserDialog
+//
+// FileChooserDialog is a Dialog box suitable for use with "File/Open" or
+// "File/Save as" commands. This widget works by putting a FileChooserWidget
+// inside a Dialog. It exposes the FileChooser interface, so you can use all
+// of the FileChooser functions on the file chooser dialog as well as those for
+// Dialog.
+//
+// Activating a file within the FileChooserDialog emits the first of the
+// ResponseAccept, ResponseOk, ResponseYes or ResponseApply responses that has
+// a Button in the action area. When the action is FILE_CHOOSER_ACTION_SAVE and
+// do-overwrite-confirmation is enabled, accepting the name of an existing file
+// emits the confirm-overwrite signal and, unless a handler decides otherwise,
+// asks the user to confirm before the response is delivered.
+type FileChooserDialog interface {
+	Dialog
+	FileChooser
+
+	GetFileChooserWidget() (widget FileChooserWidget)
+}
+
+var _ FileChooserDialog = (*CFileChooserDialog)(nil)
+
+// The CFileChooserDialog structure implements the FileChooserDialog interface
+// and is exported to facilitate type embedding with custom implementations. No
+// member variables are exported as the interface methods are the only intended
+// means of interacting with FileChooserDialog objects.
+type CFileChooserDialog struct {
+	CDialog
+
+	chooser   FileChooserWidget
+	confirmed string
+}
+
+// MakeFileChooserDialog is used by the Buildable system to construct a new
+// FileChooserDialog.
+func MakeFileChooserDialog() FileChooserDialog {
+	return NewFileChooserDialog("", nil, enums.FILE_CHOOSER_ACTION_OPEN)
+}
+
+// NewFileChooserDialog creates a new FileChooserDialog with title, transient
+// parent, the FileChooserAction and a variadic list of paired items. The items
+// are the button ResponseType paired with a Button label string (which can be
+// a ctk.StockID for access to the stock Buttons in CTK). This function is
+// analogous to NewDialogWithButtons.
+//
+// Parameters:
+//
+//	title	title of the dialog
+//	parent	Transient parent of the dialog, or `nil`
+//	action	Open or save mode for the dialog
+//	argv	response ID with label pairs
+func NewFileChooserDialog(title string, parent Window, action enums.FileChooserAction, argv ...interface{}) FileChooserDialog {
+	d := new(CFileChooserDialog)
+	d.Init()
+	d.SetTitle(title)
+	d.SetDecorated(true)
+	if parent != nil {
+		d.SetTransientFor(parent)
+		d.SetParent(parent)
+		if err := d.ImportStylesFromString(parent.ExportStylesToString()); err != nil {
+			d.LogErr(err)
+		}
+	}
+	d.SetWindow(d)
+	if len(argv) > 0 {
+		d.AddButtons(argv...)
+	}
+	d.SetAction(action)
+	d.SetSizeRequest(64, 20)
+	return d
+}
+
+// Init initializes a FileChooserDialog object. This must be called at least
+// once to set up the necessary defaults and allocate any memory structures.
+// Calling this more than once is safe though unnecessary. Only the first call
+// will result in any effect upon the FileChooserDialog instance. Init is used
+// in the NewFileChooserDialog constructor and only necessary when implementing
+// a derivative FileChooserDialog type.
+func (d *CFileChooserDialog) Init() (already bool) {
+	if d.InitTypeItem(TypeFileChooserDialog, d) {
+		return true
+	}
+	d.CDialog.Init()
+	d.chooser = NewFileChooserWidget(enums.FILE_CHOOSER_ACTION_OPEN)
+	d.chooser.Show()
+	d.GetContentArea().PackStart(d.chooser, true, true, 0)
+	d.chooser.Connect(SignalSelectionChanged, FileChooserDialogSelectionChangedHandle, d.forward(SignalSelectionChanged))
+	d.chooser.Connect(SignalCurrentFolderChanged, FileChooserDialogFolderChangedHandle, d.forward(SignalCurrentFolderChanged))
+	d.chooser.Connect(SignalFileActivated, FileChooserDialogFileActivatedHandle, d.fileActivated)
+	d.Connect(SignalResponse, FileChooserDialogResponseHandle, d.respond)
+	return false
+}
+
+// GetFileChooserWidget returns the FileChooserWidget within the content area
+// of the FileChooserDialog.
+func (d *CFileChooserDialog) GetFileChooserWidget() (widget FileChooserWidget) {
+	d.RLock()
+	defer d.RUnlock()
+	return d.chooser
+}
+
+// SetAction is a convenience method for FileChooserWidget.SetAction.
+func (d *CFileChooserDialog) SetAction(action enums.FileChooserAction) {
+	d.GetFileChooserWidget().SetAction(action)
+}
+
+// GetAction is a convenience method for FileChooserWidget.GetAction.
+func (d *CFileChooserDialog) GetAction() (value enums.FileChooserAction) {
+	return d.GetFileChooserWidget().GetAction()
+}
+
+// SetSelectMultiple is a convenience method for
+// FileChooserWidget.SetSelectMultiple.
+func (d *CFileChooserDialog) SetSelectMultiple(selectMultiple bool) {
+	d.GetFileChooserWidget().SetSelectMultiple(selectMultiple)
+}
+
+// GetSelectMultiple is a convenience method for
+// FileChooserWidget.GetSelectMultiple.
+func (d *CFileChooserDialog) GetSelectMultiple() (value bool) {
+	return d.GetFileChooserWidget().GetSelectMultiple()
+}
+
+// SetShowHidden is a convenience method for FileChooserWidget.SetShowHidden.
+func (d *CFileChooserDialog) SetShowHidden(showHidden bool) {
+	d.GetFileChooserWidget().SetShowHidden(showHidden)
+}
+
+// GetShowHidden is a convenience method for FileChooserWidget.GetShowHidden.
+func (d *CFileChooserDialog) GetShowHidden() (value bool) {
+	return d.GetFileChooserWidget().GetShowHidden()
+}
+
+// SetDoOverwriteConfirmation is a convenience method for
+// FileChooserWidget.SetDoOverwriteConfirmation.
+func (d *CFileChooserDialog) SetDoOverwriteConfirmation(doOverwriteConfirmation bool) {
+	d.GetFileChooserWidget().SetDoOverwriteConfirmation(doOverwriteConfirmation)
+}
+
+// GetDoOverwriteConfirmation is a convenience method for
+// FileChooserWidget.GetDoOverwriteConfirmation.
+func (d *CFileChooserDialog) GetDoOverwriteConfirmation() (value bool) {
+	return d.GetFileChooserWidget().GetDoOverwriteConfirmation()
+}
+
+// SetCurrentName is a convenience method for FileChooserWidget.SetCurrentName.
+func (d *CFileChooserDialog) SetCurrentName(name string) {
+	d.GetFileChooserWidget().SetCurrentName(name)
+}
+
+// GetCurrentName is a convenience method for FileChooserWidget.GetCurrentName.
+func (d *CFileChooserDialog) GetCurrentName() (name string) {
+	return d.GetFileChooserWidget().GetCurrentName()
+}
+
+// GetFilename is a convenience method for FileChooserWidget.GetFilename.
+func (d *CFileChooserDialog) GetFilename() (filename string) {
+	return d.GetFileChooserWidget().GetFilename()
+}
+
+// SetFilename is a convenience method for FileChooserWidget.SetFilename.
+func (d *CFileChooserDialog) SetFilename(filename string) (ok bool) {
+	return d.GetFileChooserWidget().SetFilename(filename)
+}
+
+// SelectFilename is a convenience method for FileChooserWidget.SelectFilename.
+func (d *CFileChooserDialog) SelectFilename(filename string) (ok bool) {
+	return d.GetFileChooserWidget().SelectFilename(filename)
+}
+
+// UnselectFilename is a convenience method for
+// FileChooserWidget.UnselectFilename.
+func (d *CFileChooserDialog) UnselectFilename(filename string) {
+	d.GetFileChooserWidget().UnselectFilename(filename)
+}
+
+// SelectAll is a convenience method for FileChooserWidget.SelectAll.
+func (d *CFileChooserDialog) SelectAll() {
+	d.GetFileChooserWidget().SelectAll()
+}
+
+// UnselectAll is a convenience method for FileChooserWidget.UnselectAll.
+func (d *CFileChooserDialog) UnselectAll() {
+	d.GetFileChooserWidget().UnselectAll()
+}
+
+// GetFilenames is a convenience method for FileChooserWidget.GetFilenames.
+func (d *CFileChooserDialog) GetFilenames() (filenames []string) {
+	return d.GetFileChooserWidget().GetFilenames()
+}
+
+// SetCurrentFolder is a convenience method for
+// FileChooserWidget.SetCurrentFolder.
+func (d *CFileChooserDialog) SetCurrentFolder(filename string) (ok bool) {
+	return d.GetFileChooserWidget().SetCurrentFolder(filename)
+}
+
+// GetCurrentFolder is a convenience method for
+// FileChooserWidget.GetCurrentFolder.
+func (d *CFileChooserDialog) GetCurrentFolder() (folder string) {
+	return d.GetFileChooserWidget().GetCurrentFolder()
+}
+
+// AddFilter is a convenience method for FileChooserWidget.AddFilter.
+func (d *CFileChooserDialog) AddFilter(filter FileFilter) {
+	d.GetFileChooserWidget().AddFilter(filter)
+}
+
+// RemoveFilter is a convenience method for FileChooserWidget.RemoveFilter.
+func (d *CFileChooserDialog) RemoveFilter(filter FileFilter) {
+	d.GetFileChooserWidget().RemoveFilter(filter)
+}
+
+// ListFilters is a convenience method for FileChooserWidget.ListFilters.
+func (d *CFileChooserDialog) ListFilters() (filters []FileFilter) {
+	return d.GetFileChooserWidget().ListFilters()
+}
+
+// SetFilter is a convenience method for FileChooserWidget.SetFilter.
+func (d *CFileChooserDialog) SetFilter(filter FileFilter) {
+	d.GetFileChooserWidget().SetFilter(filter)
+}
+
+// GetFilter is a convenience method for FileChooserWidget.GetFilter.
+func (d *CFileChooserDialog) GetFilter() (filter FileFilter) {
+	return d.GetFileChooserWidget().GetFilter()
+}
+
+// forward returns a signal listener re-emitting the given FileChooserWidget
+// signal from the FileChooserDialog
+func (d *CFileChooserDialog) forward(signal cdk.Signal) cdk.SignalListenerFn {
+	return func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+		if len(argv) > 0 {
+			argv = append([]interface{}{d}, argv[1:]...)
+		}
+		return d.Emit(signal, argv...)
+	}
+}
+
+// acceptResponse returns the first of the accepting ResponseType values with a
+// Widget in the action area
+func (d *CFileChooserDialog) acceptResponse() (response enums.ResponseType, ok bool) {
+	for _, response = range []enums.ResponseType{enums.ResponseAccept, enums.ResponseOk, enums.ResponseYes, enums.ResponseApply} {
+		if d.GetWidgetForResponse(response) != nil {
+			return response, true
+		}
+	}
+	return enums.ResponseNone, false
+}
+
+// confirmOverwrite presents the stock confirmation Dialog, responding to the
+// FileChooserDialog once again if the user chooses to replace the file
+func (d *CFileChooserDialog) confirmOverwrite(filename string, responseId enums.ResponseType) {
+	message := fmt.Sprintf("A file named \"%v\" already exists. Do you want to replace it?", filepath.Base(filename))
+	confirm := NewYesNoDialog("Confirm Overwrite", message, true)
+	confirm.SetTransientFor(d)
+	confirm.RunFunc(func(response enums.ResponseType, argv ...interface{}) {
+		if response == enums.ResponseYes {
+			d.Lock()
+			d.confirmed = filename
+			d.Unlock()
+			d.Response(responseId)
+		}
+	})
+}
+
+func (d *CFileChooserDialog) fileActivated(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if f := d.forward(SignalFileActivated)(data, argv...); f == cenums.EVENT_STOP {
+		return cenums.EVENT_STOP
+	}
+	if response, ok := d.acceptResponse(); ok {
+		d.Response(response)
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+func (d *CFileChooserDialog) respond(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if len(argv) != 1 {
+		return cenums.EVENT_PASS
+	}
+	responseId, ok := argv[0].(enums.ResponseType)
+	if !ok {
+		return cenums.EVENT_PASS
+	}
+	switch responseId {
+	case enums.ResponseAccept, enums.ResponseOk, enums.ResponseYes, enums.ResponseApply:
+	default:
+		return cenums.EVENT_PASS
+	}
+	if d.GetAction() != enums.FILE_CHOOSER_ACTION_SAVE || !d.GetDoOverwriteConfirmation() {
+		return cenums.EVENT_PASS
+	}
+	filename := d.GetFilename()
+	if filename == "" {
+		return cenums.EVENT_PASS
+	}
+	if _, err := os.Stat(filename); err != nil {
+		return cenums.EVENT_PASS
+	}
+	d.Lock()
+	confirmed := d.confirmed == filename
+	d.confirmed = ""
+	d.Unlock()
+	if confirmed {
+		return cenums.EVENT_PASS
+	}
+	confirmation := enums.FILE_CHOOSER_CONFIRMATION_CONFIRM
+	d.Emit(SignalConfirmOverwrite, d, filename, &confirmation)
+	switch confirmation {
+	case enums.FILE_CHOOSER_CONFIRMATION_ACCEPT_FILENAME:
+		return cenums.EVENT_PASS
+	case enums.FILE_CHOOSER_CONFIRMATION_SELECT_AGAIN:
+		return cenums.EVENT_STOP
+	}
+	d.confirmOverwrite(filename, responseId)
+	return cenums.EVENT_STOP
+}
+
+const FileChooserDialogSelectionChangedHandle = "file-chooser-dialog-selection-changed-handler"
+
+const FileChooserDialogFolderChangedHandle = "file-chooser-dialog-folder-changed-handler"
+
+const FileChooserDialogFileActivatedHandle = "file-chooser-dialog-file-activated-handler"
+
+const FileChooserDialogResponseHandle = "file-chooser-dialog-response-handler"
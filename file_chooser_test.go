@@ -0,0 +1,256 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+func TestFileChooser(t *testing.T) {
+	Convey("Testing File Choosers", t, func() {
+		tmp := t.TempDir()
+		So(os.Mkdir(filepath.Join(tmp, "sub"), 0755), ShouldBeNil)
+		for _, name := range []string{"b.txt", "a.go", ".hidden", filepath.Join("sub", "c.go")} {
+			So(os.WriteFile(filepath.Join(tmp, name), []byte("test"), 0644), ShouldBeNil)
+		}
+		listNames := func(fc FileChooserWidget) (names []string) {
+			model := fc.GetFileList().GetModel()
+			iter, ok := model.GetIterFirst()
+			for ok {
+				names = append(names, TreeModelGetString(model, iter, 0))
+				ok = model.IterNext(iter)
+			}
+			return
+		}
+
+		Convey("file filters", func() {
+			info := FileFilterInfo{Contains: enums.FILE_FILTER_DISPLAY_NAME, DisplayName: "main.go"}
+			f := NewFileFilter()
+			So(f.Filter(info), ShouldEqual, false)
+			f.AddPattern("*.txt")
+			So(f.Filter(info), ShouldEqual, false)
+			f.AddPattern("*.go")
+			So(f.Filter(info), ShouldEqual, true)
+			So(f.GetNeeded(), ShouldEqual, enums.FILE_FILTER_DISPLAY_NAME)
+			f.AddPattern("[")
+			c := NewFileFilterWithPatterns("Custom")
+			So(c.GetName(), ShouldEqual, "Custom")
+			c.AddCustom(enums.FILE_FILTER_MIME_TYPE, func(info FileFilterInfo) bool {
+				return info.MimeType == "text/plain"
+			})
+			So(c.Filter(info), ShouldEqual, false)
+			info.Contains |= enums.FILE_FILTER_MIME_TYPE
+			info.MimeType = "text/plain"
+			So(c.Filter(info), ShouldEqual, true)
+		})
+
+		Convey("listing", func() {
+			fc := NewFileChooserWidget(enums.FILE_CHOOSER_ACTION_OPEN)
+			folders := 0
+			fc.Connect(SignalCurrentFolderChanged, "test-folder-changed", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				folders += 1
+				return cenums.EVENT_PASS
+			})
+			So(fc.SetCurrentFolder(filepath.Join(tmp, "a.go")), ShouldEqual, false)
+			So(fc.SetCurrentFolder(tmp), ShouldEqual, true)
+			So(folders, ShouldEqual, 1)
+			So(fc.GetCurrentFolder(), ShouldEqual, tmp)
+			So(listNames(fc), ShouldResemble, []string{"../", "sub/", "a.go", "b.txt"})
+			fc.SetShowHidden(true)
+			So(listNames(fc), ShouldResemble, []string{"../", "sub/", ".hidden", "a.go", "b.txt"})
+			fc.(*CFileChooserWidget).hiddenToggle.SetActive(false)
+			So(fc.GetShowHidden(), ShouldEqual, false)
+			goFilter := NewFileFilterWithPatterns("Go", "*.go")
+			allFilter := NewFileFilterWithPatterns("All", "*")
+			fc.AddFilter(goFilter)
+			fc.AddFilter(allFilter)
+			So(fc.GetFilter(), ShouldEqual, goFilter)
+			So(fc.ListFilters(), ShouldHaveLength, 2)
+			So(listNames(fc), ShouldResemble, []string{"../", "sub/", "a.go"})
+			button := fc.(*CFileChooserWidget).filterButton
+			So(button.GetLabel(), ShouldEqual, "Go")
+			button.Clicked()
+			So(fc.GetFilter(), ShouldEqual, allFilter)
+			So(listNames(fc), ShouldResemble, []string{"../", "sub/", "a.go", "b.txt"})
+			fc.RemoveFilter(allFilter)
+			So(fc.GetFilter(), ShouldEqual, goFilter)
+			fc.SetAction(enums.FILE_CHOOSER_ACTION_SELECT_FOLDER)
+			So(listNames(fc), ShouldResemble, []string{"../", "sub/"})
+			So(fc.GetFilename(), ShouldEqual, tmp)
+		})
+
+		Convey("selection", func() {
+			fc := NewFileChooserWidget(enums.FILE_CHOOSER_ACTION_OPEN)
+			So(fc.SetCurrentFolder(tmp), ShouldEqual, true)
+			changed := 0
+			fc.Connect(SignalSelectionChanged, "test-selection-changed", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				changed += 1
+				return cenums.EVENT_PASS
+			})
+			So(fc.GetFilename(), ShouldEqual, "")
+			So(fc.SelectFilename("a.go"), ShouldEqual, true)
+			So(changed, ShouldBeGreaterThan, 0)
+			So(fc.GetFilename(), ShouldEqual, filepath.Join(tmp, "a.go"))
+			So(fc.GetCurrentName(), ShouldEqual, "a.go")
+			So(fc.SelectFilename("missing.go"), ShouldEqual, false)
+			So(fc.SetFilename(filepath.Join(tmp, "sub", "c.go")), ShouldEqual, true)
+			So(fc.GetCurrentFolder(), ShouldEqual, filepath.Join(tmp, "sub"))
+			So(fc.GetFilenames(), ShouldResemble, []string{filepath.Join(tmp, "sub", "c.go")})
+			fc.UnselectFilename(filepath.Join(tmp, "sub", "c.go"))
+			So(fc.GetFilenames(), ShouldResemble, []string{filepath.Join(tmp, "sub", "c.go")})
+			fc.SetCurrentName("")
+			So(fc.GetFilenames(), ShouldHaveLength, 0)
+
+			fc.SetSelectMultiple(true)
+			So(fc.SetCurrentFolder(tmp), ShouldEqual, true)
+			So(fc.SelectFilename("a.go"), ShouldEqual, true)
+			So(fc.SelectFilename("b.txt"), ShouldEqual, true)
+			So(fc.GetFilenames(), ShouldResemble, []string{filepath.Join(tmp, "a.go"), filepath.Join(tmp, "b.txt")})
+			fc.UnselectAll()
+			fc.SelectAll()
+			So(fc.GetFilenames(), ShouldHaveLength, 2)
+		})
+
+		Convey("navigation", func() {
+			fc := NewFileChooserWidget(enums.FILE_CHOOSER_ACTION_OPEN)
+			So(fc.SetCurrentFolder(tmp), ShouldEqual, true)
+			activated := ""
+			fc.Connect(SignalFileActivated, "test-file-activated", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				activated, _ = argv[1].(string)
+				return cenums.EVENT_PASS
+			})
+			view := fc.GetFileList()
+			So(view.SetBoolProperty(PropertyHasFocus, true), ShouldBeNil)
+			view.RowActivated(NewTreePathFromIndices(1), nil)
+			So(fc.GetCurrentFolder(), ShouldEqual, filepath.Join(tmp, "sub"))
+			So(listNames(fc), ShouldResemble, []string{"../", "c.go"})
+			view.RowActivated(NewTreePathFromIndices(1), nil)
+			So(activated, ShouldEqual, filepath.Join(tmp, "sub", "c.go"))
+			view.ProcessEvent(cdk.NewEventKey(cdk.KeyLeft, 0, cdk.ModNone))
+			So(fc.GetCurrentFolder(), ShouldEqual, tmp)
+			So(view.SetBoolProperty(PropertyHasFocus, false), ShouldBeNil)
+
+			activated = ""
+			entry := fc.GetLocationEntry()
+			So(entry.SetBoolProperty(PropertyHasFocus, true), ShouldBeNil)
+			entry.SetText("s")
+			So(fc.(*CFileChooserWidget).completeLocation(), ShouldEqual, true)
+			So(entry.GetText(), ShouldEqual, "sub/")
+			entry.SetText("sub/c")
+			So(fc.(*CFileChooserWidget).completeLocation(), ShouldEqual, true)
+			So(entry.GetText(), ShouldEqual, "sub/c.go")
+			entry.SetText("x")
+			So(fc.(*CFileChooserWidget).completeLocation(), ShouldEqual, false)
+			entry.SetText("sub")
+			entry.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, rune(cdk.KeyEnter), cdk.ModNone))
+			So(fc.GetCurrentFolder(), ShouldEqual, filepath.Join(tmp, "sub"))
+			So(entry.GetText(), ShouldEqual, "")
+			entry.SetText("c.go")
+			entry.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, rune(cdk.KeyEnter), cdk.ModNone))
+			So(activated, ShouldEqual, filepath.Join(tmp, "sub", "c.go"))
+			So(fc.GetFilename(), ShouldEqual, filepath.Join(tmp, "sub", "c.go"))
+		})
+
+		Convey("save mode", func() {
+			fc := NewFileChooserWidget(enums.FILE_CHOOSER_ACTION_SAVE)
+			So(fc.SetCurrentFolder(tmp), ShouldEqual, true)
+			So(fc.GetFilename(), ShouldEqual, "")
+			fc.SetCurrentName("new.txt")
+			So(fc.GetFilename(), ShouldEqual, filepath.Join(tmp, "new.txt"))
+			So(fc.SelectFilename("b.txt"), ShouldEqual, true)
+			So(fc.GetCurrentName(), ShouldEqual, "b.txt")
+			fc.SetSelectMultiple(true)
+			So(fc.GetSelectMultiple(), ShouldEqual, false)
+			So(fc.SetFilename(filepath.Join(tmp, "sub", "d.txt")), ShouldEqual, true)
+			So(fc.GetCurrentFolder(), ShouldEqual, filepath.Join(tmp, "sub"))
+			So(fc.GetFilename(), ShouldEqual, filepath.Join(tmp, "sub", "d.txt"))
+		})
+
+		Convey("dialog", func() {
+			d := NewFileChooserDialog("Save", nil, enums.FILE_CHOOSER_ACTION_SAVE, StockCancel, enums.ResponseCancel, StockSave, enums.ResponseAccept)
+			So(d.GetAction(), ShouldEqual, enums.FILE_CHOOSER_ACTION_SAVE)
+			So(d.GetFileChooserWidget().GetWindow(), ShouldEqual, d)
+			cd := d.(*CFileChooserDialog)
+			So(d.SetCurrentFolder(tmp), ShouldEqual, true)
+			d.SetDoOverwriteConfirmation(true)
+			d.SetCurrentName("a.go")
+			decision := enums.FILE_CHOOSER_CONFIRMATION_SELECT_AGAIN
+			confirmations := 0
+			d.Connect(SignalConfirmOverwrite, "test-confirm-overwrite", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				confirmations += 1
+				So(argv[1], ShouldEqual, filepath.Join(tmp, "a.go"))
+				if confirmation, ok := argv[2].(*enums.FileChooserConfirmation); ok {
+					*confirmation = decision
+				}
+				return cenums.EVENT_STOP
+			})
+			d.Response(enums.ResponseAccept)
+			So(confirmations, ShouldEqual, 1)
+			So(len(cd.done), ShouldEqual, 0)
+			d.SetCurrentName("new.go")
+			d.Response(enums.ResponseAccept)
+			So(confirmations, ShouldEqual, 1)
+			So(len(cd.done), ShouldEqual, 1)
+			<-cd.done
+			d.SetCurrentName("a.go")
+			decision = enums.FILE_CHOOSER_CONFIRMATION_ACCEPT_FILENAME
+			d.Response(enums.ResponseAccept)
+			So(confirmations, ShouldEqual, 2)
+			So(len(cd.done), ShouldEqual, 1)
+			So(cd.response, ShouldEqual, enums.ResponseAccept)
+		})
+
+		Convey("dialog activation", func() {
+			d := NewFileChooserDialog("Open", nil, enums.FILE_CHOOSER_ACTION_OPEN, StockCancel, enums.ResponseCancel, StockOpen, enums.ResponseOk)
+			So(d.SetCurrentFolder(tmp), ShouldEqual, true)
+			activated := 0
+			d.Connect(SignalFileActivated, "test-file-activated", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				activated += 1
+				So(argv[0], ShouldEqual, d)
+				return cenums.EVENT_PASS
+			})
+			d.GetFileChooserWidget().GetFileList().RowActivated(NewTreePathFromIndices(2), nil)
+			So(activated, ShouldEqual, 1)
+			cd := d.(*CFileChooserDialog)
+			So(len(cd.done), ShouldEqual, 1)
+			So(cd.response, ShouldEqual, enums.ResponseOk)
+		})
+
+		Convey("builder", func() {
+			builder := NewBuilder()
+			_, err := builder.LoadFromString(`<interface>
+  <object class="GtkFileChooserWidget" id="test-file-chooser">
+    <property name="action">GTK_FILE_CHOOSER_ACTION_SELECT_FOLDER</property>
+    <property name="select_multiple">True</property>
+    <property name="show_hidden">True</property>
+  </object>
+</interface>`)
+			So(err, ShouldBeNil)
+			fc, ok := builder.GetWidget("test-file-chooser").(FileChooserWidget)
+			So(ok, ShouldEqual, true)
+			So(fc.GetAction(), ShouldEqual, enums.FILE_CHOOSER_ACTION_SELECT_FOLDER)
+			So(fc.GetSelectMultiple(), ShouldEqual, true)
+			So(fc.GetShowHidden(), ShouldEqual, true)
+		})
+	})
+}
@@ -0,0 +1,1038 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeFileChooserWidget cdk.CTypeTag = "ctk-file-chooser-widget"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeFileChooserWidget, func() interface{} { return MakeFileChooserWidget() })
+	ctkBuilderTranslators[TypeFileChooserWidget] = fileChooserBuilderTranslator
+}
+
+const (
+	fileChooserColumnName int = iota
+	fileChooserColumnSize
+	fileChooserColumnModified
+)
+
+// fileChooserRow is a single entry of the file list, in display order
+type fileChooserRow struct {
+	name string
+	path string
+	dir  bool
+	up   bool
+}
+
+// FileChooserWidget Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Box
+//	        +- VBox
+//	          +- FileChooserWidget
+//
+// The FileChooserWidget is a Widget suitable for selecting files. It is the
+// main building block of a FileChooserDialog. Most applications will only need
+// to use the latter; you can use FileChooserWidget as part of a larger window
+// if you have special needs.
+//
+// The FileChooserWidget presents the current folder above a list of the
+// folders and files within it, a location Entry for typing file names and a
+// CheckButton to toggle the display of hidden files. When one or more
+// FileFilter instances are added, a Button cycling through the filters is
+// shown as well.
+//
+// Folders are entered by activating them in the list; the Left and Backspace
+// keys of the list go up to the parent folder. Pressing Tab in the location
+// Entry completes the typed file name and pressing Enter either changes to the
+// typed folder or activates the typed file.
+type FileChooserWidget interface {
+	VBox
+	FileChooser
+	Buildable
+
+	Init() (already bool)
+	GetLocationEntry() (entry Entry)
+	GetFileList() (treeView TreeView)
+}
+
+var _ FileChooserWidget = (*CFileChooserWidget)(nil)
+
+// The CFileChooserWidget structure implements the FileChooserWidget interface
+// and is exported to facilitate type embedding with custom implementations. No
+// member variables are exported as the interface methods are the only intended
+// means of interacting with FileChooserWidget objects.
+type CFileChooserWidget struct {
+	CVBox
+
+	folder    string
+	rows      []*fileChooserRow
+	filters   []FileFilter
+	reloading bool
+	keyWindow Window
+	keyHandle string
+
+	folderLabel  Label
+	store        ListStore
+	view         TreeView
+	location     Entry
+	hiddenToggle CheckButton
+	filterButton Button
+}
+
+// MakeFileChooserWidget is used by the Buildable system to construct a new
+// FileChooserWidget.
+func MakeFileChooserWidget() FileChooserWidget {
+	return NewFileChooserWidget(enums.FILE_CHOOSER_ACTION_OPEN)
+}
+
+// NewFileChooserWidget is the constructor for new FileChooserWidget instances.
+// The current folder of the new FileChooserWidget is the current working
+// directory of the process.
+//
+// Parameters:
+//
+//	action	Open or Save mode for the widget
+func NewFileChooserWidget(action enums.FileChooserAction) FileChooserWidget {
+	fc := new(CFileChooserWidget)
+	fc.Init()
+	fc.SetAction(action)
+	return fc
+}
+
+// Init initializes a FileChooserWidget object. This must be called at least
+// once to set up the necessary defaults and allocate any memory structures.
+// Calling this more than once is safe though unnecessary. Only the first call
+// will result in any effect upon the FileChooserWidget instance. Init is used
+// in the NewFileChooserWidget constructor and only necessary when implementing
+// a derivative FileChooserWidget type.
+func (fc *CFileChooserWidget) Init() (already bool) {
+	if fc.InitTypeItem(TypeFileChooserWidget, fc) {
+		return true
+	}
+	fc.CVBox.Init()
+	_ = fc.InstallBuildableProperty(PropertyFileChooserAction, cdk.StructProperty, true, enums.FILE_CHOOSER_ACTION_OPEN)
+	_ = fc.InstallBuildableProperty(PropertyFileChooserFilter, cdk.StructProperty, true, nil)
+	_ = fc.InstallBuildableProperty(PropertySelectMultiple, cdk.BoolProperty, true, false)
+	_ = fc.InstallBuildableProperty(PropertyShowHidden, cdk.BoolProperty, true, false)
+	_ = fc.InstallBuildableProperty(PropertyDoOverwriteConfirmation, cdk.BoolProperty, true, false)
+	fc.keyWindow = nil
+	fc.keyHandle = fmt.Sprintf("%v-%v", FileChooserWidgetKeyHandle, fc.ObjectID())
+	fc.filters = make([]FileFilter, 0)
+	if wd, err := os.Getwd(); err == nil {
+		fc.folder = wd
+	} else {
+		fc.folder = string(filepath.Separator)
+	}
+
+	fc.folderLabel = NewLabel(fc.folder)
+	fc.folderLabel.SetSingleLineMode(true)
+	fc.folderLabel.Show()
+	fc.PackStart(fc.folderLabel, false, false, 0)
+
+	fc.store = NewListStore(cdk.StringProperty, cdk.StringProperty, cdk.StringProperty)
+	fc.view = NewTreeViewWithModel(fc.store)
+	fc.view.SetHeadersClickable(false)
+	nameColumn := NewTreeViewColumnWithAttributes("Name", TreeViewColumnAttributeText, fileChooserColumnName)
+	nameColumn.SetExpand(true)
+	fc.view.AppendColumn(nameColumn)
+	sizeColumn := NewTreeViewColumnWithAttributes("Size", TreeViewColumnAttributeText, fileChooserColumnSize)
+	sizeColumn.SetAlignment(1.0)
+	fc.view.AppendColumn(sizeColumn)
+	fc.view.AppendColumn(NewTreeViewColumnWithAttributes("Modified", TreeViewColumnAttributeText, fileChooserColumnModified))
+	fc.view.Show()
+	fc.view.Connect(SignalRowActivated, FileChooserWidgetRowActivatedHandle, fc.rowActivated)
+	fc.view.Connect(SignalCdkEvent, FileChooserWidgetListEventHandle, fc.listEvent)
+	fc.view.GetSelection().Connect(
+		SignalChanged,
+		fmt.Sprintf("%v-%v", FileChooserWidgetSelectionHandle, fc.ObjectID()),
+		fc.selectionChanged,
+	)
+	fc.PackStart(fc.view, true, true, 0)
+
+	fc.location = NewEntry("")
+	fc.location.SetSingleLineMode(true)
+	fc.location.Show()
+	fc.location.Connect(SignalCdkEvent, FileChooserWidgetLocationEventHandle, fc.locationEvent)
+	fc.PackStart(fc.location, false, false, 0)
+
+	options := NewHBox(false, 1)
+	options.Show()
+	fc.hiddenToggle = NewCheckButtonWithLabel("Show Hidden")
+	fc.hiddenToggle.Show()
+	fc.hiddenToggle.Connect(SignalToggled, FileChooserWidgetHiddenToggledHandle, fc.hiddenToggled)
+	options.PackStart(fc.hiddenToggle, false, false, 0)
+	fc.filterButton = NewButtonWithLabel("")
+	fc.filterButton.Connect(SignalClicked, FileChooserWidgetFilterClickedHandle, fc.filterClicked)
+	options.PackEnd(fc.filterButton, false, false, 0)
+	fc.PackStart(options, false, false, 0)
+
+	fc.reload()
+	return false
+}
+
+// SetWindow updates the Window the FileChooserWidget belongs to and starts
+// watching the key events of the Window for Tab completion within the location
+// Entry.
+func (fc *CFileChooserWidget) SetWindow(w Window) {
+	fc.CVBox.SetWindow(w)
+	fc.Lock()
+	previous := fc.keyWindow
+	if previous != nil && w != nil && previous.ObjectID() == w.ObjectID() {
+		fc.Unlock()
+		return
+	}
+	fc.keyWindow = w
+	fc.Unlock()
+	if previous != nil {
+		_ = previous.Disconnect(SignalEventKey, fc.keyHandle)
+	}
+	if w != nil {
+		w.Connect(SignalEventKey, fc.keyHandle, fc.windowKeyEvent)
+	}
+}
+
+// GetLocationEntry returns the Entry used for typing file names.
+func (fc *CFileChooserWidget) GetLocationEntry() (entry Entry) {
+	fc.RLock()
+	defer fc.RUnlock()
+	return fc.location
+}
+
+// GetFileList returns the TreeView listing the contents of the current folder.
+func (fc *CFileChooserWidget) GetFileList() (treeView TreeView) {
+	fc.RLock()
+	defer fc.RUnlock()
+	return fc.view
+}
+
+// SetAction sets the type of operation that the chooser is performing; the
+// user interface is adapted to suit the selected action. For example, an
+// option to create a new folder might be shown if the action is
+// FILE_CHOOSER_ACTION_SAVE but not if the action is FILE_CHOOSER_ACTION_OPEN.
+// Only folders are listed when the action is FILE_CHOOSER_ACTION_SELECT_FOLDER
+// or FILE_CHOOSER_ACTION_CREATE_FOLDER.
+//
+// Parameters:
+//
+//	action	the action that the file selector is performing
+func (fc *CFileChooserWidget) SetAction(action enums.FileChooserAction) {
+	if action == fc.GetAction() {
+		return
+	}
+	if err := fc.SetStructProperty(PropertyFileChooserAction, action); err != nil {
+		fc.LogErr(err)
+		return
+	}
+	if action == enums.FILE_CHOOSER_ACTION_SAVE && fc.GetSelectMultiple() {
+		fc.SetSelectMultiple(false)
+	}
+	fc.reload()
+}
+
+// GetAction returns the type of operation that the file chooser is
+// performing.
+// See: SetAction()
+func (fc *CFileChooserWidget) GetAction() (value enums.FileChooserAction) {
+	var ok bool
+	if v, err := fc.GetStructProperty(PropertyFileChooserAction); err != nil {
+		fc.LogErr(err)
+	} else if value, ok = v.(enums.FileChooserAction); !ok {
+		fc.LogError("invalid value stored in %v: %v (%T)", PropertyFileChooserAction, v, v)
+	}
+	return
+}
+
+// SetSelectMultiple sets whether multiple files can be selected in the file
+// selector. This is only relevant if the action is set to be
+// FILE_CHOOSER_ACTION_OPEN or FILE_CHOOSER_ACTION_SELECT_FOLDER.
+//
+// Parameters:
+//
+//	selectMultiple	TRUE if multiple files can be selected.
+func (fc *CFileChooserWidget) SetSelectMultiple(selectMultiple bool) {
+	if selectMultiple && fc.GetAction() == enums.FILE_CHOOSER_ACTION_SAVE {
+		fc.LogError("multiple selection is not supported in save mode")
+		return
+	}
+	if err := fc.SetBoolProperty(PropertySelectMultiple, selectMultiple); err != nil {
+		fc.LogErr(err)
+		return
+	}
+	if selectMultiple {
+		fc.view.GetSelection().SetMode(enums.SELECTION_MULTIPLE)
+	} else {
+		fc.view.GetSelection().SetMode(enums.SELECTION_SINGLE)
+	}
+}
+
+// GetSelectMultiple returns whether multiple files can be selected in the file
+// selector.
+// See: SetSelectMultiple()
+func (fc *CFileChooserWidget) GetSelectMultiple() (value bool) {
+	var err error
+	if value, err = fc.GetBoolProperty(PropertySelectMultiple); err != nil {
+		fc.LogErr(err)
+	}
+	return
+}
+
+// SetShowHidden sets whether hidden files and folders are displayed in the
+// file selector.
+//
+// Parameters:
+//
+//	showHidden	TRUE if hidden files and folders should be displayed.
+func (fc *CFileChooserWidget) SetShowHidden(showHidden bool) {
+	if showHidden == fc.GetShowHidden() {
+		return
+	}
+	if err := fc.SetBoolProperty(PropertyShowHidden, showHidden); err != nil {
+		fc.LogErr(err)
+		return
+	}
+	if fc.hiddenToggle.GetActive() != showHidden {
+		fc.hiddenToggle.SetActive(showHidden)
+	}
+	fc.reload()
+}
+
+// GetShowHidden returns whether hidden files and folders are displayed in the
+// file selector.
+// See: SetShowHidden()
+func (fc *CFileChooserWidget) GetShowHidden() (value bool) {
+	var err error
+	if value, err = fc.GetBoolProperty(PropertyShowHidden); err != nil {
+		fc.LogErr(err)
+	}
+	return
+}
+
+// SetDoOverwriteConfirmation sets whether a file chooser in
+// FILE_CHOOSER_ACTION_SAVE mode will present a confirmation dialog if the user
+// types a file name that already exists. This is FALSE by default.
+//
+// The confirmation itself is presented by FileChooserDialog, see the
+// confirm-overwrite signal for customizing the behaviour.
+//
+// Parameters:
+//
+//	doOverwriteConfirmation	whether to confirm overwriting in save mode
+func (fc *CFileChooserWidget) SetDoOverwriteConfirmation(doOverwriteConfirmation bool) {
+	if err := fc.SetBoolProperty(PropertyDoOverwriteConfirmation, doOverwriteConfirmation); err != nil {
+		fc.LogErr(err)
+	}
+}
+
+// GetDoOverwriteConfirmation queries whether a file chooser is set to confirm
+// for overwriting when the user types a file name that already exists.
+// See: SetDoOverwriteConfirmation()
+func (fc *CFileChooserWidget) GetDoOverwriteConfirmation() (value bool) {
+	var err error
+	if value, err = fc.GetBoolProperty(PropertyDoOverwriteConfirmation); err != nil {
+		fc.LogErr(err)
+	}
+	return
+}
+
+// SetCurrentName sets the current name in the file selector, as if entered by
+// the user. Note that the name passed in here is a UTF-8 string rather than a
+// filename. This function is meant for such uses as a suggested name in a
+// "Save As..." dialog.
+//
+// If you want to preselect a particular existing file, you should use
+// SetFilename or SelectFilename instead.
+//
+// Parameters:
+//
+//	name	the filename to use, as a UTF-8 string
+func (fc *CFileChooserWidget) SetCurrentName(name string) {
+	fc.location.SetText(name)
+	fc.location.SetPosition(len([]rune(name)))
+}
+
+// GetCurrentName returns the text within the location Entry, which is the
+// name of the file to be saved when in FILE_CHOOSER_ACTION_SAVE mode.
+func (fc *CFileChooserWidget) GetCurrentName() (name string) {
+	return fc.location.GetText()
+}
+
+// GetFilename returns the filename for the currently selected file in the
+// file selector. If multiple files are selected, one of the filenames will be
+// returned at random. In FILE_CHOOSER_ACTION_SAVE mode this is the current
+// name resolved within the current folder and in
+// FILE_CHOOSER_ACTION_SELECT_FOLDER mode the current folder is returned if no
+// folder is selected. Returns an empty string if there is no selection.
+func (fc *CFileChooserWidget) GetFilename() (filename string) {
+	if filenames := fc.GetFilenames(); len(filenames) > 0 {
+		filename = filenames[0]
+	}
+	return
+}
+
+// SetFilename sets filename as the current filename for the file chooser, by
+// changing to the file's parent folder and actually selecting the file in
+// list; all other files are unselected. If the chooser is in
+// FILE_CHOOSER_ACTION_SAVE mode, the file's base name will also appear in the
+// dialog's file name entry. Returns TRUE if the file could be selected.
+//
+// Parameters:
+//
+//	filename	the filename to set as current
+func (fc *CFileChooserWidget) SetFilename(filename string) (ok bool) {
+	filename = fc.resolve(filename)
+	fc.UnselectAll()
+	if fc.GetAction() == enums.FILE_CHOOSER_ACTION_SAVE {
+		if ok = fc.SetCurrentFolder(filepath.Dir(filename)); ok {
+			fc.SelectFilename(filename)
+			fc.SetCurrentName(filepath.Base(filename))
+		}
+		return
+	}
+	return fc.SelectFilename(filename)
+}
+
+// SelectFilename selects a filename. If the file name isn't in the current
+// folder of chooser, then the current folder of chooser will be changed to the
+// folder containing filename. Returns TRUE if the file was found and selected.
+//
+// Parameters:
+//
+//	filename	the filename to select
+func (fc *CFileChooserWidget) SelectFilename(filename string) (ok bool) {
+	filename = fc.resolve(filename)
+	if folder := filepath.Dir(filename); folder != fc.GetCurrentFolder() {
+		if !fc.SetCurrentFolder(folder) {
+			return false
+		}
+	}
+	if path := fc.pathOfFilename(filename); path != nil {
+		selection := fc.view.GetSelection()
+		if selection.GetMode() != enums.SELECTION_MULTIPLE {
+			fc.view.SetCursor(path, nil)
+		}
+		selection.SelectPath(path)
+		return true
+	}
+	return false
+}
+
+// UnselectFilename unselects a currently selected filename. If the filename
+// is not in the current folder, does not exist, or is otherwise not currently
+// selected, does nothing.
+//
+// Parameters:
+//
+//	filename	the filename to unselect
+func (fc *CFileChooserWidget) UnselectFilename(filename string) {
+	if path := fc.pathOfFilename(fc.resolve(filename)); path != nil {
+		fc.view.GetSelection().UnselectPath(path)
+	}
+}
+
+// SelectAll selects all the files in the current folder of a file chooser.
+// This does nothing unless multiple selection is enabled.
+func (fc *CFileChooserWidget) SelectAll() {
+	if fc.GetSelectMultiple() {
+		fc.view.GetSelection().SelectAll()
+	}
+}
+
+// UnselectAll unselects all the files in the current folder of a file
+// chooser.
+func (fc *CFileChooserWidget) UnselectAll() {
+	fc.view.GetSelection().UnselectAll()
+}
+
+// GetFilenames lists all the selected files and subfolders in the current
+// folder of chooser. The returned names are full absolute paths. Folders are
+// only included when the action is FILE_CHOOSER_ACTION_SELECT_FOLDER or
+// FILE_CHOOSER_ACTION_CREATE_FOLDER.
+func (fc *CFileChooserWidget) GetFilenames() (filenames []string) {
+	action := fc.GetAction()
+	folderMode := action == enums.FILE_CHOOSER_ACTION_SELECT_FOLDER || action == enums.FILE_CHOOSER_ACTION_CREATE_FOLDER
+	name := strings.TrimSpace(fc.location.GetText())
+	switch action {
+	case enums.FILE_CHOOSER_ACTION_SAVE, enums.FILE_CHOOSER_ACTION_CREATE_FOLDER:
+		if name != "" {
+			return []string{fc.resolve(name)}
+		}
+		if action == enums.FILE_CHOOSER_ACTION_SAVE {
+			return
+		}
+	}
+	for _, row := range fc.getSelectedRows() {
+		if !row.up && row.dir == folderMode {
+			filenames = append(filenames, row.path)
+		}
+	}
+	if len(filenames) == 0 {
+		if name != "" {
+			filenames = append(filenames, fc.resolve(name))
+		} else if folderMode {
+			filenames = append(filenames, fc.GetCurrentFolder())
+		}
+	}
+	return
+}
+
+// SetCurrentFolder sets the current folder for chooser from a local filename.
+// The user will be shown the full contents of the current folder, plus user
+// interface elements for navigating to other folders. Returns TRUE if the
+// folder could be changed successfully.
+//
+// Emits: SignalCurrentFolderChanged, Argv=[FileChooserWidget instance, folder]
+//
+// Parameters:
+//
+//	filename	the full path of the new current folder
+func (fc *CFileChooserWidget) SetCurrentFolder(filename string) (ok bool) {
+	folder := fc.resolve(filename)
+	if info, err := os.Stat(folder); err != nil {
+		fc.LogErr(err)
+		return false
+	} else if !info.IsDir() {
+		fc.LogError("not a folder: %v", folder)
+		return false
+	}
+	fc.Lock()
+	fc.folder = folder
+	fc.Unlock()
+	fc.folderLabel.SetText(folder)
+	if fc.GetAction() == enums.FILE_CHOOSER_ACTION_OPEN {
+		fc.location.SetText("")
+	}
+	fc.reload()
+	fc.Emit(SignalCurrentFolderChanged, fc, folder)
+	return true
+}
+
+// GetCurrentFolder returns the full path of the current folder of the file
+// chooser.
+// See: SetCurrentFolder()
+func (fc *CFileChooserWidget) GetCurrentFolder() (folder string) {
+	fc.RLock()
+	defer fc.RUnlock()
+	return fc.folder
+}
+
+// AddFilter adds filter to the list of filters that the user can select
+// between. When a filter is selected, only files that are passed by that
+// filter are displayed. The first filter added becomes the current filter.
+//
+// Parameters:
+//
+//	filter	a FileFilter
+func (fc *CFileChooserWidget) AddFilter(filter FileFilter) {
+	if filter == nil {
+		return
+	}
+	fc.Lock()
+	fc.filters = append(fc.filters, filter)
+	fc.Unlock()
+	if fc.GetFilter() == nil {
+		fc.SetFilter(filter)
+	} else {
+		fc.updateFilterButton()
+	}
+}
+
+// RemoveFilter removes filter from the list of filters that the user can
+// select between. If the filter is the current filter, the first remaining
+// filter becomes the current filter.
+//
+// Parameters:
+//
+//	filter	a FileFilter
+func (fc *CFileChooserWidget) RemoveFilter(filter FileFilter) {
+	if filter == nil {
+		return
+	}
+	fc.Lock()
+	var filters []FileFilter
+	for _, f := range fc.filters {
+		if f.ObjectID() != filter.ObjectID() {
+			filters = append(filters, f)
+		}
+	}
+	fc.filters = filters
+	fc.Unlock()
+	if current := fc.GetFilter(); current != nil && current.ObjectID() == filter.ObjectID() {
+		if len(filters) > 0 {
+			fc.SetFilter(filters[0])
+		} else {
+			fc.SetFilter(nil)
+		}
+	} else {
+		fc.updateFilterButton()
+	}
+}
+
+// ListFilters lists the current set of user-selectable filters.
+func (fc *CFileChooserWidget) ListFilters() (filters []FileFilter) {
+	fc.RLock()
+	defer fc.RUnlock()
+	return append(filters, fc.filters...)
+}
+
+// SetFilter sets the current filter; only the files that pass the filter will
+// be displayed. If the user-selectable list of filters is non-empty, then the
+// filter should be one of the filters in that list. Setting the current filter
+// when the list of filters is empty is useful if you want to restrict the
+// displayed set of files without letting the user change it.
+//
+// Parameters:
+//
+//	filter	a FileFilter, or nil
+func (fc *CFileChooserWidget) SetFilter(filter FileFilter) {
+	var value interface{}
+	if filter != nil {
+		value = filter
+	}
+	if err := fc.SetStructProperty(PropertyFileChooserFilter, value); err != nil {
+		fc.LogErr(err)
+		return
+	}
+	fc.updateFilterButton()
+	fc.reload()
+}
+
+// GetFilter returns the current filter.
+// See: SetFilter()
+func (fc *CFileChooserWidget) GetFilter() (filter FileFilter) {
+	if v, err := fc.GetStructProperty(PropertyFileChooserFilter); err != nil {
+		fc.LogErr(err)
+	} else if v != nil {
+		var ok bool
+		if filter, ok = v.(FileFilter); !ok {
+			fc.LogError("invalid value stored in %v: %v (%T)", PropertyFileChooserFilter, v, v)
+		}
+	}
+	return
+}
+
+// resolve returns the absolute and cleaned path of the given filename, with a
+// leading "~" expanded to the home directory of the user and relative paths
+// resolved within the current folder.
+func (fc *CFileChooserWidget) resolve(filename string) string {
+	filename = fileChooserExpandHome(filename)
+	if !filepath.IsAbs(filename) {
+		filename = filepath.Join(fc.GetCurrentFolder(), filename)
+	}
+	return filepath.Clean(filename)
+}
+
+func (fc *CFileChooserWidget) pathOfFilename(filename string) (path TreePath) {
+	fc.RLock()
+	defer fc.RUnlock()
+	for idx, row := range fc.rows {
+		if !row.up && row.path == filename {
+			return NewTreePathFromIndices(idx)
+		}
+	}
+	return nil
+}
+
+func (fc *CFileChooserWidget) getRow(path TreePath) (row *fileChooserRow) {
+	fc.RLock()
+	defer fc.RUnlock()
+	if path.GetDepth() == 1 {
+		if idx := path.GetIndices()[0]; idx >= 0 && idx < len(fc.rows) {
+			return fc.rows[idx]
+		}
+	}
+	return nil
+}
+
+func (fc *CFileChooserWidget) getSelectedRows() (rows []*fileChooserRow) {
+	_, paths := fc.view.GetSelection().GetSelectedRows()
+	for _, path := range paths {
+		if row := fc.getRow(path); row != nil {
+			rows = append(rows, row)
+		}
+	}
+	return
+}
+
+// include returns TRUE if the directory entry is to be listed
+func (fc *CFileChooserWidget) include(entry os.DirEntry, showHidden, folderMode bool, filter FileFilter) bool {
+	name := entry.Name()
+	if !showHidden && strings.HasPrefix(name, ".") {
+		return false
+	}
+	if fileChooserIsDir(fc.GetCurrentFolder(), entry) {
+		return true
+	}
+	if folderMode {
+		return false
+	}
+	if filter != nil {
+		return filter.Filter(FileFilterInfo{
+			Contains:    enums.FILE_FILTER_FILENAME | enums.FILE_FILTER_DISPLAY_NAME,
+			Filename:    filepath.Join(fc.GetCurrentFolder(), name),
+			DisplayName: name,
+		})
+	}
+	return true
+}
+
+// reload reads the current folder and replaces the contents of the file list,
+// folders are listed first and the parent folder is listed as "../"
+func (fc *CFileChooserWidget) reload() {
+	folder := fc.GetCurrentFolder()
+	action := fc.GetAction()
+	folderMode := action == enums.FILE_CHOOSER_ACTION_SELECT_FOLDER || action == enums.FILE_CHOOSER_ACTION_CREATE_FOLDER
+	showHidden := fc.GetShowHidden()
+	filter := fc.GetFilter()
+
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		fc.LogErr(err)
+	}
+	var rows, dirs, files []*fileChooserRow
+	if parent := filepath.Dir(folder); parent != folder {
+		rows = append(rows, &fileChooserRow{name: "..", path: parent, dir: true, up: true})
+	}
+	for _, entry := range entries {
+		if !fc.include(entry, showHidden, folderMode, filter) {
+			continue
+		}
+		row := &fileChooserRow{
+			name: entry.Name(),
+			path: filepath.Join(folder, entry.Name()),
+			dir:  fileChooserIsDir(folder, entry),
+		}
+		if row.dir {
+			dirs = append(dirs, row)
+		} else {
+			files = append(files, row)
+		}
+	}
+	for _, list := range [][]*fileChooserRow{dirs, files} {
+		sort.SliceStable(list, func(i, j int) bool {
+			return strings.ToLower(list[i].name) < strings.ToLower(list[j].name)
+		})
+		rows = append(rows, list...)
+	}
+
+	fc.Lock()
+	fc.reloading = true
+	fc.rows = rows
+	fc.Unlock()
+	fc.view.GetSelection().UnselectAll()
+	fc.store.Clear()
+	for _, row := range rows {
+		name, size, modified := row.name, "", ""
+		if row.dir {
+			name += string(filepath.Separator)
+		}
+		if !row.up {
+			if info, err := os.Stat(row.path); err == nil {
+				modified = info.ModTime().Format("2006-01-02 15:04")
+				if !row.dir {
+					size = fileChooserFormatSize(info.Size())
+				}
+			}
+		}
+		if _, err := fc.store.InsertWithValues(-1, []int{fileChooserColumnName, fileChooserColumnSize, fileChooserColumnModified}, []interface{}{name, size, modified}); err != nil {
+			fc.LogErr(err)
+		}
+	}
+	if len(rows) > 0 {
+		fc.view.SetCursor(NewTreePathFromIndices(0), nil)
+		fc.view.GetSelection().UnselectAll()
+	}
+	fc.Lock()
+	fc.reloading = false
+	fc.Unlock()
+	fc.Emit(SignalSelectionChanged, fc)
+	fc.Invalidate()
+}
+
+func (fc *CFileChooserWidget) updateFilterButton() {
+	filters := fc.ListFilters()
+	if len(filters) == 0 {
+		fc.filterButton.Hide()
+		return
+	}
+	label := "All Files"
+	if filter := fc.GetFilter(); filter != nil {
+		label = filter.GetName()
+	}
+	fc.filterButton.SetLabel(label)
+	fc.filterButton.Show()
+}
+
+// completeLocation completes the file name typed into the location Entry with
+// the longest common prefix of the matching names within the typed folder.
+// Returns TRUE if the text of the location Entry was changed.
+func (fc *CFileChooserWidget) completeLocation() (completed bool) {
+	text := fc.location.GetText()
+	dirPart, prefix := filepath.Split(text)
+	folder := fc.GetCurrentFolder()
+	if dirPart != "" {
+		folder = fc.resolve(dirPart)
+	}
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return false
+	}
+	action := fc.GetAction()
+	folderMode := action == enums.FILE_CHOOSER_ACTION_SELECT_FOLDER || action == enums.FILE_CHOOSER_ACTION_CREATE_FOLDER
+	showHidden := fc.GetShowHidden() || strings.HasPrefix(prefix, ".")
+	var matches []os.DirEntry
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if !showHidden && strings.HasPrefix(name, ".") {
+			continue
+		}
+		if folderMode && !fileChooserIsDir(folder, entry) {
+			continue
+		}
+		matches = append(matches, entry)
+	}
+	if len(matches) == 0 {
+		return false
+	}
+	common := matches[0].Name()
+	for _, entry := range matches[1:] {
+		name := entry.Name()
+		for !strings.HasPrefix(name, common) {
+			common = common[:len(common)-1]
+		}
+	}
+	if len(matches) == 1 && fileChooserIsDir(folder, matches[0]) {
+		common += string(filepath.Separator)
+	}
+	if common == prefix {
+		return false
+	}
+	completion := dirPart + common
+	fc.location.SetText(completion)
+	fc.location.SetPosition(len([]rune(completion)))
+	return true
+}
+
+// activateLocation handles the Enter key within the location Entry, changing
+// to the typed folder or activating the typed file.
+func (fc *CFileChooserWidget) activateLocation() {
+	text := strings.TrimSpace(fc.location.GetText())
+	if text == "" {
+		return
+	}
+	filename := fc.resolve(text)
+	info, err := os.Stat(filename)
+	if err == nil && info.IsDir() {
+		action := fc.GetAction()
+		if fc.SetCurrentFolder(filename) && action != enums.FILE_CHOOSER_ACTION_OPEN {
+			fc.location.SetText("")
+		}
+		return
+	}
+	switch fc.GetAction() {
+	case enums.FILE_CHOOSER_ACTION_OPEN:
+		if err != nil {
+			fc.LogErr(err)
+			return
+		}
+		fc.UnselectAll()
+		fc.SelectFilename(filename)
+	case enums.FILE_CHOOSER_ACTION_SAVE:
+		if folder := filepath.Dir(filename); folder != fc.GetCurrentFolder() {
+			if !fc.SetCurrentFolder(folder) {
+				return
+			}
+			fc.SetCurrentName(filepath.Base(filename))
+		}
+	default:
+		return
+	}
+	fc.Emit(SignalFileActivated, fc, filename)
+}
+
+func (fc *CFileChooserWidget) rowActivated(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if len(argv) < 2 {
+		return cenums.EVENT_PASS
+	}
+	path, ok := argv[1].(TreePath)
+	if !ok {
+		return cenums.EVENT_PASS
+	}
+	if row := fc.getRow(path); row != nil {
+		if row.dir {
+			fc.SetCurrentFolder(row.path)
+		} else {
+			fc.Emit(SignalFileActivated, fc, row.path)
+		}
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+func (fc *CFileChooserWidget) selectionChanged(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	fc.RLock()
+	reloading := fc.reloading
+	fc.RUnlock()
+	if reloading {
+		return cenums.EVENT_PASS
+	}
+	if rows := fc.getSelectedRows(); len(rows) == 1 && !rows[0].dir {
+		if action := fc.GetAction(); action == enums.FILE_CHOOSER_ACTION_OPEN || action == enums.FILE_CHOOSER_ACTION_SAVE {
+			fc.SetCurrentName(rows[0].name)
+		}
+	}
+	fc.Emit(SignalSelectionChanged, fc)
+	return cenums.EVENT_PASS
+}
+
+func (fc *CFileChooserWidget) hiddenToggled(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	fc.SetShowHidden(fc.hiddenToggle.GetActive())
+	return cenums.EVENT_PASS
+}
+
+func (fc *CFileChooserWidget) filterClicked(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	filters := fc.ListFilters()
+	if len(filters) == 0 {
+		return cenums.EVENT_PASS
+	}
+	next := filters[0]
+	if current := fc.GetFilter(); current != nil {
+		for idx, filter := range filters {
+			if filter.ObjectID() == current.ObjectID() {
+				next = filters[(idx+1)%len(filters)]
+				break
+			}
+		}
+	}
+	fc.SetFilter(next)
+	return cenums.EVENT_STOP
+}
+
+func (fc *CFileChooserWidget) listEvent(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if evt, ok := argv[1].(cdk.Event); ok {
+		if e, ok := evt.(*cdk.EventKey); ok && fc.view.HasFocus() {
+			switch {
+			case e.Key() == cdk.KeyLeft && e.Modifiers() == cdk.ModNone,
+				cdk.Key(e.Rune()) == cdk.KeyBackspace,
+				cdk.Key(e.Rune()) == cdk.KeyBackspace2:
+				if parent := filepath.Dir(fc.GetCurrentFolder()); parent != fc.GetCurrentFolder() {
+					fc.SetCurrentFolder(parent)
+				}
+				return cenums.EVENT_STOP
+			}
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (fc *CFileChooserWidget) locationEvent(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if evt, ok := argv[1].(cdk.Event); ok {
+		if e, ok := evt.(*cdk.EventKey); ok && fc.location.HasFocus() {
+			switch e.Rune() {
+			case 10, 13:
+				fc.activateLocation()
+				return cenums.EVENT_STOP
+			}
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (fc *CFileChooserWidget) windowKeyEvent(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if _, event, ok := ArgvSignalEvent(argv...); ok {
+		if e, ok := event.(*cdk.EventKey); ok {
+			if !fc.IsVisible() || !fc.location.HasFocus() || e.Modifiers().Has(cdk.ModShift) {
+				return cenums.EVENT_PASS
+			}
+			if e.RuneAsKey() == cdk.KeyTAB || e.Key() == cdk.KeyTAB {
+				if fc.completeLocation() {
+					return cenums.EVENT_STOP
+				}
+			}
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+// fileChooserExpandHome replaces a leading "~" with the home directory of the
+// current user
+func fileChooserExpandHome(filename string) string {
+	if filename == "~" || strings.HasPrefix(filename, "~"+string(filepath.Separator)) {
+		if home, err := os.UserHomeDir(); err == nil {
+			return home + filename[1:]
+		}
+	}
+	return filename
+}
+
+// fileChooserIsDir returns TRUE if the entry is a directory, following
+// symbolic links
+func fileChooserIsDir(folder string, entry os.DirEntry) bool {
+	if entry.IsDir() {
+		return true
+	}
+	if entry.Type()&os.ModeSymlink != 0 {
+		if info, err := os.Stat(filepath.Join(folder, entry.Name())); err == nil {
+			return info.IsDir()
+		}
+	}
+	return false
+}
+
+// fileChooserFormatSize returns the size in a short human-readable form
+func fileChooserFormatSize(size int64) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size)
+	for _, unit := range []string{"K", "M", "G", "T"} {
+		value /= 1024.0
+		if value < 1024.0 || unit == "T" {
+			return fmt.Sprintf("%.1f %s", value, unit)
+		}
+	}
+	return ""
+}
+
+const FileChooserWidgetKeyHandle = "file-chooser-widget-key-handler"
+
+const FileChooserWidgetRowActivatedHandle = "file-chooser-widget-row-activated-handler"
+
+const FileChooserWidgetListEventHandle = "file-chooser-widget-list-event-handler"
+
+const FileChooserWidgetSelectionHandle = "file-chooser-widget-selection-handler"
+
+const FileChooserWidgetLocationEventHandle = "file-chooser-widget-location-event-handler"
+
+const FileChooserWidgetHiddenToggledHandle = "file-chooser-widget-hidden-toggled-handler"
+
+const FileChooserWidgetFilterClickedHandle = "file-chooser-widget-filter-clicked-handler"
@@ -0,0 +1,210 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"path/filepath"
+
+	"github.com/go-curses/cdk"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeFileFilter cdk.CTypeTag = "ctk-file-filter"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeFileFilter, func() interface{} { return MakeFileFilter() })
+}
+
+// FileFilterInfo is used to pass information about the tested file to
+// FileFilter.Filter. The Contains field indicates which of the other fields
+// are filled in.
+type FileFilterInfo struct {
+	Contains    enums.FileFilterFlags
+	Filename    string
+	Uri         string
+	DisplayName string
+	MimeType    string
+}
+
+// FileFilterFunc is the signature of the callbacks given to
+// FileFilter.AddCustom. The function returns TRUE if the file should be
+// displayed.
+type FileFilterFunc = func(info FileFilterInfo) (include bool)
+
+type fileFilterRule struct {
+	needed  enums.FileFilterFlags
+	pattern string
+	fn      FileFilterFunc
+}
+
+// FileFilter Hierarchy:
+//
+//	Object
+//	  +- FileFilter
+//
+// A FileFilter can be used to restrict the files being shown in a
+// FileChooser. Files can be filtered based on their name, with AddPattern, or
+// with a custom filter function, with AddCustom. A file is shown if it matches
+// any of the rules of the filter, so a filter without rules shows no files at
+// all. The name of the FileFilter, from Object.SetName, is used as the
+// human-readable label of the filter.
+type FileFilter interface {
+	Object
+
+	Init() (already bool)
+	AddPattern(pattern string)
+	AddCustom(needed enums.FileFilterFlags, fn FileFilterFunc)
+	GetNeeded() (needed enums.FileFilterFlags)
+	Filter(info FileFilterInfo) (include bool)
+}
+
+var _ FileFilter = (*CFileFilter)(nil)
+
+// The CFileFilter structure implements the FileFilter interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with FileFilter objects.
+type CFileFilter struct {
+	CObject
+
+	rules []*fileFilterRule
+}
+
+// MakeFileFilter is used by the Buildable system to construct a new
+// FileFilter.
+func MakeFileFilter() FileFilter {
+	return NewFileFilter()
+}
+
+// NewFileFilter is the constructor for new FileFilter instances. The new
+// filter has no rules and therefore does not accept any files.
+func NewFileFilter() FileFilter {
+	f := new(CFileFilter)
+	f.Init()
+	return f
+}
+
+// NewFileFilterWithPatterns is a convenience constructor for a named
+// FileFilter accepting any file matching one of the given glob patterns.
+//
+// Parameters:
+//
+//	name	the human-readable name of the filter
+//	patterns	shell style glob patterns, ie: "*.go"
+func NewFileFilterWithPatterns(name string, patterns ...string) FileFilter {
+	f := NewFileFilter()
+	f.SetName(name)
+	for _, pattern := range patterns {
+		f.AddPattern(pattern)
+	}
+	return f
+}
+
+// Init initializes a FileFilter object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the FileFilter instance. Init is used in the
+// NewFileFilter constructor and only necessary when implementing a derivative
+// FileFilter type.
+func (f *CFileFilter) Init() (already bool) {
+	if f.InitTypeItem(TypeFileFilter, f) {
+		return true
+	}
+	f.CObject.Init()
+	f.rules = make([]*fileFilterRule, 0)
+	return false
+}
+
+// AddPattern adds a rule allowing a shell style glob to a filter. The pattern
+// is matched against the display name of the file, using the syntax of
+// filepath.Match.
+//
+// Parameters:
+//
+//	pattern	a shell style glob
+func (f *CFileFilter) AddPattern(pattern string) {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		f.LogError("invalid pattern %q: %v", pattern, err)
+		return
+	}
+	f.Lock()
+	f.rules = append(f.rules, &fileFilterRule{
+		needed:  enums.FILE_FILTER_DISPLAY_NAME,
+		pattern: pattern,
+	})
+	f.Unlock()
+}
+
+// AddCustom adds a rule to a filter that allows files based on a custom
+// callback function. The bitfield needed which is passed in provides
+// information about what sorts of information that the filter function needs;
+// this allows CTK to avoid retrieving expensive information when it isn't
+// needed by the filter.
+//
+// Parameters:
+//
+//	needed	bitfield of flags indicating the information that the custom filter function needs.
+//	fn	callback function; if the function returns TRUE, then the file will be displayed.
+func (f *CFileFilter) AddCustom(needed enums.FileFilterFlags, fn FileFilterFunc) {
+	if fn == nil {
+		return
+	}
+	f.Lock()
+	f.rules = append(f.rules, &fileFilterRule{
+		needed: needed,
+		fn:     fn,
+	})
+	f.Unlock()
+}
+
+// GetNeeded gets the fields that need to be filled in for the FileFilterInfo
+// passed to Filter. This function will not typically be used by applications;
+// it is intended principally for use in the implementation of FileChooser.
+func (f *CFileFilter) GetNeeded() (needed enums.FileFilterFlags) {
+	f.RLock()
+	defer f.RUnlock()
+	for _, rule := range f.rules {
+		needed = needed.Set(rule.needed)
+	}
+	return
+}
+
+// Filter tests whether a file should be displayed according to filter. The
+// FileFilterInfo structure info should include the fields returned from
+// GetNeeded. This function will not typically be used by applications; it is
+// intended principally for use in the implementation of FileChooser.
+//
+// Parameters:
+//
+//	info	a FileFilterInfo structure containing information about a file.
+func (f *CFileFilter) Filter(info FileFilterInfo) (include bool) {
+	f.RLock()
+	rules := append([]*fileFilterRule{}, f.rules...)
+	f.RUnlock()
+	for _, rule := range rules {
+		if info.Contains&rule.needed != rule.needed {
+			continue
+		}
+		if rule.fn != nil {
+			if rule.fn(info) {
+				return true
+			}
+		} else if matched, _ := filepath.Match(rule.pattern, info.DisplayName); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -385,7 +385,7 @@ func (f *CFrame) GetSizeRequest() (width, height int) {
 	_, yAlign := f.GetLabelAlign()
 	size := ptypes.NewRectangle(f.CWidget.GetSizeRequest())
 	if child := f.GetChild(); child != nil {
-		childSize := ptypes.NewRectangle(child.GetSizeRequest())
+		childSize := ptypes.NewRectangle(GetGroupedSizeRequest(child))
 		if size.W <= -1 {
 			if childSize.W > -1 {
 				size.W = 1 + childSize.W + 1
@@ -0,0 +1,58 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+)
+
+const (
+	TypeHPaned cdk.CTypeTag = "ctk-h-paned"
+)
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeHPaned, func() interface{} { return MakeHPaned() })
+}
+
+// HPaned is a Paned with the two panes arranged side by side, with a vertical divider between them.
+type HPaned interface {
+	Paned
+}
+
+var _ HPaned = (*CHPaned)(nil)
+
+type CHPaned struct {
+	CPaned
+}
+
+func MakeHPaned() HPaned {
+	return NewHPaned()
+}
+
+func NewHPaned() HPaned {
+	p := new(CHPaned)
+	p.Init()
+	return p
+}
+
+func (p *CHPaned) Init() bool {
+	if p.InitTypeItem(TypeHPaned, p) {
+		return true
+	}
+	p.CPaned.Init()
+	p.SetOrientation(cenums.ORIENTATION_HORIZONTAL)
+	return false
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+)
+
+const TypeHScale cdk.CTypeTag = "ctk-h-scale"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeHScale, func() interface{} { return MakeHScale() })
+}
+
+// HScale Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Range
+//	      +- Scale
+//	        +- HScale
+//
+// The HScale Widget is used to allow the user to select a value using a
+// horizontal slider.
+type HScale interface {
+	Scale
+}
+
+var _ HScale = (*CHScale)(nil)
+
+// The CHScale structure implements the HScale interface and is exported to
+// facilitate type embedding with custom implementations. No member variables
+// are exported as the interface methods are the only intended means of
+// interacting with HScale objects.
+type CHScale struct {
+	CScale
+}
+
+// MakeHScale is used by the Buildable system to construct a new HScale.
+func MakeHScale() HScale {
+	return NewHScale(nil)
+}
+
+// NewHScale is the constructor for new HScale instances.
+//
+// Parameters:
+//
+//	adjustment	the Adjustment which sets the range of the scale, or nil to create a new one
+func NewHScale(adjustment *CAdjustment) HScale {
+	s := &CHScale{}
+	s.orientation = cenums.ORIENTATION_HORIZONTAL
+	s.Init()
+	s.SetAdjustment(adjustment)
+	return s
+}
+
+// NewHScaleWithRange creates a new HScale with an Adjustment that ranges from
+// min to max, moving by step with the arrow keys and by ten steps with the PgUp
+// and PgDn keys.
+//
+// Parameters:
+//
+//	min	minimum value
+//	max	maximum value
+//	step	step increment (tick size) used with keyboard shortcuts
+func NewHScaleWithRange(min, max, step int) HScale {
+	s := NewHScale(nil)
+	s.SetRange(min, max)
+	s.SetIncrements(step, step*10)
+	s.SetValue(min)
+	return s
+}
+
+// Init initializes an HScale object. This must be called at least once to set
+// up the necessary defaults and allocate any memory structures. Calling this
+// more than once is safe though unnecessary. Only the first call will result in
+// any effect upon the HScale instance. Init is used in the NewHScale
+// constructor and only necessary when implementing a derivative HScale type.
+func (s *CHScale) Init() (already bool) {
+	if s.InitTypeItem(TypeHScale, s) {
+		return true
+	}
+	s.CScale.Init()
+	return false
+}
@@ -37,6 +37,22 @@ const (
 	ASSISTANT_PAGE_PROGRESS
 )
 
+func (t AssistantPageType) FromString(value string) (enum interface{}, err error) {
+	switch strings.TrimPrefix(strings.ToLower(value), "gtk_assistant_page_") {
+	case "content":
+		return ASSISTANT_PAGE_CONTENT, nil
+	case "intro":
+		return ASSISTANT_PAGE_INTRO, nil
+	case "confirm":
+		return ASSISTANT_PAGE_CONFIRM, nil
+	case "summary":
+		return ASSISTANT_PAGE_SUMMARY, nil
+	case "progress":
+		return ASSISTANT_PAGE_PROGRESS, nil
+	}
+	return nil, fmt.Errorf("unknown value for AssistantPageType.FromString(%v)", value)
+}
+
 type BuilderError uint64
 
 const (
@@ -232,6 +248,24 @@ const (
 	FILL
 )
 
+func (i AttachOptions) FromString(value string) (enum interface{}, err error) {
+	var options AttachOptions
+	for _, part := range strings.Split(value, "|") {
+		switch strings.TrimPrefix(strings.ToLower(strings.TrimSpace(part)), "gtk_") {
+		case "expand":
+			options = options.Set(EXPAND)
+		case "shrink":
+			options = options.Set(SHRINK)
+		case "fill":
+			options = options.Set(FILL)
+		case "", "0":
+		default:
+			return nil, fmt.Errorf("unknown value for AttachOptions.FromString(%v)", value)
+		}
+	}
+	return options, nil
+}
+
 type ButtonBoxStyle uint64
 
 const (
@@ -449,6 +483,20 @@ const (
 	POS_BOTTOM
 )
 
+func (p PositionType) FromString(value string) (enum interface{}, err error) {
+	switch strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(value), "gtk_"), "pos_") {
+	case "left":
+		return POS_LEFT, nil
+	case "right":
+		return POS_RIGHT, nil
+	case "top":
+		return POS_TOP, nil
+	case "bottom":
+		return POS_BOTTOM, nil
+	}
+	return nil, fmt.Errorf("unknown value for PositionType.FromString(%v)", value)
+}
+
 type ReliefStyle uint64
 
 const (
@@ -576,6 +624,20 @@ const (
 	TOOLBAR_BOTH_HORIZ
 )
 
+func (s ToolbarStyle) FromString(value string) (enum interface{}, err error) {
+	switch strings.TrimPrefix(strings.ToLower(value), "gtk_toolbar_") {
+	case "icons":
+		return TOOLBAR_ICONS, nil
+	case "text":
+		return TOOLBAR_TEXT, nil
+	case "both":
+		return TOOLBAR_BOTH, nil
+	case "both-horiz", "both_horiz":
+		return TOOLBAR_BOTH_HORIZ, nil
+	}
+	return nil, fmt.Errorf("unknown value for ToolbarStyle.FromString(%v)", value)
+}
+
 type UpdateType uint64
 
 const (
@@ -857,6 +919,20 @@ const (
 	FILE_CHOOSER_ACTION_CREATE_FOLDER
 )
 
+func (a FileChooserAction) FromString(value string) (enum interface{}, err error) {
+	switch strings.TrimPrefix(strings.ToLower(value), "gtk_file_chooser_action_") {
+	case "open":
+		return FILE_CHOOSER_ACTION_OPEN, nil
+	case "save":
+		return FILE_CHOOSER_ACTION_SAVE, nil
+	case "select-folder", "select_folder":
+		return FILE_CHOOSER_ACTION_SELECT_FOLDER, nil
+	case "create-folder", "create_folder":
+		return FILE_CHOOSER_ACTION_CREATE_FOLDER, nil
+	}
+	return nil, fmt.Errorf("unknown value for FileChooserAction.FromString(%v)", value)
+}
+
 type FileChooserConfirmation uint64
 
 const (
@@ -1000,6 +1076,16 @@ const (
 	PROGRESS_DISCRETE
 )
 
+func (s ProgressBarStyle) FromString(value string) (enum interface{}, err error) {
+	switch strings.TrimPrefix(strings.ToLower(value), "gtk_progress_") {
+	case "continuous":
+		return PROGRESS_CONTINUOUS, nil
+	case "discrete":
+		return PROGRESS_DISCRETE, nil
+	}
+	return nil, fmt.Errorf("unknown value for ProgressBarStyle.FromString(%v)", value)
+}
+
 type ProgressBarOrientation uint64
 
 const (
@@ -1009,6 +1095,20 @@ const (
 	PROGRESS_TOP_TO_BOTTOM
 )
 
+func (o ProgressBarOrientation) FromString(value string) (enum interface{}, err error) {
+	switch strings.TrimPrefix(strings.ToLower(value), "gtk_progress_") {
+	case "left-to-right", "left_to_right":
+		return PROGRESS_LEFT_TO_RIGHT, nil
+	case "right-to-left", "right_to_left":
+		return PROGRESS_RIGHT_TO_LEFT, nil
+	case "bottom-to-top", "bottom_to_top":
+		return PROGRESS_BOTTOM_TO_TOP, nil
+	case "top-to-bottom", "top_to_bottom":
+		return PROGRESS_TOP_TO_BOTTOM, nil
+	}
+	return nil, fmt.Errorf("unknown value for ProgressBarOrientation.FromString(%v)", value)
+}
+
 type RBNodeColor uint64
 
 const (
@@ -1088,6 +1188,20 @@ const (
 	RECENT_SORT_CUSTOM
 )
 
+func (s RecentSortType) FromString(value string) (enum interface{}, err error) {
+	switch strings.TrimPrefix(strings.ToLower(value), "gtk_recent_sort_") {
+	case "none":
+		return RECENT_SORT_NONE, nil
+	case "mru":
+		return RECENT_SORT_MRU, nil
+	case "lru":
+		return RECENT_SORT_LRU, nil
+	case "custom":
+		return RECENT_SORT_CUSTOM, nil
+	}
+	return nil, fmt.Errorf("unknown value for RecentSortType.FromString(%v)", value)
+}
+
 type RecentChooserError uint64
 
 const (
@@ -1144,6 +1258,20 @@ const (
 	SIZE_GROUP_BOTH
 )
 
+func (s SizeGroupMode) FromString(value string) (enum interface{}, err error) {
+	switch strings.TrimPrefix(strings.ToLower(value), "gtk_size_group_") {
+	case "none":
+		return SIZE_GROUP_NONE, nil
+	case "horizontal":
+		return SIZE_GROUP_HORIZONTAL, nil
+	case "vertical":
+		return SIZE_GROUP_VERTICAL, nil
+	case "both":
+		return SIZE_GROUP_BOTH, nil
+	}
+	return nil, fmt.Errorf("unknown value for SizeGroupMode.FromString(%v)", value)
+}
+
 type SpinButtonUpdatePolicy uint64
 
 const (
@@ -1151,6 +1279,16 @@ const (
 	UPDATE_IF_VALID
 )
 
+func (p SpinButtonUpdatePolicy) FromString(value string) (enum interface{}, err error) {
+	switch strings.TrimPrefix(strings.ToLower(value), "gtk_update_") {
+	case "always":
+		return UPDATE_ALWAYS, nil
+	case "if-valid", "if_valid":
+		return UPDATE_IF_VALID, nil
+	}
+	return nil, fmt.Errorf("unknown value for SpinButtonUpdatePolicy.FromString(%v)", value)
+}
+
 type SpinType uint64
 
 const (
@@ -0,0 +1,157 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"github.com/go-curses/cdk"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeListStore cdk.CTypeTag = "ctk-list-store"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeListStore, func() interface{} { return MakeListStore() })
+}
+
+// ListStore Hierarchy:
+//	Object
+//	  +- ListStore
+//
+// The ListStore object is a list model for use with a TreeView widget. It
+// implements the TreeModel interface, and consequentially, can use all of the
+// methods available there. It also implements the TreeSortable interface so it
+// can be sorted by the view. Unlike the TreeStore, rows in a ListStore never
+// have children.
+//
+// The CListStore embeds a CTreeStore for storage, though the Insert,
+// InsertBefore, InsertAfter, Prepend, Append and Reorder methods do not take
+// parent iters and so a ListStore does not implement the TreeStore interface.
+type ListStore interface {
+	TreeSortable
+
+	Init() (already bool)
+	SetColumnTypes(types ...cdk.PropertyType)
+	Set(iter *TreeIter, columns []int, values []interface{}) (err error)
+	SetValue(iter *TreeIter, column int, value interface{}) (err error)
+	Remove(iter *TreeIter) (ok bool)
+	Insert(position int) (iter *TreeIter)
+	InsertBefore(sibling *TreeIter) (iter *TreeIter)
+	InsertAfter(sibling *TreeIter) (iter *TreeIter)
+	InsertWithValues(position int, columns []int, values []interface{}) (iter *TreeIter, err error)
+	Prepend() (iter *TreeIter)
+	Append() (iter *TreeIter)
+	Clear()
+	IterIsValid(iter *TreeIter) (ok bool)
+	Reorder(newOrder []int)
+	Swap(a, b *TreeIter)
+	MoveBefore(iter, position *TreeIter)
+	MoveAfter(iter, position *TreeIter)
+}
+
+var _ ListStore = (*CListStore)(nil)
+
+// The CListStore structure implements the ListStore interface and is exported
+// to facilitate type embedding with custom implementations. No member variables
+// are exported as the interface methods are the only intended means of
+// interacting with ListStore objects.
+type CListStore struct {
+	CTreeStore
+}
+
+// MakeListStore is used by the Buildable system to construct a new ListStore.
+func MakeListStore() ListStore {
+	return NewListStore()
+}
+
+// NewListStore is the constructor for new ListStore instances. The types given
+// are the column types of the new ListStore, in column order.
+func NewListStore(types ...cdk.PropertyType) ListStore {
+	l := new(CListStore)
+	l.Init()
+	l.SetColumnTypes(types...)
+	return l
+}
+
+// Init initializes a ListStore object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the ListStore instance. Init is used in the
+// NewListStore constructor and only necessary when implementing a derivative
+// ListStore type.
+func (l *CListStore) Init() (already bool) {
+	if l.InitTypeItem(TypeListStore, l) {
+		return true
+	}
+	l.CTreeStore.Init()
+	l.modelFlags = enums.TREE_MODEL_ITERS_PERSIST | enums.TREE_MODEL_LIST_ONLY
+	return false
+}
+
+// Insert creates a new row at the given position. A negative position, or one
+// larger than the number of rows, appends the new row.
+//
+// Emits: SignalRowInserted, Argv=[ListStore instance, TreePath, *TreeIter]
+func (l *CListStore) Insert(position int) (iter *TreeIter) {
+	return l.CTreeStore.Insert(nil, position)
+}
+
+// InsertBefore creates a new row before the given sibling, or appends a new row
+// if sibling is nil.
+//
+// Emits: SignalRowInserted, Argv=[ListStore instance, TreePath, *TreeIter]
+func (l *CListStore) InsertBefore(sibling *TreeIter) (iter *TreeIter) {
+	return l.CTreeStore.InsertBefore(nil, sibling)
+}
+
+// InsertAfter creates a new row after the given sibling, or prepends a new row
+// if sibling is nil.
+//
+// Emits: SignalRowInserted, Argv=[ListStore instance, TreePath, *TreeIter]
+func (l *CListStore) InsertAfter(sibling *TreeIter) (iter *TreeIter) {
+	return l.CTreeStore.InsertAfter(nil, sibling)
+}
+
+// InsertWithValues creates a new row at the given position and sets the given
+// column values in one call.
+//
+// Emits: SignalRowInserted, Argv=[ListStore instance, TreePath, *TreeIter]
+// Emits: SignalRowChanged, Argv=[ListStore instance, TreePath, *TreeIter]
+func (l *CListStore) InsertWithValues(position int, columns []int, values []interface{}) (iter *TreeIter, err error) {
+	return l.CTreeStore.InsertWithValues(nil, position, columns, values)
+}
+
+// Prepend creates a new row at the start of the list.
+//
+// Emits: SignalRowInserted, Argv=[ListStore instance, TreePath, *TreeIter]
+func (l *CListStore) Prepend() (iter *TreeIter) {
+	return l.CTreeStore.Insert(nil, 0)
+}
+
+// Append creates a new row at the end of the list.
+//
+// Emits: SignalRowInserted, Argv=[ListStore instance, TreePath, *TreeIter]
+func (l *CListStore) Append() (iter *TreeIter) {
+	return l.CTreeStore.Insert(nil, -1)
+}
+
+// Reorder reorders the rows of the list such that
+// newOrder[newPosition] = oldPosition. This method does nothing if the
+// ListStore is sorted.
+//
+// Emits: SignalRowsReordered, Argv=[ListStore instance, TreePath, *TreeIter, []int]
+func (l *CListStore) Reorder(newOrder []int) {
+	l.CTreeStore.Reorder(nil, newOrder)
+}
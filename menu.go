@@ -0,0 +1,728 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/go-curses/cdk/lib/ptypes"
+	"github.com/go-curses/cdk/memphis"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeMenu cdk.CTypeTag = "ctk-menu"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeMenu, func() interface{} { return MakeMenu() })
+}
+
+// Menu Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- MenuShell
+//	        +- Menu
+//
+// A Menu is a MenuShell that implements a drop down menu consisting of a list
+// of MenuItem objects which can be navigated and activated by the user to
+// perform application functions. A Menu is most commonly dropped down by
+// activating a MenuItem in a MenuBar or popped up by activating a MenuItem in
+// another Menu. A Menu can also be popped up by calling PopupAt, for example
+// in response to a right mouse button press.
+//
+// Menus are drawn within their own undecorated popup Window, overlaid upon
+// the Window of the MenuShell that opened them. Submenus open to the right of
+// their MenuItem unless there is not enough room on the screen, in which case
+// they open to the left.
+type Menu interface {
+	MenuShell
+
+	Popup(parentMenuShell MenuShell, parentMenuItem Widget)
+	PopupAt(x, y int)
+	Popdown()
+	Reposition()
+	IsPoppedUp() (poppedUp bool)
+	GetMenuWindow() (window Window)
+	ReorderChild(child Widget, position int)
+	GetActive() (value Widget)
+	SetActive(index int)
+	GetTitle() (value string)
+	SetTitle(title string)
+	GetAttachWidget() (value Widget)
+	AttachToWidget(attachWidget Widget)
+	Detach()
+	GetAccelGroup() (value AccelGroup)
+	SetAccelGroup(accelGroup AccelGroup)
+}
+
+var _ Menu = (*CMenu)(nil)
+
+// The CMenu structure implements the Menu interface and is exported to
+// facilitate type embedding with custom implementations. No member variables
+// are exported as the interface methods are the only intended means of
+// interacting with Menu objects.
+type CMenu struct {
+	CMenuShell
+
+	window       Window
+	poppedUp     bool
+	popupItem    Widget
+	popupAt      *ptypes.Point2I
+	direction    enums.SubmenuDirection
+	attachWidget Widget
+	accelGroup   AccelGroup
+	lastActive   MenuItem
+}
+
+// MakeMenu is used by the Buildable system to construct a new Menu.
+func MakeMenu() Menu {
+	return NewMenu()
+}
+
+// NewMenu is the constructor for new Menu instances.
+func NewMenu() Menu {
+	m := new(CMenu)
+	m.Init()
+	return m
+}
+
+// Init initializes a Menu object. This must be called at least once to set up
+// the necessary defaults and allocate any memory structures. Calling this more
+// than once is safe though unnecessary. Only the first call will result in any
+// effect upon the Menu instance. Init is used in the NewMenu constructor and
+// only necessary when implementing a derivative Menu type.
+func (m *CMenu) Init() (already bool) {
+	if m.InitTypeItem(TypeMenu, m) {
+		return true
+	}
+	m.CMenuShell.Init()
+	m.window = nil
+	m.poppedUp = false
+	m.popupItem = nil
+	m.popupAt = nil
+	m.direction = enums.DIRECTION_RIGHT
+	m.attachWidget = nil
+	m.accelGroup = nil
+	m.lastActive = nil
+	_ = m.InstallProperty(PropertyAccelGroup, cdk.StructProperty, true, nil)
+	_ = m.InstallProperty(PropertyAttachWidget, cdk.StructProperty, true, nil)
+	_ = m.InstallProperty(PropertyTitle, cdk.StringProperty, true, "")
+	m.Connect(SignalCdkEvent, MenuEventHandle, m.event)
+	m.Connect(SignalResize, MenuResizeHandle, m.resize)
+	m.Connect(SignalDraw, MenuDrawHandle, m.draw)
+	return false
+}
+
+// Popup displays the Menu as a submenu of the given MenuShell, positioned
+// next to the given MenuItem. Applications should use PopupAt to display a
+// Menu that is not part of a menu hierarchy.
+//
+// Parameters:
+//
+//	parentMenuShell	the menu shell containing the triggering menu item, or nil
+//	parentMenuItem	the menu item whose activation triggered the popup, or nil
+func (m *CMenu) Popup(parentMenuShell MenuShell, parentMenuItem Widget) {
+	m.Lock()
+	m.parentShell = parentMenuShell
+	m.popupItem = parentMenuItem
+	m.popupAt = nil
+	m.Unlock()
+	m.popup()
+}
+
+// PopupAt displays the Menu with its top-left corner at the given screen
+// coordinates. The Menu grabs the events of the Window of the attach widget,
+// or the focused Window if there is no attach widget, until it is popped down.
+//
+// Parameters:
+//
+//	x	screen column for the top-left corner of the menu
+//	y	screen row for the top-left corner of the menu
+func (m *CMenu) PopupAt(x, y int) {
+	m.Lock()
+	m.parentShell = nil
+	m.popupItem = nil
+	m.popupAt = ptypes.NewPoint2I(x, y)
+	m.Unlock()
+	m.popup()
+}
+
+// Popdown removes the Menu from the screen, popping down any open submenus
+// first.
+func (m *CMenu) Popdown() {
+	m.CMenuShell.Deactivate()
+	m.Lock()
+	poppedUp := m.poppedUp
+	window := m.window
+	m.poppedUp = false
+	m.parentShell = nil
+	m.Unlock()
+	if poppedUp {
+		if window != nil {
+			window.Hide()
+		}
+		menuRequestDraw()
+	}
+}
+
+// Deactivate is a convenience method for Popdown.
+func (m *CMenu) Deactivate() {
+	m.Popdown()
+}
+
+// Reposition moves the Menu to the proper position and updates its size. This
+// is done automatically when the Menu is popped up and should only be needed
+// after the items of an open Menu have changed.
+func (m *CMenu) Reposition() {
+	window := m.GetMenuWindow()
+	w, h := m.GetSizeRequest()
+	bounds := m.getScreenBounds()
+	if bounds.W > 0 && w > bounds.W {
+		w = bounds.W
+	}
+	if bounds.H > 0 && h > bounds.H {
+		h = bounds.H
+	}
+	m.RLock()
+	item := m.popupItem
+	at := m.popupAt
+	direction := enums.DIRECTION_RIGHT
+	if parent, ok := m.parentShell.(Menu); ok && parent != nil {
+		if pm, ok := parent.Self().(*CMenu); ok {
+			pm.RLock()
+			direction = pm.direction
+			pm.RUnlock()
+		}
+	}
+	m.RUnlock()
+	x, y := 0, 0
+	if item != nil {
+		origin := item.GetOrigin()
+		alloc := item.GetAllocation()
+		placement := enums.LEFT_RIGHT
+		if parent := item.GetParent(); parent != nil {
+			if _, ok := parent.Self().(*CMenuBar); ok {
+				placement = enums.TOP_BOTTOM
+			}
+		}
+		switch placement {
+		case enums.TOP_BOTTOM:
+			x, y = origin.X, origin.Y+alloc.H
+			if bounds.H > 0 && y+h > bounds.Y+bounds.H && origin.Y-h >= bounds.Y {
+				y = origin.Y - h
+			}
+		default:
+			// the submenu border overlaps the border of the parent menu
+			right, left := origin.X+alloc.W, origin.X-w
+			y = origin.Y - 1
+			if bounds.W > 0 {
+				if direction == enums.DIRECTION_RIGHT && right+w > bounds.X+bounds.W && left >= bounds.X {
+					direction = enums.DIRECTION_LEFT
+				} else if direction == enums.DIRECTION_LEFT && left < bounds.X && right+w <= bounds.X+bounds.W {
+					direction = enums.DIRECTION_RIGHT
+				}
+			}
+			if direction == enums.DIRECTION_LEFT {
+				x = left
+			} else {
+				x = right
+			}
+		}
+	} else if at != nil {
+		x, y = at.X, at.Y
+	}
+	if bounds.W > 0 {
+		if x+w > bounds.X+bounds.W {
+			x = bounds.X + bounds.W - w
+		}
+		if x < bounds.X {
+			x = bounds.X
+		}
+	}
+	if bounds.H > 0 {
+		if y+h > bounds.Y+bounds.H {
+			y = bounds.Y + bounds.H - h
+		}
+		if y < bounds.Y {
+			y = bounds.Y
+		}
+	}
+	m.Lock()
+	m.direction = direction
+	m.Unlock()
+	window.Move(x, y)
+	window.SetAllocation(ptypes.MakeRectangle(w, h))
+	window.Resize()
+}
+
+// IsPoppedUp returns TRUE if the Menu is currently displayed.
+func (m *CMenu) IsPoppedUp() (poppedUp bool) {
+	m.RLock()
+	defer m.RUnlock()
+	return m.poppedUp
+}
+
+// GetMenuWindow returns the popup Window the Menu is drawn within, creating
+// it if necessary.
+func (m *CMenu) GetMenuWindow() (window Window) {
+	m.RLock()
+	window = m.window
+	m.RUnlock()
+	if window == nil {
+		window = NewWindow()
+		window.SetWindowType(cenums.WINDOW_POPUP)
+		window.SetFlags(enums.TOPLEVEL)
+		window.SetDecorated(false)
+		window.SetTheme(m.GetTheme())
+		m.Lock()
+		m.window = window
+		m.Unlock()
+		window.GetVBox().PackStart(m, true, true, 0)
+	}
+	return
+}
+
+// ReorderChild moves a MenuItem to a new position within the Menu.
+//
+// Parameters:
+//
+//	child	the MenuItem to move
+//	position	the new position to place child. Positions are numbered from
+//	            0 to n - 1
+func (m *CMenu) ReorderChild(child Widget, position int) {
+	m.Lock()
+	index := -1
+	for idx, c := range m.children {
+		if c.ObjectID() == child.ObjectID() {
+			index = idx
+			break
+		}
+	}
+	if index < 0 {
+		m.Unlock()
+		return
+	}
+	last := len(m.children) - 1
+	if position < 0 || position > last {
+		position = last
+	}
+	m.children = append(m.children[:index], m.children[index+1:]...)
+	m.children = append(m.children, nil)
+	copy(m.children[position+1:], m.children[position:])
+	m.children[position] = child
+	m.Unlock()
+	m.Resize()
+}
+
+// SelectItem selects the given MenuItem, remembering it as the active item of
+// the Menu.
+func (m *CMenu) SelectItem(menuItem Widget) {
+	m.CMenuShell.SelectItem(menuItem)
+	if selected := m.GetSelectedItem(); selected != nil {
+		m.Lock()
+		m.lastActive = selected
+		m.Unlock()
+	}
+}
+
+// GetActive returns the selected menu item from the menu. This is used by the
+// OptionMenu.
+func (m *CMenu) GetActive() (value Widget) {
+	m.RLock()
+	active := m.lastActive
+	m.RUnlock()
+	if active != nil && active.GetParent() != nil && active.GetParent().ObjectID() == m.ObjectID() {
+		return active
+	}
+	for _, item := range m.GetItems() {
+		if menuItemIsSelectable(item) {
+			return item
+		}
+	}
+	return nil
+}
+
+// SetActive selects the specified menu item within the menu. This is used by
+// the OptionMenu and should not be used by anyone else.
+//
+// Parameters:
+//
+//	index	the index of the menu item to select. Index values are from 0 to n-1
+func (m *CMenu) SetActive(index int) {
+	if items := m.GetItems(); index >= 0 && index < len(items) {
+		m.Lock()
+		m.lastActive = items[index]
+		m.Unlock()
+	}
+}
+
+// GetTitle returns the title of the menu. See SetTitle.
+func (m *CMenu) GetTitle() (value string) {
+	var err error
+	if value, err = m.GetStringProperty(PropertyTitle); err != nil {
+		m.LogErr(err)
+	}
+	return
+}
+
+// SetTitle sets the title string for the menu. The title is displayed when
+// the menu is shown as a tearoff menu.
+//
+// Parameters:
+//
+//	title	a string containing the title for the menu
+func (m *CMenu) SetTitle(title string) {
+	if err := m.SetStringProperty(PropertyTitle, title); err != nil {
+		m.LogErr(err)
+	}
+}
+
+// GetAttachWidget returns the Widget that the menu is attached to.
+func (m *CMenu) GetAttachWidget() (value Widget) {
+	m.RLock()
+	defer m.RUnlock()
+	return m.attachWidget
+}
+
+// AttachToWidget attaches the menu to the widget. The attach widget is used
+// to find the Window to grab events from when the menu is popped up with
+// PopupAt.
+//
+// Parameters:
+//
+//	attachWidget	the Widget that the menu will be attached to
+func (m *CMenu) AttachToWidget(attachWidget Widget) {
+	m.Lock()
+	m.attachWidget = attachWidget
+	m.Unlock()
+	if err := m.SetStructProperty(PropertyAttachWidget, attachWidget); err != nil {
+		m.LogErr(err)
+	}
+}
+
+// Detach detaches the menu from the widget to which it had been attached.
+func (m *CMenu) Detach() {
+	m.AttachToWidget(nil)
+}
+
+// GetAccelGroup returns the AccelGroup which holds global accelerators for
+// the menu. See SetAccelGroup.
+func (m *CMenu) GetAccelGroup() (value AccelGroup) {
+	m.RLock()
+	defer m.RUnlock()
+	return m.accelGroup
+}
+
+// SetAccelGroup sets the AccelGroup which holds global accelerators for the
+// menu. This accelerator group needs to also be added to all windows that
+// this menu is being used in with Window.AddAccelGroup, in order for those
+// windows to support all the accelerators contained in this group.
+//
+// Parameters:
+//
+//	accelGroup	the AccelGroup to be associated with the menu
+func (m *CMenu) SetAccelGroup(accelGroup AccelGroup) {
+	m.Lock()
+	m.accelGroup = accelGroup
+	m.Unlock()
+	if err := m.SetStructProperty(PropertyAccelGroup, accelGroup); err != nil {
+		m.LogErr(err)
+	}
+}
+
+// GetWidgetAt returns the Menu itself if the given point is within the Menu
+// region. The MenuItem children handle no events of their own.
+func (m *CMenu) GetWidgetAt(p *ptypes.Point2I) Widget {
+	if m.HasPoint(p) && m.IsVisible() {
+		return m
+	}
+	return nil
+}
+
+// GetSizeRequest returns the requested size of the Menu, which is large
+// enough for the widest MenuItem and one row per visible MenuItem, plus the
+// border.
+func (m *CMenu) GetSizeRequest() (width, height int) {
+	width, height = m.CMenuShell.GetSizeRequest()
+	items := m.getVisibleItems()
+	toggleSize := 0
+	for _, item := range items {
+		if size := item.ToggleSizeRequest(); size > toggleSize {
+			toggleSize = size
+		}
+	}
+	maxWidth := 0
+	for _, item := range items {
+		item.ToggleSizeAllocate(toggleSize)
+		if w, _ := item.GetSizeRequest(); w > maxWidth {
+			maxWidth = w
+		}
+	}
+	if width <= -1 {
+		width = maxWidth + 2
+	}
+	if height <= -1 {
+		height = len(items) + 2
+	}
+	return
+}
+
+func (m *CMenu) popup() {
+	window := m.GetMenuWindow()
+	if m.GetParentShell() == nil {
+		m.activateShell(m.getParentWindow())
+	} else {
+		m.activateShell(nil)
+	}
+	m.Lock()
+	m.poppedUp = true
+	m.Unlock()
+	m.Show()
+	m.Reposition()
+	window.Show()
+	m.Invalidate()
+	menuRequestDraw()
+}
+
+func (m *CMenu) getVisibleItems() (items []MenuItem) {
+	for _, item := range m.GetItems() {
+		if item.IsVisible() {
+			items = append(items, item)
+		}
+	}
+	return
+}
+
+// getItemAt returns the visible MenuItem at the given screen point, if any.
+func (m *CMenu) getItemAt(point ptypes.Point2I) (item MenuItem) {
+	for _, child := range m.getVisibleItems() {
+		if menuRegionHasPoint(child, point) {
+			return child
+		}
+	}
+	return nil
+}
+
+// getParentWindow returns the Window that the root of the menu hierarchy is
+// within.
+func (m *CMenu) getParentWindow() (window Window) {
+	root := m.getRootShell()
+	if menu, ok := root.Self().(Menu); ok {
+		if attach := menu.GetAttachWidget(); attach != nil {
+			return attach.GetWindow()
+		}
+		if display := cdk.GetDefaultDisplay(); display != nil {
+			if focused, ok := display.FocusedWindow().(Window); ok {
+				return focused
+			}
+		}
+		return nil
+	}
+	return root.GetWindow()
+}
+
+// getScreenBounds returns the region the Menu must fit within, which is the
+// whole screen when the display is running or the region of the parent Window
+// otherwise.
+func (m *CMenu) getScreenBounds() (bounds ptypes.Region) {
+	if display := cdk.GetDefaultDisplay(); display != nil && display.IsRunning() {
+		alloc := ptypes.MakeRectangle(display.Screen().Size())
+		return ptypes.MakeRegion(0, 0, alloc.W, alloc.H)
+	}
+	if window := m.getParentWindow(); window != nil {
+		origin := window.GetOrigin()
+		alloc := window.GetAllocation()
+		return ptypes.MakeRegion(origin.X, origin.Y, alloc.W, alloc.H)
+	}
+	return
+}
+
+func (m *CMenu) event(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if evt, ok := argv[1].(cdk.Event); ok {
+		switch e := evt.(type) {
+		case *cdk.EventMouse:
+			return m.processMouseEvent(e)
+		case *cdk.EventKey:
+			return m.processKeyEvent(e)
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (m *CMenu) processKeyEvent(e *cdk.EventKey) cenums.EventFlag {
+	if menuIsMenuBarAccel(e) {
+		m.getRootShell().Cancel()
+		return cenums.EVENT_STOP
+	}
+	switch e.Key() {
+	case cdk.KeyUp:
+		m.MoveCurrent(enums.MENU_DIR_PREV)
+		return cenums.EVENT_STOP
+	case cdk.KeyDown:
+		m.MoveCurrent(enums.MENU_DIR_NEXT)
+		return cenums.EVENT_STOP
+	case cdk.KeyHome, cdk.KeyPgUp:
+		m.Deselect()
+		m.SelectFirst(true)
+		return cenums.EVENT_STOP
+	case cdk.KeyEnd, cdk.KeyPgDn:
+		m.Deselect()
+		m.MoveSelected(-1)
+		return cenums.EVENT_STOP
+	case cdk.KeyLeft:
+		m.MoveCurrent(enums.MENU_DIR_PARENT)
+		return cenums.EVENT_STOP
+	case cdk.KeyRight:
+		m.MoveCurrent(enums.MENU_DIR_CHILD)
+		return cenums.EVENT_STOP
+	case cdk.KeyEscape:
+		if parent := m.GetParentShell(); parent != nil {
+			if _, ok := parent.Self().(Menu); ok {
+				m.Popdown()
+				return cenums.EVENT_STOP
+			}
+		}
+		m.getRootShell().Cancel()
+		return cenums.EVENT_STOP
+	}
+	switch cdk.Key(e.Rune()) {
+	case cdk.KeyEnter, cdk.KeySpace:
+		m.ActivateCurrent(false)
+		return cenums.EVENT_STOP
+	}
+	if e.Key() == cdk.KeyRune && !e.Modifiers().Has(cdk.ModCtrl) {
+		if item := m.getMnemonicItem(e.Rune()); item != nil {
+			m.ActivateItem(item, false)
+		}
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+func (m *CMenu) processMouseEvent(e *cdk.EventMouse) cenums.EventFlag {
+	if e.IsWheelImpulse() {
+		return cenums.EVENT_STOP
+	}
+	item := m.getItemAt(e.Point2I())
+	if item == nil || !menuItemIsSelectable(item) {
+		return cenums.EVENT_STOP
+	}
+	switch e.State() {
+	case cdk.MOUSE_MOVE, cdk.DRAG_MOVE, cdk.DRAG_START:
+		m.hoverItem(item, GetDefaultSettings().GetMenuPopupDelay())
+	case cdk.BUTTON_PRESS:
+		if item.GetSubmenu() != nil {
+			m.popupSubmenu(item, false)
+		} else {
+			m.SelectItem(item)
+		}
+	case cdk.BUTTON_RELEASE, cdk.DRAG_STOP:
+		if item.GetSubmenu() == nil {
+			m.ActivateItem(item, true)
+		}
+	}
+	return cenums.EVENT_STOP
+}
+
+func (m *CMenu) resize(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	origin := m.GetOrigin()
+	alloc := m.GetAllocation()
+	// updates the toggle size allocation of the items
+	_, _ = m.GetSizeRequest()
+	row := 0
+	for _, item := range m.GetItems() {
+		if !item.IsVisible() || row >= alloc.H-2 || alloc.W <= 2 {
+			item.SetAllocation(ptypes.MakeRectangle(0, 0))
+			item.Resize()
+			continue
+		}
+		item.SetOrigin(origin.X+1, origin.Y+1+row)
+		item.SetAllocation(ptypes.MakeRectangle(alloc.W-2, 1))
+		item.Resize()
+		row += 1
+	}
+	m.Invalidate()
+	return cenums.EVENT_STOP
+}
+
+func (m *CMenu) draw(data []interface{}, argv ...interface{}) cenums.EventFlag {
+
+	if surface, ok := argv[1].(*memphis.CSurface); ok {
+		alloc := m.GetAllocation()
+		if !m.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+			m.LogTrace("not visible, zero width or zero height")
+			return cenums.EVENT_PASS
+		}
+
+		theme := m.GetTheme()
+		surface.Box(
+			ptypes.MakePoint2I(0, 0),
+			ptypes.MakeRectangle(alloc.W, alloc.H),
+			true, true,
+			theme.Content.Overlay,
+			theme.Content.FillRune,
+			theme.Content.Normal,
+			theme.Border.Normal,
+			theme.Border.BorderRunes,
+		)
+
+		origin := m.GetOrigin()
+		for _, item := range m.GetItems() {
+			itemAlloc := item.GetAllocation()
+			if !item.IsVisible() || itemAlloc.W <= 0 || itemAlloc.H <= 0 {
+				continue
+			}
+			if _, ok := item.Self().(*CSeparatorMenuItem); ok {
+				row := item.GetOrigin().Y - origin.Y
+				_ = surface.SetRune(0, row, paint.RuneLTee, theme.Border.Normal)
+				_ = surface.SetRune(alloc.W-1, row, paint.RuneRTee, theme.Border.Normal)
+			}
+			item.Draw()
+			item.LockDraw()
+			if err := surface.Composite(item.ObjectID()); err != nil {
+				m.LogError("composite error: %v", err)
+			}
+			item.UnlockDraw()
+		}
+
+		if debug, _ := m.GetBoolProperty(cdk.PropertyDebug); debug {
+			surface.DebugBox(paint.ColorNavy, m.ObjectInfo())
+		}
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+// The accel group holding accelerators for the menu.
+// Flags: Read / Write
+const PropertyAccelGroup cdk.Property = "accel-group"
+
+// The widget the menu is attached to.
+// Flags: Read / Write
+const PropertyAttachWidget cdk.Property = "attach-widget"
+
+// The title of the menu.
+// Flags: Read / Write
+// Default value: ""
+// const PropertyTitle cdk.Property = "title"
+
+const MenuEventHandle = "menu-event-handler"
+
+const MenuResizeHandle = "menu-resize-handler"
+
+const MenuDrawHandle = "menu-draw-handler"
@@ -0,0 +1,408 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/go-curses/cdk/lib/ptypes"
+	"github.com/go-curses/cdk/memphis"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeMenuBar cdk.CTypeTag = "ctk-menu-bar"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeMenuBar, func() interface{} { return MakeMenuBar() })
+}
+
+// MenuBar Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- MenuShell
+//	        +- MenuBar
+//
+// The MenuBar is a subclass of MenuShell which contains one or more
+// MenuItem. The result is a standard single line menu bar which can hold many
+// menu items. Items with right-justified set are packed at the right end of
+// the bar.
+//
+// The MenuBar watches the key events of its Window: the menu-bar-accel of the
+// default Settings (F10) selects the first item and opens its submenu, while
+// the mnemonic modifier of the Window combined with the mnemonic of an item
+// opens that item directly.
+type MenuBar interface {
+	MenuShell
+}
+
+var _ MenuBar = (*CMenuBar)(nil)
+
+// The CMenuBar structure implements the MenuBar interface and is exported to
+// facilitate type embedding with custom implementations. No member variables
+// are exported as the interface methods are the only intended means of
+// interacting with MenuBar objects.
+type CMenuBar struct {
+	CMenuShell
+
+	keyWindow Window
+	keyHandle string
+}
+
+// MakeMenuBar is used by the Buildable system to construct a new MenuBar.
+func MakeMenuBar() MenuBar {
+	return NewMenuBar()
+}
+
+// NewMenuBar is the constructor for new MenuBar instances.
+func NewMenuBar() MenuBar {
+	m := new(CMenuBar)
+	m.Init()
+	return m
+}
+
+// Init initializes a MenuBar object. This must be called at least once to set
+// up the necessary defaults and allocate any memory structures. Calling this
+// more than once is safe though unnecessary. Only the first call will result
+// in any effect upon the MenuBar instance. Init is used in the NewMenuBar
+// constructor and only necessary when implementing a derivative MenuBar type.
+func (m *CMenuBar) Init() (already bool) {
+	if m.InitTypeItem(TypeMenuBar, m) {
+		return true
+	}
+	m.CMenuShell.Init()
+	m.keyWindow = nil
+	m.keyHandle = fmt.Sprintf("%v-%v", MenuBarKeyHandle, m.ObjectID())
+	m.Connect(SignalCdkEvent, MenuBarEventHandle, m.event)
+	m.Connect(SignalResize, MenuBarResizeHandle, m.resize)
+	m.Connect(SignalDraw, MenuBarDrawHandle, m.draw)
+	return false
+}
+
+// SetWindow updates the Window the MenuBar belongs to and starts watching the
+// key events of the Window for the menu-bar-accel and item mnemonics.
+func (m *CMenuBar) SetWindow(w Window) {
+	m.CMenuShell.SetWindow(w)
+	m.Lock()
+	previous := m.keyWindow
+	if previous != nil && w != nil && previous.ObjectID() == w.ObjectID() {
+		m.Unlock()
+		return
+	}
+	m.keyWindow = w
+	m.Unlock()
+	if previous != nil {
+		_ = previous.Disconnect(SignalEventKey, m.keyHandle)
+	}
+	if w != nil {
+		w.Connect(SignalEventKey, m.keyHandle, m.windowKeyEvent)
+	}
+}
+
+// GetWidgetAt returns the MenuBar itself if the given point is within the
+// MenuBar region. The MenuItem children handle no events of their own.
+func (m *CMenuBar) GetWidgetAt(p *ptypes.Point2I) Widget {
+	if m.HasPoint(p) && m.IsVisible() {
+		return m
+	}
+	return nil
+}
+
+// MoveSelected changes the selected item of the MenuBar, opening the submenu
+// of the new item if the submenu of the previous item was open.
+func (m *CMenuBar) MoveSelected(distance int) (moved bool) {
+	wasOpen := false
+	if selected := m.GetSelectedItem(); selected != nil {
+		wasOpen = menuItemSubmenuIsOpen(selected)
+	}
+	if moved = m.CMenuShell.MoveSelected(distance); moved && wasOpen {
+		if selected := m.GetSelectedItem(); selected != nil && selected.GetSubmenu() != nil {
+			m.popupSubmenu(selected, true)
+		}
+	}
+	return
+}
+
+// MoveCurrent moves the selection of the MenuBar. Moving to the previous or
+// next item moves along the bar while moving to the child opens the submenu of
+// the selected item. Moving to the parent does nothing.
+func (m *CMenuBar) MoveCurrent(direction enums.MenuDirectionType) {
+	if f := m.Emit(SignalMoveCurrent, m, direction); f == cenums.EVENT_STOP {
+		return
+	}
+	switch direction {
+	case enums.MENU_DIR_PREV:
+		m.MoveSelected(-1)
+	case enums.MENU_DIR_NEXT:
+		m.MoveSelected(1)
+	case enums.MENU_DIR_CHILD:
+		if selected := m.GetSelectedItem(); selected != nil && selected.GetSubmenu() != nil {
+			m.popupSubmenu(selected, true)
+		}
+	}
+	menuRequestDraw()
+}
+
+// GetSizeRequest returns the requested size of the MenuBar, which is the sum of
+// the widths of the visible items and a single line in height.
+func (m *CMenuBar) GetSizeRequest() (width, height int) {
+	width, height = m.CMenuShell.GetSizeRequest()
+	if width <= -1 {
+		width = 0
+		for _, item := range m.GetItems() {
+			if item.IsVisible() {
+				w, _ := item.GetSizeRequest()
+				width += w
+			}
+		}
+	}
+	if height <= -1 {
+		height = 1
+	}
+	return
+}
+
+// openMenuFromKey selects the given item (or the first item) and opens its
+// submenu, or simply activates the MenuBar if the item has no submenu.
+func (m *CMenuBar) openMenuFromKey(item MenuItem) {
+	if item == nil {
+		m.activateShell(m.GetWindow())
+		m.SelectFirst(true)
+		item = m.GetSelectedItem()
+	}
+	if item == nil {
+		m.Deactivate()
+		return
+	}
+	if item.GetSubmenu() != nil {
+		m.popupSubmenu(item, true)
+		return
+	}
+	m.activateShell(m.GetWindow())
+	m.SelectItem(item)
+	menuRequestDraw()
+}
+
+func (m *CMenuBar) windowKeyEvent(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if _, event, ok := ArgvSignalEvent(argv...); ok {
+		if e, ok := event.(*cdk.EventKey); ok {
+			if !m.IsVisible() || !m.IsSensitive() || m.IsActive() {
+				return cenums.EVENT_PASS
+			}
+			if menuIsMenuBarAccel(e) {
+				m.openMenuFromKey(nil)
+				return cenums.EVENT_STOP
+			}
+			if window := m.GetWindow(); window != nil && e.Key() == cdk.KeyRune {
+				if mods := window.GetMnemonicModifier(); mods != 0 && e.Modifiers() == mods {
+					if item := m.getMnemonicItem(e.Rune()); item != nil {
+						m.openMenuFromKey(item)
+						return cenums.EVENT_STOP
+					}
+				}
+			}
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (m *CMenuBar) event(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if evt, ok := argv[1].(cdk.Event); ok {
+		switch e := evt.(type) {
+		case *cdk.EventMouse:
+			return m.processMouseEvent(e)
+		case *cdk.EventKey:
+			return m.processKeyEvent(e)
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (m *CMenuBar) processKeyEvent(e *cdk.EventKey) cenums.EventFlag {
+	if !m.IsActive() {
+		return cenums.EVENT_PASS
+	}
+	if menuIsMenuBarAccel(e) {
+		m.Cancel()
+		return cenums.EVENT_STOP
+	}
+	switch e.Key() {
+	case cdk.KeyLeft:
+		m.MoveCurrent(enums.MENU_DIR_PREV)
+		return cenums.EVENT_STOP
+	case cdk.KeyRight:
+		m.MoveCurrent(enums.MENU_DIR_NEXT)
+		return cenums.EVENT_STOP
+	case cdk.KeyUp, cdk.KeyDown:
+		m.ActivateCurrent(false)
+		return cenums.EVENT_STOP
+	case cdk.KeyEscape:
+		m.Cancel()
+		return cenums.EVENT_STOP
+	}
+	switch cdk.Key(e.Rune()) {
+	case cdk.KeyEnter, cdk.KeySpace:
+		m.ActivateCurrent(false)
+		return cenums.EVENT_STOP
+	}
+	if e.Key() == cdk.KeyRune && !e.Modifiers().Has(cdk.ModCtrl) {
+		if item := m.getMnemonicItem(e.Rune()); item != nil {
+			m.ActivateItem(item, false)
+		}
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+func (m *CMenuBar) processMouseEvent(e *cdk.EventMouse) cenums.EventFlag {
+	if e.IsWheelImpulse() {
+		return cenums.EVENT_STOP
+	}
+	var item MenuItem
+	point := e.Point2I()
+	for _, child := range m.GetItems() {
+		if child.IsVisible() && menuRegionHasPoint(child, point) {
+			item = child
+			break
+		}
+	}
+	if item == nil || !menuItemIsSelectable(item) {
+		return cenums.EVENT_STOP
+	}
+	selected := m.GetSelectedItem()
+	isSelected := selected != nil && selected.ObjectID() == item.ObjectID()
+	switch e.State() {
+	case cdk.MOUSE_MOVE, cdk.DRAG_MOVE, cdk.DRAG_START:
+		if m.IsActive() && !isSelected {
+			if selected != nil && menuItemSubmenuIsOpen(selected) && item.GetSubmenu() != nil {
+				m.popupSubmenu(item, false)
+			} else {
+				m.hoverItem(item, 0)
+			}
+		}
+	case cdk.BUTTON_PRESS:
+		if isSelected && menuItemSubmenuIsOpen(item) {
+			m.Cancel()
+		} else if item.GetSubmenu() != nil {
+			m.popupSubmenu(item, false)
+		} else {
+			m.activateShell(m.GetWindow())
+			m.SelectItem(item)
+		}
+	case cdk.BUTTON_RELEASE, cdk.DRAG_STOP:
+		if isSelected && item.GetSubmenu() == nil {
+			m.ActivateItem(item, true)
+		}
+	}
+	menuRequestDraw()
+	return cenums.EVENT_STOP
+}
+
+func (m *CMenuBar) resize(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	origin := m.GetOrigin()
+	alloc := m.GetAllocation()
+	var left, right []MenuItem
+	for _, item := range m.GetItems() {
+		if !item.IsVisible() {
+			item.SetAllocation(ptypes.MakeRectangle(0, 0))
+			item.Resize()
+			continue
+		}
+		if item.GetRightJustified() {
+			right = append(right, item)
+		} else {
+			left = append(left, item)
+		}
+	}
+	x := 0
+	place := func(item MenuItem, at int) {
+		w, _ := item.GetSizeRequest()
+		if at+w > alloc.W {
+			w = alloc.W - at
+		}
+		if w <= 0 || alloc.H <= 0 {
+			item.SetAllocation(ptypes.MakeRectangle(0, 0))
+		} else {
+			item.SetOrigin(origin.X+at, origin.Y)
+			item.SetAllocation(ptypes.MakeRectangle(w, 1))
+		}
+		item.Resize()
+	}
+	for _, item := range left {
+		place(item, x)
+		w, _ := item.GetSizeRequest()
+		x += w
+	}
+	rightWidth := 0
+	for _, item := range right {
+		w, _ := item.GetSizeRequest()
+		rightWidth += w
+	}
+	if start := alloc.W - rightWidth; start > x {
+		x = start
+	}
+	for _, item := range right {
+		place(item, x)
+		w, _ := item.GetSizeRequest()
+		x += w
+	}
+	m.Invalidate()
+	return cenums.EVENT_STOP
+}
+
+func (m *CMenuBar) draw(data []interface{}, argv ...interface{}) cenums.EventFlag {
+
+	if surface, ok := argv[1].(*memphis.CSurface); ok {
+		alloc := m.GetAllocation()
+		if !m.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+			m.LogTrace("not visible, zero width or zero height")
+			return cenums.EVENT_PASS
+		}
+
+		surface.Fill(m.GetTheme())
+
+		for _, item := range m.GetItems() {
+			itemAlloc := item.GetAllocation()
+			if !item.IsVisible() || itemAlloc.W <= 0 || itemAlloc.H <= 0 {
+				continue
+			}
+			item.Draw()
+			item.LockDraw()
+			if err := surface.Composite(item.ObjectID()); err != nil {
+				m.LogError("composite error: %v", err)
+			}
+			item.UnlockDraw()
+		}
+
+		if debug, _ := m.GetBoolProperty(cdk.PropertyDebug); debug {
+			surface.DebugBox(paint.ColorNavy, m.ObjectInfo())
+		}
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+const MenuBarKeyHandle = "menu-bar-key-handler"
+
+const MenuBarEventHandle = "menu-bar-event-handler"
+
+const MenuBarResizeHandle = "menu-bar-resize-handler"
+
+const MenuBarDrawHandle = "menu-bar-draw-handler"
@@ -0,0 +1,739 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	cmath "github.com/go-curses/cdk/lib/math"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/go-curses/cdk/lib/ptypes"
+	cstrings "github.com/go-curses/cdk/lib/strings"
+	"github.com/go-curses/cdk/memphis"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeMenuItem cdk.CTypeTag = "ctk-menu-item"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeMenuItem, func() interface{} { return MakeMenuItem() })
+}
+
+// MenuItem Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Bin
+//	        +- Item
+//	          +- MenuItem
+//	            +- CheckMenuItem
+//	            +- ImageMenuItem
+//	            +- SeparatorMenuItem
+//	            +- TearoffMenuItem
+//
+// The MenuItem Widget and the derived widgets are the only valid children for
+// menus. Their function is to correctly handle highlighting, alignment, events
+// and submenus. As it derives from Bin it can hold any valid child widget,
+// although only a few are really useful; typically a Label.
+//
+// Within a Menu, each MenuItem is drawn on a single line with the label
+// followed by the accelerator of the accel-path (right aligned) and an arrow
+// if the item has a submenu. Within a MenuBar, only the label is drawn.
+type MenuItem interface {
+	Bin
+	Activatable
+	Buildable
+
+	Activate() (value bool)
+	Clicked() cenums.EventFlag
+	Select()
+	Deselect()
+	GetLabel() (value string)
+	SetLabel(label string)
+	GetUseUnderline() (value bool)
+	SetUseUnderline(setting bool)
+	GetRightJustified() (value bool)
+	SetRightJustified(rightJustified bool)
+	GetSubmenu() (value Menu)
+	SetSubmenu(submenu Menu)
+	RemoveSubmenu()
+	GetAccelPath() (value string)
+	GetAccelLabel() (value string)
+	ToggleSizeRequest() (requisition int)
+	ToggleSizeAllocate(allocation int)
+}
+
+var _ MenuItem = (*CMenuItem)(nil)
+
+// The CMenuItem structure implements the MenuItem interface and is exported
+// to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with MenuItem objects.
+type CMenuItem struct {
+	CBin
+
+	submenu     Menu
+	selected    bool
+	toggleSize  int
+	accelGroup  AccelGroup
+	accelHandle string
+}
+
+// MakeMenuItem is used by the Buildable system to construct a new MenuItem.
+func MakeMenuItem() MenuItem {
+	return NewMenuItem()
+}
+
+// NewMenuItem is the constructor for new MenuItem instances without a label.
+func NewMenuItem() MenuItem {
+	i := new(CMenuItem)
+	i.Init()
+	return i
+}
+
+// NewMenuItemWithLabel creates a new MenuItem whose child is a Label.
+//
+// Parameters:
+//
+//	label	the text for the label
+func NewMenuItemWithLabel(label string) MenuItem {
+	i := NewMenuItem()
+	i.SetLabel(label)
+	return i
+}
+
+// NewMenuItemWithMnemonic creates a new MenuItem containing a Label. The label
+// will be created using NewLabelWithMnemonic, so underscores in label indicate
+// the mnemonic for the menu item.
+//
+// Parameters:
+//
+//	label	the text of the button, with an underscore in front of the
+//	        mnemonic character
+func NewMenuItemWithMnemonic(label string) MenuItem {
+	i := NewMenuItem()
+	i.SetUseUnderline(true)
+	i.SetLabel(label)
+	return i
+}
+
+// Init initializes a MenuItem object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the MenuItem instance. Init is used in the
+// NewMenuItem constructor and only necessary when implementing a derivative
+// MenuItem type.
+func (i *CMenuItem) Init() (already bool) {
+	if i.InitTypeItem(TypeMenuItem, i) {
+		return true
+	}
+	i.CBin.Init()
+	i.flags = enums.NULL_WIDGET_FLAG
+	i.SetFlags(enums.SENSITIVE | enums.PARENT_SENSITIVE | enums.APP_PAINTABLE)
+	i.submenu = nil
+	i.selected = false
+	i.toggleSize = 0
+	i.accelGroup = nil
+	i.accelHandle = fmt.Sprintf("%v-%v", MenuItemAccelHandle, i.ObjectID())
+	_ = i.InstallBuildableProperty(PropertyAccelPath, cdk.StringProperty, true, "")
+	_ = i.InstallBuildableProperty(PropertyLabel, cdk.StringProperty, true, "")
+	_ = i.InstallBuildableProperty(PropertyRightJustified, cdk.BoolProperty, true, false)
+	_ = i.InstallProperty(PropertySubmenu, cdk.StructProperty, true, nil)
+	_ = i.InstallBuildableProperty(PropertyUseUnderline, cdk.BoolProperty, true, false)
+	i.Connect(SignalInvalidate, MenuItemInvalidateHandle, i.invalidate)
+	i.Connect(SignalResize, MenuItemResizeHandle, i.resize)
+	i.Connect(SignalDraw, MenuItemDrawHandle, i.draw)
+	return false
+}
+
+// Build provides customizations to the Buildable system for MenuItem Widgets.
+// A Menu child (typically within a <child type="submenu"> tag) is used as the
+// submenu of the MenuItem.
+func (i *CMenuItem) Build(builder Builder, element *CBuilderElement) error {
+	i.Freeze()
+	defer i.Thaw()
+	if name, ok := element.Attributes["id"]; ok {
+		i.SetName(name)
+	}
+	if v, ok := element.Properties[PropertyUseUnderline.String()]; ok {
+		i.SetUseUnderline(cstrings.IsTrue(v))
+	}
+	if v, ok := element.Properties[PropertyLabel.String()]; ok {
+		i.SetLabel(v)
+	}
+	for k, v := range element.Properties {
+		switch cdk.Property(k) {
+		case PropertyLabel:
+		case PropertyUseUnderline:
+		default:
+			element.ApplyProperty(k, v)
+		}
+	}
+	for _, child := range element.Children {
+		if newChild := builder.Build(child); newChild != nil {
+			child.Instance = newChild
+			if menu, ok := newChild.(Menu); ok {
+				i.SetSubmenu(menu)
+			} else if newChildWidget, ok := newChild.(Widget); ok {
+				newChildWidget.Show()
+				i.Add(newChildWidget)
+			} else {
+				i.LogError("new child object is not a Widget type: %v (%T)", newChild, newChild)
+			}
+		}
+	}
+	element.ApplySignals()
+	return nil
+}
+
+// Activate emits the activate signal on the MenuItem. If the MenuItem has a
+// submenu, the submenu is popped up by the parent MenuShell.
+//
+// Returns:
+//
+//	TRUE if the MenuItem was activated
+func (i *CMenuItem) Activate() (value bool) {
+	if !i.IsVisible() || !i.IsSensitive() {
+		return false
+	}
+	i.Emit(SignalActivate, i)
+	if i.GetSubmenu() != nil {
+		if shell := i.getParentShell(); shell != nil {
+			shell.ActivateItem(i, false)
+		}
+	}
+	return true
+}
+
+// Clicked is a convenience method to Activate the MenuItem, returning
+// EVENT_STOP if the MenuItem was activated.
+func (i *CMenuItem) Clicked() cenums.EventFlag {
+	if menuItem, ok := i.Self().(MenuItem); ok && menuItem.Activate() {
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+// Select emits the select signal on the MenuItem and if the listeners return
+// EVENT_PASS, the MenuItem is highlighted.
+func (i *CMenuItem) Select() {
+	if f := i.Emit(SignalSelect, i); f == cenums.EVENT_PASS {
+		i.Lock()
+		i.selected = true
+		i.Unlock()
+		i.Invalidate()
+	}
+}
+
+// Deselect emits the deselect signal on the MenuItem and if the listeners
+// return EVENT_PASS, the highlight is removed and any open submenu is popped
+// down.
+func (i *CMenuItem) Deselect() {
+	if f := i.Emit(SignalDeselect, i); f == cenums.EVENT_PASS {
+		i.Lock()
+		i.selected = false
+		i.Unlock()
+		if submenu := i.GetSubmenu(); submenu != nil && submenu.IsPoppedUp() {
+			submenu.Popdown()
+		}
+		i.Invalidate()
+	}
+}
+
+// GetLabel returns the text of the MenuItem label. If the child Widget is not
+// a Label, the value of the label property is returned instead.
+func (i *CMenuItem) GetLabel() (value string) {
+	if child := i.GetChild(); child != nil {
+		if label, ok := child.Self().(Label); ok {
+			return label.GetLabel()
+		}
+	}
+	var err error
+	if value, err = i.GetStringProperty(PropertyLabel); err != nil {
+		i.LogErr(err)
+	}
+	return
+}
+
+// SetLabel updates the text of the MenuItem label, creating a new child Label
+// if the MenuItem does not already have one.
+//
+// Parameters:
+//
+//	label	the text you want to set
+func (i *CMenuItem) SetLabel(label string) {
+	if err := i.SetStringProperty(PropertyLabel, label); err != nil {
+		i.LogErr(err)
+	}
+	if child := i.GetChild(); child != nil {
+		if l, ok := child.Self().(Label); ok {
+			l.SetLabel(label)
+			i.Resize()
+			return
+		}
+		i.Remove(child)
+	}
+	i.Add(i.makeLabel(label))
+	i.Resize()
+}
+
+// GetUseUnderline checks if an underline in the text indicates the next
+// character should be used for the mnemonic accelerator key.
+func (i *CMenuItem) GetUseUnderline() (value bool) {
+	var err error
+	if value, err = i.GetBoolProperty(PropertyUseUnderline); err != nil {
+		i.LogErr(err)
+	}
+	return
+}
+
+// SetUseUnderline updates the use-underline property. If TRUE, an underline
+// in the text of the MenuItem label indicates the next character should be
+// used for the mnemonic accelerator key.
+//
+// Parameters:
+//
+//	setting	TRUE if underlines in the text indicate mnemonics
+func (i *CMenuItem) SetUseUnderline(setting bool) {
+	if err := i.SetBoolProperty(PropertyUseUnderline, setting); err != nil {
+		i.LogErr(err)
+	}
+	if child := i.GetChild(); child != nil {
+		if label, ok := child.Self().(Label); ok {
+			label.SetUseUnderline(setting)
+		}
+	}
+}
+
+// GetRightJustified returns whether the MenuItem appears justified at the
+// right side of a MenuBar.
+func (i *CMenuItem) GetRightJustified() (value bool) {
+	var err error
+	if value, err = i.GetBoolProperty(PropertyRightJustified); err != nil {
+		i.LogErr(err)
+	}
+	return
+}
+
+// SetRightJustified updates whether the MenuItem appears justified at the
+// right side of a MenuBar. This was traditionally done for "Help" menu items,
+// but is now considered a bad idea. (If the widget layout is reversed for a
+// right-to-left language like Hebrew or Arabic, right-justified-menus will be
+// justified at the left side.)
+//
+// Parameters:
+//
+//	rightJustified	if TRUE the menu item will appear at the far right if
+//	                added to a menu bar
+func (i *CMenuItem) SetRightJustified(rightJustified bool) {
+	if err := i.SetBoolProperty(PropertyRightJustified, rightJustified); err != nil {
+		i.LogErr(err)
+	}
+	if shell := i.getParentShell(); shell != nil {
+		shell.Resize()
+	}
+}
+
+// GetSubmenu returns the submenu underneath this MenuItem, if any.
+func (i *CMenuItem) GetSubmenu() (value Menu) {
+	i.RLock()
+	defer i.RUnlock()
+	return i.submenu
+}
+
+// SetSubmenu sets or replaces the MenuItem's submenu, or removes it when a nil
+// submenu is passed.
+//
+// Parameters:
+//
+//	submenu	the submenu, or nil
+func (i *CMenuItem) SetSubmenu(submenu Menu) {
+	previous := i.GetSubmenu()
+	if previous != nil {
+		if submenu != nil && previous.ObjectID() == submenu.ObjectID() {
+			return
+		}
+		previous.Popdown()
+		previous.Detach()
+	}
+	i.Lock()
+	i.submenu = submenu
+	i.Unlock()
+	if err := i.SetStructProperty(PropertySubmenu, submenu); err != nil {
+		i.LogErr(err)
+	}
+	if submenu != nil {
+		submenu.AttachToWidget(i)
+	}
+	if shell := i.getParentShell(); shell != nil {
+		shell.Resize()
+	}
+	i.Invalidate()
+}
+
+// RemoveSubmenu removes the MenuItem's submenu.
+func (i *CMenuItem) RemoveSubmenu() {
+	i.SetSubmenu(nil)
+}
+
+// GetAccelPath returns the path to the accelerator that was set with
+// SetAccelPath, or an empty string.
+func (i *CMenuItem) GetAccelPath() (value string) {
+	var err error
+	if value, err = i.GetStringProperty(PropertyAccelPath); err != nil {
+		i.LogErr(err)
+	}
+	return
+}
+
+// SetAccelPath sets the accelerator path on the MenuItem, through which
+// runtime changes of the menu item's accelerator caused by the user can be
+// identified and saved to persistent storage (see AccelMap.Save on this). When
+// an AccelGroup is given, the MenuItem is activated by the accelerator
+// registered for the path in the AccelMap.
+//
+// Parameters:
+//
+//	accelPath	accelerator path, corresponding to this menu item's
+//	            functionality, or an empty string to unset the current path
+//	accelGroup	the AccelGroup to connect the accelerator with, or nil
+func (i *CMenuItem) SetAccelPath(accelPath string, accelGroup AccelGroup) {
+	previousPath := i.GetAccelPath()
+	i.Lock()
+	previousGroup := i.accelGroup
+	i.accelGroup = accelGroup
+	i.Unlock()
+	if previousGroup != nil && previousPath != "" {
+		if accelMap := GetAccelMap(); accelMap != nil {
+			if accelerator, ok := accelMap.LookupEntry(previousPath); ok {
+				previousGroup.DisconnectKey(accelerator.Key(), accelerator.Mods())
+			}
+		}
+	}
+	if err := i.SetStringProperty(PropertyAccelPath, accelPath); err != nil {
+		i.LogErr(err)
+	}
+	if accelGroup != nil && accelPath != "" {
+		accelGroup.ConnectByPath(accelPath, i.accelHandle, func(argv ...interface{}) (handled bool) {
+			if menuItem, ok := i.Self().(MenuItem); ok {
+				return menuItem.Activate()
+			}
+			return false
+		})
+	}
+	i.Invalidate()
+}
+
+// GetAccelLabel returns the text of the accelerator registered for the
+// accel-path of the MenuItem, for example "Ctrl+Q", or an empty string if there
+// is none.
+func (i *CMenuItem) GetAccelLabel() (value string) {
+	if accelPath := i.GetAccelPath(); accelPath != "" {
+		if accelMap := GetAccelMap(); accelMap != nil {
+			if accelerator, ok := accelMap.LookupEntry(accelPath); ok {
+				value = menuItemAccelLabel(accelerator.Key(), accelerator.Mods())
+			}
+		}
+	}
+	return
+}
+
+// ToggleSizeRequest returns the number of columns the MenuItem needs in front
+// of the label for drawing a toggle indicator. Plain MenuItems do not need
+// any.
+func (i *CMenuItem) ToggleSizeRequest() (requisition int) {
+	return 0
+}
+
+// ToggleSizeAllocate sets the number of columns in front of the label that are
+// reserved for toggle indicators. This is used by Menu to line up the labels
+// of all items.
+//
+// Parameters:
+//
+//	allocation	the number of columns to reserve
+func (i *CMenuItem) ToggleSizeAllocate(allocation int) {
+	i.Lock()
+	i.toggleSize = allocation
+	i.Unlock()
+}
+
+// GetSizeRequest returns the requested size of the MenuItem, which is the
+// width of the label, toggle indicator, accelerator and submenu arrow plus one
+// space of padding on either side.
+func (i *CMenuItem) GetSizeRequest() (width, height int) {
+	width, height = i.CBin.GetSizeRequest()
+	if width <= -1 {
+		width = 2
+		if child := i.GetChild(); child != nil {
+			if label, ok := child.Self().(Label); ok {
+				lw, _ := label.GetPlainTextInfo()
+				width += cmath.FloorI(lw, 0)
+			} else {
+				cw, _ := child.GetSizeRequest()
+				width += cmath.FloorI(cw, 0)
+			}
+		}
+		if i.getPlacement() == enums.LEFT_RIGHT {
+			i.RLock()
+			width += i.toggleSize
+			i.RUnlock()
+			if accel := i.GetAccelLabel(); accel != "" {
+				width += 2 + utf8.RuneCountInString(accel)
+			}
+			if i.GetSubmenu() != nil {
+				width += 2
+			}
+		}
+	}
+	if height <= -1 {
+		height = 1
+	}
+	return
+}
+
+// getParentShell returns the MenuShell this MenuItem is in, if any.
+func (i *CMenuItem) getParentShell() (shell MenuShell) {
+	if parent := i.GetParent(); parent != nil {
+		shell, _ = parent.Self().(MenuShell)
+	}
+	return
+}
+
+// getPlacement returns TOP_BOTTOM for items within a MenuBar (the submenu
+// appears below the item) and LEFT_RIGHT otherwise.
+func (i *CMenuItem) getPlacement() enums.SubmenuPlacement {
+	if parent := i.GetParent(); parent != nil {
+		if _, ok := parent.Self().(*CMenuBar); ok {
+			return enums.TOP_BOTTOM
+		}
+	}
+	return enums.LEFT_RIGHT
+}
+
+func (i *CMenuItem) isSelected() bool {
+	i.RLock()
+	defer i.RUnlock()
+	return i.selected
+}
+
+// getItemTheme returns the theme of the MenuItem with the normal content style
+// replaced for the insensitive or selected states.
+func (i *CMenuItem) getItemTheme() (theme paint.Theme) {
+	theme = i.GetTheme()
+	if !i.IsSensitive() {
+		theme.Content.Normal = theme.Content.Insensitive
+	} else if i.isSelected() {
+		theme.Content.Normal = theme.Content.Active
+	}
+	return
+}
+
+func (i *CMenuItem) makeLabel(text string) (label Label) {
+	label = NewLabel("")
+	label.Show()
+	label.UnsetFlags(enums.CAN_FOCUS)
+	label.UnsetFlags(enums.CAN_DEFAULT)
+	label.UnsetFlags(enums.RECEIVES_DEFAULT)
+	label.SetLineWrap(false)
+	label.SetLineWrapMode(cenums.WRAP_NONE)
+	label.SetJustify(cenums.JUSTIFY_LEFT)
+	label.SetAlignment(0.0, 0.5)
+	label.SetSingleLineMode(true)
+	label.SetUseUnderline(i.GetUseUnderline())
+	label.SetLabel(text)
+	return
+}
+
+func (i *CMenuItem) invalidate(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if child := i.GetChild(); child != nil {
+		WidgetRecurseInvalidate(child)
+	}
+	return cenums.EVENT_PASS
+}
+
+func (i *CMenuItem) resize(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	child := i.GetChild()
+	if child == nil {
+		return cenums.EVENT_STOP
+	}
+	alloc := i.GetAllocation()
+	origin := i.GetOrigin()
+	if alloc.W <= 2 || alloc.H <= 0 {
+		child.SetAllocation(ptypes.MakeRectangle(0, 0))
+		return child.Resize()
+	}
+	x, w := 1, alloc.W-2
+	if i.getPlacement() == enums.LEFT_RIGHT {
+		i.RLock()
+		x += i.toggleSize
+		w -= i.toggleSize
+		i.RUnlock()
+		if accel := i.GetAccelLabel(); accel != "" {
+			w -= 2 + utf8.RuneCountInString(accel)
+		}
+		if i.GetSubmenu() != nil {
+			w -= 2
+		}
+	}
+	if w < 0 {
+		w = 0
+	}
+	child.SetOrigin(origin.X+x, origin.Y)
+	child.SetAllocation(ptypes.MakeRectangle(w, 1))
+	child.Resize()
+	i.Invalidate()
+	return cenums.EVENT_STOP
+}
+
+func (i *CMenuItem) draw(data []interface{}, argv ...interface{}) cenums.EventFlag {
+
+	if surface, ok := argv[1].(*memphis.CSurface); ok {
+		alloc := i.GetAllocation()
+		if !i.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+			i.LogTrace("not visible, zero width or zero height")
+			return cenums.EVENT_PASS
+		}
+
+		theme := i.getItemTheme()
+		style := theme.Content.Normal
+		surface.Fill(theme)
+
+		if i.getPlacement() == enums.LEFT_RIGHT {
+			if indicator := menuItemToggleIndicator(i); indicator != "" {
+				surface.DrawSingleLineText(ptypes.MakePoint2I(1, 0), alloc.W-2, false, cenums.JUSTIFY_LEFT, style, false, false, indicator)
+			}
+			right := alloc.W - 1
+			if i.GetSubmenu() != nil && alloc.W > 3 {
+				_ = surface.SetRune(alloc.W-2, 0, theme.Content.ArrowRunes.Right, style)
+				right = alloc.W - 3
+			}
+			if accel := i.GetAccelLabel(); accel != "" {
+				size := utf8.RuneCountInString(accel)
+				if pos := right - size; pos > 1 {
+					surface.DrawSingleLineText(ptypes.MakePoint2I(pos, 0), size, false, cenums.JUSTIFY_LEFT, style, false, false, accel)
+				}
+			}
+		}
+
+		if child := i.GetChild(); child != nil && child.IsVisible() {
+			child.SetTheme(theme)
+			child.Draw()
+			child.LockDraw()
+			if err := surface.Composite(child.ObjectID()); err != nil {
+				i.LogError("composite error: %v", err)
+			}
+			child.UnlockDraw()
+		}
+
+		if debug, _ := i.GetBoolProperty(cdk.PropertyDebug); debug {
+			surface.DebugBox(paint.ColorSilver, i.ObjectInfo())
+		}
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+// menuItemToggleIndicator returns the text drawn in the toggle column of the
+// given MenuItem, ie: "[x]" for an active CheckMenuItem.
+func menuItemToggleIndicator(item *CMenuItem) (indicator string) {
+	if check, ok := item.Self().(CheckMenuItem); ok {
+		open, mark, closed := "[", " ", "]"
+		if check.GetDrawAsRadio() {
+			open, closed = "(", ")"
+		}
+		if check.GetInconsistent() {
+			mark = "-"
+		} else if check.GetActive() {
+			mark = "x"
+			if check.GetDrawAsRadio() {
+				mark = "*"
+			}
+		}
+		indicator = open + mark + closed
+	}
+	return
+}
+
+// menuItemAccelLabel returns the human-readable text for the given accelerator
+// key and modifiers, ie: "Ctrl+Shift+S".
+func menuItemAccelLabel(key cdk.Key, mods cdk.ModMask) (label string) {
+	if mods.Has(cdk.ModCtrl) {
+		label += "Ctrl+"
+	}
+	if mods.Has(cdk.ModAlt) {
+		label += "Alt+"
+	}
+	if mods.Has(cdk.ModMeta) {
+		label += "Meta+"
+	}
+	if mods.Has(cdk.ModShift) {
+		label += "Shift+"
+	}
+	if key > 32 && key < 127 {
+		label += strings.ToUpper(string(rune(key)))
+	} else {
+		label += cdk.LookupKeyName(key)
+	}
+	return
+}
+
+// The accel path of the menu item.
+// Flags: Read / Write
+// Default value: NULL
+// const PropertyAccelPath cdk.Property = "accel-path"
+
+// The text for the child label.
+// Flags: Read / Write
+// Default value: ""
+// const PropertyLabel cdk.Property = "label"
+
+// Sets whether the menu item appears justified at the right side of a menu
+// bar.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyRightJustified cdk.Property = "right-justified"
+
+// The submenu attached to the menu item, or NULL if it has none.
+// Flags: Read / Write
+const PropertySubmenu cdk.Property = "submenu"
+
+// True if underlines in the text indicate mnemonics
+// Flags: Read / Write
+// Default value: FALSE
+// const PropertyUseUnderline cdk.Property = "use-underline"
+
+// Emitted when the item is activated.
+// const SignalActivate cdk.Signal = "activate"
+
+// Emitted when the item is deselected.
+const SignalDeselect cdk.Signal = "deselect"
+
+// Emitted when the item is selected.
+const SignalSelect cdk.Signal = "select"
+
+const MenuItemAccelHandle = "menu-item-accel-handler"
+
+const MenuItemInvalidateHandle = "menu-item-invalidate-handler"
+
+const MenuItemResizeHandle = "menu-item-resize-handler"
+
+const MenuItemDrawHandle = "menu-item-draw-handler"
@@ -0,0 +1,802 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"time"
+	"unicode"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/ptypes"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeMenuShell cdk.CTypeTag = "ctk-menu-shell"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeMenuShell, nil)
+}
+
+// MenuShell Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- MenuShell
+//	        +- MenuBar
+//	        +- Menu
+//
+// The MenuShell is the abstract base class of MenuBar and Menu. A MenuShell
+// is a Container of MenuItem objects arranged in a list which can be
+// navigated, selected, and activated by the user to perform application
+// functions. A MenuItem can have a submenu associated with it, allowing for
+// nested hierarchical menus.
+//
+// While a MenuShell is active, it holds a grab on the Window it belongs to:
+// all key events are delivered to the deepest open Menu and mouse events are
+// delivered to whichever open MenuShell is under the pointer. Pressing a mouse
+// button outside all open menus cancels the whole chain.
+type MenuShell interface {
+	Container
+	Buildable
+
+	Append(child Widget)
+	Prepend(child Widget)
+	Insert(child Widget, position int)
+	Deactivate()
+	SelectItem(menuItem Widget)
+	Deselect()
+	ActivateItem(menuItem Widget, forceDeactivate bool)
+	SelectFirst(searchSensitive bool)
+	Cancel()
+	GetTakeFocus() (value bool)
+	SetTakeFocus(takeFocus bool)
+	GetSelectedItem() (value MenuItem)
+	GetParentShell() (value MenuShell)
+	IsActive() (active bool)
+	GetItems() (items []MenuItem)
+	MoveSelected(distance int) (moved bool)
+	MoveCurrent(direction enums.MenuDirectionType)
+	ActivateCurrent(forceHide bool)
+	CancelEvent()
+}
+
+var _ MenuShell = (*CMenuShell)(nil)
+
+// The CMenuShell structure implements the MenuShell interface and is exported
+// to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with MenuShell objects.
+type CMenuShell struct {
+	CContainer
+
+	active      bool
+	selected    MenuItem
+	parentShell MenuShell
+	grabWindow  Window
+	grabHandle  string
+	timer       uuid.UUID
+}
+
+// Init initializes a MenuShell object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the MenuShell instance. Init is used by the
+// MenuBar and Menu constructors and only necessary when implementing a
+// derivative MenuShell type.
+func (s *CMenuShell) Init() (already bool) {
+	if s.InitTypeItem(TypeMenuShell, s) {
+		return true
+	}
+	s.CContainer.Init()
+	s.flags = enums.NULL_WIDGET_FLAG
+	s.SetFlags(enums.SENSITIVE | enums.PARENT_SENSITIVE | enums.APP_PAINTABLE)
+	s.active = false
+	s.selected = nil
+	s.parentShell = nil
+	s.grabWindow = nil
+	s.grabHandle = fmt.Sprintf("%v-%v", MenuShellGrabHandle, s.ObjectID())
+	s.timer = uuid.Nil
+	_ = s.InstallProperty(PropertyTakeFocus, cdk.BoolProperty, true, true)
+	return false
+}
+
+// Build provides customizations to the Buildable system for MenuShell
+// Widgets. Each child object must be a MenuItem and is appended in the order
+// given.
+func (s *CMenuShell) Build(builder Builder, element *CBuilderElement) error {
+	s.Freeze()
+	defer s.Thaw()
+	if err := s.CObject.Build(builder, element); err != nil {
+		return err
+	}
+	for _, child := range element.Children {
+		if newChild := builder.Build(child); newChild != nil {
+			child.Instance = newChild
+			if newChildWidget, ok := newChild.(Widget); ok {
+				newChildWidget.Show()
+				s.Append(newChildWidget)
+			} else {
+				s.LogError("new child object is not a Widget type: %v (%T)", newChild, newChild)
+			}
+		}
+	}
+	return nil
+}
+
+// Add is a convenience method for Append.
+func (s *CMenuShell) Add(w Widget) {
+	s.Insert(w, -1)
+}
+
+// Remove the given MenuItem from the MenuShell, deselecting it first if it is
+// the currently selected item.
+func (s *CMenuShell) Remove(w Widget) {
+	if selected := s.GetSelectedItem(); selected != nil && selected.ObjectID() == w.ObjectID() {
+		s.Deselect()
+	}
+	s.CContainer.Remove(w)
+	s.Resize()
+}
+
+// Append adds a new MenuItem to the end of the MenuShell's item list.
+//
+// Parameters:
+//
+//	child	the MenuItem to add
+func (s *CMenuShell) Append(child Widget) {
+	s.Insert(child, -1)
+}
+
+// Prepend adds a new MenuItem to the beginning of the MenuShell's item list.
+//
+// Parameters:
+//
+//	child	the MenuItem to add
+func (s *CMenuShell) Prepend(child Widget) {
+	s.Insert(child, 0)
+}
+
+// Insert adds a new MenuItem to the MenuShell's item list at the position
+// indicated by position. A negative position appends the item.
+//
+// Parameters:
+//
+//	child	the MenuItem to add
+//	position	the position in the item list where child is added
+func (s *CMenuShell) Insert(child Widget, position int) {
+	if _, ok := child.Self().(MenuItem); !ok {
+		s.LogError("menu shell children must be MenuItem widgets: %v (%T)", child, child)
+		return
+	}
+	s.CContainer.Add(child)
+	s.Lock()
+	last := len(s.children) - 1
+	if last >= 0 && s.children[last].ObjectID() == child.ObjectID() {
+		if position >= 0 && position < last {
+			copy(s.children[position+1:], s.children[position:last])
+			s.children[position] = child
+		}
+	}
+	s.Unlock()
+	s.Resize()
+}
+
+// GetItems returns the MenuItem children of the MenuShell, in order.
+func (s *CMenuShell) GetItems() (items []MenuItem) {
+	for _, child := range s.GetChildren() {
+		if item, ok := child.Self().(MenuItem); ok {
+			items = append(items, item)
+		}
+	}
+	return
+}
+
+// GetTakeFocus returns TRUE if the menu shell will take the keyboard focus on
+// popup.
+func (s *CMenuShell) GetTakeFocus() (value bool) {
+	var err error
+	if value, err = s.GetBoolProperty(PropertyTakeFocus); err != nil {
+		s.LogErr(err)
+	}
+	return
+}
+
+// SetTakeFocus updates the take-focus property. If takeFocus is TRUE (the
+// default) the menu shell will take the keyboard focus so that it will
+// receive all keyboard events which is needed to enable keyboard navigation
+// in menus.
+//
+// Parameters:
+//
+//	takeFocus	TRUE if the menu shell should take the keyboard focus on popup
+func (s *CMenuShell) SetTakeFocus(takeFocus bool) {
+	if err := s.SetBoolProperty(PropertyTakeFocus, takeFocus); err != nil {
+		s.LogErr(err)
+	}
+}
+
+// GetSelectedItem returns the currently selected MenuItem, or nil if there is
+// no selection.
+func (s *CMenuShell) GetSelectedItem() (value MenuItem) {
+	s.RLock()
+	defer s.RUnlock()
+	return s.selected
+}
+
+// GetParentShell returns the MenuShell that popped up this one, or nil if this
+// MenuShell is the root of the menu hierarchy.
+func (s *CMenuShell) GetParentShell() (value MenuShell) {
+	s.RLock()
+	defer s.RUnlock()
+	return s.parentShell
+}
+
+// IsActive returns TRUE if the MenuShell is currently in use by the user.
+func (s *CMenuShell) IsActive() (active bool) {
+	s.RLock()
+	defer s.RUnlock()
+	return s.active
+}
+
+// SelectItem selects the given MenuItem, deselecting any previously selected
+// item first. Insensitive, hidden and separator items cannot be selected.
+//
+// Parameters:
+//
+//	menuItem	the MenuItem to select
+func (s *CMenuShell) SelectItem(menuItem Widget) {
+	item, ok := menuItem.Self().(MenuItem)
+	if !ok {
+		s.LogError("not a MenuItem: %v (%T)", menuItem, menuItem)
+		return
+	}
+	if selected := s.GetSelectedItem(); selected != nil {
+		if selected.ObjectID() == item.ObjectID() {
+			return
+		}
+		s.Deselect()
+	}
+	if !menuItemIsSelectable(item) {
+		return
+	}
+	s.Lock()
+	s.selected = item
+	s.Unlock()
+	item.Select()
+	s.Invalidate()
+}
+
+// Deselect the currently selected item from the MenuShell, if any. Any
+// submenu of the item is popped down.
+func (s *CMenuShell) Deselect() {
+	s.stopTimer()
+	s.Lock()
+	selected := s.selected
+	s.selected = nil
+	s.Unlock()
+	if selected != nil {
+		selected.Deselect()
+		s.Invalidate()
+	}
+}
+
+// SelectFirst selects the first visible or selectable child of the MenuShell;
+// don't select tearoff items unless the only item is a tearoff item.
+//
+// Parameters:
+//
+//	searchSensitive	if TRUE, search for the first selectable menu item,
+//	                otherwise select nothing if the first item isn't sensitive.
+func (s *CMenuShell) SelectFirst(searchSensitive bool) {
+	for _, item := range s.GetItems() {
+		if !item.IsVisible() {
+			continue
+		}
+		if menuItemIsSelectable(item) {
+			s.SelectItem(item)
+			return
+		}
+		if !searchSensitive {
+			return
+		}
+	}
+}
+
+// ActivateItem activates the given MenuItem. If the item has a submenu and
+// forceDeactivate is FALSE, the submenu is opened and nothing else happens.
+// Otherwise, the whole menu hierarchy is deactivated, the item emits its
+// activate signal and the root MenuShell emits selection-done.
+//
+// Parameters:
+//
+//	menuItem	the MenuItem to activate
+//	forceDeactivate	if TRUE, force the deactivation of the menu shell
+//	                after the menu item is activated
+func (s *CMenuShell) ActivateItem(menuItem Widget, forceDeactivate bool) {
+	item, ok := menuItem.Self().(MenuItem)
+	if !ok {
+		s.LogError("not a MenuItem: %v (%T)", menuItem, menuItem)
+		return
+	}
+	if !item.IsVisible() || !item.IsSensitive() {
+		return
+	}
+	if item.GetSubmenu() != nil && !forceDeactivate {
+		s.popupSubmenu(item, true)
+		return
+	}
+	root := s.getRootShell()
+	root.Deactivate()
+	item.Activate()
+	root.Emit(SignalSelectionDone, root)
+	menuRequestDraw()
+}
+
+// ActivateCurrent activates the currently selected item. If the item has a
+// submenu, the submenu is opened and its first item selected.
+//
+// Parameters:
+//
+//	forceHide	if TRUE, hide the menu after activating the menu item
+func (s *CMenuShell) ActivateCurrent(forceHide bool) {
+	if f := s.Emit(SignalActivateCurrent, s, forceHide); f == cenums.EVENT_PASS {
+		if selected := s.GetSelectedItem(); selected != nil {
+			if selected.GetSubmenu() != nil {
+				s.popupSubmenu(selected, true)
+			} else {
+				s.ActivateItem(selected, forceHide)
+			}
+		}
+	}
+}
+
+// Cancel the selection within the MenuShell, deactivating it and emitting the
+// selection-done signal.
+func (s *CMenuShell) Cancel() {
+	if f := s.Emit(SignalCancel, s); f == cenums.EVENT_PASS {
+		if shell, ok := s.Self().(MenuShell); ok {
+			shell.Deactivate()
+			shell.Emit(SignalSelectionDone, shell)
+		}
+		menuRequestDraw()
+	}
+}
+
+// CancelEvent cancels the selection within the MenuShell if it is active. This
+// is used by the Window when a mouse interaction is interrupted.
+func (s *CMenuShell) CancelEvent() {
+	if f := s.Emit(SignalCancelEvent, s); f == cenums.EVENT_PASS {
+		if s.IsActive() {
+			if shell, ok := s.Self().(MenuShell); ok {
+				shell.Cancel()
+			}
+		}
+	}
+}
+
+// Deactivate the MenuShell. Typically this results in the MenuShell being
+// erased from the screen and the grab on the Window being released.
+func (s *CMenuShell) Deactivate() {
+	if !s.IsActive() {
+		return
+	}
+	if f := s.Emit(SignalDeactivate, s); f == cenums.EVENT_PASS {
+		s.Deselect()
+		s.Lock()
+		s.active = false
+		window := s.grabWindow
+		s.grabWindow = nil
+		s.Unlock()
+		if window != nil {
+			_ = window.Disconnect(SignalCdkEvent, s.grabHandle)
+		}
+		s.Invalidate()
+	}
+}
+
+// MoveSelected moves the selection by distance selectable items, skipping
+// separators, hidden and insensitive items. The move wraps around the ends of
+// the list when the keynav-wrap-around Settings is TRUE.
+//
+// Parameters:
+//
+//	distance	+1 to select the next item, -1 to select the previous
+//
+// Returns:
+//
+//	TRUE if the selection was changed
+func (s *CMenuShell) MoveSelected(distance int) (moved bool) {
+	if distance == 0 {
+		return false
+	}
+	if f := s.Emit(SignalMoveSelected, s, distance); f == cenums.EVENT_STOP {
+		return false
+	}
+	items := s.GetItems()
+	if len(items) == 0 {
+		return false
+	}
+	step := 1
+	if distance < 0 {
+		step = -1
+		distance = -distance
+	}
+	current := -1
+	if step < 0 {
+		current = len(items)
+	}
+	if selected := s.GetSelectedItem(); selected != nil {
+		for idx, item := range items {
+			if item.ObjectID() == selected.ObjectID() {
+				current = idx
+				break
+			}
+		}
+	}
+	wrap := GetDefaultSettings().GetKeynavWrapAround()
+	target := current
+	for ; distance > 0; distance-- {
+		next := menuShellNextSelectable(items, target, step, wrap)
+		if next < 0 {
+			break
+		}
+		target = next
+	}
+	if target < 0 || target >= len(items) || target == current {
+		return false
+	}
+	s.SelectItem(items[target])
+	return true
+}
+
+// MoveCurrent moves the current selection in the given direction. Moving to
+// the next or previous item changes the selected item, moving to the child
+// opens the submenu of the selected item and moving to the parent closes the
+// Menu (or moves the selection of a parent MenuBar).
+//
+// Parameters:
+//
+//	direction	the direction to move
+func (s *CMenuShell) MoveCurrent(direction enums.MenuDirectionType) {
+	if f := s.Emit(SignalMoveCurrent, s, direction); f == cenums.EVENT_STOP {
+		return
+	}
+	switch direction {
+	case enums.MENU_DIR_PREV:
+		s.MoveSelected(-1)
+	case enums.MENU_DIR_NEXT:
+		s.MoveSelected(1)
+	case enums.MENU_DIR_CHILD:
+		if selected := s.GetSelectedItem(); selected != nil && selected.GetSubmenu() != nil {
+			s.popupSubmenu(selected, true)
+		} else if bar, ok := s.getRootShell().Self().(*CMenuBar); ok {
+			bar.MoveCurrent(enums.MENU_DIR_NEXT)
+		}
+	case enums.MENU_DIR_PARENT:
+		if parent := s.GetParentShell(); parent != nil {
+			if bar, ok := parent.Self().(*CMenuBar); ok {
+				bar.MoveCurrent(enums.MENU_DIR_PREV)
+			} else if menu, ok := s.Self().(Menu); ok {
+				menu.Popdown()
+			}
+		}
+	}
+	menuRequestDraw()
+}
+
+// popupSubmenu selects the given item and pops up its submenu, optionally
+// selecting the first item of the submenu. The MenuShell is activated first if
+// it is not already active.
+func (s *CMenuShell) popupSubmenu(item MenuItem, selectFirst bool) {
+	s.stopTimer()
+	submenu := item.GetSubmenu()
+	if submenu == nil || !item.IsVisible() || !item.IsSensitive() {
+		return
+	}
+	s.activateShell(s.GetWindow())
+	s.SelectItem(item)
+	if !submenu.IsPoppedUp() {
+		if shell, ok := s.Self().(MenuShell); ok {
+			submenu.Popup(shell, item)
+		}
+	}
+	if selectFirst {
+		submenu.SelectFirst(true)
+	}
+	menuRequestDraw()
+}
+
+// hoverItem is used by the mouse handlers of MenuShell derived types to track
+// the pointer moving over the given item. When the currently selected item has
+// an open submenu, the change of selection is delayed by the menu popdown
+// delay to give the user a chance to reach the open submenu.
+func (s *CMenuShell) hoverItem(item MenuItem, popupDelay time.Duration) {
+	selected := s.GetSelectedItem()
+	if selected != nil && selected.ObjectID() == item.ObjectID() {
+		return
+	}
+	if !menuItemIsSelectable(item) {
+		return
+	}
+	if selected != nil && menuItemSubmenuIsOpen(selected) && popupDelay > 0 {
+		s.startTimer(GetDefaultSettings().GetMenuPopdownDelay(), func() {
+			s.hoverSelect(item, popupDelay)
+		})
+		return
+	}
+	s.hoverSelect(item, popupDelay)
+}
+
+func (s *CMenuShell) hoverSelect(item MenuItem, popupDelay time.Duration) {
+	s.SelectItem(item)
+	if item.GetSubmenu() != nil {
+		s.startTimer(popupDelay, func() {
+			if selected := s.GetSelectedItem(); selected != nil && selected.ObjectID() == item.ObjectID() {
+				s.popupSubmenu(item, false)
+			}
+		})
+	}
+	menuRequestDraw()
+}
+
+// getMnemonicItem returns the first selectable item with a Label mnemonic
+// matching the given rune, case-insensitively.
+func (s *CMenuShell) getMnemonicItem(r rune) (item MenuItem) {
+	r = unicode.ToLower(r)
+	for _, child := range s.GetItems() {
+		if !menuItemIsSelectable(child) || !child.GetUseUnderline() {
+			continue
+		}
+		if label, ok := child.GetChild().(Label); ok {
+			_, _ = label.GetPlainTextInfo()
+			if mnemonic := label.GetMnemonicKeyVal(); mnemonic != 0 && unicode.ToLower(mnemonic) == r {
+				return child
+			}
+		}
+	}
+	return nil
+}
+
+// activateShell marks the MenuShell active and, if this is the root of the
+// menu hierarchy, grabs all events of the given Window.
+func (s *CMenuShell) activateShell(window Window) {
+	s.Lock()
+	if s.active {
+		s.Unlock()
+		return
+	}
+	s.active = true
+	isRoot := s.parentShell == nil
+	if isRoot {
+		s.grabWindow = window
+	}
+	s.Unlock()
+	if isRoot && window != nil {
+		window.Connect(SignalCdkEvent, s.grabHandle, s.grabEvent)
+	}
+}
+
+// getRootShell returns the MenuShell at the top of the menu hierarchy that
+// this MenuShell is a part of.
+func (s *CMenuShell) getRootShell() (root MenuShell) {
+	root, _ = s.Self().(MenuShell)
+	for root != nil {
+		if parent := root.GetParentShell(); parent != nil {
+			root = parent
+			continue
+		}
+		break
+	}
+	return
+}
+
+// getOpenShells returns this MenuShell followed by each of the submenus
+// currently popped up beneath it, in order.
+func (s *CMenuShell) getOpenShells() (shells []MenuShell) {
+	shell, _ := s.Self().(MenuShell)
+	for shell != nil {
+		shells = append(shells, shell)
+		var next MenuShell
+		if selected := shell.GetSelectedItem(); selected != nil {
+			if submenu := selected.GetSubmenu(); submenu != nil && submenu.IsPoppedUp() {
+				next = submenu
+			}
+		}
+		shell = next
+	}
+	return
+}
+
+func (s *CMenuShell) startTimer(delay time.Duration, fn func()) {
+	s.stopTimer()
+	if delay > 0 {
+		id := cdk.AddTimeout(delay, func() cenums.EventFlag {
+			s.Lock()
+			s.timer = uuid.Nil
+			s.Unlock()
+			fn()
+			return cenums.EVENT_STOP
+		})
+		if id != uuid.Nil {
+			s.Lock()
+			s.timer = id
+			s.Unlock()
+			return
+		}
+	}
+	fn()
+}
+
+func (s *CMenuShell) stopTimer() {
+	s.Lock()
+	id := s.timer
+	s.timer = uuid.Nil
+	s.Unlock()
+	if id != uuid.Nil {
+		cdk.StopTimeout(id)
+	}
+}
+
+// grabEvent is connected to the cdk-event signal of the Window the root
+// MenuShell is active within, routing all events to the open menus.
+func (s *CMenuShell) grabEvent(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if _, event, ok := ArgvSignalEvent(argv...); ok {
+		shells := s.getOpenShells()
+		switch e := event.(type) {
+		case *cdk.EventKey:
+			shells[len(shells)-1].ProcessEvent(e)
+			menuRequestDraw()
+			return cenums.EVENT_STOP
+		case *cdk.EventMouse:
+			point := ptypes.NewPoint2I(e.Position())
+			for idx := len(shells) - 1; idx >= 0; idx-- {
+				if menuRegionHasPoint(shells[idx], *point) {
+					// the pointer made it here, abandon any pending changes
+					// to the menus leading to this one
+					for _, parent := range shells[:idx] {
+						if timed, ok := parent.Self().(menuShellTimer); ok {
+							timed.stopTimer()
+						}
+					}
+					shells[idx].ProcessEvent(e)
+					menuRequestDraw()
+					return cenums.EVENT_STOP
+				}
+			}
+			switch e.State() {
+			case cdk.BUTTON_PRESS, cdk.DRAG_START:
+				if shell, ok := s.Self().(MenuShell); ok {
+					shell.Cancel()
+				}
+			}
+			return cenums.EVENT_STOP
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+// menuShellTimer is implemented by all CMenuShell derived types.
+type menuShellTimer interface {
+	stopTimer()
+}
+
+// menuShellNextSelectable returns the index of the next selectable item from
+// the given index in the direction of step, or -1 if there is none.
+func menuShellNextSelectable(items []MenuItem, from, step int, wrap bool) int {
+	count := len(items)
+	for i := 1; i <= count; i++ {
+		idx := from + step*i
+		if idx < 0 || idx >= count {
+			if !wrap {
+				return -1
+			}
+			idx = ((idx % count) + count) % count
+		}
+		if menuItemIsSelectable(items[idx]) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// menuItemIsSelectable returns true if the given item is visible, sensitive
+// and not a separator.
+func menuItemIsSelectable(item MenuItem) bool {
+	if _, ok := item.Self().(*CSeparatorMenuItem); ok {
+		return false
+	}
+	return item.IsVisible() && item.IsSensitive()
+}
+
+// menuItemSubmenuIsOpen returns true if the given item has a submenu that is
+// currently popped up.
+func menuItemSubmenuIsOpen(item MenuItem) bool {
+	if submenu := item.GetSubmenu(); submenu != nil {
+		return submenu.IsPoppedUp()
+	}
+	return false
+}
+
+// menuRegionHasPoint returns true if the given screen point is within the
+// region of the given Widget.
+func menuRegionHasPoint(widget Widget, point ptypes.Point2I) bool {
+	origin := widget.GetOrigin()
+	alloc := widget.GetAllocation()
+	return point.X >= origin.X && point.X < origin.X+alloc.W &&
+		point.Y >= origin.Y && point.Y < origin.Y+alloc.H
+}
+
+// menuIsMenuBarAccel returns true if the given key event matches the
+// menu-bar-accel of the default Settings.
+func menuIsMenuBarAccel(e *cdk.EventKey) bool {
+	if accel := GetDefaultSettings().GetMenuBarAccel(); accel != "" {
+		if key, mods, err := cdk.ParseKeyMods(accel); err == nil {
+			return e.Key() == key && e.Modifiers() == mods
+		}
+	}
+	return false
+}
+
+// menuRequestDraw requests the default Display to redraw and show the screen.
+func menuRequestDraw() {
+	if display := cdk.GetDefaultDisplay(); display != nil {
+		display.RequestDraw()
+		display.RequestShow()
+	}
+}
+
+// A boolean that determines whether the menu grabs the keyboard focus.
+// Flags: Read / Write
+// Default value: TRUE
+const PropertyTakeFocus cdk.Property = "take-focus"
+
+// An action signal that activates the current menu item within the menu
+// shell.
+// Listener function arguments:
+//
+//	forceHide bool	if TRUE, hide the menu after activating the menu item
+const SignalActivateCurrent cdk.Signal = "activate-current"
+
+// An action signal which cancels the selection within the menu shell. Causes
+// the selection-done signal to be emitted.
+const SignalCancel cdk.Signal = "cancel"
+
+// This signal is emitted when a menu shell is deactivated.
+const SignalDeactivate cdk.Signal = "deactivate"
+
+// An keybinding signal which moves the current menu item in the direction
+// specified by direction .
+// Listener function arguments:
+//
+//	direction enums.MenuDirectionType	the direction to move
+const SignalMoveCurrent cdk.Signal = "move-current"
+
+// The ::move-selected signal is emitted to move the selection to another item.
+// Listener function arguments:
+//
+//	distance int	+1 to move to the next item, -1 to move to the previous
+const SignalMoveSelected cdk.Signal = "move-selected"
+
+// This signal is emitted when a selection has been completed within a menu
+// shell.
+const SignalSelectionDone cdk.Signal = "selection-done"
+
+const MenuShellGrabHandle = "menu-shell-grab-handler"
@@ -0,0 +1,277 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"testing"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/ptypes"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMenu(t *testing.T) {
+	Convey("Testing Menus", t, func() {
+		Convey("Basics", func() {
+			m := &CMenu{}
+			So(m.Init(), ShouldEqual, false)
+			So(m.Init(), ShouldEqual, true)
+			bar := NewMenuBar()
+			file := NewMenuItemWithMnemonic("_File")
+			So(file.GetLabel(), ShouldEqual, "_File")
+			So(file.GetUseUnderline(), ShouldEqual, true)
+			file.Show()
+			sep := NewSeparatorMenuItem()
+			bar.Append(file)
+			bar.Prepend(sep)
+			So(bar.GetItems(), ShouldHaveLength, 2)
+			So(bar.GetItems()[1], ShouldEqual, file)
+			bar.Remove(sep)
+			So(bar.GetItems(), ShouldHaveLength, 1)
+			bar.SelectItem(sep)
+			So(bar.GetSelectedItem(), ShouldBeNil)
+			bar.Append(NewLabel("not an item"))
+			So(bar.GetItems(), ShouldHaveLength, 1)
+			bar.SelectFirst(true)
+			So(bar.GetSelectedItem(), ShouldEqual, file)
+			bar.Deselect()
+			So(bar.GetSelectedItem(), ShouldBeNil)
+
+			check := NewCheckMenuItemWithLabel("Check")
+			So(check.GetActive(), ShouldEqual, false)
+			toggled := 0
+			check.Connect(SignalToggled, "test-toggled", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				toggled += 1
+				return cenums.EVENT_PASS
+			})
+			check.Show()
+			So(check.Activate(), ShouldEqual, true)
+			So(check.GetActive(), ShouldEqual, true)
+			So(toggled, ShouldEqual, 1)
+			So(check.ToggleSizeRequest(), ShouldEqual, 4)
+
+			one := NewRadioMenuItemWithLabel(nil, "One")
+			two := NewRadioMenuItemWithLabelFromWidget(one, "Two")
+			three := NewRadioMenuItemWithLabel(two.GetGroup(), "Three")
+			So(one.GetGroup(), ShouldHaveLength, 3)
+			So(one.GetActive(), ShouldEqual, true)
+			So(two.GetActive(), ShouldEqual, false)
+			So(three.GetDrawAsRadio(), ShouldEqual, true)
+			three.SetActive(true)
+			So(one.GetActive(), ShouldEqual, false)
+			So(three.GetActive(), ShouldEqual, true)
+			three.SetActive(false)
+			So(three.GetActive(), ShouldEqual, true)
+			two.SetGroup(nil)
+			So(one.GetGroup(), ShouldHaveLength, 2)
+			So(two.GetGroup(), ShouldHaveLength, 1)
+
+			So(menuItemAccelLabel(cdk.Key('q'), cdk.ModCtrl), ShouldEqual, "Ctrl+Q")
+		})
+
+		Convey("Navigation", func() {
+			window := NewWindow()
+			window.SetDecorated(false)
+			window.Show()
+			window.SetAllocation(ptypes.MakeRectangle(40, 20))
+			bar := NewMenuBar()
+			bar.Show()
+			window.GetVBox().PackStart(bar, false, false, 0)
+
+			fileMenu := NewMenu()
+			file := NewMenuItemWithMnemonic("_File")
+			file.SetSubmenu(fileMenu)
+			openItem := NewMenuItemWithMnemonic("_Open")
+			sep := NewSeparatorMenuItem()
+			recent := NewMenuItemWithMnemonic("_Recent")
+			recentMenu := NewMenu()
+			recentOne := NewMenuItemWithLabel("one.txt")
+			recentMenu.Append(recentOne)
+			recent.SetSubmenu(recentMenu)
+			quit := NewMenuItemWithMnemonic("_Quit")
+			for _, item := range []MenuItem{openItem, sep, recent, quit} {
+				item.Show()
+				fileMenu.Append(item)
+			}
+			recentOne.Show()
+			edit := NewMenuItemWithMnemonic("_Edit")
+			editMenu := NewMenu()
+			undo := NewCheckMenuItemWithMnemonic("_Wrap")
+			undo.Show()
+			editMenu.Append(undo)
+			edit.SetSubmenu(editMenu)
+			help := NewMenuItemWithMnemonic("_Help")
+			help.SetRightJustified(true)
+			for _, item := range []MenuItem{file, edit, help} {
+				item.Show()
+				bar.Append(item)
+			}
+			window.Resize()
+
+			So(bar.GetOrigin(), ShouldResemble, ptypes.MakePoint2I(0, 0))
+			So(file.GetOrigin(), ShouldResemble, ptypes.MakePoint2I(0, 0))
+			So(file.GetAllocation(), ShouldResemble, ptypes.MakeRectangle(6, 1))
+			So(edit.GetOrigin(), ShouldResemble, ptypes.MakePoint2I(6, 0))
+			So(help.GetOrigin(), ShouldResemble, ptypes.MakePoint2I(34, 0))
+
+			activated := ""
+			for _, item := range []MenuItem{openItem, quit, recentOne, help} {
+				item.Connect(SignalActivate, "test-activate", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+					if mi, ok := argv[0].(MenuItem); ok {
+						activated = mi.GetLabel()
+					}
+					return cenums.EVENT_PASS
+				})
+			}
+			selectionDone := 0
+			bar.Connect(SignalSelectionDone, "test-selection-done", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				selectionDone += 1
+				return cenums.EVENT_PASS
+			})
+
+			Convey("F10 and Keyboard", func() {
+				window.ProcessEvent(cdk.NewEventKey(cdk.KeyF10, 0, cdk.ModNone))
+				So(bar.IsActive(), ShouldEqual, true)
+				So(bar.GetSelectedItem(), ShouldEqual, file)
+				So(fileMenu.IsPoppedUp(), ShouldEqual, true)
+				So(fileMenu.GetSelectedItem(), ShouldEqual, openItem)
+				So(fileMenu.GetMenuWindow().GetOrigin(), ShouldResemble, ptypes.MakePoint2I(0, 1))
+				So(openItem.GetOrigin(), ShouldResemble, ptypes.MakePoint2I(1, 2))
+				// separators are skipped
+				window.ProcessEvent(cdk.NewEventKey(cdk.KeyDown, 0, cdk.ModNone))
+				So(fileMenu.GetSelectedItem(), ShouldEqual, recent)
+				window.ProcessEvent(cdk.NewEventKey(cdk.KeyRight, 0, cdk.ModNone))
+				So(recentMenu.IsPoppedUp(), ShouldEqual, true)
+				So(recentMenu.GetSelectedItem(), ShouldEqual, recentOne)
+				So(recentMenu.GetMenuWindow().GetOrigin().X, ShouldEqual, fileMenu.GetMenuWindow().GetOrigin().X+fileMenu.GetAllocation().W-1)
+				window.ProcessEvent(cdk.NewEventKey(cdk.KeyLeft, 0, cdk.ModNone))
+				So(recentMenu.IsPoppedUp(), ShouldEqual, false)
+				So(fileMenu.IsPoppedUp(), ShouldEqual, true)
+				// moving along the menu bar reopens the next menu
+				window.ProcessEvent(cdk.NewEventKey(cdk.KeyRight, 0, cdk.ModNone))
+				window.ProcessEvent(cdk.NewEventKey(cdk.KeyRight, 0, cdk.ModNone))
+				So(bar.GetSelectedItem(), ShouldEqual, edit)
+				So(fileMenu.IsPoppedUp(), ShouldEqual, false)
+				So(editMenu.IsPoppedUp(), ShouldEqual, true)
+				window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, rune(cdk.KeyEnter), cdk.ModNone))
+				So(undo.GetActive(), ShouldEqual, true)
+				So(bar.IsActive(), ShouldEqual, false)
+				So(editMenu.IsPoppedUp(), ShouldEqual, false)
+				So(selectionDone, ShouldEqual, 1)
+
+				window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 'f', cdk.ModAlt))
+				So(fileMenu.IsPoppedUp(), ShouldEqual, true)
+				window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 'q', cdk.ModNone))
+				So(activated, ShouldEqual, "_Quit")
+				So(fileMenu.IsPoppedUp(), ShouldEqual, false)
+
+				window.ProcessEvent(cdk.NewEventKey(cdk.KeyF10, 0, cdk.ModNone))
+				window.ProcessEvent(cdk.NewEventKey(cdk.KeyEscape, 0, cdk.ModNone))
+				So(fileMenu.IsPoppedUp(), ShouldEqual, false)
+				So(bar.IsActive(), ShouldEqual, false)
+				window.ProcessEvent(cdk.NewEventKey(cdk.KeyF10, 0, cdk.ModNone))
+				window.ProcessEvent(cdk.NewEventKey(cdk.KeyF10, 0, cdk.ModNone))
+				So(bar.IsActive(), ShouldEqual, false)
+			})
+
+			Convey("Mouse", func() {
+				window.ProcessEvent(cdk.NewEventMouse(1, 0, cdk.Button1, cdk.ModNone))
+				window.ProcessEvent(cdk.NewEventMouse(1, 0, cdk.ButtonNone, cdk.ModNone))
+				So(fileMenu.IsPoppedUp(), ShouldEqual, true)
+				So(fileMenu.GetSelectedItem(), ShouldBeNil)
+				// hovering over the menu bar switches menus
+				window.ProcessEvent(cdk.NewEventMouse(7, 0, cdk.ButtonNone, cdk.ModNone))
+				So(editMenu.IsPoppedUp(), ShouldEqual, true)
+				So(fileMenu.IsPoppedUp(), ShouldEqual, false)
+				window.ProcessEvent(cdk.NewEventMouse(1, 0, cdk.ButtonNone, cdk.ModNone))
+				So(fileMenu.IsPoppedUp(), ShouldEqual, true)
+				// quit is on the fourth row of the menu
+				window.ProcessEvent(cdk.NewEventMouse(2, 5, cdk.ButtonNone, cdk.ModNone))
+				So(fileMenu.GetSelectedItem(), ShouldEqual, quit)
+				window.ProcessEvent(cdk.NewEventMouse(2, 5, cdk.Button1, cdk.ModNone))
+				window.ProcessEvent(cdk.NewEventMouse(2, 5, cdk.ButtonNone, cdk.ModNone))
+				So(activated, ShouldEqual, "_Quit")
+				So(bar.IsActive(), ShouldEqual, false)
+
+				// clicking outside of the menus cancels them
+				window.ProcessEvent(cdk.NewEventMouse(1, 0, cdk.Button1, cdk.ModNone))
+				window.ProcessEvent(cdk.NewEventMouse(1, 0, cdk.ButtonNone, cdk.ModNone))
+				So(fileMenu.IsPoppedUp(), ShouldEqual, true)
+				window.ProcessEvent(cdk.NewEventMouse(30, 15, cdk.Button1, cdk.ModNone))
+				window.ProcessEvent(cdk.NewEventMouse(30, 15, cdk.ButtonNone, cdk.ModNone))
+				So(fileMenu.IsPoppedUp(), ShouldEqual, false)
+				So(bar.IsActive(), ShouldEqual, false)
+
+				window.ProcessEvent(cdk.NewEventMouse(35, 0, cdk.Button1, cdk.ModNone))
+				window.ProcessEvent(cdk.NewEventMouse(35, 0, cdk.ButtonNone, cdk.ModNone))
+				So(activated, ShouldEqual, "_Help")
+				So(bar.IsActive(), ShouldEqual, false)
+			})
+		})
+
+		Convey("Builder and Actions", func() {
+			builder := NewBuilder()
+			_, err := builder.LoadFromString(`<interface>
+  <object class="GtkMenuBar" id="test-menu-bar">
+    <child>
+      <object class="GtkMenuItem" id="test-menu-file">
+        <property name="label">_File</property>
+        <property name="use_underline">True</property>
+        <child type="submenu">
+          <object class="GtkMenu" id="test-menu-file-menu">
+            <child>
+              <object class="GtkCheckMenuItem" id="test-menu-check">
+                <property name="label">Check</property>
+                <property name="active">True</property>
+              </object>
+            </child>
+            <child>
+              <object class="GtkSeparatorMenuItem" id="test-menu-separator"/>
+            </child>
+          </object>
+        </child>
+      </object>
+    </child>
+  </object>
+</interface>`)
+			So(err, ShouldBeNil)
+			bar, ok := builder.GetWidget("test-menu-bar").(MenuBar)
+			So(ok, ShouldEqual, true)
+			So(bar.GetItems(), ShouldHaveLength, 1)
+			file := bar.GetItems()[0]
+			So(file.GetLabel(), ShouldEqual, "_File")
+			So(file.GetSubmenu(), ShouldEqual, builder.GetWidget("test-menu-file-menu"))
+			So(file.GetSubmenu().GetAttachWidget(), ShouldEqual, file)
+			So(file.GetSubmenu().GetItems(), ShouldHaveLength, 2)
+			check, ok := builder.GetWidget("test-menu-check").(CheckMenuItem)
+			So(ok, ShouldEqual, true)
+			So(check.GetActive(), ShouldEqual, true)
+
+			action := NewAction("quit", "_Quit", "Quit the application", "")
+			So(action.GetName(), ShouldEqual, "quit")
+			So(action.GetLabel(), ShouldEqual, "_Quit")
+			item, ok := action.CreateMenuItem().(MenuItem)
+			So(ok, ShouldEqual, true)
+			So(item.GetLabel(), ShouldEqual, "_Quit")
+			So(item.GetUseUnderline(), ShouldEqual, true)
+			So(action.GetProxies(), ShouldHaveLength, 1)
+			_, ok = NewToggleAction("wrap", "_Wrap", "", "").CreateMenuItem().(CheckMenuItem)
+			So(ok, ShouldEqual, true)
+			_, ok = action.CreateMenu().(Menu)
+			So(ok, ShouldEqual, true)
+		})
+	})
+}
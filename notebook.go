@@ -0,0 +1,1950 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/go-curses/cdk/lib/ptypes"
+	cstrings "github.com/go-curses/cdk/lib/strings"
+	"github.com/go-curses/cdk/memphis"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeNotebook cdk.CTypeTag = "ctk-notebook"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeNotebook, func() interface{} { return MakeNotebook() })
+}
+
+// Notebook Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Notebook
+//
+// The Notebook Widget is a Container whose children are pages that can be
+// switched between using tab labels along one edge. There are many
+// configuration options for Notebook. Among other things, you can choose on
+// which edge the tabs appear (see SetTabPos), whether, if there are too many
+// tabs to fit the notebook should be made bigger or scrolling arrows added
+// (see SetScrollable), and whether there will be a popup menu allowing the
+// users to switch pages (see PopupEnable, PopupDisable).
+//
+// The current page can be changed with the mouse by clicking on the tab
+// labels, with Ctrl+PgUp and Ctrl+PgDn whenever the focus is within the
+// Notebook and with the mnemonic of any tab label that has one. When the
+// Notebook itself has the focus, the arrow keys, Home and End switch pages
+// and Alt with the same keys moves a reorderable page.
+type Notebook interface {
+	Container
+	Buildable
+
+	AppendPage(child Widget, tabLabel Widget) (value int)
+	AppendPageMenu(child Widget, tabLabel Widget, menuLabel Widget) (value int)
+	PrependPage(child Widget, tabLabel Widget) (value int)
+	PrependPageMenu(child Widget, tabLabel Widget, menuLabel Widget) (value int)
+	InsertPage(child Widget, tabLabel Widget, position int) (value int)
+	InsertPageMenu(child Widget, tabLabel Widget, menuLabel Widget, position int) (value int)
+	RemovePage(pageNum int)
+	PageNum(child Widget) (value int)
+	NextPage()
+	PrevPage()
+	ReorderChild(child Widget, position int)
+	GetTabPos() (value enums.PositionType)
+	SetTabPos(pos enums.PositionType)
+	GetShowTabs() (value bool)
+	SetShowTabs(showTabs bool)
+	GetShowBorder() (value bool)
+	SetShowBorder(showBorder bool)
+	GetScrollable() (value bool)
+	SetScrollable(scrollable bool)
+	GetHomogeneousTabs() (value bool)
+	SetHomogeneousTabs(homogeneous bool)
+	PopupEnable()
+	PopupDisable()
+	GetCurrentPage() (value int)
+	SetCurrentPage(pageNum int)
+	GetNthPage(pageNum int) (value Widget)
+	GetNPages() (value int)
+	GetTabLabel(child Widget) (value Widget)
+	SetTabLabel(child Widget, tabLabel Widget)
+	GetTabLabelText(child Widget) (value string)
+	SetTabLabelText(child Widget, tabText string)
+	GetMenuLabel(child Widget) (value Widget)
+	SetMenuLabel(child Widget, menuLabel Widget)
+	GetMenuLabelText(child Widget) (value string)
+	SetMenuLabelText(child Widget, menuText string)
+	GetTabReorderable(child Widget) (value bool)
+	SetTabReorderable(child Widget, reorderable bool)
+}
+
+var _ Notebook = (*CNotebook)(nil)
+
+// The CNotebook structure implements the Notebook interface and is exported
+// to facilitate type embedding with custom implementations. No member variables
+// are exported as the interface methods are the only intended means of
+// interacting with Notebook objects.
+type CNotebook struct {
+	CContainer
+
+	pages     []*cNotebookPage
+	firstTab  int
+	arrowBack ptypes.Region
+	arrowNext ptypes.Region
+	hasArrows bool
+	dragging  Widget
+	window    Window
+	handle    string
+}
+
+// cNotebookPage tracks the tab and menu labels of a Notebook page.
+type cNotebookPage struct {
+	child     Widget
+	tabLabel  Widget
+	menuLabel Widget
+	tab       ptypes.Region
+}
+
+// MakeNotebook is used by the Buildable system to construct a new Notebook.
+func MakeNotebook() Notebook {
+	return NewNotebook()
+}
+
+// NewNotebook is the constructor for new Notebook instances.
+func NewNotebook() Notebook {
+	n := new(CNotebook)
+	n.Init()
+	return n
+}
+
+// Init initializes a Notebook object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the Notebook instance. Init is used in the
+// NewNotebook constructor and only necessary when implementing a derivative
+// Notebook type.
+func (n *CNotebook) Init() (already bool) {
+	if n.InitTypeItem(TypeNotebook, n) {
+		return true
+	}
+	n.CContainer.Init()
+	n.flags = enums.NULL_WIDGET_FLAG
+	n.SetFlags(enums.SENSITIVE | enums.PARENT_SENSITIVE | enums.APP_PAINTABLE)
+	n.pages = make([]*cNotebookPage, 0)
+	n.firstTab = 0
+	n.hasArrows = false
+	n.dragging = nil
+	n.window = nil
+	n.handle = fmt.Sprintf("%v-%v", NotebookWindowEventHandle, n.ObjectID())
+
+	_ = n.InstallProperty(PropertyEnablePopup, cdk.BoolProperty, true, false)
+	_ = n.InstallProperty(PropertyHomogeneous, cdk.BoolProperty, true, false)
+	_ = n.InstallProperty(PropertyPage, cdk.IntProperty, true, -1)
+	_ = n.InstallProperty(PropertyScrollable, cdk.BoolProperty, true, false)
+	_ = n.InstallProperty(PropertyShowBorder, cdk.BoolProperty, true, true)
+	_ = n.InstallProperty(PropertyShowTabs, cdk.BoolProperty, true, true)
+	_ = n.InstallProperty(PropertyTabPos, cdk.StructProperty, true, enums.POS_TOP)
+
+	_ = n.InstallChildProperty(PropertyNotebookChildPosition, cdk.IntProperty, true, 0)
+	_ = n.InstallChildProperty(PropertyNotebookChildReorderable, cdk.BoolProperty, true, false)
+
+	n.Connect(SignalCdkEvent, NotebookEventHandle, n.event)
+	n.Connect(SignalLostFocus, NotebookLostFocusHandle, n.lostFocus)
+	n.Connect(SignalGainedFocus, NotebookGainedFocusHandle, n.gainedFocus)
+	n.Connect(SignalResize, NotebookResizeHandle, n.resize)
+	n.Connect(SignalDraw, NotebookDrawHandle, n.draw)
+	return false
+}
+
+// Build provides customizations to the Buildable system for Notebook Widgets.
+// Each page child may be followed by a child with the "tab" type, which is
+// used as the tab label of the preceding page.
+func (n *CNotebook) Build(builder Builder, element *CBuilderElement) error {
+	n.Freeze()
+	defer n.Thaw()
+	if name, ok := element.Attributes["id"]; ok {
+		n.SetName(name)
+	}
+	for k, v := range element.Properties {
+		switch cdk.Property(k) {
+		case PropertyTabPos:
+			n.SetTabPos(notebookPositionTypeFromString(v))
+		case PropertyPage:
+		default:
+			element.ApplyProperty(k, v)
+		}
+	}
+	var lastPage Widget
+	for _, child := range element.Children {
+		if newChild := builder.Build(child); newChild != nil {
+			child.Instance = newChild
+			newChildWidget, ok := newChild.(Widget)
+			if !ok {
+				n.LogError("new child object is not a Widget type: %v (%T)", newChild, newChild)
+				continue
+			}
+			if child.Packing["type"] == "tab" {
+				if lastPage == nil {
+					n.LogError("tab label found without a preceding page: %v", newChildWidget.ObjectName())
+					continue
+				}
+				newChildWidget.Show()
+				n.SetTabLabel(lastPage, newChildWidget)
+				continue
+			}
+			newChildWidget.Show()
+			n.AppendPage(newChildWidget, nil)
+			lastPage = newChildWidget
+			for k, v := range child.Packing {
+				switch strings.ReplaceAll(k, "_", "-") {
+				case "tab-label":
+					n.SetTabLabelText(newChildWidget, v)
+				case "menu-label":
+					n.SetMenuLabelText(newChildWidget, v)
+				case "reorderable":
+					n.SetTabReorderable(newChildWidget, cstrings.IsTrue(v))
+				}
+			}
+		}
+	}
+	if v, ok := element.Properties[PropertyPage.String()]; ok {
+		if pageNum, err := strconv.Atoi(v); err != nil {
+			n.LogErr(err)
+		} else {
+			n.SetCurrentPage(pageNum)
+		}
+	}
+	element.ApplySignals()
+	return nil
+}
+
+// SetWindow updates the Window of the Notebook, its pages and tab labels. The
+// Notebook listens for key events on the Window in order to switch pages with
+// Ctrl+PgUp, Ctrl+PgDn and the tab label mnemonics.
+func (n *CNotebook) SetWindow(w Window) {
+	n.Lock()
+	previous := n.window
+	n.window = w
+	n.Unlock()
+	if previous != nil {
+		_ = previous.Disconnect(SignalEventKey, n.handle)
+	}
+	if w != nil {
+		w.Connect(SignalEventKey, n.handle, n.windowEventKey)
+	}
+	for _, page := range n.getPages() {
+		if page.tabLabel != nil {
+			WidgetRecurseSetWindow(page.tabLabel, w)
+		}
+	}
+	n.CContainer.SetWindow(w)
+}
+
+// Add is a convenience method for AppendPage with a default tab label.
+//
+// Locking: write
+func (n *CNotebook) Add(w Widget) {
+	n.InsertPageMenu(w, nil, nil, -1)
+}
+
+// Remove will remove the given Widget from the Notebook, along with the tab and
+// menu labels of its page.
+//
+// Locking: write
+func (n *CNotebook) Remove(w Widget) {
+	if pageNum := n.PageNum(w); pageNum > -1 {
+		n.RemovePage(pageNum)
+		return
+	}
+	n.CContainer.Remove(w)
+}
+
+// AppendPage appends a page to the Notebook.
+//
+// Parameters:
+// 	child	the Widget to use as the contents of the page.
+// 	tabLabel	the Widget to be used as the label for the page, or nil to use
+// 	            the default label, 'page N'.
+//
+// Returns:
+// 	the index (starting from 0) of the appended page in the notebook, or -1
+// 	if function fails
+func (n *CNotebook) AppendPage(child Widget, tabLabel Widget) (value int) {
+	return n.InsertPageMenu(child, tabLabel, nil, -1)
+}
+
+// AppendPageMenu appends a page to the Notebook, specifying the Widget to use
+// as the label in the popup menu.
+//
+// Parameters:
+// 	child	the Widget to use as the contents of the page.
+// 	tabLabel	the Widget to be used as the label for the page, or nil to use
+// 	            the default label, 'page N'.
+// 	menuLabel	the widget to use as a label for the page-switch menu, if that
+// 	            is enabled. If nil, and tabLabel is a Label or nil, then the
+// 	            menu label will be a newly created label with the same text as
+// 	            tabLabel; If tabLabel is not a Label, menuLabel must be
+// 	            specified if the page-switch menu is to be used.
+//
+// Returns:
+// 	the index (starting from 0) of the appended page in the notebook, or -1
+// 	if function fails
+func (n *CNotebook) AppendPageMenu(child Widget, tabLabel Widget, menuLabel Widget) (value int) {
+	return n.InsertPageMenu(child, tabLabel, menuLabel, -1)
+}
+
+// PrependPage prepends a page to the Notebook.
+// See: AppendPage()
+func (n *CNotebook) PrependPage(child Widget, tabLabel Widget) (value int) {
+	return n.InsertPageMenu(child, tabLabel, nil, 0)
+}
+
+// PrependPageMenu prepends a page to the Notebook, specifying the Widget to
+// use as the label in the popup menu.
+// See: AppendPageMenu()
+func (n *CNotebook) PrependPageMenu(child Widget, tabLabel Widget, menuLabel Widget) (value int) {
+	return n.InsertPageMenu(child, tabLabel, menuLabel, 0)
+}
+
+// InsertPage inserts a page into the Notebook at the given position.
+//
+// Parameters:
+// 	child	the Widget to use as the contents of the page.
+// 	tabLabel	the Widget to be used as the label for the page, or nil to use
+// 	            the default label, 'page N'.
+// 	position	the index (starting at 0) at which to insert the page, or -1 to
+// 	            append the page after all other pages.
+//
+// Returns:
+// 	the index (starting from 0) of the inserted page in the notebook, or -1
+// 	if function fails
+func (n *CNotebook) InsertPage(child Widget, tabLabel Widget, position int) (value int) {
+	return n.InsertPageMenu(child, tabLabel, nil, position)
+}
+
+// InsertPageMenu inserts a page into the Notebook at the given position,
+// specifying the Widget to use as the label in the popup menu. The first page
+// added to a Notebook becomes the current page. This method emits a page-added
+// signal once the page is inserted.
+//
+// Parameters:
+// 	child	the Widget to use as the contents of the page.
+// 	tabLabel	the Widget to be used as the label for the page, or nil to use
+// 	            the default label, 'page N'.
+// 	menuLabel	the widget to use as a label for the page-switch menu, if that
+// 	            is enabled. If nil, and tabLabel is a Label or nil, then the
+// 	            menu label will be a newly created label with the same text as
+// 	            tabLabel; If tabLabel is not a Label, menuLabel must be
+// 	            specified if the page-switch menu is to be used.
+// 	position	the index (starting at 0) at which to insert the page, or -1 to
+// 	            append the page after all other pages.
+//
+// Returns:
+// 	the index (starting from 0) of the inserted page in the notebook, or -1
+// 	if function fails
+//
+// Emits: SignalPageAdded, Argv=[Notebook instance, child Widget, page number]
+func (n *CNotebook) InsertPageMenu(child Widget, tabLabel Widget, menuLabel Widget, position int) (value int) {
+	if child == nil || n.PageNum(child) > -1 {
+		return -1
+	}
+	n.CContainer.Add(child)
+	if !n.HasChild(child) {
+		return -1
+	}
+	page := &cNotebookPage{child: child}
+	n.Lock()
+	if position < 0 || position > len(n.pages) {
+		position = len(n.pages)
+	}
+	n.pages = append(n.pages, nil)
+	copy(n.pages[position+1:], n.pages[position:])
+	n.pages[position] = page
+	n.Unlock()
+	n.syncChildren()
+	n.setTabLabel(page, tabLabel, position)
+	n.setMenuLabel(page, menuLabel)
+	current := n.GetCurrentPage()
+	if current < 0 {
+		if !n.switchPage(position) {
+			n.setPage(position)
+		}
+	} else if position <= current {
+		n.setPage(current + 1)
+	}
+	n.updateFocusability()
+	n.Emit(SignalPageAdded, n, child, position)
+	n.queueResize()
+	return position
+}
+
+// RemovePage removes a page from the Notebook given its index in the
+// Notebook. If the current page is removed, the following page (or preceding
+// page when the last page is removed) becomes the current page. This method
+// emits a page-removed signal once the page is removed.
+//
+// Parameters:
+// 	pageNum	the index of a notebook page, starting from 0. If -1, the last
+// 	        page will be removed.
+//
+// Emits: SignalPageRemoved, Argv=[Notebook instance, child Widget, page number]
+func (n *CNotebook) RemovePage(pageNum int) {
+	pages := n.getPages()
+	if pageNum < 0 {
+		pageNum = len(pages) - 1
+	}
+	if pageNum < 0 || pageNum >= len(pages) {
+		return
+	}
+	page := pages[pageNum]
+	hadFocus := n.hasFocusWithin()
+	n.CContainer.Remove(page.child)
+	if n.HasChild(page.child) {
+		return
+	}
+	if page.tabLabel != nil {
+		n.PopCompositeChild(page.tabLabel)
+		page.tabLabel.SetParent(nil)
+	}
+	n.Lock()
+	n.pages = append(n.pages[:pageNum], n.pages[pageNum+1:]...)
+	if n.dragging != nil && n.dragging.ObjectID() == page.child.ObjectID() {
+		n.dragging = nil
+	}
+	count := len(n.pages)
+	n.Unlock()
+	n.syncChildren()
+	current := n.GetCurrentPage()
+	switch {
+	case count == 0:
+		n.setPage(-1)
+	case pageNum < current:
+		n.setPage(current - 1)
+	case pageNum == current:
+		next := pageNum
+		if next >= count {
+			next = count - 1
+		}
+		n.setPage(-1)
+		if !n.switchPage(next) {
+			n.setPage(next)
+		}
+	}
+	n.updateFocusability()
+	if hadFocus {
+		n.focusCurrentPage()
+	}
+	n.Emit(SignalPageRemoved, n, page.child, pageNum)
+	n.queueResize()
+}
+
+// PageNum finds the index of the page which contains the given child Widget.
+//
+// Parameters:
+// 	child	a Widget
+//
+// Returns:
+// 	the index of the page containing child, or -1 if child is not in the
+// 	notebook.
+func (n *CNotebook) PageNum(child Widget) (value int) {
+	if child == nil {
+		return -1
+	}
+	n.RLock()
+	defer n.RUnlock()
+	for idx, page := range n.pages {
+		if page.child.ObjectID() == child.ObjectID() {
+			return idx
+		}
+	}
+	return -1
+}
+
+// NextPage switches to the next page. Nothing happens if the current page is
+// the last page.
+func (n *CNotebook) NextPage() {
+	if current := n.GetCurrentPage(); current < n.GetNPages()-1 {
+		n.switchPage(current + 1)
+	}
+}
+
+// PrevPage switches to the previous page. Nothing happens if the current page
+// is the first page.
+func (n *CNotebook) PrevPage() {
+	if current := n.GetCurrentPage(); current > 0 {
+		n.switchPage(current - 1)
+	}
+}
+
+// ReorderChild reorders the page containing child, so that it appears in
+// the given position. If position is greater than or equal to the number of
+// children in the list or negative, child will be moved to the end of the
+// list. This method emits a page-reordered signal once the page is moved.
+//
+// Parameters:
+// 	child	the child to move
+// 	position	the new position, or -1 to move to the end
+//
+// Emits: SignalPageReordered, Argv=[Notebook instance, child Widget, page number]
+func (n *CNotebook) ReorderChild(child Widget, position int) {
+	pageNum := n.PageNum(child)
+	if pageNum < 0 {
+		return
+	}
+	current := n.GetCurrentPage()
+	var currentChild Widget
+	if current > -1 {
+		currentChild = n.GetNthPage(current)
+	}
+	n.Lock()
+	if position < 0 || position >= len(n.pages) {
+		position = len(n.pages) - 1
+	}
+	if position == pageNum {
+		n.Unlock()
+		return
+	}
+	page := n.pages[pageNum]
+	n.pages = append(n.pages[:pageNum], n.pages[pageNum+1:]...)
+	n.pages = append(n.pages, nil)
+	copy(n.pages[position+1:], n.pages[position:])
+	n.pages[position] = page
+	n.Unlock()
+	n.syncChildren()
+	if currentChild != nil {
+		n.setPage(n.PageNum(currentChild))
+	}
+	n.Emit(SignalPageReordered, n, child, position)
+	n.queueResize()
+}
+
+// GetTabPos returns the edge at which the tabs for switching pages in the
+// Notebook are drawn.
+// See: SetTabPos()
+//
+// Locking: read
+func (n *CNotebook) GetTabPos() (value enums.PositionType) {
+	var ok bool
+	if v, err := n.GetStructProperty(PropertyTabPos); err != nil {
+		n.LogErr(err)
+	} else if value, ok = v.(enums.PositionType); !ok {
+		n.LogError("value stored in %v is not a PositionType: %v (%T)", PropertyTabPos, v, v)
+	}
+	return
+}
+
+// SetTabPos updates the edge at which the tabs for switching pages in the
+// Notebook are drawn.
+//
+// Parameters:
+// 	pos	the edge to draw the tabs at.
+//
+// Locking: write
+func (n *CNotebook) SetTabPos(pos enums.PositionType) {
+	if err := n.SetStructProperty(PropertyTabPos, pos); err != nil {
+		n.LogErr(err)
+	} else {
+		n.queueResize()
+	}
+}
+
+// GetShowTabs returns whether the tabs of the Notebook are shown.
+// See: SetShowTabs()
+//
+// Locking: read
+func (n *CNotebook) GetShowTabs() (value bool) {
+	var err error
+	if value, err = n.GetBoolProperty(PropertyShowTabs); err != nil {
+		n.LogErr(err)
+	}
+	return
+}
+
+// SetShowTabs updates whether to show the tabs for the Notebook or not.
+//
+// Parameters:
+// 	showTabs	TRUE if the tabs should be shown.
+//
+// Locking: write
+func (n *CNotebook) SetShowTabs(showTabs bool) {
+	if err := n.SetBoolProperty(PropertyShowTabs, showTabs); err != nil {
+		n.LogErr(err)
+	} else {
+		n.updateFocusability()
+		n.queueResize()
+	}
+}
+
+// GetShowBorder returns whether a bevel will be drawn around the Notebook
+// pages.
+// See: SetShowBorder()
+//
+// Locking: read
+func (n *CNotebook) GetShowBorder() (value bool) {
+	var err error
+	if value, err = n.GetBoolProperty(PropertyShowBorder); err != nil {
+		n.LogErr(err)
+	}
+	return
+}
+
+// SetShowBorder updates whether a bevel will be drawn around the Notebook
+// pages. This only has a visual effect when the tabs are not shown.
+//
+// Parameters:
+// 	showBorder	TRUE if a bevel should be drawn around the notebook.
+//
+// Locking: write
+func (n *CNotebook) SetShowBorder(showBorder bool) {
+	if err := n.SetBoolProperty(PropertyShowBorder, showBorder); err != nil {
+		n.LogErr(err)
+	} else {
+		n.queueResize()
+	}
+}
+
+// GetScrollable returns whether the tab label area has arrows for scrolling.
+// See: SetScrollable()
+//
+// Locking: read
+func (n *CNotebook) GetScrollable() (value bool) {
+	var err error
+	if value, err = n.GetBoolProperty(PropertyScrollable); err != nil {
+		n.LogErr(err)
+	}
+	return
+}
+
+// SetScrollable updates whether the tab label area will have arrows for
+// scrolling if there are too many tabs to fit in the area.
+//
+// Parameters:
+// 	scrollable	TRUE if scroll arrows should be added
+//
+// Locking: write
+func (n *CNotebook) SetScrollable(scrollable bool) {
+	if err := n.SetBoolProperty(PropertyScrollable, scrollable); err != nil {
+		n.LogErr(err)
+	} else {
+		n.queueResize()
+	}
+}
+
+// GetHomogeneousTabs returns whether all tabs are given the same size.
+// See: SetHomogeneousTabs()
+//
+// Locking: read
+func (n *CNotebook) GetHomogeneousTabs() (value bool) {
+	var err error
+	if value, err = n.GetBoolProperty(PropertyHomogeneous); err != nil {
+		n.LogErr(err)
+	}
+	return
+}
+
+// SetHomogeneousTabs updates whether the tabs must have all the same size or
+// not.
+//
+// Parameters:
+// 	homogeneous	TRUE if all tabs should be the same size.
+//
+// Locking: write
+func (n *CNotebook) SetHomogeneousTabs(homogeneous bool) {
+	if err := n.SetBoolProperty(PropertyHomogeneous, homogeneous); err != nil {
+		n.LogErr(err)
+	} else {
+		n.queueResize()
+	}
+}
+
+// PopupEnable enables the popup menu: if the user clicks with the right mouse
+// button on the tab labels, a menu with all the pages will be popped up.
+//
+// Note that usage of this within CTK is unimplemented at this time
+//
+// Locking: write
+func (n *CNotebook) PopupEnable() {
+	if err := n.SetBoolProperty(PropertyEnablePopup, true); err != nil {
+		n.LogErr(err)
+	}
+}
+
+// PopupDisable disables the popup menu.
+// See: PopupEnable()
+//
+// Locking: write
+func (n *CNotebook) PopupDisable() {
+	if err := n.SetBoolProperty(PropertyEnablePopup, false); err != nil {
+		n.LogErr(err)
+	}
+}
+
+// GetCurrentPage returns the page number of the current page.
+//
+// Returns:
+// 	the index (starting from 0) of the current page in the notebook. If the
+// 	notebook has no pages, then -1 will be returned.
+//
+// Locking: read
+func (n *CNotebook) GetCurrentPage() (value int) {
+	var err error
+	if value, err = n.GetIntProperty(PropertyPage); err != nil {
+		n.LogErr(err)
+	}
+	return
+}
+
+// SetCurrentPage switches to the page number given. This method emits a
+// switch-page signal initially and if the listeners return EVENT_PASS, the
+// change is applied.
+//
+// Parameters:
+// 	pageNum	index of the page to switch to, starting from 0. If negative, the
+// 	        last page will be used. If greater than the number of pages in the
+// 	        notebook, nothing will be done.
+//
+// Emits: SignalSwitchPage, Argv=[Notebook instance, child Widget, page number]
+func (n *CNotebook) SetCurrentPage(pageNum int) {
+	count := n.GetNPages()
+	if pageNum < 0 {
+		pageNum = count - 1
+	}
+	if pageNum < 0 || pageNum >= count {
+		return
+	}
+	n.switchPage(pageNum)
+}
+
+// GetNthPage returns the child Widget contained in page number pageNum.
+//
+// Parameters:
+// 	pageNum	the index of a page in the notebook, or -1 to get the last page.
+//
+// Returns:
+// 	the child widget, or nil if pageNum is out of bounds.
+//
+// Locking: read
+func (n *CNotebook) GetNthPage(pageNum int) (value Widget) {
+	n.RLock()
+	defer n.RUnlock()
+	if pageNum < 0 {
+		pageNum = len(n.pages) - 1
+	}
+	if pageNum >= 0 && pageNum < len(n.pages) {
+		value = n.pages[pageNum].child
+	}
+	return
+}
+
+// GetNPages returns the number of pages in the Notebook.
+//
+// Locking: read
+func (n *CNotebook) GetNPages() (value int) {
+	n.RLock()
+	defer n.RUnlock()
+	return len(n.pages)
+}
+
+// GetTabLabel returns the tab label Widget for the page child. nil is returned
+// if child is not in the Notebook.
+//
+// Locking: read
+func (n *CNotebook) GetTabLabel(child Widget) (value Widget) {
+	if page := n.getPage(child); page != nil {
+		n.RLock()
+		value = page.tabLabel
+		n.RUnlock()
+	}
+	return
+}
+
+// SetTabLabel changes the tab label for child. If nil is given for tabLabel,
+// then the page will have the label 'page N'.
+//
+// Parameters:
+// 	child	the page
+// 	tabLabel	the tab label widget to use, or nil for default tab label.
+func (n *CNotebook) SetTabLabel(child Widget, tabLabel Widget) {
+	if page := n.getPage(child); page != nil {
+		n.setTabLabel(page, tabLabel, n.PageNum(child))
+		n.queueResize()
+	}
+}
+
+// GetTabLabelText retrieves the text of the tab label for the page containing
+// child. An empty string is returned if the tab label widget is not a Label.
+func (n *CNotebook) GetTabLabelText(child Widget) (value string) {
+	if tabLabel := n.GetTabLabel(child); tabLabel != nil {
+		if label, ok := tabLabel.Self().(Label); ok {
+			value = label.GetText()
+		}
+	}
+	return
+}
+
+// SetTabLabelText creates a new Label and sets it as the tab label for the
+// page containing child.
+//
+// Parameters:
+// 	child	the page
+// 	tabText	the label text
+func (n *CNotebook) SetTabLabelText(child Widget, tabText string) {
+	n.SetTabLabel(child, n.makeLabel(tabText))
+}
+
+// GetMenuLabel retrieves the menu label Widget of the page containing child.
+// nil is returned if the notebook page does not have a menu label other than
+// the default (the tab label).
+//
+// Locking: read
+func (n *CNotebook) GetMenuLabel(child Widget) (value Widget) {
+	if page := n.getPage(child); page != nil {
+		n.RLock()
+		value = page.menuLabel
+		n.RUnlock()
+	}
+	return
+}
+
+// SetMenuLabel changes the menu label for the page containing child.
+//
+// Parameters:
+// 	child	the child widget
+// 	menuLabel	the menu label, or nil for default
+func (n *CNotebook) SetMenuLabel(child Widget, menuLabel Widget) {
+	if page := n.getPage(child); page != nil {
+		n.setMenuLabel(page, menuLabel)
+	}
+}
+
+// GetMenuLabelText retrieves the text of the menu label for the page
+// containing child. If the page does not have a menu label that is a Label,
+// the tab label text is returned.
+func (n *CNotebook) GetMenuLabelText(child Widget) (value string) {
+	if menuLabel := n.GetMenuLabel(child); menuLabel != nil {
+		if label, ok := menuLabel.Self().(Label); ok {
+			return label.GetText()
+		}
+	}
+	return n.GetTabLabelText(child)
+}
+
+// SetMenuLabelText creates a new Label and sets it as the menu label of
+// child.
+//
+// Parameters:
+// 	child	the child widget
+// 	menuText	the label text
+func (n *CNotebook) SetMenuLabelText(child Widget, menuText string) {
+	n.SetMenuLabel(child, n.makeLabel(menuText))
+}
+
+// GetTabReorderable returns whether the tab contents can be reordered via drag
+// and drop or not.
+//
+// Parameters:
+// 	child	a child Widget
+func (n *CNotebook) GetTabReorderable(child Widget) (value bool) {
+	if n.PageNum(child) > -1 {
+		value, _ = n.GetChildProperty(child, PropertyNotebookChildReorderable).(bool)
+	}
+	return
+}
+
+// SetTabReorderable updates whether the notebook tab can be reordered via
+// mouse dragging or the Alt+arrow keys.
+//
+// Parameters:
+// 	child	a child Widget
+// 	reorderable	whether the tab is reorderable or not.
+func (n *CNotebook) SetTabReorderable(child Widget, reorderable bool) {
+	if n.PageNum(child) > -1 {
+		n.SetChildProperty(child, PropertyNotebookChildReorderable, reorderable)
+	}
+}
+
+// GetFocusChain returns the Notebook itself, when it can take the focus, and
+// the focus chain of the current page. The pages that are not currently shown
+// are not part of the focus chain.
+//
+// Locking: read
+func (n *CNotebook) GetFocusChain() (focusableWidgets []Widget, explicitlySet bool) {
+	n.RLock()
+	if n.focusChainSet {
+		n.RUnlock()
+		return n.focusChain, true
+	}
+	n.RUnlock()
+	if n.CanFocus() && n.IsVisible() && n.IsSensitive() {
+		focusableWidgets = append(focusableWidgets, n)
+	}
+	focusableWidgets = append(focusableWidgets, notebookPageFocusChain(n.GetNthPage(n.GetCurrentPage()))...)
+	return
+}
+
+// GetSizeRequest returns the requested size of the Notebook, taking into
+// account the largest page, the tab labels and the border.
+func (n *CNotebook) GetSizeRequest() (width, height int) {
+	size := ptypes.NewRectangle(n.CWidget.GetSizeRequest())
+	if size.W > -1 && size.H > -1 {
+		return size.W, size.H
+	}
+	pageW, pageH := 0, 0
+	for _, page := range n.getPages() {
+		if !page.child.IsVisible() {
+			continue
+		}
+		w, h := page.child.GetSizeRequest()
+		if w > pageW {
+			pageW = w
+		}
+		if h > pageH {
+			pageH = h
+		}
+	}
+	if n.GetShowBorder() {
+		pageW += 2
+		pageH += 2
+	}
+	if n.showTabs() {
+		cells := n.tabCellSizes()
+		stripLength := 0
+		for _, cell := range cells {
+			stripLength += cell
+		}
+		switch n.GetTabPos() {
+		case enums.POS_LEFT, enums.POS_RIGHT:
+			stripWidth := 0
+			for _, cell := range cells {
+				if cell > stripWidth {
+					stripWidth = cell
+				}
+			}
+			pageW += stripWidth
+			if len(cells) > pageH {
+				pageH = len(cells)
+			}
+		default:
+			pageH += 1
+			if stripLength > pageW {
+				pageW = stripLength
+			}
+		}
+	}
+	if size.W <= -1 {
+		size.W = pageW
+	}
+	if size.H <= -1 {
+		size.H = pageH
+	}
+	return size.W, size.H
+}
+
+// GetWidgetAt returns the Notebook if the given point is within the tab labels
+// area, the Widget at the given point within the current page or the Notebook
+// itself if the point is otherwise within the Notebook's bounds.
+func (n *CNotebook) GetWidgetAt(p *ptypes.Point2I) Widget {
+	if n.HasPoint(p) && n.IsVisible() {
+		if child := n.GetNthPage(n.GetCurrentPage()); child != nil && child.IsVisible() && child.HasPoint(p) {
+			if w := child.GetWidgetAt(p); w != nil {
+				return w
+			}
+		}
+		return n
+	}
+	return nil
+}
+
+func (n *CNotebook) getPages() (pages []*cNotebookPage) {
+	n.RLock()
+	defer n.RUnlock()
+	pages = append(pages, n.pages...)
+	return
+}
+
+func (n *CNotebook) getPage(child Widget) *cNotebookPage {
+	if child == nil {
+		return nil
+	}
+	n.RLock()
+	defer n.RUnlock()
+	for _, page := range n.pages {
+		if page.child.ObjectID() == child.ObjectID() {
+			return page
+		}
+	}
+	return nil
+}
+
+func (n *CNotebook) makeLabel(text string) Label {
+	label := NewLabel(text)
+	label.SetSingleLineMode(true)
+	label.SetLineWrap(false)
+	label.SetLineWrapMode(cenums.WRAP_NONE)
+	label.SetJustify(cenums.JUSTIFY_CENTER)
+	label.Show()
+	return label
+}
+
+func (n *CNotebook) setTabLabel(page *cNotebookPage, tabLabel Widget, pageNum int) {
+	if tabLabel == nil {
+		tabLabel = n.makeLabel(fmt.Sprintf("Page %d", pageNum+1))
+	}
+	n.Lock()
+	previous := page.tabLabel
+	page.tabLabel = tabLabel
+	n.Unlock()
+	if previous != nil {
+		n.PopCompositeChild(previous)
+		previous.SetParent(nil)
+	}
+	if label, ok := tabLabel.Self().(Label); ok {
+		// prepares the label text for the mnemonics before the first resize
+		_, _ = label.GetPlainTextInfo()
+	}
+	tabLabel.Show()
+	n.PushCompositeChild(tabLabel)
+}
+
+func (n *CNotebook) setMenuLabel(page *cNotebookPage, menuLabel Widget) {
+	n.Lock()
+	page.menuLabel = menuLabel
+	n.Unlock()
+}
+
+// syncChildren updates the Container children and the position child property
+// to match the order of the pages.
+func (n *CNotebook) syncChildren() {
+	pages := n.getPages()
+	children := make([]Widget, len(pages))
+	for idx, page := range pages {
+		children[idx] = page.child
+	}
+	n.Lock()
+	n.children = children
+	n.Unlock()
+	for idx, child := range children {
+		n.SetChildProperty(child, PropertyNotebookChildPosition, idx)
+	}
+}
+
+func (n *CNotebook) setPage(pageNum int) {
+	if err := n.SetIntProperty(PropertyPage, pageNum); err != nil {
+		n.LogErr(err)
+	}
+}
+
+// switchPage emits the switch-page signal and applies the change if the
+// listeners allow it, returning true if the current page is now pageNum.
+func (n *CNotebook) switchPage(pageNum int) bool {
+	if pageNum == n.GetCurrentPage() {
+		return true
+	}
+	child := n.GetNthPage(pageNum)
+	if child == nil {
+		return false
+	}
+	if f := n.Emit(SignalSwitchPage, n, child, pageNum); f == cenums.EVENT_PASS {
+		hadFocus := n.hasFocusWithin()
+		n.setPage(pageNum)
+		n.updateFocusability()
+		if hadFocus {
+			n.focusCurrentPage()
+		}
+		n.queueResize()
+		return true
+	}
+	return false
+}
+
+// changeCurrentPage moves the current page by the given offset, clamped to the
+// first and last pages.
+//
+// Emits: SignalChangeCurrentPage, Argv=[Notebook instance, offset]
+func (n *CNotebook) changeCurrentPage(offset int) cenums.EventFlag {
+	count := n.GetNPages()
+	if count == 0 {
+		return cenums.EVENT_PASS
+	}
+	if f := n.Emit(SignalChangeCurrentPage, n, offset); f == cenums.EVENT_PASS {
+		target := n.GetCurrentPage() + offset
+		if target < 0 {
+			target = 0
+		} else if target >= count {
+			target = count - 1
+		}
+		n.switchPage(target)
+	}
+	return cenums.EVENT_STOP
+}
+
+// focusTab switches to the first or last page.
+//
+// Emits: SignalFocusTab, Argv=[Notebook instance, enums.NotebookTab]
+func (n *CNotebook) focusTab(tab enums.NotebookTab) cenums.EventFlag {
+	count := n.GetNPages()
+	if count == 0 {
+		return cenums.EVENT_PASS
+	}
+	if f := n.Emit(SignalFocusTab, n, tab); f == cenums.EVENT_PASS {
+		switch tab {
+		case enums.NOTEBOOK_TAB_FIRST:
+			n.switchPage(0)
+		case enums.NOTEBOOK_TAB_LAST:
+			n.switchPage(count - 1)
+		}
+	}
+	return cenums.EVENT_STOP
+}
+
+// reorderTab moves the current page in the given direction, or to the first or
+// last position when moveToLast is true, if the page is reorderable.
+//
+// Emits: SignalReorderTab, Argv=[Notebook instance, enums.DirectionType, moveToLast]
+func (n *CNotebook) reorderTab(direction enums.DirectionType, moveToLast bool) cenums.EventFlag {
+	current := n.GetCurrentPage()
+	child := n.GetNthPage(current)
+	if child == nil || !n.GetTabReorderable(child) {
+		return cenums.EVENT_PASS
+	}
+	if f := n.Emit(SignalReorderTab, n, direction, moveToLast); f == cenums.EVENT_PASS {
+		target := current
+		switch direction {
+		case enums.DIR_UP, enums.DIR_LEFT, enums.DIR_TAB_BACKWARD:
+			if moveToLast {
+				target = 0
+			} else {
+				target -= 1
+			}
+		default:
+			if moveToLast {
+				target = n.GetNPages() - 1
+			} else {
+				target += 1
+			}
+		}
+		if target >= 0 && target < n.GetNPages() {
+			n.ReorderChild(child, target)
+		}
+	}
+	return cenums.EVENT_STOP
+}
+
+// activateMnemonic switches to the page whose tab label has the given mnemonic
+// key, returning true if one was found.
+func (n *CNotebook) activateMnemonic(r rune) bool {
+	if r == 0 || !n.showTabs() {
+		return false
+	}
+	r = unicode.ToLower(r)
+	for idx, page := range n.getPages() {
+		if page.tabLabel == nil || !page.child.IsVisible() {
+			continue
+		}
+		if label, ok := page.tabLabel.Self().(Label); ok {
+			if keyval := label.GetMnemonicKeyVal(); keyval > 0 && keyval == r {
+				n.switchPage(idx)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (n *CNotebook) showTabs() bool {
+	return n.GetShowTabs() && n.GetNPages() > 0
+}
+
+// updateFocusability makes the Notebook focusable when the tabs are shown and
+// the current page has nothing else that can take the focus.
+func (n *CNotebook) updateFocusability() {
+	if n.showTabs() && len(notebookPageFocusChain(n.GetNthPage(n.GetCurrentPage()))) == 0 {
+		n.SetFlags(enums.CAN_FOCUS)
+	} else {
+		n.UnsetFlags(enums.CAN_FOCUS)
+	}
+}
+
+// hasFocusWithin returns true if the Window focus is the Notebook itself or
+// any of its descendants.
+func (n *CNotebook) hasFocusWithin() bool {
+	if window := n.GetWindow(); window != nil {
+		for focus := window.GetFocus(); focus != nil; focus = focus.GetParent() {
+			if focus.ObjectID() == n.ObjectID() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// focusCurrentPage moves the focus to the first focusable Widget within the
+// current page, or to the Notebook itself if the page has none.
+func (n *CNotebook) focusCurrentPage() {
+	if chain := notebookPageFocusChain(n.GetNthPage(n.GetCurrentPage())); len(chain) > 0 {
+		chain[0].GrabFocus()
+	} else if n.CanFocus() {
+		n.GrabFocus()
+	}
+}
+
+// tabCellSizes returns the length of each tab along the tab strip: the label
+// width plus padding for the horizontal positions and the same width for every
+// tab for the vertical positions, where each tab is one line tall.
+func (n *CNotebook) tabCellSizes() (cells []int) {
+	pages := n.getPages()
+	cells = make([]int, len(pages))
+	largest := 0
+	for idx, page := range pages {
+		width := 1
+		if page.tabLabel != nil {
+			if label, ok := page.tabLabel.Self().(Label); ok {
+				width, _ = label.GetPlainTextInfo()
+			} else {
+				width, _ = page.tabLabel.GetSizeRequest()
+			}
+		}
+		if width < 1 {
+			width = 1
+		}
+		cells[idx] = width + 2
+		if cells[idx] > largest {
+			largest = cells[idx]
+		}
+	}
+	switch n.GetTabPos() {
+	case enums.POS_LEFT, enums.POS_RIGHT:
+		for idx := range cells {
+			cells[idx] = largest
+		}
+	default:
+		if n.GetHomogeneousTabs() {
+			for idx := range cells {
+				cells[idx] = largest
+			}
+		}
+	}
+	return
+}
+
+// pageRegion returns the local region of the page area, including the border
+// when shown.
+func (n *CNotebook) pageRegion(alloc ptypes.Rectangle, stripWidth int) (region ptypes.Region) {
+	region = ptypes.MakeRegion(0, 0, alloc.W, alloc.H)
+	if !n.showTabs() {
+		return
+	}
+	switch n.GetTabPos() {
+	case enums.POS_LEFT:
+		region.X += stripWidth
+		region.W -= stripWidth
+	case enums.POS_RIGHT:
+		region.W -= stripWidth
+	case enums.POS_BOTTOM:
+		region.H -= 1
+	default:
+		region.Y += 1
+		region.H -= 1
+	}
+	return
+}
+
+// layoutTabs positions the tab labels within the tab strip, adding the scroll
+// arrows when the tabs overflow and the Notebook is scrollable, and returns the
+// width of the tab strip for the vertical positions.
+func (n *CNotebook) layoutTabs(alloc ptypes.Rectangle) (stripWidth int) {
+	pages := n.getPages()
+	cells := n.tabCellSizes()
+	pos := n.GetTabPos()
+	vertical := pos == enums.POS_LEFT || pos == enums.POS_RIGHT
+	if vertical && len(cells) > 0 {
+		stripWidth = cells[0]
+		if limit := alloc.W / 2; stripWidth > limit {
+			stripWidth = limit
+		}
+		for idx := range cells {
+			cells[idx] = 1
+		}
+	}
+
+	// the length and offset of the tab strip
+	length, offset := alloc.W, 0
+	if vertical {
+		length = alloc.H
+	}
+	if n.GetShowBorder() && length > 2 {
+		offset += 1
+		length -= 2
+	}
+	total := 0
+	for _, cell := range cells {
+		total += cell
+	}
+
+	current := n.GetCurrentPage()
+	n.Lock()
+	n.hasArrows = n.GetScrollable() && total > length && length > 2
+	if n.hasArrows {
+		switch pos {
+		case enums.POS_LEFT, enums.POS_RIGHT:
+			x := 0
+			if pos == enums.POS_RIGHT {
+				x = alloc.W - stripWidth
+			}
+			n.arrowBack = ptypes.MakeRegion(x, offset, stripWidth, 1)
+			n.arrowNext = ptypes.MakeRegion(x, offset+length-1, stripWidth, 1)
+		default:
+			y := 0
+			if pos == enums.POS_BOTTOM {
+				y = alloc.H - 1
+			}
+			n.arrowBack = ptypes.MakeRegion(offset, y, 1, 1)
+			n.arrowNext = ptypes.MakeRegion(offset+length-1, y, 1, 1)
+		}
+		offset += 1
+		length -= 2
+	} else {
+		n.firstTab = 0
+	}
+	if n.firstTab >= len(cells) {
+		n.firstTab = 0
+	}
+	if current > -1 && current < len(cells) {
+		if current < n.firstTab {
+			n.firstTab = current
+		}
+		for n.firstTab < current {
+			used := 0
+			for idx := n.firstTab; idx <= current; idx++ {
+				used += cells[idx]
+			}
+			if used <= length {
+				break
+			}
+			n.firstTab += 1
+		}
+	}
+	first := n.firstTab
+	n.Unlock()
+
+	// place the tabs along the strip, clipping any that overflow
+	at := offset
+	for idx, page := range pages {
+		cell := cells[idx]
+		if idx < first || at >= offset+length {
+			cell = 0
+		} else if at+cell > offset+length {
+			cell = offset + length - at
+		}
+		var tab ptypes.Region
+		if cell > 0 {
+			switch pos {
+			case enums.POS_LEFT:
+				tab = ptypes.MakeRegion(0, at, stripWidth, cell)
+			case enums.POS_RIGHT:
+				tab = ptypes.MakeRegion(alloc.W-stripWidth, at, stripWidth, cell)
+			case enums.POS_BOTTOM:
+				tab = ptypes.MakeRegion(at, alloc.H-1, cell, 1)
+			default:
+				tab = ptypes.MakeRegion(at, 0, cell, 1)
+			}
+			at += cell
+		}
+		n.Lock()
+		page.tab = tab
+		n.Unlock()
+	}
+	return
+}
+
+// tabAt returns the index of the page with the tab at the given local point,
+// or -1 if there is none.
+func (n *CNotebook) tabAt(local ptypes.Point2I) int {
+	n.RLock()
+	defer n.RUnlock()
+	for idx, page := range n.pages {
+		if notebookRegionHasPoint(page.tab, local) {
+			return idx
+		}
+	}
+	return -1
+}
+
+func (n *CNotebook) queueResize() {
+	n.Resize()
+}
+
+func (n *CNotebook) windowEventKey(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if len(argv) < 2 || !n.IsVisible() || !n.IsSensitive() {
+		return cenums.EVENT_PASS
+	}
+	if e, ok := argv[1].(*cdk.EventKey); ok {
+		mods := e.Modifiers()
+		if mods.Has(cdk.ModCtrl) && !mods.Has(cdk.ModShift) && n.hasFocusWithin() {
+			switch e.Key() {
+			case cdk.KeyPgUp:
+				return n.changeCurrentPage(-1)
+			case cdk.KeyPgDn:
+				return n.changeCurrentPage(1)
+			}
+		}
+		if window := n.GetWindow(); window != nil && e.Key() == cdk.KeyRune {
+			if mods == window.GetMnemonicModifier() && n.activateMnemonic(e.Rune()) {
+				if n.hasFocusWithin() || n.CanFocus() {
+					n.focusCurrentPage()
+				}
+				return cenums.EVENT_STOP
+			}
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (n *CNotebook) event(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if !n.IsSensitive() {
+		return cenums.EVENT_PASS
+	}
+	if evt, ok := argv[1].(cdk.Event); ok {
+		switch e := evt.(type) {
+		case *cdk.EventMouse:
+			return n.processMouseEvent(e)
+		case *cdk.EventKey:
+			return n.processKeyEvent(e)
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (n *CNotebook) processKeyEvent(e *cdk.EventKey) cenums.EventFlag {
+	mods := e.Modifiers()
+	if mods.Has(cdk.ModCtrl) {
+		switch e.Key() {
+		case cdk.KeyPgUp:
+			return n.changeCurrentPage(-1)
+		case cdk.KeyPgDn:
+			return n.changeCurrentPage(1)
+		}
+		return cenums.EVENT_PASS
+	}
+	if mods.Has(cdk.ModAlt) {
+		switch e.Key() {
+		case cdk.KeyLeft:
+			return n.reorderTab(enums.DIR_LEFT, false)
+		case cdk.KeyUp:
+			return n.reorderTab(enums.DIR_UP, false)
+		case cdk.KeyRight:
+			return n.reorderTab(enums.DIR_RIGHT, false)
+		case cdk.KeyDown:
+			return n.reorderTab(enums.DIR_DOWN, false)
+		case cdk.KeyHome:
+			return n.reorderTab(enums.DIR_LEFT, true)
+		case cdk.KeyEnd:
+			return n.reorderTab(enums.DIR_RIGHT, true)
+		case cdk.KeyRune:
+			if n.activateMnemonic(e.Rune()) {
+				return cenums.EVENT_STOP
+			}
+		}
+		return cenums.EVENT_PASS
+	}
+	switch e.Key() {
+	case cdk.KeyLeft, cdk.KeyUp:
+		return n.changeCurrentPage(-1)
+	case cdk.KeyRight, cdk.KeyDown:
+		return n.changeCurrentPage(1)
+	case cdk.KeyHome:
+		return n.focusTab(enums.NOTEBOOK_TAB_FIRST)
+	case cdk.KeyEnd:
+		return n.focusTab(enums.NOTEBOOK_TAB_LAST)
+	}
+	return cenums.EVENT_PASS
+}
+
+func (n *CNotebook) processMouseEvent(e *cdk.EventMouse) cenums.EventFlag {
+	pos := ptypes.NewPoint2I(e.Position())
+	origin := n.GetOrigin()
+	local := ptypes.MakePoint2I(pos.X-origin.X, pos.Y-origin.Y)
+	if e.IsWheelImpulse() {
+		if !n.HasPoint(pos) || n.tabAt(local) < 0 {
+			return cenums.EVENT_PASS
+		}
+		switch e.WheelImpulse() {
+		case cdk.WheelUp, cdk.WheelLeft:
+			n.PrevPage()
+		case cdk.WheelDown, cdk.WheelRight:
+			n.NextPage()
+		}
+		return cenums.EVENT_STOP
+	}
+	switch e.State() {
+	case cdk.BUTTON_PRESS, cdk.DRAG_START:
+		if !n.HasPoint(pos) {
+			return cenums.EVENT_PASS
+		}
+		n.RLock()
+		hasArrows, arrowBack, arrowNext := n.hasArrows, n.arrowBack, n.arrowNext
+		n.RUnlock()
+		if hasArrows {
+			if notebookRegionHasPoint(arrowBack, local) {
+				n.PrevPage()
+				return cenums.EVENT_STOP
+			}
+			if notebookRegionHasPoint(arrowNext, local) {
+				n.NextPage()
+				return cenums.EVENT_STOP
+			}
+		}
+		if idx := n.tabAt(local); idx > -1 {
+			n.switchPage(idx)
+			if n.CanFocus() && !n.IsFocus() {
+				n.GrabFocus()
+			}
+			if child := n.GetNthPage(idx); child != nil && n.GetTabReorderable(child) {
+				n.Lock()
+				n.dragging = child
+				n.Unlock()
+				n.GrabEventFocus()
+			}
+			return cenums.EVENT_STOP
+		}
+	case cdk.MOUSE_MOVE, cdk.DRAG_MOVE:
+		n.RLock()
+		dragging := n.dragging
+		n.RUnlock()
+		if dragging != nil && n.HasEventFocus() {
+			if idx := n.tabAt(local); idx > -1 && idx != n.PageNum(dragging) {
+				n.ReorderChild(dragging, idx)
+			}
+			return cenums.EVENT_STOP
+		}
+	case cdk.BUTTON_RELEASE, cdk.DRAG_STOP:
+		n.Lock()
+		dragging := n.dragging
+		n.dragging = nil
+		n.Unlock()
+		if dragging != nil && n.HasEventFocus() {
+			n.ReleaseEventFocus()
+			return cenums.EVENT_STOP
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (n *CNotebook) lostFocus(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	n.Invalidate()
+	return cenums.EVENT_PASS
+}
+
+func (n *CNotebook) gainedFocus(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	n.Invalidate()
+	return cenums.EVENT_PASS
+}
+
+func (n *CNotebook) resize(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	alloc := n.GetAllocation()
+	origin := n.GetOrigin()
+	pages := n.getPages()
+	current := n.GetCurrentPage()
+
+	if alloc.W <= 0 || alloc.H <= 0 {
+		for _, page := range pages {
+			if page.tabLabel != nil {
+				page.tabLabel.SetAllocation(ptypes.MakeRectangle(0, 0))
+				page.tabLabel.Resize()
+			}
+			page.child.SetAllocation(ptypes.MakeRectangle(0, 0))
+			page.child.Resize()
+		}
+		return cenums.EVENT_PASS
+	}
+
+	showTabs := n.showTabs()
+	stripWidth := 0
+	if showTabs {
+		stripWidth = n.layoutTabs(alloc)
+	}
+	region := n.pageRegion(alloc, stripWidth)
+	if n.GetShowBorder() {
+		region.X += 1
+		region.Y += 1
+		region.W -= 2
+		region.H -= 2
+	}
+	if region.W < 0 {
+		region.W = 0
+	}
+	if region.H < 0 {
+		region.H = 0
+	}
+
+	for idx, page := range pages {
+		if page.tabLabel != nil {
+			n.RLock()
+			tab := page.tab
+			n.RUnlock()
+			if showTabs && tab.W > 2 {
+				page.tabLabel.SetOrigin(origin.X+tab.X+1, origin.Y+tab.Y)
+				page.tabLabel.SetAllocation(ptypes.MakeRectangle(tab.W-2, tab.H))
+			} else {
+				page.tabLabel.SetAllocation(ptypes.MakeRectangle(0, 0))
+			}
+			page.tabLabel.Resize()
+		}
+		if idx == current {
+			page.child.SetOrigin(origin.X+region.X, origin.Y+region.Y)
+			page.child.SetAllocation(region.Size())
+		} else {
+			page.child.SetAllocation(ptypes.MakeRectangle(0, 0))
+		}
+		page.child.Resize()
+	}
+
+	n.Invalidate()
+	return cenums.EVENT_STOP
+}
+
+func (n *CNotebook) draw(data []interface{}, argv ...interface{}) cenums.EventFlag {
+
+	if surface, ok := argv[1].(*memphis.CSurface); ok {
+		alloc := n.GetAllocation()
+		if !n.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+			n.LogTrace("not visible, zero width or zero height")
+			return cenums.EVENT_PASS
+		}
+
+		theme := n.GetThemeRequest()
+		surface.Fill(theme)
+
+		pages := n.getPages()
+		current := n.GetCurrentPage()
+		showTabs := n.showTabs()
+		stripWidth := 0
+		if showTabs && len(pages) > 0 {
+			n.RLock()
+			switch n.GetTabPos() {
+			case enums.POS_LEFT, enums.POS_RIGHT:
+				for _, page := range n.pages {
+					if page.tab.W > stripWidth {
+						stripWidth = page.tab.W
+					}
+				}
+			}
+			n.RUnlock()
+		}
+		region := n.pageRegion(alloc, stripWidth)
+
+		if n.GetShowBorder() && region.W > 0 && region.H > 0 {
+			surface.BoxWithTheme(region.Origin(), region.Size(), true, false, theme)
+		}
+
+		if showTabs {
+			focused := n.HasState(enums.StateSelected)
+			for idx, page := range pages {
+				n.RLock()
+				tab := page.tab
+				n.RUnlock()
+				if tab.W <= 0 || tab.H <= 0 {
+					continue
+				}
+				style := theme.Content.Normal
+				state := enums.StateNormal
+				if idx == current {
+					if focused {
+						style = theme.Content.Active
+						state = enums.StateActive
+					} else {
+						style = theme.Content.Selected
+						state = enums.StateSelected
+					}
+					if n.GetShowBorder() {
+						n.drawTabGap(surface, theme, region, tab)
+					}
+				}
+				for y := tab.Y; y < tab.Y+tab.H; y++ {
+					for x := tab.X; x < tab.X+tab.W; x++ {
+						_ = surface.SetRune(x, y, ' ', style)
+					}
+				}
+				if tabLabel := page.tabLabel; tabLabel != nil && tab.W > 2 {
+					tabLabel.UnsetState(enums.StateActive | enums.StateSelected)
+					if state != enums.StateNormal {
+						tabLabel.SetState(state)
+					}
+					tabLabel.Draw()
+					tabLabel.LockDraw()
+					if err := surface.Composite(tabLabel.ObjectID()); err != nil {
+						n.LogError("composite error: %v", err)
+					}
+					tabLabel.UnlockDraw()
+				}
+			}
+			n.drawArrows(surface, theme, current, len(pages))
+		}
+
+		if child := n.GetNthPage(current); child != nil && child.IsVisible() {
+			child.Draw()
+			child.LockDraw()
+			if err := surface.Composite(child.ObjectID()); err != nil {
+				n.LogError("composite error: %v", err)
+			}
+			child.UnlockDraw()
+		}
+
+		if debug, _ := n.GetBoolProperty(cdk.PropertyDebug); debug {
+			surface.DebugBox(paint.ColorSilver, n.ObjectInfo())
+		}
+
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+// drawTabGap opens the border of the page area along the current tab, joining
+// the tab with the page it shows.
+func (n *CNotebook) drawTabGap(surface *memphis.CSurface, theme paint.Theme, region ptypes.Region, tab ptypes.Region) {
+	runes := theme.Border.BorderRunes
+	style := theme.Border.Normal
+	right, bottom := region.X+region.W-1, region.Y+region.H-1
+	switch n.GetTabPos() {
+	case enums.POS_LEFT, enums.POS_RIGHT:
+		x, before, after := region.X, runes.BottomRight, runes.TopRight
+		if n.GetTabPos() == enums.POS_RIGHT {
+			x, before, after = right, runes.BottomLeft, runes.TopLeft
+		}
+		for y := tab.Y; y < tab.Y+tab.H; y++ {
+			if y > region.Y && y < bottom {
+				_ = surface.SetRune(x, y, ' ', style)
+			}
+		}
+		if y := tab.Y - 1; y > region.Y {
+			_ = surface.SetRune(x, y, before, style)
+		} else if y == region.Y {
+			_ = surface.SetRune(x, y, runes.Top, style)
+		}
+		if y := tab.Y + tab.H; y < bottom {
+			_ = surface.SetRune(x, y, after, style)
+		} else if y == bottom {
+			_ = surface.SetRune(x, y, runes.Bottom, style)
+		}
+	default:
+		y, before, after := region.Y, runes.BottomRight, runes.BottomLeft
+		if n.GetTabPos() == enums.POS_BOTTOM {
+			y, before, after = bottom, runes.TopRight, runes.TopLeft
+		}
+		for x := tab.X; x < tab.X+tab.W; x++ {
+			if x > region.X && x < right {
+				_ = surface.SetRune(x, y, ' ', style)
+			}
+		}
+		if x := tab.X - 1; x > region.X {
+			_ = surface.SetRune(x, y, before, style)
+		} else if x == region.X {
+			_ = surface.SetRune(x, y, runes.Left, style)
+		}
+		if x := tab.X + tab.W; x < right {
+			_ = surface.SetRune(x, y, after, style)
+		} else if x == right {
+			_ = surface.SetRune(x, y, runes.Right, style)
+		}
+	}
+}
+
+// drawArrows renders the scroll arrows, dimmed when there is no page to scroll
+// towards.
+func (n *CNotebook) drawArrows(surface *memphis.CSurface, theme paint.Theme, current, count int) {
+	n.RLock()
+	hasArrows, arrowBack, arrowNext := n.hasArrows, n.arrowBack, n.arrowNext
+	n.RUnlock()
+	if !hasArrows {
+		return
+	}
+	back, next := theme.Border.ArrowRunes.Left, theme.Border.ArrowRunes.Right
+	switch n.GetTabPos() {
+	case enums.POS_LEFT, enums.POS_RIGHT:
+		back, next = theme.Border.ArrowRunes.Up, theme.Border.ArrowRunes.Down
+	}
+	backStyle, nextStyle := theme.Content.Normal, theme.Content.Normal
+	if current <= 0 {
+		backStyle = theme.Content.Insensitive
+	}
+	if current >= count-1 {
+		nextStyle = theme.Content.Insensitive
+	}
+	for _, arrow := range []struct {
+		region ptypes.Region
+		r      rune
+		style  paint.Style
+	}{
+		{arrowBack, back, backStyle},
+		{arrowNext, next, nextStyle},
+	} {
+		for x := arrow.region.X; x < arrow.region.X+arrow.region.W; x++ {
+			_ = surface.SetRune(x, arrow.region.Y, ' ', arrow.style)
+		}
+		_ = surface.SetRune(arrow.region.X+arrow.region.W/2, arrow.region.Y, arrow.r, arrow.style)
+	}
+}
+
+// notebookPageFocusChain returns the focusable Widgets within the given page.
+func notebookPageFocusChain(child Widget) (focusableWidgets []Widget) {
+	if child == nil || !child.IsVisible() {
+		return
+	}
+	if cc, ok := child.Self().(Container); ok {
+		fc, _ := cc.GetFocusChain()
+		for _, cChild := range fc {
+			if cChild.CanFocus() && cChild.IsVisible() && cChild.IsSensitive() {
+				focusableWidgets = append(focusableWidgets, cChild)
+			}
+		}
+	} else if child.CanFocus() && child.IsSensitive() {
+		focusableWidgets = append(focusableWidgets, child)
+	}
+	return
+}
+
+// notebookRegionHasPoint returns true if the local point is within the region,
+// excluding the far edges.
+func notebookRegionHasPoint(region ptypes.Region, point ptypes.Point2I) bool {
+	return point.X >= region.X && point.X < region.X+region.W &&
+		point.Y >= region.Y && point.Y < region.Y+region.H
+}
+
+// notebookPositionTypeFromString parses GtkPositionType names
+func notebookPositionTypeFromString(value string) enums.PositionType {
+	value = strings.ToLower(value)
+	value = strings.TrimPrefix(value, "gtk_pos_")
+	switch value {
+	case "left", "0":
+		return enums.POS_LEFT
+	case "right", "1":
+		return enums.POS_RIGHT
+	case "bottom", "3":
+		return enums.POS_BOTTOM
+	}
+	return enums.POS_TOP
+}
+
+// Whether the tab can be reordered by the user.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyNotebookChildReorderable cdk.Property = "notebook-child--reorderable"
+
+// The index of the child in the parent.
+// Flags: Read / Write
+// Allowed values: >= -1
+// Default value: 0
+const PropertyNotebookChildPosition cdk.Property = "notebook-child--position"
+
+// If TRUE, pressing the right mouse button on the notebook pops up a menu that
+// you can use to go to a page.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyEnablePopup cdk.Property = "enable-popup"
+
+// Whether tabs should have homogeneous sizes.
+// Flags: Read / Write
+// Default value: FALSE
+// const PropertyHomogeneous cdk.Property = "homogeneous"
+
+// The index of the current page.
+// Flags: Read / Write
+// Allowed values: >= -1
+// Default value: -1
+const PropertyPage cdk.Property = "page"
+
+// If TRUE, scroll arrows are added if there are too many tabs to fit.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyScrollable cdk.Property = "scrollable"
+
+// Whether the border should be shown or not.
+// Flags: Read / Write
+// Default value: TRUE
+const PropertyShowBorder cdk.Property = "show-border"
+
+// Whether tabs should be shown or not.
+// Flags: Read / Write
+// Default value: TRUE
+const PropertyShowTabs cdk.Property = "show-tabs"
+
+// Which side of the notebook holds the tabs.
+// Flags: Read / Write
+// Default value: GTK_POS_TOP
+const PropertyTabPos cdk.Property = "tab-pos"
+
+// Emitted when the current page is moved by an offset with the keyboard.
+// Listener function arguments:
+// 	offset int	the number of pages to move by
+const SignalChangeCurrentPage cdk.Signal = "change-current-page"
+
+// Emitted when the first or last tab is focused with the keyboard.
+// Listener function arguments:
+// 	tab enums.NotebookTab	which tab is being focused
+const SignalFocusTab cdk.Signal = "focus-tab"
+
+// The ::page-added signal is emitted in the notebook right after a page is
+// added to the notebook.
+// Listener function arguments:
+// 	child Widget	the child Widget affected
+// 	pageNum int	the child page number
+const SignalPageAdded cdk.Signal = "page-added"
+
+// The ::page-removed signal is emitted in the notebook right after a page is
+// removed from the notebook.
+// Listener function arguments:
+// 	child Widget	the child Widget affected
+// 	pageNum int	the child page number
+const SignalPageRemoved cdk.Signal = "page-removed"
+
+// The ::page-reordered signal is emitted in the notebook right after a page
+// has been reordered.
+// Listener function arguments:
+// 	child Widget	the child Widget affected
+// 	pageNum int	the new page number for child
+const SignalPageReordered cdk.Signal = "page-reordered"
+
+// Emitted when the current page is moved with the Alt+arrow keys.
+// Listener function arguments:
+// 	direction enums.DirectionType	the direction to move the page in
+// 	moveToLast bool	whether to move the page to the first or last position
+const SignalReorderTab cdk.Signal = "reorder-tab"
+
+// Emitted when the user or a function changes the current page.
+// Listener function arguments:
+// 	page Widget	the new current page
+// 	pageNum int	the index of the page
+const SignalSwitchPage cdk.Signal = "switch-page"
+
+const NotebookEventHandle = "notebook-event-handler"
+
+const NotebookWindowEventHandle = "notebook-window-event-handler"
+
+const NotebookLostFocusHandle = "notebook-lost-focus-handler"
+
+const NotebookGainedFocusHandle = "notebook-gained-focus-handler"
+
+const NotebookResizeHandle = "notebook-resize-handler"
+
+const NotebookDrawHandle = "notebook-draw-handler"
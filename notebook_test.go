@@ -0,0 +1,187 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"testing"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/ptypes"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+func TestNotebook(t *testing.T) {
+	Convey("Testing Notebooks", t, func() {
+		Convey("Basics", func() {
+			n := &CNotebook{}
+			So(n.Init(), ShouldEqual, false)
+			So(n.Init(), ShouldEqual, true)
+			nb := NewNotebook()
+			So(nb.GetCurrentPage(), ShouldEqual, -1)
+			So(nb.GetTabPos(), ShouldEqual, enums.POS_TOP)
+			So(nb.GetShowTabs(), ShouldEqual, true)
+			So(nb.GetShowBorder(), ShouldEqual, true)
+			So(nb.GetScrollable(), ShouldEqual, false)
+
+			nb.Show()
+			one, two, three := NewLabel("one"), NewLabel("two"), NewLabel("three")
+			one.Show()
+			two.Show()
+			three.Show()
+			So(nb.AppendPage(one, nil), ShouldEqual, 0)
+			So(nb.GetCurrentPage(), ShouldEqual, 0)
+			So(nb.GetTabLabelText(one), ShouldEqual, "Page 1")
+			So(nb.AppendPage(two, NewLabelWithMnemonic("_Two")), ShouldEqual, 1)
+			So(nb.PrependPage(three, nil), ShouldEqual, 0)
+			So(nb.GetNPages(), ShouldEqual, 3)
+			So(nb.GetCurrentPage(), ShouldEqual, 1)
+			So(nb.GetNthPage(1), ShouldEqual, one)
+			So(nb.PageNum(two), ShouldEqual, 2)
+			So(nb.GetChildren(), ShouldHaveLength, 3)
+			So(nb.GetChildProperty(two, PropertyNotebookChildPosition), ShouldEqual, 2)
+			nb.SetTabLabelText(three, "Three")
+			So(nb.GetTabLabelText(three), ShouldEqual, "Three")
+			So(nb.GetMenuLabelText(three), ShouldEqual, "Three")
+
+			Convey("Switching Pages", func() {
+				var switched []int
+				nb.Connect(SignalSwitchPage, "test-switch-page", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+					pageNum, _ := argv[2].(int)
+					switched = append(switched, pageNum)
+					return cenums.EVENT_PASS
+				})
+				nb.NextPage()
+				So(nb.GetCurrentPage(), ShouldEqual, 2)
+				nb.NextPage()
+				So(nb.GetCurrentPage(), ShouldEqual, 2)
+				nb.SetCurrentPage(0)
+				nb.PrevPage()
+				So(nb.GetCurrentPage(), ShouldEqual, 0)
+				So(switched, ShouldResemble, []int{2, 0})
+				nb.ProcessEvent(cdk.NewEventKey(cdk.KeyPgDn, 0, cdk.ModCtrl))
+				So(nb.GetCurrentPage(), ShouldEqual, 1)
+				nb.ProcessEvent(cdk.NewEventKey(cdk.KeyEnd, 0, cdk.ModNone))
+				So(nb.GetCurrentPage(), ShouldEqual, 2)
+				nb.ProcessEvent(cdk.NewEventKey(cdk.KeyPgUp, 0, cdk.ModCtrl))
+				So(nb.GetCurrentPage(), ShouldEqual, 1)
+				nb.ProcessEvent(cdk.NewEventKey(cdk.KeyHome, 0, cdk.ModNone))
+				So(nb.GetCurrentPage(), ShouldEqual, 0)
+				nb.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 't', cdk.ModAlt))
+				So(nb.GetCurrentPage(), ShouldEqual, 2)
+
+				nb.Connect(SignalSwitchPage, "test-veto-page", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+					return cenums.EVENT_STOP
+				})
+				nb.SetCurrentPage(0)
+				So(nb.GetCurrentPage(), ShouldEqual, 2)
+			})
+
+			Convey("Reordering and Removing", func() {
+				nb.SetCurrentPage(2)
+				nb.ReorderChild(two, 0)
+				So(nb.PageNum(two), ShouldEqual, 0)
+				So(nb.GetCurrentPage(), ShouldEqual, 0)
+				So(nb.GetChildren()[0], ShouldEqual, two)
+				nb.ProcessEvent(cdk.NewEventKey(cdk.KeyRight, 0, cdk.ModAlt))
+				So(nb.PageNum(two), ShouldEqual, 0)
+				nb.SetTabReorderable(two, true)
+				So(nb.GetTabReorderable(two), ShouldEqual, true)
+				nb.ProcessEvent(cdk.NewEventKey(cdk.KeyRight, 0, cdk.ModAlt))
+				So(nb.PageNum(two), ShouldEqual, 1)
+				So(nb.GetCurrentPage(), ShouldEqual, 1)
+
+				removed := -1
+				nb.Connect(SignalPageRemoved, "test-page-removed", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+					removed, _ = argv[2].(int)
+					return cenums.EVENT_PASS
+				})
+				nb.RemovePage(1)
+				So(removed, ShouldEqual, 1)
+				So(nb.GetNPages(), ShouldEqual, 2)
+				So(nb.GetCurrentPage(), ShouldEqual, 1)
+				So(nb.GetNthPage(1), ShouldEqual, one)
+				nb.Remove(three)
+				So(nb.GetNPages(), ShouldEqual, 1)
+				So(nb.GetCurrentPage(), ShouldEqual, 0)
+				nb.RemovePage(-1)
+				So(nb.GetNPages(), ShouldEqual, 0)
+				So(nb.GetCurrentPage(), ShouldEqual, -1)
+			})
+
+			Convey("Layout and Mouse", func() {
+				nb.SetAllocation(ptypes.MakeRectangle(20, 6))
+				nb.Resize()
+				So(one.GetOrigin(), ShouldResemble, ptypes.MakePoint2I(1, 2))
+				So(one.GetAllocation(), ShouldResemble, ptypes.MakeRectangle(18, 3))
+				So(two.GetAllocation(), ShouldResemble, ptypes.MakeRectangle(0, 0))
+				// tabs: " Three " at 1-7, " Page 1 " at 8-15, " Two " at 16-18
+				nb.ProcessEvent(cdk.NewEventMouse(17, 0, cdk.Button1, cdk.ModNone))
+				nb.ProcessEvent(cdk.NewEventMouse(17, 0, cdk.ButtonNone, cdk.ModNone))
+				So(nb.GetCurrentPage(), ShouldEqual, 2)
+				So(two.GetAllocation(), ShouldResemble, ptypes.MakeRectangle(18, 3))
+
+				nb.SetScrollable(true)
+				nb.SetAllocation(ptypes.MakeRectangle(12, 6))
+				nb.Resize()
+				nb.ProcessEvent(cdk.NewEventMouse(1, 0, cdk.Button1, cdk.ModNone))
+				nb.ProcessEvent(cdk.NewEventMouse(1, 0, cdk.ButtonNone, cdk.ModNone))
+				So(nb.GetCurrentPage(), ShouldEqual, 1)
+
+				nb.SetTabPos(enums.POS_LEFT)
+				nb.SetShowBorder(false)
+				So(one.GetOrigin(), ShouldResemble, ptypes.MakePoint2I(6, 0))
+				nb.ProcessEvent(cdk.NewEventMouse(2, 0, cdk.Button1, cdk.ModNone))
+				nb.ProcessEvent(cdk.NewEventMouse(2, 0, cdk.ButtonNone, cdk.ModNone))
+				So(nb.GetCurrentPage(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("Builder", func() {
+			builder := NewBuilder()
+			_, err := builder.LoadFromString(`<interface>
+  <object class="GtkNotebook" id="test-notebook">
+    <property name="tab_pos">GTK_POS_BOTTOM</property>
+    <property name="scrollable">True</property>
+    <property name="page">1</property>
+    <child>
+      <object class="GtkLabel" id="test-notebook-first"><property name="label">First</property></object>
+    </child>
+    <child type="tab">
+      <object class="GtkLabel" id="test-notebook-first-tab"><property name="label">_Alpha</property><property name="use_underline">True</property></object>
+    </child>
+    <child>
+      <object class="GtkLabel" id="test-notebook-second"><property name="label">Second</property></object>
+      <packing><property name="tab_label">Bravo</property><property name="reorderable">True</property></packing>
+    </child>
+  </object>
+</interface>`)
+			So(err, ShouldBeNil)
+			nb, ok := builder.GetWidget("test-notebook").(Notebook)
+			So(ok, ShouldEqual, true)
+			So(nb.GetTabPos(), ShouldEqual, enums.POS_BOTTOM)
+			So(nb.GetScrollable(), ShouldEqual, true)
+			So(nb.GetNPages(), ShouldEqual, 2)
+			So(nb.GetCurrentPage(), ShouldEqual, 1)
+			So(nb.GetTabLabelText(nb.GetNthPage(1)), ShouldEqual, "Bravo")
+			So(nb.GetTabReorderable(nb.GetNthPage(1)), ShouldEqual, true)
+			So(nb.GetTabLabel(nb.GetNthPage(0)), ShouldEqual, builder.GetWidget("test-notebook-first-tab"))
+			nb.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 'a', cdk.ModAlt))
+			So(nb.GetCurrentPage(), ShouldEqual, 0)
+		})
+	})
+}
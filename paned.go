@@ -0,0 +1,1065 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	cmath "github.com/go-curses/cdk/lib/math"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/go-curses/cdk/lib/ptypes"
+	cstrings "github.com/go-curses/cdk/lib/strings"
+	"github.com/go-curses/cdk/memphis"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypePaned cdk.CTypeTag = "ctk-paned"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypePaned, func() interface{} { return MakePaned() })
+}
+
+// panedPageStep is the number of cells the divider moves with Ctrl and the
+// arrow keys.
+const panedPageStep = 5
+
+// Paned Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Paned
+//	        +- HPaned
+//	        +- VPaned
+//
+// The Paned Widget is a Container with two panes arranged either horizontally
+// or vertically. The division between the two panes is a one-cell divider
+// which can be dragged with the mouse to adjust the space given to each
+// child.
+//
+// Child Widgets are added to the panes with Pack1 and Pack2. The resize flag
+// of a child determines whether it grows or shrinks along with the Paned and
+// the shrink flag determines whether it can be made smaller than its size
+// request by the user moving the divider.
+//
+// When the focus is within the Paned, pressing F8 gives the divider the focus.
+// The arrow keys then move the divider one cell at a time (or several cells
+// with Ctrl), Home and End move it to the minimum and maximum positions,
+// Enter (or F8 again) accepts the new position and Escape restores the
+// position the divider had when it was focused.
+type Paned interface {
+	Container
+	Buildable
+	Orientable
+
+	Init() (already bool)
+	Build(builder Builder, element *CBuilderElement) error
+	SetWindow(w Window)
+	Add(child Widget)
+	Remove(child Widget)
+	Add1(child Widget)
+	Add2(child Widget)
+	Pack1(child Widget, resize, shrink bool)
+	Pack2(child Widget, resize, shrink bool)
+	GetChild1() (value Widget)
+	GetChild2() (value Widget)
+	GetChildResize(child Widget) (value bool)
+	SetChildResize(child Widget, resize bool)
+	GetChildShrink(child Widget) (value bool)
+	SetChildShrink(child Widget, shrink bool)
+	GetPosition() (value int)
+	SetPosition(position int)
+	GetPositionSet() (value bool)
+	GetMinPosition() (value int)
+	GetMaxPosition() (value int)
+	GetHandleFocus() (value bool)
+	GetHandleRegion() (region ptypes.Region)
+	MoveHandle(scroll enums.ScrollType) cenums.EventFlag
+	CancelEvent()
+	GetSizeRequest() (width, height int)
+	GetWidgetAt(p *ptypes.Point2I) Widget
+}
+
+var _ Paned = (*CPaned)(nil)
+
+// The CPaned structure implements the Paned interface and is exported to
+// facilitate type embedding with custom implementations. No member variables
+// are exported as the interface methods are the only intended means of
+// interacting with Paned objects.
+type CPaned struct {
+	CContainer
+
+	child1         Widget
+	child2         Widget
+	lastAllocation int
+	minPosition    int
+	maxPosition    int
+	handleFocus    bool
+	origPosition   int
+	dragging       bool
+	dragOffset     int
+	window         Window
+	handle         string
+}
+
+// MakePaned is used by the Buildable system to construct a new Paned.
+func MakePaned() Paned {
+	return NewPaned(cenums.ORIENTATION_HORIZONTAL)
+}
+
+// NewPaned is the constructor for new Paned instances.
+//
+// Parameters:
+//
+//	orientation	the orientation of the divider between the two panes
+func NewPaned(orientation cenums.Orientation) Paned {
+	p := new(CPaned)
+	p.Init()
+	p.SetOrientation(orientation)
+	return p
+}
+
+// Init initializes a Paned object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the Paned instance. Init is used in the
+// NewPaned constructor and only necessary when implementing a derivative
+// Paned type.
+func (p *CPaned) Init() (already bool) {
+	if p.InitTypeItem(TypePaned, p) {
+		return true
+	}
+	p.CContainer.Init()
+	p.flags = enums.NULL_WIDGET_FLAG
+	p.SetFlags(enums.SENSITIVE | enums.PARENT_SENSITIVE | enums.APP_PAINTABLE)
+	p.child1 = nil
+	p.child2 = nil
+	p.lastAllocation = -1
+	p.minPosition = 0
+	p.maxPosition = 0
+	p.handleFocus = false
+	p.origPosition = -1
+	p.dragging = false
+	p.window = nil
+	p.handle = fmt.Sprintf("%v-%v", PanedWindowEventHandle, p.ObjectID())
+
+	_ = p.InstallBuildableProperty(PropertyOrientation, cdk.StructProperty, true, cenums.ORIENTATION_HORIZONTAL)
+	_ = p.InstallProperty(PropertyPanedPosition, cdk.IntProperty, true, 0)
+	_ = p.InstallProperty(PropertyPositionSet, cdk.BoolProperty, true, false)
+	_ = p.InstallChildProperty(PropertyPanedChildResize, cdk.BoolProperty, true, true)
+	_ = p.InstallChildProperty(PropertyPanedChildShrink, cdk.BoolProperty, true, true)
+
+	p.Connect(SignalCdkEvent, PanedEventHandle, p.event)
+	p.Connect(SignalResize, PanedResizeHandle, p.resize)
+	p.Connect(SignalDraw, PanedDrawHandle, p.draw)
+	return false
+}
+
+// Build provides customizations to the Buildable system for Paned Widgets. The
+// first child is packed into the first pane and the second child into the
+// second pane, each using the "resize" and "shrink" packing properties.
+func (p *CPaned) Build(builder Builder, element *CBuilderElement) error {
+	p.Freeze()
+	defer p.Thaw()
+	if name, ok := element.Attributes["id"]; ok {
+		p.SetName(name)
+	}
+	for k, v := range element.Properties {
+		switch cdk.Property(strings.ReplaceAll(k, "_", "-")) {
+		case PropertyOrientation:
+			value := strings.TrimPrefix(strings.ToLower(v), "gtk_orientation_")
+			if orientation, err := cenums.ORIENTATION_NONE.FromString(value); err != nil {
+				p.LogErr(err)
+			} else {
+				p.SetOrientation(orientation.(cenums.Orientation))
+			}
+		case PropertyPanedPosition:
+			if position, err := strconv.Atoi(v); err != nil {
+				p.LogErr(err)
+			} else {
+				p.SetPosition(position)
+			}
+		case PropertyPositionSet:
+		default:
+			element.ApplyProperty(k, v)
+		}
+	}
+	packed := 0
+	for _, child := range element.Children {
+		if newChild := builder.Build(child); newChild != nil {
+			child.Instance = newChild
+			newChildWidget, ok := newChild.(Widget)
+			if !ok {
+				p.LogError("new child object is not a Widget type: %v (%T)", newChild, newChild)
+				continue
+			}
+			resize, shrink := packed > 0, true
+			if v, ok := child.Packing["resize"]; ok {
+				resize = cstrings.IsTrue(v)
+			}
+			if v, ok := child.Packing["shrink"]; ok {
+				shrink = cstrings.IsTrue(v)
+			}
+			newChildWidget.Show()
+			switch packed {
+			case 0:
+				p.Pack1(newChildWidget, resize, shrink)
+			case 1:
+				p.Pack2(newChildWidget, resize, shrink)
+			default:
+				p.LogError("paned already has two children, ignoring: %v", newChildWidget.ObjectName())
+				continue
+			}
+			packed += 1
+		}
+	}
+	element.ApplySignals()
+	return nil
+}
+
+// SetWindow updates the Window of the Paned and its children. The Paned
+// listens for key events on the Window in order to give the divider the focus
+// with F8.
+func (p *CPaned) SetWindow(w Window) {
+	p.Lock()
+	previous := p.window
+	p.window = w
+	p.Unlock()
+	if previous != nil {
+		_ = previous.Disconnect(SignalEventKey, p.handle)
+	}
+	if w != nil {
+		w.Connect(SignalEventKey, p.handle, p.windowEventKey)
+	}
+	p.CContainer.SetWindow(w)
+}
+
+// GetOrientation is a convenience method for returning the orientation
+// property value.
+// See: SetOrientation()
+//
+// Locking: read
+func (p *CPaned) GetOrientation() (orientation cenums.Orientation) {
+	p.RLock()
+	defer p.RUnlock()
+	var ok bool
+	if v, err := p.GetStructProperty(PropertyOrientation); err != nil {
+		p.LogErr(err)
+	} else if orientation, ok = v.(cenums.Orientation); !ok && v != nil {
+		p.LogError("invalid value stored in %v: %v (%T)", PropertyOrientation, v, v)
+	}
+	return
+}
+
+// SetOrientation is a convenience method for updating the orientation property
+// value. A horizontal Paned places the panes side by side with a vertical
+// divider between them while a vertical Paned stacks the panes.
+//
+// Parameters:
+//
+//	orientation	the desired cenums.Orientation to use
+//
+// Locking: write
+func (p *CPaned) SetOrientation(orientation cenums.Orientation) {
+	if err := p.SetStructProperty(PropertyOrientation, orientation); err != nil {
+		p.LogErr(err)
+	}
+	p.queueResize()
+}
+
+// Add is a convenience method for adding the given Widget to the first empty
+// pane, using the default packing of Add1 or Add2.
+func (p *CPaned) Add(child Widget) {
+	switch {
+	case p.GetChild1() == nil:
+		p.Add1(child)
+	case p.GetChild2() == nil:
+		p.Add2(child)
+	default:
+		p.LogError("paned already has two children, ignoring: %v", child.ObjectName())
+	}
+}
+
+// Remove will remove the given Widget from its pane.
+func (p *CPaned) Remove(child Widget) {
+	p.Lock()
+	if p.child1 != nil && p.child1.ObjectID() == child.ObjectID() {
+		p.child1 = nil
+	} else if p.child2 != nil && p.child2.ObjectID() == child.ObjectID() {
+		p.child2 = nil
+	}
+	p.Unlock()
+	p.CContainer.Remove(child)
+	p.queueResize()
+}
+
+// Add1 adds a child to the top or left pane with default parameters. This is
+// equivalent to Pack1(child, false, true).
+func (p *CPaned) Add1(child Widget) {
+	p.Pack1(child, false, true)
+}
+
+// Add2 adds a child to the bottom or right pane with default parameters. This
+// is equivalent to Pack2(child, true, true).
+func (p *CPaned) Add2(child Widget) {
+	p.Pack2(child, true, true)
+}
+
+// Pack1 adds a child to the top or left pane. Nothing happens if the pane
+// already has a child.
+//
+// Parameters:
+//
+//	child	the child to add
+//	resize	should this child expand when the paned widget is resized.
+//	shrink	can this child be made smaller than its requisition.
+func (p *CPaned) Pack1(child Widget, resize, shrink bool) {
+	if p.GetChild1() != nil {
+		p.LogError("first pane already has a child, ignoring: %v", child.ObjectName())
+		return
+	}
+	p.pack(child, resize, shrink, true)
+}
+
+// Pack2 adds a child to the bottom or right pane. Nothing happens if the pane
+// already has a child.
+//
+// Parameters:
+//
+//	child	the child to add
+//	resize	should this child expand when the paned widget is resized.
+//	shrink	can this child be made smaller than its requisition.
+func (p *CPaned) Pack2(child Widget, resize, shrink bool) {
+	if p.GetChild2() != nil {
+		p.LogError("second pane already has a child, ignoring: %v", child.ObjectName())
+		return
+	}
+	p.pack(child, resize, shrink, false)
+}
+
+// GetChild1 returns the first child of the Paned, or nil if there is none.
+//
+// Locking: read
+func (p *CPaned) GetChild1() (value Widget) {
+	p.RLock()
+	defer p.RUnlock()
+	return p.child1
+}
+
+// GetChild2 returns the second child of the Paned, or nil if there is none.
+//
+// Locking: read
+func (p *CPaned) GetChild2() (value Widget) {
+	p.RLock()
+	defer p.RUnlock()
+	return p.child2
+}
+
+// GetChildResize returns whether the given child expands when the Paned is
+// resized.
+func (p *CPaned) GetChildResize(child Widget) (value bool) {
+	if v, ok := p.GetChildProperty(child, PropertyPanedChildResize).(bool); ok {
+		value = v
+	}
+	return
+}
+
+// SetChildResize updates whether the given child expands when the Paned is
+// resized.
+func (p *CPaned) SetChildResize(child Widget, resize bool) {
+	p.SetChildProperty(child, PropertyPanedChildResize, resize)
+	p.queueResize()
+}
+
+// GetChildShrink returns whether the given child can be made smaller than its
+// size request.
+func (p *CPaned) GetChildShrink(child Widget) (value bool) {
+	if v, ok := p.GetChildProperty(child, PropertyPanedChildShrink).(bool); ok {
+		value = v
+	}
+	return
+}
+
+// SetChildShrink updates whether the given child can be made smaller than its
+// size request.
+func (p *CPaned) SetChildShrink(child Widget, shrink bool) {
+	p.SetChildProperty(child, PropertyPanedChildShrink, shrink)
+	p.queueResize()
+}
+
+// GetPosition returns the position of the divider between the two panes, which
+// is the size given to the first pane.
+//
+// Locking: read
+func (p *CPaned) GetPosition() (value int) {
+	var err error
+	if value, err = p.GetIntProperty(PropertyPanedPosition); err != nil {
+		p.LogErr(err)
+	}
+	return
+}
+
+// SetPosition sets the position of the divider between the two panes. The
+// position is clamped to the minimum and maximum positions during the next
+// resize.
+//
+// Parameters:
+//
+//	position	divider position, a negative value means that the position is
+//	            unset and the Paned will work out the position from the size
+//	            requests of its children.
+func (p *CPaned) SetPosition(position int) {
+	if position >= 0 {
+		p.setPosition(position)
+		if err := p.SetBoolProperty(PropertyPositionSet, true); err != nil {
+			p.LogErr(err)
+		}
+	} else if err := p.SetBoolProperty(PropertyPositionSet, false); err != nil {
+		p.LogErr(err)
+	}
+	p.queueResize()
+}
+
+// GetPositionSet returns TRUE if the position was set by the program or the
+// user rather than computed from the size requests of the children.
+//
+// Locking: read
+func (p *CPaned) GetPositionSet() (value bool) {
+	var err error
+	if value, err = p.GetBoolProperty(PropertyPositionSet); err != nil {
+		p.LogErr(err)
+	}
+	return
+}
+
+// GetMinPosition returns the smallest possible value for the position
+// property. This is derived from the size request and shrink flag of the first
+// child during the last resize.
+//
+// Locking: read
+func (p *CPaned) GetMinPosition() (value int) {
+	p.RLock()
+	defer p.RUnlock()
+	return p.minPosition
+}
+
+// GetMaxPosition returns the largest possible value for the position
+// property. This is derived from the size request and shrink flag of the
+// second child during the last resize.
+//
+// Locking: read
+func (p *CPaned) GetMaxPosition() (value int) {
+	p.RLock()
+	defer p.RUnlock()
+	return p.maxPosition
+}
+
+// GetHandleFocus returns TRUE if the divider currently has the keyboard focus.
+//
+// Locking: read
+func (p *CPaned) GetHandleFocus() (value bool) {
+	p.RLock()
+	defer p.RUnlock()
+	return p.handleFocus
+}
+
+// GetHandleRegion returns the region of the divider, relative to the origin of
+// the Paned. The region is empty when either of the children is not visible.
+func (p *CPaned) GetHandleRegion() (region ptypes.Region) {
+	child1, child2 := p.GetChild1(), p.GetChild2()
+	if child1 == nil || child2 == nil || !child1.IsVisible() || !child2.IsVisible() {
+		return
+	}
+	alloc := p.GetAllocation()
+	position := p.GetPosition()
+	if p.GetOrientation() == cenums.ORIENTATION_VERTICAL {
+		return ptypes.MakeRegion(0, position, alloc.W, 1)
+	}
+	return ptypes.MakeRegion(position, 0, 1, alloc.H)
+}
+
+// MoveHandle moves the divider as if by the keyboard, emitting the move-handle
+// signal initially and if the listeners return EVENT_PASS, the position is
+// updated and clamped to the minimum and maximum positions.
+//
+// Parameters:
+//
+//	scroll	the ScrollType describing the movement
+//
+// Emits: SignalMoveHandle, Argv=[Paned instance, scroll]
+func (p *CPaned) MoveHandle(scroll enums.ScrollType) cenums.EventFlag {
+	if f := p.Emit(SignalMoveHandle, p, scroll); f == cenums.EVENT_PASS {
+		position := p.GetPosition()
+		minimum, maximum := p.GetMinPosition(), p.GetMaxPosition()
+		switch scroll {
+		case enums.SCROLL_STEP_BACKWARD, enums.SCROLL_STEP_UP, enums.SCROLL_STEP_LEFT:
+			position -= 1
+		case enums.SCROLL_STEP_FORWARD, enums.SCROLL_STEP_DOWN, enums.SCROLL_STEP_RIGHT:
+			position += 1
+		case enums.SCROLL_PAGE_BACKWARD, enums.SCROLL_PAGE_UP, enums.SCROLL_PAGE_LEFT:
+			position -= panedPageStep
+		case enums.SCROLL_PAGE_FORWARD, enums.SCROLL_PAGE_DOWN, enums.SCROLL_PAGE_RIGHT:
+			position += panedPageStep
+		case enums.SCROLL_START:
+			position = minimum
+		case enums.SCROLL_END:
+			position = maximum
+		default:
+			return cenums.EVENT_PASS
+		}
+		if position < minimum {
+			position = minimum
+		} else if position > maximum {
+			position = maximum
+		}
+		p.SetPosition(position)
+	}
+	return cenums.EVENT_STOP
+}
+
+// CancelEvent emits a cancel-event signal and if the signal handlers all return
+// EVENT_PASS, then stops any divider drag in progress and returns the focus to
+// the panes.
+func (p *CPaned) CancelEvent() {
+	if f := p.Emit(SignalCancelEvent, p); f == cenums.EVENT_PASS {
+		p.Lock()
+		p.dragging = false
+		p.handleFocus = false
+		p.Unlock()
+		p.ReleaseEventFocus()
+		p.Invalidate()
+	}
+}
+
+// GetSizeRequest returns the requested size of the Paned, which is the sum of
+// the size requests of the visible children plus the divider along the
+// orientation and the largest of the two across it.
+func (p *CPaned) GetSizeRequest() (width, height int) {
+	rw, rh := p.CContainer.GetSizeRequest()
+	isVertical := p.GetOrientation() == cenums.ORIENTATION_VERTICAL
+	along, across, visible := 0, 0, 0
+	for _, child := range []Widget{p.GetChild1(), p.GetChild2()} {
+		if child == nil || !child.IsVisible() {
+			continue
+		}
+		visible += 1
+		cw, ch := GetGroupedSizeRequest(child)
+		if isVertical {
+			cw, ch = ch, cw
+		}
+		if cw > 0 {
+			along += cw
+		}
+		if ch > across {
+			across = ch
+		}
+	}
+	if visible == 2 {
+		along += 1
+	}
+	if isVertical {
+		along, across = across, along
+	}
+	if rw <= -1 {
+		rw = along
+	}
+	if rh <= -1 {
+		rh = across
+	}
+	return rw, rh
+}
+
+// GetWidgetAt returns the Paned if the given point is on the divider, or the
+// Widget at the given point within either of the panes.
+func (p *CPaned) GetWidgetAt(point *ptypes.Point2I) Widget {
+	if !p.HasPoint(point) || !p.IsVisible() {
+		return nil
+	}
+	self, _ := p.Self().(Widget)
+	origin := p.GetOrigin()
+	local := ptypes.MakePoint2I(point.X-origin.X, point.Y-origin.Y)
+	if region := p.GetHandleRegion(); region.HasPoint(local) {
+		return self
+	}
+	for _, child := range []Widget{p.GetChild1(), p.GetChild2()} {
+		if child != nil && child.IsVisible() && child.HasPoint(point) {
+			if w := child.GetWidgetAt(point); w != nil {
+				return w
+			}
+			return child
+		}
+	}
+	return self
+}
+
+func (p *CPaned) pack(child Widget, resize, shrink, first bool) {
+	p.CContainer.Add(child)
+	p.Lock()
+	if first {
+		p.child1 = child
+	} else {
+		p.child2 = child
+	}
+	p.Unlock()
+	p.SetChildProperty(child, PropertyPanedChildResize, resize)
+	p.SetChildProperty(child, PropertyPanedChildShrink, shrink)
+	p.queueResize()
+}
+
+func (p *CPaned) setPosition(position int) {
+	if err := p.SetIntProperty(PropertyPanedPosition, position); err != nil {
+		p.LogErr(err)
+	}
+}
+
+func (p *CPaned) queueResize() {
+	p.Resize()
+}
+
+// computePosition works out the minimum, maximum and current positions of the
+// divider for the given space, which excludes the divider itself.
+func (p *CPaned) computePosition(space, req1, req2 int, resize1, resize2, shrink1, shrink2 bool) {
+	minimum := 0
+	if !shrink1 {
+		minimum = req1
+	}
+	maximum := space
+	if !shrink2 {
+		maximum = cmath.FloorI(space-req2, 1)
+	}
+	maximum = cmath.FloorI(maximum, minimum)
+
+	p.RLock()
+	lastAllocation := p.lastAllocation
+	p.RUnlock()
+	position := p.GetPosition()
+	if !p.GetPositionSet() {
+		switch {
+		case resize1 && !resize2:
+			position = cmath.FloorI(space-req2, 0)
+		case !resize1 && resize2:
+			position = req1
+		case req1+req2 > 0:
+			position = int(float64(space)*(float64(req1)/float64(req1+req2)) + 0.5)
+		default:
+			position = int(float64(space)*0.5 + 0.5)
+		}
+	} else if lastAllocation > 0 && lastAllocation != space {
+		switch {
+		case resize1 && !resize2:
+			position += space - lastAllocation
+		case !resize1 && resize2:
+		default:
+			position = int(float64(space)*(float64(position)/float64(lastAllocation)) + 0.5)
+		}
+	}
+	if position < minimum {
+		position = minimum
+	} else if position > maximum {
+		position = maximum
+	}
+
+	p.Lock()
+	p.lastAllocation = space
+	p.minPosition = minimum
+	p.maxPosition = maximum
+	p.Unlock()
+	p.setPosition(position)
+}
+
+func (p *CPaned) resize(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	alloc := p.GetAllocation()
+	origin := p.GetOrigin()
+	isVertical := p.GetOrientation() == cenums.ORIENTATION_VERTICAL
+	var visible []Widget
+	for _, child := range []Widget{p.GetChild1(), p.GetChild2()} {
+		if child != nil {
+			if child.IsVisible() {
+				visible = append(visible, child)
+			} else {
+				child.SetAllocation(ptypes.MakeRectangle(0, 0))
+				child.Resize()
+			}
+		}
+	}
+
+	if alloc.W <= 0 || alloc.H <= 0 || len(visible) == 0 {
+		for _, child := range visible {
+			child.SetAllocation(ptypes.MakeRectangle(0, 0))
+			child.Resize()
+		}
+		return cenums.EVENT_PASS
+	}
+
+	if len(visible) == 1 {
+		visible[0].SetOrigin(origin.X, origin.Y)
+		visible[0].SetAllocation(*alloc.NewClone())
+		visible[0].Resize()
+		p.Invalidate()
+		return cenums.EVENT_STOP
+	}
+
+	child1, child2 := visible[0], visible[1]
+	w1, h1 := GetGroupedSizeRequest(child1)
+	w2, h2 := GetGroupedSizeRequest(child2)
+	space, req1, req2 := alloc.W-1, w1, w2
+	if isVertical {
+		space, req1, req2 = alloc.H-1, h1, h2
+	}
+	if space < 0 {
+		space = 0
+	}
+	p.computePosition(
+		space, cmath.FloorI(req1, 0), cmath.FloorI(req2, 0),
+		p.GetChildResize(child1), p.GetChildResize(child2),
+		p.GetChildShrink(child1), p.GetChildShrink(child2),
+	)
+	position := p.GetPosition()
+
+	if isVertical {
+		child1.SetOrigin(origin.X, origin.Y)
+		child1.SetAllocation(ptypes.MakeRectangle(alloc.W, position))
+		child2.SetOrigin(origin.X, origin.Y+position+1)
+		child2.SetAllocation(ptypes.MakeRectangle(alloc.W, space-position))
+	} else {
+		child1.SetOrigin(origin.X, origin.Y)
+		child1.SetAllocation(ptypes.MakeRectangle(position, alloc.H))
+		child2.SetOrigin(origin.X+position+1, origin.Y)
+		child2.SetAllocation(ptypes.MakeRectangle(space-position, alloc.H))
+	}
+	child1.Resize()
+	child2.Resize()
+	p.Invalidate()
+	return cenums.EVENT_STOP
+}
+
+func (p *CPaned) draw(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if surface, ok := argv[1].(*memphis.CSurface); ok {
+		alloc := p.GetAllocation()
+		if !p.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+			p.LogTrace("not visible, zero width or zero height")
+			return cenums.EVENT_PASS
+		}
+
+		theme := p.GetThemeRequest()
+		surface.Fill(theme)
+
+		for _, child := range []Widget{p.GetChild1(), p.GetChild2()} {
+			if child != nil && child.IsVisible() {
+				child.Draw()
+				child.LockDraw()
+				if err := surface.Composite(child.ObjectID()); err != nil {
+					p.LogError("composite error: %v", err)
+				}
+				child.UnlockDraw()
+			}
+		}
+
+		if region := p.GetHandleRegion(); region.W > 0 && region.H > 0 {
+			p.RLock()
+			active := p.handleFocus || p.dragging
+			p.RUnlock()
+			style := theme.Border.Normal
+			if !p.IsSensitive() {
+				style = theme.Border.Insensitive
+			} else if active {
+				style = theme.Border.Selected
+			}
+			r := theme.Border.BorderRunes.Left
+			if p.GetOrientation() == cenums.ORIENTATION_VERTICAL {
+				r = theme.Border.BorderRunes.Top
+			}
+			for y := region.Y; y < region.Y+region.H; y++ {
+				for x := region.X; x < region.X+region.W; x++ {
+					_ = surface.SetRune(x, y, r, style)
+				}
+			}
+		}
+
+		if debug, _ := p.GetBoolProperty(cdk.PropertyDebug); debug {
+			surface.DebugBox(paint.ColorSilver, p.ObjectInfo())
+		}
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+// hasFocusWithin returns true if the Window focus is a descendant of this
+// Paned and not within a nested Paned.
+func (p *CPaned) hasFocusWithin() bool {
+	if window := p.GetWindow(); window != nil {
+		for focus := window.GetFocus(); focus != nil; focus = focus.GetParent() {
+			if focus.ObjectID() == p.ObjectID() {
+				return true
+			}
+			if _, ok := focus.Self().(Paned); ok {
+				return false
+			}
+			if parent := focus.GetParent(); parent == nil || parent.ObjectID() == focus.ObjectID() {
+				break
+			}
+		}
+	}
+	return false
+}
+
+// setHandleFocus gives or takes the keyboard focus of the divider, remembering
+// the divider position when given the focus so that it can be restored with
+// Escape.
+func (p *CPaned) setHandleFocus(focused bool) {
+	position := p.GetPosition()
+	p.Lock()
+	p.handleFocus = focused
+	if focused {
+		p.origPosition = position
+	}
+	p.Unlock()
+	p.Invalidate()
+}
+
+// acceptPosition takes the focus from the divider, keeping its position.
+//
+// Emits: SignalAcceptPosition, Argv=[Paned instance]
+func (p *CPaned) acceptPosition() cenums.EventFlag {
+	if f := p.Emit(SignalAcceptPosition, p); f == cenums.EVENT_PASS {
+		p.setHandleFocus(false)
+	}
+	return cenums.EVENT_STOP
+}
+
+// cancelPosition takes the focus from the divider, restoring the position it
+// had when given the focus.
+//
+// Emits: SignalCancelPosition, Argv=[Paned instance]
+func (p *CPaned) cancelPosition() cenums.EventFlag {
+	if f := p.Emit(SignalCancelPosition, p); f == cenums.EVENT_PASS {
+		p.RLock()
+		position := p.origPosition
+		p.RUnlock()
+		if position > -1 {
+			p.SetPosition(position)
+		}
+		p.setHandleFocus(false)
+	}
+	return cenums.EVENT_STOP
+}
+
+func (p *CPaned) windowEventKey(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if len(argv) < 2 || !p.IsVisible() || !p.IsSensitive() {
+		return cenums.EVENT_PASS
+	}
+	if e, ok := argv[1].(*cdk.EventKey); ok {
+		if !p.hasFocusWithin() {
+			if p.GetHandleFocus() {
+				p.setHandleFocus(false)
+			}
+			return cenums.EVENT_PASS
+		}
+		if p.GetHandleFocus() {
+			return p.processKeyEvent(e)
+		}
+		if e.Key() == cdk.KeyF8 && p.GetHandleRegion().W > 0 {
+			p.setHandleFocus(true)
+			return cenums.EVENT_STOP
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (p *CPaned) processKeyEvent(e *cdk.EventKey) cenums.EventFlag {
+	if !p.GetHandleFocus() {
+		return cenums.EVENT_PASS
+	}
+	isVertical := p.GetOrientation() == cenums.ORIENTATION_VERTICAL
+	paged := e.Modifiers().Has(cdk.ModCtrl)
+	switch e.Key() {
+	case cdk.KeyLeft:
+		if !isVertical {
+			if paged {
+				return p.MoveHandle(enums.SCROLL_PAGE_LEFT)
+			}
+			return p.MoveHandle(enums.SCROLL_STEP_LEFT)
+		}
+	case cdk.KeyRight:
+		if !isVertical {
+			if paged {
+				return p.MoveHandle(enums.SCROLL_PAGE_RIGHT)
+			}
+			return p.MoveHandle(enums.SCROLL_STEP_RIGHT)
+		}
+	case cdk.KeyUp:
+		if isVertical {
+			if paged {
+				return p.MoveHandle(enums.SCROLL_PAGE_UP)
+			}
+			return p.MoveHandle(enums.SCROLL_STEP_UP)
+		}
+	case cdk.KeyDown:
+		if isVertical {
+			if paged {
+				return p.MoveHandle(enums.SCROLL_PAGE_DOWN)
+			}
+			return p.MoveHandle(enums.SCROLL_STEP_DOWN)
+		}
+	case cdk.KeyHome:
+		return p.MoveHandle(enums.SCROLL_START)
+	case cdk.KeyEnd:
+		return p.MoveHandle(enums.SCROLL_END)
+	case cdk.KeyF8:
+		return p.acceptPosition()
+	case cdk.KeyEscape:
+		return p.cancelPosition()
+	default:
+		switch e.Rune() {
+		case 10, 13:
+			return p.acceptPosition()
+		}
+	}
+	// the divider keeps the keyboard to itself while focused
+	return cenums.EVENT_STOP
+}
+
+func (p *CPaned) processMouseEvent(e *cdk.EventMouse) cenums.EventFlag {
+	point := ptypes.NewPoint2I(e.Position())
+	origin := p.GetOrigin()
+	local := ptypes.MakePoint2I(point.X-origin.X, point.Y-origin.Y)
+	isVertical := p.GetOrientation() == cenums.ORIENTATION_VERTICAL
+	offset := local.X
+	if isVertical {
+		offset = local.Y
+	}
+	p.RLock()
+	dragging := p.dragging
+	p.RUnlock()
+	switch e.State() {
+	case cdk.BUTTON_PRESS, cdk.DRAG_START:
+		if dragging {
+			return p.dragTo(offset)
+		}
+		if region := p.GetHandleRegion(); e.Button().Has(cdk.Button1) && region.HasPoint(local) {
+			p.Lock()
+			p.dragging = true
+			p.dragOffset = offset - p.GetPosition()
+			p.Unlock()
+			p.GrabEventFocus()
+			p.Invalidate()
+			return cenums.EVENT_STOP
+		}
+	case cdk.MOUSE_MOVE, cdk.DRAG_MOVE:
+		if dragging {
+			return p.dragTo(offset)
+		}
+	case cdk.BUTTON_RELEASE, cdk.DRAG_STOP:
+		if dragging {
+			p.Lock()
+			p.dragging = false
+			p.Unlock()
+			if p.HasEventFocus() {
+				p.ReleaseEventFocus()
+			}
+			p.Invalidate()
+			return cenums.EVENT_STOP
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+// dragTo moves the divider so that it follows the mouse pointer at the given
+// offset along the orientation of the Paned.
+func (p *CPaned) dragTo(offset int) cenums.EventFlag {
+	p.RLock()
+	position := offset - p.dragOffset
+	p.RUnlock()
+	if minimum := p.GetMinPosition(); position < minimum {
+		position = minimum
+	} else if maximum := p.GetMaxPosition(); position > maximum {
+		position = maximum
+	}
+	if position != p.GetPosition() {
+		p.SetPosition(position)
+	}
+	return cenums.EVENT_STOP
+}
+
+func (p *CPaned) event(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if !p.IsSensitive() {
+		return cenums.EVENT_PASS
+	}
+	if evt, ok := argv[1].(cdk.Event); ok {
+		switch e := evt.(type) {
+		case *cdk.EventMouse:
+			return p.processMouseEvent(e)
+		case *cdk.EventKey:
+			return p.processKeyEvent(e)
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+// Position of paned separator in cells (0 means all the way to the left/top).
+// Flags: Read / Write
+// Allowed values: >= 0
+// Default value: 0
+const PropertyPanedPosition cdk.Property = "position"
+
+// TRUE if the Position property should be used.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyPositionSet cdk.Property = "position-set"
+
+// If TRUE, the child expands and shrinks along with the paned widget.
+// Flags: Read / Write
+// Default value: TRUE
+const PropertyPanedChildResize cdk.Property = "paned-child--resize"
+
+// If TRUE, the child can be made smaller than its requisition.
+// Flags: Read / Write
+// Default value: TRUE
+const PropertyPanedChildShrink cdk.Property = "paned-child--shrink"
+
+// The ::accept-position signal is emitted when the divider loses the keyboard
+// focus with Enter or F8, keeping its new position.
+// Listener function arguments:
+//
+//	paned Paned	the Paned instance
+const SignalAcceptPosition cdk.Signal = "accept-position"
+
+// The ::cancel-position signal is emitted when the divider loses the keyboard
+// focus with Escape, restoring the position it had when it was focused.
+// Listener function arguments:
+//
+//	paned Paned	the Paned instance
+const SignalCancelPosition cdk.Signal = "cancel-position"
+
+// The ::move-handle signal is emitted when the divider is moved with the
+// keyboard.
+// Listener function arguments:
+//
+//	paned Paned	the Paned instance
+//	scrollType enums.ScrollType	the movement requested
+const SignalMoveHandle cdk.Signal = "move-handle"
+
+const PanedEventHandle = "paned-event-handler"
+
+const PanedResizeHandle = "paned-resize-handler"
+
+const PanedDrawHandle = "paned-draw-handler"
+
+const PanedWindowEventHandle = "paned-window-event-handler"
@@ -0,0 +1,252 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"testing"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/ptypes"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+func TestPaned(t *testing.T) {
+	Convey("Testing Paned", t, func() {
+		makeWindow := func(w, h int) Window {
+			window := NewWindow()
+			window.SetDecorated(false)
+			window.Show()
+			window.SetAllocation(ptypes.MakeRectangle(w, h))
+			return window
+		}
+
+		Convey("Basics", func() {
+			p := &CPaned{}
+			So(p.Init(), ShouldEqual, false)
+			So(p.Init(), ShouldEqual, true)
+			hp, vp := NewHPaned(), NewVPaned()
+			So(hp.GetOrientation(), ShouldEqual, cenums.ORIENTATION_HORIZONTAL)
+			So(vp.GetOrientation(), ShouldEqual, cenums.ORIENTATION_VERTICAL)
+			one, two, three := NewLabel("one"), NewLabel("two"), NewLabel("three")
+			hp.Add(one)
+			hp.Add(two)
+			hp.Add(three)
+			So(hp.GetChildren(), ShouldHaveLength, 2)
+			So(hp.GetChild1(), ShouldEqual, one)
+			So(hp.GetChild2(), ShouldEqual, two)
+			So(hp.GetChildResize(one), ShouldEqual, false)
+			So(hp.GetChildShrink(one), ShouldEqual, true)
+			So(hp.GetChildResize(two), ShouldEqual, true)
+			So(hp.GetChildShrink(two), ShouldEqual, true)
+			hp.Remove(one)
+			So(hp.GetChild1(), ShouldBeNil)
+			hp.Pack1(three, true, false)
+			So(hp.GetChild1(), ShouldEqual, three)
+			So(hp.GetChildResize(three), ShouldEqual, true)
+			So(hp.GetChildShrink(three), ShouldEqual, false)
+			one.Show()
+			two.Show()
+			three.Show()
+			w, h := hp.GetSizeRequest()
+			So(w, ShouldEqual, 9)
+			So(h, ShouldEqual, 1)
+			w, h = vp.GetSizeRequest()
+			So(w, ShouldEqual, 0)
+			So(h, ShouldEqual, 0)
+		})
+
+		Convey("Layout", func() {
+			window := makeWindow(21, 5)
+			left, right := NewLabel("left"), NewLabel("right")
+			left.Show()
+			right.Show()
+			paned := NewHPaned()
+			paned.Show()
+			paned.Add1(left)
+			paned.Add2(right)
+			window.GetVBox().PackStart(paned, true, true, 0)
+			window.Resize()
+			So(paned.GetPosition(), ShouldEqual, 4)
+			So(paned.GetPositionSet(), ShouldEqual, false)
+			So(paned.GetMinPosition(), ShouldEqual, 0)
+			So(paned.GetMaxPosition(), ShouldEqual, 20)
+			So(left.GetAllocation().W, ShouldEqual, 4)
+			So(right.GetOrigin().X, ShouldEqual, 5)
+			So(right.GetAllocation().W, ShouldEqual, 16)
+			So(paned.GetHandleRegion(), ShouldResemble, ptypes.MakeRegion(4, 0, 1, 5))
+			So(paned.GetWidgetAt(ptypes.NewPoint2I(4, 2)), ShouldEqual, paned)
+			So(paned.GetWidgetAt(ptypes.NewPoint2I(6, 0)), ShouldEqual, right)
+
+			paned.SetPosition(10)
+			So(paned.GetPositionSet(), ShouldEqual, true)
+			So(left.GetAllocation().W, ShouldEqual, 10)
+			So(right.GetOrigin().X, ShouldEqual, 11)
+			window.SetAllocation(ptypes.MakeRectangle(31, 5))
+			window.Resize()
+			So(paned.GetPosition(), ShouldEqual, 10)
+			So(right.GetAllocation().W, ShouldEqual, 20)
+
+			paned.SetChildResize(left, true)
+			paned.SetChildResize(right, false)
+			window.SetAllocation(ptypes.MakeRectangle(21, 5))
+			window.Resize()
+			So(paned.GetPosition(), ShouldEqual, 0)
+			paned.SetChildShrink(left, false)
+			So(paned.GetPosition(), ShouldEqual, 4)
+
+			right.Hide()
+			So(left.GetAllocation().W, ShouldEqual, 21)
+			So(paned.GetHandleRegion().W, ShouldEqual, 0)
+		})
+
+		Convey("Keyboard", func() {
+			window := makeWindow(21, 5)
+			left, entry := NewLabel("left"), NewEntry("")
+			left.Show()
+			entry.Show()
+			entry.SetSizeRequest(6, 1)
+			paned := NewHPaned()
+			paned.Show()
+			paned.Pack1(left, false, true)
+			paned.Pack2(entry, true, false)
+			window.GetVBox().PackStart(paned, true, true, 0)
+			window.Resize()
+			window.SetFocus(entry)
+			So(paned.GetPosition(), ShouldEqual, 4)
+			So(paned.GetHandleFocus(), ShouldEqual, false)
+
+			moves := 0
+			paned.Connect(SignalMoveHandle, "test-paned-move-handle", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				moves += 1
+				return cenums.EVENT_PASS
+			})
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyF8, 0, cdk.ModNone))
+			So(paned.GetHandleFocus(), ShouldEqual, true)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRight, 0, cdk.ModNone))
+			So(paned.GetPosition(), ShouldEqual, 5)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRight, 0, cdk.ModCtrl))
+			So(paned.GetPosition(), ShouldEqual, 10)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyUp, 0, cdk.ModNone))
+			So(paned.GetPosition(), ShouldEqual, 10)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyEnd, 0, cdk.ModNone))
+			So(paned.GetPosition(), ShouldEqual, 14)
+			So(paned.GetMaxPosition(), ShouldEqual, 14)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyHome, 0, cdk.ModNone))
+			So(paned.GetPosition(), ShouldEqual, 0)
+			So(moves, ShouldEqual, 4)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyEscape, 0, cdk.ModNone))
+			So(paned.GetHandleFocus(), ShouldEqual, false)
+			So(paned.GetPosition(), ShouldEqual, 4)
+
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyF8, 0, cdk.ModNone))
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyLeft, 0, cdk.ModNone))
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, rune(cdk.KeyEnter), cdk.ModNone))
+			So(paned.GetHandleFocus(), ShouldEqual, false)
+			So(paned.GetPosition(), ShouldEqual, 3)
+			So(left.GetAllocation().W, ShouldEqual, 3)
+		})
+
+		Convey("Mouse", func() {
+			window := makeWindow(21, 5)
+			left, right := NewLabel("left"), NewLabel("right")
+			left.Show()
+			right.Show()
+			paned := NewHPaned()
+			paned.Show()
+			paned.Add1(left)
+			paned.Add2(right)
+			window.GetVBox().PackStart(paned, true, true, 0)
+			window.Resize()
+			paned.ProcessEvent(cdk.NewEventMouse(0, 4, cdk.ButtonNone, cdk.ModNone))
+			So(paned.ProcessEvent(cdk.NewEventMouse(8, 1, cdk.Button1, cdk.ModNone)), ShouldEqual, cenums.EVENT_PASS)
+			paned.ProcessEvent(cdk.NewEventMouse(8, 1, cdk.ButtonNone, cdk.ModNone))
+			So(paned.GetPosition(), ShouldEqual, 4)
+
+			So(paned.ProcessEvent(cdk.NewEventMouse(4, 1, cdk.Button1, cdk.ModNone)), ShouldEqual, cenums.EVENT_STOP)
+			paned.ProcessEvent(cdk.NewEventMouse(6, 1, cdk.Button1, cdk.ModNone))
+			So(paned.GetPosition(), ShouldEqual, 6)
+			paned.ProcessEvent(cdk.NewEventMouse(12, 2, cdk.Button1, cdk.ModNone))
+			So(paned.GetPosition(), ShouldEqual, 12)
+			So(right.GetOrigin().X, ShouldEqual, 13)
+			paned.ProcessEvent(cdk.NewEventMouse(40, 2, cdk.Button1, cdk.ModNone))
+			So(paned.GetPosition(), ShouldEqual, 20)
+			paned.ProcessEvent(cdk.NewEventMouse(40, 2, cdk.ButtonNone, cdk.ModNone))
+			paned.ProcessEvent(cdk.NewEventMouse(2, 2, cdk.ButtonNone, cdk.ModNone))
+			So(paned.GetPosition(), ShouldEqual, 20)
+		})
+
+		Convey("Vertical", func() {
+			window := makeWindow(10, 11)
+			top, bottom := NewLabel("top"), NewLabel("bottom")
+			top.Show()
+			bottom.Show()
+			paned := NewVPaned()
+			paned.Show()
+			paned.Pack1(top, true, false)
+			paned.Pack2(bottom, true, false)
+			window.GetVBox().PackStart(paned, true, true, 0)
+			window.Resize()
+			So(paned.GetPosition(), ShouldEqual, 5)
+			So(paned.GetMinPosition(), ShouldEqual, 1)
+			So(paned.GetMaxPosition(), ShouldEqual, 9)
+			So(bottom.GetOrigin().Y, ShouldEqual, 6)
+			So(bottom.GetAllocation().H, ShouldEqual, 5)
+			paned.MoveHandle(enums.SCROLL_END)
+			So(paned.GetPosition(), ShouldEqual, 9)
+			So(bottom.GetAllocation().H, ShouldEqual, 1)
+			paned.MoveHandle(enums.SCROLL_PAGE_UP)
+			So(paned.GetPosition(), ShouldEqual, 4)
+		})
+
+		Convey("Builder", func() {
+			builder := NewBuilder()
+			_, err := builder.LoadFromString(`<interface>
+  <object class="GtkPaned" id="test-paned">
+    <property name="orientation">vertical</property>
+    <property name="position">3</property>
+    <child>
+      <object class="GtkLabel" id="test-paned-top">
+        <property name="label">top</property>
+      </object>
+      <packing>
+        <property name="resize">True</property>
+        <property name="shrink">False</property>
+      </packing>
+    </child>
+    <child>
+      <object class="GtkLabel" id="test-paned-bottom">
+        <property name="label">bottom</property>
+      </object>
+    </child>
+  </object>
+</interface>`)
+			So(err, ShouldBeNil)
+			paned, ok := builder.GetWidget("test-paned").(Paned)
+			So(ok, ShouldEqual, true)
+			So(paned.GetOrientation(), ShouldEqual, cenums.ORIENTATION_VERTICAL)
+			So(paned.GetPosition(), ShouldEqual, 3)
+			So(paned.GetPositionSet(), ShouldEqual, true)
+			top, bottom := paned.GetChild1(), paned.GetChild2()
+			So(top, ShouldNotBeNil)
+			So(bottom, ShouldNotBeNil)
+			So(paned.GetChildResize(top), ShouldEqual, true)
+			So(paned.GetChildShrink(top), ShouldEqual, false)
+			So(paned.GetChildResize(bottom), ShouldEqual, true)
+		})
+	})
+}
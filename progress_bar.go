@@ -0,0 +1,725 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	cmath "github.com/go-curses/cdk/lib/math"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/go-curses/cdk/lib/ptypes"
+	"github.com/go-curses/cdk/memphis"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeProgressBar cdk.CTypeTag = "ctk-progress-bar"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeProgressBar, func() interface{} { return MakeProgressBar() })
+	ctkBuilderTranslators[TypeProgressBar] = func(builder Builder, widget Widget, name, value string) error {
+		switch strings.ToLower(name) {
+		case "orientation":
+			if orientation, err := enums.ProgressBarOrientation(0).FromString(value); err != nil {
+				return err
+			} else {
+				return widget.SetStructProperty(PropertyOrientation, orientation)
+			}
+		case "bar-style", "bar_style":
+			if style, err := enums.ProgressBarStyle(0).FromString(value); err != nil {
+				return err
+			} else {
+				return widget.SetStructProperty(PropertyBarStyle, style)
+			}
+		}
+		return ErrFallthrough
+	}
+}
+
+// ProgressBar Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- ProgressBar
+//
+// The ProgressBar is typically used to display the progress of a long running
+// operation. It provides a visual clue that processing is underway. The
+// ProgressBar can be used in two different modes: percentage mode and activity
+// mode.
+//
+// When an application can determine how much work needs to take place (e.g.
+// read a fixed number of bytes from a file) and can monitor its progress, it
+// can use the ProgressBar in percentage mode and the user sees a growing bar
+// indicating the percentage of the work that has been completed. In this mode,
+// the application is required to call SetFraction periodically to update the
+// progress bar.
+//
+// When an application has no accurate way of knowing the amount of work to do,
+// it can use the ProgressBar in activity mode, which shows activity by a block
+// moving back and forth within the progress area. In this mode, the
+// application is required to call Pulse periodically to update the progress
+// bar, or use StartPulsing to have the ProgressBar pulse itself on a timer
+// until StopPulsing is called.
+//
+// With the default PROGRESS_CONTINUOUS bar style and the default fill
+// character, the end of the bar is drawn with Unicode block elements for
+// sub-cell precision. The PROGRESS_DISCRETE bar style only ever fills whole
+// cells. The fill and empty characters can be themed with the
+// "fill-character" and "empty-character" CSS properties, given as quoted
+// strings within style sheets (ie: fill-character: "=";).
+type ProgressBar interface {
+	Widget
+	Buildable
+
+	Init() (already bool)
+	Pulse()
+	StartPulsing()
+	StopPulsing()
+	IsPulsing() (pulsing bool)
+	GetActivityMode() (activityMode bool)
+	SetText(text string)
+	GetText() (text string)
+	SetShowText(showText bool)
+	GetShowText() (showText bool)
+	SetFraction(fraction float64)
+	GetFraction() (fraction float64)
+	SetPulseStep(fraction float64)
+	GetPulseStep() (fraction float64)
+	SetOrientation(orientation enums.ProgressBarOrientation)
+	GetOrientation() (orientation enums.ProgressBarOrientation)
+	SetBarStyle(style enums.ProgressBarStyle)
+	GetBarStyle() (style enums.ProgressBarStyle)
+	SetActivityBlocks(blocks int)
+	GetActivityBlocks() (blocks int)
+	GetFillCharacter() (r rune)
+	GetEmptyCharacter() (r rune)
+	GetSizeRequest() (width, height int)
+}
+
+var _ ProgressBar = (*CProgressBar)(nil)
+
+// The CProgressBar structure implements the ProgressBar interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with ProgressBar objects.
+type CProgressBar struct {
+	CWidget
+
+	pulsePosition float64
+	pulseBackward bool
+
+	ticker  *time.Ticker
+	stopped chan bool
+}
+
+// MakeProgressBar is used by the Buildable system to construct a new
+// ProgressBar.
+func MakeProgressBar() ProgressBar {
+	return NewProgressBar()
+}
+
+// NewProgressBar is the constructor for new ProgressBar instances.
+func NewProgressBar() ProgressBar {
+	p := new(CProgressBar)
+	p.Init()
+	return p
+}
+
+// Init initializes a ProgressBar object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the ProgressBar instance. Init is used in the
+// NewProgressBar constructor and only necessary when implementing a derivative
+// ProgressBar type.
+func (p *CProgressBar) Init() (already bool) {
+	if p.InitTypeItem(TypeProgressBar, p) {
+		return true
+	}
+	p.CWidget.Init()
+	p.flags = enums.NULL_WIDGET_FLAG
+	p.SetFlags(enums.PARENT_SENSITIVE)
+	p.SetFlags(enums.APP_PAINTABLE)
+	p.pulsePosition = 0.0
+	p.pulseBackward = false
+	p.ticker = nil
+	_ = p.InstallBuildableProperty(PropertyFraction, cdk.FloatProperty, true, 0.0)
+	_ = p.InstallBuildableProperty(PropertyPulseStep, cdk.FloatProperty, true, 0.1)
+	_ = p.InstallBuildableProperty(PropertyText, cdk.StringProperty, true, "")
+	_ = p.InstallBuildableProperty(PropertyShowText, cdk.BoolProperty, true, false)
+	_ = p.InstallBuildableProperty(PropertyActivityMode, cdk.BoolProperty, true, false)
+	_ = p.InstallBuildableProperty(PropertyActivityBlocks, cdk.IntProperty, true, 5)
+	_ = p.InstallBuildableProperty(PropertyOrientation, cdk.StructProperty, true, enums.PROGRESS_LEFT_TO_RIGHT)
+	_ = p.InstallBuildableProperty(PropertyBarStyle, cdk.StructProperty, true, enums.PROGRESS_CONTINUOUS)
+	for _, state := range []enums.StateType{enums.StateNormal, enums.StateActive, enums.StatePrelight, enums.StateSelected, enums.StateInsensitive} {
+		_ = p.InstallCssProperty(CssPropertyFillCharacter, state, cdk.StringProperty, true, string(ProgressBarFillRune))
+		_ = p.InstallCssProperty(CssPropertyEmptyCharacter, state, cdk.StringProperty, true, string(ProgressBarEmptyRune))
+	}
+	p.Connect(SignalResize, ProgressBarResizeHandle, p.resize)
+	p.Connect(SignalDraw, ProgressBarDrawHandle, p.draw)
+	return false
+}
+
+// Pulse indicates that some progress is made, but you don't know how much.
+// Causes the progress bar to enter "activity mode", where a block bounces back
+// and forth. Each call to Pulse causes the block to move by a little bit (the
+// amount of movement per pulse is determined by SetPulseStep).
+func (p *CProgressBar) Pulse() {
+	if !p.GetActivityMode() {
+		p.Lock()
+		p.pulsePosition = 0.0
+		p.pulseBackward = false
+		p.Unlock()
+		if err := p.SetBoolProperty(PropertyActivityMode, true); err != nil {
+			p.LogErr(err)
+		}
+	}
+	step := p.GetPulseStep()
+	p.Lock()
+	if p.pulseBackward {
+		if p.pulsePosition -= step; p.pulsePosition <= 0.0 {
+			p.pulsePosition = 0.0
+			p.pulseBackward = false
+		}
+	} else {
+		if p.pulsePosition += step; p.pulsePosition >= 1.0 {
+			p.pulsePosition = 1.0
+			p.pulseBackward = true
+		}
+	}
+	p.Unlock()
+	p.Invalidate()
+}
+
+// StartPulsing starts a timer which calls Pulse periodically, requesting the
+// display to redraw after each pulse. Does nothing if the timer is already
+// running.
+func (p *CProgressBar) StartPulsing() {
+	if p.IsPulsing() {
+		return
+	}
+	p.Lock()
+	p.ticker = time.NewTicker(ProgressBarPulseInterval)
+	p.stopped = make(chan bool)
+	ticker, stopped := p.ticker, p.stopped
+	p.Unlock()
+	p.Pulse()
+	cdk.Go(func() {
+		for {
+			select {
+			case <-stopped:
+				return
+			case <-ticker.C:
+				p.Pulse()
+				if d := p.GetDisplay(); d != nil {
+					d.RequestDraw()
+					d.RequestShow()
+				}
+			}
+		}
+	})
+}
+
+// StopPulsing stops the timer started with StartPulsing. The ProgressBar
+// remains in activity mode until SetFraction is called.
+func (p *CProgressBar) StopPulsing() {
+	p.Lock()
+	defer p.Unlock()
+	if p.ticker != nil {
+		p.ticker.Stop()
+		p.ticker = nil
+		close(p.stopped)
+	}
+}
+
+// IsPulsing returns TRUE if the pulse timer started with StartPulsing is
+// running.
+func (p *CProgressBar) IsPulsing() (pulsing bool) {
+	p.RLock()
+	defer p.RUnlock()
+	return p.ticker != nil
+}
+
+// GetActivityMode returns TRUE if the progress bar is in activity mode, ie:
+// Pulse has been called since the last call to SetFraction.
+func (p *CProgressBar) GetActivityMode() (activityMode bool) {
+	var err error
+	if activityMode, err = p.GetBoolProperty(PropertyActivityMode); err != nil {
+		p.LogErr(err)
+	}
+	return
+}
+
+// SetText causes the given text to appear superimposed on the progress bar
+// when the show-text property is TRUE.
+//
+// Parameters:
+//
+//	text	a UTF-8 string, or empty
+func (p *CProgressBar) SetText(text string) {
+	if err := p.SetStringProperty(PropertyText, text); err != nil {
+		p.LogErr(err)
+	} else {
+		p.Invalidate()
+	}
+}
+
+// GetText retrieves the text displayed superimposed on the progress bar, if
+// any.
+func (p *CProgressBar) GetText() (text string) {
+	var err error
+	if text, err = p.GetStringProperty(PropertyText); err != nil {
+		p.LogErr(err)
+	}
+	return
+}
+
+// SetShowText sets whether the progress bar shows text centred on the bar. The
+// shown text is either the value of the text property or, if that is empty,
+// the fraction value as a percentage. Text is only shown with horizontal
+// orientations.
+//
+// Parameters:
+//
+//	showText	whether to show superimposed text
+func (p *CProgressBar) SetShowText(showText bool) {
+	if err := p.SetBoolProperty(PropertyShowText, showText); err != nil {
+		p.LogErr(err)
+	} else {
+		p.Invalidate()
+	}
+}
+
+// GetShowText returns whether the progress bar shows text.
+// See: SetShowText()
+func (p *CProgressBar) GetShowText() (showText bool) {
+	var err error
+	if showText, err = p.GetBoolProperty(PropertyShowText); err != nil {
+		p.LogErr(err)
+	}
+	return
+}
+
+// SetFraction causes the progress bar to "fill in" the given fraction of the
+// bar. The fraction should be between 0.0 and 1.0, inclusive. Calling
+// SetFraction leaves activity mode.
+//
+// Parameters:
+//
+//	fraction	fraction of the task that's been completed
+func (p *CProgressBar) SetFraction(fraction float64) {
+	if err := p.SetFloatProperty(PropertyFraction, cmath.ClampF(fraction, 0.0, 1.0)); err != nil {
+		p.LogErr(err)
+	}
+	if err := p.SetBoolProperty(PropertyActivityMode, false); err != nil {
+		p.LogErr(err)
+	}
+	p.Invalidate()
+}
+
+// GetFraction returns the current fraction of the task that's been completed.
+func (p *CProgressBar) GetFraction() (fraction float64) {
+	var err error
+	if fraction, err = p.GetFloatProperty(PropertyFraction); err != nil {
+		p.LogErr(err)
+	}
+	return
+}
+
+// SetPulseStep sets the fraction of total progress bar length to move the
+// bouncing block for each call to Pulse.
+//
+// Parameters:
+//
+//	fraction	fraction between 0.0 and 1.0
+func (p *CProgressBar) SetPulseStep(fraction float64) {
+	if err := p.SetFloatProperty(PropertyPulseStep, cmath.ClampF(fraction, 0.0, 1.0)); err != nil {
+		p.LogErr(err)
+	}
+}
+
+// GetPulseStep retrieves the pulse step set with SetPulseStep.
+func (p *CProgressBar) GetPulseStep() (fraction float64) {
+	var err error
+	if fraction, err = p.GetFloatProperty(PropertyPulseStep); err != nil {
+		p.LogErr(err)
+	}
+	return
+}
+
+// SetOrientation causes the progress bar to switch to a different orientation
+// (left-to-right, right-to-left, top-to-bottom, or bottom-to-top).
+//
+// Parameters:
+//
+//	orientation	orientation of the progress bar
+func (p *CProgressBar) SetOrientation(orientation enums.ProgressBarOrientation) {
+	if err := p.SetStructProperty(PropertyOrientation, orientation); err != nil {
+		p.LogErr(err)
+	} else {
+		p.Resize()
+	}
+}
+
+// GetOrientation retrieves the current progress bar orientation.
+func (p *CProgressBar) GetOrientation() (orientation enums.ProgressBarOrientation) {
+	var ok bool
+	if v, err := p.GetStructProperty(PropertyOrientation); err != nil {
+		p.LogErr(err)
+	} else if orientation, ok = v.(enums.ProgressBarOrientation); !ok {
+		p.LogError("invalid value stored in %v: %v (%T)", PropertyOrientation, v, v)
+	}
+	return
+}
+
+// SetBarStyle updates the style of the progress bar. PROGRESS_CONTINUOUS draws
+// the end of the bar with sub-cell precision while PROGRESS_DISCRETE only ever
+// fills whole cells.
+//
+// Parameters:
+//
+//	style	the progress bar style
+func (p *CProgressBar) SetBarStyle(style enums.ProgressBarStyle) {
+	if err := p.SetStructProperty(PropertyBarStyle, style); err != nil {
+		p.LogErr(err)
+	} else {
+		p.Invalidate()
+	}
+}
+
+// GetBarStyle retrieves the current progress bar style.
+// See: SetBarStyle()
+func (p *CProgressBar) GetBarStyle() (style enums.ProgressBarStyle) {
+	var ok bool
+	if v, err := p.GetStructProperty(PropertyBarStyle); err != nil {
+		p.LogErr(err)
+	} else if style, ok = v.(enums.ProgressBarStyle); !ok {
+		p.LogError("invalid value stored in %v: %v (%T)", PropertyBarStyle, v, v)
+	}
+	return
+}
+
+// SetActivityBlocks sets the number of blocks which can fit in the progress
+// bar area in activity mode, ie: the bouncing block is 1/blocks the length of
+// the progress bar.
+//
+// Parameters:
+//
+//	blocks	number of blocks, two or more
+func (p *CProgressBar) SetActivityBlocks(blocks int) {
+	if err := p.SetIntProperty(PropertyActivityBlocks, cmath.ClampI(blocks, 2, math.MaxInt32)); err != nil {
+		p.LogErr(err)
+	} else {
+		p.Invalidate()
+	}
+}
+
+// GetActivityBlocks returns the number of blocks which can fit in the progress
+// bar area in activity mode.
+// See: SetActivityBlocks()
+func (p *CProgressBar) GetActivityBlocks() (blocks int) {
+	var err error
+	if blocks, err = p.GetIntProperty(PropertyActivityBlocks); err != nil {
+		p.LogErr(err)
+	}
+	return
+}
+
+// GetFillCharacter returns the rune used to draw the completed portion of the
+// progress bar, as set by the "fill-character" CSS property for the current
+// state of the ProgressBar.
+func (p *CProgressBar) GetFillCharacter() (r rune) {
+	return p.getCssRune(CssPropertyFillCharacter, ProgressBarFillRune)
+}
+
+// GetEmptyCharacter returns the rune used to draw the remaining portion of the
+// progress bar, as set by the "empty-character" CSS property for the current
+// state of the ProgressBar.
+func (p *CProgressBar) GetEmptyCharacter() (r rune) {
+	return p.getCssRune(CssPropertyEmptyCharacter, ProgressBarEmptyRune)
+}
+
+// GetSizeRequest returns the requested size of the Drawable Widget. This method
+// is used by Container Widgets to resolve the surface space allocated for their
+// child Widget instances.
+//
+// Horizontal progress bars request a height of one and a width of at least ten
+// cells, or the width of the text shown. Vertical progress bars request a width
+// of one and a height of at least five cells.
+func (p *CProgressBar) GetSizeRequest() (width, height int) {
+	size := ptypes.NewRectangle(p.CWidget.GetSizeRequest())
+	switch p.GetOrientation() {
+	case enums.PROGRESS_BOTTOM_TO_TOP, enums.PROGRESS_TOP_TO_BOTTOM:
+		if size.W <= -1 {
+			size.W = 1
+		}
+		if size.H <= -1 {
+			size.H = 5
+		}
+	default:
+		if size.W <= -1 {
+			size.W = 10
+			if text := p.getDisplayText(); text != "" {
+				size.W = max(size.W, runewidth.StringWidth(text)+2)
+			}
+		}
+		if size.H <= -1 {
+			size.H = 1
+		}
+	}
+	size.Floor(1, 1)
+	return size.W, size.H
+}
+
+func (p *CProgressBar) getCssRune(name cdk.Property, def rune) (r rune) {
+	if value, err := p.GetCssString(name, p.GetState()); err != nil {
+		p.LogErr(err)
+	} else if value != "" {
+		r, _ = utf8.DecodeRuneInString(value)
+		return
+	}
+	return def
+}
+
+func (p *CProgressBar) getDisplayText() (text string) {
+	if !p.GetShowText() {
+		return ""
+	}
+	if text = p.GetText(); text == "" && !p.GetActivityMode() {
+		text = fmt.Sprintf("%d %%", int(math.Round(p.GetFraction()*100.0)))
+	}
+	return
+}
+
+// getFilled returns the number of whole cells filled in along the length of the
+// progress bar and the number of eighths of the following cell.
+func (p *CProgressBar) getFilled(length int) (cells, eighths int) {
+	fraction := p.GetFraction()
+	if p.GetBarStyle() == enums.PROGRESS_DISCRETE {
+		return int(math.Round(fraction * float64(length))), 0
+	}
+	total := int(math.Round(fraction * float64(length) * 8.0))
+	return total / 8, total % 8
+}
+
+// getActivityBlock returns the starting cell and the length of the bouncing
+// block drawn in activity mode.
+func (p *CProgressBar) getActivityBlock(length int) (start, size int) {
+	if blocks := p.GetActivityBlocks(); blocks > 0 {
+		size = length / blocks
+	}
+	if size < 1 {
+		size = 1
+	}
+	p.RLock()
+	start = int(math.Round(p.pulsePosition * float64(length-size)))
+	p.RUnlock()
+	return
+}
+
+func (p *CProgressBar) resize(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	p.Invalidate()
+	return cenums.EVENT_STOP
+}
+
+func (p *CProgressBar) draw(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if surface, ok := argv[1].(*memphis.CSurface); ok {
+		alloc := p.GetAllocation()
+		if !p.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+			p.LogTrace("not visible, zero width or zero height")
+			return cenums.EVENT_PASS
+		}
+
+		theme := p.GetThemeRequest()
+		style := theme.Content.Normal
+		if !p.IsSensitive() {
+			style = theme.Content.Insensitive
+		}
+		surface.Fill(theme)
+
+		orientation := p.GetOrientation()
+		vertical := orientation == enums.PROGRESS_BOTTOM_TO_TOP || orientation == enums.PROGRESS_TOP_TO_BOTTOM
+		length, breadth := alloc.W, alloc.H
+		if vertical {
+			length, breadth = alloc.H, alloc.W
+		}
+
+		fill, empty := p.GetFillCharacter(), p.GetEmptyCharacter()
+		cells := make([]rune, length)
+		filled := make([]bool, length)
+		for i := range cells {
+			cells[i] = empty
+		}
+		if p.GetActivityMode() {
+			start, size := p.getActivityBlock(length)
+			for i := start; i < start+size && i < length; i++ {
+				cells[i], filled[i] = fill, true
+			}
+		} else {
+			full, eighths := p.getFilled(length)
+			for i := 0; i < full && i < length; i++ {
+				cells[i], filled[i] = fill, true
+			}
+			if eighths > 0 && full < length && fill == ProgressBarFillRune {
+				cells[full] = progressBarPartialRune(orientation, eighths)
+				filled[full] = eighths >= 4
+			}
+		}
+
+		// position along the length of the bar, with the first cell being
+		// where the progress starts from
+		position := func(i, j int) (x, y int) {
+			switch orientation {
+			case enums.PROGRESS_RIGHT_TO_LEFT:
+				return length - 1 - i, j
+			case enums.PROGRESS_TOP_TO_BOTTOM:
+				return j, i
+			case enums.PROGRESS_BOTTOM_TO_TOP:
+				return j, length - 1 - i
+			}
+			return i, j
+		}
+		for i := 0; i < length; i++ {
+			for j := 0; j < breadth; j++ {
+				x, y := position(i, j)
+				if err := surface.SetRune(x, y, cells[i], style); err != nil {
+					p.LogError("set rune error: %v", err)
+				}
+			}
+		}
+
+		if text := p.getDisplayText(); text != "" && !vertical {
+			width := runewidth.StringWidth(text)
+			x := (alloc.W - width) / 2
+			if x < 0 {
+				x = 0
+			}
+			y := (alloc.H - 1) / 2
+			for _, r := range text {
+				if x >= alloc.W {
+					break
+				}
+				i := x
+				if orientation == enums.PROGRESS_RIGHT_TO_LEFT {
+					i = length - 1 - x
+				}
+				rs := style
+				if filled[i] {
+					rs = style.Reverse(true)
+				}
+				if err := surface.SetRune(x, y, r, rs); err != nil {
+					p.LogError("set rune error: %v", err)
+				}
+				x += runewidth.RuneWidth(r)
+			}
+		}
+
+		if debug, _ := p.GetBoolProperty(cdk.PropertyDebug); debug {
+			surface.DebugBox(paint.ColorSilver, p.ObjectInfo())
+		}
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+// progressBarPartialRune returns the Unicode block element representing the
+// given number of eighths of a cell, growing in the direction of the
+// orientation given. Right-to-left and top-to-bottom bars only have one eighth
+// and one half block elements available.
+func progressBarPartialRune(orientation enums.ProgressBarOrientation, eighths int) rune {
+	switch orientation {
+	case enums.PROGRESS_RIGHT_TO_LEFT:
+		if eighths >= 4 {
+			return '▐'
+		}
+		return '▕'
+	case enums.PROGRESS_TOP_TO_BOTTOM:
+		if eighths >= 4 {
+			return '▀'
+		}
+		return '▔'
+	case enums.PROGRESS_BOTTOM_TO_TOP:
+		return []rune("▁▂▃▄▅▆▇")[eighths-1]
+	}
+	return []rune("▏▎▍▌▋▊▉")[eighths-1]
+}
+
+// ProgressBarFillRune is the default rune used to draw the completed portion of
+// a ProgressBar.
+const ProgressBarFillRune rune = '█'
+
+// ProgressBarEmptyRune is the default rune used to draw the remaining portion of
+// a ProgressBar.
+const ProgressBarEmptyRune rune = '░'
+
+// ProgressBarPulseInterval is the time between pulses of a ProgressBar started
+// with StartPulsing.
+const ProgressBarPulseInterval = time.Millisecond * 100
+
+// The fraction of total work that has been completed.
+// Flags: Read / Write
+// Allowed values: [0,1]
+// Default value: 0
+const PropertyFraction cdk.Property = "fraction"
+
+// The fraction of total progress to move the bouncing block when pulsed.
+// Flags: Read / Write
+// Allowed values: [0,1]
+// Default value: 0.1
+const PropertyPulseStep cdk.Property = "pulse-step"
+
+// Text to be displayed in the progress bar.
+// Flags: Read / Write
+// Default value: ""
+// const PropertyText cdk.Property = "text"
+
+// Whether the progress is shown as text.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyShowText cdk.Property = "show-text"
+
+// Whether the progress bar is in activity mode, showing a bouncing block
+// instead of the fraction completed.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyActivityMode cdk.Property = "activity-mode"
+
+// The number of blocks which can fit in the progress bar area in activity
+// mode.
+// Flags: Read / Write
+// Allowed values: [2,INT_MAX]
+// Default value: 5
+const PropertyActivityBlocks cdk.Property = "activity-blocks"
+
+// Specifies the visual style of the bar in percentage mode.
+// Flags: Read / Write
+// Default value: PROGRESS_CONTINUOUS
+const PropertyBarStyle cdk.Property = "bar-style"
+
+// The rune used to draw the completed portion of a ProgressBar.
+const CssPropertyFillCharacter cdk.Property = "fill-character"
+
+// The rune used to draw the remaining portion of a ProgressBar.
+const CssPropertyEmptyCharacter cdk.Property = "empty-character"
+
+const ProgressBarResizeHandle = "progress-bar-resize-handler"
+
+const ProgressBarDrawHandle = "progress-bar-draw-handler"
@@ -0,0 +1,168 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"testing"
+
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/ptypes"
+	"github.com/go-curses/cdk/memphis"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+func TestProgressBar(t *testing.T) {
+	Convey("Testing Progress Bars", t, func() {
+		render := func(p ProgressBar, w, h int) (lines []string) {
+			p.Show()
+			p.SetOrigin(0, 0)
+			p.SetAllocation(ptypes.MakeRectangle(w, h))
+			p.Resize()
+			So(p.Draw(), ShouldEqual, cenums.EVENT_STOP)
+			surface, err := memphis.GetSurface(p.ObjectID())
+			So(err, ShouldBeNil)
+			for y := 0; y < h; y++ {
+				line := ""
+				for x := 0; x < w; x++ {
+					line += string(surface.GetContent(x, y).Value())
+				}
+				lines = append(lines, line)
+			}
+			return
+		}
+		Convey("fraction", func() {
+			p := NewProgressBar()
+			So(p, ShouldNotBeNil)
+			So(p.GetFraction(), ShouldEqual, 0.0)
+			p.SetFraction(1.5)
+			So(p.GetFraction(), ShouldEqual, 1.0)
+			p.SetFraction(-1)
+			So(p.GetFraction(), ShouldEqual, 0.0)
+			w, h := p.GetSizeRequest()
+			So(w, ShouldEqual, 10)
+			So(h, ShouldEqual, 1)
+			p.SetOrientation(enums.PROGRESS_BOTTOM_TO_TOP)
+			w, h = p.GetSizeRequest()
+			So(w, ShouldEqual, 1)
+			So(h, ShouldEqual, 5)
+		})
+		Convey("drawing", func() {
+			p := NewProgressBar()
+			p.SetFraction(0.5)
+			So(render(p, 10, 1), ShouldResemble, []string{"█████░░░░░"})
+			p.SetFraction(0.25)
+			So(render(p, 10, 1), ShouldResemble, []string{"██▌░░░░░░░"})
+			p.SetFraction(0.3125)
+			So(render(p, 4, 1), ShouldResemble, []string{"█▎░░"})
+			p.SetBarStyle(enums.PROGRESS_DISCRETE)
+			So(render(p, 10, 1), ShouldResemble, []string{"███░░░░░░░"})
+			p.SetBarStyle(enums.PROGRESS_CONTINUOUS)
+			p.SetFraction(0.25)
+			p.SetOrientation(enums.PROGRESS_RIGHT_TO_LEFT)
+			So(render(p, 10, 1), ShouldResemble, []string{"░░░░░░░▐██"})
+			p.SetFraction(0.5)
+			p.SetOrientation(enums.PROGRESS_BOTTOM_TO_TOP)
+			So(render(p, 2, 4), ShouldResemble, []string{"░░", "░░", "██", "██"})
+			p.SetFraction(0.375)
+			So(render(p, 1, 4), ShouldResemble, []string{"░", "░", "▄", "█"})
+			p.SetOrientation(enums.PROGRESS_TOP_TO_BOTTOM)
+			So(render(p, 1, 4), ShouldResemble, []string{"█", "▀", "░", "░"})
+		})
+		Convey("text", func() {
+			p := NewProgressBar()
+			p.SetFraction(0.5)
+			p.SetShowText(true)
+			w, _ := p.GetSizeRequest()
+			So(w, ShouldEqual, 10)
+			So(render(p, 10, 1), ShouldResemble, []string{"███50 %░░░"})
+			surface, _ := memphis.GetSurface(p.ObjectID())
+			_, _, attrs := surface.GetContent(3, 0).Style().Decompose()
+			So(attrs.IsReverse(), ShouldEqual, true)
+			_, _, attrs = surface.GetContent(5, 0).Style().Decompose()
+			So(attrs.IsReverse(), ShouldEqual, false)
+			p.SetText("loading files")
+			w, _ = p.GetSizeRequest()
+			So(w, ShouldEqual, 15)
+			So(render(p, 15, 1), ShouldResemble, []string{"█loading files░"})
+			p.SetShowText(false)
+			So(render(p, 4, 1), ShouldResemble, []string{"██░░"})
+		})
+		Convey("activity mode", func() {
+			p := NewProgressBar()
+			p.SetPulseStep(0.5)
+			So(p.GetActivityMode(), ShouldEqual, false)
+			p.Pulse()
+			So(p.GetActivityMode(), ShouldEqual, true)
+			So(render(p, 10, 1), ShouldResemble, []string{"░░░░██░░░░"})
+			p.Pulse()
+			So(render(p, 10, 1), ShouldResemble, []string{"░░░░░░░░██"})
+			p.Pulse()
+			So(render(p, 10, 1), ShouldResemble, []string{"░░░░██░░░░"})
+			p.Pulse()
+			So(render(p, 10, 1), ShouldResemble, []string{"██░░░░░░░░"})
+			p.SetActivityBlocks(2)
+			So(render(p, 10, 1), ShouldResemble, []string{"█████░░░░░"})
+			p.SetFraction(0.0)
+			So(p.GetActivityMode(), ShouldEqual, false)
+			So(p.IsPulsing(), ShouldEqual, false)
+			p.StartPulsing()
+			So(p.IsPulsing(), ShouldEqual, true)
+			So(p.GetActivityMode(), ShouldEqual, true)
+			p.StopPulsing()
+			So(p.IsPulsing(), ShouldEqual, false)
+		})
+		Convey("css characters", func() {
+			p := NewProgressBar()
+			So(p.GetFillCharacter(), ShouldEqual, ProgressBarFillRune)
+			So(p.SetCssPropertyFromStyle("fill-character", "#"), ShouldBeNil)
+			So(p.SetCssPropertyFromStyle("empty-character:normal", "-"), ShouldBeNil)
+			So(p.GetFillCharacter(), ShouldEqual, '#')
+			So(p.GetEmptyCharacter(), ShouldEqual, '-')
+			p.SetFraction(0.25)
+			So(render(p, 10, 1), ShouldResemble, []string{"##--------"})
+
+			window := NewWindow()
+			window.SetDecorated(false)
+			window.Show()
+			So(window.ImportStylesFromString("ctk-progress-bar {\n\tfill-character: \"=\";\n\tempty-character: \" \";\n}\n"), ShouldBeNil)
+			p = NewProgressBar()
+			window.ApplyStylesTo(p)
+			So(p.GetFillCharacter(), ShouldEqual, '=')
+			So(p.GetEmptyCharacter(), ShouldEqual, ' ')
+			p.SetFraction(0.5)
+			So(render(p, 10, 1), ShouldResemble, []string{"=====     "})
+		})
+		Convey("builder", func() {
+			builder := NewBuilder()
+			_, err := builder.LoadFromString(`<interface>
+  <object class="GtkProgressBar" id="test-progress-bar">
+    <property name="fraction">0.75</property>
+    <property name="orientation">GTK_PROGRESS_RIGHT_TO_LEFT</property>
+    <property name="bar_style">GTK_PROGRESS_DISCRETE</property>
+    <property name="text">busy</property>
+  </object>
+</interface>`)
+			So(err, ShouldBeNil)
+			p, ok := builder.GetWidget("test-progress-bar").(ProgressBar)
+			So(ok, ShouldEqual, true)
+			So(p.GetFraction(), ShouldEqual, 0.75)
+			So(p.GetOrientation(), ShouldEqual, enums.PROGRESS_RIGHT_TO_LEFT)
+			So(p.GetBarStyle(), ShouldEqual, enums.PROGRESS_DISCRETE)
+			So(p.GetText(), ShouldEqual, "busy")
+		})
+	})
+}
@@ -15,6 +15,8 @@
 package ctk
 
 import (
+	"sync"
+
 	"github.com/go-curses/cdk"
 )
 
@@ -34,6 +36,8 @@ type RadioAction interface {
 
 	GetGroup() (value ActionGroup)
 	SetGroup(group ActionGroup)
+	JoinGroup(groupSource RadioAction)
+	GetGroupMembers() (members []RadioAction)
 	GetCurrentValue() (value int)
 	SetCurrentValue(currentValue int)
 }
@@ -46,6 +50,15 @@ var _ RadioAction = (*CRadioAction)(nil)
 // of interacting with RadioAction objects.
 type CRadioAction struct {
 	CToggleAction
+
+	radio *cRadioActionGroup
+}
+
+// cRadioActionGroup is shared by all members of a radio group.
+type cRadioActionGroup struct {
+	members []RadioAction
+
+	sync.RWMutex
 }
 
 // MakeRadioAction is used by the Buildable system to construct a new RadioAction.
@@ -55,9 +68,13 @@ func MakeRadioAction() RadioAction {
 
 // NewRadioAction is the constructor for new RadioAction instances.
 func NewRadioAction(name string, label string, tooltip string, stockId string, value int) (r RadioAction) {
-	r = new(CRadioAction)
-	r.Init()
-	return r
+	ra := new(CRadioAction)
+	ra.Init()
+	ra.setup(name, label, tooltip, stockId)
+	if err := ra.SetIntProperty(PropertyValue, value); err != nil {
+		ra.LogErr(err)
+	}
+	return ra
 }
 
 // Init initializes an RadioAction object. This must be called at least once to
@@ -71,12 +88,138 @@ func (r *CRadioAction) Init() (already bool) {
 		return true
 	}
 	r.CToggleAction.Init()
+	r.radio = &cRadioActionGroup{members: []RadioAction{r}}
 	_ = r.InstallProperty(PropertyCurrentValue, cdk.IntProperty, true, 0)
 	_ = r.InstallProperty(PropertyGroup, cdk.StructProperty, true, nil)
 	_ = r.InstallProperty(PropertyValue, cdk.IntProperty, true, 0)
+	r.SetDrawAsRadio(true)
 	return false
 }
 
+// Activate makes the RadioAction the active member of its group and then
+// emits the "activate" signal, if the action is sensitive. Activating the
+// already active member of a group does not deactivate it.
+func (r *CRadioAction) Activate() {
+	if !r.canActivate() {
+		return
+	}
+	r.SetActive(true)
+	r.CAction.Activate()
+}
+
+// SetActive updates the checked state of the radio action. Making the action
+// active deactivates all other members of the group and emits the "changed"
+// signal on every member. The last active member of a group cannot be
+// deactivated directly.
+//
+// Parameters:
+// 	isActive	whether the action should be checked or not
+func (r *CRadioAction) SetActive(isActive bool) {
+	if r.GetActive() == isActive {
+		return
+	}
+	members := r.GetGroupMembers()
+	if !isActive {
+		for _, member := range members {
+			if member.ObjectID() != r.ObjectID() && member.GetActive() {
+				r.CToggleAction.SetActive(false)
+				return
+			}
+		}
+		if len(members) <= 1 {
+			r.CToggleAction.SetActive(false)
+		}
+		return
+	}
+	for _, member := range members {
+		if member.ObjectID() != r.ObjectID() {
+			if ra, ok := member.Self().(*CRadioAction); ok {
+				ra.CToggleAction.SetActive(false)
+			}
+		}
+	}
+	r.CToggleAction.SetActive(true)
+	value := r.getValue()
+	for _, member := range members {
+		if err := member.SetIntProperty(PropertyCurrentValue, value); err != nil {
+			member.LogErr(err)
+		}
+		member.Emit(SignalRadioActionChanged, member, r)
+	}
+}
+
+// JoinGroup joins a radio action object to the group of another radio action
+// object, leaving the group it previously belonged to. The action becomes
+// inactive if the joined group already has an active member.
+//
+// Parameters:
+// 	groupSource	a radio action object whose group we are joining, or nil to
+// 	            remove the radio action from its group
+func (r *CRadioAction) JoinGroup(groupSource RadioAction) {
+	r.RLock()
+	previous := r.radio
+	r.RUnlock()
+	if previous != nil {
+		previous.Lock()
+		for idx, member := range previous.members {
+			if member.ObjectID() == r.ObjectID() {
+				previous.members = append(previous.members[:idx], previous.members[idx+1:]...)
+				break
+			}
+		}
+		previous.Unlock()
+	}
+	var next *cRadioActionGroup
+	if groupSource != nil && groupSource.ObjectID() != r.ObjectID() {
+		if ra, ok := groupSource.Self().(*CRadioAction); ok {
+			ra.RLock()
+			next = ra.radio
+			ra.RUnlock()
+		}
+	}
+	if next == nil {
+		next = &cRadioActionGroup{}
+	}
+	hasActive := false
+	next.Lock()
+	for _, member := range next.members {
+		if member.GetActive() {
+			hasActive = true
+		}
+	}
+	next.members = append(next.members, r)
+	next.Unlock()
+	r.Lock()
+	r.radio = next
+	r.Unlock()
+	if hasActive {
+		r.CToggleAction.SetActive(false)
+	}
+}
+
+// GetGroupMembers returns the list of radio actions sharing a radio group
+// with this action, including the action itself. The list is a copy and
+// changing it does not change the group.
+func (r *CRadioAction) GetGroupMembers() (members []RadioAction) {
+	r.RLock()
+	g := r.radio
+	r.RUnlock()
+	if g != nil {
+		g.RLock()
+		members = append(members, g.members...)
+		g.RUnlock()
+	}
+	return
+}
+
+func (r *CRadioAction) getValue() (value int) {
+	var err error
+	if value, err = r.GetIntProperty(PropertyValue); err != nil {
+		r.LogErr(err)
+	}
+	return
+}
+
 // GetGroup returns the list representing the radio group for this object. Note
 // that the returned list is only valid until the next change to the group.
 //
@@ -109,11 +252,14 @@ func (r *CRadioAction) SetGroup(group ActionGroup) {
 // Returns:
 // 	The value of the currently active group member
 func (r *CRadioAction) GetCurrentValue() (value int) {
-	var err error
-	if value, err = r.GetIntProperty(PropertyCurrentValue); err != nil {
-		r.LogErr(err)
+	for _, member := range r.GetGroupMembers() {
+		if member.GetActive() {
+			if ra, ok := member.Self().(*CRadioAction); ok {
+				return ra.getValue()
+			}
+		}
 	}
-	return
+	return r.getValue()
 }
 
 // SetCurrentValue updates the currently active group member to the member with
@@ -122,9 +268,13 @@ func (r *CRadioAction) GetCurrentValue() (value int) {
 // Parameters:
 // 	currentValue	the new value
 func (r *CRadioAction) SetCurrentValue(currentValue int) {
-	if err := r.SetIntProperty(PropertyCurrentValue, currentValue); err != nil {
-		r.LogErr(err)
+	for _, member := range r.GetGroupMembers() {
+		if ra, ok := member.Self().(*CRadioAction); ok && ra.getValue() == currentValue {
+			ra.SetActive(true)
+			return
+		}
 	}
+	r.LogError("no radio action with value %d in group", currentValue)
 }
 
 // The value property of the currently active member of the group to which
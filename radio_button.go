@@ -0,0 +1,313 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+)
+
+const TypeRadioButton cdk.CTypeTag = "ctk-radio-button"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeRadioButton, func() interface{} { return MakeRadioButton() })
+	ctkBuilderTranslators[TypeRadioButton] = func(builder Builder, widget Widget, name, value string) error {
+		switch cdk.Property(name) {
+		case PropertyGroup:
+			if r, ok := widget.Self().(RadioButton); ok {
+				if member, ok := builder.GetWidget(value).(RadioButton); ok {
+					r.SetGroup(member.GetGroup())
+					return nil
+				}
+				return fmt.Errorf("radio button group member not found: %v", value)
+			}
+		}
+		return ErrFallthrough
+	}
+}
+
+// RadioButton Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Bin
+//	        +- Button
+//	          +- ToggleButton
+//	            +- CheckButton
+//	              +- RadioButton
+//
+// A RadioButton is a CheckButton that belongs to a group, drawn with a round
+// indicator, ie: "(*) Label". At each instant exactly one of the radio
+// buttons from a group is active. Clicking an inactive member of the group
+// makes it the active member, clicking the active member has no effect.
+type RadioButton interface {
+	CheckButton
+
+	GetGroup() (group []RadioButton)
+	SetGroup(group []RadioButton)
+}
+
+var _ RadioButton = (*CRadioButton)(nil)
+
+// The CRadioButton structure implements the RadioButton interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with RadioButton objects.
+type CRadioButton struct {
+	CCheckButton
+
+	group *cRadioButtonGroup
+}
+
+// cRadioButtonGroup is shared by all members of a radio group.
+type cRadioButtonGroup struct {
+	members []RadioButton
+
+	sync.RWMutex
+}
+
+// MakeRadioButton is used by the Buildable system to construct a new
+// RadioButton with a default label that is empty.
+func MakeRadioButton() RadioButton {
+	return NewRadioButtonWithLabel(nil, "")
+}
+
+// NewRadioButton creates a new RadioButton without a label Widget.
+//
+// Parameters:
+//
+//	group	an existing radio button group, or nil if creating a new group
+func NewRadioButton(group []RadioButton) RadioButton {
+	r := new(CRadioButton)
+	r.Init()
+	r.SetGroup(group)
+	return r
+}
+
+// NewRadioButtonFromWidget creates a new RadioButton, adding it to the same
+// group as the given member.
+//
+// Parameters:
+//
+//	member	an existing RadioButton, or nil
+func NewRadioButtonFromWidget(member RadioButton) RadioButton {
+	if member != nil {
+		return NewRadioButton(member.GetGroup())
+	}
+	return NewRadioButton(nil)
+}
+
+// NewRadioButtonWithLabel creates a new RadioButton with a Label to the right
+// of the indicator.
+//
+// Parameters:
+//
+//	group	an existing radio button group, or nil
+//	label	the text label to display next to the radio button
+func NewRadioButtonWithLabel(group []RadioButton, label string) RadioButton {
+	r := NewRadioButton(group)
+	buttonAddLabel(r, label)
+	return r
+}
+
+// NewRadioButtonWithLabelFromWidget creates a new RadioButton with a Label,
+// adding it to the same group as the given member.
+//
+// Parameters:
+//
+//	member	an existing RadioButton, or nil
+//	label	the text label to display next to the radio button
+func NewRadioButtonWithLabelFromWidget(member RadioButton, label string) RadioButton {
+	r := NewRadioButtonFromWidget(member)
+	buttonAddLabel(r, label)
+	return r
+}
+
+// NewRadioButtonWithMnemonic creates a new RadioButton containing a label.
+// Underscores in the label indicate the mnemonic for the radio button.
+//
+// Parameters:
+//
+//	group	an existing radio button group, or nil
+//	label	the text of the button, with an underscore in front of the
+//	        mnemonic character
+func NewRadioButtonWithMnemonic(group []RadioButton, label string) RadioButton {
+	r := NewRadioButtonWithLabel(group, label)
+	r.SetUseUnderline(true)
+	return r
+}
+
+// NewRadioButtonWithMnemonicFromWidget creates a new RadioButton containing a
+// label, adding it to the same group as the given member. Underscores in the
+// label indicate the mnemonic for the radio button.
+//
+// Parameters:
+//
+//	member	an existing RadioButton, or nil
+//	label	the text of the button, with an underscore in front of the
+//	        mnemonic character
+func NewRadioButtonWithMnemonicFromWidget(member RadioButton, label string) RadioButton {
+	r := NewRadioButtonWithLabelFromWidget(member, label)
+	r.SetUseUnderline(true)
+	return r
+}
+
+// Init initializes a RadioButton object. This must be called at least once
+// to set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the RadioButton instance. Init is used in the
+// NewRadioButton constructor and only necessary when implementing a
+// derivative RadioButton type.
+func (r *CRadioButton) Init() (already bool) {
+	if r.InitTypeItem(TypeRadioButton, r) {
+		return true
+	}
+	r.CCheckButton.Init()
+	r.group = nil
+	_ = r.InstallBuildableProperty(PropertyGroup, cdk.StructProperty, true, nil)
+	_ = r.Disconnect(SignalClicked, ToggleButtonClickedHandle)
+	r.Connect(SignalClicked, RadioButtonClickedHandle, r.clicked)
+	return false
+}
+
+// SetActive updates the active state of the RadioButton. Making the button
+// active deactivates all other members of the group. The last active member
+// of a group cannot be deactivated directly.
+//
+// Parameters:
+//
+//	isActive	TRUE or FALSE
+func (r *CRadioButton) SetActive(isActive bool) {
+	if r.GetActive() == isActive {
+		return
+	}
+	if isActive {
+		for _, member := range r.GetGroup() {
+			if member.ObjectID() != r.ObjectID() {
+				if rb, ok := member.Self().(*CRadioButton); ok {
+					rb.CCheckButton.SetActive(false)
+				}
+			}
+		}
+	} else {
+		others := false
+		for _, member := range r.GetGroup() {
+			if member.ObjectID() != r.ObjectID() && member.GetActive() {
+				others = true
+				break
+			}
+		}
+		if !others && len(r.GetGroup()) > 1 {
+			return
+		}
+	}
+	r.CCheckButton.SetActive(isActive)
+}
+
+// GetGroup returns the group to which the RadioButton belongs, as a list of
+// RadioButton. The list is a copy and changing it does not change the group.
+func (r *CRadioButton) GetGroup() (group []RadioButton) {
+	r.RLock()
+	g := r.group
+	r.RUnlock()
+	if g != nil {
+		g.RLock()
+		group = append(group, g.members...)
+		g.RUnlock()
+	}
+	return
+}
+
+// SetGroup sets the group of the RadioButton, or changes it. The button is
+// removed from its previous group first and becomes inactive if it is joining
+// a group that already has an active member.
+//
+// Parameters:
+//
+//	group	the new group, or nil to create a new group for the button alone
+func (r *CRadioButton) SetGroup(group []RadioButton) {
+	r.Lock()
+	previous := r.group
+	r.Unlock()
+	if previous != nil {
+		previous.Lock()
+		for idx, member := range previous.members {
+			if member.ObjectID() == r.ObjectID() {
+				previous.members = append(previous.members[:idx], previous.members[idx+1:]...)
+				break
+			}
+		}
+		previous.Unlock()
+	}
+	var next *cRadioButtonGroup
+	for _, member := range group {
+		if rb, ok := member.Self().(*CRadioButton); ok && rb.ObjectID() != r.ObjectID() {
+			rb.RLock()
+			next = rb.group
+			rb.RUnlock()
+			if next != nil {
+				break
+			}
+		}
+	}
+	if next == nil {
+		next = &cRadioButtonGroup{}
+	}
+	hasActive := false
+	next.Lock()
+	for _, member := range next.members {
+		if member.GetActive() {
+			hasActive = true
+		}
+	}
+	next.members = append(next.members, r)
+	next.Unlock()
+	r.Lock()
+	r.group = next
+	r.Unlock()
+	if err := r.SetStructProperty(PropertyGroup, r.GetGroup()); err != nil {
+		r.LogErr(err)
+	}
+	if hasActive {
+		r.CCheckButton.SetActive(false)
+	} else {
+		r.CCheckButton.SetActive(true)
+	}
+	r.Emit(SignalGroupChanged, r)
+}
+
+func (r *CRadioButton) clicked(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if rb, ok := r.Self().(RadioButton); ok {
+		rb.SetActive(true)
+	}
+	return cenums.EVENT_PASS
+}
+
+// Sets a new group for a radio button.
+// Flags: Write
+// const PropertyGroup cdk.Property = "group"
+
+// Emitted when the group of radio buttons that a radio button belongs to
+// changes. This is emitted when a radio button switches from being alone to
+// being part of a group of 2 or more buttons, or vice-versa, and when a button
+// is moved from one group of 2 or more buttons to a different one, but not
+// when the composition of the group that a radio button belongs to changes.
+// const SignalGroupChanged cdk.Signal = "group-changed"
+
+const RadioButtonClickedHandle = "radio-button-clicked-handler"
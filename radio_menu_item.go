@@ -0,0 +1,304 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"sync"
+
+	"github.com/go-curses/cdk"
+)
+
+const TypeRadioMenuItem cdk.CTypeTag = "ctk-radio-menu-item"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeRadioMenuItem, func() interface{} { return MakeRadioMenuItem() })
+}
+
+// RadioMenuItem Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Bin
+//	        +- Item
+//	          +- MenuItem
+//	            +- CheckMenuItem
+//	              +- RadioMenuItem
+//
+// A RadioMenuItem is a check menu item that belongs to a group. At each
+// instant exactly one of the radio menu items from a group is selected.
+type RadioMenuItem interface {
+	CheckMenuItem
+
+	GetGroup() (group []RadioMenuItem)
+	SetGroup(group []RadioMenuItem)
+}
+
+var _ RadioMenuItem = (*CRadioMenuItem)(nil)
+
+// The CRadioMenuItem structure implements the RadioMenuItem interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with RadioMenuItem objects.
+type CRadioMenuItem struct {
+	CCheckMenuItem
+
+	group *cRadioMenuItemGroup
+}
+
+// cRadioMenuItemGroup is shared by all members of a radio group.
+type cRadioMenuItemGroup struct {
+	members []RadioMenuItem
+
+	sync.RWMutex
+}
+
+// MakeRadioMenuItem is used by the Buildable system to construct a new
+// RadioMenuItem.
+func MakeRadioMenuItem() RadioMenuItem {
+	return NewRadioMenuItem(nil)
+}
+
+// NewRadioMenuItem creates a new RadioMenuItem.
+//
+// Parameters:
+//
+//	group	the group to which the radio menu item is to be attached, or nil
+func NewRadioMenuItem(group []RadioMenuItem) RadioMenuItem {
+	r := new(CRadioMenuItem)
+	r.Init()
+	r.SetGroup(group)
+	return r
+}
+
+// NewRadioMenuItemWithLabel creates a new RadioMenuItem whose child is a
+// simple Label.
+//
+// Parameters:
+//
+//	group	the group to which the radio menu item is to be attached, or nil
+//	label	the text for the label
+func NewRadioMenuItemWithLabel(group []RadioMenuItem, label string) RadioMenuItem {
+	r := NewRadioMenuItem(group)
+	r.SetLabel(label)
+	return r
+}
+
+// NewRadioMenuItemWithMnemonic creates a new RadioMenuItem containing a
+// label. The label will be created using NewLabelWithMnemonic, so underscores
+// in label indicate the mnemonic for the menu item.
+//
+// Parameters:
+//
+//	group	the group to which the radio menu item is to be attached, or nil
+//	label	the text of the button, with an underscore in front of the
+//	        mnemonic character
+func NewRadioMenuItemWithMnemonic(group []RadioMenuItem, label string) RadioMenuItem {
+	r := NewRadioMenuItem(group)
+	r.SetUseUnderline(true)
+	r.SetLabel(label)
+	return r
+}
+
+// NewRadioMenuItemFromWidget creates a new RadioMenuItem adding it to the same
+// group as the given member.
+//
+// Parameters:
+//
+//	group	an existing RadioMenuItem, or nil
+func NewRadioMenuItemFromWidget(group RadioMenuItem) RadioMenuItem {
+	if group != nil {
+		return NewRadioMenuItem(group.GetGroup())
+	}
+	return NewRadioMenuItem(nil)
+}
+
+// NewRadioMenuItemWithLabelFromWidget creates a new RadioMenuItem whose child
+// is a simple Label. The new RadioMenuItem is added to the same group as the
+// given member.
+//
+// Parameters:
+//
+//	group	an existing RadioMenuItem, or nil
+//	label	the text for the label
+func NewRadioMenuItemWithLabelFromWidget(group RadioMenuItem, label string) RadioMenuItem {
+	r := NewRadioMenuItemFromWidget(group)
+	r.SetLabel(label)
+	return r
+}
+
+// NewRadioMenuItemWithMnemonicFromWidget creates a new RadioMenuItem
+// containing a label. The label will be created using NewLabelWithMnemonic,
+// so underscores in label indicate the mnemonic for the menu item. The new
+// RadioMenuItem is added to the same group as the given member.
+//
+// Parameters:
+//
+//	group	an existing RadioMenuItem, or nil
+//	label	the text of the button, with an underscore in front of the
+//	        mnemonic character
+func NewRadioMenuItemWithMnemonicFromWidget(group RadioMenuItem, label string) RadioMenuItem {
+	r := NewRadioMenuItemFromWidget(group)
+	r.SetUseUnderline(true)
+	r.SetLabel(label)
+	return r
+}
+
+// Init initializes a RadioMenuItem object. This must be called at least once
+// to set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the RadioMenuItem instance. Init is used in the
+// NewRadioMenuItem constructor and only necessary when implementing a
+// derivative RadioMenuItem type.
+func (r *CRadioMenuItem) Init() (already bool) {
+	if r.InitTypeItem(TypeRadioMenuItem, r) {
+		return true
+	}
+	r.CCheckMenuItem.Init()
+	r.group = nil
+	_ = r.InstallProperty(PropertyGroup, cdk.StructProperty, true, nil)
+	r.SetDrawAsRadio(true)
+	return false
+}
+
+// Activate makes the RadioMenuItem the active member of its group and then
+// emits the activate signal. Activating the already active member of a group
+// does not deactivate it.
+func (r *CRadioMenuItem) Activate() (value bool) {
+	if !r.IsVisible() || !r.IsSensitive() {
+		return false
+	}
+	r.SetActive(true)
+	return r.CMenuItem.Activate()
+}
+
+// SetActive updates the active state of the RadioMenuItem. Making the item
+// active deactivates all other members of the group. The last active member
+// of a group cannot be deactivated directly.
+//
+// Parameters:
+//
+//	isActive	boolean value indicating whether the item is active
+func (r *CRadioMenuItem) SetActive(isActive bool) {
+	if r.GetActive() == isActive {
+		return
+	}
+	if isActive {
+		for _, member := range r.GetGroup() {
+			if member.ObjectID() != r.ObjectID() {
+				if rmi, ok := member.Self().(*CRadioMenuItem); ok {
+					rmi.CCheckMenuItem.SetActive(false)
+				}
+			}
+		}
+	} else {
+		others := false
+		for _, member := range r.GetGroup() {
+			if member.ObjectID() != r.ObjectID() && member.GetActive() {
+				others = true
+				break
+			}
+		}
+		if !others && len(r.GetGroup()) > 1 {
+			return
+		}
+	}
+	r.CCheckMenuItem.SetActive(isActive)
+}
+
+// GetGroup returns the group to which the radio menu item belongs, as a list
+// of RadioMenuItem. The list is a copy and changing it does not change the
+// group.
+func (r *CRadioMenuItem) GetGroup() (group []RadioMenuItem) {
+	r.RLock()
+	g := r.group
+	r.RUnlock()
+	if g != nil {
+		g.RLock()
+		group = append(group, g.members...)
+		g.RUnlock()
+	}
+	return
+}
+
+// SetGroup sets the group of a radio menu item, or changes it. The item is
+// removed from its previous group first and becomes inactive if it is joining
+// a group that already has an active member.
+//
+// Parameters:
+//
+//	group	the new group, or nil to create a new group for the item alone
+func (r *CRadioMenuItem) SetGroup(group []RadioMenuItem) {
+	r.Lock()
+	previous := r.group
+	r.Unlock()
+	if previous != nil {
+		previous.Lock()
+		for idx, member := range previous.members {
+			if member.ObjectID() == r.ObjectID() {
+				previous.members = append(previous.members[:idx], previous.members[idx+1:]...)
+				break
+			}
+		}
+		previous.Unlock()
+	}
+	var next *cRadioMenuItemGroup
+	for _, member := range group {
+		if rmi, ok := member.Self().(*CRadioMenuItem); ok && rmi.ObjectID() != r.ObjectID() {
+			rmi.RLock()
+			next = rmi.group
+			rmi.RUnlock()
+			if next != nil {
+				break
+			}
+		}
+	}
+	if next == nil {
+		next = &cRadioMenuItemGroup{}
+	}
+	hasActive := false
+	next.Lock()
+	for _, member := range next.members {
+		if member.GetActive() {
+			hasActive = true
+		}
+	}
+	next.members = append(next.members, r)
+	next.Unlock()
+	r.Lock()
+	r.group = next
+	r.Unlock()
+	if err := r.SetStructProperty(PropertyGroup, r.GetGroup()); err != nil {
+		r.LogErr(err)
+	}
+	if hasActive {
+		r.CCheckMenuItem.SetActive(false)
+	} else {
+		r.CCheckMenuItem.SetActive(true)
+	}
+	r.Emit(SignalGroupChanged, r)
+}
+
+// The radio menu item whose group this widget belongs to.
+// Flags: Write
+// const PropertyGroup cdk.Property = "group"
+
+// Emitted when the group of radio menu items that a radio menu item belongs
+// to changes. This is emitted when a radio menu item switches from being
+// alone to being part of a group of 2 or more menu items, or vice-versa, and
+// when a button is moved from one group of 2 or more menu items to a
+// different one, but not when the composition of the group that a radio menu
+// item belongs to changes.
+const SignalGroupChanged cdk.Signal = "group-changed"
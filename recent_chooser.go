@@ -0,0 +1,560 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	cstrings "github.com/go-curses/cdk/lib/strings"
+	"github.com/go-curses/cdk/lib/sync"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+var (
+	ErrRecentChooserNotFound   = fmt.Errorf("recent chooser item not found")
+	ErrRecentChooserInvalidUri = fmt.Errorf("invalid recent chooser uri")
+)
+
+// RecentSortFunc is the signature of the comparison functions given to
+// RecentChooser.SetSortFunc. The function returns a negative integer if a
+// sorts before b, zero if they sort equally and a positive integer if a sorts
+// after b.
+type RecentSortFunc = func(a, b *RecentInfo) int
+
+// RecentChooser Hierarchy:
+//
+//	Interface
+//	  +- RecentChooser
+//
+// RecentChooser is an interface that can be implemented by widgets
+// displaying the list of recently used files. In CTK, the main objects that
+// implement this interface are RecentChooserWidget and RecentChooserMenu.
+//
+// The items listed are the ones of the RecentManager of the chooser, sorted by
+// the sort-type of the chooser and restricted to the ones passing the current
+// RecentFilter. Note that the list of items is named GetRecentItems, as
+// GetItems is already used by the MenuShell of a RecentChooserMenu.
+type RecentChooser interface {
+	SetShowPrivate(showPrivate bool)
+	GetShowPrivate() (value bool)
+	SetShowNotFound(showNotFound bool)
+	GetShowNotFound() (value bool)
+	SetSelectMultiple(selectMultiple bool)
+	GetSelectMultiple() (value bool)
+	SetLimit(limit int)
+	GetLimit() (value int)
+	SetLocalOnly(localOnly bool)
+	GetLocalOnly() (value bool)
+	SetSortType(sortType enums.RecentSortType)
+	GetSortType() (value enums.RecentSortType)
+	SetSortFunc(fn RecentSortFunc)
+	SetCurrentUri(uri string) (err error)
+	GetCurrentUri() (uri string)
+	GetCurrentItem() (info *RecentInfo)
+	SelectUri(uri string) (err error)
+	UnselectUri(uri string)
+	SelectAll()
+	UnselectAll()
+	GetRecentItems() (items []*RecentInfo)
+	GetUris() (uris []string)
+	AddFilter(filter RecentFilter)
+	RemoveFilter(filter RecentFilter)
+	ListFilters() (filters []RecentFilter)
+	SetFilter(filter RecentFilter)
+	GetFilter() (filter RecentFilter)
+	GetRecentManager() (manager RecentManager)
+}
+
+// recentChooserBuilderTranslator applies the RecentChooser properties found
+// in Builder files, for any of the RecentChooser implementations.
+func recentChooserBuilderTranslator(builder Builder, widget Widget, name, value string) error {
+	rc, ok := widget.Self().(RecentChooser)
+	if !ok {
+		return ErrFallthrough
+	}
+	switch strings.ReplaceAll(strings.ToLower(name), "_", "-") {
+	case "show-private":
+		rc.SetShowPrivate(cstrings.IsTrue(value))
+	case "show-not-found":
+		rc.SetShowNotFound(cstrings.IsTrue(value))
+	case "select-multiple":
+		rc.SetSelectMultiple(cstrings.IsTrue(value))
+	case "local-only":
+		rc.SetLocalOnly(cstrings.IsTrue(value))
+	case "limit":
+		if limit, err := strconv.Atoi(value); err != nil {
+			return err
+		} else {
+			rc.SetLimit(limit)
+		}
+	case "sort-type":
+		if sortType, err := enums.RecentSortType(0).FromString(value); err != nil {
+			return err
+		} else {
+			rc.SetSortType(sortType.(enums.RecentSortType))
+		}
+	default:
+		return ErrFallthrough
+	}
+	return nil
+}
+
+// recentChooser implements the parts of the RecentChooser interface shared by
+// all the RecentChooser implementations, which embed it and call init from
+// their own Init method. The reload function given to init is called whenever
+// the list of items to display changes.
+type recentChooser struct {
+	chooser Object
+	manager RecentManager
+	handle  string
+	sortFn  RecentSortFunc
+	filters []RecentFilter
+	reload  func()
+	lock    *sync.RWMutex
+}
+
+func (r *recentChooser) init(chooser Object, reload func()) {
+	r.chooser = chooser
+	r.reload = reload
+	r.lock = &sync.RWMutex{}
+	r.filters = make([]RecentFilter, 0)
+	r.handle = fmt.Sprintf("%v-%v", RecentChooserManagerHandle, chooser.ObjectID())
+	_ = chooser.InstallBuildableProperty(PropertyShowPrivate, cdk.BoolProperty, true, false)
+	_ = chooser.InstallBuildableProperty(PropertyShowNotFound, cdk.BoolProperty, true, true)
+	_ = chooser.InstallBuildableProperty(PropertySelectMultiple, cdk.BoolProperty, true, false)
+	_ = chooser.InstallBuildableProperty(PropertyRecentChooserLimit, cdk.IntProperty, true, 50)
+	_ = chooser.InstallBuildableProperty(PropertyLocalOnly, cdk.BoolProperty, true, true)
+	_ = chooser.InstallBuildableProperty(PropertySortType, cdk.StructProperty, true, enums.RECENT_SORT_MRU)
+	_ = chooser.InstallProperty(PropertyRecentChooserFilter, cdk.StructProperty, true, nil)
+}
+
+// setRecentManager changes the RecentManager the chooser is listing the items
+// of, a nil manager selects the default RecentManager
+func (r *recentChooser) setRecentManager(manager RecentManager) {
+	if manager == nil {
+		manager = GetDefaultRecentManager()
+	}
+	r.lock.Lock()
+	previous := r.manager
+	r.manager = manager
+	r.lock.Unlock()
+	if previous != nil {
+		_ = previous.Disconnect(SignalChanged, r.handle)
+	}
+	manager.Connect(SignalChanged, r.handle, func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+		r.reload()
+		return cenums.EVENT_PASS
+	})
+	r.reload()
+}
+
+// GetRecentManager returns the RecentManager the chooser is listing the items
+// of.
+func (r *recentChooser) GetRecentManager() (manager RecentManager) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.manager
+}
+
+// SetShowPrivate sets whether to show recently used resources marked
+// registered as private. Private resources registered by the current
+// application are always shown.
+//
+// Parameters:
+//
+//	showPrivate	TRUE to show private items, FALSE otherwise
+func (r *recentChooser) SetShowPrivate(showPrivate bool) {
+	r.setBool(PropertyShowPrivate, showPrivate)
+}
+
+// GetShowPrivate returns whether chooser should display recently used
+// resources registered as private.
+// See: SetShowPrivate()
+func (r *recentChooser) GetShowPrivate() (value bool) {
+	return r.getBool(PropertyShowPrivate)
+}
+
+// SetShowNotFound sets whether chooser should display the recently used
+// resources that it didn't find. This only applies to local resources.
+//
+// Parameters:
+//
+//	showNotFound	whether to show the local items we didn't find
+func (r *recentChooser) SetShowNotFound(showNotFound bool) {
+	r.setBool(PropertyShowNotFound, showNotFound)
+}
+
+// GetShowNotFound returns whether chooser should display recently used
+// resources that it didn't find.
+// See: SetShowNotFound()
+func (r *recentChooser) GetShowNotFound() (value bool) {
+	return r.getBool(PropertyShowNotFound)
+}
+
+// SetSelectMultiple sets whether chooser can select multiple items.
+//
+// Parameters:
+//
+//	selectMultiple	TRUE if chooser can select more than one item
+func (r *recentChooser) SetSelectMultiple(selectMultiple bool) {
+	if err := r.chooser.SetBoolProperty(PropertySelectMultiple, selectMultiple); err != nil {
+		r.chooser.LogErr(err)
+	}
+}
+
+// GetSelectMultiple returns whether chooser can select multiple items.
+// See: SetSelectMultiple()
+func (r *recentChooser) GetSelectMultiple() (value bool) {
+	return r.getBool(PropertySelectMultiple)
+}
+
+// SetLimit sets the number of items that should be returned by GetRecentItems
+// and GetUris. If limit is set to -1, then return all the items.
+//
+// Parameters:
+//
+//	limit	a positive integer, or -1 for all items
+func (r *recentChooser) SetLimit(limit int) {
+	if limit < 0 {
+		limit = -1
+	}
+	if err := r.chooser.SetIntProperty(PropertyRecentChooserLimit, limit); err != nil {
+		r.chooser.LogErr(err)
+		return
+	}
+	r.reload()
+}
+
+// GetLimit returns the number of items returned by GetRecentItems and GetUris.
+// See: SetLimit()
+func (r *recentChooser) GetLimit() (value int) {
+	var err error
+	if value, err = r.chooser.GetIntProperty(PropertyRecentChooserLimit); err != nil {
+		r.chooser.LogErr(err)
+	}
+	return
+}
+
+// SetLocalOnly sets whether only local resources, that is resources using the
+// file:// URI scheme, should be shown in the recently used resources selector.
+// If localOnly is TRUE (the default) then the shown resources are guaranteed
+// to be accessible through the operating system native file system.
+//
+// Parameters:
+//
+//	localOnly	TRUE if only local files can be shown
+func (r *recentChooser) SetLocalOnly(localOnly bool) {
+	r.setBool(PropertyLocalOnly, localOnly)
+}
+
+// GetLocalOnly returns whether only local resources should be shown in the
+// recently used resources selector.
+// See: SetLocalOnly()
+func (r *recentChooser) GetLocalOnly() (value bool) {
+	return r.getBool(PropertyLocalOnly)
+}
+
+// SetSortType changes the sorting order of the recently used resources list
+// displayed by chooser. RECENT_SORT_MRU lists the most recently used items
+// first, RECENT_SORT_LRU the least recently used first, RECENT_SORT_CUSTOM
+// uses the function given to SetSortFunc and RECENT_SORT_NONE uses the order
+// of the RecentManager.
+//
+// Parameters:
+//
+//	sortType	sort order that the chooser should use
+func (r *recentChooser) SetSortType(sortType enums.RecentSortType) {
+	if err := r.chooser.SetStructProperty(PropertySortType, sortType); err != nil {
+		r.chooser.LogErr(err)
+		return
+	}
+	r.reload()
+}
+
+// GetSortType returns the value set by SetSortType.
+func (r *recentChooser) GetSortType() (value enums.RecentSortType) {
+	var ok bool
+	if v, err := r.chooser.GetStructProperty(PropertySortType); err != nil {
+		r.chooser.LogErr(err)
+	} else if value, ok = v.(enums.RecentSortType); !ok {
+		r.chooser.LogError("invalid value stored in %v: %v (%T)", PropertySortType, v, v)
+	}
+	return
+}
+
+// SetSortFunc sets the comparison function used when sorting to be fn. If
+// the chooser has the sort type set to RECENT_SORT_CUSTOM then the chooser
+// will sort using this function.
+//
+// Parameters:
+//
+//	fn	the comparison function
+func (r *recentChooser) SetSortFunc(fn RecentSortFunc) {
+	r.lock.Lock()
+	r.sortFn = fn
+	r.lock.Unlock()
+	if r.GetSortType() == enums.RECENT_SORT_CUSTOM {
+		r.reload()
+	}
+}
+
+// GetRecentItems returns the list of recently used resources displayed by the
+// chooser, sorted and filtered as configured.
+func (r *recentChooser) GetRecentItems() (items []*RecentInfo) {
+	manager := r.GetRecentManager()
+	if manager == nil {
+		return
+	}
+	showPrivate := r.GetShowPrivate()
+	showNotFound := r.GetShowNotFound()
+	localOnly := r.GetLocalOnly()
+	filter := r.GetFilter()
+	appName := recentManagerAppName()
+	for _, info := range manager.GetItems() {
+		if info.GetPrivateHint() && !showPrivate && !info.HasApplication(appName) {
+			continue
+		}
+		if localOnly && !info.IsLocal() {
+			continue
+		}
+		if !showNotFound && info.IsLocal() && !info.Exists() {
+			continue
+		}
+		if filter != nil && !filter.Filter(recentChooserFilterInfo(info, filter.GetNeeded())) {
+			continue
+		}
+		items = append(items, info)
+	}
+	r.lock.RLock()
+	sortFn := r.sortFn
+	r.lock.RUnlock()
+	switch r.GetSortType() {
+	case enums.RECENT_SORT_MRU:
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].GetModified().After(items[j].GetModified())
+		})
+	case enums.RECENT_SORT_LRU:
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].GetModified().Before(items[j].GetModified())
+		})
+	case enums.RECENT_SORT_CUSTOM:
+		if sortFn != nil {
+			sort.SliceStable(items, func(i, j int) bool {
+				return sortFn(items[i], items[j]) < 0
+			})
+		}
+	}
+	if limit := r.GetLimit(); limit >= 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return
+}
+
+// GetUris returns the URI of the recently used resources displayed by the
+// chooser.
+// See: GetRecentItems()
+func (r *recentChooser) GetUris() (uris []string) {
+	for _, info := range r.GetRecentItems() {
+		uris = append(uris, info.GetUri())
+	}
+	return
+}
+
+// AddFilter adds filter to the list of RecentFilter objects held by chooser.
+// If no previous filter objects were defined, this function will call
+// SetFilter.
+//
+// Parameters:
+//
+//	filter	a RecentFilter
+func (r *recentChooser) AddFilter(filter RecentFilter) {
+	if filter == nil {
+		return
+	}
+	r.lock.Lock()
+	r.filters = append(r.filters, filter)
+	r.lock.Unlock()
+	if r.GetFilter() == nil {
+		r.SetFilter(filter)
+	} else {
+		r.reload()
+	}
+}
+
+// RemoveFilter removes filter from the list of RecentFilter objects held by
+// chooser. If the filter is the current filter, the first remaining filter
+// becomes the current filter.
+//
+// Parameters:
+//
+//	filter	a RecentFilter
+func (r *recentChooser) RemoveFilter(filter RecentFilter) {
+	if filter == nil {
+		return
+	}
+	r.lock.Lock()
+	var filters []RecentFilter
+	for _, f := range r.filters {
+		if f.ObjectID() != filter.ObjectID() {
+			filters = append(filters, f)
+		}
+	}
+	r.filters = filters
+	r.lock.Unlock()
+	if current := r.GetFilter(); current != nil && current.ObjectID() == filter.ObjectID() {
+		if len(filters) > 0 {
+			r.SetFilter(filters[0])
+		} else {
+			r.SetFilter(nil)
+		}
+	} else {
+		r.reload()
+	}
+}
+
+// ListFilters returns the RecentFilter objects held by chooser.
+func (r *recentChooser) ListFilters() (filters []RecentFilter) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return append(filters, r.filters...)
+}
+
+// SetFilter sets filter as the current RecentFilter object used by chooser to
+// affect the displayed recently used resources.
+//
+// Parameters:
+//
+//	filter	a RecentFilter, or nil
+func (r *recentChooser) SetFilter(filter RecentFilter) {
+	var value interface{}
+	if filter != nil {
+		value = filter
+	}
+	if err := r.chooser.SetStructProperty(PropertyRecentChooserFilter, value); err != nil {
+		r.chooser.LogErr(err)
+		return
+	}
+	r.reload()
+}
+
+// GetFilter returns the RecentFilter object currently used by chooser to
+// affect the display of the recently used resources.
+func (r *recentChooser) GetFilter() (filter RecentFilter) {
+	if v, err := r.chooser.GetStructProperty(PropertyRecentChooserFilter); err != nil {
+		r.chooser.LogErr(err)
+	} else if v != nil {
+		var ok bool
+		if filter, ok = v.(RecentFilter); !ok {
+			r.chooser.LogError("invalid value stored in %v: %v (%T)", PropertyRecentChooserFilter, v, v)
+		}
+	}
+	return
+}
+
+func (r *recentChooser) setBool(property cdk.Property, value bool) {
+	if err := r.chooser.SetBoolProperty(property, value); err != nil {
+		r.chooser.LogErr(err)
+		return
+	}
+	r.reload()
+}
+
+func (r *recentChooser) getBool(property cdk.Property) (value bool) {
+	var err error
+	if value, err = r.chooser.GetBoolProperty(property); err != nil {
+		r.chooser.LogErr(err)
+	}
+	return
+}
+
+// findUri returns the index of the given uri within the items, or -1
+func (r *recentChooser) findUri(items []*RecentInfo, uri string) (index int) {
+	for idx, info := range items {
+		if info.GetUri() == uri {
+			return idx
+		}
+	}
+	return -1
+}
+
+// recentChooserFilterInfo returns the RecentFilterInfo for the given item,
+// with the needed fields filled in
+func recentChooserFilterInfo(info *RecentInfo, needed enums.RecentFilterFlags) (filterInfo RecentFilterInfo) {
+	filterInfo.Contains = enums.RECENT_FILTER_URI | enums.RECENT_FILTER_DISPLAY_NAME | enums.RECENT_FILTER_MIME_TYPE
+	filterInfo.Uri = info.GetUri()
+	filterInfo.DisplayName = info.GetDisplayName()
+	filterInfo.MimeType = info.GetMimeType()
+	if needed.Has(enums.RECENT_FILTER_APPLICATION) {
+		filterInfo.Contains = filterInfo.Contains.Set(enums.RECENT_FILTER_APPLICATION)
+		filterInfo.Applications = info.GetApplications()
+	}
+	if needed.Has(enums.RECENT_FILTER_GROUP) {
+		filterInfo.Contains = filterInfo.Contains.Set(enums.RECENT_FILTER_GROUP)
+		filterInfo.Groups = info.GetGroups()
+	}
+	if needed.Has(enums.RECENT_FILTER_AGE) {
+		filterInfo.Contains = filterInfo.Contains.Set(enums.RECENT_FILTER_AGE)
+		filterInfo.Age = info.GetAge()
+	}
+	return
+}
+
+// Whether the private items should be displayed.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyShowPrivate cdk.Property = "show-private"
+
+// Whether the items pointing to unavailable resources should be displayed.
+// Flags: Read / Write
+// Default value: TRUE
+const PropertyShowNotFound cdk.Property = "show-not-found"
+
+// The maximum number of items to be displayed.
+// Flags: Read / Write
+// Allowed values: >= -1
+// Default value: 50
+const PropertyRecentChooserLimit cdk.Property = "limit"
+
+// Whether this RecentChooser should display only local (file:) resources.
+// Flags: Read / Write
+// Default value: TRUE
+const PropertyLocalOnly cdk.Property = "local-only"
+
+// Sorting order to be used when displaying the recently used resources.
+// Flags: Read / Write
+// Default value: RECENT_SORT_MRU
+const PropertySortType cdk.Property = "sort-type"
+
+// The RecentFilter object to be used when displaying the recently used
+// resources.
+// Flags: Read / Write
+const PropertyRecentChooserFilter cdk.Property = "filter"
+
+// This signal is emitted when the user "activates" a recent item in the
+// recent chooser. This can happen by pressing Enter on one of the items of a
+// RecentChooserWidget or by activating one of the items of a
+// RecentChooserMenu.
+// Listener function arguments:
+//
+//	uri string	the activated URI
+const SignalItemActivated cdk.Signal = "item-activated"
+
+const RecentChooserManagerHandle = "recent-chooser-manager-handler"
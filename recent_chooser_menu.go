@@ -0,0 +1,328 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	cstrings "github.com/go-curses/cdk/lib/strings"
+)
+
+const TypeRecentChooserMenu cdk.CTypeTag = "ctk-recent-chooser-menu"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeRecentChooserMenu, func() interface{} { return MakeRecentChooserMenu() })
+	ctkBuilderTranslators[TypeRecentChooserMenu] = func(builder Builder, widget Widget, name, value string) error {
+		if strings.ReplaceAll(strings.ToLower(name), "_", "-") == "show-numbers" {
+			if rcm, ok := widget.Self().(RecentChooserMenu); ok {
+				rcm.SetShowNumbers(cstrings.IsTrue(value))
+				return nil
+			}
+		}
+		return recentChooserBuilderTranslator(builder, widget, name, value)
+	}
+}
+
+// RecentChooserMenu Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- MenuShell
+//	        +- Menu
+//	          +- RecentChooserMenu
+//
+// RecentChooserMenu is a widget suitable for displaying recently used files
+// inside a menu. It can be used to set a sub-menu of a MenuItem, typically the
+// "Open Recent" item of a "File" menu, or as the menu of a MenuToolButton.
+//
+// Each recently used resource is listed as a MenuItem labelled with the
+// display name of the resource, activating the MenuItem makes the resource the
+// current one and emits the item-activated signal. The recently used
+// resources are listed before any other MenuItem added to the menu.
+//
+// Note that RecentChooserMenu does not support multiple filters, as it has no
+// way to let the user choose between them as the RecentChooserWidget does.
+// Only the current filter of a RecentChooserMenu is used, which is the first
+// filter added unless changed with SetFilter.
+type RecentChooserMenu interface {
+	Menu
+	RecentChooser
+
+	Init() (already bool)
+	SetShowNumbers(showNumbers bool)
+	GetShowNumbers() (value bool)
+	GetRecentMenuItems() (items []MenuItem)
+}
+
+var _ RecentChooserMenu = (*CRecentChooserMenu)(nil)
+
+// The CRecentChooserMenu structure implements the RecentChooserMenu interface
+// and is exported to facilitate type embedding with custom implementations. No
+// member variables are exported as the interface methods are the only intended
+// means of interacting with RecentChooserMenu objects.
+type CRecentChooserMenu struct {
+	CMenu
+	recentChooser
+
+	current   string
+	items     []*RecentInfo
+	menuItems []MenuItem
+}
+
+// MakeRecentChooserMenu is used by the Buildable system to construct a new
+// RecentChooserMenu.
+func MakeRecentChooserMenu() RecentChooserMenu {
+	return NewRecentChooserMenu()
+}
+
+// NewRecentChooserMenu is the constructor for new RecentChooserMenu instances
+// listing the items of the default RecentManager.
+func NewRecentChooserMenu() RecentChooserMenu {
+	return NewRecentChooserMenuForManager(nil)
+}
+
+// NewRecentChooserMenuForManager is the constructor for new RecentChooserMenu
+// instances listing the items of the given RecentManager. This is useful if
+// you have implemented your own recent manager, or if you have a customized
+// instance of a RecentManager object.
+//
+// Parameters:
+//
+//	manager	a RecentManager, or nil for the default RecentManager
+func NewRecentChooserMenuForManager(manager RecentManager) RecentChooserMenu {
+	m := new(CRecentChooserMenu)
+	m.Init()
+	m.setRecentManager(manager)
+	return m
+}
+
+// Init initializes a RecentChooserMenu object. This must be called at least
+// once to set up the necessary defaults and allocate any memory structures.
+// Calling this more than once is safe though unnecessary. Only the first call
+// will result in any effect upon the RecentChooserMenu instance. Init is used
+// in the NewRecentChooserMenu constructor and only necessary when implementing
+// a derivative RecentChooserMenu type.
+func (m *CRecentChooserMenu) Init() (already bool) {
+	if m.InitTypeItem(TypeRecentChooserMenu, m) {
+		return true
+	}
+	m.CMenu.Init()
+	m.recentChooser.init(m, m.reload)
+	_ = m.InstallBuildableProperty(PropertyShowNumbers, cdk.BoolProperty, true, false)
+	m.current = ""
+	m.items = make([]*RecentInfo, 0)
+	m.menuItems = make([]MenuItem, 0)
+	return false
+}
+
+// SetShowNumbers sets whether a number should be added to the items of menu.
+// The numbers are shown to provide a unique character for a mnemonic to be
+// used inside the menu item's label. Only the first ten items get a mnemonic.
+//
+// Parameters:
+//
+//	showNumbers	whether to show numbers
+func (m *CRecentChooserMenu) SetShowNumbers(showNumbers bool) {
+	m.setBool(PropertyShowNumbers, showNumbers)
+}
+
+// GetShowNumbers returns the value set by SetShowNumbers.
+func (m *CRecentChooserMenu) GetShowNumbers() (value bool) {
+	return m.getBool(PropertyShowNumbers)
+}
+
+// SetSelectMultiple is not supported by RecentChooserMenu, enabling multiple
+// selection logs an error.
+//
+// Parameters:
+//
+//	selectMultiple	TRUE if chooser can select more than one item
+func (m *CRecentChooserMenu) SetSelectMultiple(selectMultiple bool) {
+	if selectMultiple {
+		m.LogError("multiple selection is not supported by recent chooser menus")
+		return
+	}
+	m.recentChooser.SetSelectMultiple(false)
+}
+
+// SetCurrentUri sets uri as the current URI for the menu, selecting the item
+// of the resource.
+//
+// Parameters:
+//
+//	uri	a URI
+func (m *CRecentChooserMenu) SetCurrentUri(uri string) (err error) {
+	return m.SelectUri(uri)
+}
+
+// GetCurrentUri returns the URI of the last activated or selected item of the
+// menu.
+func (m *CRecentChooserMenu) GetCurrentUri() (uri string) {
+	m.RLock()
+	defer m.RUnlock()
+	return m.current
+}
+
+// GetCurrentItem returns a copy of the meta-data of the last activated or
+// selected item of the menu, or nil if there is none.
+func (m *CRecentChooserMenu) GetCurrentItem() (info *RecentInfo) {
+	if uri := m.GetCurrentUri(); uri != "" {
+		if manager := m.GetRecentManager(); manager != nil {
+			info, _ = manager.LookupItem(uri)
+		}
+	}
+	return
+}
+
+// SelectUri selects the item of the menu displaying uri, making it the current
+// URI. Returns ErrRecentChooserNotFound if the uri is not displayed.
+//
+// Emits: SignalSelectionChanged, Argv=[RecentChooserMenu instance]
+//
+// Parameters:
+//
+//	uri	a URI
+func (m *CRecentChooserMenu) SelectUri(uri string) (err error) {
+	m.RLock()
+	index := m.findUri(m.items, uri)
+	var item MenuItem
+	if index >= 0 {
+		item = m.menuItems[index]
+	}
+	m.RUnlock()
+	if item == nil {
+		return fmt.Errorf("%w: %v", ErrRecentChooserNotFound, uri)
+	}
+	m.SelectItem(item)
+	m.Lock()
+	m.current = uri
+	m.Unlock()
+	m.Emit(SignalSelectionChanged, m)
+	return nil
+}
+
+// UnselectUri unselects uri if it is the current URI of the menu.
+//
+// Parameters:
+//
+//	uri	a URI
+func (m *CRecentChooserMenu) UnselectUri(uri string) {
+	if uri != "" && uri == m.GetCurrentUri() {
+		m.UnselectAll()
+	}
+}
+
+// SelectAll is not supported by RecentChooserMenu and logs an error.
+func (m *CRecentChooserMenu) SelectAll() {
+	m.LogError("multiple selection is not supported by recent chooser menus")
+}
+
+// UnselectAll clears the current URI of the menu.
+//
+// Emits: SignalSelectionChanged, Argv=[RecentChooserMenu instance]
+func (m *CRecentChooserMenu) UnselectAll() {
+	m.Lock()
+	changed := m.current != ""
+	m.current = ""
+	m.Unlock()
+	m.Deselect()
+	if changed {
+		m.Emit(SignalSelectionChanged, m)
+	}
+}
+
+// GetRecentMenuItems returns the MenuItem widgets listing the recently used
+// resources, in the order of GetRecentItems.
+func (m *CRecentChooserMenu) GetRecentMenuItems() (items []MenuItem) {
+	m.RLock()
+	defer m.RUnlock()
+	if len(m.items) > 0 {
+		items = append(items, m.menuItems...)
+	}
+	return
+}
+
+// reload replaces the MenuItem widgets of the recently used resources
+func (m *CRecentChooserMenu) reload() {
+	items := m.GetRecentItems()
+	showNumbers := m.GetShowNumbers()
+	m.Lock()
+	previous := m.menuItems
+	m.menuItems = make([]MenuItem, 0)
+	m.items = items
+	m.Unlock()
+	for _, item := range previous {
+		m.Remove(item)
+		item.Destroy()
+	}
+	var menuItems []MenuItem
+	if len(items) == 0 {
+		empty := NewMenuItemWithLabel("No items found")
+		empty.SetSensitive(false)
+		menuItems = append(menuItems, empty)
+	}
+	for idx, info := range items {
+		var item MenuItem
+		name := info.GetDisplayName()
+		if showNumbers {
+			name = strings.ReplaceAll(name, "_", "__")
+			switch {
+			case idx < 9:
+				item = NewMenuItemWithMnemonic(fmt.Sprintf("_%d. %s", idx+1, name))
+			case idx == 9:
+				item = NewMenuItemWithMnemonic(fmt.Sprintf("1_0. %s", name))
+			default:
+				item = NewMenuItemWithMnemonic(fmt.Sprintf("%d. %s", idx+1, name))
+			}
+		} else {
+			item = NewMenuItemWithLabel(name)
+		}
+		uri := info.GetUri()
+		item.Connect(SignalActivate, RecentChooserMenuItemActivateHandle, func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+			m.itemActivated(uri)
+			return cenums.EVENT_PASS
+		})
+		menuItems = append(menuItems, item)
+	}
+	for idx, item := range menuItems {
+		item.Show()
+		m.Insert(item, idx)
+	}
+	m.Lock()
+	m.menuItems = menuItems
+	if m.findUri(items, m.current) < 0 {
+		m.current = ""
+	}
+	m.Unlock()
+}
+
+func (m *CRecentChooserMenu) itemActivated(uri string) {
+	m.Lock()
+	m.current = uri
+	m.Unlock()
+	m.Emit(SignalSelectionChanged, m)
+	m.Emit(SignalItemActivated, m, uri)
+}
+
+// Whether the items should be displayed with a number.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyShowNumbers cdk.Property = "show-numbers"
+
+const RecentChooserMenuItemActivateHandle = "recent-chooser-menu-item-activate-handler"
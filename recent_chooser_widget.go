@@ -0,0 +1,379 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeRecentChooserWidget cdk.CTypeTag = "ctk-recent-chooser-widget"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeRecentChooserWidget, func() interface{} { return MakeRecentChooserWidget() })
+	ctkBuilderTranslators[TypeRecentChooserWidget] = recentChooserBuilderTranslator
+}
+
+const (
+	recentChooserColumnName int = iota
+	recentChooserColumnLocation
+	recentChooserColumnModified
+)
+
+// RecentChooserWidget Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Box
+//	        +- VBox
+//	          +- RecentChooserWidget
+//
+// RecentChooserWidget is a widget suitable for selecting recently used files,
+// for example within a Dialog presenting the recently used documents of an
+// editor. For an "Open Recent" menu see RecentChooserMenu.
+//
+// The RecentChooserWidget lists the name, location and modification time of
+// the recently used resources. Pressing Enter on one of the resources emits
+// the item-activated signal. When more than one RecentFilter is added, a
+// Button cycling through the filters is shown below the list.
+type RecentChooserWidget interface {
+	VBox
+	RecentChooser
+	Buildable
+
+	Init() (already bool)
+	GetRecentList() (treeView TreeView)
+	GetSelectedUris() (uris []string)
+}
+
+var _ RecentChooserWidget = (*CRecentChooserWidget)(nil)
+
+// The CRecentChooserWidget structure implements the RecentChooserWidget
+// interface and is exported to facilitate type embedding with custom
+// implementations. No member variables are exported as the interface methods
+// are the only intended means of interacting with RecentChooserWidget objects.
+type CRecentChooserWidget struct {
+	CVBox
+	recentChooser
+
+	items     []*RecentInfo
+	reloading bool
+
+	store        ListStore
+	view         TreeView
+	filterButton Button
+}
+
+// MakeRecentChooserWidget is used by the Buildable system to construct a new
+// RecentChooserWidget.
+func MakeRecentChooserWidget() RecentChooserWidget {
+	return NewRecentChooserWidget()
+}
+
+// NewRecentChooserWidget is the constructor for new RecentChooserWidget
+// instances listing the items of the default RecentManager.
+func NewRecentChooserWidget() RecentChooserWidget {
+	return NewRecentChooserWidgetForManager(nil)
+}
+
+// NewRecentChooserWidgetForManager is the constructor for new
+// RecentChooserWidget instances listing the items of the given RecentManager.
+// This is useful if you have implemented your own recent manager, or if you
+// have a customized instance of a RecentManager object.
+//
+// Parameters:
+//
+//	manager	a RecentManager, or nil for the default RecentManager
+func NewRecentChooserWidgetForManager(manager RecentManager) RecentChooserWidget {
+	rc := new(CRecentChooserWidget)
+	rc.Init()
+	rc.setRecentManager(manager)
+	return rc
+}
+
+// Init initializes a RecentChooserWidget object. This must be called at least
+// once to set up the necessary defaults and allocate any memory structures.
+// Calling this more than once is safe though unnecessary. Only the first call
+// will result in any effect upon the RecentChooserWidget instance. Init is
+// used in the NewRecentChooserWidget constructor and only necessary when
+// implementing a derivative RecentChooserWidget type.
+func (rc *CRecentChooserWidget) Init() (already bool) {
+	if rc.InitTypeItem(TypeRecentChooserWidget, rc) {
+		return true
+	}
+	rc.CVBox.Init()
+	rc.recentChooser.init(rc, rc.reload)
+	rc.items = make([]*RecentInfo, 0)
+
+	rc.store = NewListStore(cdk.StringProperty, cdk.StringProperty, cdk.StringProperty)
+	rc.view = NewTreeViewWithModel(rc.store)
+	rc.view.SetHeadersClickable(false)
+	nameColumn := NewTreeViewColumnWithAttributes("Name", TreeViewColumnAttributeText, recentChooserColumnName)
+	nameColumn.SetExpand(true)
+	rc.view.AppendColumn(nameColumn)
+	locationColumn := NewTreeViewColumnWithAttributes("Location", TreeViewColumnAttributeText, recentChooserColumnLocation)
+	locationColumn.SetExpand(true)
+	rc.view.AppendColumn(locationColumn)
+	rc.view.AppendColumn(NewTreeViewColumnWithAttributes("Modified", TreeViewColumnAttributeText, recentChooserColumnModified))
+	rc.view.Show()
+	rc.view.Connect(SignalRowActivated, RecentChooserWidgetRowActivatedHandle, rc.rowActivated)
+	rc.view.GetSelection().Connect(
+		SignalChanged,
+		fmt.Sprintf("%v-%v", RecentChooserWidgetSelectionHandle, rc.ObjectID()),
+		rc.selectionChanged,
+	)
+	rc.PackStart(rc.view, true, true, 0)
+
+	rc.filterButton = NewButtonWithLabel("")
+	rc.filterButton.Connect(SignalClicked, RecentChooserWidgetFilterClickedHandle, rc.filterClicked)
+	rc.PackStart(rc.filterButton, false, false, 0)
+	return false
+}
+
+// GetRecentList returns the TreeView listing the recently used resources.
+func (rc *CRecentChooserWidget) GetRecentList() (treeView TreeView) {
+	rc.RLock()
+	defer rc.RUnlock()
+	return rc.view
+}
+
+// SetSelectMultiple sets whether chooser can select multiple items.
+//
+// Parameters:
+//
+//	selectMultiple	TRUE if chooser can select more than one item
+func (rc *CRecentChooserWidget) SetSelectMultiple(selectMultiple bool) {
+	rc.recentChooser.SetSelectMultiple(selectMultiple)
+	if selectMultiple {
+		rc.view.GetSelection().SetMode(enums.SELECTION_MULTIPLE)
+	} else {
+		rc.view.GetSelection().SetMode(enums.SELECTION_SINGLE)
+	}
+}
+
+// SetCurrentUri sets uri as the current URI for chooser, unselecting any
+// other item. Returns ErrRecentChooserNotFound if the uri is not displayed.
+//
+// Parameters:
+//
+//	uri	a URI
+func (rc *CRecentChooserWidget) SetCurrentUri(uri string) (err error) {
+	path := rc.pathOfUri(uri)
+	if path == nil {
+		return fmt.Errorf("%w: %v", ErrRecentChooserNotFound, uri)
+	}
+	rc.view.GetSelection().UnselectAll()
+	rc.view.SetCursor(path, nil)
+	rc.view.GetSelection().SelectPath(path)
+	return nil
+}
+
+// GetCurrentUri returns the URI of the currently selected item, or the first
+// selected item if multiple items are selected.
+func (rc *CRecentChooserWidget) GetCurrentUri() (uri string) {
+	if info := rc.GetCurrentItem(); info != nil {
+		uri = info.GetUri()
+	}
+	return
+}
+
+// GetCurrentItem returns a copy of the meta-data of the currently selected
+// item, or nil if there is none.
+func (rc *CRecentChooserWidget) GetCurrentItem() (info *RecentInfo) {
+	if selected := rc.getSelectedItems(); len(selected) > 0 {
+		return selected[0]
+	}
+	return nil
+}
+
+// SelectUri selects uri inside chooser. Returns ErrRecentChooserNotFound if
+// the uri is not displayed.
+//
+// Parameters:
+//
+//	uri	a URI
+func (rc *CRecentChooserWidget) SelectUri(uri string) (err error) {
+	path := rc.pathOfUri(uri)
+	if path == nil {
+		return fmt.Errorf("%w: %v", ErrRecentChooserNotFound, uri)
+	}
+	selection := rc.view.GetSelection()
+	if selection.GetMode() != enums.SELECTION_MULTIPLE {
+		rc.view.SetCursor(path, nil)
+	}
+	selection.SelectPath(path)
+	return nil
+}
+
+// UnselectUri unselects uri inside chooser.
+//
+// Parameters:
+//
+//	uri	a URI
+func (rc *CRecentChooserWidget) UnselectUri(uri string) {
+	if path := rc.pathOfUri(uri); path != nil {
+		rc.view.GetSelection().UnselectPath(path)
+	}
+}
+
+// SelectAll selects all the items inside chooser, if the chooser supports
+// multiple selection.
+func (rc *CRecentChooserWidget) SelectAll() {
+	if rc.GetSelectMultiple() {
+		rc.view.GetSelection().SelectAll()
+	}
+}
+
+// UnselectAll unselects all the items inside chooser.
+func (rc *CRecentChooserWidget) UnselectAll() {
+	rc.view.GetSelection().UnselectAll()
+}
+
+// GetSelectedUris returns the URI of all the selected items.
+func (rc *CRecentChooserWidget) GetSelectedUris() (uris []string) {
+	for _, info := range rc.getSelectedItems() {
+		uris = append(uris, info.GetUri())
+	}
+	return
+}
+
+func (rc *CRecentChooserWidget) pathOfUri(uri string) (path TreePath) {
+	rc.RLock()
+	defer rc.RUnlock()
+	if idx := rc.findUri(rc.items, uri); idx >= 0 {
+		return NewTreePathFromIndices(idx)
+	}
+	return nil
+}
+
+func (rc *CRecentChooserWidget) getSelectedItems() (items []*RecentInfo) {
+	_, paths := rc.view.GetSelection().GetSelectedRows()
+	rc.RLock()
+	defer rc.RUnlock()
+	for _, path := range paths {
+		if path.GetDepth() == 1 {
+			if idx := path.GetIndices()[0]; idx >= 0 && idx < len(rc.items) {
+				items = append(items, rc.items[idx].clone())
+			}
+		}
+	}
+	return
+}
+
+// reload replaces the contents of the list with the recently used resources,
+// keeping the selected items selected
+func (rc *CRecentChooserWidget) reload() {
+	selected := rc.GetSelectedUris()
+	items := rc.GetRecentItems()
+	rc.Lock()
+	rc.reloading = true
+	rc.items = items
+	rc.Unlock()
+	rc.view.GetSelection().UnselectAll()
+	rc.store.Clear()
+	for _, info := range items {
+		location := info.GetUriDisplay()
+		if info.IsLocal() {
+			location = filepath.Dir(location)
+		}
+		modified := info.GetModified().Local().Format("2006-01-02 15:04")
+		if _, err := rc.store.InsertWithValues(-1, []int{recentChooserColumnName, recentChooserColumnLocation, recentChooserColumnModified}, []interface{}{info.GetDisplayName(), location, modified}); err != nil {
+			rc.LogErr(err)
+		}
+	}
+	for _, uri := range selected {
+		_ = rc.SelectUri(uri)
+	}
+	rc.Lock()
+	rc.reloading = false
+	rc.Unlock()
+	rc.updateFilterButton()
+	rc.Emit(SignalSelectionChanged, rc)
+	rc.Invalidate()
+}
+
+func (rc *CRecentChooserWidget) updateFilterButton() {
+	if len(rc.ListFilters()) < 2 {
+		rc.filterButton.Hide()
+		return
+	}
+	label := "All Files"
+	if filter := rc.GetFilter(); filter != nil {
+		label = filter.GetName()
+	}
+	rc.filterButton.SetLabel(label)
+	rc.filterButton.Show()
+}
+
+func (rc *CRecentChooserWidget) rowActivated(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if len(argv) < 2 {
+		return cenums.EVENT_PASS
+	}
+	path, ok := argv[1].(TreePath)
+	if !ok || path.GetDepth() != 1 {
+		return cenums.EVENT_PASS
+	}
+	rc.RLock()
+	var info *RecentInfo
+	if idx := path.GetIndices()[0]; idx >= 0 && idx < len(rc.items) {
+		info = rc.items[idx]
+	}
+	rc.RUnlock()
+	if info != nil {
+		rc.Emit(SignalItemActivated, rc, info.GetUri())
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+func (rc *CRecentChooserWidget) selectionChanged(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	rc.RLock()
+	reloading := rc.reloading
+	rc.RUnlock()
+	if !reloading {
+		rc.Emit(SignalSelectionChanged, rc)
+	}
+	return cenums.EVENT_PASS
+}
+
+func (rc *CRecentChooserWidget) filterClicked(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	filters := rc.ListFilters()
+	if len(filters) == 0 {
+		return cenums.EVENT_PASS
+	}
+	next := filters[0]
+	if current := rc.GetFilter(); current != nil {
+		for idx, filter := range filters {
+			if filter.ObjectID() == current.ObjectID() {
+				next = filters[(idx+1)%len(filters)]
+				break
+			}
+		}
+	}
+	rc.SetFilter(next)
+	return cenums.EVENT_STOP
+}
+
+const RecentChooserWidgetRowActivatedHandle = "recent-chooser-widget-row-activated-handler"
+
+const RecentChooserWidgetSelectionHandle = "recent-chooser-widget-selection-handler"
+
+const RecentChooserWidgetFilterClickedHandle = "recent-chooser-widget-filter-clicked-handler"
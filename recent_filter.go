@@ -0,0 +1,274 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/go-curses/cdk"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeRecentFilter cdk.CTypeTag = "ctk-recent-filter"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeRecentFilter, func() interface{} { return MakeRecentFilter() })
+}
+
+// RecentFilterInfo is used to pass information about the tested resource to
+// RecentFilter.Filter. The Contains field indicates which of the other fields
+// are filled in.
+type RecentFilterInfo struct {
+	Contains     enums.RecentFilterFlags
+	Uri          string
+	DisplayName  string
+	MimeType     string
+	Applications []string
+	Groups       []string
+	Age          int
+}
+
+// RecentFilterFunc is the signature of the callbacks given to
+// RecentFilter.AddCustom. The function returns TRUE if the resource should be
+// displayed.
+type RecentFilterFunc = func(info RecentFilterInfo) (include bool)
+
+type recentFilterRule struct {
+	needed enums.RecentFilterFlags
+	value  string
+	age    int
+	fn     RecentFilterFunc
+}
+
+// RecentFilter Hierarchy:
+//
+//	Object
+//	  +- RecentFilter
+//
+// A RecentFilter can be used to restrict the files being shown in a
+// RecentChooser. Files can be filtered based on their name (with AddPattern),
+// their mime type (with AddMimeType), the application that has registered them
+// (with AddApplication), the groups they belong to (with AddGroup), their age
+// (with AddAge) or with a custom filter function (with AddCustom). A resource
+// is shown if it matches any of the rules of the filter, so a filter without
+// rules shows no resources at all. The name of the RecentFilter, from
+// Object.SetName, is used as the human-readable label of the filter.
+type RecentFilter interface {
+	Object
+
+	Init() (already bool)
+	AddPattern(pattern string)
+	AddMimeType(mimeType string)
+	AddApplication(application string)
+	AddGroup(group string)
+	AddAge(days int)
+	AddCustom(needed enums.RecentFilterFlags, fn RecentFilterFunc)
+	GetNeeded() (needed enums.RecentFilterFlags)
+	Filter(info RecentFilterInfo) (include bool)
+}
+
+var _ RecentFilter = (*CRecentFilter)(nil)
+
+// The CRecentFilter structure implements the RecentFilter interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with RecentFilter objects.
+type CRecentFilter struct {
+	CObject
+
+	rules []*recentFilterRule
+}
+
+// MakeRecentFilter is used by the Buildable system to construct a new
+// RecentFilter.
+func MakeRecentFilter() RecentFilter {
+	return NewRecentFilter()
+}
+
+// NewRecentFilter is the constructor for new RecentFilter instances. The new
+// filter has no rules and therefore does not accept any resources.
+func NewRecentFilter() RecentFilter {
+	f := new(CRecentFilter)
+	f.Init()
+	return f
+}
+
+// Init initializes a RecentFilter object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the RecentFilter instance. Init is used in the
+// NewRecentFilter constructor and only necessary when implementing a
+// derivative RecentFilter type.
+func (f *CRecentFilter) Init() (already bool) {
+	if f.InitTypeItem(TypeRecentFilter, f) {
+		return true
+	}
+	f.CObject.Init()
+	f.rules = make([]*recentFilterRule, 0)
+	return false
+}
+
+// AddPattern adds a rule that allows resources based on a pattern matching
+// their display name, using the syntax of filepath.Match.
+//
+// Parameters:
+//
+//	pattern	a shell style glob
+func (f *CRecentFilter) AddPattern(pattern string) {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		f.LogError("invalid pattern %q: %v", pattern, err)
+		return
+	}
+	f.addRule(&recentFilterRule{needed: enums.RECENT_FILTER_DISPLAY_NAME, value: pattern})
+}
+
+// AddMimeType adds a rule that allows resources based on their registered MIME
+// type. A MIME type ending in "/*" allows all the subtypes of the given type,
+// ie: "text/*".
+//
+// Parameters:
+//
+//	mimeType	a MIME type
+func (f *CRecentFilter) AddMimeType(mimeType string) {
+	f.addRule(&recentFilterRule{needed: enums.RECENT_FILTER_MIME_TYPE, value: mimeType})
+}
+
+// AddApplication adds a rule that allows resources based on the name of the
+// application that has registered them.
+//
+// Parameters:
+//
+//	application	an application name
+func (f *CRecentFilter) AddApplication(application string) {
+	f.addRule(&recentFilterRule{needed: enums.RECENT_FILTER_APPLICATION, value: application})
+}
+
+// AddGroup adds a rule that allows resources based on the name of the group
+// to which they belong.
+//
+// Parameters:
+//
+//	group	a group name
+func (f *CRecentFilter) AddGroup(group string) {
+	f.addRule(&recentFilterRule{needed: enums.RECENT_FILTER_GROUP, value: group})
+}
+
+// AddAge adds a rule that allows resources based on their age - that is, the
+// number of days elapsed since they were last modified.
+//
+// Parameters:
+//
+//	days	number of days
+func (f *CRecentFilter) AddAge(days int) {
+	f.addRule(&recentFilterRule{needed: enums.RECENT_FILTER_AGE, age: days})
+}
+
+// AddCustom adds a rule to a filter that allows resources based on a custom
+// callback function. The bitfield needed which is passed in provides
+// information about what sorts of information that the filter function needs;
+// this allows CTK to avoid retrieving expensive information when it isn't
+// needed by the filter.
+//
+// Parameters:
+//
+//	needed	bitfield of flags indicating the information that the custom filter function needs.
+//	fn	callback function; if the function returns TRUE, then the resource will be displayed.
+func (f *CRecentFilter) AddCustom(needed enums.RecentFilterFlags, fn RecentFilterFunc) {
+	if fn == nil {
+		return
+	}
+	f.addRule(&recentFilterRule{needed: needed, fn: fn})
+}
+
+// GetNeeded gets the fields that need to be filled in for the
+// RecentFilterInfo passed to Filter. This function will not typically be used
+// by applications; it is intended principally for use in the implementation of
+// RecentChooser.
+func (f *CRecentFilter) GetNeeded() (needed enums.RecentFilterFlags) {
+	f.RLock()
+	defer f.RUnlock()
+	for _, rule := range f.rules {
+		needed = needed.Set(rule.needed)
+	}
+	return
+}
+
+// Filter tests whether a resource should be displayed according to filter.
+// The RecentFilterInfo structure info should include the fields returned from
+// GetNeeded. This function will not typically be used by applications; it is
+// intended principally for use in the implementation of RecentChooser.
+//
+// Parameters:
+//
+//	info	a RecentFilterInfo structure containing information about a resource.
+func (f *CRecentFilter) Filter(info RecentFilterInfo) (include bool) {
+	f.RLock()
+	rules := append([]*recentFilterRule{}, f.rules...)
+	f.RUnlock()
+	for _, rule := range rules {
+		if info.Contains&rule.needed != rule.needed {
+			continue
+		}
+		if rule.fn != nil {
+			if rule.fn(info) {
+				return true
+			}
+			continue
+		}
+		switch rule.needed {
+		case enums.RECENT_FILTER_DISPLAY_NAME:
+			if matched, _ := filepath.Match(rule.value, info.DisplayName); matched {
+				return true
+			}
+		case enums.RECENT_FILTER_MIME_TYPE:
+			if recentFilterMimeMatch(rule.value, info.MimeType) {
+				return true
+			}
+		case enums.RECENT_FILTER_APPLICATION:
+			for _, application := range info.Applications {
+				if application == rule.value {
+					return true
+				}
+			}
+		case enums.RECENT_FILTER_GROUP:
+			for _, group := range info.Groups {
+				if group == rule.value {
+					return true
+				}
+			}
+		case enums.RECENT_FILTER_AGE:
+			if info.Age <= rule.age {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (f *CRecentFilter) addRule(rule *recentFilterRule) {
+	f.Lock()
+	f.rules = append(f.rules, rule)
+	f.Unlock()
+}
+
+func recentFilterMimeMatch(pattern, mimeType string) bool {
+	pattern, mimeType = strings.ToLower(pattern), strings.ToLower(mimeType)
+	if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+		return strings.HasPrefix(mimeType, prefix+"/")
+	}
+	return pattern == mimeType
+}
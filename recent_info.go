@@ -0,0 +1,275 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// RecentData holds the meta-data to be passed to RecentManager.AddFull when
+// registering a recently used resource.
+//
+// The MimeType, AppName and AppExec fields are required. AppExec is the
+// command line used to launch the application, where "%u" is replaced with
+// the URI of the resource and "%f" with its local filename.
+type RecentData struct {
+	DisplayName string
+	Description string
+	MimeType    string
+	AppName     string
+	AppExec     string
+	Groups      []string
+	IsPrivate   bool
+}
+
+// recentApplication is the registration of an application with a RecentInfo
+type recentApplication struct {
+	name  string
+	exec  string
+	count int
+	stamp time.Time
+}
+
+// RecentInfo contains all the meta-data associated with an entry in the
+// recently used files list. RecentInfo values returned by the RecentManager
+// are copies of the entries it manages, changing the RecentManager does not
+// change any previously returned RecentInfo.
+type RecentInfo struct {
+	uri          string
+	displayName  string
+	description  string
+	mimeType     string
+	added        time.Time
+	modified     time.Time
+	visited      time.Time
+	private      bool
+	applications []*recentApplication
+	groups       []string
+}
+
+// RecentUriFromFilename returns the "file://" URI of the given local filename,
+// suitable for use with RecentManager.AddItem. Relative filenames are made
+// absolute within the current working directory.
+func RecentUriFromFilename(filename string) (uri string) {
+	if abs, err := filepath.Abs(filename); err == nil {
+		filename = abs
+	}
+	u := &url.URL{Scheme: "file", Path: filepath.ToSlash(filename)}
+	return u.String()
+}
+
+// GetUri returns the URI of the resource.
+func (r *RecentInfo) GetUri() (uri string) {
+	return r.uri
+}
+
+// GetDisplayName returns the name of the resource. If none has been defined,
+// the basename of the resource is obtained.
+func (r *RecentInfo) GetDisplayName() (name string) {
+	if r.displayName != "" {
+		return r.displayName
+	}
+	return r.GetShortName()
+}
+
+// GetDescription returns the (short) description of the resource.
+func (r *RecentInfo) GetDescription() (description string) {
+	return r.description
+}
+
+// GetMimeType returns the MIME type of the resource.
+func (r *RecentInfo) GetMimeType() (mimeType string) {
+	return r.mimeType
+}
+
+// GetAdded returns the time of when the resource was added to the recently
+// used resources list.
+func (r *RecentInfo) GetAdded() (added time.Time) {
+	return r.added
+}
+
+// GetModified returns the time of when the meta-data for the resource was last
+// modified.
+func (r *RecentInfo) GetModified() (modified time.Time) {
+	return r.modified
+}
+
+// GetVisited returns the time of when the meta-data for the resource was last
+// visited.
+func (r *RecentInfo) GetVisited() (visited time.Time) {
+	return r.visited
+}
+
+// GetPrivateHint returns whether the resource should be displayed only by the
+// applications that have registered it.
+func (r *RecentInfo) GetPrivateHint() (private bool) {
+	return r.private
+}
+
+// GetApplicationInfo returns the data regarding the application that has
+// registered the resource. If the application has registered the resource
+// more than once, the count is the number of registrations and the stamp is
+// the time of the last registration.
+//
+// Parameters:
+//
+//	appName	the name of the application that has registered this item
+func (r *RecentInfo) GetApplicationInfo(appName string) (appExec string, count int, stamp time.Time, ok bool) {
+	for _, app := range r.applications {
+		if app.name == appName {
+			return app.exec, app.count, app.stamp, true
+		}
+	}
+	return "", 0, time.Time{}, false
+}
+
+// GetApplications returns the names of the applications that have registered
+// the resource.
+func (r *RecentInfo) GetApplications() (appNames []string) {
+	for _, app := range r.applications {
+		appNames = append(appNames, app.name)
+	}
+	return
+}
+
+// LastApplication returns the name of the last application that has
+// registered the resource.
+func (r *RecentInfo) LastApplication() (appName string) {
+	var last time.Time
+	for _, app := range r.applications {
+		if appName == "" || app.stamp.After(last) {
+			appName, last = app.name, app.stamp
+		}
+	}
+	return
+}
+
+// HasApplication checks whether an application registered this resource
+// using appName.
+//
+// Parameters:
+//
+//	appName	a string containing an application name
+func (r *RecentInfo) HasApplication(appName string) (ok bool) {
+	_, _, _, ok = r.GetApplicationInfo(appName)
+	return
+}
+
+// GetGroups returns all groups registered for the recently used item.
+func (r *RecentInfo) GetGroups() (groups []string) {
+	return append(groups, r.groups...)
+}
+
+// HasGroup checks whether groupName appears inside the groups registered for
+// the recently used item.
+//
+// Parameters:
+//
+//	groupName	name of a group
+func (r *RecentInfo) HasGroup(groupName string) (ok bool) {
+	for _, group := range r.groups {
+		if group == groupName {
+			return true
+		}
+	}
+	return false
+}
+
+// GetShortName computes a valid UTF-8 string that can be used as the name of
+// the item in a menu or list. For example, calling this function on an item
+// that refers to "file:///foo/bar.txt" will yield "bar.txt".
+func (r *RecentInfo) GetShortName() (name string) {
+	if u, err := url.Parse(r.uri); err == nil && u.Path != "" {
+		return path.Base(u.Path)
+	}
+	return r.uri
+}
+
+// GetUriDisplay returns a displayable version of the URI of the resource. If
+// the resource is local, it returns a local path; if the resource is not
+// local, it returns the UTF-8 encoded content of the URI.
+func (r *RecentInfo) GetUriDisplay() (display string) {
+	if filename, ok := r.getFilename(); ok {
+		return filename
+	}
+	if unescaped, err := url.PathUnescape(r.uri); err == nil {
+		return unescaped
+	}
+	return r.uri
+}
+
+// GetAge returns the number of days elapsed since the last update of the
+// resource pointed by the RecentInfo.
+func (r *RecentInfo) GetAge() (days int) {
+	return int(time.Since(r.modified).Hours() / 24)
+}
+
+// IsLocal checks whether the resource is local or not by looking at the scheme
+// of its URI.
+func (r *RecentInfo) IsLocal() (local bool) {
+	_, local = r.getFilename()
+	return
+}
+
+// Exists checks whether the resource pointed by the RecentInfo still exists.
+// At the moment this check is done only on resources pointing to local files.
+func (r *RecentInfo) Exists() (exists bool) {
+	if filename, ok := r.getFilename(); ok {
+		_, err := os.Stat(filename)
+		return err == nil
+	}
+	return false
+}
+
+// Match checks whether two RecentInfo structures point to the same resource.
+//
+// Parameters:
+//
+//	other	a RecentInfo
+func (r *RecentInfo) Match(other *RecentInfo) (match bool) {
+	return other != nil && r.uri == other.uri
+}
+
+func (r *RecentInfo) getFilename() (filename string, ok bool) {
+	if u, err := url.Parse(r.uri); err == nil && u.Scheme == "file" {
+		return filepath.FromSlash(u.Path), true
+	}
+	return "", false
+}
+
+func (r *RecentInfo) getApplication(appName string) (app *recentApplication) {
+	for _, app = range r.applications {
+		if app.name == appName {
+			return app
+		}
+	}
+	return nil
+}
+
+func (r *RecentInfo) clone() (c *RecentInfo) {
+	c = new(RecentInfo)
+	*c = *r
+	c.groups = append([]string{}, r.groups...)
+	c.applications = make([]*recentApplication, len(r.applications))
+	for idx, app := range r.applications {
+		dup := *app
+		c.applications[idx] = &dup
+	}
+	return
+}
@@ -0,0 +1,743 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-curses/cdk"
+)
+
+const TypeRecentManager cdk.CTypeTag = "ctk-recent-manager"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeRecentManager, func() interface{} { return MakeRecentManager() })
+}
+
+var (
+	ErrRecentManagerNotFound      = fmt.Errorf("recent item not found")
+	ErrRecentManagerInvalidUri    = fmt.Errorf("invalid recent item uri")
+	ErrRecentManagerNotRegistered = fmt.Errorf("recent item not registered")
+	ErrRecentManagerRead          = fmt.Errorf("error reading recent items")
+	ErrRecentManagerWrite         = fmt.Errorf("error writing recent items")
+)
+
+const (
+	recentXbelBookmarkNamespace = "http://www.freedesktop.org/standards/desktop-bookmarks"
+	recentXbelMimeNamespace     = "http://www.freedesktop.org/standards/shared-mime-info"
+	recentXbelTimeFormat        = "2006-01-02T15:04:05.000000Z"
+)
+
+var ctkDefaultRecentManager RecentManager
+
+// RecentManager Hierarchy:
+//
+//	Object
+//	  +- RecentManager
+//
+// RecentManager provides a facility for adding, removing and looking up
+// recently used files. Each recently used file is identified by its URI, and
+// has meta-data associated to it, like the names and command lines of the
+// applications that have registered it, the number of time each application
+// has registered the same file, the mime type of the file and whether the file
+// should be displayed only by the applications that have registered it.
+//
+// The recently used files list is stored in an XBEL file shared with other
+// desktop applications, by default "recently-used.xbel" within the data
+// directory of the user ($XDG_DATA_HOME or ~/.local/share). Every change is
+// written to the file immediately and changes made to the file by other
+// processes are picked up the next time the list is read.
+//
+// You should use GetDefaultRecentManager to retrieve the RecentManager shared
+// by the whole application.
+type RecentManager interface {
+	Object
+
+	Init() (already bool)
+	SetFilename(filename string)
+	GetFilename() (filename string)
+	AddItem(uri string) (ok bool)
+	AddFull(uri string, data RecentData) (ok bool)
+	RemoveItem(uri string) (err error)
+	LookupItem(uri string) (info *RecentInfo, err error)
+	HasItem(uri string) (ok bool)
+	MoveItem(uri string, newUri string) (err error)
+	GetItems() (items []*RecentInfo)
+	PurgeItems() (removed int, err error)
+	SetLimit(limit int)
+	GetLimit() (limit int)
+	GetSize() (size int)
+}
+
+var _ RecentManager = (*CRecentManager)(nil)
+
+// The CRecentManager structure implements the RecentManager interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with RecentManager objects.
+type CRecentManager struct {
+	CObject
+
+	items []*RecentInfo
+	stamp time.Time
+}
+
+// MakeRecentManager is used by the Buildable system to construct a new
+// RecentManager.
+func MakeRecentManager() RecentManager {
+	return NewRecentManager()
+}
+
+// NewRecentManager is the constructor for new RecentManager instances using
+// the default storage file. You should not need to use this function, use
+// GetDefaultRecentManager instead.
+func NewRecentManager() RecentManager {
+	m := new(CRecentManager)
+	m.Init()
+	return m
+}
+
+// NewRecentManagerForFile is the constructor for new RecentManager instances
+// storing the recently used files list in the given filename.
+//
+// Parameters:
+//
+//	filename	path to the XBEL storage file
+func NewRecentManagerForFile(filename string) RecentManager {
+	m := new(CRecentManager)
+	m.Init()
+	m.SetFilename(filename)
+	return m
+}
+
+// GetDefaultRecentManager returns the RecentManager instance shared by the
+// whole application, using the default storage file.
+func GetDefaultRecentManager() (manager RecentManager) {
+	if ctkDefaultRecentManager == nil {
+		ctkDefaultRecentManager = NewRecentManager()
+	}
+	return ctkDefaultRecentManager
+}
+
+// RecentManagerDefaultFilename returns the path to the storage file shared by
+// the desktop applications of the user, within $XDG_DATA_HOME or
+// ~/.local/share if the former is not set.
+func RecentManagerDefaultFilename() (filename string) {
+	dataDir := os.Getenv("XDG_DATA_HOME")
+	if dataDir == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			dataDir = filepath.Join(home, ".local", "share")
+		} else {
+			dataDir = os.TempDir()
+		}
+	}
+	return filepath.Join(dataDir, "recently-used.xbel")
+}
+
+// Init initializes a RecentManager object. This must be called at least once
+// to set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the RecentManager instance. Init is used in the
+// NewRecentManager constructor and only necessary when implementing a
+// derivative RecentManager type.
+func (m *CRecentManager) Init() (already bool) {
+	if m.InitTypeItem(TypeRecentManager, m) {
+		return true
+	}
+	m.CObject.Init()
+	_ = m.InstallProperty(PropertyRecentManagerFilename, cdk.StringProperty, true, RecentManagerDefaultFilename())
+	_ = m.InstallProperty(PropertyRecentManagerLimit, cdk.IntProperty, true, -1)
+	m.items = make([]*RecentInfo, 0)
+	m.stamp = time.Time{}
+	return false
+}
+
+// SetFilename changes the storage file of the RecentManager, the recently
+// used files list is loaded from the new file.
+//
+// Emits: SignalChanged, Argv=[RecentManager instance]
+//
+// Parameters:
+//
+//	filename	path to the XBEL storage file
+func (m *CRecentManager) SetFilename(filename string) {
+	if err := m.SetStringProperty(PropertyRecentManagerFilename, filename); err != nil {
+		m.LogErr(err)
+		return
+	}
+	m.Lock()
+	m.items = make([]*RecentInfo, 0)
+	m.stamp = time.Time{}
+	m.Unlock()
+	m.refresh()
+	m.Emit(SignalChanged, m)
+}
+
+// GetFilename returns the path to the storage file of the RecentManager.
+// See: SetFilename()
+func (m *CRecentManager) GetFilename() (filename string) {
+	var err error
+	if filename, err = m.GetStringProperty(PropertyRecentManagerFilename); err != nil {
+		m.LogErr(err)
+	}
+	return
+}
+
+// AddItem adds a new resource, pointed by uri, into the recently used
+// resources list. This function automatically retrieves some of the needed
+// meta-data and sets other meta-data to common default values; it then feeds
+// the data to AddFull. The MIME type is guessed from the file extension, the
+// application name is the name of the current Application (or program) and the
+// command line is the program followed by the URI of the resource. See AddFull
+// if you want to explicitly define the meta-data for the resource.
+//
+// Parameters:
+//
+//	uri	a valid URI
+func (m *CRecentManager) AddItem(uri string) (ok bool) {
+	appName := recentManagerAppName()
+	data := RecentData{
+		MimeType: recentManagerGuessMimeType(uri),
+		AppName:  appName,
+		AppExec:  fmt.Sprintf("'%s %%u'", appName),
+	}
+	return m.AddFull(uri, data)
+}
+
+// AddFull adds a new resource, pointed by uri, into the recently used
+// resources list, using the meta-data specified inside the RecentData passed
+// in data. The MimeType, AppName and AppExec fields of data are required. If
+// the resource is already present, its meta-data is updated and the
+// registration count of the application is incremented. Returns TRUE if the
+// new item was successfully added to the recently used resources list.
+//
+// Emits: SignalChanged, Argv=[RecentManager instance]
+//
+// Parameters:
+//
+//	uri	a valid URI
+//	data	meta-data of the resource
+func (m *CRecentManager) AddFull(uri string, data RecentData) (ok bool) {
+	if err := recentManagerCheckUri(uri); err != nil {
+		m.LogErr(err)
+		return false
+	}
+	if data.MimeType == "" || data.AppName == "" || data.AppExec == "" {
+		m.LogError("mime type, application name and command line are required: %v", uri)
+		return false
+	}
+	m.refresh()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	m.Lock()
+	info := m.lookup(uri)
+	if info == nil {
+		info = &RecentInfo{uri: uri, added: now}
+		m.items = append(m.items, info)
+	}
+	info.modified, info.visited = now, now
+	info.mimeType = data.MimeType
+	info.private = data.IsPrivate
+	if data.DisplayName != "" {
+		info.displayName = data.DisplayName
+	}
+	if data.Description != "" {
+		info.description = data.Description
+	}
+	for _, group := range data.Groups {
+		if !info.HasGroup(group) {
+			info.groups = append(info.groups, group)
+		}
+	}
+	if app := info.getApplication(data.AppName); app != nil {
+		app.exec = data.AppExec
+		app.count += 1
+		app.stamp = now
+	} else {
+		info.applications = append(info.applications, &recentApplication{
+			name:  data.AppName,
+			exec:  data.AppExec,
+			count: 1,
+			stamp: now,
+		})
+	}
+	m.Unlock()
+	if err := m.save(); err != nil {
+		m.LogErr(err)
+		return false
+	}
+	m.Emit(SignalChanged, m)
+	return true
+}
+
+// RemoveItem removes a resource pointed by uri from the recently used
+// resources list handled by a recent manager. Returns ErrRecentManagerNotFound
+// if the uri is not present in the list.
+//
+// Emits: SignalChanged, Argv=[RecentManager instance]
+//
+// Parameters:
+//
+//	uri	the URI of the item you wish to remove
+func (m *CRecentManager) RemoveItem(uri string) (err error) {
+	m.refresh()
+	m.Lock()
+	found := false
+	for idx, info := range m.items {
+		if info.uri == uri {
+			m.items = append(m.items[:idx], m.items[idx+1:]...)
+			found = true
+			break
+		}
+	}
+	m.Unlock()
+	if !found {
+		return fmt.Errorf("%w: %v", ErrRecentManagerNotFound, uri)
+	}
+	if err = m.save(); err != nil {
+		return
+	}
+	m.Emit(SignalChanged, m)
+	return
+}
+
+// LookupItem searches for a URI inside the recently used resources list, and
+// returns a copy of the meta-data stored for it. Returns
+// ErrRecentManagerNotFound if the uri is not present in the list.
+//
+// Parameters:
+//
+//	uri	a URI
+func (m *CRecentManager) LookupItem(uri string) (info *RecentInfo, err error) {
+	m.refresh()
+	m.RLock()
+	defer m.RUnlock()
+	if found := m.lookup(uri); found != nil {
+		return found.clone(), nil
+	}
+	return nil, fmt.Errorf("%w: %v", ErrRecentManagerNotFound, uri)
+}
+
+// HasItem checks whether there is a recently used resource registered with
+// uri inside the recent manager.
+//
+// Parameters:
+//
+//	uri	a URI
+func (m *CRecentManager) HasItem(uri string) (ok bool) {
+	m.refresh()
+	m.RLock()
+	defer m.RUnlock()
+	return m.lookup(uri) != nil
+}
+
+// MoveItem changes the location of a recently used resource from uri to
+// newUri. Please note that this function will not affect the resource pointed
+// by the URIs, but only the URI used in the recently used resources list. An
+// empty newUri removes the item.
+//
+// Emits: SignalChanged, Argv=[RecentManager instance]
+//
+// Parameters:
+//
+//	uri	the URI of a recently used resource
+//	newUri	the new URI of the recently used resource
+func (m *CRecentManager) MoveItem(uri string, newUri string) (err error) {
+	if newUri == "" {
+		return m.RemoveItem(uri)
+	}
+	if err = recentManagerCheckUri(newUri); err != nil {
+		return
+	}
+	m.refresh()
+	m.Lock()
+	info := m.lookup(uri)
+	if info == nil {
+		m.Unlock()
+		return fmt.Errorf("%w: %v", ErrRecentManagerNotFound, uri)
+	}
+	if other := m.lookup(newUri); other != nil && other != info {
+		for idx, item := range m.items {
+			if item == other {
+				m.items = append(m.items[:idx], m.items[idx+1:]...)
+				break
+			}
+		}
+	}
+	info.uri = newUri
+	info.modified = time.Now().UTC().Truncate(time.Microsecond)
+	m.Unlock()
+	if err = m.save(); err != nil {
+		return
+	}
+	m.Emit(SignalChanged, m)
+	return
+}
+
+// GetItems returns copies of the recently used resources, the most recently
+// used first. If a limit is set, no more than limit items are returned.
+// See: SetLimit()
+func (m *CRecentManager) GetItems() (items []*RecentInfo) {
+	m.refresh()
+	limit := m.GetLimit()
+	m.RLock()
+	for _, info := range m.items {
+		items = append(items, info.clone())
+	}
+	m.RUnlock()
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].modified.After(items[j].modified)
+	})
+	if limit >= 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return
+}
+
+// PurgeItems purges every item from the recently used resources list and
+// returns the number of items that have been removed.
+//
+// Emits: SignalChanged, Argv=[RecentManager instance]
+func (m *CRecentManager) PurgeItems() (removed int, err error) {
+	m.refresh()
+	m.Lock()
+	removed = len(m.items)
+	m.items = make([]*RecentInfo, 0)
+	m.Unlock()
+	if err = m.save(); err != nil {
+		return
+	}
+	m.Emit(SignalChanged, m)
+	return
+}
+
+// SetLimit sets the maximum number of items that the GetItems method should
+// return. If limit is set to -1, then return all the items.
+//
+// Parameters:
+//
+//	limit	the maximum number of items to return, or -1.
+func (m *CRecentManager) SetLimit(limit int) {
+	if limit < 0 {
+		limit = -1
+	}
+	if err := m.SetIntProperty(PropertyRecentManagerLimit, limit); err != nil {
+		m.LogErr(err)
+	}
+}
+
+// GetLimit returns the maximum number of items that the GetItems method can
+// return.
+// See: SetLimit()
+func (m *CRecentManager) GetLimit() (limit int) {
+	var err error
+	if limit, err = m.GetIntProperty(PropertyRecentManagerLimit); err != nil {
+		m.LogErr(err)
+	}
+	return
+}
+
+// GetSize returns the total number of items in the recently used resources
+// list, regardless of any limit set.
+func (m *CRecentManager) GetSize() (size int) {
+	m.refresh()
+	m.RLock()
+	defer m.RUnlock()
+	return len(m.items)
+}
+
+func (m *CRecentManager) lookup(uri string) (info *RecentInfo) {
+	for _, info = range m.items {
+		if info.uri == uri {
+			return info
+		}
+	}
+	return nil
+}
+
+// refresh reloads the storage file if it was modified since it was last read
+// or written, emitting a changed signal when the list was reloaded
+func (m *CRecentManager) refresh() {
+	filename := m.GetFilename()
+	stat, err := os.Stat(filename)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			m.LogErr(err)
+		}
+		return
+	}
+	m.RLock()
+	unchanged := stat.ModTime().Equal(m.stamp)
+	m.RUnlock()
+	if unchanged {
+		return
+	}
+	items, err := recentManagerLoad(filename)
+	if err != nil {
+		m.LogErr(err)
+		return
+	}
+	m.Lock()
+	m.items = items
+	m.stamp = stat.ModTime()
+	m.Unlock()
+	m.Emit(SignalChanged, m)
+}
+
+// save writes the recently used files list to the storage file, replacing the
+// file atomically
+func (m *CRecentManager) save() (err error) {
+	filename := m.GetFilename()
+	m.RLock()
+	content := recentManagerEncode(m.items)
+	m.RUnlock()
+	dir := filepath.Dir(filename)
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("%w: %v", ErrRecentManagerWrite, err)
+	}
+	var tmp *os.File
+	if tmp, err = os.CreateTemp(dir, filepath.Base(filename)+".*"); err != nil {
+		return fmt.Errorf("%w: %v", ErrRecentManagerWrite, err)
+	}
+	_, err = tmp.WriteString(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0600)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filename)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("%w: %v", ErrRecentManagerWrite, err)
+	}
+	if stat, e := os.Stat(filename); e == nil {
+		m.Lock()
+		m.stamp = stat.ModTime()
+		m.Unlock()
+	}
+	return nil
+}
+
+func recentManagerCheckUri(uri string) (err error) {
+	if u, e := url.Parse(uri); e != nil || u.Scheme == "" {
+		return fmt.Errorf("%w: %q", ErrRecentManagerInvalidUri, uri)
+	}
+	return nil
+}
+
+func recentManagerAppName() (name string) {
+	if acd, err := cdk.GetLocalContext(); err == nil {
+		if app, ok := acd.Data.(cdk.Application); ok && app.Name() != "" {
+			return app.Name()
+		}
+	}
+	return filepath.Base(os.Args[0])
+}
+
+func recentManagerGuessMimeType(uri string) (mimeType string) {
+	info := &RecentInfo{uri: uri}
+	if filename, ok := info.getFilename(); ok {
+		if stat, err := os.Stat(filename); err == nil && stat.IsDir() {
+			return "inode/directory"
+		}
+	}
+	if mimeType = mime.TypeByExtension(filepath.Ext(info.GetShortName())); mimeType != "" {
+		if mediaType, _, err := mime.ParseMediaType(mimeType); err == nil {
+			return mediaType
+		}
+		return mimeType
+	}
+	return "application/octet-stream"
+}
+
+type recentXbel struct {
+	XMLName   xml.Name             `xml:"xbel"`
+	Bookmarks []recentXbelBookmark `xml:"bookmark"`
+}
+
+type recentXbelBookmark struct {
+	Href     string               `xml:"href,attr"`
+	Added    string               `xml:"added,attr"`
+	Modified string               `xml:"modified,attr"`
+	Visited  string               `xml:"visited,attr"`
+	Title    string               `xml:"title"`
+	Desc     string               `xml:"desc"`
+	Metadata []recentXbelMetadata `xml:"info>metadata"`
+}
+
+type recentXbelMetadata struct {
+	Owner        string                  `xml:"owner,attr"`
+	MimeType     recentXbelMimeType      `xml:"mime-type"`
+	Groups       []string                `xml:"groups>group"`
+	Applications []recentXbelApplication `xml:"applications>application"`
+	Private      *struct{}               `xml:"private"`
+}
+
+type recentXbelMimeType struct {
+	Type string `xml:"type,attr"`
+}
+
+type recentXbelApplication struct {
+	Name      string `xml:"name,attr"`
+	Exec      string `xml:"exec,attr"`
+	Modified  string `xml:"modified,attr"`
+	Timestamp string `xml:"timestamp,attr"`
+	Count     int    `xml:"count,attr"`
+}
+
+func recentManagerParseTime(value string) (stamp time.Time) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t.UTC()
+	}
+	return time.Time{}
+}
+
+func recentManagerLoad(filename string) (items []*RecentInfo, err error) {
+	var data []byte
+	if data, err = os.ReadFile(filename); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRecentManagerRead, err)
+	}
+	var doc recentXbel
+	if err = xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRecentManagerRead, err)
+	}
+	items = make([]*RecentInfo, 0, len(doc.Bookmarks))
+	for _, bookmark := range doc.Bookmarks {
+		if bookmark.Href == "" {
+			continue
+		}
+		info := &RecentInfo{
+			uri:         bookmark.Href,
+			displayName: bookmark.Title,
+			description: bookmark.Desc,
+			added:       recentManagerParseTime(bookmark.Added),
+			modified:    recentManagerParseTime(bookmark.Modified),
+			visited:     recentManagerParseTime(bookmark.Visited),
+		}
+		for _, metadata := range bookmark.Metadata {
+			if metadata.Owner != "" && metadata.Owner != "http://freedesktop.org" {
+				continue
+			}
+			info.mimeType = metadata.MimeType.Type
+			info.groups = append(info.groups, metadata.Groups...)
+			info.private = metadata.Private != nil
+			for _, application := range metadata.Applications {
+				stamp := recentManagerParseTime(application.Modified)
+				if stamp.IsZero() && application.Timestamp != "" {
+					if seconds, e := strconv.ParseInt(application.Timestamp, 10, 64); e == nil {
+						stamp = time.Unix(seconds, 0).UTC()
+					}
+				}
+				info.applications = append(info.applications, &recentApplication{
+					name:  application.Name,
+					exec:  application.Exec,
+					count: application.Count,
+					stamp: stamp,
+				})
+			}
+		}
+		items = append(items, info)
+	}
+	return
+}
+
+func recentManagerEscape(value string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
+
+func recentManagerFormatTime(stamp time.Time) string {
+	return stamp.UTC().Format(recentXbelTimeFormat)
+}
+
+// recentManagerEncode returns the XBEL document of the given items, written
+// by hand to use the same namespace prefixes as other desktop applications
+func recentManagerEncode(items []*RecentInfo) string {
+	var b strings.Builder
+	b.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	b.WriteString("<xbel version=\"1.0\"\n")
+	b.WriteString("      xmlns:bookmark=\"" + recentXbelBookmarkNamespace + "\"\n")
+	b.WriteString("      xmlns:mime=\"" + recentXbelMimeNamespace + "\"\n>\n")
+	for _, info := range items {
+		b.WriteString(fmt.Sprintf(
+			"  <bookmark href=\"%s\" added=\"%s\" modified=\"%s\" visited=\"%s\">\n",
+			recentManagerEscape(info.uri),
+			recentManagerFormatTime(info.added),
+			recentManagerFormatTime(info.modified),
+			recentManagerFormatTime(info.visited),
+		))
+		if info.displayName != "" {
+			b.WriteString("    <title>" + recentManagerEscape(info.displayName) + "</title>\n")
+		}
+		if info.description != "" {
+			b.WriteString("    <desc>" + recentManagerEscape(info.description) + "</desc>\n")
+		}
+		b.WriteString("    <info>\n")
+		b.WriteString("      <metadata owner=\"http://freedesktop.org\">\n")
+		b.WriteString("        <mime:mime-type type=\"" + recentManagerEscape(info.mimeType) + "\"/>\n")
+		if len(info.groups) > 0 {
+			b.WriteString("        <bookmark:groups>\n")
+			for _, group := range info.groups {
+				b.WriteString("          <bookmark:group>" + recentManagerEscape(group) + "</bookmark:group>\n")
+			}
+			b.WriteString("        </bookmark:groups>\n")
+		}
+		b.WriteString("        <bookmark:applications>\n")
+		for _, app := range info.applications {
+			b.WriteString(fmt.Sprintf(
+				"          <bookmark:application name=\"%s\" exec=\"%s\" modified=\"%s\" count=\"%d\"/>\n",
+				recentManagerEscape(app.name),
+				recentManagerEscape(app.exec),
+				recentManagerFormatTime(app.stamp),
+				app.count,
+			))
+		}
+		b.WriteString("        </bookmark:applications>\n")
+		if info.private {
+			b.WriteString("        <bookmark:private/>\n")
+		}
+		b.WriteString("      </metadata>\n")
+		b.WriteString("    </info>\n")
+		b.WriteString("  </bookmark>\n")
+	}
+	b.WriteString("</xbel>")
+	return b.String()
+}
+
+// The full path to the file to be used to store and read the recently used
+// resources list.
+// Flags: Read / Write
+// Default value: "$XDG_DATA_HOME/recently-used.xbel"
+const PropertyRecentManagerFilename cdk.Property = "filename"
+
+// The maximum number of items to be returned by the GetItems method.
+// Flags: Read / Write
+// Allowed values: >= -1
+// Default value: -1
+const PropertyRecentManagerLimit cdk.Property = "limit"
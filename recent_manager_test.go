@@ -0,0 +1,309 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	cenums "github.com/go-curses/cdk/lib/enums"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const testRecentXbel = `<?xml version="1.0" encoding="UTF-8"?>
+<xbel version="1.0"
+      xmlns:bookmark="http://www.freedesktop.org/standards/desktop-bookmarks"
+      xmlns:mime="http://www.freedesktop.org/standards/shared-mime-info"
+>
+  <bookmark href="file:///tmp/ctk-missing/old.txt" added="2020-01-01T10:00:00Z" modified="2020-01-01T10:00:00Z" visited="2020-01-01T10:00:00Z">
+    <info>
+      <metadata owner="http://freedesktop.org">
+        <mime:mime-type type="text/plain"/>
+        <bookmark:applications>
+          <bookmark:application name="gedit" exec="&apos;gedit %u&apos;" timestamp="1577872800" count="2"/>
+        </bookmark:applications>
+      </metadata>
+    </info>
+  </bookmark>
+  <bookmark href="file:///tmp/ctk-missing/new.go" added="2020-01-03T10:00:00Z" modified="2020-01-03T10:00:00.5Z" visited="2020-01-03T10:00:00Z">
+    <title>New Source</title>
+    <info>
+      <metadata owner="http://freedesktop.org">
+        <mime:mime-type type="text/x-go"/>
+        <bookmark:groups>
+          <bookmark:group>sources</bookmark:group>
+        </bookmark:groups>
+        <bookmark:applications>
+          <bookmark:application name="vim" exec="&apos;vim %f&apos;" modified="2020-01-03T10:00:00Z" count="1"/>
+        </bookmark:applications>
+      </metadata>
+    </info>
+  </bookmark>
+  <bookmark href="https://example.com/remote.html" added="2020-01-02T10:00:00Z" modified="2020-01-02T10:00:00Z" visited="2020-01-02T10:00:00Z">
+    <info>
+      <metadata owner="http://freedesktop.org">
+        <mime:mime-type type="text/html"/>
+        <bookmark:applications>
+          <bookmark:application name="browser" exec="&apos;browser %u&apos;" modified="2020-01-02T10:00:00Z" count="1"/>
+        </bookmark:applications>
+        <bookmark:private/>
+      </metadata>
+    </info>
+  </bookmark>
+</xbel>`
+
+func TestRecentManager(t *testing.T) {
+	Convey("Testing Recent Managers", t, func() {
+		tmp := t.TempDir()
+		storage := filepath.Join(tmp, "data", "recently-used.xbel")
+		document := filepath.Join(tmp, "notes_1.txt")
+		So(os.WriteFile(document, []byte("test"), 0644), ShouldBeNil)
+		documentUri := RecentUriFromFilename(document)
+
+		Convey("adding and persisting", func() {
+			So(documentUri, ShouldStartWith, "file:///")
+			m := NewRecentManagerForFile(storage)
+			So(m.GetFilename(), ShouldEqual, storage)
+			So(m.GetSize(), ShouldEqual, 0)
+			changed := 0
+			m.Connect(SignalChanged, "test-changed", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				changed += 1
+				return cenums.EVENT_PASS
+			})
+			So(m.AddItem("not a uri"), ShouldEqual, false)
+			So(m.AddItem(documentUri), ShouldEqual, true)
+			So(changed, ShouldEqual, 1)
+			So(m.HasItem(documentUri), ShouldEqual, true)
+			info, err := m.LookupItem(documentUri)
+			So(err, ShouldBeNil)
+			So(info.GetMimeType(), ShouldEqual, "text/plain")
+			So(info.GetDisplayName(), ShouldEqual, "notes_1.txt")
+			So(info.GetUriDisplay(), ShouldEqual, document)
+			So(info.IsLocal(), ShouldEqual, true)
+			So(info.Exists(), ShouldEqual, true)
+			So(info.GetApplications(), ShouldHaveLength, 1)
+			So(m.AddFull(documentUri, RecentData{
+				DisplayName: "Notes",
+				MimeType:    "text/plain",
+				AppName:     "editor",
+				AppExec:     "'editor %f'",
+				Groups:      []string{"notes"},
+			}), ShouldEqual, true)
+			So(m.AddFull(documentUri, RecentData{MimeType: "text/plain"}), ShouldEqual, false)
+			So(m.AddFull(documentUri, RecentData{MimeType: "text/plain", AppName: "editor", AppExec: "'editor %f'"}), ShouldEqual, true)
+			_, err = m.LookupItem("file:///nowhere")
+			So(errors.Is(err, ErrRecentManagerNotFound), ShouldEqual, true)
+
+			content, err := os.ReadFile(storage)
+			So(err, ShouldBeNil)
+			So(string(content), ShouldContainSubstring, `<bookmark:application name="editor" exec="&#39;editor %f&#39;"`)
+			So(string(content), ShouldContainSubstring, "<mime:mime-type type=\"text/plain\"/>")
+
+			other := NewRecentManagerForFile(storage)
+			loaded, err := other.LookupItem(documentUri)
+			So(err, ShouldBeNil)
+			So(loaded.GetDisplayName(), ShouldEqual, "Notes")
+			So(loaded.HasGroup("notes"), ShouldEqual, true)
+			So(loaded.LastApplication(), ShouldEqual, "editor")
+			exec, count, _, ok := loaded.GetApplicationInfo("editor")
+			So(ok, ShouldEqual, true)
+			So(exec, ShouldEqual, "'editor %f'")
+			So(count, ShouldEqual, 2)
+			So(loaded.GetAdded().Equal(info.GetAdded()), ShouldEqual, true)
+
+			So(other.RemoveItem(documentUri), ShouldBeNil)
+			So(errors.Is(other.RemoveItem(documentUri), ErrRecentManagerNotFound), ShouldEqual, true)
+			future := time.Now().Add(time.Minute)
+			So(os.Chtimes(storage, future, future), ShouldBeNil)
+			So(m.HasItem(documentUri), ShouldEqual, false)
+		})
+
+		Convey("reading, moving and purging", func() {
+			So(os.MkdirAll(filepath.Dir(storage), 0700), ShouldBeNil)
+			So(os.WriteFile(storage, []byte(testRecentXbel), 0600), ShouldBeNil)
+			m := NewRecentManagerForFile(storage)
+			items := m.GetItems()
+			So(items, ShouldHaveLength, 3)
+			So(items[0].GetDisplayName(), ShouldEqual, "New Source")
+			So(items[1].GetShortName(), ShouldEqual, "remote.html")
+			So(items[1].GetPrivateHint(), ShouldEqual, true)
+			So(items[1].IsLocal(), ShouldEqual, false)
+			So(items[2].GetShortName(), ShouldEqual, "old.txt")
+			_, count, stamp, ok := items[2].GetApplicationInfo("gedit")
+			So(ok, ShouldEqual, true)
+			So(count, ShouldEqual, 2)
+			So(stamp.Equal(time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)), ShouldEqual, true)
+			So(items[2].GetAge(), ShouldBeGreaterThan, 365)
+			m.SetLimit(2)
+			So(m.GetItems(), ShouldHaveLength, 2)
+			So(m.GetSize(), ShouldEqual, 3)
+			m.SetLimit(-5)
+			So(m.GetLimit(), ShouldEqual, -1)
+
+			So(m.MoveItem("file:///tmp/ctk-missing/old.txt", documentUri), ShouldBeNil)
+			So(m.HasItem("file:///tmp/ctk-missing/old.txt"), ShouldEqual, false)
+			moved, err := m.LookupItem(documentUri)
+			So(err, ShouldBeNil)
+			So(moved.HasApplication("gedit"), ShouldEqual, true)
+			So(errors.Is(m.MoveItem(documentUri, "::"), ErrRecentManagerInvalidUri), ShouldEqual, true)
+			So(m.MoveItem(documentUri, ""), ShouldBeNil)
+			So(m.GetSize(), ShouldEqual, 2)
+			removed, err := m.PurgeItems()
+			So(err, ShouldBeNil)
+			So(removed, ShouldEqual, 2)
+			So(NewRecentManagerForFile(storage).GetSize(), ShouldEqual, 0)
+		})
+
+		Convey("recent filters", func() {
+			info := RecentFilterInfo{
+				Contains:    enums.RECENT_FILTER_URI | enums.RECENT_FILTER_DISPLAY_NAME | enums.RECENT_FILTER_MIME_TYPE,
+				DisplayName: "main.go",
+				MimeType:    "text/x-go",
+			}
+			f := NewRecentFilter()
+			So(f.Filter(info), ShouldEqual, false)
+			f.AddPattern("*.txt")
+			So(f.Filter(info), ShouldEqual, false)
+			f.AddMimeType("text/*")
+			So(f.Filter(info), ShouldEqual, true)
+			g := NewRecentFilter()
+			g.AddApplication("vim")
+			g.AddAge(7)
+			So(g.GetNeeded(), ShouldEqual, enums.RECENT_FILTER_APPLICATION|enums.RECENT_FILTER_AGE)
+			So(g.Filter(info), ShouldEqual, false)
+			info.Contains |= enums.RECENT_FILTER_APPLICATION
+			info.Applications = []string{"vim"}
+			So(g.Filter(info), ShouldEqual, true)
+			h := NewRecentFilter()
+			h.AddGroup("sources")
+			h.AddCustom(enums.RECENT_FILTER_URI, func(info RecentFilterInfo) bool {
+				return strings.HasPrefix(info.Uri, "https:")
+			})
+			So(h.Filter(info), ShouldEqual, false)
+			info.Uri = "https://example.com"
+			So(h.Filter(info), ShouldEqual, true)
+		})
+
+		Convey("recent chooser menu", func() {
+			So(os.MkdirAll(filepath.Dir(storage), 0700), ShouldBeNil)
+			So(os.WriteFile(storage, []byte(testRecentXbel), 0600), ShouldBeNil)
+			m := NewRecentManagerForFile(storage)
+			menu := NewRecentChooserMenuForManager(m)
+			So(menu.GetRecentManager(), ShouldEqual, m)
+			So(menu.GetLocalOnly(), ShouldEqual, true)
+			So(menu.GetSortType(), ShouldEqual, enums.RECENT_SORT_MRU)
+			So(menu.GetUris(), ShouldResemble, []string{"file:///tmp/ctk-missing/new.go", "file:///tmp/ctk-missing/old.txt"})
+			So(menu.GetItems(), ShouldHaveLength, 2)
+			So(menu.GetRecentMenuItems()[0].GetLabel(), ShouldEqual, "New Source")
+
+			menu.SetLocalOnly(false)
+			So(menu.GetUris(), ShouldHaveLength, 2)
+			menu.SetShowPrivate(true)
+			So(menu.GetUris(), ShouldHaveLength, 3)
+			menu.SetSortType(enums.RECENT_SORT_LRU)
+			So(menu.GetUris()[0], ShouldEqual, "file:///tmp/ctk-missing/old.txt")
+			menu.SetSortFunc(func(a, b *RecentInfo) int {
+				return strings.Compare(a.GetUri(), b.GetUri())
+			})
+			menu.SetSortType(enums.RECENT_SORT_CUSTOM)
+			So(menu.GetUris()[0], ShouldEqual, "file:///tmp/ctk-missing/new.go")
+			menu.SetLimit(1)
+			So(menu.GetRecentItems(), ShouldHaveLength, 1)
+			menu.SetLimit(-1)
+			menu.SetShowNotFound(false)
+			So(menu.GetUris(), ShouldResemble, []string{"https://example.com/remote.html"})
+			menu.SetShowNotFound(true)
+
+			filter := NewRecentFilter()
+			filter.AddMimeType("text/x-go")
+			menu.AddFilter(filter)
+			So(menu.GetFilter(), ShouldEqual, filter)
+			So(menu.GetUris(), ShouldResemble, []string{"file:///tmp/ctk-missing/new.go"})
+			menu.RemoveFilter(filter)
+			So(menu.GetFilter(), ShouldBeNil)
+
+			menu.SetSortType(enums.RECENT_SORT_MRU)
+			menu.SetShowNumbers(true)
+			So(menu.GetRecentMenuItems()[0].GetLabel(), ShouldEqual, "_1. New Source")
+			var activated string
+			menu.Connect(SignalItemActivated, "test-item-activated", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				activated, _ = argv[1].(string)
+				return cenums.EVENT_PASS
+			})
+			menu.GetRecentMenuItems()[1].Activate()
+			So(activated, ShouldEqual, "https://example.com/remote.html")
+			So(menu.GetCurrentUri(), ShouldEqual, "https://example.com/remote.html")
+			So(menu.GetCurrentItem().GetPrivateHint(), ShouldEqual, true)
+			So(errors.Is(menu.SelectUri("file:///nowhere"), ErrRecentChooserNotFound), ShouldEqual, true)
+			So(menu.SetCurrentUri("file:///tmp/ctk-missing/new.go"), ShouldBeNil)
+			So(menu.GetCurrentUri(), ShouldEqual, "file:///tmp/ctk-missing/new.go")
+			menu.UnselectUri("file:///tmp/ctk-missing/new.go")
+			So(menu.GetCurrentUri(), ShouldEqual, "")
+
+			_, _ = m.PurgeItems()
+			So(menu.GetRecentMenuItems(), ShouldHaveLength, 0)
+			So(menu.GetItems(), ShouldHaveLength, 1)
+			So(menu.GetItems()[0].IsSensitive(), ShouldEqual, false)
+		})
+
+		Convey("recent chooser widget", func() {
+			So(os.MkdirAll(filepath.Dir(storage), 0700), ShouldBeNil)
+			So(os.WriteFile(storage, []byte(testRecentXbel), 0600), ShouldBeNil)
+			m := NewRecentManagerForFile(storage)
+			rc := NewRecentChooserWidgetForManager(m)
+			model := rc.GetRecentList().GetModel()
+			iter, ok := model.GetIterFirst()
+			So(ok, ShouldEqual, true)
+			So(TreeModelGetString(model, iter, 0), ShouldEqual, "New Source")
+			So(TreeModelGetString(model, iter, 1), ShouldEqual, "/tmp/ctk-missing")
+
+			selections := 0
+			rc.Connect(SignalSelectionChanged, "test-selection-changed", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				selections += 1
+				return cenums.EVENT_PASS
+			})
+			So(rc.SetCurrentUri("file:///tmp/ctk-missing/old.txt"), ShouldBeNil)
+			So(selections, ShouldBeGreaterThan, 0)
+			So(rc.GetCurrentUri(), ShouldEqual, "file:///tmp/ctk-missing/old.txt")
+			rc.SelectAll()
+			So(rc.GetSelectedUris(), ShouldHaveLength, 1)
+			rc.SetSelectMultiple(true)
+			rc.SelectAll()
+			So(rc.GetSelectedUris(), ShouldHaveLength, 2)
+			rc.UnselectUri("file:///tmp/ctk-missing/new.go")
+			So(rc.GetSelectedUris(), ShouldResemble, []string{"file:///tmp/ctk-missing/old.txt"})
+
+			So(m.AddItem(documentUri), ShouldEqual, true)
+			So(rc.GetUris(), ShouldHaveLength, 3)
+			So(rc.GetSelectedUris(), ShouldResemble, []string{"file:///tmp/ctk-missing/old.txt"})
+			rc.UnselectAll()
+			So(rc.GetCurrentItem(), ShouldBeNil)
+
+			var activated string
+			rc.Connect(SignalItemActivated, "test-item-activated", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				activated, _ = argv[1].(string)
+				return cenums.EVENT_PASS
+			})
+			rc.GetRecentList().RowActivated(NewTreePathFromIndices(0), nil)
+			So(activated, ShouldEqual, documentUri)
+		})
+	})
+}
@@ -0,0 +1,852 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/go-curses/cdk/lib/ptypes"
+	"github.com/go-curses/cdk/memphis"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeScale cdk.CTypeTag = "ctk-scale"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeScale, nil)
+}
+
+// ScaleMinTroughLength is the smallest number of cells requested for the
+// trough of a Scale.
+const ScaleMinTroughLength = 5
+
+// Scale Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Range
+//	      +- Scale
+//	        +- HScale
+//	        +- VScale
+//
+// A Scale is a slider control used to select a numeric value. To use it,
+// you'll probably want to investigate the methods on its base type, Range, in
+// addition to the methods for Scale itself. To set the value of a Scale, you
+// would normally use SetValue. To detect changes to the value, you would
+// normally connect to the value-changed signal.
+//
+// The slider is moved with the mouse by dragging it, while clicking on the
+// trough moves the slider by a page towards the pointer or, when the
+// "ctk-primary-button-warps-slider" Setting is TRUE, directly to the pointer.
+// Clicking with the middle button always warps the slider. When the Scale has
+// focus, the arrow keys move the slider by the step increment, Shift with the
+// arrow keys and the PgUp and PgDn keys move the slider by the page increment
+// and the Home and End keys move the slider to the lower and upper bounds.
+//
+// As CTK Adjustments are integer based, the Adjustment values of a Scale are
+// in units of the last decimal digit displayed, ie: with one digit, an
+// Adjustment value of 25 is displayed as "2.5". The displayed value can be
+// formatted differently with the format-value signal.
+//
+// Marks can be added to the Scale with AddMark, which draws a tick next to the
+// trough at the given value along with an optional label.
+type Scale interface {
+	Range
+	Buildable
+
+	Init() (already bool)
+	Build(builder Builder, element *CBuilderElement) error
+	SetAdjustment(adjustment *CAdjustment)
+	GetOrientation() (orientation cenums.Orientation)
+	GetDigits() (digits int)
+	SetDigits(digits int)
+	GetDrawValue() (drawValue bool)
+	SetDrawValue(drawValue bool)
+	GetValuePos() (pos enums.PositionType)
+	SetValuePos(pos enums.PositionType)
+	AddMark(value int, position enums.PositionType, markup string)
+	ClearMarks()
+	FormatValue(value int) (text string)
+	CancelEvent()
+	GetSizeRequest() (width, height int)
+	GetTroughRegion() (region ptypes.Region)
+	GetSliderRegion() (region ptypes.Region)
+}
+
+var _ Scale = (*CScale)(nil)
+
+// The CScale structure implements the Scale interface and is exported to
+// facilitate type embedding with custom implementations. No member variables
+// are exported as the interface methods are the only intended means of
+// interacting with Scale objects.
+type CScale struct {
+	CRange
+
+	orientation cenums.Orientation
+	marks       []*cScaleMark
+	dragging    bool
+}
+
+type cScaleMark struct {
+	value    int
+	position enums.PositionType
+	markup   string
+}
+
+// cScaleLayout describes where the parts of a Scale are drawn, relative to the
+// origin of the Scale.
+type cScaleLayout struct {
+	trough  ptypes.Region
+	value   ptypes.Region
+	before  int // offset of the ticks of the top or left marks
+	after   int // offset of the ticks of the bottom or right marks
+	hasMark [4]bool
+	labels  [4]bool
+}
+
+// Init initializes a Scale object. This must be called at least once to set up
+// the necessary defaults and allocate any memory structures. Calling this more
+// than once is safe though unnecessary. Only the first call will result in any
+// effect upon the Scale instance. Init is used in the NewHScale and NewVScale
+// constructors and only necessary when implementing a derivative Scale type.
+func (s *CScale) Init() (already bool) {
+	if s.InitTypeItem(TypeScale, s) {
+		return true
+	}
+	s.CRange.Init()
+	s.flags = enums.NULL_WIDGET_FLAG
+	s.SetFlags(enums.SENSITIVE | enums.PARENT_SENSITIVE | enums.CAN_FOCUS | enums.APP_PAINTABLE)
+	if s.orientation == cenums.ORIENTATION_NONE {
+		s.orientation = cenums.ORIENTATION_HORIZONTAL
+	}
+	_ = s.InstallBuildableProperty(PropertyDigits, cdk.IntProperty, true, 0)
+	_ = s.InstallBuildableProperty(PropertyDrawValue, cdk.BoolProperty, true, true)
+	_ = s.InstallBuildableProperty(PropertyValuePos, cdk.StructProperty, true, enums.POS_TOP)
+	s.marks = make([]*cScaleMark, 0)
+	s.dragging = false
+	s.Connect(SignalValueChanged, ScaleValueChangedHandle, s.valueChanged)
+	s.Connect(SignalCdkEvent, ScaleEventHandle, s.event)
+	s.Connect(SignalDraw, ScaleDrawHandle, s.draw)
+	return false
+}
+
+// Build provides customizations to the Buildable system for Scale Widgets. The
+// adjustment property is resolved by name to a previously built Adjustment.
+func (s *CScale) Build(builder Builder, element *CBuilderElement) error {
+	s.Freeze()
+	defer s.Thaw()
+	if name, ok := element.Attributes["id"]; ok {
+		s.SetName(name)
+	}
+	for k, v := range element.Properties {
+		switch cdk.Property(k) {
+		case PropertyAdjustment:
+			if adjustment, ok := builder.GetWidget(v).(*CAdjustment); ok {
+				s.SetAdjustment(adjustment)
+			} else {
+				s.LogError("adjustment not found: %v", v)
+			}
+		default:
+			element.ApplyProperty(k, v)
+		}
+	}
+	element.ApplySignals()
+	return nil
+}
+
+// SetAdjustment updates the Adjustment which is the "model" object for the
+// Scale.
+//
+// Parameters:
+//
+//	adjustment	the new Adjustment
+func (s *CScale) SetAdjustment(adjustment *CAdjustment) {
+	if adjustment == nil {
+		return
+	}
+	if err := s.SetStructProperty(PropertyAdjustment, adjustment); err != nil {
+		s.LogErr(err)
+	}
+	s.Resize()
+}
+
+// GetOrientation returns the orientation of the Scale.
+//
+// Locking: read
+func (s *CScale) GetOrientation() (orientation cenums.Orientation) {
+	s.RLock()
+	defer s.RUnlock()
+	return s.orientation
+}
+
+// GetDigits returns the number of decimal places that are displayed in the
+// value.
+// See: SetDigits()
+func (s *CScale) GetDigits() (digits int) {
+	var err error
+	if digits, err = s.GetIntProperty(PropertyDigits); err != nil {
+		s.LogErr(err)
+	}
+	return
+}
+
+// SetDigits sets the number of decimal places that are displayed in the
+// value. The Adjustment values are in units of the last decimal digit
+// displayed.
+//
+// Parameters:
+//
+//	digits	the number of decimal places to display, ie: 1 displays "2.5"
+func (s *CScale) SetDigits(digits int) {
+	if err := s.SetIntProperty(PropertyDigits, spinButtonClampDigits(digits)); err != nil {
+		s.LogErr(err)
+	}
+	s.Resize()
+}
+
+// GetDrawValue returns whether the current value is displayed as a string next
+// to the slider.
+// See: SetDrawValue()
+func (s *CScale) GetDrawValue() (drawValue bool) {
+	var err error
+	if drawValue, err = s.GetBoolProperty(PropertyDrawValue); err != nil {
+		s.LogErr(err)
+	}
+	return
+}
+
+// SetDrawValue specifies whether the current value is displayed as a string
+// next to the slider.
+//
+// Parameters:
+//
+//	drawValue	TRUE to draw the value
+func (s *CScale) SetDrawValue(drawValue bool) {
+	if err := s.SetBoolProperty(PropertyDrawValue, drawValue); err != nil {
+		s.LogErr(err)
+	}
+	s.Resize()
+}
+
+// GetValuePos returns the position in which the current value is displayed.
+// See: SetValuePos()
+func (s *CScale) GetValuePos() (pos enums.PositionType) {
+	if v, err := s.GetStructProperty(PropertyValuePos); err != nil {
+		s.LogErr(err)
+	} else if p, ok := v.(enums.PositionType); ok {
+		pos = p
+	} else {
+		s.LogError("value stored in %v property is not of PositionType type: %v (%T)", PropertyValuePos, v, v)
+	}
+	return
+}
+
+// SetValuePos sets the position in which the current value is displayed.
+//
+// Parameters:
+//
+//	pos	the position in which the current value is displayed
+func (s *CScale) SetValuePos(pos enums.PositionType) {
+	if err := s.SetStructProperty(PropertyValuePos, pos); err != nil {
+		s.LogErr(err)
+	}
+	s.Resize()
+}
+
+// AddMark adds a mark at the given value. A mark is drawn as a tick next to the
+// trough, on the top or bottom of a horizontal Scale and on the left or right
+// of a vertical Scale. If markup is not empty, it is drawn as a label next to
+// the tick.
+//
+// Parameters:
+//
+//	value	the value at which the mark is placed, must be between the lower and upper limits of the Adjustment
+//	position	where to draw the mark, POS_TOP or POS_BOTTOM for a horizontal Scale and POS_LEFT or POS_RIGHT for a vertical Scale
+//	markup	text to be shown at the mark, or empty
+func (s *CScale) AddMark(value int, position enums.PositionType, markup string) {
+	s.Lock()
+	if s.orientation == cenums.ORIENTATION_VERTICAL {
+		if position != enums.POS_LEFT {
+			position = enums.POS_RIGHT
+		}
+	} else if position != enums.POS_TOP {
+		position = enums.POS_BOTTOM
+	}
+	mark := &cScaleMark{value: value, position: position, markup: markup}
+	index := len(s.marks)
+	for i, m := range s.marks {
+		if value < m.value {
+			index = i
+			break
+		}
+	}
+	s.marks = append(s.marks, nil)
+	copy(s.marks[index+1:], s.marks[index:])
+	s.marks[index] = mark
+	s.Unlock()
+	s.Resize()
+}
+
+// ClearMarks removes any marks that have been added with AddMark.
+func (s *CScale) ClearMarks() {
+	s.Lock()
+	s.marks = make([]*cScaleMark, 0)
+	s.Unlock()
+	s.Resize()
+}
+
+// FormatValue returns the text displayed for the given Adjustment value. The
+// value is formatted with the number of digits configured and the
+// format-value signal is emitted, allowing listeners to change the text.
+//
+// Parameters:
+//
+//	value	the Adjustment value to format
+//
+// Emits: SignalFormatValue, Argv=[Scale instance, value float64, text *string]
+func (s *CScale) FormatValue(value int) (text string) {
+	digits := s.GetDigits()
+	number := float64(value) / math.Pow10(digits)
+	text = strconv.FormatFloat(number, 'f', digits, 64)
+	s.Emit(SignalFormatValue, s, number, &text)
+	return
+}
+
+// CancelEvent stops any slider drag in progress.
+func (s *CScale) CancelEvent() {
+	s.Lock()
+	s.dragging = false
+	s.Unlock()
+	if s.HasEventFocus() {
+		s.ReleaseEventFocus()
+	}
+	s.Invalidate()
+}
+
+// GetSizeRequest returns the requested size of the Scale, which is large enough
+// for a trough of ScaleMinTroughLength cells along with the value and any
+// marks.
+func (s *CScale) GetSizeRequest() (width, height int) {
+	size := ptypes.NewRectangle(s.CWidget.GetSizeRequest())
+	layout := s.getLayout(ptypes.MakeRectangle(0, 0))
+	valueW := 0
+	if s.GetDrawValue() {
+		valueW = s.getValueWidth()
+	}
+	along, across := ScaleMinTroughLength, layout.after+1
+	if s.GetOrientation() == cenums.ORIENTATION_VERTICAL {
+		across += s.getLabelsWidth(enums.POS_RIGHT)
+		if s.GetDrawValue() {
+			switch s.GetValuePos() {
+			case enums.POS_TOP, enums.POS_BOTTOM:
+				along += 1
+				if valueW > across {
+					across = valueW
+				}
+			}
+		}
+		if size.W <= -1 {
+			size.W = across
+		}
+		if size.H <= -1 {
+			size.H = along
+		}
+	} else {
+		if s.GetDrawValue() {
+			switch s.GetValuePos() {
+			case enums.POS_LEFT, enums.POS_RIGHT:
+				along += valueW + 1
+			}
+		}
+		if valueW > along {
+			along = valueW
+		}
+		if size.W <= -1 {
+			size.W = along
+		}
+		if size.H <= -1 {
+			size.H = across
+		}
+	}
+	size.Floor(1, 1)
+	return size.W, size.H
+}
+
+// GetTroughRegion returns the region of the trough, in Window coordinates.
+func (s *CScale) GetTroughRegion() (region ptypes.Region) {
+	origin := s.GetOrigin()
+	region = s.getLayout(s.GetAllocation()).trough
+	region.X += origin.X
+	region.Y += origin.Y
+	return
+}
+
+// GetSliderRegion returns the region of the slider, in Window coordinates.
+func (s *CScale) GetSliderRegion() (region ptypes.Region) {
+	region = s.GetTroughRegion()
+	if s.GetOrientation() == cenums.ORIENTATION_VERTICAL {
+		region.Y += s.getOffset(s.GetValue(), region.H)
+		region.H = 1
+	} else {
+		region.X += s.getOffset(s.GetValue(), region.W)
+		region.W = 1
+	}
+	return
+}
+
+// getLayout computes where the trough, value and marks are drawn within the
+// given allocation. A zero allocation computes the layout for the minimum
+// size.
+func (s *CScale) getLayout(alloc ptypes.Rectangle) (layout cScaleLayout) {
+	isVertical := s.GetOrientation() == cenums.ORIENTATION_VERTICAL
+	s.RLock()
+	for _, mark := range s.marks {
+		layout.hasMark[mark.position] = true
+		if mark.markup != "" {
+			layout.labels[mark.position] = true
+		}
+	}
+	s.RUnlock()
+	drawValue, valuePos, valueW := s.GetDrawValue(), s.GetValuePos(), 0
+	if drawValue {
+		valueW = s.getValueWidth()
+	}
+
+	if isVertical {
+		// columns: labels, ticks, value, trough, value, ticks, labels
+		x := 0
+		if layout.labels[enums.POS_LEFT] {
+			x += s.getLabelsWidth(enums.POS_LEFT)
+		}
+		if layout.hasMark[enums.POS_LEFT] {
+			layout.before = x
+			x += 1
+		}
+		if drawValue && valuePos == enums.POS_LEFT {
+			layout.value = ptypes.MakeRegion(x, 0, valueW, 1)
+			x += valueW + 1
+		}
+		layout.trough = ptypes.MakeRegion(x, 0, 1, alloc.H)
+		x += 1
+		if drawValue && valuePos == enums.POS_RIGHT {
+			layout.value = ptypes.MakeRegion(x+1, 0, valueW, 1)
+			x += valueW + 1
+		}
+		layout.after = x
+		if !layout.hasMark[enums.POS_RIGHT] {
+			layout.after -= 1
+		}
+		if drawValue {
+			switch valuePos {
+			case enums.POS_TOP:
+				layout.value = ptypes.MakeRegion(layout.trough.X-valueW/2, 0, valueW, 1)
+				layout.trough.Y += 1
+				layout.trough.H -= 1
+			case enums.POS_BOTTOM:
+				layout.trough.H -= 1
+				layout.value = ptypes.MakeRegion(layout.trough.X-valueW/2, layout.trough.H, valueW, 1)
+			}
+		}
+		layout.trough.Floor(0, 0)
+		return
+	}
+
+	// rows: labels, ticks, value, trough, value, ticks, labels
+	y := 0
+	if layout.labels[enums.POS_TOP] {
+		y += 1
+	}
+	if layout.hasMark[enums.POS_TOP] {
+		layout.before = y
+		y += 1
+	}
+	if drawValue && valuePos == enums.POS_TOP {
+		layout.value = ptypes.MakeRegion(0, y, valueW, 1)
+		y += 1
+	}
+	layout.trough = ptypes.MakeRegion(0, y, alloc.W, 1)
+	if drawValue && valuePos == enums.POS_BOTTOM {
+		y += 1
+		layout.value = ptypes.MakeRegion(0, y, valueW, 1)
+	}
+	layout.after = y
+	if layout.hasMark[enums.POS_BOTTOM] {
+		layout.after += 1
+		if layout.labels[enums.POS_BOTTOM] {
+			layout.after += 1
+		}
+	}
+	if drawValue {
+		switch valuePos {
+		case enums.POS_LEFT:
+			layout.value = ptypes.MakeRegion(0, y, valueW, 1)
+			layout.trough.X += valueW + 1
+			layout.trough.W -= valueW + 1
+		case enums.POS_RIGHT:
+			layout.trough.W -= valueW + 1
+			layout.value = ptypes.MakeRegion(layout.trough.W+1, y, valueW, 1)
+		}
+	}
+	layout.trough.Floor(0, 0)
+	return
+}
+
+// getValueWidth returns the width of the widest value displayed, which is the
+// widest of the lower and upper bounds of the Adjustment.
+func (s *CScale) getValueWidth() (width int) {
+	lower, upper := s.GetRange()
+	width = runewidth.StringWidth(s.FormatValue(lower))
+	if w := runewidth.StringWidth(s.FormatValue(upper)); w > width {
+		width = w
+	}
+	return
+}
+
+// getLabelsWidth returns the width needed for the labels of the marks of a
+// vertical Scale on the given side, including the space between the tick and
+// the label.
+func (s *CScale) getLabelsWidth(position enums.PositionType) (width int) {
+	s.RLock()
+	defer s.RUnlock()
+	for _, mark := range s.marks {
+		if mark.position == position && mark.markup != "" {
+			if w := runewidth.StringWidth(mark.markup) + 1; w > width {
+				width = w
+			}
+		}
+	}
+	return
+}
+
+// getOffset returns the offset of the given value within a trough of the given
+// length, taking the inverted property into account.
+func (s *CScale) getOffset(value, length int) (offset int) {
+	lower, upper := s.GetRange()
+	if upper > lower && length > 1 {
+		offset = int(math.Round(float64(value-lower) * float64(length-1) / float64(upper-lower)))
+	}
+	if offset < 0 {
+		offset = 0
+	} else if offset > length-1 {
+		offset = length - 1
+	}
+	if s.GetInverted() {
+		offset = length - 1 - offset
+	}
+	return
+}
+
+// getValueAt returns the value for the given offset within a trough of the
+// given length, taking the inverted property into account.
+func (s *CScale) getValueAt(offset, length int) (value int) {
+	lower, upper := s.GetRange()
+	if offset < 0 {
+		offset = 0
+	} else if offset > length-1 {
+		offset = length - 1
+	}
+	if s.GetInverted() {
+		offset = length - 1 - offset
+	}
+	value = lower
+	if length > 1 {
+		value += int(math.Round(float64(offset) * float64(upper-lower) / float64(length-1)))
+	}
+	return
+}
+
+// move changes the value by the given amount, where positive amounts move
+// towards the upper bound.
+func (s *CScale) move(amount int) cenums.EventFlag {
+	s.SetValue(s.GetValue() + amount)
+	return cenums.EVENT_STOP
+}
+
+func (s *CScale) processKeyEvent(e *cdk.EventKey) cenums.EventFlag {
+	step, page := s.GetIncrements()
+	if e.Modifiers().Has(cdk.ModShift) {
+		step = page
+	}
+	backward, forward := cdk.KeyLeft, cdk.KeyRight
+	if s.GetOrientation() == cenums.ORIENTATION_VERTICAL {
+		backward, forward = cdk.KeyUp, cdk.KeyDown
+	}
+	if s.GetInverted() {
+		backward, forward = forward, backward
+	}
+	switch e.Key() {
+	case backward:
+		return s.move(-step)
+	case forward:
+		return s.move(step)
+	case cdk.KeyPgUp:
+		return s.move(-page)
+	case cdk.KeyPgDn:
+		return s.move(page)
+	case cdk.KeyHome:
+		lower, _ := s.GetRange()
+		s.SetValue(lower)
+		return cenums.EVENT_STOP
+	case cdk.KeyEnd:
+		_, upper := s.GetRange()
+		s.SetValue(upper)
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+func (s *CScale) processMouseEvent(e *cdk.EventMouse) cenums.EventFlag {
+	point := ptypes.NewPoint2I(e.Position())
+	trough := s.GetTroughRegion()
+	isVertical := s.GetOrientation() == cenums.ORIENTATION_VERTICAL
+	offset, length := point.X-trough.X, trough.W
+	if isVertical {
+		offset, length = point.Y-trough.Y, trough.H
+	}
+	s.RLock()
+	dragging := s.dragging
+	s.RUnlock()
+	switch e.State() {
+	case cdk.BUTTON_PRESS, cdk.DRAG_START:
+		if dragging {
+			s.SetValue(s.getValueAt(offset, length))
+			return cenums.EVENT_STOP
+		}
+		button := e.Button()
+		if !trough.HasPoint(*point) || (!button.Has(cdk.Button1) && !button.Has(cdk.Button2)) {
+			break
+		}
+		if s.CanFocus() && !s.HasFocus() {
+			s.GrabFocus()
+		}
+		slider := s.GetSliderRegion()
+		if !slider.HasPoint(*point) {
+			if button.Has(cdk.Button2) || GetDefaultSettings().GetPrimaryButtonWarpsSlider() {
+				s.SetValue(s.getValueAt(offset, length))
+			} else {
+				_, page := s.GetIncrements()
+				sliderOffset := slider.X - trough.X
+				if isVertical {
+					sliderOffset = slider.Y - trough.Y
+				}
+				if (offset < sliderOffset) != s.GetInverted() {
+					page = -page
+				}
+				return s.move(page)
+			}
+		}
+		s.Lock()
+		s.dragging = true
+		s.Unlock()
+		s.GrabEventFocus()
+		s.Invalidate()
+		return cenums.EVENT_STOP
+	case cdk.MOUSE_MOVE, cdk.DRAG_MOVE:
+		if dragging {
+			s.SetValue(s.getValueAt(offset, length))
+			return cenums.EVENT_STOP
+		}
+	case cdk.BUTTON_RELEASE, cdk.DRAG_STOP:
+		if dragging {
+			s.CancelEvent()
+			return cenums.EVENT_STOP
+		}
+	case cdk.WHEEL_PULSE:
+		if s.HasPoint(point) {
+			step, _ := s.GetIncrements()
+			switch e.WheelImpulse() {
+			case cdk.WheelUp:
+				return s.move(-step)
+			case cdk.WheelDown:
+				return s.move(step)
+			}
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (s *CScale) event(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if !s.IsSensitive() {
+		return cenums.EVENT_PASS
+	}
+	if evt, ok := argv[1].(cdk.Event); ok {
+		switch e := evt.(type) {
+		case *cdk.EventKey:
+			return s.processKeyEvent(e)
+		case *cdk.EventMouse:
+			return s.processMouseEvent(e)
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (s *CScale) valueChanged(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	s.Invalidate()
+	return cenums.EVENT_PASS
+}
+
+// drawText draws the given text at the given position, without drawing beyond
+// the given width.
+func (s *CScale) drawText(surface *memphis.CSurface, x, y, width int, text string, style paint.Style) {
+	for _, r := range text {
+		w := runewidth.RuneWidth(r)
+		if x+w > width {
+			return
+		}
+		if x >= 0 {
+			_ = surface.SetRune(x, y, r, style)
+		}
+		x += w
+	}
+}
+
+func (s *CScale) draw(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if surface, ok := argv[1].(*memphis.CSurface); ok {
+		alloc := s.GetAllocation()
+		if !s.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+			s.LogTrace("not visible, zero width or zero height")
+			return cenums.EVENT_PASS
+		}
+		theme := s.GetThemeRequest()
+		surface.Fill(theme)
+		layout := s.getLayout(alloc)
+		trough := layout.trough
+		isVertical := s.GetOrientation() == cenums.ORIENTATION_VERTICAL
+		value := s.GetValue()
+
+		// trough and slider
+		length, lineRune := trough.W, paint.RuneHLine
+		if isVertical {
+			length, lineRune = trough.H, paint.RuneVLine
+		}
+		for i := 0; i < length; i++ {
+			if isVertical {
+				_ = surface.SetRune(trough.X, trough.Y+i, lineRune, theme.Border.Normal)
+			} else {
+				_ = surface.SetRune(trough.X+i, trough.Y, lineRune, theme.Border.Normal)
+			}
+		}
+		sliderOffset := s.getOffset(value, length)
+		if length > 0 {
+			if isVertical {
+				_ = surface.SetRune(trough.X, trough.Y+sliderOffset, paint.RuneBlock, theme.Content.Normal)
+			} else {
+				_ = surface.SetRune(trough.X+sliderOffset, trough.Y, paint.RuneBlock, theme.Content.Normal)
+			}
+		}
+
+		// marks
+		s.RLock()
+		marks := append([]*cScaleMark{}, s.marks...)
+		s.RUnlock()
+		for _, mark := range marks {
+			offset := s.getOffset(mark.value, length)
+			labelW := runewidth.StringWidth(mark.markup)
+			switch mark.position {
+			case enums.POS_TOP:
+				_ = surface.SetRune(trough.X+offset, layout.before, paint.RuneVLine, theme.Border.Normal)
+				if mark.markup != "" {
+					s.drawText(surface, trough.X+offset-labelW/2, layout.before-1, alloc.W, mark.markup, theme.Content.Normal)
+				}
+			case enums.POS_BOTTOM:
+				y := layout.after
+				if layout.labels[enums.POS_BOTTOM] {
+					y -= 1
+				}
+				_ = surface.SetRune(trough.X+offset, y, paint.RuneVLine, theme.Border.Normal)
+				if mark.markup != "" {
+					s.drawText(surface, trough.X+offset-labelW/2, y+1, alloc.W, mark.markup, theme.Content.Normal)
+				}
+			case enums.POS_LEFT:
+				_ = surface.SetRune(layout.before, trough.Y+offset, paint.RuneHLine, theme.Border.Normal)
+				if mark.markup != "" {
+					s.drawText(surface, layout.before-1-labelW, trough.Y+offset, layout.before, mark.markup, theme.Content.Normal)
+				}
+			case enums.POS_RIGHT:
+				_ = surface.SetRune(layout.after, trough.Y+offset, paint.RuneHLine, theme.Border.Normal)
+				if mark.markup != "" {
+					s.drawText(surface, layout.after+2, trough.Y+offset, alloc.W, mark.markup, theme.Content.Normal)
+				}
+			}
+		}
+
+		// value
+		if s.GetDrawValue() {
+			text := s.FormatValue(value)
+			region := layout.value
+			textW := runewidth.StringWidth(text)
+			x, y := region.X+region.W-textW, region.Y
+			switch pos := s.GetValuePos(); {
+			case !isVertical && (pos == enums.POS_TOP || pos == enums.POS_BOTTOM):
+				// follow the slider, within the allocation
+				x = trough.X + sliderOffset - textW/2
+				if x+textW > alloc.W {
+					x = alloc.W - textW
+				}
+				if x < 0 {
+					x = 0
+				}
+			case isVertical && (pos == enums.POS_LEFT || pos == enums.POS_RIGHT):
+				y = trough.Y + sliderOffset
+			case isVertical:
+				x = trough.X - textW/2
+				if x+textW > alloc.W {
+					x = alloc.W - textW
+				}
+				if x < 0 {
+					x = 0
+				}
+			}
+			s.drawText(surface, x, y, alloc.W, text, theme.Content.Normal)
+		}
+
+		if debug, _ := s.GetBoolProperty(cdk.PropertyDebug); debug {
+			surface.DebugBox(paint.ColorSilver, s.ObjectInfo())
+		}
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+// Whether the current value is displayed as a string next to the slider.
+// Flags: Read / Write
+// Default value: TRUE
+const PropertyDrawValue cdk.Property = "draw-value"
+
+// The position in which the current value is displayed.
+// Flags: Read / Write
+// Default value: GTK_POS_TOP
+const PropertyValuePos cdk.Property = "value-pos"
+
+// The format-value signal allows the application to change the text displayed
+// for the value of a Scale. Listeners change the text by updating the string
+// pointed to by the text argument.
+// Listener function arguments:
+//
+//	value float64	the value to format
+//	text *string	the text to display, initially formatted with the digits of the Scale
+const SignalFormatValue cdk.Signal = "format-value"
+
+const ScaleEventHandle = "scale-event-handler"
+
+const ScaleDrawHandle = "scale-draw-handler"
+
+const ScaleValueChangedHandle = "scale-value-changed-handler"
@@ -0,0 +1,190 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"testing"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/ptypes"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+func TestScale(t *testing.T) {
+	Convey("Testing Scales", t, func() {
+		makeWindow := func(w, h int, scale Scale) Window {
+			window := NewWindow()
+			window.SetDecorated(false)
+			window.Show()
+			window.SetAllocation(ptypes.MakeRectangle(w, h))
+			scale.Show()
+			window.GetVBox().PackStart(scale, false, false, 0)
+			window.Resize()
+			return window
+		}
+
+		Convey("Basics", func() {
+			s := NewHScaleWithRange(0, 100, 1)
+			So(s, ShouldNotBeNil)
+			So(s.GetOrientation(), ShouldEqual, cenums.ORIENTATION_HORIZONTAL)
+			So(s.GetValue(), ShouldEqual, 0)
+			So(s.GetDrawValue(), ShouldEqual, true)
+			So(s.GetValuePos(), ShouldEqual, enums.POS_TOP)
+			w, h := s.GetSizeRequest()
+			So(w, ShouldEqual, ScaleMinTroughLength)
+			So(h, ShouldEqual, 2)
+			s.SetValuePos(enums.POS_LEFT)
+			w, h = s.GetSizeRequest()
+			So(w, ShouldEqual, ScaleMinTroughLength+4)
+			So(h, ShouldEqual, 1)
+			s.SetDrawValue(false)
+			w, h = s.GetSizeRequest()
+			So(w, ShouldEqual, ScaleMinTroughLength)
+			So(h, ShouldEqual, 1)
+
+			So(s.FormatValue(25), ShouldEqual, "25")
+			s.SetDigits(1)
+			So(s.GetDigits(), ShouldEqual, 1)
+			So(s.FormatValue(25), ShouldEqual, "2.5")
+			s.Connect(SignalFormatValue, "test-format-value", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				if text, ok := argv[2].(*string); ok {
+					*text += "%"
+				}
+				return cenums.EVENT_PASS
+			})
+			So(s.FormatValue(25), ShouldEqual, "2.5%")
+
+			s.AddMark(50, enums.POS_BOTTOM, "half")
+			s.AddMark(0, enums.POS_LEFT, "")
+			w, h = s.GetSizeRequest()
+			So(h, ShouldEqual, 3)
+			s.ClearMarks()
+			w, h = s.GetSizeRequest()
+			So(h, ShouldEqual, 1)
+
+			v := NewVScaleWithRange(0, 10, 1)
+			So(v.GetOrientation(), ShouldEqual, cenums.ORIENTATION_VERTICAL)
+			w, h = v.GetSizeRequest()
+			So(w, ShouldEqual, 2)
+			So(h, ShouldEqual, ScaleMinTroughLength+1)
+			v.AddMark(5, enums.POS_RIGHT, "mid")
+			w, _ = v.GetSizeRequest()
+			So(w, ShouldEqual, 6)
+		})
+
+		Convey("Keyboard", func() {
+			s := NewHScaleWithRange(0, 100, 1)
+			window := makeWindow(20, 5, s)
+			s.GrabFocus()
+			So(s.HasFocus(), ShouldEqual, true)
+			trough := s.GetTroughRegion()
+			So(trough, ShouldResemble, ptypes.MakeRegion(0, 1, 20, 1))
+			changed := 0
+			s.Connect(SignalValueChanged, "test-value-changed", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				changed += 1
+				return cenums.EVENT_PASS
+			})
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRight, 0, cdk.ModNone))
+			So(s.GetValue(), ShouldEqual, 1)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRight, 0, cdk.ModShift))
+			So(s.GetValue(), ShouldEqual, 11)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyPgDn, 0, cdk.ModNone))
+			So(s.GetValue(), ShouldEqual, 21)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyLeft, 0, cdk.ModNone))
+			So(s.GetValue(), ShouldEqual, 20)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyEnd, 0, cdk.ModNone))
+			So(s.GetValue(), ShouldEqual, 100)
+			So(s.GetSliderRegion(), ShouldResemble, ptypes.MakeRegion(19, 1, 1, 1))
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyRight, 0, cdk.ModNone))
+			So(s.GetValue(), ShouldEqual, 100)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyHome, 0, cdk.ModNone))
+			So(s.GetValue(), ShouldEqual, 0)
+			So(changed, ShouldEqual, 6)
+
+			s.SetInverted(true)
+			So(s.GetSliderRegion().X, ShouldEqual, 19)
+			window.ProcessEvent(cdk.NewEventKey(cdk.KeyLeft, 0, cdk.ModNone))
+			So(s.GetValue(), ShouldEqual, 1)
+		})
+
+		Convey("Mouse", func() {
+			s := NewHScaleWithRange(0, 100, 1)
+			s.SetValue(50)
+			window := makeWindow(20, 5, s)
+			So(s.GetSliderRegion().X, ShouldEqual, 10)
+
+			// clicking the trough pages towards the pointer
+			window.ProcessEvent(cdk.NewEventMouse(2, 1, cdk.Button1, cdk.ModNone))
+			window.ProcessEvent(cdk.NewEventMouse(2, 1, cdk.ButtonNone, cdk.ModNone))
+			So(s.GetValue(), ShouldEqual, 40)
+			So(s.HasFocus(), ShouldEqual, true)
+
+			// unless the primary button warps the slider
+			GetDefaultSettings().SetCtkPrimaryButtonWarpsSlider(true)
+			window.ProcessEvent(cdk.NewEventMouse(15, 1, cdk.Button1, cdk.ModNone))
+			window.ProcessEvent(cdk.NewEventMouse(15, 1, cdk.ButtonNone, cdk.ModNone))
+			So(s.GetValue(), ShouldEqual, 79)
+			GetDefaultSettings().SetCtkPrimaryButtonWarpsSlider(false)
+
+			// the middle button always warps the slider
+			window.ProcessEvent(cdk.NewEventMouse(0, 1, cdk.Button2, cdk.ModNone))
+			window.ProcessEvent(cdk.NewEventMouse(0, 1, cdk.ButtonNone, cdk.ModNone))
+			So(s.GetValue(), ShouldEqual, 0)
+
+			// dragging the slider
+			window.ProcessEvent(cdk.NewEventMouse(0, 1, cdk.Button1, cdk.ModNone))
+			window.ProcessEvent(cdk.NewEventMouse(5, 1, cdk.Button1, cdk.ModNone))
+			So(s.GetValue(), ShouldEqual, 26)
+			window.ProcessEvent(cdk.NewEventMouse(19, 1, cdk.Button1, cdk.ModNone))
+			So(s.GetValue(), ShouldEqual, 100)
+			window.ProcessEvent(cdk.NewEventMouse(19, 1, cdk.ButtonNone, cdk.ModNone))
+			window.ProcessEvent(cdk.NewEventMouse(5, 1, cdk.ButtonNone, cdk.ModNone))
+			So(s.GetValue(), ShouldEqual, 100)
+		})
+
+		Convey("Builder", func() {
+			builder := NewBuilder()
+			_, err := builder.LoadFromString(`<interface>
+  <object class="GtkAdjustment" id="test-scale-adjustment">
+    <property name="lower">0</property>
+    <property name="upper">50</property>
+    <property name="value">20</property>
+    <property name="step_increment">1</property>
+    <property name="page_increment">5</property>
+  </object>
+  <object class="GtkVScale" id="test-scale">
+    <property name="adjustment">test-scale-adjustment</property>
+    <property name="digits">1</property>
+    <property name="value_pos">GTK_POS_BOTTOM</property>
+    <property name="inverted">True</property>
+  </object>
+</interface>`)
+			So(err, ShouldBeNil)
+			s, ok := builder.GetWidget("test-scale").(VScale)
+			So(ok, ShouldEqual, true)
+			So(s.GetValue(), ShouldEqual, 20)
+			So(s.GetDigits(), ShouldEqual, 1)
+			So(s.FormatValue(s.GetValue()), ShouldEqual, "2.0")
+			So(s.GetValuePos(), ShouldEqual, enums.POS_BOTTOM)
+			So(s.GetInverted(), ShouldEqual, true)
+			step, page := s.GetIncrements()
+			So(step, ShouldEqual, 1)
+			So(page, ShouldEqual, 5)
+		})
+	})
+}
@@ -697,6 +697,15 @@ func (s *CScrolledViewport) event(data []interface{}, argv ...interface{}) cenum
 				return cenums.EVENT_STOP
 			}
 		case *cdk.EventKey:
+			// focused children, ie: TreeView, handle their own scrolling
+			if child := s.GetChild(); child != nil && child.HasFocus() && child.IsSensitive() {
+				if cs, ok := child.Self().(Sensitive); ok {
+					if f := cs.ProcessEvent(evt); f == cenums.EVENT_STOP {
+						s.Invalidate()
+						return cenums.EVENT_STOP
+					}
+				}
+			}
 			if vs := s.GetVScrollbar(); vs != nil {
 				if f := vs.ProcessEvent(evt); f == cenums.EVENT_STOP {
 					s.Invalidate()
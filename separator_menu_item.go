@@ -0,0 +1,136 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/go-curses/cdk/memphis"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeSeparatorMenuItem cdk.CTypeTag = "ctk-separator-menu-item"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeSeparatorMenuItem, func() interface{} { return MakeSeparatorMenuItem() })
+}
+
+// SeparatorMenuItem Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Bin
+//	        +- Item
+//	          +- MenuItem
+//	            +- SeparatorMenuItem
+//
+// The SeparatorMenuItem is a separator used to group items within a menu. It
+// displays a horizontal line across the Menu, joined to the border of the
+// Menu. A SeparatorMenuItem can never be selected or activated.
+type SeparatorMenuItem interface {
+	MenuItem
+}
+
+var _ SeparatorMenuItem = (*CSeparatorMenuItem)(nil)
+
+// The CSeparatorMenuItem structure implements the SeparatorMenuItem interface
+// and is exported to facilitate type embedding with custom implementations.
+// No member variables are exported as the interface methods are the only
+// intended means of interacting with SeparatorMenuItem objects.
+type CSeparatorMenuItem struct {
+	CMenuItem
+}
+
+// MakeSeparatorMenuItem is used by the Buildable system to construct a new
+// SeparatorMenuItem.
+func MakeSeparatorMenuItem() SeparatorMenuItem {
+	return NewSeparatorMenuItem()
+}
+
+// NewSeparatorMenuItem is the constructor for new SeparatorMenuItem instances.
+func NewSeparatorMenuItem() SeparatorMenuItem {
+	s := new(CSeparatorMenuItem)
+	s.Init()
+	return s
+}
+
+// Init initializes a SeparatorMenuItem object. This must be called at least
+// once to set up the necessary defaults and allocate any memory structures.
+// Calling this more than once is safe though unnecessary. Only the first call
+// will result in any effect upon the SeparatorMenuItem instance. Init is used
+// in the NewSeparatorMenuItem constructor and only necessary when implementing
+// a derivative SeparatorMenuItem type.
+func (s *CSeparatorMenuItem) Init() (already bool) {
+	if s.InitTypeItem(TypeSeparatorMenuItem, s) {
+		return true
+	}
+	s.CMenuItem.Init()
+	_ = s.Disconnect(SignalDraw, MenuItemDrawHandle)
+	s.Connect(SignalDraw, SeparatorMenuItemDrawHandle, s.draw)
+	return false
+}
+
+// Activate does nothing, a SeparatorMenuItem cannot be activated.
+func (s *CSeparatorMenuItem) Activate() (value bool) {
+	return false
+}
+
+// GetSizeRequest returns the requested size of the SeparatorMenuItem, which is
+// a single line and no width of its own.
+func (s *CSeparatorMenuItem) GetSizeRequest() (width, height int) {
+	width, height = s.CBin.GetSizeRequest()
+	if width <= -1 {
+		width = 0
+		if s.getPlacement() == enums.TOP_BOTTOM {
+			width = 1
+		}
+	}
+	if height <= -1 {
+		height = 1
+	}
+	return
+}
+
+func (s *CSeparatorMenuItem) draw(data []interface{}, argv ...interface{}) cenums.EventFlag {
+
+	if surface, ok := argv[1].(*memphis.CSurface); ok {
+		alloc := s.GetAllocation()
+		if !s.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+			s.LogTrace("not visible, zero width or zero height")
+			return cenums.EVENT_PASS
+		}
+
+		theme := s.GetTheme()
+		surface.Fill(theme)
+		if s.getPlacement() == enums.TOP_BOTTOM {
+			_ = surface.SetRune(alloc.W/2, 0, paint.RuneVLine, theme.Border.Normal)
+		} else {
+			for x := 0; x < alloc.W; x++ {
+				_ = surface.SetRune(x, 0, paint.RuneHLine, theme.Border.Normal)
+			}
+		}
+
+		if debug, _ := s.GetBoolProperty(cdk.PropertyDebug); debug {
+			surface.DebugBox(paint.ColorSilver, s.ObjectInfo())
+		}
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+const SeparatorMenuItemDrawHandle = "separator-menu-item-draw-handler"
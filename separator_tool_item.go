@@ -0,0 +1,190 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/go-curses/cdk/memphis"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeSeparatorToolItem cdk.CTypeTag = "ctk-separator-tool-item"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeSeparatorToolItem, func() interface{} { return MakeSeparatorToolItem() })
+}
+
+// SeparatorToolItem Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Bin
+//	        +- ToolItem
+//	          +- SeparatorToolItem
+//
+// A SeparatorToolItem is a ToolItem that separates groups of other
+// ToolItems. Depending on the draw property and the space style of the
+// Toolbar, it is displayed as a line across the Toolbar or as blank space. If the expand property of the separator is TRUE and
+// the draw property is FALSE, the separator pushes all following items to the
+// end of the toolbar.
+type SeparatorToolItem interface {
+	ToolItem
+
+	GetDraw() (value bool)
+	SetDraw(draw bool)
+}
+
+var _ SeparatorToolItem = (*CSeparatorToolItem)(nil)
+
+// The CSeparatorToolItem structure implements the SeparatorToolItem interface
+// and is exported to facilitate type embedding with custom implementations.
+// No member variables are exported as the interface methods are the only
+// intended means of interacting with SeparatorToolItem objects.
+type CSeparatorToolItem struct {
+	CToolItem
+}
+
+// MakeSeparatorToolItem is used by the Buildable system to construct a new
+// SeparatorToolItem.
+func MakeSeparatorToolItem() SeparatorToolItem {
+	return NewSeparatorToolItem()
+}
+
+// NewSeparatorToolItem is the constructor for new SeparatorToolItem instances.
+func NewSeparatorToolItem() SeparatorToolItem {
+	s := new(CSeparatorToolItem)
+	s.Init()
+	return s
+}
+
+// Init initializes a SeparatorToolItem object. This must be called at least
+// once to set up the necessary defaults and allocate any memory structures.
+// Calling this more than once is safe though unnecessary. Only the first call
+// will result in any effect upon the SeparatorToolItem instance. Init is used
+// in the NewSeparatorToolItem constructor and only necessary when implementing
+// a derivative SeparatorToolItem type.
+func (s *CSeparatorToolItem) Init() (already bool) {
+	if s.InitTypeItem(TypeSeparatorToolItem, s) {
+		return true
+	}
+	s.CToolItem.Init()
+	_ = s.InstallBuildableProperty(PropertyDraw, cdk.BoolProperty, true, true)
+	_ = s.Disconnect(SignalDraw, ToolItemDrawHandle)
+	s.Connect(SignalDraw, SeparatorToolItemDrawHandle, s.draw)
+	s.Connect(SignalCreateMenuProxy, SeparatorToolItemCreateMenuProxyHandle, s.createMenuProxy)
+	return false
+}
+
+// GetDraw returns whether the separator is drawn as a line, or just blank.
+// See SetDraw.
+func (s *CSeparatorToolItem) GetDraw() (value bool) {
+	var err error
+	if value, err = s.GetBoolProperty(PropertyDraw); err != nil {
+		s.LogErr(err)
+	}
+	return
+}
+
+// SetDraw updates whether the separator is drawn as a line, or just blank.
+// Setting this to FALSE along with SetExpand is useful to create an item that
+// forces following items to the end of the toolbar.
+//
+// Parameters:
+//
+//	draw	whether the separator should be drawn as a line
+func (s *CSeparatorToolItem) SetDraw(draw bool) {
+	if err := s.SetBoolProperty(PropertyDraw, draw); err != nil {
+		s.LogErr(err)
+	}
+	s.Invalidate()
+}
+
+// GetSizeRequest returns the requested size of the SeparatorToolItem, which is
+// a single column and a single line.
+func (s *CSeparatorToolItem) GetSizeRequest() (width, height int) {
+	width, height = s.CBin.GetSizeRequest()
+	if width <= -1 {
+		width = 1
+	}
+	if height <= -1 {
+		height = 1
+	}
+	return
+}
+
+// getSpaceStyle returns the space style of the Toolbar the separator is on.
+func (s *CSeparatorToolItem) getSpaceStyle() enums.ToolbarSpaceStyle {
+	if toolbar := s.GetToolbar(); toolbar != nil {
+		return toolbar.GetSpaceStyle()
+	}
+	return enums.TOOLBAR_SPACE_LINE
+}
+
+func (s *CSeparatorToolItem) createMenuProxy(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if s.GetProxyMenuItem(SeparatorToolItemMenuProxyId) == nil {
+		separator := NewSeparatorMenuItem()
+		separator.Show()
+		s.SetProxyMenuItem(SeparatorToolItemMenuProxyId, separator)
+	}
+	return cenums.EVENT_PASS
+}
+
+func (s *CSeparatorToolItem) draw(data []interface{}, argv ...interface{}) cenums.EventFlag {
+
+	if surface, ok := argv[1].(*memphis.CSurface); ok {
+		alloc := s.GetAllocation()
+		if !s.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+			s.LogTrace("not visible, zero width or zero height")
+			return cenums.EVENT_PASS
+		}
+
+		theme := s.GetTheme()
+		surface.Fill(theme)
+		if s.GetDraw() && s.getSpaceStyle() == enums.TOOLBAR_SPACE_LINE {
+			if s.GetOrientation() == cenums.ORIENTATION_VERTICAL {
+				for x := 0; x < alloc.W; x++ {
+					_ = surface.SetRune(x, alloc.H/2, paint.RuneHLine, theme.Border.Normal)
+				}
+			} else {
+				for y := 0; y < alloc.H; y++ {
+					_ = surface.SetRune(alloc.W/2, y, paint.RuneVLine, theme.Border.Normal)
+				}
+			}
+		}
+
+		if debug, _ := s.GetBoolProperty(cdk.PropertyDebug); debug {
+			surface.DebugBox(paint.ColorSilver, s.ObjectInfo())
+		}
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+// Whether the separator is drawn, or just blank.
+// Flags: Read / Write
+// Default value: TRUE
+const PropertyDraw cdk.Property = "draw"
+
+const SeparatorToolItemDrawHandle = "separator-tool-item-draw-handler"
+
+const SeparatorToolItemCreateMenuProxyHandle = "separator-tool-item-create-menu-proxy-handler"
+
+// SeparatorToolItemMenuProxyId identifies the overflow menu proxies created by
+// SeparatorToolItems with SetProxyMenuItem.
+const SeparatorToolItemMenuProxyId = "ctk-separator-tool-item-menu-id"
@@ -50,6 +50,7 @@ type Settings interface {
 	GetDoubleClickDistance() (value int)
 	GetDoubleClickTime() (value time.Duration)
 	GetEnableAccels() (value bool)
+	GetEnableAnimations() (value bool)
 	GetEnableMnemonics() (value bool)
 	GetEnableTooltips() (value bool)
 	GetEntryPasswordHintTimeout() (value time.Duration)
@@ -96,6 +97,7 @@ type Settings interface {
 	SetCtkDoubleClickDistance(value int)
 	SetCtkDoubleClickTime(value time.Duration)
 	SetCtkEnableAccels(value bool)
+	SetCtkEnableAnimations(value bool)
 	SetCtkEnableMnemonics(value bool)
 	SetCtkEnableTooltips(value bool)
 	SetCtkEntryPasswordHintTimeout(value time.Duration)
@@ -161,7 +163,7 @@ func (s *CSettings) Init() (already bool) {
 	s.CObject.Init()
 	_ = s.InstallProperty(PropertyCtkAlternativeButtonOrder, cdk.BoolProperty, true, false)
 	_ = s.InstallProperty(PropertyCtkAlternativeSortArrows, cdk.BoolProperty, true, false)
-	_ = s.InstallProperty(PropertyCtkColorPalette, cdk.StringProperty, true, nil)
+	_ = s.InstallProperty(PropertyCtkColorPalette, cdk.StringProperty, true, "")
 	_ = s.InstallProperty(PropertyCtkColorScheme, cdk.StringProperty, true, "")
 	_ = s.InstallProperty(PropertyCtkCursorBlink, cdk.BoolProperty, true, true)
 	_ = s.InstallProperty(PropertyCtkCursorBlinkTime, cdk.TimeProperty, true, 1200*time.Millisecond)
@@ -171,6 +173,7 @@ func (s *CSettings) Init() (already bool) {
 	_ = s.InstallProperty(PropertyCtkDoubleClickDistance, cdk.IntProperty, true, 1)
 	_ = s.InstallProperty(PropertyCtkDoubleClickTime, cdk.TimeProperty, true, 250*time.Millisecond)
 	_ = s.InstallProperty(PropertyCtkEnableAccels, cdk.BoolProperty, true, true)
+	_ = s.InstallProperty(PropertyCtkEnableAnimations, cdk.BoolProperty, true, true)
 	_ = s.InstallProperty(PropertyCtkEnableMnemonics, cdk.BoolProperty, true, true)
 	_ = s.InstallProperty(PropertyCtkEnableTooltips, cdk.BoolProperty, true, true)
 	_ = s.InstallProperty(PropertyCtkEntryPasswordHintTimeout, cdk.TimeProperty, true, 0*time.Millisecond)
@@ -186,7 +189,7 @@ func (s *CSettings) Init() (already bool) {
 	_ = s.InstallProperty(PropertyCtkKeynavCursorOnly, cdk.BoolProperty, true, false)
 	_ = s.InstallProperty(PropertyCtkKeynavWrapAround, cdk.BoolProperty, true, true)
 	_ = s.InstallProperty(PropertyCtkLabelSelectOnFocus, cdk.BoolProperty, true, true)
-	_ = s.InstallProperty(PropertyCtkMenuBarAccel, cdk.StringProperty, true, nil)
+	_ = s.InstallProperty(PropertyCtkMenuBarAccel, cdk.StringProperty, true, "F10")
 	_ = s.InstallProperty(PropertyCtkMenuBarPopupDelay, cdk.TimeProperty, true, 0*time.Millisecond)
 	_ = s.InstallProperty(PropertyCtkMenuImages, cdk.BoolProperty, true, true)
 	_ = s.InstallProperty(PropertyCtkMenuPopdownDelay, cdk.TimeProperty, true, 1000*time.Millisecond)
@@ -342,6 +345,14 @@ func (s *CSettings) GetEnableAccels() (value bool) {
 	return
 }
 
+func (s *CSettings) GetEnableAnimations() (value bool) {
+	var err error
+	if value, err = s.GetBoolProperty(PropertyCtkEnableAnimations); err != nil {
+		s.LogErr(err)
+	}
+	return
+}
+
 func (s *CSettings) GetEnableMnemonics() (value bool) {
 	var err error
 	if value, err = s.GetBoolProperty(PropertyCtkEnableMnemonics); err != nil {
@@ -710,6 +721,14 @@ func (s *CSettings) SetCtkEnableAccels(value bool) {
 	}
 }
 
+func (s *CSettings) SetCtkEnableAnimations(value bool) {
+	if f := s.Emit(SignalSetCtkEnableAnimations, value); f == enums.EVENT_PASS {
+		if err := s.SetBoolProperty(PropertyCtkEnableAnimations, value); err != nil {
+			s.LogErr(err)
+		}
+	}
+}
+
 func (s *CSettings) SetCtkEnableMnemonics(value bool) {
 	if f := s.Emit(SignalSetCtkEnableMnemonics, value); f == enums.EVENT_PASS {
 		if err := s.SetBoolProperty(PropertyCtkEnableMnemonics, value); err != nil {
@@ -996,6 +1015,7 @@ func ctkSettingsPropertyKeys() []cdk.Property {
 		PropertyCtkDoubleClickDistance,
 		PropertyCtkDoubleClickTime,
 		PropertyCtkEnableAccels,
+		PropertyCtkEnableAnimations,
 		PropertyCtkEnableMnemonics,
 		PropertyCtkEnableTooltips,
 		PropertyCtkEntryPasswordHintTimeout,
@@ -1045,18 +1065,18 @@ const PropertyCtkAlternativeButtonOrder cdk.Property = "ctk-alternative-button-o
 // Default value: FALSE
 const PropertyCtkAlternativeSortArrows cdk.Property = "ctk-alternative-sort-arrows"
 
-// Palette to use in the color selector.
+// Name of the style sheet @palette to use for "@name" color references, in
+// addition to the palette named "default". Changes take effect the next time
+// styles are applied, see Window.ReApplyStyles.
 // Flags: Read / Write
-// Default value: "black:white:gray50:red:purple:blue:light blue:green:yellow:orange:lavender:brown:goldenrod4:dodger blue:pink:light green:gray10:gray30:gray75:gray90"
+// Default value: ""
 const PropertyCtkColorPalette cdk.Property = "ctk-color-palette"
 
 // A palette of named colors for use in themes. The format of the string is
-// Color names must be acceptable as identifiers in the color specifications
-// must be in the format accepted by ColorParse. Note that due to the
-// way the color tables from different sources are merged, color
-// specifications will be converted to hexadecimal form when getting this
-// property. Starting with CTK 2.12, the entries can alternatively be
-// separated by ';' instead of newlines:
+// a list of "name: color" pairs, separated by newlines or ';'. Color names
+// must be acceptable as identifiers and the color specifications must be in
+// the format accepted by the style sheet. Colors named here take precedence
+// over those of the style sheet @palette rules.
 // Flags: Read / Write
 // Default value: ""
 const PropertyCtkColorScheme cdk.Property = "ctk-color-scheme"
@@ -1113,6 +1133,12 @@ const PropertyCtkDoubleClickTime cdk.Property = "ctk-double-click-time"
 // Default value: TRUE
 const PropertyCtkEnableAccels cdk.Property = "ctk-enable-accels"
 
+// Whether animations should be enabled. When disabled, style sheets see the
+// prefers-reduced-motion media feature as "reduce".
+// Flags: Read / Write
+// Default value: TRUE
+const PropertyCtkEnableAnimations cdk.Property = "ctk-enable-animations"
+
 // Whether labels and menu items should have visible mnemonics which can be
 // activated.
 // Flags: Read / Write
@@ -1335,6 +1361,7 @@ const SignalSetCtkDndDragThreshold cdk.Signal = "ctk-dnd-drag-threshold"
 const SignalSetCtkDoubleClickDistance cdk.Signal = "ctk-double-click-distance"
 const SignalSetCtkDoubleClickTime cdk.Signal = "ctk-double-click-time"
 const SignalSetCtkEnableAccels cdk.Signal = "ctk-enable-accels"
+const SignalSetCtkEnableAnimations cdk.Signal = "ctk-enable-animations"
 const SignalSetCtkEnableMnemonics cdk.Signal = "ctk-enable-mnemonics"
 const SignalSetCtkEnableTooltips cdk.Signal = "ctk-enable-tooltips"
 const SignalSetCtkEntryPasswordHintTimeout cdk.Signal = "ctk-entry-password-hint-timeout"
@@ -0,0 +1,398 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	cstrings "github.com/go-curses/cdk/lib/strings"
+	"github.com/gofrs/uuid"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeSizeGroup cdk.CTypeTag = "ctk-size-group"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeSizeGroup, func() interface{} { return MakeSizeGroup() })
+}
+
+// SizeGroup Hierarchy:
+//
+//	Object
+//	  +- SizeGroup
+//
+// SizeGroup provides a mechanism for grouping a number of widgets together so
+// they all request the same amount of space. This is typically useful when
+// you want a column of widgets to have the same size, but you can't use a
+// Table widget, like the labels of a form where each row is an HBox.
+//
+// In detail, the size requested for each widget in a SizeGroup is the maximum
+// of the sizes that would have been requested for each widget in the size
+// group if they were not in the size group. The mode of the size group (see
+// SetMode) determines whether this applies to the horizontal size, the
+// vertical size, or both sizes.
+//
+// Note that size groups only affect the amount of space requested, not the
+// size that the widgets finally receive. If you want the widgets in a
+// SizeGroup to actually be the same size, you need to pack them in such a way
+// that they get the size they request and not more. For example, if you are
+// packing your widgets into a Box, put them in with expand set to FALSE.
+//
+// Containers consult the size groups of their children with
+// GetGroupedSizeRequest, which is done by Box (and so ButtonBox), Frame and
+// Alignment. Custom containers should do the same when measuring their
+// children.
+//
+// Widgets can be part of multiple size groups, in which case the largest
+// request of all the groups applies. Adding or removing widgets, or changing
+// the mode of the group, resizes the Windows of the widgets so that the
+// layout is updated.
+type SizeGroup interface {
+	Object
+
+	Init() (already bool)
+	Build(builder Builder, element *CBuilderElement) error
+	SetMode(mode enums.SizeGroupMode)
+	GetMode() (value enums.SizeGroupMode)
+	SetIgnoreHidden(ignoreHidden bool)
+	GetIgnoreHidden() (value bool)
+	AddWidget(widget Widget)
+	RemoveWidget(widget Widget)
+	GetWidgets() (widgets []Widget)
+	GetSizeRequest() (width, height int)
+}
+
+var _ SizeGroup = (*CSizeGroup)(nil)
+
+// The CSizeGroup structure implements the SizeGroup interface and is exported
+// to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with SizeGroup objects.
+type CSizeGroup struct {
+	CObject
+
+	widgets []Widget
+	handle  string
+}
+
+// MakeSizeGroup is used by the Buildable system to construct a new SizeGroup.
+func MakeSizeGroup() SizeGroup {
+	return NewSizeGroup(enums.SIZE_GROUP_HORIZONTAL)
+}
+
+// NewSizeGroup is the constructor for new SizeGroup instances.
+//
+// Parameters:
+//
+//	mode	the mode for the new size group.
+func NewSizeGroup(mode enums.SizeGroupMode) SizeGroup {
+	s := new(CSizeGroup)
+	s.Init()
+	if err := s.SetStructProperty(PropertySizeGroupMode, mode); err != nil {
+		s.LogErr(err)
+	}
+	return s
+}
+
+// Init initializes a SizeGroup object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the SizeGroup instance. Init is used in the
+// NewSizeGroup constructor and only necessary when implementing a derivative
+// SizeGroup type.
+func (s *CSizeGroup) Init() (already bool) {
+	if s.InitTypeItem(TypeSizeGroup, s) {
+		return true
+	}
+	s.CObject.Init()
+	s.widgets = make([]Widget, 0)
+	s.handle = fmt.Sprintf("%v-%v", SizeGroupWidgetHandle, s.ObjectID())
+	_ = s.InstallBuildableProperty(PropertySizeGroupMode, cdk.StructProperty, true, enums.SIZE_GROUP_HORIZONTAL)
+	_ = s.InstallBuildableProperty(PropertyIgnoreHidden, cdk.BoolProperty, true, false)
+	return false
+}
+
+// Build provides customizations to the Buildable system for SizeGroup
+// objects. The mode and ignore-hidden properties are supported along with the
+// <widgets> element of GtkSizeGroup objects, listing the names of previously
+// built widgets to add to the group.
+func (s *CSizeGroup) Build(builder Builder, element *CBuilderElement) error {
+	s.Freeze()
+	defer s.Thaw()
+	if name, ok := element.Attributes["id"]; ok {
+		s.SetName(name)
+	}
+	if v, ok := element.Properties[PropertySizeGroupMode.String()]; ok {
+		if mode, err := enums.SIZE_GROUP_NONE.FromString(v); err != nil {
+			return err
+		} else {
+			s.SetMode(mode.(enums.SizeGroupMode))
+		}
+	}
+	if v, ok := element.Properties[PropertyIgnoreHidden.String()]; ok {
+		s.SetIgnoreHidden(cstrings.IsTrue(v))
+	}
+	for _, custom := range element.Custom {
+		if custom.TagName != "widgets" {
+			continue
+		}
+		for _, child := range custom.Children {
+			name := child.Attributes["name"]
+			if widget, ok := builder.GetWidget(name).(Widget); ok {
+				s.AddWidget(widget)
+			} else {
+				s.LogError("size group widget not found: %v", name)
+			}
+		}
+	}
+	element.ApplySignals()
+	return nil
+}
+
+// SetMode sets the mode of the size group. The mode of the size group
+// determines whether the widgets in the size group should all have the same
+// horizontal requisition (SIZE_GROUP_HORIZONTAL) all have the same vertical
+// requisition (SIZE_GROUP_VERTICAL), or should all have the same requisition
+// in both directions (SIZE_GROUP_BOTH).
+//
+// Parameters:
+//
+//	mode	the mode to set for the size group.
+func (s *CSizeGroup) SetMode(mode enums.SizeGroupMode) {
+	if mode == s.GetMode() {
+		return
+	}
+	if err := s.SetStructProperty(PropertySizeGroupMode, mode); err != nil {
+		s.LogErr(err)
+	} else {
+		sizeGroupQueueResize(s.GetWidgets())
+	}
+}
+
+// GetMode returns the current mode of the size group.
+// See: SetMode()
+func (s *CSizeGroup) GetMode() (value enums.SizeGroupMode) {
+	var ok bool
+	if v, err := s.GetStructProperty(PropertySizeGroupMode); err != nil {
+		s.LogErr(err)
+	} else if value, ok = v.(enums.SizeGroupMode); !ok {
+		s.LogError("invalid value stored in %v: %v (%T)", PropertySizeGroupMode, v, v)
+	}
+	return
+}
+
+// SetIgnoreHidden sets whether unmapped widgets should be ignored when
+// calculating the size.
+//
+// Parameters:
+//
+//	ignoreHidden	whether unmapped widgets should be ignored when
+//	                calculating the size
+func (s *CSizeGroup) SetIgnoreHidden(ignoreHidden bool) {
+	if ignoreHidden == s.GetIgnoreHidden() {
+		return
+	}
+	if err := s.SetBoolProperty(PropertyIgnoreHidden, ignoreHidden); err != nil {
+		s.LogErr(err)
+	} else {
+		sizeGroupQueueResize(s.GetWidgets())
+	}
+}
+
+// GetIgnoreHidden returns if invisible widgets are ignored when calculating
+// the size.
+// See: SetIgnoreHidden()
+func (s *CSizeGroup) GetIgnoreHidden() (value bool) {
+	var err error
+	if value, err = s.GetBoolProperty(PropertyIgnoreHidden); err != nil {
+		s.LogErr(err)
+	}
+	return
+}
+
+// AddWidget adds a widget to a SizeGroup. In the future, the requisition of
+// the widget will be determined as the maximum of its requisition and the
+// requisition of the other widgets in the size group. Whether this applies
+// horizontally, vertically, or in both directions depends on the mode of the
+// size group. See SetMode.
+//
+// When the widget is destroyed, it is removed from the size group.
+//
+// Parameters:
+//
+//	widget	the Widget to add
+func (s *CSizeGroup) AddWidget(widget Widget) {
+	member, ok := widget.Self().(sizeGroupMember)
+	if !ok {
+		s.LogError("widget does not support size groups: %v (%T)", widget, widget)
+		return
+	}
+	s.Lock()
+	for _, w := range s.widgets {
+		if w.ObjectID() == widget.ObjectID() {
+			s.Unlock()
+			return
+		}
+	}
+	s.widgets = append(s.widgets, widget)
+	s.Unlock()
+	member.addSizeGroup(s)
+	widget.Connect(SignalShow, s.handle, s.widgetVisibilityChanged)
+	widget.Connect(SignalHide, s.handle, s.widgetVisibilityChanged)
+	sizeGroupQueueResize(s.GetWidgets())
+}
+
+// RemoveWidget removes a widget from a SizeGroup.
+//
+// Parameters:
+//
+//	widget	the Widget to remove
+func (s *CSizeGroup) RemoveWidget(widget Widget) {
+	s.Lock()
+	index := -1
+	for idx, w := range s.widgets {
+		if w.ObjectID() == widget.ObjectID() {
+			index = idx
+			break
+		}
+	}
+	if index < 0 {
+		s.Unlock()
+		return
+	}
+	s.widgets = append(s.widgets[:index], s.widgets[index+1:]...)
+	s.Unlock()
+	if member, ok := widget.Self().(sizeGroupMember); ok {
+		member.removeSizeGroup(s)
+	}
+	_ = widget.Disconnect(SignalShow, s.handle)
+	_ = widget.Disconnect(SignalHide, s.handle)
+	sizeGroupQueueResize(append(s.GetWidgets(), widget))
+}
+
+// GetWidgets returns the list of widgets associated with the SizeGroup.
+func (s *CSizeGroup) GetWidgets() (widgets []Widget) {
+	s.RLock()
+	defer s.RUnlock()
+	widgets = append(widgets, s.widgets...)
+	return
+}
+
+// GetSizeRequest returns the largest width and height requested by the widgets
+// of the SizeGroup, regardless of the mode. Hidden widgets are skipped when
+// the ignore-hidden property is set. A dimension is -1 when none of the
+// widgets request a size for it.
+func (s *CSizeGroup) GetSizeRequest() (width, height int) {
+	width, height = -1, -1
+	ignoreHidden := s.GetIgnoreHidden()
+	for _, widget := range s.GetWidgets() {
+		if ignoreHidden && !widget.IsVisible() {
+			continue
+		}
+		w, h := widget.GetSizeRequest()
+		if w > width {
+			width = w
+		}
+		if h > height {
+			height = h
+		}
+	}
+	return
+}
+
+func (s *CSizeGroup) widgetVisibilityChanged(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if s.GetIgnoreHidden() {
+		sizeGroupQueueResize(s.GetWidgets())
+	}
+	return cenums.EVENT_PASS
+}
+
+// GetGroupedSizeRequest returns the size requested by the given Widget, taking
+// into account the SizeGroups the Widget is a member of. For each dimension
+// grouped by the mode of a SizeGroup, the largest request of the widgets in
+// the group is returned. This is used by Container widgets to measure their
+// children.
+//
+// Parameters:
+//
+//	widget	the Widget to measure
+func GetGroupedSizeRequest(widget Widget) (width, height int) {
+	width, height = widget.GetSizeRequest()
+	member, ok := widget.Self().(sizeGroupMember)
+	if !ok {
+		return
+	}
+	for _, group := range member.getSizeGroups() {
+		mode := group.GetMode()
+		if mode == enums.SIZE_GROUP_NONE {
+			continue
+		}
+		if group.GetIgnoreHidden() && !widget.IsVisible() {
+			continue
+		}
+		gw, gh := group.GetSizeRequest()
+		if (mode == enums.SIZE_GROUP_HORIZONTAL || mode == enums.SIZE_GROUP_BOTH) && gw > width {
+			width = gw
+		}
+		if (mode == enums.SIZE_GROUP_VERTICAL || mode == enums.SIZE_GROUP_BOTH) && gh > height {
+			height = gh
+		}
+	}
+	return
+}
+
+// sizeGroupMember is implemented by all CWidget derived types.
+type sizeGroupMember interface {
+	addSizeGroup(group SizeGroup)
+	removeSizeGroup(group SizeGroup)
+	getSizeGroups() (groups []SizeGroup)
+}
+
+// sizeGroupQueueResize updates the layout of the given widgets, resizing the
+// Window of each widget, or the parent of widgets without a Window.
+func sizeGroupQueueResize(widgets []Widget) {
+	resized := make(map[uuid.UUID]bool)
+	for _, widget := range widgets {
+		var target Widget
+		if window := widget.GetWindow(); window != nil {
+			target = window
+		} else if parent := widget.GetParent(); parent != nil {
+			target = parent
+		} else {
+			continue
+		}
+		if !resized[target.ObjectID()] {
+			resized[target.ObjectID()] = true
+			target.Resize()
+		}
+	}
+}
+
+// The directions in which the size group affects the requested sizes of its
+// component widgets.
+// Flags: Read / Write
+// Default value: SIZE_GROUP_HORIZONTAL
+const PropertySizeGroupMode cdk.Property = "mode"
+
+// If TRUE, unmapped widgets are ignored when determining the size of the
+// group.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyIgnoreHidden cdk.Property = "ignore-hidden"
+
+const SizeGroupWidgetHandle = "size-group-widget-handler"
@@ -0,0 +1,125 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"testing"
+
+	"github.com/go-curses/cdk/lib/ptypes"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+func TestSizeGroup(t *testing.T) {
+	Convey("Testing Size Groups", t, func() {
+		Convey("Basics", func() {
+			g := NewSizeGroup(enums.SIZE_GROUP_VERTICAL)
+			So(g, ShouldNotBeNil)
+			So(g.GetMode(), ShouldEqual, enums.SIZE_GROUP_VERTICAL)
+			So(g.GetIgnoreHidden(), ShouldEqual, false)
+			one, two := NewLabel("one"), NewLabel("three")
+			one.SetSizeRequest(-1, 2)
+			g.AddWidget(one)
+			g.AddWidget(two)
+			g.AddWidget(one)
+			So(g.GetWidgets(), ShouldHaveLength, 2)
+			w, h := GetGroupedSizeRequest(two)
+			So(w, ShouldEqual, 5)
+			So(h, ShouldEqual, 2)
+			g.SetMode(enums.SIZE_GROUP_BOTH)
+			w, h = GetGroupedSizeRequest(one)
+			So(w, ShouldEqual, 5)
+			So(h, ShouldEqual, 2)
+			g.SetMode(enums.SIZE_GROUP_NONE)
+			w, _ = GetGroupedSizeRequest(one)
+			So(w, ShouldEqual, 3)
+			g.SetMode(enums.SIZE_GROUP_HORIZONTAL)
+			g.SetIgnoreHidden(true)
+			one.Show()
+			w, _ = GetGroupedSizeRequest(one)
+			So(w, ShouldEqual, 3)
+			two.Show()
+			w, _ = GetGroupedSizeRequest(one)
+			So(w, ShouldEqual, 5)
+			g.RemoveWidget(two)
+			So(g.GetWidgets(), ShouldHaveLength, 1)
+			w, _ = GetGroupedSizeRequest(one)
+			So(w, ShouldEqual, 3)
+			one.Destroy()
+			So(g.GetWidgets(), ShouldHaveLength, 0)
+		})
+
+		Convey("Layout", func() {
+			window := NewWindow()
+			window.SetDecorated(false)
+			window.Show()
+			window.SetAllocation(ptypes.MakeRectangle(40, 10))
+			vbox := window.GetVBox()
+			name, desc := NewLabel("Name"), NewLabel("Description")
+			nameEntry, descEntry := NewEntry(""), NewEntry("")
+			for _, row := range [][2]Widget{{name, nameEntry}, {desc, descEntry}} {
+				hbox := NewHBox(false, 1)
+				hbox.Show()
+				row[0].Show()
+				row[1].Show()
+				hbox.PackStart(row[0], false, false, 0)
+				hbox.PackStart(row[1], true, true, 0)
+				vbox.PackStart(hbox, false, false, 0)
+			}
+			window.Resize()
+			So(name.GetAllocation().W, ShouldEqual, 4)
+			So(nameEntry.GetOrigin().X, ShouldEqual, 5)
+			So(descEntry.GetOrigin().X, ShouldEqual, 12)
+
+			group := NewSizeGroup(enums.SIZE_GROUP_HORIZONTAL)
+			group.AddWidget(name)
+			group.AddWidget(desc)
+			So(name.GetAllocation().W, ShouldEqual, 11)
+			So(nameEntry.GetOrigin().X, ShouldEqual, 12)
+			So(descEntry.GetOrigin().X, ShouldEqual, 12)
+
+			group.RemoveWidget(desc)
+			So(name.GetAllocation().W, ShouldEqual, 4)
+			So(nameEntry.GetOrigin().X, ShouldEqual, 5)
+		})
+
+		Convey("Builder", func() {
+			builder := NewBuilder()
+			_, err := builder.LoadFromString(`<interface>
+  <object class="GtkLabel" id="test-size-group-one">
+    <property name="label">one</property>
+  </object>
+  <object class="GtkLabel" id="test-size-group-two">
+    <property name="label">three</property>
+  </object>
+  <object class="GtkSizeGroup" id="test-size-group">
+    <property name="mode">GTK_SIZE_GROUP_BOTH</property>
+    <property name="ignore_hidden">True</property>
+    <widgets>
+      <widget name="test-size-group-one"/>
+      <widget name="test-size-group-two"/>
+    </widgets>
+  </object>
+</interface>`)
+			So(err, ShouldBeNil)
+			g, ok := builder.GetWidget("test-size-group").(SizeGroup)
+			So(ok, ShouldEqual, true)
+			So(g.GetMode(), ShouldEqual, enums.SIZE_GROUP_BOTH)
+			So(g.GetIgnoreHidden(), ShouldEqual, true)
+			So(g.GetWidgets(), ShouldHaveLength, 2)
+		})
+	})
+}
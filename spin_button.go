@@ -0,0 +1,862 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/ptypes"
+	"github.com/go-curses/cdk/memphis"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeSpinButton cdk.CTypeTag = "ctk-spin-button"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeSpinButton, func() interface{} { return MakeSpinButton() })
+}
+
+// SpinButton Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Misc
+//	      +- Entry
+//	        +- SpinButton
+//
+// A SpinButton is an ideal way to allow the user to set the value of some
+// attribute. Rather than having to directly type a number into an Entry,
+// SpinButton allows the user to click on one of two arrows to increment or
+// decrement the displayed value. The Up, Down, PgUp and PgDn keys and the
+// mouse wheel change the value as well, while Ctrl+PgUp and Ctrl+PgDn jump to
+// the upper and lower bounds. A value can still be typed in, with the
+// additional capability to check that it is in a given range; the typed text
+// is applied when Enter is pressed or when the SpinButton loses focus.
+//
+// The main properties of a SpinButton are through an Adjustment. As CTK
+// Adjustments are integer based, the Adjustment values of a SpinButton are in
+// units of the last decimal digit displayed, ie: with two digits, an
+// Adjustment value of 150 is displayed as "1.50". The float64 methods of the
+// SpinButton (GetValue, SetRange, SetIncrements and so on) convert to and from
+// these units.
+type SpinButton interface {
+	Entry
+
+	Init() (already bool)
+	Build(builder Builder, element *CBuilderElement) error
+	Configure(adjustment Adjustment, digits int)
+	SetAdjustment(adjustment Adjustment)
+	GetAdjustment() (adjustment Adjustment)
+	SetDigits(digits int)
+	GetDigits() (digits int)
+	SetIncrements(step float64, page float64)
+	GetIncrements() (step float64, page float64)
+	SetRange(min float64, max float64)
+	GetRange() (min float64, max float64)
+	GetValue() (value float64)
+	GetValueAsInt() (value int)
+	SetValue(value float64)
+	SetUpdatePolicy(policy enums.SpinButtonUpdatePolicy)
+	GetUpdatePolicy() (policy enums.SpinButtonUpdatePolicy)
+	SetNumeric(numeric bool)
+	GetNumeric() (numeric bool)
+	Spin(direction enums.SpinType, increment float64)
+	SetWrap(wrap bool)
+	GetWrap() (wrap bool)
+	SetSnapToTicks(snapToTicks bool)
+	GetSnapToTicks() (snapToTicks bool)
+	Update()
+	GetSizeRequest() (width, height int)
+}
+
+var _ SpinButton = (*CSpinButton)(nil)
+
+// The CSpinButton structure implements the SpinButton interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with SpinButton objects.
+type CSpinButton struct {
+	CEntry
+
+	adjustmentHandle string
+}
+
+// MakeSpinButton is used by the Buildable system to construct a new
+// SpinButton.
+func MakeSpinButton() SpinButton {
+	return NewSpinButton(nil, 0)
+}
+
+// NewSpinButton is the constructor for new SpinButton instances.
+//
+// Parameters:
+//
+//	adjustment	the Adjustment that this spin button should use, or nil
+//	digits	the number of decimal places to display
+func NewSpinButton(adjustment Adjustment, digits int) SpinButton {
+	s := new(CSpinButton)
+	s.Init()
+	s.Configure(adjustment, digits)
+	return s
+}
+
+// NewSpinButtonWithRange is a convenience constructor that allows creation of
+// a numeric SpinButton without manually creating an adjustment. The value is
+// initially set to the minimum value and a page increment of 10 * step is the
+// default. The precision of the spin button is equivalent to the precision of
+// step.
+//
+// Parameters:
+//
+//	min	minimum allowable value
+//	max	maximum allowable value
+//	step	increment added or subtracted by spinning the widget
+func NewSpinButtonWithRange(min, max, step float64) SpinButton {
+	digits := 0
+	for step = math.Abs(step); digits < SpinButtonMaxDigits; digits++ {
+		if scaled := step * math.Pow10(digits); math.Abs(scaled-math.Round(scaled)) < 1e-9 {
+			break
+		}
+	}
+	scale := math.Pow10(digits)
+	units := func(v float64) int {
+		return int(math.Round(v * scale))
+	}
+	adjustment := NewAdjustment(units(min), units(min), units(max), units(step), units(step*10.0), 0)
+	s := NewSpinButton(adjustment, digits)
+	s.SetNumeric(true)
+	return s
+}
+
+// Init initializes a SpinButton object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the SpinButton instance. Init is used in the
+// NewSpinButton constructor and only necessary when implementing a derivative
+// SpinButton type.
+func (s *CSpinButton) Init() (already bool) {
+	if s.InitTypeItem(TypeSpinButton, s) {
+		return true
+	}
+	s.CEntry.Init()
+	s.tReserved = 2
+	s.adjustmentHandle = fmt.Sprintf("%v-%v", SpinButtonAdjustmentHandle, s.ObjectID())
+	s.SetSingleLineMode(true)
+	_ = s.InstallBuildableProperty(PropertyAdjustment, cdk.StructProperty, true, nil)
+	_ = s.InstallBuildableProperty(PropertyDigits, cdk.IntProperty, true, 0)
+	_ = s.InstallBuildableProperty(PropertyNumeric, cdk.BoolProperty, true, false)
+	_ = s.InstallBuildableProperty(PropertySnapToTicks, cdk.BoolProperty, true, false)
+	_ = s.InstallBuildableProperty(PropertyUpdatePolicy, cdk.StructProperty, true, enums.UPDATE_ALWAYS)
+	// PropertyWrap is installed by Entry
+	s.SetAdjustment(NewAdjustment(0, 0, 0, 0, 0, 0))
+	s.Connect(SignalCdkEvent, SpinButtonEventHandle, s.event)
+	s.Connect(SignalLostFocus, SpinButtonLostFocusHandle, s.lostFocus)
+	s.Connect(SignalDraw, SpinButtonDrawHandle, s.draw)
+	return false
+}
+
+// Build provides customizations to the Buildable system for SpinButton
+// Widgets. The adjustment property is resolved by name to a previously built
+// Adjustment and the digits property rescales the Adjustment, so that the
+// values of the Adjustment are interpreted the same as with GTK. The value
+// property is applied last.
+func (s *CSpinButton) Build(builder Builder, element *CBuilderElement) error {
+	s.Freeze()
+	defer s.Thaw()
+	if name, ok := element.Attributes["id"]; ok {
+		s.SetName(name)
+	}
+	if v, ok := element.Properties[PropertyAdjustment.String()]; ok {
+		if adjustment, ok := builder.GetWidget(v).(Adjustment); ok {
+			s.SetAdjustment(adjustment)
+		} else {
+			s.LogError("adjustment not found: %v", v)
+		}
+	}
+	if v, ok := element.Properties[PropertyDigits.String()]; ok {
+		if digits, err := strconv.Atoi(v); err != nil {
+			s.LogErr(err)
+		} else {
+			s.SetDigits(digits)
+		}
+	}
+	for k, v := range element.Properties {
+		switch cdk.Property(k) {
+		case PropertyAdjustment, PropertyDigits, PropertyValue:
+		case PropertyUpdatePolicy, "update_policy":
+			if policy, err := enums.SpinButtonUpdatePolicy(0).FromString(v); err != nil {
+				s.LogErr(err)
+			} else {
+				s.SetUpdatePolicy(policy.(enums.SpinButtonUpdatePolicy))
+			}
+		default:
+			element.ApplyProperty(k, v)
+		}
+	}
+	if v, ok := element.Properties[PropertyValue.String()]; ok {
+		if value, err := strconv.ParseFloat(v, 64); err != nil {
+			s.LogErr(err)
+		} else {
+			s.SetValue(value)
+		}
+	}
+	element.ApplySignals()
+	return nil
+}
+
+// Configure changes the properties of an existing SpinButton. The adjustment
+// values are expected to already be in units of the given digits.
+//
+// Parameters:
+//
+//	adjustment	an Adjustment, or nil to keep the current one
+//	digits	the number of decimal places to display
+func (s *CSpinButton) Configure(adjustment Adjustment, digits int) {
+	if adjustment != nil {
+		s.SetAdjustment(adjustment)
+	}
+	if err := s.SetIntProperty(PropertyDigits, spinButtonClampDigits(digits)); err != nil {
+		s.LogErr(err)
+	}
+	s.output()
+}
+
+// SetAdjustment replaces the Adjustment associated with the SpinButton.
+//
+// Parameters:
+//
+//	adjustment	an Adjustment to replace the existing one
+func (s *CSpinButton) SetAdjustment(adjustment Adjustment) {
+	if adjustment == nil {
+		return
+	}
+	if previous := s.GetAdjustment(); previous != nil {
+		_ = previous.Disconnect(SignalChanged, s.adjustmentHandle)
+		_ = previous.Disconnect(SignalValueChanged, s.adjustmentHandle)
+	}
+	if err := s.SetStructProperty(PropertyAdjustment, adjustment); err != nil {
+		s.LogErr(err)
+		return
+	}
+	adjustment.Connect(SignalChanged, s.adjustmentHandle, s.adjustmentChanged)
+	adjustment.Connect(SignalValueChanged, s.adjustmentHandle, s.adjustmentValueChanged)
+	s.output()
+}
+
+// GetAdjustment returns the Adjustment associated with the SpinButton.
+func (s *CSpinButton) GetAdjustment() (adjustment Adjustment) {
+	if v, err := s.GetStructProperty(PropertyAdjustment); err != nil {
+		s.LogErr(err)
+	} else if v != nil {
+		var ok bool
+		if adjustment, ok = v.(Adjustment); !ok {
+			s.LogError("value stored in %v property is not of Adjustment type: %v (%T)", PropertyAdjustment, v, v)
+		}
+	}
+	return
+}
+
+// SetDigits sets the precision to be displayed by the SpinButton. The values
+// of the Adjustment are rescaled so that the float64 value of the SpinButton
+// is preserved, rounding when the number of digits is reduced.
+//
+// Parameters:
+//
+//	digits	the number of digits after the decimal point to be displayed
+func (s *CSpinButton) SetDigits(digits int) {
+	digits = spinButtonClampDigits(digits)
+	previous := s.GetDigits()
+	if digits == previous {
+		return
+	}
+	if adjustment := s.GetAdjustment(); adjustment != nil {
+		scale := math.Pow10(digits - previous)
+		rescale := func(v int) int {
+			return int(math.Round(float64(v) * scale))
+		}
+		value, lower, upper, step, page, size := adjustment.Settings()
+		adjustment.Configure(rescale(value), rescale(lower), rescale(upper), rescale(step), rescale(page), rescale(size))
+	}
+	if err := s.SetIntProperty(PropertyDigits, digits); err != nil {
+		s.LogErr(err)
+	}
+	s.output()
+}
+
+// GetDigits returns the current precision.
+// See: SetDigits()
+func (s *CSpinButton) GetDigits() (digits int) {
+	var err error
+	if digits, err = s.GetIntProperty(PropertyDigits); err != nil {
+		s.LogErr(err)
+	}
+	return
+}
+
+// SetIncrements sets the step and page increments for the SpinButton. This
+// affects how quickly the value changes when the arrow keys or PgUp and PgDn
+// are pressed.
+//
+// Parameters:
+//
+//	step	increment applied for a button 1 press or arrow key
+//	page	increment applied for PgUp or PgDn
+func (s *CSpinButton) SetIncrements(step float64, page float64) {
+	if adjustment := s.GetAdjustment(); adjustment != nil {
+		adjustment.Freeze()
+		adjustment.SetStepIncrement(s.toUnits(step))
+		adjustment.SetPageIncrement(s.toUnits(page))
+		adjustment.Thaw()
+		adjustment.Changed()
+	}
+}
+
+// GetIncrements returns the current step and page increments.
+// See: SetIncrements()
+func (s *CSpinButton) GetIncrements() (step float64, page float64) {
+	if adjustment := s.GetAdjustment(); adjustment != nil {
+		step = s.fromUnits(adjustment.GetStepIncrement())
+		page = s.fromUnits(adjustment.GetPageIncrement())
+	}
+	return
+}
+
+// SetRange sets the minimum and maximum allowable values for the SpinButton,
+// clamping the current value to the new range.
+//
+// Parameters:
+//
+//	min	minimum allowable value
+//	max	maximum allowable value
+func (s *CSpinButton) SetRange(min float64, max float64) {
+	if adjustment := s.GetAdjustment(); adjustment != nil {
+		adjustment.Freeze()
+		adjustment.SetLower(s.toUnits(min))
+		adjustment.SetUpper(s.toUnits(max))
+		adjustment.Thaw()
+		adjustment.Changed()
+		s.setUnits(adjustment.GetValue())
+	}
+}
+
+// GetRange returns the range allowed for the SpinButton.
+// See: SetRange()
+func (s *CSpinButton) GetRange() (min float64, max float64) {
+	if adjustment := s.GetAdjustment(); adjustment != nil {
+		min = s.fromUnits(adjustment.GetLower())
+		max = s.fromUnits(adjustment.GetUpper())
+	}
+	return
+}
+
+// GetValue returns the value of the SpinButton.
+func (s *CSpinButton) GetValue() (value float64) {
+	if adjustment := s.GetAdjustment(); adjustment != nil {
+		value = s.fromUnits(adjustment.GetValue())
+	}
+	return
+}
+
+// GetValueAsInt returns the value of the SpinButton, rounded to an integer.
+func (s *CSpinButton) GetValueAsInt() (value int) {
+	return int(math.Round(s.GetValue()))
+}
+
+// SetValue sets the value of the SpinButton. The value is clamped to the range
+// of the SpinButton and snapped to the nearest step increment when snap-to-ticks
+// is enabled.
+//
+// Parameters:
+//
+//	value	the new value
+func (s *CSpinButton) SetValue(value float64) {
+	s.setUnits(s.toUnits(value))
+}
+
+// SetUpdatePolicy sets the update behavior of the SpinButton. This determines
+// whether the SpinButton is always updated or only when a valid value is set.
+//
+// Parameters:
+//
+//	policy	a SpinButtonUpdatePolicy value
+func (s *CSpinButton) SetUpdatePolicy(policy enums.SpinButtonUpdatePolicy) {
+	if err := s.SetStructProperty(PropertyUpdatePolicy, policy); err != nil {
+		s.LogErr(err)
+	}
+}
+
+// GetUpdatePolicy returns the current update policy of the SpinButton.
+// See: SetUpdatePolicy()
+func (s *CSpinButton) GetUpdatePolicy() (policy enums.SpinButtonUpdatePolicy) {
+	var ok bool
+	if v, err := s.GetStructProperty(PropertyUpdatePolicy); err != nil {
+		s.LogErr(err)
+	} else if policy, ok = v.(enums.SpinButtonUpdatePolicy); !ok {
+		s.LogError("invalid value stored in %v: %v (%T)", PropertyUpdatePolicy, v, v)
+	}
+	return
+}
+
+// SetNumeric sets the flag that determines if non-numeric text can be typed
+// into the SpinButton. When TRUE, only digits, a single decimal point (when
+// digits is greater than zero) and a leading sign (when the lower bound is
+// negative) are accepted.
+//
+// Parameters:
+//
+//	numeric	flag indicating if only numeric entry is allowed
+func (s *CSpinButton) SetNumeric(numeric bool) {
+	if err := s.SetBoolProperty(PropertyNumeric, numeric); err != nil {
+		s.LogErr(err)
+	}
+}
+
+// GetNumeric returns whether non-numeric text can be typed into the
+// SpinButton.
+// See: SetNumeric()
+func (s *CSpinButton) GetNumeric() (numeric bool) {
+	var err error
+	if numeric, err = s.GetBoolProperty(PropertyNumeric); err != nil {
+		s.LogErr(err)
+	}
+	return
+}
+
+// Spin increments or decrements the SpinButton value in a specified direction
+// by a specified amount. When increment is zero, the step or page increment of
+// the Adjustment is used for the step and page directions. When wrap is
+// enabled, spinning beyond a bound that the value is already at wraps the
+// value to the opposite bound and emits a wrapped signal.
+//
+// Parameters:
+//
+//	direction	a SpinType indicating the direction to spin
+//	increment	step increment to apply in the specified direction
+func (s *CSpinButton) Spin(direction enums.SpinType, increment float64) {
+	adjustment := s.GetAdjustment()
+	if adjustment == nil {
+		return
+	}
+	value, lower, upper, step, page, _ := adjustment.Settings()
+	delta := s.toUnits(increment)
+	switch direction {
+	case enums.SPIN_STEP_FORWARD, enums.SPIN_STEP_BACKWARD:
+		if delta == 0 {
+			delta = step
+		}
+		if direction == enums.SPIN_STEP_BACKWARD {
+			delta = -delta
+		}
+	case enums.SPIN_PAGE_FORWARD, enums.SPIN_PAGE_BACKWARD:
+		if delta == 0 {
+			delta = page
+		}
+		if direction == enums.SPIN_PAGE_BACKWARD {
+			delta = -delta
+		}
+	case enums.SPIN_HOME:
+		s.setUnits(lower)
+		return
+	case enums.SPIN_END:
+		s.setUnits(upper)
+		return
+	case enums.SPIN_USER_DEFINED:
+	default:
+		return
+	}
+	if delta == 0 {
+		return
+	}
+	next := value + delta
+	wrapped := false
+	if delta > 0 && next > upper {
+		if s.GetWrap() && value == upper {
+			next, wrapped = lower, true
+		} else {
+			next = upper
+		}
+	} else if delta < 0 && next < lower {
+		if s.GetWrap() && value == lower {
+			next, wrapped = upper, true
+		} else {
+			next = lower
+		}
+	}
+	s.setUnits(next)
+	if wrapped {
+		s.Emit(SignalWrapped, s)
+	}
+}
+
+// SetWrap sets the flag that determines if a SpinButton value wraps around to
+// the opposite limit when the upper or lower limit of the range is exceeded.
+//
+// Parameters:
+//
+//	wrap	a flag indicating if wrapping behavior is performed
+func (s *CSpinButton) SetWrap(wrap bool) {
+	if err := s.SetBoolProperty(PropertyWrap, wrap); err != nil {
+		s.LogErr(err)
+	} else {
+		s.Invalidate()
+	}
+}
+
+// GetWrap returns whether the SpinButton value wraps around to the opposite
+// limit when the upper or lower limit of the range is exceeded.
+// See: SetWrap()
+func (s *CSpinButton) GetWrap() (wrap bool) {
+	var err error
+	if wrap, err = s.GetBoolProperty(PropertyWrap); err != nil {
+		s.LogErr(err)
+	}
+	return
+}
+
+// SetSnapToTicks sets the policy as to whether values are corrected to the
+// nearest step increment when a SpinButton is activated after providing an
+// invalid value.
+//
+// Parameters:
+//
+//	snapToTicks	a flag indicating if invalid values should be corrected
+func (s *CSpinButton) SetSnapToTicks(snapToTicks bool) {
+	if err := s.SetBoolProperty(PropertySnapToTicks, snapToTicks); err != nil {
+		s.LogErr(err)
+	} else if snapToTicks {
+		if adjustment := s.GetAdjustment(); adjustment != nil {
+			s.setUnits(adjustment.GetValue())
+		}
+	}
+}
+
+// GetSnapToTicks returns whether the values are corrected to the nearest step.
+// See: SetSnapToTicks()
+func (s *CSpinButton) GetSnapToTicks() (snapToTicks bool) {
+	var err error
+	if snapToTicks, err = s.GetBoolProperty(PropertySnapToTicks); err != nil {
+		s.LogErr(err)
+	}
+	return
+}
+
+// Update applies the text typed into the SpinButton to its value. Text that
+// is not a number is rejected and replaced with the current value. With the
+// UPDATE_IF_VALID policy, numbers outside the range of the SpinButton are
+// rejected as well, while the UPDATE_ALWAYS policy clamps them to the range.
+func (s *CSpinButton) Update() {
+	adjustment := s.GetAdjustment()
+	if adjustment == nil {
+		return
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(s.GetText()), 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		s.LogDebug("rejecting non-numeric input: %q", s.GetText())
+		s.output()
+		return
+	}
+	units := s.toUnits(value)
+	if s.GetUpdatePolicy() == enums.UPDATE_IF_VALID {
+		if units < adjustment.GetLower() || units > adjustment.GetUpper() {
+			s.LogDebug("rejecting out of range input: %q", s.GetText())
+			s.output()
+			return
+		}
+	}
+	s.setUnits(units)
+}
+
+// GetSizeRequest returns the requested size of the SpinButton, which is wide
+// enough to display the lower and upper bounds along with the arrows.
+func (s *CSpinButton) GetSizeRequest() (width, height int) {
+	size := ptypes.NewRectangle(s.CWidget.GetSizeRequest())
+	if size.W <= -1 {
+		lower, upper := s.GetRange()
+		size.W = max(runewidth.StringWidth(s.format(lower)), runewidth.StringWidth(s.format(upper)))
+		size.W += 1 + s.tReserved
+	}
+	if size.H <= -1 {
+		size.H = 1
+	}
+	size.Floor(1, 1)
+	return size.W, size.H
+}
+
+func (s *CSpinButton) toUnits(value float64) int {
+	return int(math.Round(value * math.Pow10(s.GetDigits())))
+}
+
+func (s *CSpinButton) fromUnits(units int) float64 {
+	return float64(units) / math.Pow10(s.GetDigits())
+}
+
+func (s *CSpinButton) format(value float64) string {
+	return strconv.FormatFloat(value, 'f', s.GetDigits(), 64)
+}
+
+// setUnits clamps and snaps the given value before updating the adjustment,
+// always refreshing the displayed text.
+func (s *CSpinButton) setUnits(units int) {
+	adjustment := s.GetAdjustment()
+	if adjustment == nil {
+		return
+	}
+	value, lower, upper, step, _, _ := adjustment.Settings()
+	if s.GetSnapToTicks() && step > 0 {
+		units = lower + int(math.Round(float64(units-lower)/float64(step)))*step
+	}
+	if units > upper {
+		units = upper
+	}
+	if units < lower {
+		units = lower
+	}
+	if units != value {
+		adjustment.SetValue(units)
+		return
+	}
+	s.output()
+}
+
+// output updates the text displayed to the current value, unless a handler of
+// the output signal returns EVENT_STOP.
+func (s *CSpinButton) output() {
+	value := s.GetValue()
+	if f := s.Emit(SignalOutput, s, value); f == cenums.EVENT_PASS {
+		text := s.format(value)
+		s.SetText(text)
+		s.SetPosition(len(text))
+	}
+}
+
+// isNumericInput returns TRUE if the given rune may be inserted at the current
+// position when the numeric property is set.
+func (s *CSpinButton) isNumericInput(r rune) bool {
+	switch {
+	case r >= '0' && r <= '9':
+		return true
+	case r == '.':
+		return s.GetDigits() > 0 && !strings.Contains(s.GetText(), ".")
+	case r == '-' || r == '+':
+		lower, _ := s.GetRange()
+		return lower < 0 && s.GetPosition() == 0 && !strings.ContainsAny(s.GetText(), "-+")
+	}
+	return false
+}
+
+func (s *CSpinButton) adjustmentChanged(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	s.output()
+	return cenums.EVENT_PASS
+}
+
+func (s *CSpinButton) adjustmentValueChanged(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	s.output()
+	s.Emit(SignalValueChanged, s, s.GetValue())
+	return cenums.EVENT_PASS
+}
+
+func (s *CSpinButton) event(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if !s.IsSensitive() {
+		return cenums.EVENT_PASS
+	}
+	if evt, ok := argv[1].(cdk.Event); ok {
+		switch e := evt.(type) {
+		case *cdk.EventKey:
+			if !s.HasFocus() {
+				return cenums.EVENT_PASS
+			}
+			return s.processKeyEvent(e)
+		case *cdk.EventMouse:
+			return s.processMouseEvent(e)
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (s *CSpinButton) processKeyEvent(e *cdk.EventKey) cenums.EventFlag {
+	ctrl := e.Modifiers().Has(cdk.ModCtrl)
+	switch e.Key() {
+	case cdk.KeyUp:
+		s.Update()
+		s.Spin(enums.SPIN_STEP_FORWARD, 0)
+		return cenums.EVENT_STOP
+	case cdk.KeyDown:
+		s.Update()
+		s.Spin(enums.SPIN_STEP_BACKWARD, 0)
+		return cenums.EVENT_STOP
+	case cdk.KeyPgUp:
+		s.Update()
+		if ctrl {
+			s.Spin(enums.SPIN_END, 0)
+		} else {
+			s.Spin(enums.SPIN_PAGE_FORWARD, 0)
+		}
+		return cenums.EVENT_STOP
+	case cdk.KeyPgDn:
+		s.Update()
+		if ctrl {
+			s.Spin(enums.SPIN_HOME, 0)
+		} else {
+			s.Spin(enums.SPIN_PAGE_BACKWARD, 0)
+		}
+		return cenums.EVENT_STOP
+	}
+	switch r := e.Rune(); r {
+	case 10, 13:
+		s.Update()
+		s.Activate()
+		return cenums.EVENT_STOP
+	default:
+		if e.Key() == cdk.KeyRune && r >= ' ' && s.GetNumeric() && !s.isNumericInput(r) {
+			s.LogDebug("rejecting non-numeric key: %q", r)
+			return cenums.EVENT_STOP
+		}
+	}
+	// everything else is handled by the Entry
+	return cenums.EVENT_PASS
+}
+
+func (s *CSpinButton) processMouseEvent(e *cdk.EventMouse) cenums.EventFlag {
+	pos := ptypes.NewPoint2I(e.Position())
+	if !s.HasPoint(pos) {
+		return cenums.EVENT_PASS
+	}
+	if e.IsWheelImpulse() {
+		switch e.WheelImpulse() {
+		case cdk.WheelUp:
+			s.Update()
+			s.Spin(enums.SPIN_STEP_FORWARD, 0)
+			return cenums.EVENT_STOP
+		case cdk.WheelDown:
+			s.Update()
+			s.Spin(enums.SPIN_STEP_BACKWARD, 0)
+			return cenums.EVENT_STOP
+		}
+		return cenums.EVENT_PASS
+	}
+	if e.State() != cdk.BUTTON_PRESS || !e.Button().Has(cdk.Button1) {
+		return cenums.EVENT_PASS
+	}
+	alloc := s.GetAllocation()
+	x := pos.X - s.GetOrigin().X
+	switch x {
+	case alloc.W - 2:
+		s.Update()
+		s.Spin(enums.SPIN_STEP_BACKWARD, 0)
+	case alloc.W - 1:
+		s.Update()
+		s.Spin(enums.SPIN_STEP_FORWARD, 0)
+	default:
+		return cenums.EVENT_PASS
+	}
+	if !s.HasFocus() && s.CanFocus() {
+		s.GrabFocus()
+	}
+	return cenums.EVENT_STOP
+}
+
+func (s *CSpinButton) lostFocus(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	s.Update()
+	return cenums.EVENT_PASS
+}
+
+func (s *CSpinButton) draw(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if f := s.CEntry.draw(data, argv...); f != cenums.EVENT_STOP {
+		return f
+	}
+	if surface, ok := argv[1].(*memphis.CSurface); ok {
+		alloc := s.GetAllocation()
+		if alloc.W < s.tReserved {
+			return cenums.EVENT_STOP
+		}
+		theme := s.GetThemeRequest()
+		_, yAlign := s.GetAlignment()
+		y := int(float64(alloc.H-1) * yAlign)
+		down, up := theme.Content.Normal, theme.Content.Normal
+		if adjustment := s.GetAdjustment(); adjustment != nil && !s.GetWrap() {
+			value, lower, upper := adjustment.GetValue(), adjustment.GetLower(), adjustment.GetUpper()
+			if value <= lower {
+				down = theme.Content.Insensitive.Dim(true)
+			}
+			if value >= upper {
+				up = theme.Content.Insensitive.Dim(true)
+			}
+		}
+		if err := surface.SetRune(alloc.W-2, y, theme.Content.ArrowRunes.Down, down); err != nil {
+			s.LogErr(err)
+		}
+		if err := surface.SetRune(alloc.W-1, y, theme.Content.ArrowRunes.Up, up); err != nil {
+			s.LogErr(err)
+		}
+	}
+	return cenums.EVENT_STOP
+}
+
+func spinButtonClampDigits(digits int) int {
+	if digits < 0 {
+		return 0
+	} else if digits > SpinButtonMaxDigits {
+		return SpinButtonMaxDigits
+	}
+	return digits
+}
+
+// SpinButtonMaxDigits is the maximum number of decimal places a SpinButton can
+// display.
+const SpinButtonMaxDigits = 9
+
+// The number of decimal places to display.
+// Flags: Read / Write
+// Allowed values: <= 9
+// Default value: 0
+const PropertyDigits cdk.Property = "digits"
+
+// Whether non-numeric characters should be ignored.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyNumeric cdk.Property = "numeric"
+
+// Whether erroneous values are automatically changed to a spin button's
+// nearest step increment.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertySnapToTicks cdk.Property = "snap-to-ticks"
+
+// The output signal can be used to change the formatting of the value that is
+// displayed in a SpinButton. Listeners that set the text themselves with
+// SetText must return EVENT_STOP to prevent the default formatting.
+// Listener function arguments:
+//
+//	value float64	the current value
+const SignalOutput cdk.Signal = "output"
+
+// The wrapped signal is emitted right after the SpinButton wraps from its
+// maximum to minimum value or vice-versa.
+const SignalWrapped cdk.Signal = "wrapped"
+
+const SpinButtonAdjustmentHandle = "spin-button-adjustment-handler"
+
+const SpinButtonEventHandle = "spin-button-event-handler"
+
+const SpinButtonLostFocusHandle = "spin-button-lost-focus-handler"
+
+const SpinButtonDrawHandle = "spin-button-draw-handler"
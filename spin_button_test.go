@@ -0,0 +1,203 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"testing"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/ptypes"
+	"github.com/go-curses/cdk/memphis"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+func TestSpinButton(t *testing.T) {
+	Convey("Testing Spin Buttons", t, func() {
+		Convey("adjustment and digits", func() {
+			s := NewSpinButtonWithRange(-1, 1, 0.25)
+			So(s, ShouldNotBeNil)
+			So(s.GetDigits(), ShouldEqual, 2)
+			So(s.GetNumeric(), ShouldEqual, true)
+			So(s.GetValue(), ShouldEqual, -1.0)
+			So(s.GetText(), ShouldEqual, "-1.00")
+			So(s.GetAdjustment().GetUpper(), ShouldEqual, 100)
+			step, page := s.GetIncrements()
+			So(step, ShouldEqual, 0.25)
+			So(page, ShouldEqual, 2.5)
+			s.SetValue(0.5)
+			So(s.GetText(), ShouldEqual, "0.50")
+			So(s.GetAdjustment().GetValue(), ShouldEqual, 50)
+			s.SetDigits(1)
+			So(s.GetText(), ShouldEqual, "0.5")
+			So(s.GetAdjustment().GetValue(), ShouldEqual, 5)
+			So(s.GetValue(), ShouldEqual, 0.5)
+			lower, upper := s.GetRange()
+			So(lower, ShouldEqual, -1.0)
+			So(upper, ShouldEqual, 1.0)
+			s.SetRange(0, 0.3)
+			So(s.GetValue(), ShouldEqual, 0.3)
+			So(s.GetValueAsInt(), ShouldEqual, 0)
+			w, h := s.GetSizeRequest()
+			So(w, ShouldEqual, 6)
+			So(h, ShouldEqual, 1)
+		})
+		Convey("spinning", func() {
+			s := NewSpinButton(NewAdjustment(5, 0, 10, 2, 5, 0), 0)
+			changed := 0
+			s.Connect(SignalValueChanged, "test-value-changed", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				changed += 1
+				return cenums.EVENT_PASS
+			})
+			wrapped := 0
+			s.Connect(SignalWrapped, "test-wrapped", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				wrapped += 1
+				return cenums.EVENT_PASS
+			})
+			s.Spin(enums.SPIN_STEP_FORWARD, 0)
+			So(s.GetValue(), ShouldEqual, 7.0)
+			So(changed, ShouldEqual, 1)
+			s.Spin(enums.SPIN_PAGE_FORWARD, 0)
+			So(s.GetValue(), ShouldEqual, 10.0)
+			s.Spin(enums.SPIN_STEP_FORWARD, 0)
+			So(s.GetValue(), ShouldEqual, 10.0)
+			So(changed, ShouldEqual, 2)
+			s.Spin(enums.SPIN_USER_DEFINED, -3)
+			So(s.GetValue(), ShouldEqual, 7.0)
+			s.Spin(enums.SPIN_HOME, 0)
+			So(s.GetValue(), ShouldEqual, 0.0)
+			s.SetWrap(true)
+			s.Spin(enums.SPIN_STEP_BACKWARD, 0)
+			So(s.GetValue(), ShouldEqual, 10.0)
+			So(wrapped, ShouldEqual, 1)
+			s.Spin(enums.SPIN_STEP_FORWARD, 1)
+			So(s.GetValue(), ShouldEqual, 0.0)
+			So(wrapped, ShouldEqual, 2)
+			s.Spin(enums.SPIN_END, 0)
+			s.Spin(enums.SPIN_PAGE_BACKWARD, 0)
+			So(s.GetValue(), ShouldEqual, 5.0)
+			s.SetSnapToTicks(true)
+			s.SetValue(5)
+			So(s.GetValue(), ShouldEqual, 6.0)
+			s.SetValue(2.4)
+			So(s.GetValue(), ShouldEqual, 2.0)
+		})
+		Convey("update policy", func() {
+			s := NewSpinButton(NewAdjustment(5, 0, 10, 1, 5, 0), 0)
+			So(s.GetUpdatePolicy(), ShouldEqual, enums.UPDATE_ALWAYS)
+			s.SetText("eight")
+			s.Update()
+			So(s.GetValue(), ShouldEqual, 5.0)
+			So(s.GetText(), ShouldEqual, "5")
+			s.SetText(" 8 ")
+			s.Update()
+			So(s.GetValue(), ShouldEqual, 8.0)
+			s.SetText("25")
+			s.Update()
+			So(s.GetValue(), ShouldEqual, 10.0)
+			s.SetUpdatePolicy(enums.UPDATE_IF_VALID)
+			s.SetText("-3")
+			s.Update()
+			So(s.GetValue(), ShouldEqual, 10.0)
+			So(s.GetText(), ShouldEqual, "10")
+			s.SetText("3")
+			s.Update()
+			So(s.GetValue(), ShouldEqual, 3.0)
+		})
+		Convey("output", func() {
+			s := NewSpinButton(NewAdjustment(5, 0, 10, 1, 5, 0), 0)
+			s.Connect(SignalOutput, "test-output", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				if sb, ok := argv[0].(SpinButton); ok {
+					sb.SetText("value")
+					return cenums.EVENT_STOP
+				}
+				return cenums.EVENT_PASS
+			})
+			s.SetValue(6)
+			So(s.GetText(), ShouldEqual, "value")
+		})
+		Convey("events", func() {
+			s := NewSpinButton(NewAdjustment(5, -10, 10, 1, 5, 0), 1)
+			s.SetNumeric(true)
+			So(s.SetBoolProperty(PropertyHasFocus, true), ShouldBeNil)
+			s.Show()
+			s.SetOrigin(0, 0)
+			s.SetAllocation(ptypes.MakeRectangle(8, 1))
+			s.Resize()
+			So(s.GetText(), ShouldEqual, "0.5")
+			s.ProcessEvent(cdk.NewEventKey(cdk.KeyUp, 0, cdk.ModNone))
+			So(s.GetValue(), ShouldEqual, 0.6)
+			s.ProcessEvent(cdk.NewEventKey(cdk.KeyPgDn, 0, cdk.ModNone))
+			So(s.GetValue(), ShouldEqual, 0.1)
+			s.ProcessEvent(cdk.NewEventKey(cdk.KeyPgUp, 0, cdk.ModCtrl))
+			So(s.GetValue(), ShouldEqual, 1.0)
+			s.SetText("")
+			s.SetPosition(0)
+			for _, r := range "-x.5.2" {
+				s.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, r, cdk.ModNone))
+			}
+			So(s.GetText(), ShouldEqual, "-.52")
+			s.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, rune(cdk.KeyEnter), cdk.ModNone))
+			So(s.GetValue(), ShouldEqual, -0.5)
+			So(s.GetText(), ShouldEqual, "-0.5")
+			s.ProcessEvent(cdk.NewEventMouse(0, 0, cdk.WheelUp, cdk.ModNone))
+			So(s.GetValue(), ShouldEqual, -0.4)
+			s.ProcessEvent(cdk.NewEventMouse(6, 0, cdk.Button1, cdk.ModNone))
+			So(s.GetValue(), ShouldEqual, -0.5)
+			s.ProcessEvent(cdk.NewEventMouse(6, 0, cdk.ButtonNone, cdk.ModNone))
+			s.ProcessEvent(cdk.NewEventMouse(7, 0, cdk.Button1, cdk.ModNone))
+			So(s.GetValue(), ShouldEqual, -0.4)
+			s.ProcessEvent(cdk.NewEventMouse(7, 0, cdk.ButtonNone, cdk.ModNone))
+			So(s.Draw(), ShouldEqual, cenums.EVENT_STOP)
+			surface, err := memphis.GetSurface(s.ObjectID())
+			So(err, ShouldBeNil)
+			theme := s.GetThemeRequest()
+			So(surface.GetContent(6, 0).Value(), ShouldEqual, theme.Content.ArrowRunes.Down)
+			So(surface.GetContent(7, 0).Value(), ShouldEqual, theme.Content.ArrowRunes.Up)
+			So(surface.GetContent(0, 0).Value(), ShouldEqual, '-')
+		})
+		Convey("builder", func() {
+			builder := NewBuilder()
+			_, err := builder.LoadFromString(`<interface>
+  <object class="GtkAdjustment" id="test-spin-adjustment">
+    <property name="lower">0</property>
+    <property name="upper">100</property>
+    <property name="step_increment">1</property>
+    <property name="page_increment">10</property>
+  </object>
+  <object class="GtkSpinButton" id="test-spin-button">
+    <property name="value">42.5</property>
+    <property name="adjustment">test-spin-adjustment</property>
+    <property name="digits">1</property>
+    <property name="update_policy">GTK_UPDATE_IF_VALID</property>
+    <property name="wrap">True</property>
+  </object>
+</interface>`)
+			So(err, ShouldBeNil)
+			s, ok := builder.GetWidget("test-spin-button").(SpinButton)
+			So(ok, ShouldEqual, true)
+			So(s.GetDigits(), ShouldEqual, 1)
+			So(s.GetValue(), ShouldEqual, 42.5)
+			So(s.GetText(), ShouldEqual, "42.5")
+			lower, upper := s.GetRange()
+			So(lower, ShouldEqual, 0.0)
+			So(upper, ShouldEqual, 100.0)
+			So(s.GetUpdatePolicy(), ShouldEqual, enums.UPDATE_IF_VALID)
+			So(s.GetWrap(), ShouldEqual, true)
+		})
+	})
+}
@@ -252,4 +252,91 @@ func LookupStockLabel(label string) (item *StockItem) {
 		}
 	}
 	return
-}
\ No newline at end of file
+}
+
+// Stock icons are single runes drawn in place of images, for example by
+// ToolButtons when the Toolbar style includes icons. As with stock items,
+// applications can register their own icons with AddStockIcon.
+var ctkStockIconRegistry = map[StockID]rune{
+	StockDialogInfo:     'i',
+	StockDialogWarning:  '!',
+	StockDialogError:    '✗',
+	StockDialogQuestion: '?',
+	StockAbout:          'i',
+	StockAdd:            '+',
+	StockApply:          '✓',
+	StockBold:           'B',
+	StockCancel:         '✗',
+	StockClear:          '⌫',
+	StockClose:          '×',
+	StockCopy:           '⎘',
+	StockCut:            '✂',
+	StockDelete:         '⌦',
+	StockDirectory:      '▸',
+	StockEdit:           '✎',
+	StockExecute:        '▶',
+	StockFile:           '≡',
+	StockFind:           '⌕',
+	StockGotoBottom:     '⤓',
+	StockGotoFirst:      '⇤',
+	StockGotoLast:       '⇥',
+	StockGotoTop:        '⤒',
+	StockGoBack:         '←',
+	StockGoDown:         '↓',
+	StockGoForward:      '→',
+	StockGoUp:           '↑',
+	StockHelp:           '?',
+	StockHome:           '⌂',
+	StockIndent:         '⇥',
+	StockInfo:           'i',
+	StockItalic:         'I',
+	StockJumpTo:         '↷',
+	StockMediaForward:   '»',
+	StockMediaNext:      '⇥',
+	StockMediaPause:     '‖',
+	StockMediaPlay:      '▶',
+	StockMediaPrevious:  '⇤',
+	StockMediaRecord:    '●',
+	StockMediaRewind:    '«',
+	StockMediaStop:      '■',
+	StockNew:            '□',
+	StockNo:             '✗',
+	StockOk:             '✓',
+	StockOpen:           '⇪',
+	StockPaste:          '⎗',
+	StockPreferences:    '≣',
+	StockProperties:     '≣',
+	StockQuit:           '⎋',
+	StockRedo:           '↷',
+	StockRefresh:        '↻',
+	StockRemove:         '−',
+	StockRevertToSaved:  '↺',
+	StockSave:           '⎙',
+	StockSaveAs:         '⎙',
+	StockSelectAll:      '∗',
+	StockSortAscending:  '▲',
+	StockSortDescending: '▼',
+	StockStop:           '■',
+	StockStrikethrough:  'S',
+	StockUndelete:       '↺',
+	StockUnderline:      'U',
+	StockUndo:           '↶',
+	StockUnindent:       '⇤',
+	StockYes:            '✓',
+	StockZoom100:        '=',
+	StockZoomFit:        '□',
+	StockZoomIn:         '+',
+	StockZoomOut:        '−',
+}
+
+// AddStockIcon registers the rune to use as the icon of the given stock ID,
+// replacing any icon already registered.
+func AddStockIcon(id StockID, icon rune) {
+	ctkStockIconRegistry[id] = icon
+}
+
+// LookupStockIcon returns the icon rune registered for the given stock ID.
+func LookupStockIcon(id StockID) (icon rune, ok bool) {
+	icon, ok = ctkStockIconRegistry[id]
+	return
+}
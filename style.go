@@ -17,7 +17,10 @@ package ctk
 import (
 	"github.com/go-curses/cdk"
 	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/paint"
 	"github.com/go-curses/cdk/lib/ptypes"
+	"github.com/go-curses/cdk/memphis"
+
 	"github.com/go-curses/ctk/lib/enums"
 )
 
@@ -30,6 +33,15 @@ func init() {
 // Style Hierarchy:
 //	Object
 //	  +- Style
+//
+// The Style type provides the drawing primitives shared by all widgets. Each
+// of the Paint methods draws onto the memphis surface of the widget given, or
+// the window when widget is nil, using the theme of that widget. The stateType
+// selects the theme styles used (ie: theme.Content.Prelight for a prelight
+// state) and the shadowType selects the border drawn, where SHADOW_NONE draws
+// no border and the etched shadow types draw a dimmed border. The area given
+// clips all drawing to the rectangle of that size, starting at the origin of
+// the surface, and an empty area does not clip at all.
 type Style interface {
 	Object
 
@@ -105,6 +117,29 @@ func (s *CStyle) Init() (already bool) {
 // 	width	width of the rectangle to draw the arrow in
 // 	height	height of the rectangle to draw the arrow in
 func (s *CStyle) PaintArrow(window Window, stateType enums.StateType, shadowType enums.ShadowType, area ptypes.Rectangle, widget Widget, detail string, arrowType enums.ArrowType, fill bool, x int, y int, width int, height int) {
+	if p, ok := s.newPainter(window, widget, area); ok {
+		var r rune
+		ars := p.theme.Content.ArrowRunes
+		if !fill {
+			ars, _ = paint.GetArrows(paint.StockArrow)
+		} else if ars.Up == 0 || ars.Left == 0 || ars.Down == 0 || ars.Right == 0 {
+			ars, _ = paint.GetArrows(paint.WideArrow)
+		}
+		switch arrowType {
+		case enums.ArrowUp:
+			r = ars.Up
+		case enums.ArrowDown:
+			r = ars.Down
+		case enums.ArrowLeft:
+			r = ars.Left
+		case enums.ArrowRight:
+			r = ars.Right
+		default:
+			return
+		}
+		cx, cy := paintCentre(x, y, width, height)
+		p.setRune(cx, cy, r, p.contentStyle(stateType))
+	}
 }
 
 // Draws a box on window with the given parameters.
@@ -121,6 +156,9 @@ func (s *CStyle) PaintArrow(window Window, stateType enums.StateType, shadowType
 // 	width	the width of the box
 // 	height	the height of the box
 func (s *CStyle) PaintBox(window Window, stateType enums.StateType, shadowType enums.ShadowType, area ptypes.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+	if p, ok := s.newPainter(window, widget, area); ok {
+		p.box(stateType, shadowType, true, x, y, width, height)
+	}
 }
 
 // Draws a box in window using the given style and state and shadow type,
@@ -141,6 +179,10 @@ func (s *CStyle) PaintBox(window Window, stateType enums.StateType, shadowType e
 // 	gapX	starting position of the gap
 // 	gapWidth	width of the gap
 func (s *CStyle) PaintBoxGap(window Window, stateType enums.StateType, shadowType enums.ShadowType, area ptypes.Rectangle, widget Widget, detail string, x int, y int, width int, height int, gapSide enums.PositionType, gapX int, gapWidth int) {
+	if p, ok := s.newPainter(window, widget, area); ok {
+		p.box(stateType, shadowType, true, x, y, width, height)
+		p.gap(stateType, x, y, width, height, gapSide, gapX, gapWidth)
+	}
 }
 
 // Draws a check button indicator in the given rectangle on window with the
@@ -158,6 +200,16 @@ func (s *CStyle) PaintBoxGap(window Window, stateType enums.StateType, shadowTyp
 // 	width	the width of the rectangle to draw the check in
 // 	height	the height of the rectangle to draw the check in
 func (s *CStyle) PaintCheck(window Window, stateType enums.StateType, shadowType enums.ShadowType, area ptypes.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+	if p, ok := s.newPainter(window, widget, area); ok {
+		mark := ' '
+		switch shadowType {
+		case enums.SHADOW_IN:
+			mark = 'x'
+		case enums.SHADOW_ETCHED_IN:
+			mark = '-'
+		}
+		p.indicator(stateType, '[', mark, ']', x, y, width, height)
+	}
 }
 
 // Draws a diamond in the given rectangle on window using the given
@@ -175,6 +227,14 @@ func (s *CStyle) PaintCheck(window Window, stateType enums.StateType, shadowType
 // 	width	width of the rectangle to draw the diamond in
 // 	height	height of the rectangle to draw the diamond in
 func (s *CStyle) PaintDiamond(window Window, stateType enums.StateType, shadowType enums.ShadowType, area ptypes.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+	if p, ok := s.newPainter(window, widget, area); ok {
+		r := paint.RuneHollowDiamond
+		if shadowType == enums.SHADOW_IN || shadowType == enums.SHADOW_ETCHED_IN {
+			r = paint.RuneFilledDiamond
+		}
+		cx, cy := paintCentre(x, y, width, height)
+		p.setRune(cx, cy, r, p.contentStyle(stateType))
+	}
 }
 
 // Draws an extension, i.e. a notebook tab.
@@ -192,6 +252,34 @@ func (s *CStyle) PaintDiamond(window Window, stateType enums.StateType, shadowTy
 // 	height	width of the extension
 // 	gapSide	the side on to which the extension is attached
 func (s *CStyle) PaintExtension(window Window, stateType enums.StateType, shadowType enums.ShadowType, area ptypes.Rectangle, widget Widget, detail string, x int, y int, width int, height int, gapSide enums.PositionType) {
+	if p, ok := s.newPainter(window, widget, area); ok {
+		p.box(stateType, shadowType, true, x, y, width, height)
+		bs, border := p.borderStyle(stateType, shadowType)
+		if !border || width < 2 || height < 2 {
+			return
+		}
+		cs := p.contentStyle(stateType)
+		runes := p.theme.Border.BorderRunes
+		right, bottom := x+width-1, y+height-1
+		switch gapSide {
+		case enums.POS_TOP:
+			p.setRune(x, y, runes.Left, bs)
+			p.setRune(right, y, runes.Right, bs)
+			p.fill(cs, x+1, y, width-2, 1)
+		case enums.POS_BOTTOM:
+			p.setRune(x, bottom, runes.Left, bs)
+			p.setRune(right, bottom, runes.Right, bs)
+			p.fill(cs, x+1, bottom, width-2, 1)
+		case enums.POS_LEFT:
+			p.setRune(x, y, runes.Top, bs)
+			p.setRune(x, bottom, runes.Bottom, bs)
+			p.fill(cs, x, y+1, 1, height-2)
+		case enums.POS_RIGHT:
+			p.setRune(right, y, runes.Top, bs)
+			p.setRune(right, bottom, runes.Bottom, bs)
+			p.fill(cs, right, y+1, 1, height-2)
+		}
+	}
 }
 
 // Draws a flat box on window with the given parameters.
@@ -208,6 +296,9 @@ func (s *CStyle) PaintExtension(window Window, stateType enums.StateType, shadow
 // 	width	the width of the box
 // 	height	the height of the box
 func (s *CStyle) PaintFlatBox(window Window, stateType enums.StateType, shadowType enums.ShadowType, area ptypes.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+	if p, ok := s.newPainter(window, widget, area); ok {
+		p.fill(p.contentStyle(stateType), x, y, width, height)
+	}
 }
 
 // Draws a focus indicator around the given rectangle on window using the
@@ -224,6 +315,9 @@ func (s *CStyle) PaintFlatBox(window Window, stateType enums.StateType, shadowTy
 // 	width	the width of the rectangle around which to draw a focus indicator
 // 	height	the height of the rectangle around which to draw a focus indicator
 func (s *CStyle) PaintFocus(window Window, stateType enums.StateType, area ptypes.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+	if p, ok := s.newPainter(window, widget, area); ok {
+		p.box(stateType, enums.SHADOW_IN, false, x, y, width, height)
+	}
 }
 
 // Draws a handle as used in HandleBox and Paned.
@@ -241,6 +335,16 @@ func (s *CStyle) PaintFocus(window Window, stateType enums.StateType, area ptype
 // 	height	height of the handle
 // 	orientation	the orientation of the handle
 func (s *CStyle) PaintHandle(window Window, stateType enums.StateType, shadowType enums.ShadowType, area ptypes.Rectangle, widget Widget, detail string, x int, y int, width int, height int, orientation cenums.Orientation) {
+	if p, ok := s.newPainter(window, widget, area); ok {
+		bs, _ := p.borderStyle(stateType, shadowType)
+		cx, cy := paintCentre(x, y, width, height)
+		switch orientation {
+		case cenums.ORIENTATION_HORIZONTAL:
+			p.hLine(bs, x, cy, width)
+		case cenums.ORIENTATION_VERTICAL:
+			p.vLine(bs, cx, y, height)
+		}
+	}
 }
 
 // Draws a radio button indicator in the given rectangle on window with the
@@ -258,6 +362,16 @@ func (s *CStyle) PaintHandle(window Window, stateType enums.StateType, shadowTyp
 // 	width	the width of the rectangle to draw the option in
 // 	height	the height of the rectangle to draw the option in
 func (s *CStyle) PaintOption(window Window, stateType enums.StateType, shadowType enums.ShadowType, area ptypes.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+	if p, ok := s.newPainter(window, widget, area); ok {
+		mark := ' '
+		switch shadowType {
+		case enums.SHADOW_IN:
+			mark = '*'
+		case enums.SHADOW_ETCHED_IN:
+			mark = '-'
+		}
+		p.indicator(stateType, '(', mark, ')', x, y, width, height)
+	}
 }
 
 // Draws a polygon on window with the given parameters.
@@ -274,6 +388,23 @@ func (s *CStyle) PaintOption(window Window, stateType enums.StateType, shadowTyp
 //
 // 	fill	TRUE if the polygon should be filled
 func (s *CStyle) PaintPolygon(window Window, stateType enums.StateType, shadowType enums.ShadowType, area ptypes.Rectangle, widget Widget, detail string, points []ptypes.Point2I, nPoints int, fill bool) {
+	if p, ok := s.newPainter(window, widget, area); ok {
+		if nPoints > len(points) {
+			nPoints = len(points)
+		}
+		if nPoints <= 0 {
+			return
+		}
+		points = points[:nPoints]
+		if fill {
+			p.fillPolygon(p.contentStyle(stateType), points)
+		}
+		if bs, border := p.borderStyle(stateType, shadowType); border {
+			for idx := range points {
+				p.line(bs, points[idx], points[(idx+1)%len(points)])
+			}
+		}
+	}
 }
 
 // Draws a shadow around the given rectangle in window using the given style
@@ -291,6 +422,9 @@ func (s *CStyle) PaintPolygon(window Window, stateType enums.StateType, shadowTy
 // 	width	width of the rectangle
 // 	height	width of the rectangle
 func (s *CStyle) PaintShadow(window Window, stateType enums.StateType, shadowType enums.ShadowType, area ptypes.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+	if p, ok := s.newPainter(window, widget, area); ok {
+		p.box(stateType, shadowType, false, x, y, width, height)
+	}
 }
 
 // Draws a shadow around the given rectangle in window using the given style
@@ -311,6 +445,10 @@ func (s *CStyle) PaintShadow(window Window, stateType enums.StateType, shadowTyp
 // 	gapX	starting position of the gap
 // 	gapWidth	width of the gap
 func (s *CStyle) PaintShadowGap(window Window, stateType enums.StateType, shadowType enums.ShadowType, area ptypes.Rectangle, widget Widget, detail string, x int, y int, width int, height int, gapSide enums.PositionType, gapX int, gapWidth int) {
+	if p, ok := s.newPainter(window, widget, area); ok {
+		p.box(stateType, shadowType, false, x, y, width, height)
+		p.gap(stateType, x, y, width, height, gapSide, gapX, gapWidth)
+	}
 }
 
 // Draws a slider in the given rectangle on window using the given style and
@@ -329,6 +467,14 @@ func (s *CStyle) PaintShadowGap(window Window, stateType enums.StateType, shadow
 // 	height	the height of the rectangle in which to draw a slider
 // 	orientation	the orientation to be used
 func (s *CStyle) PaintSlider(window Window, stateType enums.StateType, shadowType enums.ShadowType, area ptypes.Rectangle, widget Widget, detail string, x int, y int, width int, height int, orientation cenums.Orientation) {
+	if p, ok := s.newPainter(window, widget, area); ok {
+		// the slider fills the area given, regardless of orientation
+		for iy := y; iy < y+height; iy++ {
+			for ix := x; ix < x+width; ix++ {
+				p.setRune(ix, iy, paint.RuneBlock, p.contentStyle(stateType))
+			}
+		}
+	}
 }
 
 // Draws a spinner on window using the given parameters.
@@ -345,6 +491,17 @@ func (s *CStyle) PaintSlider(window Window, stateType enums.StateType, shadowTyp
 // 	width	the width of the rectangle in which to draw the spinner
 // 	height	the height of the rectangle in which to draw the spinner
 func (s *CStyle) PaintSpinner(window Window, stateType enums.StateType, area ptypes.Rectangle, widget Widget, detail string, step int, x int, y int, width int, height int) {
+	if p, ok := s.newPainter(window, widget, area); ok {
+		runes, _ := paint.GetSpinners(paint.SevenDotSpinner)
+		if len(runes) == 0 {
+			return
+		}
+		if step = step % len(runes); step < 0 {
+			step += len(runes)
+		}
+		cx, cy := paintCentre(x, y, width, height)
+		p.setRune(cx, cy, runes[step], p.contentStyle(stateType))
+	}
 }
 
 // Draws an option menu tab (i.e. the up and down pointing arrows) in the
@@ -362,6 +519,10 @@ func (s *CStyle) PaintSpinner(window Window, stateType enums.StateType, area pty
 // 	width	the width of the rectangle to draw the tab in
 // 	height	the height of the rectangle to draw the tab in
 func (s *CStyle) PaintTab(window Window, stateType enums.StateType, shadowType enums.ShadowType, area ptypes.Rectangle, widget Widget, detail string, x int, y int, width int, height int) {
+	if p, ok := s.newPainter(window, widget, area); ok {
+		cx, cy := paintCentre(x, y, width, height)
+		p.setRune(cx, cy, paint.RuneFilledDownPointingSmallTriangle, p.contentStyle(stateType))
+	}
 }
 
 // Draws a vertical line from (x , y1_ ) to (x , y2_ ) in window using the
@@ -377,6 +538,13 @@ func (s *CStyle) PaintTab(window Window, stateType enums.StateType, shadowType e
 // 	y2	the ending y coordinate
 // 	x	the x coordinate
 func (s *CStyle) PaintVLine(window Window, stateType enums.StateType, area ptypes.Rectangle, widget Widget, detail string, y1 int, y2 int, x int) {
+	if p, ok := s.newPainter(window, widget, area); ok {
+		if y2 < y1 {
+			y1, y2 = y2, y1
+		}
+		bs, _ := p.borderStyle(stateType, enums.SHADOW_IN)
+		p.vLine(bs, x, y1, y2-y1+1)
+	}
 }
 
 // Draws an expander as used in TreeView. x and y specify the center the
@@ -400,6 +568,14 @@ func (s *CStyle) PaintVLine(window Window, stateType enums.StateType, area ptype
 // whether the expander is collapsed, expanded, or in an
 // intermediate state.
 func (s *CStyle) PaintExpander(window Window, stateType enums.StateType, area ptypes.Rectangle, widget Widget, detail string, x int, y int, expanderStyle enums.ExpanderStyle) {
+	if p, ok := s.newPainter(window, widget, area); ok {
+		r := paint.RuneFilledRightPointingSmallTriangle
+		switch expanderStyle {
+		case enums.EXPANDER_SEMI_EXPANDED, enums.EXPANDER_EXPANDED:
+			r = paint.RuneFilledDownPointingSmallTriangle
+		}
+		p.setRune(x, y, r, p.contentStyle(stateType))
+	}
 }
 
 // Draws a layout on window using the given parameters.
@@ -434,4 +610,264 @@ func (s *CStyle) PaintExpander(window Window, stateType enums.StateType, area pt
 // 	width	the width of the rectangle in which to draw the resize grip
 // 	height	the height of the rectangle in which to draw the resize grip
 func (s *CStyle) PaintResizeGrip(window Window, stateType enums.StateType, area ptypes.Rectangle, widget Widget, detail string, edge enums.WindowEdge, x int, y int, width int, height int) {
-}
\ No newline at end of file
+	if p, ok := s.newPainter(window, widget, area); ok {
+		if width <= 0 || height <= 0 {
+			return
+		}
+		bs, _ := p.borderStyle(stateType, enums.SHADOW_IN)
+		right, bottom := x+width-1, y+height-1
+		switch edge {
+		case enums.WindowEdgeNorthWest:
+			p.setRune(x, y, paint.RuneFilledUpperLeftTriangle, bs)
+		case enums.WindowEdgeNorthEast:
+			p.setRune(right, y, paint.RuneFilledUpperRightTriangle, bs)
+		case enums.WindowEdgeSouthWest:
+			p.setRune(x, bottom, paint.RuneFilledLowerLeftTriangle, bs)
+		case enums.WindowEdgeSouthEast:
+			p.setRune(right, bottom, paint.RuneFilledLowerRightTriangle, bs)
+		case enums.WindowEdgeNorth:
+			p.hLine(bs, x, y, width)
+		case enums.WindowEdgeSouth:
+			p.hLine(bs, x, bottom, width)
+		case enums.WindowEdgeWest:
+			p.vLine(bs, x, y, height)
+		case enums.WindowEdgeEast:
+			p.vLine(bs, right, y, height)
+		}
+	}
+}
+// newPainter returns a cStylePainter for the surface of the widget given, or
+// the window when widget is nil. The area given clips all drawing and when
+// empty, the entire surface is drawn upon.
+func (s *CStyle) newPainter(window Window, widget Widget, area ptypes.Rectangle) (p *cStylePainter, ok bool) {
+	var target Widget
+	if widget != nil {
+		target = widget
+	} else if window != nil {
+		target = window
+	} else {
+		s.LogError("paint requires a window or widget")
+		return nil, false
+	}
+	surface, err := memphis.GetSurface(target.ObjectID())
+	if err != nil {
+		s.LogErr(err)
+		return nil, false
+	}
+	p = &cStylePainter{
+		surface: surface,
+		theme:   target.GetTheme(),
+		clip:    surface.GetSize(),
+	}
+	if area.W > 0 && area.H > 0 {
+		if area.W < p.clip.W {
+			p.clip.W = area.W
+		}
+		if area.H < p.clip.H {
+			p.clip.H = area.H
+		}
+	}
+	return p, true
+}
+
+// cStylePainter draws onto a memphis surface, clipped to a given size, with
+// styles selected from a theme by widget state and shadow type.
+type cStylePainter struct {
+	surface *memphis.CSurface
+	theme   paint.Theme
+	clip    ptypes.Rectangle
+}
+
+// paintStateStyle returns the style of the theme aspect for the given state,
+// using the same precedence as Widget.GetThemeRequest.
+func paintStateStyle(aspect paint.ThemeAspect, state enums.StateType) paint.Style {
+	switch {
+	case state.Has(enums.StateInsensitive):
+		return aspect.Insensitive
+	case state.Has(enums.StateActive):
+		return aspect.Active
+	case state.Has(enums.StateSelected):
+		return aspect.Selected
+	case state.Has(enums.StatePrelight):
+		return aspect.Prelight
+	}
+	return aspect.Normal
+}
+
+// paintCentre returns the centre cell of the given rectangle
+func paintCentre(x, y, width, height int) (cx, cy int) {
+	cx, cy = x, y
+	if width > 1 {
+		cx += (width - 1) / 2
+	}
+	if height > 1 {
+		cy += (height - 1) / 2
+	}
+	return
+}
+
+func (p *cStylePainter) contentStyle(state enums.StateType) paint.Style {
+	return paintStateStyle(p.theme.Content, state)
+}
+
+// borderStyle returns the border style for the state and shadow type given,
+// along with whether a border is to be drawn at all. Etched shadows are drawn
+// dimmed and SHADOW_NONE draws no border.
+func (p *cStylePainter) borderStyle(state enums.StateType, shadowType enums.ShadowType) (style paint.Style, border bool) {
+	style = paintStateStyle(p.theme.Border, state)
+	switch shadowType {
+	case enums.SHADOW_NONE:
+		return style, false
+	case enums.SHADOW_ETCHED_IN, enums.SHADOW_ETCHED_OUT:
+		style = style.Dim(true)
+	}
+	return style, true
+}
+
+func (p *cStylePainter) setRune(x, y int, r rune, style paint.Style) {
+	if x >= 0 && y >= 0 && x < p.clip.W && y < p.clip.H {
+		_ = p.surface.SetRune(x, y, r, style)
+	}
+}
+
+func (p *cStylePainter) fill(style paint.Style, x, y, width, height int) {
+	for iy := y; iy < y+height; iy++ {
+		for ix := x; ix < x+width; ix++ {
+			p.setRune(ix, iy, p.theme.Content.FillRune, style)
+		}
+	}
+}
+
+func (p *cStylePainter) hLine(style paint.Style, x, y, length int) {
+	for ix := x; ix < x+length; ix++ {
+		p.setRune(ix, y, p.theme.Border.BorderRunes.Top, style)
+	}
+}
+
+func (p *cStylePainter) vLine(style paint.Style, x, y, length int) {
+	for iy := y; iy < y+length; iy++ {
+		p.setRune(x, iy, p.theme.Border.BorderRunes.Left, style)
+	}
+}
+
+// box draws a border (if the shadow type has one) around the rectangle given,
+// optionally filling the inside of the border with the content style
+func (p *cStylePainter) box(state enums.StateType, shadowType enums.ShadowType, fill bool, x, y, width, height int) {
+	if width <= 0 || height <= 0 {
+		return
+	}
+	bs, border := p.borderStyle(state, shadowType)
+	if border && (width < 2 || height < 2) {
+		border = false
+	}
+	if fill {
+		p.fill(p.contentStyle(state), x, y, width, height)
+	}
+	if !border {
+		return
+	}
+	runes := p.theme.Border.BorderRunes
+	right, bottom := x+width-1, y+height-1
+	for ix := x + 1; ix < right; ix++ {
+		p.setRune(ix, y, runes.Top, bs)
+		p.setRune(ix, bottom, runes.Bottom, bs)
+	}
+	for iy := y + 1; iy < bottom; iy++ {
+		p.setRune(x, iy, runes.Left, bs)
+		p.setRune(right, iy, runes.Right, bs)
+	}
+	p.setRune(x, y, runes.TopLeft, bs)
+	p.setRune(right, y, runes.TopRight, bs)
+	p.setRune(x, bottom, runes.BottomLeft, bs)
+	p.setRune(right, bottom, runes.BottomRight, bs)
+}
+
+// gap clears part of the border on the given side of the rectangle, starting
+// at gapX (relative to the rectangle) for gapWidth cells
+func (p *cStylePainter) gap(state enums.StateType, x, y, width, height int, gapSide enums.PositionType, gapX, gapWidth int) {
+	cs := p.contentStyle(state)
+	switch gapSide {
+	case enums.POS_TOP:
+		p.fill(cs, x+gapX, y, gapWidth, 1)
+	case enums.POS_BOTTOM:
+		p.fill(cs, x+gapX, y+height-1, gapWidth, 1)
+	case enums.POS_LEFT:
+		p.fill(cs, x, y+gapX, 1, gapWidth)
+	case enums.POS_RIGHT:
+		p.fill(cs, x+width-1, y+gapX, 1, gapWidth)
+	}
+}
+
+// indicator draws a check or option indicator, ie: "[x]", vertically centred
+// in the rectangle given. When too narrow, only the mark is drawn.
+func (p *cStylePainter) indicator(state enums.StateType, open, mark, closed rune, x, y, width, height int) {
+	bs, _ := p.borderStyle(state, enums.SHADOW_IN)
+	cs := p.contentStyle(state)
+	_, cy := paintCentre(x, y, width, height)
+	if width < 3 {
+		p.setRune(x, cy, mark, cs)
+		return
+	}
+	p.setRune(x, cy, open, bs)
+	p.setRune(x+1, cy, mark, cs)
+	p.setRune(x+2, cy, closed, bs)
+}
+
+// line draws a line between the two points given, using the border runes for
+// horizontal and vertical lines and bullets for any others
+func (p *cStylePainter) line(style paint.Style, from, to ptypes.Point2I) {
+	r := paint.RuneBullet
+	switch {
+	case from.Y == to.Y:
+		r = p.theme.Border.BorderRunes.Top
+	case from.X == to.X:
+		r = p.theme.Border.BorderRunes.Left
+	}
+	dx, dy := to.X-from.X, to.Y-from.Y
+	sx, sy := 1, 1
+	if dx < 0 {
+		dx, sx = -dx, -1
+	}
+	if dy < 0 {
+		dy, sy = -dy, -1
+	}
+	x, y, e := from.X, from.Y, dx-dy
+	for {
+		p.setRune(x, y, r, style)
+		if x == to.X && y == to.Y {
+			return
+		}
+		if e2 := 2 * e; e2 > -dy {
+			e -= dy
+			x += sx
+		} else {
+			e += dx
+			y += sy
+		}
+	}
+}
+
+// fillPolygon fills every cell whose centre is inside the polygon given
+func (p *cStylePainter) fillPolygon(style paint.Style, points []ptypes.Point2I) {
+	minX, minY, maxX, maxY := points[0].X, points[0].Y, points[0].X, points[0].Y
+	for _, point := range points {
+		minX, maxX = min(minX, point.X), max(maxX, point.X)
+		minY, maxY = min(minY, point.Y), max(maxY, point.Y)
+	}
+	for iy := minY; iy <= maxY; iy++ {
+		for ix := minX; ix <= maxX; ix++ {
+			px, py := float64(ix)+0.5, float64(iy)+0.5
+			inside := false
+			for i, j := 0, len(points)-1; i < len(points); j, i = i, i+1 {
+				xi, yi := float64(points[i].X)+0.5, float64(points[i].Y)+0.5
+				xj, yj := float64(points[j].X)+0.5, float64(points[j].Y)+0.5
+				if (yi > py) != (yj > py) && px < (xj-xi)*(py-yi)/(yj-yi)+xi {
+					inside = !inside
+				}
+			}
+			if inside {
+				p.setRune(ix, iy, p.theme.Content.FillRune, style)
+			}
+		}
+	}
+}
@@ -19,17 +19,41 @@ package ctk
 import (
 	"bytes"
 	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strings"
 
+	"github.com/gofrs/uuid"
 	"github.com/tdewolff/parse/v2"
 	tcss "github.com/tdewolff/parse/v2/css"
 
+	"github.com/go-curses/cdk"
 	"github.com/go-curses/cdk/lib/sync"
+
+	"github.com/go-curses/ctk/lib/enums"
 )
 
 type cStyleSheet struct {
 	Lexer      *tcss.Lexer
 	Rules      []*StyleSheetRule
 	MediaRules []*StyleSheetMedia
+	Palettes   map[string]*StyleSheetPalette
+
+	features  StyleSheetMediaFeatures
+	variables bool
+	applied   map[uuid.UUID]map[string]map[string]bool
+	count     int
+	sources   []cStyleSheetSource
+	files     []cStyleSheetFile
+
+	// parsing state, for the current file or string
+	fsys      fs.FS
+	file      string
+	source    string
+	offset    int
+	end       int
+	importing []string
 
 	sync.RWMutex
 }
@@ -39,6 +63,8 @@ func newStyleSheet() *cStyleSheet {
 		Lexer:      nil,
 		Rules:      make([]*StyleSheetRule, 0),
 		MediaRules: make([]*StyleSheetMedia, 0),
+		Palettes:   make(map[string]*StyleSheetPalette),
+		applied:    make(map[uuid.UUID]map[string]map[string]bool),
 	}
 	return ss
 }
@@ -60,61 +86,415 @@ func (s *cStyleSheet) String() string {
 	for _, m := range s.MediaRules {
 		str += m.String() + "\n"
 	}
+	var names []string
+	for name := range s.Palettes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		str += s.Palettes[name].String() + "\n"
+	}
 	s.RUnlock()
 	return str
 }
 
+// SetMediaFeatures updates the features used to evaluate @media rules,
+// returning TRUE if the features given differ from the current ones.
+func (s *cStyleSheet) SetMediaFeatures(features StyleSheetMediaFeatures) (changed bool) {
+	s.Lock()
+	if changed = s.features != features; changed {
+		s.features = features
+	}
+	s.Unlock()
+	return
+}
+
+// GetMediaFeatures returns the features used to evaluate @media rules.
+func (s *cStyleSheet) GetMediaFeatures() (features StyleSheetMediaFeatures) {
+	s.RLock()
+	features = s.features
+	s.RUnlock()
+	return
+}
+
+// HasMediaRules returns TRUE if the style sheet contains any @media rules.
+func (s *cStyleSheet) HasMediaRules() (hasMedia bool) {
+	s.RLock()
+	hasMedia = len(s.MediaRules) > 0
+	s.RUnlock()
+	return
+}
+
+// ApplyStylesTo updates the CSS properties of the given Widget with the
+// properties selected for it. Properties previously applied to the Widget by
+// the style sheet which are no longer selected revert to their default values,
+// so that rules which no longer match (ie: a style class was removed) have no
+// lasting effect. Properties never applied by the style sheet are left as-is.
+//
+// Before being applied, var() references within property values are resolved
+// with the custom properties selected for the Widget or its nearest ancestor
+// and "@name" references are resolved with the colors of the active palette.
+// Properties with references which cannot be resolved are treated as though
+// they were not selected.
 func (s *cStyleSheet) ApplyStylesTo(w Widget) {
 	selector := w.CssFullPath()
 	styles := s.SelectProperties(selector)
-	s.RLock()
-	for s, _ := range styles {
-		for k, v := range styles[s] {
-			if err := w.SetCssPropertyFromStyle(k+":"+s, v.Value); err != nil {
+	variables := s.selectVariables(w, styles)
+	colors := s.selectPaletteColors()
+	reset := func(k, state string) {
+		if prop := w.GetCssProperty(cdk.Property(k), enums.StateTypeFromString(state)); prop != nil {
+			if err := prop.Set(prop.Default()); err != nil {
+				w.LogErr(err)
+			}
+		}
+	}
+	applied := make(map[string]map[string]bool)
+	for state, properties := range styles {
+		lookup := func(name string) (value string, ok bool) {
+			if value, ok = variables[state][name]; !ok {
+				value, ok = variables["normal"][name]
+			}
+			return
+		}
+		for k, v := range properties {
+			if v.IsCustom() {
+				continue
+			}
+			value, ok := resolveStyleSheetVariables(v.Value, lookup)
+			if ok {
+				value, ok = resolveStyleSheetPaletteColors(value, colors)
+			}
+			if !ok {
+				w.LogError("unresolved style value, %v:%v: %v", k, state, v.Value)
+				continue
+			}
+			if err := w.SetCssPropertyFromStyle(k+":"+state, value); err != nil {
 				w.LogErr(err)
+				continue
 			}
+			if _, found := applied[state]; !found {
+				applied[state] = make(map[string]bool)
+			}
+			applied[state][k] = true
 		}
 	}
+	id := w.ObjectID()
+	s.Lock()
+	previous := s.applied[id]
+	if len(applied) > 0 {
+		s.applied[id] = applied
+	} else {
+		delete(s.applied, id)
+	}
+	s.Unlock()
+	for state, keys := range previous {
+		for k := range keys {
+			if !applied[state][k] {
+				reset(k, state)
+			}
+		}
+	}
+}
+
+// selectVariables returns the custom properties, keyed by state and name, for
+// the given Widget. Custom properties selected for the Widget take precedence
+// over those of its parent and so on up the Widget hierarchy.
+func (s *cStyleSheet) selectVariables(w Widget, styles map[string]map[string]*StyleSheetProperty) (variables map[string]map[string]string) {
+	variables = make(map[string]map[string]string)
+	s.RLock()
+	hasVariables := s.variables
 	s.RUnlock()
+	if !hasVariables {
+		return
+	}
+	merge := func(properties map[string]map[string]*StyleSheetProperty) {
+		for state, keys := range properties {
+			for k, v := range keys {
+				if !v.IsCustom() {
+					continue
+				}
+				if _, ok := variables[state]; !ok {
+					variables[state] = make(map[string]string)
+				}
+				if _, ok := variables[state][k]; !ok {
+					variables[state][k] = v.Value
+				}
+			}
+		}
+	}
+	merge(styles)
+	p := w.GetParent()
+	for p != nil && p.ObjectID() != w.ObjectID() {
+		merge(s.SelectProperties(p.CssFullPath()))
+		np := p.GetParent()
+		if np != nil && np.ObjectID() == p.ObjectID() {
+			break
+		}
+		p = np
+	}
+	return
 }
 
+// selectPaletteColors returns the colors of the active palette. The active
+// palette is the "default" palette, overlaid with the palette named by the
+// ctk-color-palette setting and finally the colors of the ctk-color-scheme
+// setting.
+func (s *cStyleSheet) selectPaletteColors() (colors map[string]string) {
+	colors = make(map[string]string)
+	settings := GetDefaultSettings()
+	s.RLock()
+	for _, name := range []string{StyleSheetDefaultPalette, settings.GetColorPalette()} {
+		if palette, ok := s.Palettes[name]; ok {
+			for k, v := range palette.Colors {
+				colors[k] = v
+			}
+		}
+	}
+	s.RUnlock()
+	for k, v := range parseStyleSheetColorScheme(settings.GetColorScheme()) {
+		colors[k] = v
+	}
+	return
+}
+
+// SelectProperties returns the properties, keyed by state and property name,
+// of all rules matching the given path. Rules within @media blocks are only
+// considered when their conditions match the current media features. When more
+// than one rule declares the same property, the cascade decides which one is
+// selected: important declarations win over normal ones, followed by the rule
+// with the most specific selector and finally the rule declared last.
 func (s *cStyleSheet) SelectProperties(path string) (properties map[string]map[string]*StyleSheetProperty) {
-	properties = make(map[string]map[string]*StyleSheetProperty)
 	selector := newStyleSheetSelectorFromPath(path)
+	cascade := make(map[string]map[string]*cStyleSheetCascade)
 	s.RLock()
-	for _, rule := range s.Rules {
-		rSelect := rule.Selector
-		grouped := parseStyleSheetSelectorGroup(rSelect)
-		for _, gSelector := range grouped {
-			if selector.Match(gSelector) {
-				for _, elem := range rule.Properties {
-					if _, ok := properties[gSelector.State]; !ok {
-						properties[gSelector.State] = make(map[string]*StyleSheetProperty)
-					}
-					properties[gSelector.State][elem.Key] = elem
-				}
-			}
+	selectStyleSheetRuleProperties(selector, s.Rules, cascade)
+	for _, media := range s.MediaRules {
+		if media.Match(s.features) {
+			selectStyleSheetRuleProperties(selector, media.Rules, cascade)
 		}
 	}
 	s.RUnlock()
+	properties = make(map[string]map[string]*StyleSheetProperty)
+	for state, keys := range cascade {
+		properties[state] = make(map[string]*StyleSheetProperty)
+		for k, c := range keys {
+			properties[state][k] = c.property
+		}
+	}
 	return
 }
 
+type cStyleSheetCascade struct {
+	property    *StyleSheetProperty
+	specificity StyleSheetSpecificity
+	index       int
+}
+
+// overrides returns TRUE if the cascade entry takes precedence over the other
+func (c *cStyleSheetCascade) overrides(other *cStyleSheetCascade) bool {
+	if c.property.Important != other.property.Important {
+		return c.property.Important
+	}
+	if c.specificity != other.specificity {
+		return other.specificity.Less(c.specificity)
+	}
+	return c.index > other.index
+}
+
+func selectStyleSheetRuleProperties(selector *StyleSheetSelector, rules []*StyleSheetRule, cascade map[string]map[string]*cStyleSheetCascade) {
+	for _, rule := range rules {
+		for _, gSelector := range rule.selectors {
+			if !selector.Match(gSelector) {
+				continue
+			}
+			if _, ok := cascade[gSelector.State]; !ok {
+				cascade[gSelector.State] = make(map[string]*cStyleSheetCascade)
+			}
+			specificity := gSelector.Specificity()
+			for _, elem := range rule.Properties {
+				candidate := &cStyleSheetCascade{
+					property:    elem,
+					specificity: specificity,
+					index:       rule.index,
+				}
+				if existing, ok := cascade[gSelector.State][elem.Key]; !ok || candidate.overrides(existing) {
+					cascade[gSelector.State][elem.Key] = candidate
+				}
+			}
+		}
+	}
+}
+
+// prepareRule records the source order of the rule, parses the selectors of
+// the rule and notes whether the rule declares any custom properties.
+func (s *cStyleSheet) prepareRule(rule *StyleSheetRule) {
+	rule.index = s.count
+	s.count += 1
+	rule.selectors = parseStyleSheetSelectorGroup(rule.Selector)
+	for _, elem := range rule.Properties {
+		if elem.IsCustom() {
+			s.variables = true
+		}
+	}
+}
+
+// ParseString parses the given CSS source into the style sheet. Relative
+// @import paths are resolved from the current working directory.
 func (s *cStyleSheet) ParseString(source string) (err error) {
 	s.Lock()
 	defer s.Unlock()
+	s.sources = append(s.sources, cStyleSheetSource{css: source})
+	return s.parse(nil, "", source)
+}
+
+// ParseFile parses the named CSS file into the style sheet. Relative @import
+// paths are resolved from the directory of the file doing the importing.
+func (s *cStyleSheet) ParseFile(name string) (err error) {
+	s.Lock()
+	defer s.Unlock()
+	s.sources = append(s.sources, cStyleSheetSource{file: name})
+	return s.parseFile(nil, name)
+}
+
+// ParseFS is the same as ParseFile except that the named file, and any files
+// it imports, are read from the given filesystem (ie: an embed.FS).
+func (s *cStyleSheet) ParseFS(fsys fs.FS, name string) (err error) {
+	s.Lock()
+	defer s.Unlock()
+	s.sources = append(s.sources, cStyleSheetSource{fsys: fsys, file: name})
+	return s.parseFile(fsys, name)
+}
+
+// Modified returns TRUE if any of the files parsed into the style sheet have
+// changed since they were parsed.
+func (s *cStyleSheet) Modified() (modified bool) {
+	s.RLock()
+	defer s.RUnlock()
+	for _, f := range s.files {
+		if f.modified() {
+			return true
+		}
+	}
+	return false
+}
+
+// updateFiles records the current modification details of the files parsed
+// into the style sheet, so that Modified reports only later changes.
+func (s *cStyleSheet) updateFiles() {
+	s.Lock()
+	defer s.Unlock()
+	for idx, f := range s.files {
+		if info, err := statStyleSheetFile(f.fsys, f.file); err == nil {
+			s.files[idx].modTime = info.ModTime()
+			s.files[idx].size = info.Size()
+		}
+	}
+}
+
+// appliedStyles returns a copy of the properties applied to each widget by the
+// style sheet, for tracking by a style sheet replacing this one.
+func (s *cStyleSheet) appliedStyles() (applied map[uuid.UUID]map[string]map[string]bool) {
+	s.RLock()
+	defer s.RUnlock()
+	applied = make(map[uuid.UUID]map[string]map[string]bool)
+	for id, keys := range s.applied {
+		applied[id] = keys
+	}
+	return
+}
+
+// forgetStylesOf stops tracking the properties applied to the widget with the
+// given ID, typically because the widget has been destroyed.
+func (s *cStyleSheet) forgetStylesOf(id uuid.UUID) {
+	s.Lock()
+	delete(s.applied, id)
+	s.Unlock()
+}
+
+// Reload returns a new style sheet, parsed from the same strings and files
+// (re-read from their filesystems) as this one. Properties applied to widgets
+// by this style sheet are still tracked by the new one so that any removed
+// from the files revert to their defaults when applied.
+func (s *cStyleSheet) Reload() (reloaded *cStyleSheet, err error) {
+	s.RLock()
+	defer s.RUnlock()
+	reloaded = newStyleSheet()
+	reloaded.features = s.features
+	for _, source := range s.sources {
+		reloaded.sources = append(reloaded.sources, source)
+		if source.file != "" {
+			err = reloaded.parseFile(source.fsys, source.file)
+		} else {
+			err = reloaded.parse(nil, "", source.css)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	for id, applied := range s.applied {
+		reloaded.applied[id] = applied
+	}
+	return
+}
+
+func (s *cStyleSheet) parseFile(fsys fs.FS, name string) (err error) {
+	for _, importing := range s.importing {
+		if importing == name {
+			return fmt.Errorf("circular @import of %v", name)
+		}
+	}
+	var data []byte
+	if data, err = readStyleSheetFile(fsys, name); err != nil {
+		return
+	}
+	if info, ee := statStyleSheetFile(fsys, name); ee == nil {
+		s.files = append(s.files, cStyleSheetFile{
+			fsys:    fsys,
+			file:    name,
+			modTime: info.ModTime(),
+			size:    info.Size(),
+		})
+	}
+	s.importing = append(s.importing, name)
+	err = s.parse(fsys, name, string(data))
+	s.importing = s.importing[:len(s.importing)-1]
+	return
+}
+
+// parse the given source, restoring the parsing state of any file currently
+// being parsed when done
+func (s *cStyleSheet) parse(fsys fs.FS, file, source string) (err error) {
+	lexer, pFsys, pFile, pSource, pOffset, pEnd := s.Lexer, s.fsys, s.file, s.source, s.offset, s.end
+	defer func() {
+		s.Lexer, s.fsys, s.file, s.source, s.offset, s.end = lexer, pFsys, pFile, pSource, pOffset, pEnd
+	}()
 	s.Lexer = tcss.NewLexer(parse.NewInput(bytes.NewBufferString(source)))
+	s.fsys, s.file, s.source, s.offset, s.end = fsys, file, source, 0, 0
 	for {
-		tt, data := s.Lexer.Next()
+		tt, data := s.next()
 		switch tt {
 		case tcss.ErrorToken:
-			err = nil
-			return
+			if ee := s.Lexer.Err(); ee != nil && ee != io.EOF {
+				return s.errorf("%v", ee)
+			}
+			return nil
 		case tcss.WhitespaceToken:
 			continue // nop
 		case tcss.CommentToken:
 			continue // nop, ignore actual comments
 		case tcss.AtKeywordToken:
+			switch strings.ToLower(string(data)) {
+			case "@import":
+				if err = s.recurseImport(); err != nil {
+					return
+				}
+				continue
+			case "@palette":
+				if err = s.recursePalette(); err != nil {
+					return
+				}
+				continue
+			}
 			// data == "@media"
 			if cssMediaRule, err := s.recurseMedia(); err != nil {
 				return err
@@ -126,18 +506,105 @@ func (s *cStyleSheet) ParseString(source string) (err error) {
 			if cssRule, err := s.recurseRule(tt, data); err != nil {
 				return err
 			} else {
+				s.prepareRule(cssRule)
 				s.Rules = append(s.Rules, cssRule)
 			}
 		}
 	}
 }
 
+// next returns the next token from the lexer, tracking the offset of the token
+// within the source for error reporting
+func (s *cStyleSheet) next() (tt tcss.TokenType, data []byte) {
+	tt, data = s.Lexer.Next()
+	s.offset = s.end
+	s.end += len(data)
+	return
+}
+
+// errorf returns a StyleSheetError for the most recent token
+func (s *cStyleSheet) errorf(format string, argv ...interface{}) error {
+	return newStyleSheetError(s.file, s.source, s.offset, fmt.Errorf(format, argv...))
+}
+
+// consume up to (and including) the semicolon ending the @import, parsing the
+// file named
+func (s *cStyleSheet) recurseImport() (err error) {
+	var name string
+	var offset int
+	for {
+		tt, data := s.next()
+		switch tt {
+		case tcss.WhitespaceToken, tcss.CommentToken:
+			continue // nop
+		case tcss.StringToken, tcss.URLToken:
+			if name != "" {
+				return s.errorf("@import: unexpected %v (%v)", tt, string(data))
+			}
+			name, offset = unquoteStyleSheetImport(string(data)), s.offset
+			continue
+		case tcss.ErrorToken, tcss.SemicolonToken:
+			if name == "" {
+				return s.errorf("@import: missing file name")
+			}
+			name = resolveStyleSheetImport(s.fsys, s.file, name)
+			if err = s.parseFile(s.fsys, name); err != nil {
+				if _, ok := err.(*StyleSheetError); !ok {
+					s.offset = offset
+					err = s.errorf("@import: %v", err)
+				}
+			}
+			return
+		default:
+			return s.errorf("@import: unexpected %v (%v)", tt, string(data))
+		}
+	}
+}
+
+// consume up to (and including) the closing curly brace of the @palette,
+// merging the colors declared with any existing palette of the same name
+func (s *cStyleSheet) recursePalette() (err error) {
+	var name string
+	for {
+		tt, data := s.next()
+		switch tt {
+		case tcss.WhitespaceToken, tcss.CommentToken:
+			continue // nop
+		case tcss.IdentToken:
+			if name != "" {
+				return s.errorf("@palette: unexpected %v (%v)", tt, string(data))
+			}
+			name = string(data)
+			continue
+		case tcss.LeftBraceToken:
+			if name == "" {
+				return s.errorf("@palette: missing palette name")
+			}
+			var properties map[string]*StyleSheetProperty
+			if properties, err = s.recurseKeyValues(); err != nil {
+				return
+			}
+			palette, ok := s.Palettes[name]
+			if !ok {
+				palette = &StyleSheetPalette{Name: name, Colors: make(map[string]string)}
+				s.Palettes[name] = palette
+			}
+			for k, v := range properties {
+				palette.Colors[k] = v.Value
+			}
+			return
+		default:
+			return s.errorf("@palette: unexpected %v (%v)", tt, string(data))
+		}
+	}
+}
+
 // consume up to (and including) the first opening bracket
 func (s *cStyleSheet) recurseMedia() (mediaRule *StyleSheetMedia, err error) {
 	mediaRule = &StyleSheetMedia{}
 	var ruleMode bool
 	for {
-		tt, data := s.Lexer.Next()
+		tt, data := s.next()
 		switch tt {
 		case tcss.ErrorToken:
 			return
@@ -147,18 +614,31 @@ func (s *cStyleSheet) recurseMedia() (mediaRule *StyleSheetMedia, err error) {
 		case tcss.RightParenthesisToken:
 			mediaRule.Conditions += ")"
 			continue // nop
-		case tcss.WhitespaceToken:
+		case tcss.WhitespaceToken, tcss.CommentToken:
+			if !ruleMode && len(mediaRule.Conditions) > 0 && !strings.HasSuffix(mediaRule.Conditions, " ") {
+				mediaRule.Conditions += " "
+			}
 			continue // nop
+		case tcss.CommaToken:
+			if !ruleMode {
+				mediaRule.Conditions = strings.TrimSpace(mediaRule.Conditions) + ","
+			}
+			continue
 		case tcss.RightBraceToken:
+			if ruleMode {
+				return // end of media block
+			}
 			continue // nop
 		case tcss.LeftBraceToken:
 			ruleMode = true
+			mediaRule.Conditions = strings.TrimSpace(mediaRule.Conditions)
 			continue
-		case tcss.LeftBracketToken, tcss.RightBracketToken, tcss.ColonToken, tcss.NumberToken, tcss.DimensionToken, tcss.DelimToken, tcss.IdentToken:
+		case tcss.LeftBracketToken, tcss.RightBracketToken, tcss.ColonToken, tcss.NumberToken, tcss.DimensionToken, tcss.DelimToken, tcss.HashToken, tcss.IdentToken:
 			if ruleMode {
 				if cssRule, err := s.recurseRule(tt, data); err != nil {
 					return nil, err
 				} else {
+					s.prepareRule(cssRule)
 					mediaRule.Rules = append(mediaRule.Rules, cssRule)
 				}
 			} else {
@@ -166,7 +646,7 @@ func (s *cStyleSheet) recurseMedia() (mediaRule *StyleSheetMedia, err error) {
 			}
 			continue // key / value parsing
 		default:
-			return nil, fmt.Errorf("recurseMediaRule: unexpected token type: %v (%v)", tt, data)
+			return nil, s.errorf("@media: unexpected %v (%v)", tt, string(data))
 		}
 	}
 }
@@ -191,18 +671,18 @@ func (s *cStyleSheet) recurseRule(tt tcss.TokenType, data []byte) (cssRule *Styl
 			return // end of rule block
 		case tcss.WhitespaceToken:
 			cssRule.Selector += " "
-			tt, data = s.Lexer.Next()
+			tt, data = s.next()
 			continue // nop
 		case tcss.LeftBracketToken, tcss.RightBracketToken, tcss.CommentToken, tcss.DelimToken, tcss.HashToken, tcss.ColonToken, tcss.NumberToken, tcss.IdentToken:
 			cssRule.Selector += string(data)
-			tt, data = s.Lexer.Next()
+			tt, data = s.next()
 			continue // key / value parsing
 		case tcss.CommaToken:
 			cssRule.Selector += ","
-			tt, data = s.Lexer.Next()
+			tt, data = s.next()
 			continue // key / value parsing
 		default:
-			return nil, fmt.Errorf("RecurseRule: unexpected token type: %v (%v)", tt, data)
+			return nil, s.errorf("unexpected %v in selector (%v)", tt, string(data))
 		}
 	}
 }
@@ -213,9 +693,10 @@ func (s *cStyleSheet) recurseKeyValues() (properties map[string]*StyleSheetPrope
 	properties = make(map[string]*StyleSheetProperty)
 	var key, value string
 	var vType tcss.TokenType
-	var isValue bool
+	var isValue, spaced bool
+	var tokens int
 	for {
-		tt, data := s.Lexer.Next()
+		tt, data := s.next()
 		switch tt {
 		case tcss.LeftBraceToken:
 			continue // ignore opening braces
@@ -226,26 +707,45 @@ func (s *cStyleSheet) recurseKeyValues() (properties map[string]*StyleSheetPrope
 			continue // colons transition from key to value parsing
 		case tcss.SemicolonToken:
 			isValue = false
+			important := false
+			if strings.HasSuffix(strings.ToLower(value), "!important") {
+				important = true
+				value = strings.TrimSuffix(value[:len(value)-len("!important")], " ")
+				tokens -= 2
+			}
+			if tokens == 1 && len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+				value = value[1 : len(value)-1] // a lone string value is unquoted
+			}
 			properties[key] = &StyleSheetProperty{
-				Key:   key,
-				Value: value,
-				Type:  vType,
+				Key:       key,
+				Value:     value,
+				Type:      vType,
+				Important: important,
 			}
 			key, value = "", ""
 			vType = tcss.ErrorToken
+			spaced, tokens = false, 0
 			continue // semicolons transition from current pair to new pair
 		case tcss.WhitespaceToken:
-			continue // nop
-		case tcss.LeftBracketToken, tcss.RightBracketToken, tcss.DelimToken, tcss.DimensionToken, tcss.HashToken, tcss.IdentToken:
+			spaced = isValue && value != ""
+			continue // whitespace between value tokens is kept as a single space
+		case tcss.LeftBracketToken, tcss.RightBracketToken, tcss.DelimToken, tcss.DimensionToken, tcss.HashToken, tcss.IdentToken,
+			tcss.CustomPropertyNameToken, tcss.FunctionToken, tcss.CommaToken, tcss.RightParenthesisToken, tcss.AtKeywordToken,
+			tcss.NumberToken, tcss.PercentageToken, tcss.StringToken:
 			if isValue {
+				if spaced {
+					value += " "
+					spaced = false
+				}
 				vType = tt
 				value += string(data)
+				tokens += 1
 			} else {
 				key += string(data)
 			}
 			continue // key / value parsing
 		default:
-			return nil, fmt.Errorf("RecurseKeyValues: unexpected token type: %v (%v)", tt, data)
+			return nil, s.errorf("unexpected %v in declaration (%v)", tt, string(data))
 		}
 	}
-}
\ No newline at end of file
+}
@@ -14,6 +14,118 @@
 
 package ctk
 
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-curses/cdk"
+	"github.com/go-curses/cdk/lib/ptypes"
+)
+
+// StyleSheetMediaTrueColors is the number of colors reported by a terminal
+// supporting 24-bit color, and the value of the "truecolor" keyword in media
+// queries.
+const StyleSheetMediaTrueColors = 1 << 24
+
+// StyleSheetMediaMonochromeColors is the most colors a terminal can report and
+// still be considered monochrome, both for the "monochrome" media feature and
+// the value of the "monochrome" keyword in media queries.
+const StyleSheetMediaMonochromeColors = 2
+
+// StyleSheetMediaFeatures describes the terminal a style sheet is being applied
+// to. The features are used to evaluate the conditions of @media rules:
+//
+//	width, min-width, max-width          number of columns
+//	height, min-height, max-height       number of rows
+//	monochrome, color                    whether more than two colors are supported
+//	colors, min-colors, max-colors       number of colors (16, 256, truecolor, monochrome)
+//	prefers-reduced-motion               reduce or no-preference
+//
+// The media types "all", "screen" and "tty" always match, any other media type
+// never does. Lengths may be given with a unit suffix (ie: 80ch) which is
+// ignored, as all lengths are measured in terminal cells.
+type StyleSheetMediaFeatures struct {
+	Width         int
+	Height        int
+	Colors        int
+	ReducedMotion bool
+}
+
+func newStyleSheetMediaFeatures(display cdk.Display, alloc ptypes.Rectangle) (features StyleSheetMediaFeatures) {
+	features.Width, features.Height = alloc.W, alloc.H
+	if display != nil {
+		if screen := display.Screen(); screen != nil {
+			if w, h := screen.Size(); w > 0 && h > 0 {
+				features.Width, features.Height = w, h
+			}
+		}
+		features.Colors = display.Colors()
+	}
+	features.ReducedMotion = !GetDefaultSettings().GetEnableAnimations()
+	return
+}
+
+// IsMonochrome returns TRUE if the terminal supports two colors or fewer.
+func (f StyleSheetMediaFeatures) IsMonochrome() bool {
+	return f.Colors <= StyleSheetMediaMonochromeColors
+}
+
+// Match returns TRUE if the given media feature expression is satisfied. The
+// name is the feature name (ie: min-width) and value is the (possibly empty)
+// text following the colon.
+func (f StyleSheetMediaFeatures) Match(name, value string) bool {
+	switch name {
+	case "width", "min-width", "max-width":
+		return matchStyleSheetMediaRange(name, f.Width, value)
+	case "height", "min-height", "max-height":
+		return matchStyleSheetMediaRange(name, f.Height, value)
+	case "colors", "min-colors", "max-colors":
+		return matchStyleSheetMediaRange(name, f.Colors, value)
+	case "monochrome":
+		return f.IsMonochrome()
+	case "color":
+		return !f.IsMonochrome()
+	case "prefers-reduced-motion":
+		switch value {
+		case "", "reduce":
+			return f.ReducedMotion
+		case "no-preference":
+			return !f.ReducedMotion
+		}
+	}
+	return false
+}
+
+func matchStyleSheetMediaRange(name string, have int, value string) bool {
+	var want int
+	switch value {
+	case "truecolor":
+		want = StyleSheetMediaTrueColors
+	case "monochrome":
+		want = StyleSheetMediaMonochromeColors
+		if have < want {
+			have = want // all monochrome terminals are equivalent
+		}
+	default:
+		digits := rxStyleSheetMediaNumber.FindString(value)
+		if digits == "" {
+			return false
+		}
+		var err error
+		if want, err = strconv.Atoi(digits); err != nil {
+			return false
+		}
+	}
+	switch {
+	case strings.HasPrefix(name, "min-"):
+		return have >= want
+	case strings.HasPrefix(name, "max-"):
+		return have <= want
+	}
+	return have == want
+}
+
 type StyleSheetMedia struct {
 	Conditions string
 	Rules      []*StyleSheetRule
@@ -28,4 +140,49 @@ func (m StyleSheetMedia) String() string {
 	}
 	s += "}"
 	return s
-}
\ No newline at end of file
+}
+
+// Match returns TRUE if the media query list of the @media rule is satisfied by
+// the given features. A media query list matches if any of its comma separated
+// queries match.
+func (m StyleSheetMedia) Match(features StyleSheetMediaFeatures) bool {
+	for _, query := range strings.Split(m.Conditions, ",") {
+		if matchStyleSheetMediaQuery(strings.ToLower(strings.TrimSpace(query)), features) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchStyleSheetMediaQuery(query string, features StyleSheetMediaFeatures) (match bool) {
+	if query == "" {
+		return false
+	}
+	negate := false
+	if strings.HasPrefix(query, "not ") {
+		negate = true
+		query = strings.TrimSpace(query[4:])
+	} else if strings.HasPrefix(query, "only ") {
+		query = strings.TrimSpace(query[5:])
+	}
+	mediaType := query
+	if idx := strings.Index(query, "("); idx > -1 {
+		mediaType = query[:idx]
+	}
+	mediaType = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(mediaType), "and"))
+	switch mediaType {
+	case "", "all", "screen", "tty":
+		match = true
+	}
+	for _, m := range rxStyleSheetMediaFeature.FindAllStringSubmatch(query, -1) {
+		if !features.Match(m[1], strings.TrimSpace(m[2])) {
+			match = false
+		}
+	}
+	return match != negate
+}
+
+var (
+	rxStyleSheetMediaFeature = regexp.MustCompile(`\(\s*([-a-z]+)\s*(?::\s*([^)]*))?\)`)
+	rxStyleSheetMediaNumber  = regexp.MustCompile(`^\d+`)
+)
@@ -0,0 +1,87 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// StyleSheetDefaultPalette is the name of the @palette used as the base for
+// all other palettes and when no ctk-color-palette setting is present.
+const StyleSheetDefaultPalette = "default"
+
+// StyleSheetPalette is a named set of colors declared with an @palette rule,
+// for example:
+//
+//	@palette dark {
+//	    brand: navy;
+//	    accent: #ffff00;
+//	}
+//
+// Colors within the active palette are referenced in property values by name,
+// prefixed with an "@" symbol (ie: "color: @brand;").
+type StyleSheetPalette struct {
+	Name   string
+	Colors map[string]string
+}
+
+func (p StyleSheetPalette) String() string {
+	var names []string
+	for name := range p.Colors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	s := fmt.Sprintf("@palette %v {\n", p.Name)
+	for _, name := range names {
+		s += fmt.Sprintf("\t%v: %v;\n", name, p.Colors[name])
+	}
+	s += "}"
+	return s
+}
+
+// parseStyleSheetColorScheme parses the ctk-color-scheme setting, which is a
+// list of "name:color" pairs separated by newlines or semicolons.
+func parseStyleSheetColorScheme(scheme string) (colors map[string]string) {
+	colors = make(map[string]string)
+	for _, line := range strings.FieldsFunc(scheme, func(r rune) bool {
+		return r == '\n' || r == ';'
+	}) {
+		if name, color, found := strings.Cut(line, ":"); found {
+			if name, color = strings.TrimSpace(name), strings.TrimSpace(color); name != "" && color != "" {
+				colors[name] = color
+			}
+		}
+	}
+	return
+}
+
+var rxStyleSheetPaletteColor = regexp.MustCompile(`@([-_a-zA-Z0-9]+)`)
+
+// resolveStyleSheetPaletteColors replaces all "@name" references within the
+// value with the named colors, returning FALSE if any are not present.
+func resolveStyleSheetPaletteColors(value string, colors map[string]string) (resolved string, ok bool) {
+	ok = true
+	resolved = rxStyleSheetPaletteColor.ReplaceAllStringFunc(value, func(match string) string {
+		if color, found := colors[match[1:]]; found {
+			return color
+		}
+		ok = false
+		return match
+	})
+	return
+}
@@ -16,16 +16,64 @@ package ctk
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	tcss "github.com/tdewolff/parse/v2/css"
 )
 
 type StyleSheetProperty struct {
-	Key   string
-	Value string
-	Type  tcss.TokenType
+	Key       string
+	Value     string
+	Type      tcss.TokenType
+	Important bool
 }
 
 func (e StyleSheetProperty) String() string {
+	if e.Important {
+		return fmt.Sprintf("%v: %v !important;", e.Key, e.Value)
+	}
 	return fmt.Sprintf("%v: %v;", e.Key, e.Value)
-}
\ No newline at end of file
+}
+
+// IsCustom returns TRUE if the property is a custom property (ie: "--name"),
+// which is not applied to widgets directly and instead provides values for
+// var() references.
+func (e StyleSheetProperty) IsCustom() bool {
+	return strings.HasPrefix(e.Key, "--")
+}
+
+// maxStyleSheetVariableDepth limits how many times var() references are
+// resolved within a value, preventing custom properties referring to each
+// other from looping forever.
+const maxStyleSheetVariableDepth = 16
+
+var rxStyleSheetVariable = regexp.MustCompile(`var\(\s*(--[-_a-zA-Z0-9]+)\s*(?:,([^()]*))?\)`)
+
+// resolveStyleSheetVariables replaces all var() references within the value
+// with the result of the lookup function, or the fallback given when the custom
+// property is not found. Returns FALSE if any references cannot be resolved.
+func resolveStyleSheetVariables(value string, lookup func(name string) (value string, ok bool)) (resolved string, ok bool) {
+	resolved = value
+	for depth := 0; depth < maxStyleSheetVariableDepth; depth++ {
+		if !rxStyleSheetVariable.MatchString(resolved) {
+			return resolved, true
+		}
+		ok = true
+		resolved = rxStyleSheetVariable.ReplaceAllStringFunc(resolved, func(match string) string {
+			m := rxStyleSheetVariable.FindStringSubmatch(match)
+			if v, found := lookup(m[1]); found {
+				return v
+			}
+			if strings.Contains(match, ",") {
+				return strings.TrimSpace(m[2])
+			}
+			ok = false
+			return match
+		})
+		if !ok {
+			return
+		}
+	}
+	return resolved, !rxStyleSheetVariable.MatchString(resolved)
+}
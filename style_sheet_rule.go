@@ -17,6 +17,9 @@ package ctk
 type StyleSheetRule struct {
 	Selector   string
 	Properties []*StyleSheetProperty
+
+	selectors []*StyleSheetSelector
+	index     int
 }
 
 func (r StyleSheetRule) String() string {
@@ -19,69 +19,102 @@ import (
 	"strings"
 )
 
+// StyleSheetSelector is a compound selector (ie: ctk-button#save.primary)
+// along with the compound selectors of any ancestors required to match.
+// Parents are ordered from the outermost ancestor to the nearest one and the
+// Combinator of each parent describes how it relates to the selector which
+// follows it: ">" for a direct parent and " " for any ancestor.
 type StyleSheetSelector struct {
-	Name    string
-	Type    string
-	Class   string
-	State   string
-	Parents []string
+	Name       string
+	Type       string
+	Classes    []string
+	State      string
+	Parents    []*StyleSheetSelector
+	Combinator string
+}
+
+// StyleSheetSpecificity is the number of id, class (including states) and
+// type selectors within a selector, in that order of significance.
+type StyleSheetSpecificity [3]int
+
+// Less returns TRUE if the specificity is lower than the other given.
+func (s StyleSheetSpecificity) Less(other StyleSheetSpecificity) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] != other[i] {
+			return s[i] < other[i]
+		}
+	}
+	return false
 }
 
 func parseStyleSheetSelectorGroup(path string) (selectors []*StyleSheetSelector) {
-	if rxSelectorGroup.MatchString(path) {
-		parts := rxSelectorGroup.Split(path, -1)
-		for _, part := range parts {
+	for _, part := range rxSelectorGroup.Split(path, -1) {
+		if part = strings.TrimSpace(part); part != "" {
 			selectors = append(selectors, newStyleSheetSelectorFromPath(part))
 		}
-	} else {
-		selectors = append(selectors, newStyleSheetSelectorFromPath(path))
 	}
 	return
 }
 
 func newStyleSheetSelectorFromPath(path string) (selector *StyleSheetSelector) {
-	selector = &StyleSheetSelector{
-		Name:    "",
-		Type:    "",
-		Class:   "",
-		State:   "normal",
-		Parents: []string{},
-	}
 	path = strings.TrimSpace(path)
-	parts := rxSelectorParents.Split(path, -1)
-	if num := len(parts); num > 1 {
-		path = parts[num-1]
-		selector.Parents = append(selector.Parents, parts[:num-1]...)
-	}
-	altered := path
-	if rxSelectorName.MatchString(path) {
-		m := rxSelectorName.FindStringSubmatch(path)
-		if len(m) > 1 {
-			selector.Name = m[1]
-			altered = rxSelectorName.ReplaceAllString(altered, "")
+	parts := rxSelectorCombinator.Split(path, -1)
+	combinators := rxSelectorCombinator.FindAllString(path, -1)
+	last := len(parts) - 1
+	selector = newStyleSheetCompoundSelector(parts[last])
+	for idx, part := range parts[:last] {
+		if part == "" {
+			continue
 		}
-	}
-	if rxSelectorClass.MatchString(path) {
-		m := rxSelectorClass.FindStringSubmatch(path)
-		if len(m) > 1 {
-			selector.Class = m[1]
-			altered = rxSelectorClass.ReplaceAllString(altered, "")
+		parent := newStyleSheetCompoundSelector(part)
+		if strings.Contains(combinators[idx], ">") {
+			parent.Combinator = ">"
 		}
+		selector.Parents = append(selector.Parents, parent)
 	}
-	if rxSelectorState.MatchString(path) {
-		m := rxSelectorState.FindStringSubmatch(path)
-		if len(m) > 1 {
-			selector.State = m[1]
-			altered = rxSelectorState.ReplaceAllString(altered, "")
-		}
+	return
+}
+
+func newStyleSheetCompoundSelector(compound string) (selector *StyleSheetSelector) {
+	selector = &StyleSheetSelector{
+		Name:       "",
+		Type:       "",
+		Classes:    []string{},
+		State:      "normal",
+		Parents:    []*StyleSheetSelector{},
+		Combinator: " ",
 	}
-	if len(altered) > 0 {
-		selector.Type = altered
+	for _, m := range rxSelectorCompound.FindAllStringSubmatch(compound, -1) {
+		switch m[1] {
+		case "#":
+			selector.Name = m[2]
+		case ".":
+			selector.Classes = append(selector.Classes, m[2])
+		case ":":
+			selector.State = m[2]
+		default:
+			if m[2] != "*" {
+				selector.Type = m[2]
+			}
+		}
 	}
 	return
 }
 
 func (s StyleSheetSelector) String() string {
+	str := ""
+	for _, parent := range s.Parents {
+		str += parent.compoundString()
+		if parent.Combinator == ">" {
+			str += " > "
+		} else {
+			str += " "
+		}
+	}
+	return str + s.compoundString()
+}
+
+func (s StyleSheetSelector) compoundString() string {
 	str := ""
 	if len(s.Type) > 0 {
 		str += s.Type
@@ -89,8 +122,8 @@ func (s StyleSheetSelector) String() string {
 	if len(s.Name) > 0 {
 		str += "#" + s.Name
 	}
-	if len(s.Class) > 0 {
-		str += "." + s.Class
+	for _, class := range s.Classes {
+		str += "." + class
 	}
 	if len(s.State) > 0 {
 		str += ":" + s.State
@@ -98,68 +131,83 @@ func (s StyleSheetSelector) String() string {
 	return str
 }
 
-func (s StyleSheetSelector) Match(selector *StyleSheetSelector) (match bool) {
-	var wClass, wType, wName bool
-	var mClass, mType, mName bool
-	if len(selector.Class) > 0 {
-		wClass = true
-		if len(s.Class) > 0 {
-			// has classes to compare
-			mClass = s.Class == selector.Class
-		} else if selector.Class == "normal" {
-			// normal class can be omitted
-			mClass = true
-		} else {
-			return false
+// HasClass returns TRUE if the given class is one of the selector's classes.
+func (s StyleSheetSelector) HasClass(class string) bool {
+	for _, c := range s.Classes {
+		if c == class {
+			return true
 		}
 	}
-	if len(selector.Type) > 0 {
-		wType = true
-		if len(s.Type) > 0 {
-			// has types to compare
-			mType = s.Type == selector.Type
-		} else {
+	return false
+}
+
+// Specificity returns the specificity of the selector, including all of the
+// parent selectors. The "normal" state is implied and does not count.
+func (s StyleSheetSelector) Specificity() (specificity StyleSheetSpecificity) {
+	for _, compound := range append([]*StyleSheetSelector{&s}, s.Parents...) {
+		if compound.Name != "" {
+			specificity[0] += 1
+		}
+		specificity[1] += len(compound.Classes)
+		if compound.State != "" && compound.State != "normal" {
+			specificity[1] += 1
+		}
+		if compound.Type != "" {
+			specificity[2] += 1
+		}
+	}
+	return
+}
+
+// Match returns TRUE if the selector, describing a widget's full path, is
+// matched by the given selector from a style sheet rule. States are not
+// considered as rules apply to the state they select.
+func (s StyleSheetSelector) Match(selector *StyleSheetSelector) (match bool) {
+	if !s.matchCompound(selector) {
+		return false
+	}
+	return matchStyleSheetSelectorParents(s.Parents, selector.Parents)
+}
+
+func (s StyleSheetSelector) matchCompound(selector *StyleSheetSelector) bool {
+	if len(selector.Type) > 0 && s.Type != selector.Type {
+		return false
+	}
+	if len(selector.Name) > 0 && s.Name != selector.Name {
+		return false
+	}
+	for _, class := range selector.Classes {
+		if !s.HasClass(class) {
 			return false
 		}
 	}
-	if len(selector.Name) > 0 {
-		wName = true
-		if len(s.Name) > 0 {
-			// has names to compare
-			mName = s.Name == selector.Name
-		} else {
+	return true
+}
+
+// matchStyleSheetSelectorParents walks the wanted parents from the nearest to
+// the outermost, backtracking over the ancestors when a descendant combinator
+// can be satisfied by more than one of them.
+func matchStyleSheetSelectorParents(have, want []*StyleSheetSelector) bool {
+	if len(want) == 0 {
+		return true
+	}
+	nearest := want[len(want)-1]
+	if nearest.Combinator == ">" {
+		if len(have) == 0 || !have[len(have)-1].matchCompound(nearest) {
 			return false
 		}
+		return matchStyleSheetSelectorParents(have[:len(have)-1], want[:len(want)-1])
 	}
-	if wantNum := len(selector.Parents); wantNum > 0 {
-		if haveNum := len(s.Parents); haveNum > 0 {
-			if wantNum > haveNum {
-				// more wanted than have
-				return false
-			}
-			for i := wantNum - 1; i >= 0; i-- {
-				check := selector.Parents[i]
-				ok := false
-				for j := haveNum - 1; j >= 0; j-- {
-					if s.Parents[j] == check {
-						ok = true
-						break
-					}
-				}
-				if !ok {
-					// missing at least one parent
-					return false
-				}
-			}
+	for i := len(have) - 1; i >= 0; i-- {
+		if have[i].matchCompound(nearest) && matchStyleSheetSelectorParents(have[:i], want[:len(want)-1]) {
+			return true
 		}
 	}
-	return (!wClass || (wClass && mClass)) && (!wType || (wType && mType)) && (!wName || (wName && mName))
+	return false
 }
 
 var (
-	rxSelectorName    = regexp.MustCompile(`#([a-zA-Z][-_a-zA-Z0-9]+)`)
-	rxSelectorClass   = regexp.MustCompile(`\.([a-zA-Z][-_a-zA-Z0-9]+)`)
-	rxSelectorState   = regexp.MustCompile(`:([a-zA-Z][-_a-zA-Z0-9]+)`)
-	rxSelectorGroup   = regexp.MustCompile(`\s*,\s*`)
-	rxSelectorParents = regexp.MustCompile(`\s*[\s>]+\s*`)
-)
\ No newline at end of file
+	rxSelectorCompound   = regexp.MustCompile(`([#.:]?)([-_a-zA-Z0-9]+|\*)`)
+	rxSelectorGroup      = regexp.MustCompile(`\s*,\s*`)
+	rxSelectorCombinator = regexp.MustCompile(`\s*>\s*|\s+`)
+)
@@ -0,0 +1,148 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// StyleSheetError describes a problem encountered while parsing a style sheet,
+// including the file (if any), line and column where the problem was found.
+type StyleSheetError struct {
+	File   string
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *StyleSheetError) Error() string {
+	if e.File != "" {
+		return fmt.Sprintf("%v:%d:%d: %v", e.File, e.Line, e.Column, e.Err)
+	}
+	return fmt.Sprintf("%d:%d: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *StyleSheetError) Unwrap() error {
+	return e.Err
+}
+
+// newStyleSheetError returns a StyleSheetError for the given byte offset
+// within the source of the named file.
+func newStyleSheetError(file, source string, offset int, err error) *StyleSheetError {
+	if offset > len(source) {
+		offset = len(source)
+	}
+	line, column := 1, 1
+	for _, r := range source[:offset] {
+		if r == '\n' {
+			line += 1
+			column = 1
+		} else {
+			column += 1
+		}
+	}
+	return &StyleSheetError{
+		File:   file,
+		Line:   line,
+		Column: column,
+		Err:    err,
+	}
+}
+
+// cStyleSheetSource is one of the strings or files parsed into a style sheet,
+// kept in order so that the style sheet can be rebuilt when files change.
+type cStyleSheetSource struct {
+	css  string
+	fsys fs.FS
+	file string
+}
+
+// cStyleSheetFile tracks a file (including any @import files) parsed into a
+// style sheet along with the modification details at the time of parsing.
+type cStyleSheetFile struct {
+	fsys    fs.FS
+	file    string
+	modTime time.Time
+	size    int64
+}
+
+// modified returns TRUE if the file has changed since it was parsed
+func (f cStyleSheetFile) modified() bool {
+	info, err := statStyleSheetFile(f.fsys, f.file)
+	if err != nil {
+		return true
+	}
+	return !info.ModTime().Equal(f.modTime) || info.Size() != f.size
+}
+
+// readStyleSheetFile reads the named file from the given filesystem, or the
+// operating system when fsys is nil.
+func readStyleSheetFile(fsys fs.FS, name string) (data []byte, err error) {
+	if fsys == nil {
+		return os.ReadFile(name)
+	}
+	return fs.ReadFile(fsys, name)
+}
+
+// statStyleSheetFile returns the file information of the named file from the
+// given filesystem, or the operating system when fsys is nil.
+func statStyleSheetFile(fsys fs.FS, name string) (info fs.FileInfo, err error) {
+	if fsys == nil {
+		return os.Stat(name)
+	}
+	return fs.Stat(fsys, name)
+}
+
+// resolveStyleSheetImport returns the path of the named @import, relative to
+// the directory of the file doing the importing. Absolute import paths are
+// returned as-is for the operating system and relative to the root of fs.FS
+// filesystems.
+func resolveStyleSheetImport(fsys fs.FS, from, name string) string {
+	if fsys == nil {
+		if filepath.IsAbs(name) || from == "" {
+			return filepath.Clean(name)
+		}
+		return filepath.Join(filepath.Dir(from), name)
+	}
+	if strings.HasPrefix(name, "/") {
+		return path.Clean(strings.TrimPrefix(name, "/"))
+	}
+	if from == "" {
+		return path.Clean(name)
+	}
+	return path.Join(path.Dir(from), name)
+}
+
+// unquoteStyleSheetImport returns the file name given to an @import as either
+// a string or url() token.
+func unquoteStyleSheetImport(data string) (name string) {
+	name = strings.TrimSpace(data)
+	if strings.HasPrefix(strings.ToLower(name), "url(") && strings.HasSuffix(name, ")") {
+		name = strings.TrimSpace(name[4 : len(name)-1])
+	}
+	if len(name) >= 2 {
+		if first, _ := utf8.DecodeRuneInString(name); (first == '"' || first == '\'') && name[len(name)-1] == byte(first) {
+			name = name[1 : len(name)-1]
+		}
+	}
+	return
+}
@@ -0,0 +1,522 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-curses/cdk"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/go-curses/cdk/lib/ptypes"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+func TestStyleSheet(t *testing.T) {
+	Convey("Testing Style Sheets", t, func() {
+
+		Convey("Media Parsing", func() {
+			ss, err := newStyleSheetFromString(testStyleSheetMediaCSS)
+			So(err, ShouldBeNil)
+			So(ss.Rules, ShouldHaveLength, 2)
+			So(ss.MediaRules, ShouldHaveLength, 2)
+			So(ss.MediaRules[0].Conditions, ShouldEqual, "screen and (max-width: 80) and (max-height: 24)")
+			So(ss.MediaRules[0].Rules, ShouldHaveLength, 2)
+			So(strings.TrimSpace(ss.MediaRules[0].Rules[1].Selector), ShouldEqual, "#status")
+			So(ss.MediaRules[1].Conditions, ShouldEqual, "(monochrome), (prefers-reduced-motion: reduce)")
+			So(strings.TrimSpace(ss.Rules[1].Selector), ShouldEqual, "ctk-label")
+		})
+
+		Convey("Media Features", func() {
+			small := StyleSheetMediaFeatures{Width: 80, Height: 24, Colors: 16}
+			large := StyleSheetMediaFeatures{Width: 200, Height: 60, Colors: StyleSheetMediaTrueColors}
+			mono := StyleSheetMediaFeatures{Width: 80, Height: 24, Colors: 0, ReducedMotion: true}
+			match := func(conditions string, features StyleSheetMediaFeatures) bool {
+				return StyleSheetMedia{Conditions: conditions}.Match(features)
+			}
+			So(match("all", small), ShouldEqual, true)
+			So(match("print", small), ShouldEqual, false)
+			So(match("not print", small), ShouldEqual, true)
+			So(match("(min-width: 100)", small), ShouldEqual, false)
+			So(match("(min-width: 100)", large), ShouldEqual, true)
+			So(match("only screen and (max-width: 80ch) and (max-height: 24)", small), ShouldEqual, true)
+			So(match("(width: 80) and (height: 25)", small), ShouldEqual, false)
+			So(match("(color)", small), ShouldEqual, true)
+			So(match("(monochrome)", small), ShouldEqual, false)
+			So(match("(monochrome)", mono), ShouldEqual, true)
+			So(match("(min-colors: 256)", small), ShouldEqual, false)
+			So(match("(min-colors: 256)", large), ShouldEqual, true)
+			So(match("(colors: truecolor)", large), ShouldEqual, true)
+			So(match("(max-colors: 16)", small), ShouldEqual, true)
+			So(match("(prefers-reduced-motion: reduce)", small), ShouldEqual, false)
+			So(match("(prefers-reduced-motion: no-preference)", small), ShouldEqual, true)
+			So(match("(prefers-reduced-motion)", mono), ShouldEqual, true)
+			So(match("(min-width: 100), (monochrome)", mono), ShouldEqual, true)
+			So(match("(unknown-feature)", large), ShouldEqual, false)
+			duo := StyleSheetMediaFeatures{Width: 80, Height: 24, Colors: 2}
+			So(match("(monochrome)", duo), ShouldEqual, true)
+			So(match("(color)", duo), ShouldEqual, false)
+			So(match("(colors: monochrome)", duo), ShouldEqual, true)
+			So(match("(colors: monochrome)", mono), ShouldEqual, true)
+			So(match("(colors: monochrome)", small), ShouldEqual, false)
+			So(match("(max-colors: monochrome)", duo), ShouldEqual, true)
+			So(match("(min-colors: 16)", duo), ShouldEqual, false)
+		})
+
+		Convey("Selecting Media Properties", func() {
+			ss, err := newStyleSheetFromString(testStyleSheetMediaCSS)
+			So(err, ShouldBeNil)
+			path := "ctk-window > ctk-v-box > ctk-label#status"
+			So(ss.SetMediaFeatures(StyleSheetMediaFeatures{Width: 200, Height: 60, Colors: 256}), ShouldEqual, true)
+			So(ss.SetMediaFeatures(StyleSheetMediaFeatures{Width: 200, Height: 60, Colors: 256}), ShouldEqual, false)
+			props := ss.SelectProperties(path)
+			So(props["normal"]["bold"], ShouldBeNil)
+			So(props["normal"]["color"].Value, ShouldEqual, "white")
+			So(ss.SetMediaFeatures(StyleSheetMediaFeatures{Width: 80, Height: 24, Colors: 256}), ShouldEqual, true)
+			props = ss.SelectProperties(path)
+			So(props["normal"]["bold"].Value, ShouldEqual, "true")
+			So(props["normal"]["color"].Value, ShouldEqual, "yellow")
+		})
+
+		Convey("Window Resizing", func() {
+			window := NewWindow()
+			window.SetDecorated(false)
+			window.Show()
+			So(window.ImportStylesFromString(testStyleSheetMediaCSS), ShouldBeNil)
+			label := NewLabel("Status")
+			label.SetName("status")
+			label.Show()
+			window.GetVBox().PackStart(label, false, false, 0)
+			window.SetAllocation(ptypes.MakeRectangle(80, 24))
+			window.Resize()
+			bold, err := label.GetCssBool(CssPropertyBold, enums.StateNormal)
+			So(err, ShouldBeNil)
+			So(bold, ShouldEqual, true)
+			window.ProcessEvent(cdk.NewEventResize(120, 40))
+			So(window.GetAllocation().W, ShouldEqual, 120)
+			bold, _ = label.GetCssBool(CssPropertyBold, enums.StateNormal)
+			So(bold, ShouldEqual, false)
+		})
+
+		Convey("Selectors", func() {
+			sel := newStyleSheetSelectorFromPath("ctk-window > ctk-v-box ctk-button#save.primary.large:selected")
+			So(sel.Type, ShouldEqual, "ctk-button")
+			So(sel.Name, ShouldEqual, "save")
+			So(sel.Classes, ShouldResemble, []string{"primary", "large"})
+			So(sel.State, ShouldEqual, "selected")
+			So(sel.Parents, ShouldHaveLength, 2)
+			So(sel.HasClass("large"), ShouldEqual, true)
+			So(sel.Specificity(), ShouldEqual, StyleSheetSpecificity{1, 3, 3})
+			group := parseStyleSheetSelectorGroup("#save, .primary.large, ctk-button")
+			So(group, ShouldHaveLength, 3)
+			So(group[2].Specificity().Less(group[1].Specificity()), ShouldEqual, true)
+			So(group[1].Specificity().Less(group[0].Specificity()), ShouldEqual, true)
+
+			path := newStyleSheetSelectorFromPath("ctk-window > ctk-v-box > ctk-h-box > ctk-button#save.primary")
+			match := func(rule string) bool {
+				return path.Match(newStyleSheetSelectorFromPath(rule))
+			}
+			So(match("ctk-button"), ShouldEqual, true)
+			So(match(".primary"), ShouldEqual, true)
+			So(match(".primary.large"), ShouldEqual, false)
+			So(match("ctk-window ctk-button"), ShouldEqual, true)
+			So(match("ctk-window > ctk-button"), ShouldEqual, false)
+			So(match("ctk-h-box > ctk-button"), ShouldEqual, true)
+			So(match("ctk-window > ctk-v-box ctk-button"), ShouldEqual, true)
+			So(match("ctk-v-box > ctk-h-box > #save"), ShouldEqual, true)
+			So(match("ctk-v-box > #save"), ShouldEqual, false)
+			So(match("* > ctk-button"), ShouldEqual, true)
+		})
+
+		Convey("Cascade", func() {
+			ss, err := newStyleSheetFromString(testStyleSheetCascadeCSS)
+			So(err, ShouldBeNil)
+			props := ss.SelectProperties("ctk-window > ctk-v-box > ctk-button#save")
+			So(props["normal"]["color"].Value, ShouldEqual, "green")
+			So(props["normal"]["bold"].Value, ShouldEqual, "true")
+			So(props["normal"]["bold"].Important, ShouldEqual, true)
+			props = ss.SelectProperties("ctk-window > ctk-v-box > ctk-button#save.primary.large")
+			So(props["normal"]["color"].Value, ShouldEqual, "green")
+			So(props["normal"]["background-color"].Value, ShouldEqual, "blue")
+			props = ss.SelectProperties("ctk-window > ctk-v-box > ctk-button.primary.large")
+			So(props["normal"]["color"].Value, ShouldEqual, "red")
+			props = ss.SelectProperties("ctk-window > ctk-frame > ctk-v-box > ctk-button")
+			So(props["normal"]["color"].Value, ShouldEqual, "white")
+			So(props["normal"]["underline"], ShouldBeNil)
+			props = ss.SelectProperties("ctk-window > ctk-v-box > ctk-button")
+			So(props["normal"]["underline"].Value, ShouldEqual, "true")
+		})
+
+		Convey("Style Classes", func() {
+			window := NewWindow()
+			window.SetDecorated(false)
+			window.Show()
+			So(window.ImportStylesFromString(testStyleSheetCascadeCSS), ShouldBeNil)
+			button := NewButtonWithLabel("Save")
+			button.Show()
+			window.GetVBox().PackStart(button, false, false, 0)
+			So(button.CssFullPath(), ShouldEndWith, "ctk-button")
+			So(button.HasStyleClass("primary"), ShouldEqual, false)
+			button.AddStyleClass("primary")
+			button.AddStyleClass("large")
+			button.AddStyleClass("primary")
+			So(button.ListStyleClasses(), ShouldResemble, []string{"primary", "large"})
+			So(button.CssFullPath(), ShouldEndWith, "ctk-button.primary.large")
+			bg, err := button.GetCssColor(CssPropertyBackgroundColor, enums.StateNormal)
+			So(err, ShouldBeNil)
+			So(bg, ShouldEqual, paint.ColorBlue)
+			button.RemoveStyleClass("large")
+			So(button.HasStyleClass("large"), ShouldEqual, false)
+			bg, _ = button.GetCssColor(CssPropertyBackgroundColor, enums.StateNormal)
+			So(bg, ShouldNotEqual, paint.ColorBlue)
+		})
+
+		Convey("Themes Set In Code", func() {
+			window := NewWindow()
+			window.SetDecorated(false)
+			window.Show()
+			label := NewLabel("themed")
+			label.Show()
+			window.GetVBox().PackStart(label, false, false, 0)
+			theme := label.GetTheme()
+			theme.Content.Normal = theme.Content.Normal.Foreground(paint.ColorRed)
+			label.SetTheme(theme)
+			So(window.ImportStylesFromString("#other { color: blue; }"), ShouldBeNil)
+			window.ApplyStylesTo(label)
+			fg, err := label.GetCssColor(CssPropertyColor, enums.StateNormal)
+			So(err, ShouldBeNil)
+			So(fg, ShouldEqual, paint.ColorRed)
+			label.AddStyleClass("warning")
+			So(window.ImportStylesFromString("ctk-label.warning { color: yellow; }"), ShouldBeNil)
+			window.ApplyStylesTo(label)
+			fg, _ = label.GetCssColor(CssPropertyColor, enums.StateNormal)
+			So(fg, ShouldEqual, paint.ColorYellow)
+			label.RemoveStyleClass("warning")
+			fg, _ = label.GetCssColor(CssPropertyColor, enums.StateNormal)
+			So(fg, ShouldNotEqual, paint.ColorYellow)
+
+			label.AddStyleClass("warning")
+			ss := window.(*CWindow).getStyleSheet()
+			So(ss.applied, ShouldContainKey, label.ObjectID())
+			label.Destroy()
+			So(ss.applied, ShouldNotContainKey, label.ObjectID())
+		})
+
+		Convey("Importing Files", func() {
+			dir, err := os.MkdirTemp("", "ctk-style-sheet-")
+			So(err, ShouldBeNil)
+			defer func() { _ = os.RemoveAll(dir) }()
+			writeFile := func(name, content string) {
+				So(os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755), ShouldBeNil)
+				So(os.WriteFile(filepath.Join(dir, name), []byte(content), 0644), ShouldBeNil)
+			}
+			writeFile("theme.css", "@import \"parts/label.css\";\nctk-button { bold: true; }\n")
+			writeFile("parts/label.css", "@import url(\"../base.css\");\nctk-label { color: yellow; }\n")
+			writeFile("base.css", "ctk-label { color: white; underline: true; }\n")
+			ss := newStyleSheet()
+			So(ss.ParseFile(filepath.Join(dir, "theme.css")), ShouldBeNil)
+			So(ss.Rules, ShouldHaveLength, 3)
+			So(strings.TrimSpace(ss.Rules[2].Selector), ShouldEqual, "ctk-button")
+			So(ss.files, ShouldHaveLength, 3)
+			props := ss.SelectProperties("ctk-window > ctk-label")
+			So(props["normal"]["color"].Value, ShouldEqual, "yellow")
+			So(props["normal"]["underline"].Value, ShouldEqual, "true")
+			So(ss.Modified(), ShouldEqual, false)
+
+			writeFile("loop.css", "@import \"theme.css\";\n")
+			writeFile("theme.css", "@import \"loop.css\";\n")
+			err = newStyleSheet().ParseFile(filepath.Join(dir, "theme.css"))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "circular @import")
+			So(ss.Modified(), ShouldEqual, true)
+
+			err = newStyleSheet().ParseFile(filepath.Join(dir, "missing.css"))
+			So(err, ShouldNotBeNil)
+			writeFile("broken.css", "@import \"missing.css\";\n")
+			err = newStyleSheet().ParseFile(filepath.Join(dir, "broken.css"))
+			So(err, ShouldHaveSameTypeAs, &StyleSheetError{})
+			So(err.(*StyleSheetError).File, ShouldEqual, filepath.Join(dir, "broken.css"))
+			So(err.(*StyleSheetError).Line, ShouldEqual, 1)
+			So(err.(*StyleSheetError).Column, ShouldEqual, 9)
+		})
+
+		Convey("Importing From FS", func() {
+			fsys := fstest.MapFS{
+				"styles/theme.css":  {Data: []byte("@import \"colors.css\";\n@import \"/common.css\";\n")},
+				"styles/colors.css": {Data: []byte("ctk-label { color: green; }\n")},
+				"common.css":        {Data: []byte("ctk-label { bold: true; }\n")},
+			}
+			ss := newStyleSheet()
+			So(ss.ParseFS(fsys, "styles/theme.css"), ShouldBeNil)
+			So(ss.Rules, ShouldHaveLength, 2)
+			props := ss.SelectProperties("ctk-label")
+			So(props["normal"]["color"].Value, ShouldEqual, "green")
+			So(props["normal"]["bold"].Value, ShouldEqual, "true")
+		})
+
+		Convey("Parse Errors", func() {
+			_, err := newStyleSheetFromString("ctk-label {\n\tcolor: white;\n}\nctk-button {\n\tcolor: (5);\n}\n")
+			So(err, ShouldNotBeNil)
+			ssErr, ok := err.(*StyleSheetError)
+			So(ok, ShouldEqual, true)
+			So(ssErr.File, ShouldEqual, "")
+			So(ssErr.Line, ShouldEqual, 5)
+			So(ssErr.Column, ShouldEqual, 9)
+			So(err.Error(), ShouldStartWith, "5:9: ")
+			fsys := fstest.MapFS{
+				"theme.css": {Data: []byte("@import \"bad.css\";\n")},
+				"bad.css":   {Data: []byte("\n\nctk-label { color: (5); }\n")},
+			}
+			err = newStyleSheet().ParseFS(fsys, "theme.css")
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldStartWith, "bad.css:3:20: ")
+		})
+
+		Convey("Replacing Styles", func() {
+			window := NewWindow()
+			window.SetDecorated(false)
+			window.Show()
+			label := NewLabel("replaced")
+			label.Show()
+			window.GetVBox().PackStart(label, false, false, 0)
+			So(window.ReplaceStylesFromString("ctk-label { color: yellow; }"), ShouldBeNil)
+			So(window.ExportStylesToString(), ShouldContainSubstring, "yellow")
+			fg, err := label.GetCssColor(CssPropertyColor, enums.StateNormal)
+			So(err, ShouldBeNil)
+			So(fg, ShouldEqual, paint.ColorYellow)
+			So(window.ReplaceStylesFromString("ctk-button { color: blue; }"), ShouldBeNil)
+			fg, _ = label.GetCssColor(CssPropertyColor, enums.StateNormal)
+			So(fg, ShouldNotEqual, paint.ColorYellow)
+			So(window.ReplaceStylesFromString("ctk-label { color: (5); }"), ShouldNotBeNil)
+			So(window.ExportStylesToString(), ShouldContainSubstring, "ctk-button")
+		})
+
+		Convey("Reloading Styles", func() {
+			dir, err := os.MkdirTemp("", "ctk-style-sheet-")
+			So(err, ShouldBeNil)
+			defer func() { _ = os.RemoveAll(dir) }()
+			file := filepath.Join(dir, "theme.css")
+			So(os.WriteFile(file, []byte("#status { bold: true; }\n"), 0644), ShouldBeNil)
+			window := NewWindow()
+			window.SetDecorated(false)
+			window.Show()
+			So(window.ImportStylesFromString("ctk-label { italic: true; }"), ShouldBeNil)
+			So(window.ImportStylesFromFile(file), ShouldBeNil)
+			label := NewLabel("Status")
+			label.SetName("status")
+			label.Show()
+			window.GetVBox().PackStart(label, false, false, 0)
+			bold, _ := label.GetCssBool(CssPropertyBold, enums.StateNormal)
+			So(bold, ShouldEqual, true)
+
+			So(os.WriteFile(file, []byte("#status {\n\tunderline: true;\n}\n"), 0644), ShouldBeNil)
+			cw := window.(*CWindow)
+			So(cw.styleSheet.Modified(), ShouldEqual, true)
+			So(window.ReloadStyles(), ShouldBeNil)
+			So(cw.styleSheet.Modified(), ShouldEqual, false)
+			bold, _ = label.GetCssBool(CssPropertyBold, enums.StateNormal)
+			So(bold, ShouldEqual, false)
+			underline, _ := label.GetCssBool(CssPropertyUnderline, enums.StateNormal)
+			So(underline, ShouldEqual, true)
+			italic, _ := label.GetCssBool(CssPropertyItalic, enums.StateNormal)
+			So(italic, ShouldEqual, true)
+
+			So(os.WriteFile(file, []byte("#status { underline: (1); }\n"), 0644), ShouldBeNil)
+			So(window.ReloadStyles(), ShouldNotBeNil)
+			underline, _ = label.GetCssBool(CssPropertyUnderline, enums.StateNormal)
+			So(underline, ShouldEqual, true)
+		})
+
+		Convey("Custom Properties", func() {
+			lookup := func(name string) (string, bool) {
+				switch name {
+				case "--fg":
+					return "white", true
+				case "--alias":
+					return "var(--fg)", true
+				case "--loop":
+					return "var(--loop)", true
+				}
+				return "", false
+			}
+			resolve := func(value string) string {
+				resolved, ok := resolveStyleSheetVariables(value, lookup)
+				if !ok {
+					return "<unresolved>"
+				}
+				return resolved
+			}
+			So(resolve("red"), ShouldEqual, "red")
+			So(resolve("var(--fg)"), ShouldEqual, "white")
+			So(resolve("var(--alias)"), ShouldEqual, "white")
+			So(resolve("var(--missing,blue)"), ShouldEqual, "blue")
+			So(resolve("var(--missing,var(--fg))"), ShouldEqual, "white")
+			So(resolve("var(--missing)"), ShouldEqual, "<unresolved>")
+			So(resolve("var(--loop)"), ShouldEqual, "<unresolved>")
+			So(resolve("var(--missing, 1px solid red)"), ShouldEqual, "1px solid red")
+
+			ss, err := newStyleSheetFromString("ctk-label {\n\t--n: 10;\n\t--border: 1px  solid red;\n\tcolor: rgb(255, 0, 0);\n\tborder: var(--missing, 1px solid red) !important;\n}\n")
+			So(err, ShouldBeNil)
+			props := ss.SelectProperties("ctk-label")
+			So(props["normal"]["--n"].Value, ShouldEqual, "10")
+			So(props["normal"]["--border"].Value, ShouldEqual, "1px solid red")
+			So(props["normal"]["color"].Value, ShouldEqual, "rgb(255, 0, 0)")
+			So(props["normal"]["border"].Important, ShouldEqual, true)
+			So(resolve(props["normal"]["border"].Value), ShouldEqual, "1px solid red")
+
+			window := NewWindow()
+			window.SetDecorated(false)
+			window.Show()
+			So(window.ImportStylesFromString(testStyleSheetVariablesCSS), ShouldBeNil)
+			plain := NewLabel("Plain")
+			plain.Show()
+			other := NewLabel("Other")
+			other.SetName("other")
+			other.Show()
+			window.GetVBox().PackStart(plain, false, false, 0)
+			window.GetVBox().PackStart(other, false, false, 0)
+			fg, err := plain.GetCssColor(CssPropertyColor, enums.StateNormal)
+			So(err, ShouldBeNil)
+			So(fg, ShouldEqual, paint.ColorYellow)
+			bg, _ := plain.GetCssColor(CssPropertyBackgroundColor, enums.StateNormal)
+			So(bg, ShouldEqual, paint.ColorGreen)
+			fg, _ = other.GetCssColor(CssPropertyColor, enums.StateNormal)
+			So(fg, ShouldEqual, paint.ColorRed)
+			bold, _ := other.GetCssBool(CssPropertyBold, enums.StateNormal)
+			So(bold, ShouldEqual, false)
+		})
+
+		Convey("Palettes", func() {
+			settings := GetDefaultSettings()
+			defer func() {
+				settings.SetCtkColorPalette("")
+				settings.SetCtkColorScheme("")
+			}()
+			So(parseStyleSheetColorScheme("brand: red;accent:blue\nbad"), ShouldResemble, map[string]string{"brand": "red", "accent": "blue"})
+			ss, err := newStyleSheetFromString(testStyleSheetPaletteCSS)
+			So(err, ShouldBeNil)
+			So(ss.Palettes, ShouldHaveLength, 2)
+			So(ss.Palettes["dark"].Colors["accent"], ShouldEqual, "yellow")
+			So(ss.String(), ShouldContainSubstring, "@palette dark {\n\taccent: yellow;\n\tbrand: black;\n}")
+			_, err = newStyleSheetFromString("@palette { brand: red; }")
+			So(err, ShouldNotBeNil)
+
+			window := NewWindow()
+			window.SetDecorated(false)
+			window.Show()
+			So(window.ImportStylesFromString(testStyleSheetPaletteCSS), ShouldBeNil)
+			label := NewLabel("Brand")
+			label.Show()
+			window.GetVBox().PackStart(label, false, false, 0)
+			fg, _ := label.GetCssColor(CssPropertyColor, enums.StateNormal)
+			So(fg, ShouldEqual, paint.ColorNavy)
+			bg, _ := label.GetCssColor(CssPropertyBackgroundColor, enums.StateNormal)
+			So(bg, ShouldEqual, paint.ColorGreen)
+			settings.SetCtkColorPalette("dark")
+			window.ReApplyStyles()
+			fg, _ = label.GetCssColor(CssPropertyColor, enums.StateNormal)
+			So(fg, ShouldEqual, paint.ColorBlack)
+			bg, _ = label.GetCssColor(CssPropertyBackgroundColor, enums.StateNormal)
+			So(bg, ShouldEqual, paint.ColorYellow)
+			settings.SetCtkColorScheme("brand: red")
+			window.ReApplyStyles()
+			fg, _ = label.GetCssColor(CssPropertyColor, enums.StateNormal)
+			So(fg, ShouldEqual, paint.ColorRed)
+		})
+	})
+}
+
+const testStyleSheetMediaCSS = `
+ctk-label {
+	color: white;
+}
+@media screen and (max-width: 80) and (max-height: 24) {
+	ctk-label {
+		color: yellow;
+	}
+	#status {
+		bold: true;
+	}
+}
+@media (monochrome), (prefers-reduced-motion: reduce) {
+	ctk-label {
+		reverse: true;
+	}
+}
+ctk-label {
+	underline: false;
+}
+`
+
+const testStyleSheetCascadeCSS = `
+#save {
+	color: green;
+}
+ctk-button {
+	color: white;
+	bold: true !important;
+}
+.primary.large {
+	color: red;
+	background-color: blue;
+}
+ctk-window > ctk-button {
+	underline: false;
+}
+ctk-window > ctk-v-box > ctk-button {
+	underline: true;
+}
+ctk-button#save {
+	bold: false;
+}
+`
+
+const testStyleSheetVariablesCSS = `
+ctk-window {
+	--accent: white;
+	--fill: green;
+}
+ctk-v-box {
+	--accent: yellow;
+}
+ctk-label {
+	color: var(--accent);
+	background-color: var(--missing, var(--fill));
+}
+#other {
+	--accent: red;
+	bold: var(--undefined);
+}
+`
+
+const testStyleSheetPaletteCSS = `
+@palette default {
+	brand: navy;
+	accent: green;
+}
+@palette dark {
+	brand: black;
+	accent: yellow;
+}
+ctk-label {
+	color: @brand;
+	background-color: @accent;
+}
+`
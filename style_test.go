@@ -0,0 +1,167 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"testing"
+
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/go-curses/cdk/lib/ptypes"
+	"github.com/go-curses/cdk/memphis"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+func TestStyle(t *testing.T) {
+	Convey("Testing Styles", t, func() {
+		style := NewStyle()
+		So(style, ShouldNotBeNil)
+		none := ptypes.MakeRectangle(0, 0)
+
+		makeWidget := func(w, h int) Widget {
+			widget := NewLabel("")
+			widget.Show()
+			widget.SetOrigin(0, 0)
+			widget.SetAllocation(ptypes.MakeRectangle(w, h))
+			widget.Resize()
+			widget.Draw()
+			surface, err := memphis.GetSurface(widget.ObjectID())
+			So(err, ShouldBeNil)
+			for y := 0; y < h; y++ {
+				for x := 0; x < w; x++ {
+					_ = surface.SetRune(x, y, '.', paint.StyleDefault)
+				}
+			}
+			return widget
+		}
+		render := func(widget Widget) (lines []string) {
+			surface, err := memphis.GetSurface(widget.ObjectID())
+			So(err, ShouldBeNil)
+			size := surface.GetSize()
+			for y := 0; y < size.H; y++ {
+				line := ""
+				for x := 0; x < size.W; x++ {
+					line += string(surface.GetContent(x, y).Value())
+				}
+				lines = append(lines, line)
+			}
+			return
+		}
+		cell := func(widget Widget, x, y int) memphis.TextCell {
+			surface, _ := memphis.GetSurface(widget.ObjectID())
+			return surface.GetContent(x, y)
+		}
+
+		Convey("Boxes and Shadows", func() {
+			w := makeWidget(5, 3)
+			style.PaintBox(nil, enums.StateNormal, enums.SHADOW_IN, none, w, "", 0, 0, 5, 3)
+			So(render(w), ShouldResemble, []string{"┌───┐", "│   │", "└───┘"})
+
+			w = makeWidget(5, 3)
+			style.PaintShadow(nil, enums.StateNormal, enums.SHADOW_OUT, none, w, "", 0, 0, 5, 3)
+			So(render(w), ShouldResemble, []string{"┌───┐", "│...│", "└───┘"})
+
+			w = makeWidget(5, 3)
+			style.PaintShadow(nil, enums.StateNormal, enums.SHADOW_NONE, none, w, "", 0, 0, 5, 3)
+			So(render(w), ShouldResemble, []string{".....", ".....", "....."})
+
+			w = makeWidget(5, 3)
+			style.PaintFlatBox(nil, enums.StateNormal, enums.SHADOW_IN, none, w, "", 1, 1, 3, 1)
+			So(render(w), ShouldResemble, []string{".....", ".   .", "....."})
+
+			w = makeWidget(6, 3)
+			style.PaintBoxGap(nil, enums.StateNormal, enums.SHADOW_IN, none, w, "", 0, 0, 6, 3, enums.POS_TOP, 1, 2)
+			So(render(w), ShouldResemble, []string{"┌  ──┐", "│    │", "└────┘"})
+
+			w = makeWidget(5, 3)
+			style.PaintExtension(nil, enums.StateNormal, enums.SHADOW_IN, none, w, "", 0, 0, 5, 3, enums.POS_BOTTOM)
+			So(render(w), ShouldResemble, []string{"┌───┐", "│   │", "│   │"})
+
+			w = makeWidget(5, 3)
+			style.PaintFocus(nil, enums.StateNormal, none, w, "", 0, 0, 5, 3)
+			So(render(w), ShouldResemble, []string{"┌───┐", "│...│", "└───┘"})
+		})
+
+		Convey("States and Clipping", func() {
+			w := makeWidget(5, 3)
+			theme := w.GetTheme()
+			theme.Content.Prelight = paint.StyleDefault.Foreground(paint.ColorYellow)
+			theme.Border.Prelight = paint.StyleDefault.Foreground(paint.ColorRed)
+			w.SetTheme(theme)
+			style.PaintBox(nil, enums.StatePrelight, enums.SHADOW_ETCHED_IN, none, w, "", 0, 0, 5, 3)
+			So(cell(w, 1, 1).Style(), ShouldResemble, theme.Content.Prelight)
+			So(cell(w, 0, 0).Style(), ShouldResemble, theme.Border.Prelight.Dim(true))
+
+			w = makeWidget(5, 3)
+			style.PaintHandle(nil, enums.StateNormal, enums.SHADOW_IN, ptypes.MakeRectangle(2, 3), w, "", 0, 0, 5, 3, cenums.ORIENTATION_HORIZONTAL)
+			So(render(w), ShouldResemble, []string{".....", "──...", "....."})
+
+			w = makeWidget(3, 3)
+			style.PaintVLine(nil, enums.StateNormal, none, w, "", 2, 0, 1)
+			So(render(w), ShouldResemble, []string{".│.", ".│.", ".│."})
+
+			// painting without a widget or window is ignored
+			style.PaintBox(nil, enums.StateNormal, enums.SHADOW_IN, none, nil, "", 0, 0, 5, 3)
+		})
+
+		Convey("Indicators", func() {
+			w := makeWidget(3, 4)
+			style.PaintCheck(nil, enums.StateNormal, enums.SHADOW_IN, none, w, "", 0, 0, 3, 1)
+			style.PaintCheck(nil, enums.StateNormal, enums.SHADOW_ETCHED_IN, none, w, "", 0, 1, 3, 1)
+			style.PaintCheck(nil, enums.StateNormal, enums.SHADOW_OUT, none, w, "", 0, 2, 3, 1)
+			style.PaintOption(nil, enums.StateNormal, enums.SHADOW_IN, none, w, "", 0, 3, 3, 1)
+			So(render(w), ShouldResemble, []string{"[x]", "[-]", "[ ]", "(*)"})
+
+			w = makeWidget(3, 3)
+			style.PaintArrow(nil, enums.StateNormal, enums.SHADOW_NONE, none, w, "", enums.ArrowRight, true, 0, 0, 3, 3)
+			So(render(w), ShouldResemble, []string{"...", ".→.", "..."})
+
+			w = makeWidget(3, 1)
+			style.PaintDiamond(nil, enums.StateNormal, enums.SHADOW_IN, none, w, "", 0, 0, 1, 1)
+			style.PaintDiamond(nil, enums.StateNormal, enums.SHADOW_OUT, none, w, "", 1, 0, 1, 1)
+			style.PaintTab(nil, enums.StateNormal, enums.SHADOW_OUT, none, w, "", 2, 0, 1, 1)
+			So(render(w), ShouldResemble, []string{"◆◇▾"})
+
+			w = makeWidget(2, 1)
+			style.PaintExpander(nil, enums.StateNormal, none, w, "", 0, 0, enums.EXPANDER_COLLAPSED)
+			style.PaintExpander(nil, enums.StateNormal, none, w, "", 1, 0, enums.EXPANDER_EXPANDED)
+			So(render(w), ShouldResemble, []string{"▸▾"})
+
+			runes, _ := paint.GetSpinners(paint.SevenDotSpinner)
+			w = makeWidget(1, 1)
+			style.PaintSpinner(nil, enums.StateNormal, none, w, "", -1, 0, 0, 1, 1)
+			So(render(w), ShouldResemble, []string{string(runes[len(runes)-1])})
+		})
+
+		Convey("Sliders, Grips and Polygons", func() {
+			w := makeWidget(4, 2)
+			style.PaintSlider(nil, enums.StateNormal, enums.SHADOW_OUT, none, w, "", 1, 0, 2, 1, cenums.ORIENTATION_HORIZONTAL)
+			style.PaintResizeGrip(nil, enums.StateNormal, none, w, "", enums.WindowEdgeSouthEast, 0, 0, 4, 2)
+			So(render(w), ShouldResemble, []string{".██.", "...◢"})
+
+			w = makeWidget(5, 3)
+			points := []ptypes.Point2I{
+				ptypes.MakePoint2I(0, 0),
+				ptypes.MakePoint2I(4, 0),
+				ptypes.MakePoint2I(4, 2),
+				ptypes.MakePoint2I(0, 2),
+			}
+			style.PaintPolygon(nil, enums.StateNormal, enums.SHADOW_IN, none, w, "", points, len(points), true)
+			So(render(w), ShouldResemble, []string{"│───│", "│   │", "│────"})
+		})
+	})
+}
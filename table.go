@@ -0,0 +1,961 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	cmath "github.com/go-curses/cdk/lib/math"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/go-curses/cdk/lib/ptypes"
+	"github.com/go-curses/cdk/memphis"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeTable cdk.CTypeTag = "ctk-table"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeTable, func() interface{} { return MakeTable() })
+}
+
+// Table Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Table
+//
+// The Table Widget is a Container which arranges its children in rows and
+// columns, making it easy to line up the labels and fields of a form without
+// nesting boxes.
+//
+// Children are attached to the Table with Attach, giving the column and row
+// edges the child spans. Column 0 is the left edge of the first column and
+// row 0 is the top edge of the first row, so a child attached with left=0,
+// right=2, top=1 and bottom=2 spans the first two columns of the second row.
+// The Table grows as needed to fit the children attached.
+//
+// The AttachOptions of each child determine how it is allocated within the
+// cells it spans: EXPAND gives the child's rows or columns a share of any
+// extra space, SHRINK allows them to be made smaller than requested when there
+// is not enough space and FILL allocates the child all the space of its cells
+// rather than centering it within them.
+type Table interface {
+	Container
+	Buildable
+
+	Init() (already bool)
+	Build(builder Builder, element *CBuilderElement) error
+	GetSize() (rows, columns int)
+	SetSize(rows, columns int)
+	Attach(child Widget, leftAttach, rightAttach, topAttach, bottomAttach int, xOptions, yOptions enums.AttachOptions, xPadding, yPadding int)
+	AttachDefaults(child Widget, leftAttach, rightAttach, topAttach, bottomAttach int)
+	Add(child Widget)
+	Remove(child Widget)
+	QueryChildAttach(child Widget) (leftAttach, rightAttach, topAttach, bottomAttach int)
+	QueryChildOptions(child Widget) (xOptions, yOptions enums.AttachOptions, xPadding, yPadding int)
+	SetChildAttach(child Widget, leftAttach, rightAttach, topAttach, bottomAttach int)
+	SetChildOptions(child Widget, xOptions, yOptions enums.AttachOptions, xPadding, yPadding int)
+	GetRowSpacing(row int) (spacing int)
+	SetRowSpacing(row int, spacing int)
+	GetColSpacing(column int) (spacing int)
+	SetColSpacing(column int, spacing int)
+	GetDefaultRowSpacing() (spacing int)
+	SetRowSpacings(spacing int)
+	GetDefaultColSpacing() (spacing int)
+	SetColSpacings(spacing int)
+	GetHomogeneous() (value bool)
+	SetHomogeneous(homogeneous bool)
+	GetSizeRequest() (width, height int)
+}
+
+var _ Table = (*CTable)(nil)
+
+// The CTable structure implements the Table interface and is exported to
+// facilitate type embedding with custom implementations. No member variables
+// are exported as the interface methods are the only intended means of
+// interacting with Table objects.
+type CTable struct {
+	CContainer
+
+	rows    []*cTableRowCol
+	columns []*cTableRowCol
+}
+
+// The cTableRowCol is an internal structure used for tracking the spacing,
+// requisition and allocation of a single row or column of a Table.
+type cTableRowCol struct {
+	spacing     int
+	requisition int
+	allocation  int
+	expand      bool
+	shrink      bool
+}
+
+// The cTableChild is an internal structure used for tracking the attachment
+// of a single child of a Table during size negotiation.
+type cTableChild struct {
+	widget Widget
+	left   int
+	right  int
+	top    int
+	bottom int
+	xOpts  enums.AttachOptions
+	yOpts  enums.AttachOptions
+	xPad   int
+	yPad   int
+	width  int
+	height int
+}
+
+// MakeTable is used by the Buildable system to construct a new Table with a
+// single row and column, which grows as children are attached.
+func MakeTable() Table {
+	return NewTable(1, 1, false)
+}
+
+// NewTable is the constructor for new Table instances.
+//
+// Parameters:
+//
+//	rows	the number of rows the new table should have
+//	columns	the number of columns the new table should have
+//	homogeneous	if set to TRUE, all table cells are resized to the size of
+//	            the cell containing the largest widget
+func NewTable(rows, columns int, homogeneous bool) Table {
+	t := new(CTable)
+	t.Init()
+	t.SetSize(rows, columns)
+	t.SetHomogeneous(homogeneous)
+	return t
+}
+
+// Init initializes a Table object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the Table instance. Init is used in the
+// NewTable constructor and only necessary when implementing a derivative
+// Table type.
+func (t *CTable) Init() (already bool) {
+	if t.InitTypeItem(TypeTable, t) {
+		return true
+	}
+	t.CContainer.Init()
+	t.flags = enums.NULL_WIDGET_FLAG
+	t.SetFlags(enums.SENSITIVE | enums.PARENT_SENSITIVE | enums.APP_PAINTABLE)
+	t.rows = []*cTableRowCol{newTableRowCol(0)}
+	t.columns = []*cTableRowCol{newTableRowCol(0)}
+
+	_ = t.InstallBuildableProperty(PropertyNRows, cdk.IntProperty, true, 1)
+	_ = t.InstallBuildableProperty(PropertyNColumns, cdk.IntProperty, true, 1)
+	_ = t.InstallBuildableProperty(PropertyRowSpacing, cdk.IntProperty, true, 0)
+	_ = t.InstallBuildableProperty(PropertyColumnSpacing, cdk.IntProperty, true, 0)
+	_ = t.InstallBuildableProperty(PropertyHomogeneous, cdk.BoolProperty, true, false)
+	_ = t.InstallChildProperty(PropertyTableChildLeftAttach, cdk.IntProperty, true, 0)
+	_ = t.InstallChildProperty(PropertyTableChildRightAttach, cdk.IntProperty, true, 1)
+	_ = t.InstallChildProperty(PropertyTableChildTopAttach, cdk.IntProperty, true, 0)
+	_ = t.InstallChildProperty(PropertyTableChildBottomAttach, cdk.IntProperty, true, 1)
+	_ = t.InstallChildProperty(PropertyTableChildXOptions, cdk.StructProperty, true, enums.EXPAND|enums.FILL)
+	_ = t.InstallChildProperty(PropertyTableChildYOptions, cdk.StructProperty, true, enums.EXPAND|enums.FILL)
+	_ = t.InstallChildProperty(PropertyTableChildXPadding, cdk.IntProperty, true, 0)
+	_ = t.InstallChildProperty(PropertyTableChildYPadding, cdk.IntProperty, true, 0)
+
+	t.Connect(SignalResize, TableResizeHandle, t.resize)
+	t.Connect(SignalDraw, TableDrawHandle, t.draw)
+	return false
+}
+
+// Build provides customizations to the Buildable system for Table Widgets.
+// Each child is attached using the "left_attach", "right_attach",
+// "top_attach", "bottom_attach", "x_options", "y_options", "x_padding" and
+// "y_padding" packing properties. When the right or bottom attachments are
+// not given, the child spans a single column or row.
+func (t *CTable) Build(builder Builder, element *CBuilderElement) error {
+	t.Freeze()
+	defer t.Thaw()
+	if name, ok := element.Attributes["id"]; ok {
+		t.SetName(name)
+	}
+	rows, columns := t.GetSize()
+	for k, v := range element.Properties {
+		switch cdk.Property(strings.ReplaceAll(k, "_", "-")) {
+		case PropertyNRows:
+			if value, err := strconv.Atoi(v); err != nil {
+				t.LogErr(err)
+			} else {
+				rows = value
+			}
+		case PropertyNColumns:
+			if value, err := strconv.Atoi(v); err != nil {
+				t.LogErr(err)
+			} else {
+				columns = value
+			}
+		case PropertyRowSpacing:
+			if value, err := strconv.Atoi(v); err != nil {
+				t.LogErr(err)
+			} else {
+				t.SetRowSpacings(value)
+			}
+		case PropertyColumnSpacing:
+			if value, err := strconv.Atoi(v); err != nil {
+				t.LogErr(err)
+			} else {
+				t.SetColSpacings(value)
+			}
+		default:
+			element.ApplyProperty(k, v)
+		}
+	}
+	t.SetSize(rows, columns)
+	for _, child := range element.Children {
+		if newChild := builder.Build(child); newChild != nil {
+			child.Instance = newChild
+			newChildWidget, ok := newChild.(Widget)
+			if !ok {
+				t.LogError("new child object is not a Widget type: %v (%T)", newChild, newChild)
+				continue
+			}
+			left, right, top, bottom := 0, -1, 0, -1
+			xOptions, yOptions := enums.EXPAND|enums.FILL, enums.EXPAND|enums.FILL
+			xPadding, yPadding := 0, 0
+			for pk, pv := range child.Packing {
+				var err error
+				switch strings.ReplaceAll(pk, "-", "_") {
+				case "left_attach":
+					left, err = strconv.Atoi(pv)
+				case "right_attach":
+					right, err = strconv.Atoi(pv)
+				case "top_attach":
+					top, err = strconv.Atoi(pv)
+				case "bottom_attach":
+					bottom, err = strconv.Atoi(pv)
+				case "x_padding":
+					xPadding, err = strconv.Atoi(pv)
+				case "y_padding":
+					yPadding, err = strconv.Atoi(pv)
+				case "x_options":
+					var v interface{}
+					if v, err = enums.AttachOptions(0).FromString(pv); err == nil {
+						xOptions = v.(enums.AttachOptions)
+					}
+				case "y_options":
+					var v interface{}
+					if v, err = enums.AttachOptions(0).FromString(pv); err == nil {
+						yOptions = v.(enums.AttachOptions)
+					}
+				}
+				if err != nil {
+					t.LogErr(err)
+				}
+			}
+			if right <= left {
+				right = left + 1
+			}
+			if bottom <= top {
+				bottom = top + 1
+			}
+			newChildWidget.Show()
+			t.Attach(newChildWidget, left, right, top, bottom, xOptions, yOptions, xPadding, yPadding)
+		}
+	}
+	element.ApplySignals()
+	return nil
+}
+
+// GetSize returns the number of rows and columns in the Table.
+//
+// Locking: read
+func (t *CTable) GetSize() (rows, columns int) {
+	t.RLock()
+	defer t.RUnlock()
+	return len(t.rows), len(t.columns)
+}
+
+// SetSize changes the number of rows and columns in the Table. The Table is
+// never made smaller than needed by the children already attached.
+//
+// Parameters:
+//
+//	rows	the new number of rows
+//	columns	the new number of columns
+func (t *CTable) SetSize(rows, columns int) {
+	for _, child := range t.getTableChildren() {
+		rows = cmath.FloorI(rows, child.bottom)
+		columns = cmath.FloorI(columns, child.right)
+	}
+	rows = cmath.FloorI(rows, 1)
+	columns = cmath.FloorI(columns, 1)
+	rowSpacing, colSpacing := t.GetDefaultRowSpacing(), t.GetDefaultColSpacing()
+	t.Lock()
+	t.rows = resizeTableRowCols(t.rows, rows, rowSpacing)
+	t.columns = resizeTableRowCols(t.columns, columns, colSpacing)
+	t.Unlock()
+	if err := t.SetIntProperty(PropertyNRows, rows); err != nil {
+		t.LogErr(err)
+	}
+	if err := t.SetIntProperty(PropertyNColumns, columns); err != nil {
+		t.LogErr(err)
+	}
+	t.Resize()
+}
+
+// Attach adds a Widget to the Table. The number of cells that a Widget will
+// occupy is specified by the attachments, which are the column and row edges
+// the child spans. The Table grows to fit the attachments given.
+//
+// Parameters:
+//
+//	child	the Widget to add
+//	leftAttach	the column number to attach the left side of the child to
+//	rightAttach	the column number to attach the right side of the child to
+//	topAttach	the row number to attach the top of the child to
+//	bottomAttach	the row number to attach the bottom of the child to
+//	xOptions	used to specify the properties of the child when the table
+//	            is resized horizontally
+//	yOptions	used to specify the properties of the child when the table
+//	            is resized vertically
+//	xPadding	an integer value specifying the padding on the left and right
+//	            of the child
+//	yPadding	an integer value specifying the padding above and below the
+//	            child
+func (t *CTable) Attach(child Widget, leftAttach, rightAttach, topAttach, bottomAttach int, xOptions, yOptions enums.AttachOptions, xPadding, yPadding int) {
+	if leftAttach < 0 || rightAttach <= leftAttach || topAttach < 0 || bottomAttach <= topAttach {
+		t.LogError("invalid attachments given for %v: %d,%d %d,%d", child.ObjectName(), leftAttach, rightAttach, topAttach, bottomAttach)
+		return
+	}
+	if child.GetParent() != nil {
+		t.LogError("child already has a parent, ignoring: %v", child.ObjectName())
+		return
+	}
+	if rows, columns := t.GetSize(); rightAttach > columns || bottomAttach > rows {
+		t.SetSize(cmath.FloorI(rows, bottomAttach), cmath.FloorI(columns, rightAttach))
+	}
+	t.CContainer.AddWithProperties(child,
+		PropertyTableChildLeftAttach, leftAttach,
+		PropertyTableChildRightAttach, rightAttach,
+		PropertyTableChildTopAttach, topAttach,
+		PropertyTableChildBottomAttach, bottomAttach,
+		PropertyTableChildXOptions, xOptions,
+		PropertyTableChildYOptions, yOptions,
+		PropertyTableChildXPadding, xPadding,
+		PropertyTableChildYPadding, yPadding,
+	)
+	t.Resize()
+}
+
+// AttachDefaults is a convenience method for Attach, using EXPAND|FILL for
+// both the xOptions and yOptions and zero padding.
+func (t *CTable) AttachDefaults(child Widget, leftAttach, rightAttach, topAttach, bottomAttach int) {
+	t.Attach(child, leftAttach, rightAttach, topAttach, bottomAttach, enums.EXPAND|enums.FILL, enums.EXPAND|enums.FILL, 0, 0)
+}
+
+// Add attaches the given Widget to the first column of a new row at the bottom
+// of the Table, using the default AttachOptions.
+func (t *CTable) Add(child Widget) {
+	rows := 0
+	for _, c := range t.getTableChildren() {
+		rows = cmath.FloorI(rows, c.bottom)
+	}
+	t.AttachDefaults(child, 0, 1, rows, rows+1)
+}
+
+// Remove the given Widget from the Table.
+func (t *CTable) Remove(child Widget) {
+	t.CContainer.Remove(child)
+	t.Resize()
+}
+
+// QueryChildAttach returns the attachments of the given child.
+func (t *CTable) QueryChildAttach(child Widget) (leftAttach, rightAttach, topAttach, bottomAttach int) {
+	leftAttach = t.getChildInt(child, PropertyTableChildLeftAttach)
+	rightAttach = t.getChildInt(child, PropertyTableChildRightAttach)
+	topAttach = t.getChildInt(child, PropertyTableChildTopAttach)
+	bottomAttach = t.getChildInt(child, PropertyTableChildBottomAttach)
+	return
+}
+
+// QueryChildOptions returns the AttachOptions and padding of the given child.
+func (t *CTable) QueryChildOptions(child Widget) (xOptions, yOptions enums.AttachOptions, xPadding, yPadding int) {
+	if v, ok := t.GetChildProperty(child, PropertyTableChildXOptions).(enums.AttachOptions); ok {
+		xOptions = v
+	}
+	if v, ok := t.GetChildProperty(child, PropertyTableChildYOptions).(enums.AttachOptions); ok {
+		yOptions = v
+	}
+	xPadding = t.getChildInt(child, PropertyTableChildXPadding)
+	yPadding = t.getChildInt(child, PropertyTableChildYPadding)
+	return
+}
+
+// SetChildAttach moves the given child to the given attachments, growing the
+// Table as needed.
+func (t *CTable) SetChildAttach(child Widget, leftAttach, rightAttach, topAttach, bottomAttach int) {
+	if leftAttach < 0 || rightAttach <= leftAttach || topAttach < 0 || bottomAttach <= topAttach {
+		t.LogError("invalid attachments given for %v: %d,%d %d,%d", child.ObjectName(), leftAttach, rightAttach, topAttach, bottomAttach)
+		return
+	}
+	t.ChildSet(child,
+		PropertyTableChildLeftAttach, leftAttach,
+		PropertyTableChildRightAttach, rightAttach,
+		PropertyTableChildTopAttach, topAttach,
+		PropertyTableChildBottomAttach, bottomAttach,
+	)
+	rows, columns := t.GetSize()
+	t.SetSize(rows, columns)
+}
+
+// SetChildOptions updates the AttachOptions and padding of the given child.
+func (t *CTable) SetChildOptions(child Widget, xOptions, yOptions enums.AttachOptions, xPadding, yPadding int) {
+	t.ChildSet(child,
+		PropertyTableChildXOptions, xOptions,
+		PropertyTableChildYOptions, yOptions,
+		PropertyTableChildXPadding, xPadding,
+		PropertyTableChildYPadding, yPadding,
+	)
+	t.Resize()
+}
+
+// GetRowSpacing returns the amount of space between the given row and the row
+// following it.
+// See: SetRowSpacing()
+//
+// Locking: read
+func (t *CTable) GetRowSpacing(row int) (spacing int) {
+	t.RLock()
+	defer t.RUnlock()
+	if row >= 0 && row < len(t.rows) {
+		spacing = t.rows[row].spacing
+	}
+	return
+}
+
+// SetRowSpacing changes the space between the given row and the row
+// following it.
+//
+// Parameters:
+//
+//	row	row number whose spacing will be changed
+//	spacing	number of cells that the spacing should take up
+func (t *CTable) SetRowSpacing(row int, spacing int) {
+	t.Lock()
+	if row >= 0 && row < len(t.rows) {
+		t.rows[row].spacing = cmath.FloorI(spacing, 0)
+	}
+	t.Unlock()
+	t.Resize()
+}
+
+// GetColSpacing returns the amount of space between the given column and the
+// column following it.
+// See: SetColSpacing()
+//
+// Locking: read
+func (t *CTable) GetColSpacing(column int) (spacing int) {
+	t.RLock()
+	defer t.RUnlock()
+	if column >= 0 && column < len(t.columns) {
+		spacing = t.columns[column].spacing
+	}
+	return
+}
+
+// SetColSpacing changes the space between the given column and the column
+// following it.
+//
+// Parameters:
+//
+//	column	column number whose spacing will be changed
+//	spacing	number of cells that the spacing should take up
+func (t *CTable) SetColSpacing(column int, spacing int) {
+	t.Lock()
+	if column >= 0 && column < len(t.columns) {
+		t.columns[column].spacing = cmath.FloorI(spacing, 0)
+	}
+	t.Unlock()
+	t.Resize()
+}
+
+// GetDefaultRowSpacing returns the default row spacing for the Table, which is
+// the spacing given to new rows.
+// See: SetRowSpacings()
+//
+// Locking: read
+func (t *CTable) GetDefaultRowSpacing() (spacing int) {
+	var err error
+	if spacing, err = t.GetIntProperty(PropertyRowSpacing); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetRowSpacings sets the space between every row of the Table, and the
+// default spacing for any rows added later.
+//
+// Parameters:
+//
+//	spacing	the number of cells of space to place between every row
+func (t *CTable) SetRowSpacings(spacing int) {
+	spacing = cmath.FloorI(spacing, 0)
+	if err := t.SetIntProperty(PropertyRowSpacing, spacing); err != nil {
+		t.LogErr(err)
+	}
+	t.Lock()
+	for _, row := range t.rows {
+		row.spacing = spacing
+	}
+	t.Unlock()
+	t.Resize()
+}
+
+// GetDefaultColSpacing returns the default column spacing for the Table, which
+// is the spacing given to new columns.
+// See: SetColSpacings()
+//
+// Locking: read
+func (t *CTable) GetDefaultColSpacing() (spacing int) {
+	var err error
+	if spacing, err = t.GetIntProperty(PropertyColumnSpacing); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetColSpacings sets the space between every column of the Table, and the
+// default spacing for any columns added later.
+//
+// Parameters:
+//
+//	spacing	the number of cells of space to place between every column
+func (t *CTable) SetColSpacings(spacing int) {
+	spacing = cmath.FloorI(spacing, 0)
+	if err := t.SetIntProperty(PropertyColumnSpacing, spacing); err != nil {
+		t.LogErr(err)
+	}
+	t.Lock()
+	for _, column := range t.columns {
+		column.spacing = spacing
+	}
+	t.Unlock()
+	t.Resize()
+}
+
+// GetHomogeneous returns whether all cells of the Table are the same size.
+// See: SetHomogeneous()
+//
+// Locking: read
+func (t *CTable) GetHomogeneous() (value bool) {
+	var err error
+	if value, err = t.GetBoolProperty(PropertyHomogeneous); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetHomogeneous changes the homogeneous property of the Table, controlling
+// whether every cell is given the size of the largest cell.
+//
+// Parameters:
+//
+//	homogeneous	TRUE to make all cells the same size
+func (t *CTable) SetHomogeneous(homogeneous bool) {
+	if err := t.SetBoolProperty(PropertyHomogeneous, homogeneous); err != nil {
+		t.LogErr(err)
+	}
+	t.Resize()
+}
+
+// GetSizeRequest returns the requested size of the Table, which is the sum of
+// the row and column requisitions and the spacing between them.
+func (t *CTable) GetSizeRequest() (width, height int) {
+	rw, rh := t.CContainer.GetSizeRequest()
+	children := t.getTableChildren()
+	homogeneous := t.GetHomogeneous()
+	t.Lock()
+	defer t.Unlock()
+	tableRequest(t.columns, children, homogeneous, false)
+	tableRequest(t.rows, children, homogeneous, true)
+	if rw <= -1 {
+		rw = tableRequisition(t.columns)
+	}
+	if rh <= -1 {
+		rh = tableRequisition(t.rows)
+	}
+	return rw, rh
+}
+
+func newTableRowCol(spacing int) *cTableRowCol {
+	return &cTableRowCol{spacing: spacing, shrink: true}
+}
+
+// resizeTableRowCols returns the given rows or columns truncated or extended
+// to the given count, new entries using the given spacing.
+func resizeTableRowCols(list []*cTableRowCol, count, spacing int) []*cTableRowCol {
+	if count < len(list) {
+		return list[:count]
+	}
+	for len(list) < count {
+		list = append(list, newTableRowCol(spacing))
+	}
+	return list
+}
+
+func (t *CTable) getChildInt(child Widget, property cdk.Property) (value int) {
+	if v, ok := t.GetChildProperty(child, property).(int); ok {
+		value = v
+	}
+	return
+}
+
+// getTableChildren returns the attachment details of all children of the
+// Table, including their size requests.
+func (t *CTable) getTableChildren() (children []*cTableChild) {
+	for _, child := range t.GetChildren() {
+		tc := &cTableChild{widget: child}
+		tc.left, tc.right, tc.top, tc.bottom = t.QueryChildAttach(child)
+		tc.xOpts, tc.yOpts, tc.xPad, tc.yPad = t.QueryChildOptions(child)
+		tc.width, tc.height = GetGroupedSizeRequest(child)
+		tc.width, tc.height = cmath.FloorI(tc.width, 0), cmath.FloorI(tc.height, 0)
+		children = append(children, tc)
+	}
+	return
+}
+
+// span returns the start and end attachments, options, padding and requested
+// size of the child along either the rows or the columns.
+func (c *cTableChild) span(vertical bool) (start, end int, options enums.AttachOptions, padding, size int) {
+	if vertical {
+		return c.top, c.bottom, c.yOpts, c.yPad, c.height
+	}
+	return c.left, c.right, c.xOpts, c.xPad, c.width
+}
+
+// tableSpacing returns the spacing between the rows or columns from start to
+// end, excluding the spacing following the last.
+func tableSpacing(list []*cTableRowCol, start, end int) (spacing int) {
+	for i := start; i < end-1 && i < len(list); i++ {
+		spacing += list[i].spacing
+	}
+	return
+}
+
+// tableRequisition returns the total requisition of the rows or columns.
+func tableRequisition(list []*cTableRowCol) (size int) {
+	for _, rc := range list {
+		size += rc.requisition
+	}
+	return size + tableSpacing(list, 0, len(list))
+}
+
+// tableRequest works out the requisition, expand and shrink flags for each of
+// the rows or columns from the visible children attached to them. Children
+// spanning a single row or column are considered first and any children
+// spanning several have their extra space spread evenly across them.
+func tableRequest(list []*cTableRowCol, children []*cTableChild, homogeneous, vertical bool) {
+	for _, rc := range list {
+		rc.requisition = 0
+		rc.expand = false
+		rc.shrink = true
+	}
+	for _, child := range children {
+		if !child.widget.IsVisible() {
+			continue
+		}
+		start, end, options, padding, size := child.span(vertical)
+		if start+1 != end || end > len(list) {
+			continue
+		}
+		rc := list[start]
+		rc.requisition = cmath.FloorI(rc.requisition, size+padding*2)
+		if options.Has(enums.EXPAND) {
+			rc.expand = true
+		}
+		if !options.Has(enums.SHRINK) {
+			rc.shrink = false
+		}
+	}
+	for _, child := range children {
+		if !child.widget.IsVisible() {
+			continue
+		}
+		start, end, options, padding, size := child.span(vertical)
+		if start+1 == end || end > len(list) {
+			continue
+		}
+		hasExpand, allShrink := false, true
+		current := tableSpacing(list, start, end)
+		for i := start; i < end; i++ {
+			current += list[i].requisition
+			if list[i].expand {
+				hasExpand = true
+			}
+			if !list[i].shrink {
+				allShrink = false
+			}
+		}
+		for i := start; i < end; i++ {
+			if options.Has(enums.EXPAND) && !hasExpand {
+				list[i].expand = true
+			}
+			if !options.Has(enums.SHRINK) && allShrink {
+				list[i].shrink = false
+			}
+		}
+		if extra := size + padding*2 - current; extra > 0 {
+			for i := start; i < end; i++ {
+				share := extra / (end - i)
+				list[i].requisition += share
+				extra -= share
+			}
+		}
+	}
+	if homogeneous {
+		largest := 0
+		for _, rc := range list {
+			largest = cmath.FloorI(largest, rc.requisition)
+		}
+		for _, rc := range list {
+			rc.requisition = largest
+		}
+	}
+}
+
+// tableAllocate works out the allocation of each of the rows or columns
+// within the given space, giving any extra space to those which expand and
+// taking any missing space from those which shrink.
+func tableAllocate(list []*cTableRowCol, space int, homogeneous bool) {
+	nExpand, nShrink, total := 0, 0, 0
+	for _, rc := range list {
+		rc.allocation = rc.requisition
+		total += rc.allocation
+		if rc.expand {
+			nExpand += 1
+		}
+		if rc.shrink && rc.allocation > 0 {
+			nShrink += 1
+		}
+	}
+	available := cmath.FloorI(space-tableSpacing(list, 0, len(list)), 0)
+	if homogeneous {
+		if nExpand > 0 || total > available {
+			remaining := available
+			for i, rc := range list {
+				rc.allocation = remaining / (len(list) - i)
+				remaining -= rc.allocation
+			}
+		}
+		return
+	}
+	if total < available && nExpand > 0 {
+		extra := available - total
+		for _, rc := range list {
+			if rc.expand {
+				share := extra / nExpand
+				rc.allocation += share
+				extra -= share
+				nExpand -= 1
+			}
+		}
+	} else if total > available {
+		extra := total - available
+		for extra > 0 && nShrink > 0 {
+			count := nShrink
+			for _, rc := range list {
+				if !rc.shrink || rc.allocation <= 0 || extra <= 0 {
+					continue
+				}
+				share := cmath.FloorI(extra/count, 1)
+				if share > rc.allocation {
+					share = rc.allocation
+				}
+				rc.allocation -= share
+				extra -= share
+				count -= 1
+				if rc.allocation <= 0 {
+					nShrink -= 1
+				}
+			}
+		}
+	}
+}
+
+// tablePlace returns the offset and size of a child within the rows or
+// columns it spans, honouring its padding and FILL option.
+func tablePlace(list []*cTableRowCol, start, end int, options enums.AttachOptions, padding, size int) (offset, length int) {
+	for i := 0; i < start && i < len(list); i++ {
+		offset += list[i].allocation + list[i].spacing
+	}
+	spanned := tableSpacing(list, start, end)
+	for i := start; i < end && i < len(list); i++ {
+		spanned += list[i].allocation
+	}
+	inner := cmath.FloorI(spanned-padding*2, 0)
+	if options.Has(enums.FILL) {
+		return offset + padding, inner
+	}
+	length = size
+	if length > inner {
+		length = inner
+	}
+	return offset + padding + (inner-length)/2, length
+}
+
+func (t *CTable) resize(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	alloc := t.GetAllocation()
+	origin := t.GetOrigin()
+	children := t.getTableChildren()
+	homogeneous := t.GetHomogeneous()
+
+	if alloc.W <= 0 || alloc.H <= 0 {
+		for _, child := range children {
+			child.widget.SetAllocation(ptypes.MakeRectangle(0, 0))
+			child.widget.Resize()
+		}
+		return cenums.EVENT_PASS
+	}
+
+	t.Lock()
+	tableRequest(t.columns, children, homogeneous, false)
+	tableRequest(t.rows, children, homogeneous, true)
+	tableAllocate(t.columns, alloc.W, homogeneous)
+	tableAllocate(t.rows, alloc.H, homogeneous)
+	type placement struct {
+		x, y, w, h int
+	}
+	placements := make([]placement, len(children))
+	for idx, child := range children {
+		x, w := tablePlace(t.columns, child.left, child.right, child.xOpts, child.xPad, child.width)
+		y, h := tablePlace(t.rows, child.top, child.bottom, child.yOpts, child.yPad, child.height)
+		placements[idx] = placement{x, y, w, h}
+	}
+	t.Unlock()
+
+	for idx, child := range children {
+		if !child.widget.IsVisible() {
+			child.widget.SetAllocation(ptypes.MakeRectangle(0, 0))
+			child.widget.Resize()
+			continue
+		}
+		place := placements[idx]
+		child.widget.SetOrigin(origin.X+place.x, origin.Y+place.y)
+		child.widget.SetAllocation(ptypes.MakeRectangle(place.w, place.h))
+		child.widget.Resize()
+	}
+	t.Invalidate()
+	return cenums.EVENT_STOP
+}
+
+func (t *CTable) draw(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if surface, ok := argv[1].(*memphis.CSurface); ok {
+		alloc := t.GetAllocation()
+		if !t.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+			t.LogTrace("not visible, zero width or zero height")
+			return cenums.EVENT_PASS
+		}
+
+		theme := t.GetThemeRequest()
+		surface.Fill(theme)
+
+		for _, child := range t.GetChildren() {
+			if child.IsVisible() {
+				child.Draw()
+				child.LockDraw()
+				if err := surface.Composite(child.ObjectID()); err != nil {
+					t.LogError("composite error: %v", err)
+				}
+				child.UnlockDraw()
+			}
+		}
+
+		if debug, _ := t.GetBoolProperty(cdk.PropertyDebug); debug {
+			surface.DebugBox(paint.ColorSilver, t.ObjectInfo())
+		}
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+// The amount of space between two consecutive columns.
+// Flags: Read / Write
+// Default value: 0
+const PropertyColumnSpacing cdk.Property = "column-spacing"
+
+// If TRUE, the table cells are all the same width/height.
+// Flags: Read / Write
+// Default value: FALSE
+// const PropertyHomogeneous cdk.Property = "homogeneous"
+
+// The number of columns in the table.
+// Flags: Read / Write
+// Default value: 1
+const PropertyNColumns cdk.Property = "n-columns"
+
+// The number of rows in the table.
+// Flags: Read / Write
+// Default value: 1
+const PropertyNRows cdk.Property = "n-rows"
+
+// The amount of space between two consecutive rows.
+// Flags: Read / Write
+// Default value: 0
+const PropertyRowSpacing cdk.Property = "row-spacing"
+
+// The column number to attach the left side of the child to.
+// Flags: Read / Write
+// Default value: 0
+const PropertyTableChildLeftAttach cdk.Property = "table-child--left-attach"
+
+// The column number to attach the right side of the child to.
+// Flags: Read / Write
+// Default value: 1
+const PropertyTableChildRightAttach cdk.Property = "table-child--right-attach"
+
+// The row number to attach the top of the child to.
+// Flags: Read / Write
+// Default value: 0
+const PropertyTableChildTopAttach cdk.Property = "table-child--top-attach"
+
+// The row number to attach the bottom of the child to.
+// Flags: Read / Write
+// Default value: 1
+const PropertyTableChildBottomAttach cdk.Property = "table-child--bottom-attach"
+
+// Options specifying the horizontal behaviour of the child.
+// Flags: Read / Write
+// Default value: EXPAND | FILL
+const PropertyTableChildXOptions cdk.Property = "table-child--x-options"
+
+// Options specifying the vertical behaviour of the child.
+// Flags: Read / Write
+// Default value: EXPAND | FILL
+const PropertyTableChildYOptions cdk.Property = "table-child--y-options"
+
+// Extra space to put between the child and its left and right neighbors.
+// Flags: Read / Write
+// Default value: 0
+const PropertyTableChildXPadding cdk.Property = "table-child--x-padding"
+
+// Extra space to put between the child and its upper and lower neighbors.
+// Flags: Read / Write
+// Default value: 0
+const PropertyTableChildYPadding cdk.Property = "table-child--y-padding"
+
+const TableResizeHandle = "table-resize-handler"
+
+const TableDrawHandle = "table-draw-handler"
@@ -0,0 +1,225 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"testing"
+
+	"github.com/go-curses/cdk/lib/ptypes"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+func TestTable(t *testing.T) {
+	Convey("Testing Tables", t, func() {
+		makeWindow := func(w, h int, table Table) Window {
+			window := NewWindow()
+			window.SetDecorated(false)
+			window.Show()
+			window.SetAllocation(ptypes.MakeRectangle(w, h))
+			table.Show()
+			window.GetVBox().PackStart(table, true, true, 0)
+			window.Resize()
+			return window
+		}
+		makeLabel := func(text string) Label {
+			label := NewLabel(text)
+			label.Show()
+			return label
+		}
+
+		Convey("Basics", func() {
+			table := NewTable(1, 1, false)
+			So(table, ShouldNotBeNil)
+			rows, columns := table.GetSize()
+			So(rows, ShouldEqual, 1)
+			So(columns, ShouldEqual, 1)
+			child := makeLabel("child")
+			table.Attach(child, 2, 3, 1, 2, enums.FILL, enums.SHRINK, 1, 0)
+			rows, columns = table.GetSize()
+			So(rows, ShouldEqual, 2)
+			So(columns, ShouldEqual, 3)
+			left, right, top, bottom := table.QueryChildAttach(child)
+			So([]int{left, right, top, bottom}, ShouldResemble, []int{2, 3, 1, 2})
+			xOptions, yOptions, xPadding, yPadding := table.QueryChildOptions(child)
+			So(xOptions, ShouldEqual, enums.FILL)
+			So(yOptions, ShouldEqual, enums.SHRINK)
+			So(xPadding, ShouldEqual, 1)
+			So(yPadding, ShouldEqual, 0)
+			table.SetSize(1, 1)
+			rows, columns = table.GetSize()
+			So(rows, ShouldEqual, 2)
+			So(columns, ShouldEqual, 3)
+
+			table.SetColSpacings(2)
+			table.SetRowSpacing(0, 1)
+			So(table.GetDefaultColSpacing(), ShouldEqual, 2)
+			So(table.GetColSpacing(1), ShouldEqual, 2)
+			So(table.GetRowSpacing(0), ShouldEqual, 1)
+			So(table.GetRowSpacing(1), ShouldEqual, 0)
+			table.SetSize(2, 4)
+			So(table.GetColSpacing(3), ShouldEqual, 2)
+			w, h := table.GetSizeRequest()
+			So(w, ShouldEqual, 13)
+			So(h, ShouldEqual, 2)
+
+			table.Remove(child)
+			So(table.GetChildren(), ShouldHaveLength, 0)
+			table.SetSize(1, 1)
+			rows, columns = table.GetSize()
+			So(rows, ShouldEqual, 1)
+			So(columns, ShouldEqual, 1)
+			table.Add(child)
+			table.Add(makeLabel("next"))
+			rows, _ = table.GetSize()
+			So(rows, ShouldEqual, 2)
+			_, _, top, bottom = table.QueryChildAttach(table.GetChildren()[1])
+			So(top, ShouldEqual, 1)
+			So(bottom, ShouldEqual, 2)
+		})
+
+		Convey("Layout", func() {
+			table := NewTable(2, 2, false)
+			table.SetColSpacings(1)
+			name, desc := makeLabel("Name"), makeLabel("Description")
+			nameEntry, descEntry := NewEntry(""), NewEntry("")
+			for _, entry := range []Entry{nameEntry, descEntry} {
+				entry.Show()
+				entry.SetSizeRequest(6, 1)
+			}
+			table.Attach(name, 0, 1, 0, 1, 0, enums.FILL, 0, 0)
+			table.Attach(desc, 0, 1, 1, 2, enums.FILL, enums.FILL, 0, 0)
+			table.Attach(nameEntry, 1, 2, 0, 1, enums.EXPAND|enums.FILL, enums.FILL, 0, 0)
+			table.Attach(descEntry, 1, 2, 1, 2, enums.EXPAND|enums.FILL, enums.FILL, 0, 0)
+			w, h := table.GetSizeRequest()
+			So(w, ShouldEqual, 18)
+			So(h, ShouldEqual, 2)
+
+			makeWindow(20, 5, table)
+			So(name.GetOrigin().X, ShouldEqual, 3)
+			So(name.GetAllocation().W, ShouldEqual, 4)
+			So(desc.GetAllocation().W, ShouldEqual, 11)
+			So(desc.GetOrigin().Y, ShouldEqual, 1)
+			So(nameEntry.GetOrigin().X, ShouldEqual, 12)
+			So(descEntry.GetOrigin().X, ShouldEqual, 12)
+			So(nameEntry.GetAllocation().W, ShouldEqual, 8)
+			So(descEntry.GetOrigin().Y, ShouldEqual, 1)
+			So(descEntry.GetAllocation().H, ShouldEqual, 1)
+
+			table.SetChildOptions(descEntry, enums.EXPAND|enums.FILL, enums.EXPAND|enums.FILL, 1, 0)
+			So(descEntry.GetOrigin().X, ShouldEqual, 13)
+			So(descEntry.GetAllocation().W, ShouldEqual, 6)
+			So(descEntry.GetAllocation().H, ShouldEqual, 4)
+			So(nameEntry.GetAllocation().H, ShouldEqual, 1)
+		})
+
+		Convey("Spanning", func() {
+			table := NewTable(2, 2, false)
+			table.SetColSpacings(1)
+			left, right := makeLabel("left"), makeLabel("right!")
+			title := makeLabel("Personal Details")
+			table.Attach(title, 0, 2, 0, 1, enums.FILL, enums.FILL, 0, 0)
+			table.Attach(left, 0, 1, 1, 2, enums.FILL, enums.FILL, 0, 0)
+			table.Attach(right, 1, 2, 1, 2, enums.FILL, enums.FILL, 0, 0)
+			w, _ := table.GetSizeRequest()
+			So(w, ShouldEqual, 16)
+			makeWindow(16, 2, table)
+			So(title.GetAllocation().W, ShouldEqual, 16)
+			So(left.GetAllocation().W, ShouldEqual, 6)
+			So(right.GetOrigin().X, ShouldEqual, 7)
+			So(right.GetAllocation().W, ShouldEqual, 9)
+		})
+
+		Convey("Homogeneous", func() {
+			table := NewTable(1, 3, true)
+			So(table.GetHomogeneous(), ShouldEqual, true)
+			a, b, c := makeLabel("a"), makeLabel("bbb"), makeLabel("cc")
+			table.AttachDefaults(a, 0, 1, 0, 1)
+			table.AttachDefaults(b, 1, 2, 0, 1)
+			table.AttachDefaults(c, 2, 3, 0, 1)
+			w, _ := table.GetSizeRequest()
+			So(w, ShouldEqual, 9)
+			makeWindow(12, 1, table)
+			So(a.GetAllocation().W, ShouldEqual, 4)
+			So(b.GetOrigin().X, ShouldEqual, 4)
+			So(c.GetOrigin().X, ShouldEqual, 8)
+			So(c.GetAllocation().W, ShouldEqual, 4)
+		})
+
+		Convey("Shrink", func() {
+			table := NewTable(1, 2, false)
+			first, second := makeLabel("abcdef"), makeLabel("ghij")
+			table.Attach(first, 0, 1, 0, 1, enums.SHRINK|enums.FILL, enums.FILL, 0, 0)
+			table.Attach(second, 1, 2, 0, 1, enums.FILL, enums.FILL, 0, 0)
+			makeWindow(8, 1, table)
+			So(first.GetAllocation().W, ShouldEqual, 4)
+			So(second.GetOrigin().X, ShouldEqual, 4)
+			So(second.GetAllocation().W, ShouldEqual, 4)
+		})
+
+		Convey("Builder", func() {
+			builder := NewBuilder()
+			_, err := builder.LoadFromString(`<interface>
+  <object class="GtkTable" id="test-table">
+    <property name="n_rows">2</property>
+    <property name="n_columns">2</property>
+    <property name="column_spacing">1</property>
+    <child>
+      <object class="GtkLabel" id="test-table-name">
+        <property name="label">Name</property>
+      </object>
+      <packing>
+        <property name="x_options">GTK_FILL</property>
+        <property name="y_options"></property>
+      </packing>
+    </child>
+    <child>
+      <object class="GtkLabel" id="test-table-value">
+        <property name="label">Value</property>
+      </object>
+      <packing>
+        <property name="left_attach">1</property>
+        <property name="right_attach">2</property>
+        <property name="top_attach">1</property>
+        <property name="bottom_attach">2</property>
+        <property name="x_options">GTK_EXPAND | GTK_FILL</property>
+        <property name="x_padding">2</property>
+      </packing>
+    </child>
+  </object>
+</interface>`)
+			So(err, ShouldBeNil)
+			table, ok := builder.GetWidget("test-table").(Table)
+			So(ok, ShouldEqual, true)
+			rows, columns := table.GetSize()
+			So(rows, ShouldEqual, 2)
+			So(columns, ShouldEqual, 2)
+			So(table.GetColSpacing(0), ShouldEqual, 1)
+			So(table.GetChildren(), ShouldHaveLength, 2)
+			name, value := table.GetChildren()[0], table.GetChildren()[1]
+			left, right, top, bottom := table.QueryChildAttach(name)
+			So([]int{left, right, top, bottom}, ShouldResemble, []int{0, 1, 0, 1})
+			xOptions, yOptions, _, _ := table.QueryChildOptions(name)
+			So(xOptions, ShouldEqual, enums.FILL)
+			So(yOptions, ShouldEqual, enums.AttachOptions(0))
+			left, right, top, bottom = table.QueryChildAttach(value)
+			So([]int{left, right, top, bottom}, ShouldResemble, []int{1, 2, 1, 2})
+			xOptions, _, xPadding, _ := table.QueryChildOptions(value)
+			So(xOptions, ShouldEqual, enums.EXPAND|enums.FILL)
+			So(xPadding, ShouldEqual, 2)
+		})
+	})
+}
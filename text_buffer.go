@@ -0,0 +1,1356 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/gofrs/uuid"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeTextBuffer cdk.CTypeTag = "ctk-text-buffer"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeTextBuffer, func() interface{} { return MakeTextBuffer() })
+}
+
+// TextBuffer Hierarchy:
+//
+//	Object
+//	  +- TextBuffer
+//
+// A TextBuffer stores the text displayed by one or more TextView widgets,
+// along with the TextTag ranges applied to that text and the TextMark
+// positions preserved across modifications. Positions within the buffer are
+// given by TextIter instances, counted in runes from the start of the buffer.
+//
+// Changes to the buffer are made by emitting a signal first, ie: the
+// "insert-text" signal for Insert and the "delete-range" signal for Delete,
+// and are only applied when all the signal listeners return EVENT_PASS.
+type TextBuffer interface {
+	Object
+
+	GetTagTable() (table TextTagTable)
+	GetLineCount() (count int)
+	GetCharCount() (count int)
+	SetText(text string)
+	GetText(start, end *TextIter, includeHiddenChars bool) (text string)
+	GetSlice(start, end *TextIter, includeHiddenChars bool) (text string)
+	Insert(iter *TextIter, text string)
+	InsertAtCursor(text string)
+	InsertInteractive(iter *TextIter, text string, defaultEditable bool) (ok bool)
+	InsertInteractiveAtCursor(text string, defaultEditable bool) (ok bool)
+	InsertWithTags(iter *TextIter, text string, tags ...TextTag)
+	InsertWithTagsByName(iter *TextIter, text string, tagNames ...string)
+	Delete(start, end *TextIter)
+	DeleteInteractive(start, end *TextIter, defaultEditable bool) (ok bool)
+	Backspace(iter *TextIter, interactive, defaultEditable bool) (ok bool)
+	CreateMark(markName string, where *TextIter, leftGravity bool) (mark TextMark)
+	AddMark(mark TextMark, where *TextIter)
+	MoveMark(mark TextMark, where *TextIter)
+	MoveMarkByName(name string, where *TextIter)
+	DeleteMark(mark TextMark)
+	DeleteMarkByName(name string)
+	GetMark(name string) (mark TextMark)
+	GetInsert() (mark TextMark)
+	GetSelectionBound() (mark TextMark)
+	HasSelection() (hasSelection bool)
+	PlaceCursor(where *TextIter)
+	SelectRange(ins, bound *TextIter)
+	GetSelectionBounds() (start, end *TextIter, ok bool)
+	DeleteSelection(interactive, defaultEditable bool) (ok bool)
+	CreateTag(tagName string, style paint.Style) (tag TextTag)
+	ApplyTag(tag TextTag, start, end *TextIter)
+	RemoveTag(tag TextTag, start, end *TextIter)
+	ApplyTagByName(name string, start, end *TextIter)
+	RemoveTagByName(name string, start, end *TextIter)
+	RemoveAllTags(start, end *TextIter)
+	GetIterAtOffset(charOffset int) (iter *TextIter)
+	GetIterAtLine(lineNumber int) (iter *TextIter)
+	GetIterAtLineOffset(lineNumber, charOffset int) (iter *TextIter)
+	GetIterAtMark(mark TextMark) (iter *TextIter)
+	GetStartIter() (iter *TextIter)
+	GetEndIter() (iter *TextIter)
+	GetBounds() (start, end *TextIter)
+	GetModified() (modified bool)
+	SetModified(setting bool)
+	CutClipboard(clipboard cdk.Clipboard, defaultEditable bool)
+	CopyClipboard(clipboard cdk.Clipboard)
+	PasteClipboard(clipboard cdk.Clipboard, overrideLocation *TextIter, defaultEditable bool)
+}
+
+var _ TextBuffer = (*CTextBuffer)(nil)
+
+// textTagRange is a span of text, [start, end), with a tag applied
+type textTagRange struct {
+	tag   TextTag
+	start int
+	end   int
+}
+
+// The CTextBuffer structure implements the TextBuffer interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with TextBuffer objects.
+type CTextBuffer struct {
+	CObject
+
+	text        []rune
+	lineStarts  []int
+	table       TextTagTable
+	tableHandle string
+	ranges      []*textTagRange
+	marks       []*CTextMark
+	insert      *CTextMark
+	selection   *CTextMark
+}
+
+// MakeTextBuffer is used by the Buildable system to construct a new
+// TextBuffer.
+func MakeTextBuffer() TextBuffer {
+	return NewTextBuffer(nil)
+}
+
+// NewTextBuffer is the constructor for new, empty, TextBuffer instances.
+//
+// Parameters:
+//
+//	table	a tag table, or nil to create a new one
+func NewTextBuffer(table TextTagTable) TextBuffer {
+	b := new(CTextBuffer)
+	b.Init()
+	if table != nil {
+		b.setTagTable(table)
+	}
+	return b
+}
+
+// Init initializes a TextBuffer object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the TextBuffer instance. Init is used in the
+// NewTextBuffer constructor and only necessary when implementing a derivative
+// TextBuffer type.
+func (b *CTextBuffer) Init() (already bool) {
+	if b.InitTypeItem(TypeTextBuffer, b) {
+		return true
+	}
+	b.CObject.Init()
+	b.text = make([]rune, 0)
+	b.lineStarts = nil
+	b.ranges = make([]*textTagRange, 0)
+	b.marks = make([]*CTextMark, 0)
+	b.tableHandle = fmt.Sprintf("%v-%v", TextBufferTagTableHandle, b.ObjectID())
+	_ = b.InstallProperty(PropertyTagTable, cdk.StructProperty, true, nil)
+	_ = b.InstallProperty(PropertyModified, cdk.BoolProperty, true, false)
+	b.insert = b.newMark(TextBufferInsertMarkName, false)
+	b.insert.SetVisible(true)
+	b.selection = b.newMark(TextBufferSelectionBoundMarkName, false)
+	b.setTagTable(NewTextTagTable())
+	return false
+}
+
+// Build provides customizations to the Buildable system for TextBuffer
+// objects. The "tag-table" property is resolved by name to a previously built
+// TextTagTable and the "text" property sets the contents of the buffer.
+func (b *CTextBuffer) Build(builder Builder, element *CBuilderElement) error {
+	b.Freeze()
+	defer b.Thaw()
+	if name, ok := element.Attributes["id"]; ok {
+		b.SetName(name)
+	}
+	if v, ok := element.Properties[PropertyTagTable.String()]; ok {
+		if table, ok := builder.GetWidget(v).(TextTagTable); ok {
+			b.setTagTable(table)
+		} else {
+			b.LogError("tag-table not found or not a TextTagTable: %v", v)
+		}
+	}
+	for k, v := range element.Properties {
+		switch cdk.Property(k) {
+		case PropertyTagTable:
+		case PropertyText:
+			b.SetText(v)
+		default:
+			element.ApplyProperty(k, v)
+		}
+	}
+	element.ApplySignals()
+	return nil
+}
+
+// GetTagTable returns the TextTagTable associated with this buffer.
+func (b *CTextBuffer) GetTagTable() (table TextTagTable) {
+	b.RLock()
+	defer b.RUnlock()
+	return b.table
+}
+
+// GetLineCount returns the number of lines in the buffer. An empty buffer has
+// one line.
+func (b *CTextBuffer) GetLineCount() (count int) {
+	return len(b.getLineStarts())
+}
+
+// GetCharCount returns the number of characters in the buffer.
+func (b *CTextBuffer) GetCharCount() (count int) {
+	b.RLock()
+	defer b.RUnlock()
+	return len(b.text)
+}
+
+// SetText deletes the current contents of the buffer and inserts the text
+// given instead.
+//
+// Parameters:
+//
+//	text	text to insert
+func (b *CTextBuffer) SetText(text string) {
+	start, end := b.GetBounds()
+	b.Delete(start, end)
+	if text != "" {
+		b.Insert(b.GetStartIter(), text)
+	}
+}
+
+// GetText returns the text in the range [start, end). Excludes text hidden by
+// invisible tags if includeHiddenChars is FALSE.
+//
+// Parameters:
+//
+//	start	start of a range
+//	end	end of a range
+//	includeHiddenChars	whether to include invisible text
+func (b *CTextBuffer) GetText(start, end *TextIter, includeHiddenChars bool) (text string) {
+	return b.getText(start.offset, end.offset, includeHiddenChars)
+}
+
+// GetSlice is the same as GetText. TextBuffer contents have no embedded images
+// or child widgets to represent within the returned text.
+func (b *CTextBuffer) GetSlice(start, end *TextIter, includeHiddenChars bool) (text string) {
+	return b.GetText(start, end, includeHiddenChars)
+}
+
+// Insert the text at the position of the iterator. Emits the "insert-text"
+// signal and if all listeners return EVENT_PASS, the text is inserted and the
+// iterator is moved to the end of the inserted text.
+//
+// Parameters:
+//
+//	iter	a position in the buffer
+//	text	text to insert
+func (b *CTextBuffer) Insert(iter *TextIter, text string) {
+	if iter == nil || text == "" {
+		return
+	}
+	b.clampIter(iter)
+	if f := b.Emit(SignalInsertText, b, iter, text); f == cenums.EVENT_PASS {
+		runes := []rune(text)
+		b.insertRunes(iter.offset, runes)
+		iter.offset += len(runes)
+		b.changed()
+	}
+}
+
+// InsertAtCursor is a convenience method that calls Insert using the current
+// cursor position as the insertion point.
+//
+// Parameters:
+//
+//	text	text to insert
+func (b *CTextBuffer) InsertAtCursor(text string) {
+	b.Insert(b.GetIterAtMark(b.GetInsert()), text)
+}
+
+// InsertInteractive is like Insert, except the text is only inserted if the
+// position given is editable. Returns TRUE if the text was inserted.
+//
+// Parameters:
+//
+//	iter	a position in the buffer
+//	text	text to insert
+//	defaultEditable	default editability of the buffer
+func (b *CTextBuffer) InsertInteractive(iter *TextIter, text string, defaultEditable bool) (ok bool) {
+	if iter == nil || !iter.Editable(defaultEditable) {
+		return false
+	}
+	b.Insert(iter, text)
+	return true
+}
+
+// InsertInteractiveAtCursor calls InsertInteractive at the cursor position.
+//
+// Parameters:
+//
+//	text	text to insert
+//	defaultEditable	default editability of the buffer
+func (b *CTextBuffer) InsertInteractiveAtCursor(text string, defaultEditable bool) (ok bool) {
+	return b.InsertInteractive(b.GetIterAtMark(b.GetInsert()), text, defaultEditable)
+}
+
+// InsertWithTags inserts the text at the iterator and then applies the tags
+// given to the newly inserted text.
+//
+// Parameters:
+//
+//	iter	a position in the buffer
+//	text	text to insert
+//	tags	tags to apply to the inserted text
+func (b *CTextBuffer) InsertWithTags(iter *TextIter, text string, tags ...TextTag) {
+	if iter == nil || text == "" {
+		return
+	}
+	b.clampIter(iter)
+	start := iter.offset
+	b.Insert(iter, text)
+	startIter := newTextIter(b, start)
+	for _, tag := range tags {
+		b.ApplyTag(tag, startIter, iter)
+	}
+}
+
+// InsertWithTagsByName is the same as InsertWithTags, but the tags are looked
+// up by name within the TextTagTable of the buffer.
+//
+// Parameters:
+//
+//	iter	a position in the buffer
+//	text	text to insert
+//	tagNames	names of the tags to apply to the inserted text
+func (b *CTextBuffer) InsertWithTagsByName(iter *TextIter, text string, tagNames ...string) {
+	var tags []TextTag
+	for _, name := range tagNames {
+		if tag := b.GetTagTable().Lookup(name); tag != nil {
+			tags = append(tags, tag)
+		} else {
+			b.LogError("no tag named %q in the tag table", name)
+		}
+	}
+	b.InsertWithTags(iter, text, tags...)
+}
+
+// Delete the text in the range [start, end). Emits the "delete-range" signal
+// and if all listeners return EVENT_PASS, the text is removed and both
+// iterators are moved to the position where the text was deleted.
+//
+// Parameters:
+//
+//	start	a position in the buffer
+//	end	another position in the buffer
+func (b *CTextBuffer) Delete(start, end *TextIter) {
+	if start == nil || end == nil {
+		return
+	}
+	b.clampIter(start)
+	b.clampIter(end)
+	if start.offset > end.offset {
+		start, end = end, start
+	}
+	if start.offset == end.offset {
+		return
+	}
+	if f := b.Emit(SignalDeleteRange, b, start, end); f == cenums.EVENT_PASS {
+		b.deleteRunes(start.offset, end.offset)
+		end.offset = start.offset
+		b.changed()
+	}
+}
+
+// DeleteInteractive deletes the range [start, end) only if all of the text
+// within the range is editable. Returns TRUE if the text was deleted.
+//
+// Parameters:
+//
+//	start	a position in the buffer
+//	end	another position in the buffer
+//	defaultEditable	default editability of the buffer
+func (b *CTextBuffer) DeleteInteractive(start, end *TextIter, defaultEditable bool) (ok bool) {
+	if start == nil || end == nil {
+		return false
+	}
+	if start.offset > end.offset {
+		start, end = end, start
+	}
+	for offset := start.offset; offset < end.offset; offset++ {
+		if !newTextIter(b, offset).Editable(defaultEditable) {
+			return false
+		}
+	}
+	if start.offset == end.offset {
+		return false
+	}
+	b.Delete(start, end)
+	return true
+}
+
+// Backspace performs the appropriate action as if the user hit the delete key
+// with the cursor at the position specified by the iterator. If there is a
+// selection it is deleted instead. Returns TRUE if the buffer was modified.
+//
+// Parameters:
+//
+//	iter	a position in the buffer
+//	interactive	whether the deletion is caused by user interaction
+//	defaultEditable	whether the buffer is editable by default
+func (b *CTextBuffer) Backspace(iter *TextIter, interactive, defaultEditable bool) (ok bool) {
+	if iter == nil || iter.IsStart() {
+		return false
+	}
+	start := iter.Copy()
+	start.BackwardChar()
+	if interactive {
+		ok = b.DeleteInteractive(start, iter, defaultEditable)
+	} else {
+		b.Delete(start, iter)
+		ok = true
+	}
+	if ok {
+		iter.offset = start.offset
+	}
+	return
+}
+
+// CreateMark creates a mark at the position of the iterator given. If the name
+// is empty, the mark is anonymous, otherwise the mark can be retrieved by name
+// using GetMark. A mark with left gravity stays to the left of any text
+// inserted at its position. Emits the "mark-set" signal.
+//
+// Parameters:
+//
+//	markName	name for mark, or empty
+//	where	location to place mark
+//	leftGravity	whether the mark has left gravity
+func (b *CTextBuffer) CreateMark(markName string, where *TextIter, leftGravity bool) (mark TextMark) {
+	if markName != "" && b.GetMark(markName) != nil {
+		b.LogError("a mark named %q already exists", markName)
+		return nil
+	}
+	m := b.newMark(markName, leftGravity)
+	b.MoveMark(m, where)
+	return m
+}
+
+// AddMark adds the mark at the position given. The mark must not already be
+// added to a buffer and must not have the same name as an existing mark.
+// Emits the "mark-set" signal.
+//
+// Parameters:
+//
+//	mark	the mark to add
+//	where	location to place mark
+func (b *CTextBuffer) AddMark(mark TextMark, where *TextIter) {
+	m, ok := mark.Self().(*CTextMark)
+	if !ok {
+		b.LogError("mark does not embed CTextMark: %v (%T)", mark, mark)
+		return
+	}
+	if !m.GetDeleted() {
+		b.LogError("mark is already within a buffer: %v", mark.ObjectInfo())
+		return
+	}
+	if name := m.GetName(); name != "" && b.GetMark(name) != nil {
+		b.LogError("a mark named %q already exists", name)
+		return
+	}
+	m.setBuffer(b)
+	b.Lock()
+	b.marks = append(b.marks, m)
+	b.Unlock()
+	b.MoveMark(m, where)
+}
+
+// MoveMark moves the mark to the position given. Emits the "mark-set" signal.
+//
+// Parameters:
+//
+//	mark	a TextMark
+//	where	new location for mark
+func (b *CTextBuffer) MoveMark(mark TextMark, where *TextIter) {
+	if mark == nil || where == nil {
+		return
+	}
+	if m, ok := mark.Self().(*CTextMark); ok && !m.GetDeleted() {
+		b.clampIter(where)
+		m.setOffset(where.offset)
+		b.Emit(SignalMarkSet, b, where.Copy(), mark)
+	}
+}
+
+// MoveMarkByName moves the mark named to the position given.
+//
+// Parameters:
+//
+//	name	name of a mark
+//	where	new location for mark
+func (b *CTextBuffer) MoveMarkByName(name string, where *TextIter) {
+	if mark := b.GetMark(name); mark != nil {
+		b.MoveMark(mark, where)
+	} else {
+		b.LogError("no mark named %q", name)
+	}
+}
+
+// DeleteMark removes the mark from the buffer. The "insert" and
+// "selection_bound" marks cannot be deleted. Emits the "mark-deleted" signal.
+//
+// Parameters:
+//
+//	mark	a TextMark
+func (b *CTextBuffer) DeleteMark(mark TextMark) {
+	if mark == nil {
+		return
+	}
+	if mark.ObjectID() == b.insert.ObjectID() || mark.ObjectID() == b.selection.ObjectID() {
+		b.LogError("the insert and selection_bound marks cannot be deleted")
+		return
+	}
+	found := false
+	b.Lock()
+	for idx, m := range b.marks {
+		if m.ObjectID() == mark.ObjectID() {
+			b.marks = append(b.marks[:idx], b.marks[idx+1:]...)
+			found = true
+			break
+		}
+	}
+	b.Unlock()
+	if found {
+		if m, ok := mark.Self().(*CTextMark); ok {
+			m.setBuffer(nil)
+		}
+		b.Emit(SignalMarkDeleted, b, mark)
+	}
+}
+
+// DeleteMarkByName deletes the mark named.
+//
+// Parameters:
+//
+//	name	name of a mark in buffer
+func (b *CTextBuffer) DeleteMarkByName(name string) {
+	if mark := b.GetMark(name); mark != nil {
+		b.DeleteMark(mark)
+	} else {
+		b.LogError("no mark named %q", name)
+	}
+}
+
+// GetMark returns the mark named within the buffer, or nil if no such mark
+// exists.
+//
+// Parameters:
+//
+//	name	a mark name
+func (b *CTextBuffer) GetMark(name string) (mark TextMark) {
+	if name == "" {
+		return nil
+	}
+	b.RLock()
+	marks := append([]*CTextMark{}, b.marks...)
+	b.RUnlock()
+	for _, m := range marks {
+		if m.GetName() == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// GetInsert returns the mark that represents the cursor (insertion point).
+// Equivalent to calling GetMark to get the mark named "insert".
+func (b *CTextBuffer) GetInsert() (mark TextMark) {
+	b.RLock()
+	defer b.RUnlock()
+	return b.insert
+}
+
+// GetSelectionBound returns the mark that represents the selection bound.
+// Equivalent to calling GetMark to get the mark named "selection_bound". The
+// selection is the text between the "insert" and "selection_bound" marks.
+func (b *CTextBuffer) GetSelectionBound() (mark TextMark) {
+	b.RLock()
+	defer b.RUnlock()
+	return b.selection
+}
+
+// HasSelection returns TRUE if some text is selected.
+func (b *CTextBuffer) HasSelection() (hasSelection bool) {
+	return b.insert.getOffset() != b.selection.getOffset()
+}
+
+// PlaceCursor moves the "insert" and "selection_bound" marks simultaneously,
+// clearing any selection.
+//
+// Parameters:
+//
+//	where	where to put the cursor
+func (b *CTextBuffer) PlaceCursor(where *TextIter) {
+	b.SelectRange(where, where)
+}
+
+// SelectRange moves the "insert" and "selection_bound" marks simultaneously.
+//
+// Parameters:
+//
+//	ins	where to put the "insert" mark
+//	bound	where to put the "selection_bound" mark
+func (b *CTextBuffer) SelectRange(ins, bound *TextIter) {
+	if ins == nil || bound == nil {
+		return
+	}
+	b.clampIter(ins)
+	b.clampIter(bound)
+	b.insert.setOffset(ins.offset)
+	b.selection.setOffset(bound.offset)
+	b.Emit(SignalMarkSet, b, ins.Copy(), b.insert)
+	b.Emit(SignalMarkSet, b, bound.Copy(), b.selection)
+}
+
+// GetSelectionBounds returns the bounds of the selection, in ascending order,
+// and TRUE if the selection has nonzero length. If there is no selection, both
+// start and end are the position of the cursor.
+func (b *CTextBuffer) GetSelectionBounds() (start, end *TextIter, ok bool) {
+	s, e := b.insert.getOffset(), b.selection.getOffset()
+	if s > e {
+		s, e = e, s
+	}
+	return newTextIter(b, s), newTextIter(b, e), s != e
+}
+
+// DeleteSelection deletes the text within the selection. When interactive is
+// TRUE, only editable text is deleted. Returns TRUE if any text was deleted.
+//
+// Parameters:
+//
+//	interactive	whether the deletion is caused by user interaction
+//	defaultEditable	whether the buffer is editable by default
+func (b *CTextBuffer) DeleteSelection(interactive, defaultEditable bool) (ok bool) {
+	start, end, selected := b.GetSelectionBounds()
+	if !selected {
+		return false
+	}
+	if interactive {
+		return b.DeleteInteractive(start, end, defaultEditable)
+	}
+	b.Delete(start, end)
+	return true
+}
+
+// CreateTag creates a tag with the style given and adds it to the tag table
+// for the buffer. If the name is empty, the tag is anonymous. Returns nil if a
+// tag with the same name already exists.
+//
+// Parameters:
+//
+//	tagName	name of the new tag, or empty
+//	style	the paint.Style of the tag
+func (b *CTextBuffer) CreateTag(tagName string, style paint.Style) (tag TextTag) {
+	tag = NewTextTag(tagName)
+	tag.SetStyle(style)
+	if !b.GetTagTable().Add(tag) {
+		return nil
+	}
+	return
+}
+
+// ApplyTag emits the "apply-tag" signal and if all listeners return
+// EVENT_PASS, the tag is applied to the range [start, end). The tag must be
+// within the tag table of the buffer.
+//
+// Parameters:
+//
+//	tag	a TextTag
+//	start	one bound of range to be tagged
+//	end	other bound of range to be tagged
+func (b *CTextBuffer) ApplyTag(tag TextTag, start, end *TextIter) {
+	if !b.validTagRange(tag, start, end) {
+		return
+	}
+	if f := b.Emit(SignalApplyTag, b, tag, start, end); f == cenums.EVENT_PASS {
+		s, e := b.orderedOffsets(start, end)
+		b.Lock()
+		b.ranges = append(b.ranges, &textTagRange{tag: tag, start: s, end: e})
+		b.normalizeRanges()
+		b.Unlock()
+		b.Emit(SignalChanged, b)
+	}
+}
+
+// RemoveTag emits the "remove-tag" signal and if all listeners return
+// EVENT_PASS, the tag is removed from the range [start, end).
+//
+// Parameters:
+//
+//	tag	a TextTag
+//	start	one bound of range to be untagged
+//	end	other bound of range to be untagged
+func (b *CTextBuffer) RemoveTag(tag TextTag, start, end *TextIter) {
+	if !b.validTagRange(tag, start, end) {
+		return
+	}
+	if f := b.Emit(SignalRemoveTag, b, tag, start, end); f == cenums.EVENT_PASS {
+		s, e := b.orderedOffsets(start, end)
+		b.Lock()
+		b.removeRange(tag, s, e)
+		b.Unlock()
+		b.Emit(SignalChanged, b)
+	}
+}
+
+// ApplyTagByName calls ApplyTag with the tag named in the tag table.
+//
+// Parameters:
+//
+//	name	name of a named TextTag
+//	start	one bound of range to be tagged
+//	end	other bound of range to be tagged
+func (b *CTextBuffer) ApplyTagByName(name string, start, end *TextIter) {
+	if tag := b.GetTagTable().Lookup(name); tag != nil {
+		b.ApplyTag(tag, start, end)
+	} else {
+		b.LogError("no tag named %q in the tag table", name)
+	}
+}
+
+// RemoveTagByName calls RemoveTag with the tag named in the tag table.
+//
+// Parameters:
+//
+//	name	name of a TextTag
+//	start	one bound of range to be untagged
+//	end	other bound of range to be untagged
+func (b *CTextBuffer) RemoveTagByName(name string, start, end *TextIter) {
+	if tag := b.GetTagTable().Lookup(name); tag != nil {
+		b.RemoveTag(tag, start, end)
+	} else {
+		b.LogError("no tag named %q in the tag table", name)
+	}
+}
+
+// RemoveAllTags removes all tags from the range [start, end).
+//
+// Parameters:
+//
+//	start	one bound of range to be untagged
+//	end	other bound of range to be untagged
+func (b *CTextBuffer) RemoveAllTags(start, end *TextIter) {
+	b.GetTagTable().Foreach(func(tag TextTag) {
+		b.RemoveTag(tag, start, end)
+	})
+}
+
+// GetIterAtOffset returns an iterator at the character offset given, counting
+// from the start of the buffer. A negative offset is the end of the buffer.
+//
+// Parameters:
+//
+//	charOffset	char offset from start of buffer, counting from 0, or -1
+func (b *CTextBuffer) GetIterAtOffset(charOffset int) (iter *TextIter) {
+	return newTextIter(b, charOffset)
+}
+
+// GetIterAtLine returns an iterator at the start of the line given. If the
+// line is out of range, the iterator is at the start of the last line.
+//
+// Parameters:
+//
+//	lineNumber	line number counting from 0
+func (b *CTextBuffer) GetIterAtLine(lineNumber int) (iter *TextIter) {
+	return newTextIter(b, b.lineStart(lineNumber))
+}
+
+// GetIterAtLineOffset returns an iterator at the character offset within the
+// line given. The offset is clamped to the end of the line.
+//
+// Parameters:
+//
+//	lineNumber	line number counting from 0
+//	charOffset	char offset from start of line
+func (b *CTextBuffer) GetIterAtLineOffset(lineNumber, charOffset int) (iter *TextIter) {
+	iter = b.GetIterAtLine(lineNumber)
+	iter.SetLineOffset(charOffset)
+	return
+}
+
+// GetIterAtMark returns an iterator at the current position of the mark.
+//
+// Parameters:
+//
+//	mark	a TextMark in the buffer
+func (b *CTextBuffer) GetIterAtMark(mark TextMark) (iter *TextIter) {
+	if m, ok := mark.Self().(*CTextMark); ok {
+		return newTextIter(b, m.getOffset())
+	}
+	return b.GetEndIter()
+}
+
+// GetStartIter returns an iterator at the first position in the buffer.
+func (b *CTextBuffer) GetStartIter() (iter *TextIter) {
+	return newTextIter(b, 0)
+}
+
+// GetEndIter returns an iterator at the end of the buffer, one past the last
+// valid character.
+func (b *CTextBuffer) GetEndIter() (iter *TextIter) {
+	return newTextIter(b, -1)
+}
+
+// GetBounds returns the first and last iterators in the buffer, ie: the entire
+// buffer lies within the range [start, end).
+func (b *CTextBuffer) GetBounds() (start, end *TextIter) {
+	return b.GetStartIter(), b.GetEndIter()
+}
+
+// GetModified returns TRUE if the buffer has been modified since the last call
+// to SetModified with FALSE.
+func (b *CTextBuffer) GetModified() (modified bool) {
+	var err error
+	if modified, err = b.GetBoolProperty(PropertyModified); err != nil {
+		b.LogErr(err)
+	}
+	return
+}
+
+// SetModified updates the modification flag of the buffer. Used to keep track
+// of whether the buffer has been modified since it was last saved. Emits the
+// "modified-changed" signal when the flag changes.
+//
+// Parameters:
+//
+//	setting	modification flag setting
+func (b *CTextBuffer) SetModified(setting bool) {
+	if b.GetModified() == setting {
+		return
+	}
+	if err := b.SetBoolProperty(PropertyModified, setting); err != nil {
+		b.LogErr(err)
+	} else {
+		b.Emit(SignalModifiedChanged, b)
+	}
+}
+
+// CutClipboard copies the currently selected text to the clipboard given and
+// deletes it if editable.
+//
+// Parameters:
+//
+//	clipboard	the Clipboard object to cut to
+//	defaultEditable	default editability of the buffer
+func (b *CTextBuffer) CutClipboard(clipboard cdk.Clipboard, defaultEditable bool) {
+	if start, end, ok := b.GetSelectionBounds(); ok {
+		value := b.GetText(start, end, true)
+		if b.DeleteInteractive(start, end, defaultEditable) {
+			if clipboard != nil {
+				clipboard.Copy(value)
+			}
+			b.Emit(SignalCutClipboard, b, value)
+		}
+	}
+}
+
+// CopyClipboard copies the currently selected text to the clipboard given.
+//
+// Parameters:
+//
+//	clipboard	the Clipboard object to copy to
+func (b *CTextBuffer) CopyClipboard(clipboard cdk.Clipboard) {
+	if start, end, ok := b.GetSelectionBounds(); ok {
+		value := b.GetText(start, end, true)
+		if clipboard != nil {
+			clipboard.Copy(value)
+		}
+		b.Emit(SignalCopyClipboard, b, value)
+	}
+}
+
+// PasteClipboard pastes the contents of the clipboard given at the insertion
+// point, or at overrideLocation if not nil, replacing any selected text. The
+// cursor is moved to the end of the pasted text.
+//
+// Parameters:
+//
+//	clipboard	the Clipboard to paste from
+//	overrideLocation	location to insert pasted text, or nil
+//	defaultEditable	whether the buffer is editable by default
+func (b *CTextBuffer) PasteClipboard(clipboard cdk.Clipboard, overrideLocation *TextIter, defaultEditable bool) {
+	if clipboard == nil {
+		return
+	}
+	value := clipboard.GetText()
+	if value == "" {
+		return
+	}
+	var iter *TextIter
+	if overrideLocation != nil {
+		iter = overrideLocation.Copy()
+	} else {
+		if start, end, ok := b.GetSelectionBounds(); ok {
+			if !b.DeleteInteractive(start, end, defaultEditable) {
+				return
+			}
+		}
+		iter = b.GetIterAtMark(b.GetInsert())
+	}
+	if b.InsertInteractive(iter, value, defaultEditable) {
+		b.PlaceCursor(iter)
+		b.Emit(SignalPasteClipboard, b, value)
+	}
+}
+
+func (b *CTextBuffer) setTagTable(table TextTagTable) {
+	b.Lock()
+	previous := b.table
+	b.table = table
+	b.ranges = make([]*textTagRange, 0)
+	b.Unlock()
+	if previous != nil {
+		_ = previous.Disconnect(SignalTagRemoved, b.tableHandle)
+	}
+	table.Connect(SignalTagRemoved, b.tableHandle, b.tagRemoved)
+	if err := b.SetStructProperty(PropertyTagTable, table); err != nil {
+		b.LogErr(err)
+	}
+}
+
+func (b *CTextBuffer) tagRemoved(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if len(argv) > 1 {
+		if tag, ok := argv[1].(TextTag); ok {
+			b.Lock()
+			kept := make([]*textTagRange, 0, len(b.ranges))
+			for _, r := range b.ranges {
+				if r.tag.ObjectID() != tag.ObjectID() {
+					kept = append(kept, r)
+				}
+			}
+			b.ranges = kept
+			b.Unlock()
+			b.Emit(SignalChanged, b)
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (b *CTextBuffer) newMark(name string, leftGravity bool) *CTextMark {
+	m, _ := NewTextMark(name, leftGravity).Self().(*CTextMark)
+	m.setBuffer(b)
+	b.Lock()
+	b.marks = append(b.marks, m)
+	b.Unlock()
+	return m
+}
+
+func (b *CTextBuffer) changed() {
+	b.SetModified(true)
+	b.Emit(SignalChanged, b)
+}
+
+func (b *CTextBuffer) clampIter(iter *TextIter) {
+	if iter.buffer == nil {
+		iter.buffer = b
+	}
+	iter.SetOffset(iter.offset)
+}
+
+func (b *CTextBuffer) orderedOffsets(start, end *TextIter) (s, e int) {
+	b.clampIter(start)
+	b.clampIter(end)
+	s, e = start.offset, end.offset
+	if s > e {
+		s, e = e, s
+	}
+	return
+}
+
+func (b *CTextBuffer) validTagRange(tag TextTag, start, end *TextIter) bool {
+	if tag == nil || start == nil || end == nil {
+		return false
+	}
+	if table := tag.GetTagTable(); table == nil || table.ObjectID() != b.GetTagTable().ObjectID() {
+		b.LogError("tag is not within the tag table of the buffer: %v", tag.ObjectInfo())
+		return false
+	}
+	return start.offset != end.offset
+}
+
+// getRunes returns the current text of the buffer, the slice returned must
+// not be modified as it is replaced rather than updated by any changes
+func (b *CTextBuffer) getRunes() []rune {
+	b.RLock()
+	defer b.RUnlock()
+	return b.text
+}
+
+func (b *CTextBuffer) getText(start, end int, includeHidden bool) string {
+	text := b.getRunes()
+	if start > end {
+		start, end = end, start
+	}
+	if start < 0 {
+		start = 0
+	}
+	if end > len(text) || end < 0 {
+		end = len(text)
+	}
+	if start >= end {
+		return ""
+	}
+	if includeHidden {
+		return string(text[start:end])
+	}
+	hidden := b.hiddenMask(start, end)
+	visible := make([]rune, 0, end-start)
+	for offset := start; offset < end; offset++ {
+		if !hidden[offset-start] {
+			visible = append(visible, text[offset])
+		}
+	}
+	return string(visible)
+}
+
+// hiddenMask returns a flag for each character within [start, end) that is
+// TRUE if the character has an invisible tag applied
+func (b *CTextBuffer) hiddenMask(start, end int) (hidden []bool) {
+	hidden = make([]bool, end-start)
+	for _, r := range b.getRanges() {
+		if r.end <= start || r.start >= end || !r.tag.GetInvisible() {
+			continue
+		}
+		for offset := r.start; offset < r.end; offset++ {
+			if offset >= start && offset < end {
+				hidden[offset-start] = true
+			}
+		}
+	}
+	return
+}
+
+func (b *CTextBuffer) getRanges() (ranges []textTagRange) {
+	b.RLock()
+	defer b.RUnlock()
+	ranges = make([]textTagRange, len(b.ranges))
+	for idx, r := range b.ranges {
+		ranges[idx] = *r
+	}
+	return
+}
+
+// tagsAt returns the tags applied to the character at the offset given, in
+// ascending order of priority
+func (b *CTextBuffer) tagsAt(offset int) (tags []TextTag) {
+	for _, r := range b.getRanges() {
+		if offset >= r.start && offset < r.end {
+			tags = append(tags, r.tag)
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].GetPriority() < tags[j].GetPriority()
+	})
+	return
+}
+
+func (b *CTextBuffer) togglesAt(offset int, tag TextTag, begins bool) bool {
+	for _, r := range b.getRanges() {
+		if tag != nil && r.tag.ObjectID() != tag.ObjectID() {
+			continue
+		}
+		if (begins && r.start == offset) || (!begins && r.end == offset) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *CTextBuffer) getLineStarts() []int {
+	b.Lock()
+	defer b.Unlock()
+	if b.lineStarts == nil {
+		b.lineStarts = []int{0}
+		for idx, r := range b.text {
+			if r == '\n' {
+				b.lineStarts = append(b.lineStarts, idx+1)
+			}
+		}
+	}
+	return b.lineStarts
+}
+
+// lineOfOffset returns the line number containing the offset and the offset
+// of the start of that line
+func (b *CTextBuffer) lineOfOffset(offset int) (line, start int) {
+	starts := b.getLineStarts()
+	line = sort.Search(len(starts), func(i int) bool { return starts[i] > offset }) - 1
+	if line < 0 {
+		line = 0
+	}
+	return line, starts[line]
+}
+
+func (b *CTextBuffer) lineStart(line int) int {
+	starts := b.getLineStarts()
+	if line < 0 || line >= len(starts) {
+		line = len(starts) - 1
+	}
+	return starts[line]
+}
+
+// lineEnd returns the offset of the end of the line, either at the newline
+// character or just past it when includeNewline is TRUE
+func (b *CTextBuffer) lineEnd(line int, includeNewline bool) int {
+	starts := b.getLineStarts()
+	if line < 0 || line >= len(starts) {
+		line = len(starts) - 1
+	}
+	if line+1 < len(starts) {
+		if includeNewline {
+			return starts[line+1]
+		}
+		return starts[line+1] - 1
+	}
+	return b.GetCharCount()
+}
+
+func (b *CTextBuffer) insertRunes(offset int, runes []rune) {
+	count := len(runes)
+	b.Lock()
+	text := make([]rune, 0, len(b.text)+count)
+	text = append(text, b.text[:offset]...)
+	text = append(text, runes...)
+	text = append(text, b.text[offset:]...)
+	b.text = text
+	b.lineStarts = nil
+	for _, r := range b.ranges {
+		// text inserted at the end of a tagged range extends it
+		if r.start >= offset {
+			r.start += count
+		}
+		if r.end >= offset {
+			r.end += count
+		}
+	}
+	marks := append([]*CTextMark{}, b.marks...)
+	b.Unlock()
+	for _, m := range marks {
+		if o := m.getOffset(); o > offset || (o == offset && !m.GetLeftGravity()) {
+			m.setOffset(o + count)
+		}
+	}
+}
+
+func (b *CTextBuffer) deleteRunes(start, end int) {
+	count := end - start
+	adjust := func(offset int) int {
+		if offset <= start {
+			return offset
+		} else if offset >= end {
+			return offset - count
+		}
+		return start
+	}
+	b.Lock()
+	text := make([]rune, 0, len(b.text)-count)
+	text = append(text, b.text[:start]...)
+	text = append(text, b.text[end:]...)
+	b.text = text
+	b.lineStarts = nil
+	for _, r := range b.ranges {
+		r.start, r.end = adjust(r.start), adjust(r.end)
+	}
+	b.normalizeRanges()
+	marks := append([]*CTextMark{}, b.marks...)
+	b.Unlock()
+	for _, m := range marks {
+		m.setOffset(adjust(m.getOffset()))
+	}
+}
+
+// removeRange removes the tag from [start, end), splitting any range that
+// extends beyond both ends
+func (b *CTextBuffer) removeRange(tag TextTag, start, end int) {
+	kept := make([]*textTagRange, 0, len(b.ranges))
+	for _, r := range b.ranges {
+		if r.tag.ObjectID() != tag.ObjectID() || r.end <= start || r.start >= end {
+			kept = append(kept, r)
+			continue
+		}
+		if r.start < start {
+			kept = append(kept, &textTagRange{tag: r.tag, start: r.start, end: start})
+		}
+		if r.end > end {
+			kept = append(kept, &textTagRange{tag: r.tag, start: end, end: r.end})
+		}
+	}
+	b.ranges = kept
+}
+
+// normalizeRanges drops empty ranges and merges the overlapping or adjacent
+// ranges of each tag
+func (b *CTextBuffer) normalizeRanges() {
+	sort.SliceStable(b.ranges, func(i, j int) bool {
+		return b.ranges[i].start < b.ranges[j].start
+	})
+	merged := make([]*textTagRange, 0, len(b.ranges))
+	last := make(map[uuid.UUID]*textTagRange)
+	for _, r := range b.ranges {
+		if r.start >= r.end {
+			continue
+		}
+		key := r.tag.ObjectID()
+		if prev, ok := last[key]; ok && r.start <= prev.end {
+			if r.end > prev.end {
+				prev.end = r.end
+			}
+			continue
+		}
+		merged = append(merged, r)
+		last[key] = r
+	}
+	b.ranges = merged
+}
+
+// search looks for str within [start, end), returning the first match when
+// searching forwards or the last match when searching backwards
+func (b *CTextBuffer) search(str string, flags enums.TextSearchFlags, start, end int, forward bool) (matchStart, matchEnd *TextIter, ok bool) {
+	needle := []rune(str)
+	text := b.getRunes()
+	if start < 0 {
+		start = 0
+	}
+	if end > len(text) {
+		end = len(text)
+	}
+	if len(needle) == 0 || start >= end {
+		return nil, nil, false
+	}
+	// offsets maps the searched characters to their buffer offsets
+	offsets := make([]int, 0, end-start)
+	var hidden []bool
+	if flags.Has(enums.TEXT_SEARCH_VISIBLE_ONLY) {
+		hidden = b.hiddenMask(start, end)
+	}
+	for offset := start; offset < end; offset++ {
+		if hidden == nil || !hidden[offset-start] {
+			offsets = append(offsets, offset)
+		}
+	}
+	matches := func(idx int) bool {
+		for n, r := range needle {
+			if text[offsets[idx+n]] != r {
+				return false
+			}
+		}
+		return true
+	}
+	last := len(offsets) - len(needle)
+	for n := 0; n <= last; n++ {
+		idx := n
+		if !forward {
+			idx = last - n
+		}
+		if matches(idx) {
+			matchStart = newTextIter(b, offsets[idx])
+			matchEnd = newTextIter(b, offsets[idx+len(needle)-1]+1)
+			return matchStart, matchEnd, true
+		}
+	}
+	return nil, nil, false
+}
+
+const TextBufferInsertMarkName = "insert"
+
+const TextBufferSelectionBoundMarkName = "selection_bound"
+
+// The TextTagTable of the buffer.
+// Flags: Read / Write / Construct Only
+const PropertyTagTable cdk.Property = "tag-table"
+
+// Whether the buffer has been modified since the modified flag was last
+// cleared.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyModified cdk.Property = "modified"
+
+// The apply-tag signal is emitted to apply a tag to a range of text in a
+// TextBuffer. Applying actually occurs when all listeners return EVENT_PASS.
+// Listener function arguments:
+//
+//	tag TextTag	the applied tag
+//	start *TextIter	the start of the range the tag is applied to
+//	end *TextIter	the end of the range the tag is applied to
+const SignalApplyTag cdk.Signal = "apply-tag"
+
+// The changed signal is emitted when the content of a TextBuffer has changed.
+// const SignalChanged cdk.Signal = "changed"
+
+// The delete-range signal is emitted to delete a range from a TextBuffer.
+// Deletion actually occurs when all listeners return EVENT_PASS.
+// Listener function arguments:
+//
+//	start *TextIter	the start of the range to be deleted
+//	end *TextIter	the end of the range to be deleted
+const SignalDeleteRange cdk.Signal = "delete-range"
+
+// The insert-text signal is emitted to insert text in a TextBuffer. Insertion
+// actually occurs when all listeners return EVENT_PASS.
+// Listener function arguments:
+//
+//	location *TextIter	position to insert text in textbuffer
+//	text string	the text to be inserted
+// const SignalInsertText cdk.Signal = "insert-text"
+
+// The mark-deleted signal is emitted as notification after a TextMark is
+// deleted.
+// Listener function arguments:
+//
+//	mark TextMark	The mark that was deleted
+const SignalMarkDeleted cdk.Signal = "mark-deleted"
+
+// The mark-set signal is emitted as notification after a TextMark is set.
+// Listener function arguments:
+//
+//	location *TextIter	The location of mark in textbuffer
+//	mark TextMark	The mark that is set
+const SignalMarkSet cdk.Signal = "mark-set"
+
+// The modified-changed signal is emitted when the modified bit of a
+// TextBuffer flips.
+const SignalModifiedChanged cdk.Signal = "modified-changed"
+
+// The remove-tag signal is emitted to remove all occurrences of tag from a
+// range of text in a TextBuffer. Removal actually occurs when all listeners
+// return EVENT_PASS.
+// Listener function arguments:
+//
+//	tag TextTag	the tag to be removed
+//	start *TextIter	the start of the range the tag is removed from
+//	end *TextIter	the end of the range the tag is removed from
+const SignalRemoveTag cdk.Signal = "remove-tag"
+
+// Listener function arguments:
+//
+//	text string	the text cut to the clipboard
+const SignalCutClipboard cdk.Signal = "cut-clipboard"
+
+// Listener function arguments:
+//
+//	text string	the text pasted from the clipboard
+const SignalPasteClipboard cdk.Signal = "paste-clipboard"
+
+const TextBufferTagTableHandle = "text-buffer-tag-table-handler"
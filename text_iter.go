@@ -0,0 +1,393 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"unicode"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+// TextIter is a position between two characters of a TextBuffer, counted in
+// runes from the start of the buffer. Iterators are not valid indefinitely;
+// whenever the buffer is modified in a way that affects the number of runes
+// in the buffer, all outstanding iterators become invalid. Use a TextMark to
+// preserve a position across buffer modifications.
+type TextIter struct {
+	buffer *CTextBuffer
+	offset int
+}
+
+func newTextIter(buffer *CTextBuffer, offset int) *TextIter {
+	iter := &TextIter{buffer: buffer}
+	iter.SetOffset(offset)
+	return iter
+}
+
+// GetBuffer returns the TextBuffer this iterator is associated with.
+func (i *TextIter) GetBuffer() (buffer TextBuffer) {
+	if i.buffer != nil {
+		buffer, _ = i.buffer.Self().(TextBuffer)
+	}
+	return
+}
+
+// Copy returns a new TextIter at the same position.
+func (i *TextIter) Copy() *TextIter {
+	return &TextIter{buffer: i.buffer, offset: i.offset}
+}
+
+// Assign moves the iterator to the position of the other iterator given.
+func (i *TextIter) Assign(other *TextIter) {
+	i.buffer, i.offset = other.buffer, other.offset
+}
+
+// GetOffset returns the character offset of the iterator, counting from the
+// start of the buffer.
+func (i *TextIter) GetOffset() (offset int) {
+	return i.offset
+}
+
+// GetLine returns the line number containing the iterator, counting from zero.
+func (i *TextIter) GetLine() (line int) {
+	line, _ = i.buffer.lineOfOffset(i.offset)
+	return
+}
+
+// GetLineOffset returns the character offset of the iterator, counting from
+// the start of the line containing the iterator.
+func (i *TextIter) GetLineOffset() (offset int) {
+	_, start := i.buffer.lineOfOffset(i.offset)
+	return i.offset - start
+}
+
+// GetCharsInLine returns the number of characters in the line containing the
+// iterator, including the newline if there is one.
+func (i *TextIter) GetCharsInLine() (count int) {
+	line, start := i.buffer.lineOfOffset(i.offset)
+	return i.buffer.lineEnd(line, true) - start
+}
+
+// GetChar returns the character at the iterator, or zero if the iterator is at
+// the end of the buffer.
+func (i *TextIter) GetChar() (char rune) {
+	text := i.buffer.getRunes()
+	if i.offset < len(text) {
+		return text[i.offset]
+	}
+	return 0
+}
+
+// GetText returns the text between the iterator and the end iterator given,
+// including any invisible text.
+func (i *TextIter) GetText(end *TextIter) (text string) {
+	return i.buffer.getText(i.offset, end.offset, true)
+}
+
+// GetVisibleText returns the text between the iterator and the end iterator
+// given, excluding text that is hidden by invisible tags.
+func (i *TextIter) GetVisibleText(end *TextIter) (text string) {
+	return i.buffer.getText(i.offset, end.offset, false)
+}
+
+// Compare returns a negative value if the iterator is before the other, a
+// positive value if it is after and zero if they are at the same position.
+func (i *TextIter) Compare(other *TextIter) int {
+	switch {
+	case i.offset < other.offset:
+		return -1
+	case i.offset > other.offset:
+		return 1
+	}
+	return 0
+}
+
+// Equal returns TRUE if both iterators point at the same position.
+func (i *TextIter) Equal(other *TextIter) bool {
+	return i.offset == other.offset
+}
+
+// InRange returns TRUE if the iterator is within the range [start, end).
+func (i *TextIter) InRange(start, end *TextIter) bool {
+	return i.offset >= start.offset && i.offset < end.offset
+}
+
+// IsStart returns TRUE if the iterator is at the start of the buffer.
+func (i *TextIter) IsStart() bool {
+	return i.offset == 0
+}
+
+// IsEnd returns TRUE if the iterator is at the end of the buffer.
+func (i *TextIter) IsEnd() bool {
+	return i.offset >= i.buffer.GetCharCount()
+}
+
+// StartsLine returns TRUE if the iterator is at the start of a line.
+func (i *TextIter) StartsLine() bool {
+	_, start := i.buffer.lineOfOffset(i.offset)
+	return i.offset == start
+}
+
+// EndsLine returns TRUE if the iterator points at a newline or the end of the
+// buffer.
+func (i *TextIter) EndsLine() bool {
+	c := i.GetChar()
+	return c == '\n' || i.IsEnd()
+}
+
+// StartsWord returns TRUE if the iterator is at the start of a word.
+func (i *TextIter) StartsWord() bool {
+	text := i.buffer.getRunes()
+	return i.offset < len(text) && textIsWordRune(text[i.offset]) && (i.offset == 0 || !textIsWordRune(text[i.offset-1]))
+}
+
+// EndsWord returns TRUE if the iterator is at the end of a word.
+func (i *TextIter) EndsWord() bool {
+	text := i.buffer.getRunes()
+	return i.offset > 0 && textIsWordRune(text[i.offset-1]) && (i.offset >= len(text) || !textIsWordRune(text[i.offset]))
+}
+
+// InsideWord returns TRUE if the iterator is inside a word.
+func (i *TextIter) InsideWord() bool {
+	text := i.buffer.getRunes()
+	return i.offset < len(text) && textIsWordRune(text[i.offset])
+}
+
+// SetOffset moves the iterator to the character offset given, clamped to the
+// bounds of the buffer.
+func (i *TextIter) SetOffset(offset int) {
+	count := i.buffer.GetCharCount()
+	if offset < 0 || offset > count {
+		offset = count
+	}
+	i.offset = offset
+}
+
+// SetLine moves the iterator to the start of the line given. If the line is
+// out of range, the iterator is moved to the start of the last line.
+func (i *TextIter) SetLine(line int) {
+	i.offset = i.buffer.lineStart(line)
+}
+
+// SetLineOffset moves the iterator within the current line to the character
+// offset given, clamped to the end of the line.
+func (i *TextIter) SetLineOffset(offset int) {
+	line, start := i.buffer.lineOfOffset(i.offset)
+	end := i.buffer.lineEnd(line, false)
+	if offset < 0 || start+offset > end {
+		offset = end - start
+	}
+	i.offset = start + offset
+}
+
+// ForwardChar moves the iterator forward by one character. Returns TRUE if
+// the iterator moved and is not at the end of the buffer.
+func (i *TextIter) ForwardChar() bool {
+	return i.ForwardChars(1)
+}
+
+// BackwardChar moves the iterator backward by one character. Returns TRUE if
+// the iterator moved.
+func (i *TextIter) BackwardChar() bool {
+	return i.BackwardChars(1)
+}
+
+// ForwardChars moves the iterator forward by count characters, stopping at
+// the end of the buffer. Returns TRUE if the iterator moved and is not at the
+// end of the buffer.
+func (i *TextIter) ForwardChars(count int) bool {
+	if count < 0 {
+		return i.BackwardChars(-count)
+	}
+	total := i.buffer.GetCharCount()
+	if count == 0 || i.offset >= total {
+		return false
+	}
+	i.offset += count
+	if i.offset >= total {
+		i.offset = total
+		return false
+	}
+	return true
+}
+
+// BackwardChars moves the iterator backward by count characters, stopping at
+// the start of the buffer. Returns TRUE if the iterator moved.
+func (i *TextIter) BackwardChars(count int) bool {
+	if count < 0 {
+		return i.ForwardChars(-count)
+	}
+	if count == 0 || i.offset == 0 {
+		return false
+	}
+	i.offset -= count
+	if i.offset < 0 {
+		i.offset = 0
+	}
+	return true
+}
+
+// ForwardLine moves the iterator to the start of the next line. If the
+// iterator is on the last line, it is moved to the end of the buffer. Returns
+// TRUE if the iterator moved and is not at the end of the buffer.
+func (i *TextIter) ForwardLine() bool {
+	line := i.GetLine()
+	if line+1 >= i.buffer.GetLineCount() {
+		i.offset = i.buffer.GetCharCount()
+		return false
+	}
+	i.offset = i.buffer.lineStart(line + 1)
+	return !i.IsEnd()
+}
+
+// BackwardLine moves the iterator to the start of the previous line. If the
+// iterator is on the first line, it is moved to the start of the buffer.
+// Returns TRUE if the iterator moved.
+func (i *TextIter) BackwardLine() bool {
+	line := i.GetLine()
+	if line == 0 {
+		moved := i.offset != 0
+		i.offset = 0
+		return moved
+	}
+	i.offset = i.buffer.lineStart(line - 1)
+	return true
+}
+
+// ForwardToLineEnd moves the iterator to the end of the current line, ie: the
+// position of the newline. If the iterator is already at the end of a line,
+// it is moved to the end of the next line. Returns TRUE if the iterator moved
+// and is not at the end of the buffer.
+func (i *TextIter) ForwardToLineEnd() bool {
+	line, _ := i.buffer.lineOfOffset(i.offset)
+	end := i.buffer.lineEnd(line, false)
+	if i.offset == end {
+		if line+1 >= i.buffer.GetLineCount() {
+			return false
+		}
+		end = i.buffer.lineEnd(line+1, false)
+	}
+	i.offset = end
+	return !i.IsEnd()
+}
+
+// ForwardToEnd moves the iterator to the end of the buffer.
+func (i *TextIter) ForwardToEnd() {
+	i.offset = i.buffer.GetCharCount()
+}
+
+// ForwardWordEnd moves the iterator forward to the next word end. Returns TRUE
+// if the iterator moved and is not at the end of the buffer.
+func (i *TextIter) ForwardWordEnd() bool {
+	text := i.buffer.getRunes()
+	offset := i.offset
+	for offset < len(text) && !textIsWordRune(text[offset]) {
+		offset++
+	}
+	for offset < len(text) && textIsWordRune(text[offset]) {
+		offset++
+	}
+	moved := offset != i.offset
+	i.offset = offset
+	return moved && offset < len(text)
+}
+
+// BackwardWordStart moves the iterator backward to the previous word start.
+// Returns TRUE if the iterator moved.
+func (i *TextIter) BackwardWordStart() bool {
+	text := i.buffer.getRunes()
+	offset := i.offset
+	for offset > 0 && !textIsWordRune(text[offset-1]) {
+		offset--
+	}
+	for offset > 0 && textIsWordRune(text[offset-1]) {
+		offset--
+	}
+	moved := offset != i.offset
+	i.offset = offset
+	return moved
+}
+
+// HasTag returns TRUE if the character at the iterator has the tag applied.
+func (i *TextIter) HasTag(tag TextTag) bool {
+	for _, t := range i.GetTags() {
+		if t.ObjectID() == tag.ObjectID() {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTags returns the tags applied to the character at the iterator, in
+// ascending order of priority.
+func (i *TextIter) GetTags() (tags []TextTag) {
+	return i.buffer.tagsAt(i.offset)
+}
+
+// BeginsTag returns TRUE if the tag is applied to the character at the
+// iterator but not to the preceding character. If tag is nil, returns TRUE if
+// any tag begins at the iterator.
+func (i *TextIter) BeginsTag(tag TextTag) bool {
+	return i.buffer.togglesAt(i.offset, tag, true)
+}
+
+// EndsTag returns TRUE if the tag is applied to the preceding character but not
+// to the character at the iterator. If tag is nil, returns TRUE if any tag
+// ends at the iterator.
+func (i *TextIter) EndsTag(tag TextTag) bool {
+	return i.buffer.togglesAt(i.offset, tag, false)
+}
+
+// Editable returns whether text inserted at the iterator would be editable.
+// The defaultSetting is used when no tag applied at the iterator changes the
+// editability of the text.
+func (i *TextIter) Editable(defaultSetting bool) bool {
+	editable := defaultSetting
+	for _, tag := range i.GetTags() {
+		if !tag.GetEditable() {
+			editable = false
+		}
+	}
+	return editable
+}
+
+// ForwardSearch searches forward for the given text, starting at the iterator
+// and ending at the limit, or the end of the buffer if limit is nil. When the
+// flags include TEXT_SEARCH_VISIBLE_ONLY, text hidden by invisible tags is
+// skipped over and the match may span the hidden text. TEXT_SEARCH_TEXT_ONLY
+// has no effect as a TextBuffer contains only text. Returns the bounds of the
+// first match found and TRUE, or FALSE if the text was not found.
+func (i *TextIter) ForwardSearch(str string, flags enums.TextSearchFlags, limit *TextIter) (matchStart, matchEnd *TextIter, ok bool) {
+	end := i.buffer.GetCharCount()
+	if limit != nil && limit.offset < end {
+		end = limit.offset
+	}
+	return i.buffer.search(str, flags, i.offset, end, true)
+}
+
+// BackwardSearch is the same as ForwardSearch, except it searches backward
+// from the iterator to the limit, or to the start of the buffer if limit is
+// nil.
+func (i *TextIter) BackwardSearch(str string, flags enums.TextSearchFlags, limit *TextIter) (matchStart, matchEnd *TextIter, ok bool) {
+	start := 0
+	if limit != nil && limit.offset > start {
+		start = limit.offset
+	}
+	return i.buffer.search(str, flags, start, i.offset, false)
+}
+
+func textIsWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
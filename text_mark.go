@@ -0,0 +1,176 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"github.com/go-curses/cdk"
+)
+
+const TypeTextMark cdk.CTypeTag = "ctk-text-mark"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeTextMark, func() interface{} { return MakeTextMark() })
+}
+
+// TextMark Hierarchy:
+//
+//	Object
+//	  +- TextMark
+//
+// A TextMark is a position within a TextBuffer that is preserved across
+// modifications of the buffer. When text is inserted at the position of a
+// mark, a mark with left gravity stays to the left of the new text while a
+// mark with right gravity (the default) moves to the right of it. Every
+// TextBuffer has two named marks, "insert" for the cursor position and
+// "selection_bound" for the other end of the selection.
+type TextMark interface {
+	Object
+
+	GetVisible() (visible bool)
+	SetVisible(setting bool)
+	GetDeleted() (deleted bool)
+	GetBuffer() (buffer TextBuffer)
+	GetLeftGravity() (leftGravity bool)
+}
+
+var _ TextMark = (*CTextMark)(nil)
+
+// The CTextMark structure implements the TextMark interface and is exported to
+// facilitate type embedding with custom implementations. No member variables
+// are exported as the interface methods are the only intended means of
+// interacting with TextMark objects.
+type CTextMark struct {
+	CObject
+
+	buffer *CTextBuffer
+	offset int
+}
+
+// MakeTextMark is used by the Buildable system to construct a new TextMark.
+func MakeTextMark() TextMark {
+	return NewTextMark("", false)
+}
+
+// NewTextMark creates a new TextMark that is not yet added to any buffer. Add
+// it to a buffer with TextBuffer.AddMark.
+//
+// Parameters:
+//
+//	name	mark name, or empty for an anonymous mark
+//	leftGravity	whether the mark should have left gravity
+func NewTextMark(name string, leftGravity bool) TextMark {
+	m := new(CTextMark)
+	m.Init()
+	if name != "" {
+		m.SetName(name)
+	}
+	if err := m.SetBoolProperty(PropertyLeftGravity, leftGravity); err != nil {
+		m.LogErr(err)
+	}
+	return m
+}
+
+// Init initializes a TextMark object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the TextMark instance. Init is used in the
+// NewTextMark constructor and only necessary when implementing a derivative
+// TextMark type.
+func (m *CTextMark) Init() (already bool) {
+	if m.InitTypeItem(TypeTextMark, m) {
+		return true
+	}
+	m.CObject.Init()
+	m.buffer = nil
+	m.offset = 0
+	_ = m.InstallProperty(PropertyLeftGravity, cdk.BoolProperty, true, false)
+	_ = m.InstallProperty(PropertyVisible, cdk.BoolProperty, true, false)
+	return false
+}
+
+// GetVisible returns TRUE if the mark is visible, ie: a cursor is displayed
+// for it.
+func (m *CTextMark) GetVisible() (visible bool) {
+	var err error
+	if visible, err = m.GetBoolProperty(PropertyVisible); err != nil {
+		m.LogErr(err)
+	}
+	return
+}
+
+// SetVisible updates the visibility of the mark. The insertion point is
+// normally visible, ie: you can see it as the screen cursor, while all other
+// marks are invisible by default.
+//
+// Parameters:
+//
+//	setting	visibility of mark
+func (m *CTextMark) SetVisible(setting bool) {
+	if err := m.SetBoolProperty(PropertyVisible, setting); err != nil {
+		m.LogErr(err)
+	}
+}
+
+// GetDeleted returns TRUE if the mark has been removed from its buffer with
+// TextBuffer.DeleteMark.
+func (m *CTextMark) GetDeleted() (deleted bool) {
+	m.RLock()
+	defer m.RUnlock()
+	return m.buffer == nil
+}
+
+// GetBuffer returns the buffer this mark is located inside, or nil if the mark
+// is deleted.
+func (m *CTextMark) GetBuffer() (buffer TextBuffer) {
+	m.RLock()
+	b := m.buffer
+	m.RUnlock()
+	if b != nil {
+		buffer, _ = b.Self().(TextBuffer)
+	}
+	return
+}
+
+// GetLeftGravity returns TRUE if the mark has left gravity.
+func (m *CTextMark) GetLeftGravity() (leftGravity bool) {
+	var err error
+	if leftGravity, err = m.GetBoolProperty(PropertyLeftGravity); err != nil {
+		m.LogErr(err)
+	}
+	return
+}
+
+func (m *CTextMark) getOffset() int {
+	m.RLock()
+	defer m.RUnlock()
+	return m.offset
+}
+
+func (m *CTextMark) setOffset(offset int) {
+	m.Lock()
+	m.offset = offset
+	m.Unlock()
+}
+
+func (m *CTextMark) setBuffer(buffer *CTextBuffer) {
+	m.Lock()
+	m.buffer = buffer
+	m.Unlock()
+}
+
+// Whether the mark has left gravity.
+// Flags: Read / Write / Construct Only
+// Default value: FALSE
+const PropertyLeftGravity cdk.Property = "left-gravity"
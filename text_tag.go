@@ -0,0 +1,252 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"github.com/go-curses/cdk"
+	"github.com/go-curses/cdk/lib/paint"
+)
+
+const TypeTextTag cdk.CTypeTag = "ctk-text-tag"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeTextTag, func() interface{} { return MakeTextTag() })
+}
+
+// TextTag Hierarchy:
+//
+//	Object
+//	  +- TextTag
+//
+// A TextTag describes how a range of text within a TextBuffer is displayed
+// and edited. Tags are added to the TextTagTable of a TextBuffer and applied
+// to ranges of text with TextBuffer.ApplyTag. When more than one tag applies
+// to the same text, the paint.Style of the tag with the highest priority wins
+// for each of the foreground color, background color and attributes it sets.
+type TextTag interface {
+	Object
+
+	GetStyle() (style paint.Style)
+	SetStyle(style paint.Style)
+	GetInvisible() (invisible bool)
+	SetInvisible(invisible bool)
+	GetEditable() (editable bool)
+	SetEditable(editable bool)
+	GetPriority() (priority int)
+	SetPriority(priority int)
+	GetTagTable() (table TextTagTable)
+}
+
+var _ TextTag = (*CTextTag)(nil)
+
+// The CTextTag structure implements the TextTag interface and is exported to
+// facilitate type embedding with custom implementations. No member variables
+// are exported as the interface methods are the only intended means of
+// interacting with TextTag objects.
+type CTextTag struct {
+	CObject
+
+	table TextTagTable
+}
+
+// MakeTextTag is used by the Buildable system to construct a new TextTag.
+func MakeTextTag() TextTag {
+	return NewTextTag("")
+}
+
+// NewTextTag is the constructor for new TextTag instances.
+//
+// Parameters:
+//
+//	name	tag name, or empty for an anonymous tag
+func NewTextTag(name string) TextTag {
+	t := new(CTextTag)
+	t.Init()
+	if name != "" {
+		t.SetName(name)
+	}
+	return t
+}
+
+// Init initializes a TextTag object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the TextTag instance. Init is used in the
+// NewTextTag constructor and only necessary when implementing a derivative
+// TextTag type.
+func (t *CTextTag) Init() (already bool) {
+	if t.InitTypeItem(TypeTextTag, t) {
+		return true
+	}
+	t.CObject.Init()
+	t.table = nil
+	_ = t.InstallBuildableProperty(PropertyStyle, cdk.StructProperty, true, paint.StyleDefault)
+	_ = t.InstallBuildableProperty(PropertyInvisible, cdk.BoolProperty, true, false)
+	_ = t.InstallBuildableProperty(PropertyEditable, cdk.BoolProperty, true, true)
+	_ = t.InstallBuildableProperty(PropertyPriority, cdk.IntProperty, true, 0)
+	return false
+}
+
+// GetStyle returns the paint.Style used to display text with this tag applied.
+func (t *CTextTag) GetStyle() (style paint.Style) {
+	var err error
+	var v interface{}
+	if v, err = t.GetStructProperty(PropertyStyle); err != nil {
+		t.LogErr(err)
+	} else {
+		var ok bool
+		if style, ok = v.(paint.Style); !ok {
+			t.LogError("value stored in %v property is not of paint.Style type: %v (%T)", PropertyStyle, v, v)
+		}
+	}
+	return
+}
+
+// SetStyle updates the paint.Style used to display text with this tag applied.
+//
+// Parameters:
+//
+//	style	the paint.Style to use
+func (t *CTextTag) SetStyle(style paint.Style) {
+	if err := t.SetStructProperty(PropertyStyle, style); err != nil {
+		t.LogErr(err)
+	} else {
+		t.changed()
+	}
+}
+
+// GetInvisible returns TRUE if text with this tag applied is hidden from view.
+func (t *CTextTag) GetInvisible() (invisible bool) {
+	var err error
+	if invisible, err = t.GetBoolProperty(PropertyInvisible); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetInvisible updates whether text with this tag applied is hidden from view.
+// Invisible text is also skipped by searches using TEXT_SEARCH_VISIBLE_ONLY.
+//
+// Parameters:
+//
+//	invisible	TRUE to hide the text
+func (t *CTextTag) SetInvisible(invisible bool) {
+	if err := t.SetBoolProperty(PropertyInvisible, invisible); err != nil {
+		t.LogErr(err)
+	} else {
+		t.changed()
+	}
+}
+
+// GetEditable returns TRUE if text with this tag applied can be modified by
+// the user.
+func (t *CTextTag) GetEditable() (editable bool) {
+	var err error
+	if editable, err = t.GetBoolProperty(PropertyEditable); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetEditable updates whether text with this tag applied can be modified by
+// the user.
+//
+// Parameters:
+//
+//	editable	TRUE if the text can be modified
+func (t *CTextTag) SetEditable(editable bool) {
+	if err := t.SetBoolProperty(PropertyEditable, editable); err != nil {
+		t.LogErr(err)
+	} else {
+		t.changed()
+	}
+}
+
+// GetPriority returns the tag priority.
+func (t *CTextTag) GetPriority() (priority int) {
+	var err error
+	if priority, err = t.GetIntProperty(PropertyPriority); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetPriority updates the priority of the tag. Valid priorities start at 0 and
+// go to one less than TextTagTable.GetSize. Each tag in a table has a unique
+// priority, setting the priority of one tag shifts the priorities of all the
+// other tags in the table to maintain a unique priority for each tag. Higher
+// priority tags "win" if two tags both set the same aspect of the paint.Style.
+// When adding a tag to a tag table, it will be assigned the highest priority
+// in the table by default.
+//
+// Parameters:
+//
+//	priority	the new priority
+func (t *CTextTag) SetPriority(priority int) {
+	t.RLock()
+	table := t.table
+	t.RUnlock()
+	if table != nil {
+		if tt, ok := table.Self().(*CTextTagTable); ok {
+			tt.setPriority(t, priority)
+			return
+		}
+	}
+	t.setPriority(priority)
+}
+
+// GetTagTable returns the TextTagTable this tag has been added to, or nil.
+func (t *CTextTag) GetTagTable() (table TextTagTable) {
+	t.RLock()
+	defer t.RUnlock()
+	return t.table
+}
+
+func (t *CTextTag) setPriority(priority int) {
+	if err := t.SetIntProperty(PropertyPriority, priority); err != nil {
+		t.LogErr(err)
+	}
+}
+
+func (t *CTextTag) setTagTable(table TextTagTable) {
+	t.Lock()
+	t.table = table
+	t.Unlock()
+}
+
+func (t *CTextTag) changed() {
+	if table := t.GetTagTable(); table != nil {
+		table.Emit(SignalTagChanged, table, t)
+	}
+}
+
+// Whether this text is hidden.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyInvisible cdk.Property = "invisible"
+
+// The priority of the tag, higher priority tags take precedence.
+// Flags: Read / Write
+// Default value: 0
+const PropertyPriority cdk.Property = "priority"
+
+// The paint.Style used to display text with the tag applied.
+// Flags: Read / Write
+// const PropertyStyle cdk.Property = "style"
+
+// Whether the text can be modified by the user.
+// Flags: Read / Write
+// Default value: TRUE
+// const PropertyEditable cdk.Property = "editable"
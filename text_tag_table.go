@@ -0,0 +1,265 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"github.com/go-curses/cdk"
+)
+
+const TypeTextTagTable cdk.CTypeTag = "ctk-text-tag-table"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeTextTagTable, func() interface{} { return MakeTextTagTable() })
+}
+
+// TextTagTableForeachFunc is the signature of the callback used by
+// TextTagTable.Foreach.
+type TextTagTableForeachFunc = func(tag TextTag)
+
+// TextTagTable Hierarchy:
+//
+//	Object
+//	  +- TextTagTable
+//
+// A TextTagTable is the collection of TextTag instances that can be used
+// within a TextBuffer. Tags must be added to the table before they can be
+// applied to any text. Tag names are unique within a table and each tag has a
+// unique priority, ordered by when the tag was added.
+type TextTagTable interface {
+	Object
+
+	Add(tag TextTag) (ok bool)
+	Remove(tag TextTag)
+	Lookup(name string) (tag TextTag)
+	Foreach(fn TextTagTableForeachFunc)
+	GetSize() (size int)
+}
+
+var _ TextTagTable = (*CTextTagTable)(nil)
+
+// The CTextTagTable structure implements the TextTagTable interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with TextTagTable objects.
+type CTextTagTable struct {
+	CObject
+
+	tags []TextTag
+}
+
+// MakeTextTagTable is used by the Buildable system to construct a new
+// TextTagTable.
+func MakeTextTagTable() TextTagTable {
+	return NewTextTagTable()
+}
+
+// NewTextTagTable is the constructor for new, empty, TextTagTable instances.
+func NewTextTagTable() TextTagTable {
+	t := new(CTextTagTable)
+	t.Init()
+	return t
+}
+
+// Init initializes a TextTagTable object. This must be called at least once
+// to set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the TextTagTable instance. Init is used in the
+// NewTextTagTable constructor and only necessary when implementing a
+// derivative TextTagTable type.
+func (t *CTextTagTable) Init() (already bool) {
+	if t.InitTypeItem(TypeTextTagTable, t) {
+		return true
+	}
+	t.CObject.Init()
+	t.tags = make([]TextTag, 0)
+	return false
+}
+
+// Build provides customizations to the Buildable system for TextTagTable
+// objects. GtkTextTag children are added to the table.
+func (t *CTextTagTable) Build(builder Builder, element *CBuilderElement) error {
+	if name, ok := element.Attributes["id"]; ok {
+		t.SetName(name)
+	}
+	for k, v := range element.Properties {
+		element.ApplyProperty(k, v)
+	}
+	for _, child := range element.Children {
+		if newChild := builder.Build(child); newChild != nil {
+			child.Instance = newChild
+			if tag, ok := newChild.(TextTag); ok {
+				t.Add(tag)
+			} else {
+				t.LogError("new child object is not a TextTag type: %v (%T)", newChild, newChild)
+			}
+		}
+	}
+	element.ApplySignals()
+	return nil
+}
+
+// Add a tag to the table. The tag is assigned the highest priority in the
+// table. The tag must not already be in a table and must not have the same
+// name as a tag already in this table. Returns FALSE if the tag could not be
+// added.
+//
+// Parameters:
+//
+//	tag	a TextTag
+func (t *CTextTagTable) Add(tag TextTag) (ok bool) {
+	if tag == nil {
+		return false
+	}
+	if tag.GetTagTable() != nil {
+		t.LogError("tag is already within a tag table: %v", tag.ObjectInfo())
+		return false
+	}
+	if name := tag.GetName(); name != "" && t.Lookup(name) != nil {
+		t.LogError("a tag named %q is already in the tag table", name)
+		return false
+	}
+	ct, ok := tag.Self().(*CTextTag)
+	if !ok {
+		t.LogError("tag does not embed CTextTag: %v (%T)", tag, tag)
+		return false
+	}
+	t.Lock()
+	t.tags = append(t.tags, tag)
+	priority := len(t.tags) - 1
+	t.Unlock()
+	ct.setTagTable(t)
+	ct.setPriority(priority)
+	t.Emit(SignalTagAdded, t, tag)
+	return true
+}
+
+// Remove a tag from the table. Any TextBuffer using the table will remove the
+// tag from all of its text.
+//
+// Parameters:
+//
+//	tag	a TextTag
+func (t *CTextTagTable) Remove(tag TextTag) {
+	if tag == nil {
+		return
+	}
+	found := false
+	t.Lock()
+	for idx, tt := range t.tags {
+		if tt.ObjectID() == tag.ObjectID() {
+			t.tags = append(t.tags[:idx], t.tags[idx+1:]...)
+			found = true
+			break
+		}
+	}
+	tags := append([]TextTag{}, t.tags...)
+	t.Unlock()
+	if !found {
+		return
+	}
+	for idx, tt := range tags {
+		if ct, ok := tt.Self().(*CTextTag); ok {
+			ct.setPriority(idx)
+		}
+	}
+	if ct, ok := tag.Self().(*CTextTag); ok {
+		ct.setTagTable(nil)
+	}
+	t.Emit(SignalTagRemoved, t, tag)
+}
+
+// Lookup a tag by name. Returns nil if no tag has the given name.
+//
+// Parameters:
+//
+//	name	name of a tag
+func (t *CTextTagTable) Lookup(name string) (tag TextTag) {
+	t.RLock()
+	defer t.RUnlock()
+	for _, tt := range t.tags {
+		if tt.GetName() == name {
+			return tt
+		}
+	}
+	return nil
+}
+
+// Foreach calls the given function on each tag in the table, in order of
+// priority. The table must not be modified from within the function.
+//
+// Parameters:
+//
+//	fn	a function to call on each tag
+func (t *CTextTagTable) Foreach(fn TextTagTableForeachFunc) {
+	t.RLock()
+	tags := append([]TextTag{}, t.tags...)
+	t.RUnlock()
+	for _, tag := range tags {
+		fn(tag)
+	}
+}
+
+// GetSize returns the number of tags in the table.
+func (t *CTextTagTable) GetSize() (size int) {
+	t.RLock()
+	defer t.RUnlock()
+	return len(t.tags)
+}
+
+func (t *CTextTagTable) setPriority(tag *CTextTag, priority int) {
+	t.Lock()
+	current := -1
+	for idx, tt := range t.tags {
+		if tt.ObjectID() == tag.ObjectID() {
+			current = idx
+			break
+		}
+	}
+	if current < 0 {
+		t.Unlock()
+		return
+	}
+	if priority < 0 {
+		priority = 0
+	} else if priority >= len(t.tags) {
+		priority = len(t.tags) - 1
+	}
+	moved := t.tags[current]
+	t.tags = append(t.tags[:current], t.tags[current+1:]...)
+	t.tags = append(t.tags[:priority], append([]TextTag{moved}, t.tags[priority:]...)...)
+	tags := append([]TextTag{}, t.tags...)
+	t.Unlock()
+	for idx, tt := range tags {
+		if ct, ok := tt.Self().(*CTextTag); ok {
+			ct.setPriority(idx)
+		}
+	}
+	t.Emit(SignalTagChanged, t, tag)
+}
+
+// Listener function arguments:
+//
+//	tag TextTag
+const SignalTagAdded cdk.Signal = "tag-added"
+
+// Listener function arguments:
+//
+//	tag TextTag
+const SignalTagChanged cdk.Signal = "tag-changed"
+
+// Listener function arguments:
+//
+//	tag TextTag
+const SignalTagRemoved cdk.Signal = "tag-removed"
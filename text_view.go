@@ -0,0 +1,1567 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/go-curses/cdk/lib/ptypes"
+	"github.com/go-curses/cdk/memphis"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeTextView cdk.CTypeTag = "ctk-text-view"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeTextView, func() interface{} { return MakeTextView() })
+	ctkBuilderTranslators[TypeTextView] = func(builder Builder, widget Widget, name, value string) error {
+		switch cdk.Property(name) {
+		case PropertyBuffer:
+			if tv, ok := widget.Self().(TextView); ok {
+				if buffer, ok := builder.GetWidget(value).(TextBuffer); ok {
+					tv.SetBuffer(buffer)
+					return nil
+				}
+				return fmt.Errorf("text buffer not found: %v", value)
+			}
+		case PropertyWrapMode:
+			if tv, ok := widget.Self().(TextView); ok {
+				tv.SetWrapMode(textViewWrapModeFromString(value))
+				return nil
+			}
+		}
+		return ErrFallthrough
+	}
+}
+
+// TextView Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- TextView
+//
+// The TextView Widget displays and edits the contents of a TextBuffer. Text
+// is styled by the TextTag ranges of the buffer and lines longer than the
+// width of the TextView are wrapped according to the "wrap-mode" property.
+// The cursor is the "insert" mark of the buffer and the selection is the text
+// between the "insert" and "selection_bound" marks. Cut, copy and paste use
+// the clipboard of the Display.
+//
+// When placed within a ScrolledViewport, the TextView requests the size of
+// all of its lines and follows the ScrolledViewport Adjustments. Otherwise,
+// the TextView scrolls its lines within its own allocation using its own
+// Adjustments. In either case, moving the cursor scrolls as few lines as
+// needed to keep the cursor visible.
+type TextView interface {
+	Container
+
+	Init() (already bool)
+	Build(builder Builder, element *CBuilderElement) error
+	SetBuffer(buffer TextBuffer)
+	GetBuffer() (buffer TextBuffer)
+	GetHAdjustment() (adjustment Adjustment)
+	SetHAdjustment(adjustment Adjustment)
+	GetVAdjustment() (adjustment Adjustment)
+	SetVAdjustment(adjustment Adjustment)
+	GetEditable() (editable bool)
+	SetEditable(setting bool)
+	GetWrapMode() (wrapMode cenums.WrapMode)
+	SetWrapMode(wrapMode cenums.WrapMode)
+	GetCursorVisible() (visible bool)
+	SetCursorVisible(setting bool)
+	GetOverwrite() (overwrite bool)
+	SetOverwrite(overwrite bool)
+	GetAcceptsTab() (acceptsTab bool)
+	SetAcceptsTab(acceptsTab bool)
+	GetLeftMargin() (margin int)
+	SetLeftMargin(leftMargin int)
+	GetRightMargin() (margin int)
+	SetRightMargin(rightMargin int)
+	ScrollToMark(mark TextMark, useAlign bool, xAlign, yAlign float64)
+	ScrollToIter(iter *TextIter, useAlign bool, xAlign, yAlign float64) (scrolled bool)
+	ScrollMarkOnscreen(mark TextMark)
+	MoveMarkOnscreen(mark TextMark) (moved bool)
+	PlaceCursorOnscreen() (moved bool)
+	GetVisibleRange() (start, end *TextIter)
+	GetIterLocation(iter *TextIter) (x, y int)
+	GetIterAtLocation(x, y int) (iter *TextIter)
+	ForwardDisplayLine(iter *TextIter) (ok bool)
+	BackwardDisplayLine(iter *TextIter) (ok bool)
+	StartsDisplayLine(iter *TextIter) (ok bool)
+	SelectAll(selectAll bool)
+	CutClipboard()
+	CopyClipboard()
+	PasteClipboard()
+	GetSizeRequest() (width, height int)
+	GetWidgetAt(p *ptypes.Point2I) Widget
+	CancelEvent()
+}
+
+var _ TextView = (*CTextView)(nil)
+
+// textViewLine is one display line of a TextView, the characters [start, end)
+// of the buffer, excluding any newline
+type textViewLine struct {
+	start int
+	end   int
+	width int
+}
+
+// The CTextView structure implements the TextView interface and is exported
+// to facilitate type embedding with custom implementations. No member variables
+// are exported as the interface methods are the only intended means of
+// interacting with TextView objects.
+type CTextView struct {
+	CContainer
+
+	buffer       TextBuffer
+	bufferHandle string
+	lines        []textViewLine
+	linesValid   bool
+	linesWidth   int
+	contentW     int
+	preferredX   int
+}
+
+// MakeTextView is used by the Buildable system to construct a new TextView.
+func MakeTextView() TextView {
+	return NewTextView()
+}
+
+// NewTextView is the constructor for new TextView instances. A new, empty,
+// TextBuffer is created for the TextView.
+func NewTextView() TextView {
+	t := new(CTextView)
+	t.Init()
+	return t
+}
+
+// NewTextViewWithBuffer is a convenience constructor for new TextView instances
+// displaying the buffer given.
+//
+// Parameters:
+//
+//	buffer	a TextBuffer
+func NewTextViewWithBuffer(buffer TextBuffer) TextView {
+	t := NewTextView()
+	t.SetBuffer(buffer)
+	return t
+}
+
+// Init initializes a TextView object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the TextView instance. Init is used in the
+// NewTextView constructor and only necessary when implementing a derivative
+// TextView type.
+func (t *CTextView) Init() (already bool) {
+	if t.InitTypeItem(TypeTextView, t) {
+		return true
+	}
+	t.CContainer.Init()
+	t.flags = enums.NULL_WIDGET_FLAG
+	t.SetFlags(enums.SENSITIVE | enums.PARENT_SENSITIVE | enums.CAN_FOCUS | enums.APP_PAINTABLE)
+	_ = t.InstallBuildableProperty(PropertyBuffer, cdk.StructProperty, true, nil)
+	_ = t.InstallBuildableProperty(PropertyViewportHAdjustment, cdk.StructProperty, true, nil)
+	_ = t.InstallBuildableProperty(PropertyViewportVAdjustment, cdk.StructProperty, true, nil)
+	_ = t.InstallBuildableProperty(PropertyEditable, cdk.BoolProperty, true, true)
+	_ = t.InstallBuildableProperty(PropertyWrapMode, cdk.StructProperty, true, cenums.WRAP_NONE)
+	_ = t.InstallBuildableProperty(PropertyCursorVisible, cdk.BoolProperty, true, true)
+	_ = t.InstallBuildableProperty(PropertyOverwrite, cdk.BoolProperty, true, false)
+	_ = t.InstallBuildableProperty(PropertyAcceptsTab, cdk.BoolProperty, true, true)
+	_ = t.InstallBuildableProperty(PropertyLeftMargin, cdk.IntProperty, true, 0)
+	_ = t.InstallBuildableProperty(PropertyRightMargin, cdk.IntProperty, true, 0)
+	t.bufferHandle = fmt.Sprintf("%v-%v", TextViewBufferHandle, t.ObjectID())
+	t.preferredX = -1
+	if theme, ok := paint.GetTheme(EntryColorTheme); ok {
+		t.SetTheme(theme)
+	}
+	t.SetHAdjustment(NewAdjustment(0, 0, 0, 1, 0, 0))
+	t.SetVAdjustment(NewAdjustment(0, 0, 0, 1, 0, 0))
+	t.SetBuffer(NewTextBuffer(nil))
+	t.Connect(SignalCdkEvent, TextViewEventHandle, t.event)
+	t.Connect(SignalLostFocus, TextViewLostFocusHandle, t.lostFocus)
+	t.Connect(SignalGainedFocus, TextViewGainedFocusHandle, t.gainedFocus)
+	t.Connect(SignalResize, TextViewResizeHandle, t.resize)
+	t.Connect(SignalDraw, TextViewDrawHandle, t.draw)
+	return false
+}
+
+// Build provides customizations to the Buildable system for TextView Widgets.
+// The "buffer" property is resolved by name to a previously built TextBuffer
+// and the "text" property, when given, replaces the contents of the buffer.
+func (t *CTextView) Build(builder Builder, element *CBuilderElement) error {
+	t.Freeze()
+	defer t.Thaw()
+	if name, ok := element.Attributes["id"]; ok {
+		t.SetName(name)
+	}
+	for k, v := range element.Properties {
+		switch cdk.Property(k) {
+		case PropertyText:
+			t.GetBuffer().SetText(v)
+		default:
+			element.ApplyProperty(k, v)
+		}
+	}
+	element.ApplySignals()
+	return nil
+}
+
+// SetBuffer updates the buffer being displayed by the TextView. If buffer is
+// nil, a new, empty, TextBuffer is created.
+//
+// Parameters:
+//
+//	buffer	a TextBuffer
+func (t *CTextView) SetBuffer(buffer TextBuffer) {
+	if buffer == nil {
+		buffer = NewTextBuffer(nil)
+	}
+	t.Lock()
+	previous := t.buffer
+	t.buffer = buffer
+	t.preferredX = -1
+	t.Unlock()
+	if previous != nil {
+		_ = previous.Disconnect(SignalChanged, t.bufferHandle)
+		_ = previous.Disconnect(SignalMarkSet, t.bufferHandle)
+		_ = previous.GetTagTable().Disconnect(SignalTagChanged, t.bufferHandle)
+	}
+	buffer.Connect(SignalChanged, t.bufferHandle, t.bufferChanged)
+	buffer.Connect(SignalMarkSet, t.bufferHandle, t.markSet)
+	buffer.GetTagTable().Connect(SignalTagChanged, t.bufferHandle, t.bufferChanged)
+	if err := t.SetStructProperty(PropertyBuffer, buffer); err != nil {
+		t.LogErr(err)
+	}
+	t.queueResize()
+}
+
+// GetBuffer returns the TextBuffer being displayed by this TextView.
+func (t *CTextView) GetBuffer() (buffer TextBuffer) {
+	t.RLock()
+	defer t.RUnlock()
+	return t.buffer
+}
+
+// GetHAdjustment returns the Adjustment currently being used for the
+// horizontal aspect. When the TextView is the child of a ScrolledViewport, the
+// ScrolledViewport's horizontal Adjustment is returned.
+func (t *CTextView) GetHAdjustment() (adjustment Adjustment) {
+	if sv := t.getScrolledViewport(); sv != nil {
+		return sv.GetHAdjustment()
+	}
+	if v, err := t.GetStructProperty(PropertyViewportHAdjustment); err != nil {
+		t.LogErr(err)
+	} else if adjustment, _ = v.(Adjustment); adjustment == nil && v != nil {
+		t.LogError("value stored in %v property is not of Adjustment type: %v (%T)", PropertyViewportHAdjustment, v, v)
+	}
+	return
+}
+
+// SetHAdjustment sets the Adjustment for the current horizontal aspect.
+//
+// Parameters:
+//
+//	adjustment	the Adjustment to set, or nil
+func (t *CTextView) SetHAdjustment(adjustment Adjustment) {
+	if err := t.SetStructProperty(PropertyViewportHAdjustment, adjustment); err != nil {
+		t.LogErr(err)
+	}
+}
+
+// GetVAdjustment returns the Adjustment currently being used for the vertical
+// aspect. When the TextView is the child of a ScrolledViewport, the
+// ScrolledViewport's vertical Adjustment is returned.
+func (t *CTextView) GetVAdjustment() (adjustment Adjustment) {
+	if sv := t.getScrolledViewport(); sv != nil {
+		return sv.GetVAdjustment()
+	}
+	if v, err := t.GetStructProperty(PropertyViewportVAdjustment); err != nil {
+		t.LogErr(err)
+	} else if adjustment, _ = v.(Adjustment); adjustment == nil && v != nil {
+		t.LogError("value stored in %v property is not of Adjustment type: %v (%T)", PropertyViewportVAdjustment, v, v)
+	}
+	return
+}
+
+// SetVAdjustment sets the Adjustment for the current vertical aspect.
+//
+// Parameters:
+//
+//	adjustment	the Adjustment to set, or nil
+func (t *CTextView) SetVAdjustment(adjustment Adjustment) {
+	if err := t.SetStructProperty(PropertyViewportVAdjustment, adjustment); err != nil {
+		t.LogErr(err)
+	}
+}
+
+// GetEditable returns the default editability of the TextView. Tags in the
+// buffer may override this setting for some ranges of text.
+func (t *CTextView) GetEditable() (editable bool) {
+	var err error
+	if editable, err = t.GetBoolProperty(PropertyEditable); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetEditable updates the default editability of the TextView. You can
+// override this default setting with tags in the buffer, using the "editable"
+// property of TextTag.
+//
+// Parameters:
+//
+//	setting	whether it's editable
+func (t *CTextView) SetEditable(setting bool) {
+	if err := t.SetBoolProperty(PropertyEditable, setting); err != nil {
+		t.LogErr(err)
+	}
+}
+
+// GetWrapMode returns the line wrapping for the TextView.
+func (t *CTextView) GetWrapMode() (wrapMode cenums.WrapMode) {
+	wrapMode = cenums.WRAP_NONE
+	if v, err := t.GetStructProperty(PropertyWrapMode); err != nil {
+		t.LogErr(err)
+	} else if mode, ok := v.(cenums.WrapMode); ok {
+		wrapMode = mode
+	} else {
+		t.LogError("value stored in %v property is not of WrapMode type: %v (%T)", PropertyWrapMode, v, v)
+	}
+	return
+}
+
+// SetWrapMode updates the line wrapping for the TextView. WRAP_CHAR breaks
+// lines at any character, WRAP_WORD and WRAP_WORD_CHAR break lines after the
+// last space that fits, breaking words longer than a line at any character.
+// With WRAP_NONE, lines are not wrapped and the TextView scrolls horizontally.
+//
+// Parameters:
+//
+//	wrapMode	a WrapMode
+func (t *CTextView) SetWrapMode(wrapMode cenums.WrapMode) {
+	if err := t.SetStructProperty(PropertyWrapMode, wrapMode); err != nil {
+		t.LogErr(err)
+	} else {
+		t.queueResize()
+	}
+}
+
+// GetCursorVisible returns TRUE if the cursor is being displayed.
+func (t *CTextView) GetCursorVisible() (visible bool) {
+	var err error
+	if visible, err = t.GetBoolProperty(PropertyCursorVisible); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetCursorVisible toggles whether the insertion point is displayed. A buffer
+// with no editable text probably shouldn't have a visible cursor, so you may
+// want to turn the cursor off.
+//
+// Parameters:
+//
+//	setting	whether to show the insertion cursor
+func (t *CTextView) SetCursorVisible(setting bool) {
+	if err := t.SetBoolProperty(PropertyCursorVisible, setting); err != nil {
+		t.LogErr(err)
+	} else {
+		t.Invalidate()
+	}
+}
+
+// GetOverwrite returns whether the TextView is in overwrite mode or not.
+func (t *CTextView) GetOverwrite() (overwrite bool) {
+	var err error
+	if overwrite, err = t.GetBoolProperty(PropertyOverwrite); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetOverwrite changes the TextView overwrite mode. The Insert key toggles
+// overwrite mode while editing.
+//
+// Parameters:
+//
+//	overwrite	TRUE to turn on overwrite mode, FALSE to turn it off
+func (t *CTextView) SetOverwrite(overwrite bool) {
+	if err := t.SetBoolProperty(PropertyOverwrite, overwrite); err != nil {
+		t.LogErr(err)
+	}
+}
+
+// GetAcceptsTab returns whether pressing the Tab key inserts a tab character.
+func (t *CTextView) GetAcceptsTab() (acceptsTab bool) {
+	var err error
+	if acceptsTab, err = t.GetBoolProperty(PropertyAcceptsTab); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetAcceptsTab updates the behavior of the TextView when the Tab key is
+// pressed. If acceptsTab is TRUE, a tab character is inserted. If acceptsTab
+// is FALSE the keyboard focus is moved to the next widget in the focus chain.
+//
+// Parameters:
+//
+//	acceptsTab	TRUE if pressing the Tab key should insert a tab character
+func (t *CTextView) SetAcceptsTab(acceptsTab bool) {
+	if err := t.SetBoolProperty(PropertyAcceptsTab, acceptsTab); err != nil {
+		t.LogErr(err)
+	}
+}
+
+// GetLeftMargin returns the number of columns left blank before the text.
+func (t *CTextView) GetLeftMargin() (margin int) {
+	var err error
+	if margin, err = t.GetIntProperty(PropertyLeftMargin); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetLeftMargin updates the number of columns left blank before the text.
+//
+// Parameters:
+//
+//	leftMargin	left margin in columns
+func (t *CTextView) SetLeftMargin(leftMargin int) {
+	if leftMargin < 0 {
+		leftMargin = 0
+	}
+	if err := t.SetIntProperty(PropertyLeftMargin, leftMargin); err != nil {
+		t.LogErr(err)
+	} else {
+		t.queueResize()
+	}
+}
+
+// GetRightMargin returns the number of columns left blank after the text.
+func (t *CTextView) GetRightMargin() (margin int) {
+	var err error
+	if margin, err = t.GetIntProperty(PropertyRightMargin); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetRightMargin updates the number of columns left blank after the text.
+//
+// Parameters:
+//
+//	rightMargin	right margin in columns
+func (t *CTextView) SetRightMargin(rightMargin int) {
+	if rightMargin < 0 {
+		rightMargin = 0
+	}
+	if err := t.SetIntProperty(PropertyRightMargin, rightMargin); err != nil {
+		t.LogErr(err)
+	} else {
+		t.queueResize()
+	}
+}
+
+// ScrollToMark scrolls the TextView so that the mark is on the screen, in the
+// same way as ScrollToIter.
+//
+// Parameters:
+//
+//	mark	a TextMark
+//	useAlign	whether to use alignment arguments
+//	xAlign	horizontal alignment of mark within visible area
+//	yAlign	vertical alignment of mark within visible area
+func (t *CTextView) ScrollToMark(mark TextMark, useAlign bool, xAlign, yAlign float64) {
+	if buffer := t.GetBuffer(); buffer != nil && mark != nil {
+		t.ScrollToIter(buffer.GetIterAtMark(mark), useAlign, xAlign, yAlign)
+	}
+}
+
+// ScrollToIter scrolls the TextView so that the iterator is on the screen.
+// When useAlign is FALSE, the TextView scrolls as few lines and columns as
+// possible. Otherwise, the iterator is placed at the position given by the
+// alignments, ie: a yAlign of 0.5 places the line of the iterator in the
+// middle of the visible area. Returns TRUE if the TextView scrolled.
+//
+// Parameters:
+//
+//	iter	a TextIter
+//	useAlign	whether to use alignment arguments
+//	xAlign	horizontal alignment of iter within visible area
+//	yAlign	vertical alignment of iter within visible area
+func (t *CTextView) ScrollToIter(iter *TextIter, useAlign bool, xAlign, yAlign float64) (scrolled bool) {
+	if iter == nil {
+		return false
+	}
+	lines := t.getLines()
+	idx := t.lineIndexOf(iter.offset)
+	col := t.columnOf(lines[idx], iter.offset)
+	height, width := t.viewHeight(), t.textWidth()
+	if vAdjustment := t.GetVAdjustment(); vAdjustment != nil && height > 0 {
+		offset := vAdjustment.GetValue()
+		value := offset
+		if useAlign {
+			value = idx - int(yAlign*float64(height-1))
+		} else if idx < offset {
+			value = idx
+		} else if idx >= offset+height {
+			value = idx - height + 1
+		}
+		value = textViewClamp(value, len(lines)-height)
+		if value != offset {
+			vAdjustment.SetValue(value)
+			scrolled = true
+		}
+	}
+	if hAdjustment := t.GetHAdjustment(); hAdjustment != nil && width > 0 && t.GetWrapMode() == cenums.WRAP_NONE {
+		offset := hAdjustment.GetValue()
+		value := offset
+		if useAlign {
+			value = col - int(xAlign*float64(width-1))
+		} else if col < offset {
+			value = col
+		} else if col >= offset+width {
+			value = col - width + 1
+		}
+		value = textViewClamp(value, t.contentWidth()+1-width)
+		if value != offset {
+			hAdjustment.SetValue(value)
+			scrolled = true
+		}
+	}
+	if scrolled {
+		if sv := t.getScrolledViewport(); sv != nil {
+			sv.Resize()
+		} else {
+			t.Invalidate()
+		}
+	}
+	return
+}
+
+// ScrollMarkOnscreen scrolls the TextView the minimum distance such that the
+// mark is contained within the visible area of the widget.
+//
+// Parameters:
+//
+//	mark	a mark in the buffer for the TextView
+func (t *CTextView) ScrollMarkOnscreen(mark TextMark) {
+	t.ScrollToMark(mark, false, 0, 0)
+}
+
+// MoveMarkOnscreen moves a mark in the buffer so that it's located within the
+// currently-visible text area. Returns TRUE if the mark moved.
+//
+// Parameters:
+//
+//	mark	a TextMark
+func (t *CTextView) MoveMarkOnscreen(mark TextMark) (moved bool) {
+	buffer := t.GetBuffer()
+	if buffer == nil || mark == nil {
+		return false
+	}
+	iter := buffer.GetIterAtMark(mark)
+	start, end := t.GetVisibleRange()
+	if iter.offset < start.offset {
+		buffer.MoveMark(mark, start)
+		return true
+	} else if iter.offset > end.offset {
+		buffer.MoveMark(mark, end)
+		return true
+	}
+	return false
+}
+
+// PlaceCursorOnscreen moves the cursor to the currently visible region of the
+// buffer, if it isn't there already. Returns TRUE if the cursor had to be
+// moved.
+func (t *CTextView) PlaceCursorOnscreen() (moved bool) {
+	buffer := t.GetBuffer()
+	if buffer == nil {
+		return false
+	}
+	iter := buffer.GetIterAtMark(buffer.GetInsert())
+	start, end := t.GetVisibleRange()
+	if iter.offset < start.offset {
+		buffer.PlaceCursor(start)
+		return true
+	} else if iter.offset > end.offset {
+		buffer.PlaceCursor(end)
+		return true
+	}
+	return false
+}
+
+// GetVisibleRange returns iterators at the start of the first and the end of
+// the last display lines currently visible.
+func (t *CTextView) GetVisibleRange() (start, end *TextIter) {
+	buffer, _ := t.GetBuffer().Self().(*CTextBuffer)
+	lines := t.getLines()
+	first, last := t.visibleLineRange(len(lines))
+	return newTextIter(buffer, lines[first].start), newTextIter(buffer, lines[last].end)
+}
+
+// GetIterLocation returns the position of the iterator in buffer coordinates,
+// ie: the column and display line of the iterator counting from the top-left
+// corner of the entire buffer, including the left margin.
+//
+// Parameters:
+//
+//	iter	a TextIter
+func (t *CTextView) GetIterLocation(iter *TextIter) (x, y int) {
+	lines := t.getLines()
+	y = t.lineIndexOf(iter.offset)
+	x = t.GetLeftMargin() + t.columnOf(lines[y], iter.offset)
+	return
+}
+
+// GetIterAtLocation returns the iterator at the buffer coordinates given, as
+// returned by GetIterLocation.
+//
+// Parameters:
+//
+//	x	x position, in buffer coordinates
+//	y	y position, in buffer coordinates
+func (t *CTextView) GetIterAtLocation(x, y int) (iter *TextIter) {
+	buffer, _ := t.GetBuffer().Self().(*CTextBuffer)
+	lines := t.getLines()
+	if y < 0 {
+		y = 0
+	} else if y >= len(lines) {
+		y = len(lines) - 1
+	}
+	return newTextIter(buffer, t.offsetAtColumn(lines[y], x-t.GetLeftMargin()))
+}
+
+// ForwardDisplayLine moves the iterator forward by one display (wrapped) line.
+// Returns TRUE if the iterator moved and is not at the end of the buffer.
+//
+// Parameters:
+//
+//	iter	a TextIter
+func (t *CTextView) ForwardDisplayLine(iter *TextIter) (ok bool) {
+	lines := t.getLines()
+	idx := t.lineIndexOf(iter.offset)
+	if idx+1 >= len(lines) {
+		iter.ForwardToEnd()
+		return false
+	}
+	iter.offset = lines[idx+1].start
+	return !iter.IsEnd()
+}
+
+// BackwardDisplayLine moves the iterator backward by one display (wrapped)
+// line. Returns TRUE if the iterator moved.
+//
+// Parameters:
+//
+//	iter	a TextIter
+func (t *CTextView) BackwardDisplayLine(iter *TextIter) (ok bool) {
+	lines := t.getLines()
+	idx := t.lineIndexOf(iter.offset)
+	if idx == 0 {
+		moved := iter.offset != 0
+		iter.offset = 0
+		return moved
+	}
+	iter.offset = lines[idx-1].start
+	return true
+}
+
+// StartsDisplayLine returns TRUE if the iterator is at the start of a display
+// line.
+//
+// Parameters:
+//
+//	iter	a TextIter
+func (t *CTextView) StartsDisplayLine(iter *TextIter) (ok bool) {
+	lines := t.getLines()
+	return lines[t.lineIndexOf(iter.offset)].start == iter.offset
+}
+
+// SelectAll selects all the text in the buffer, or clears the selection.
+//
+// Parameters:
+//
+//	selectAll	TRUE to select all text, FALSE to clear the selection
+func (t *CTextView) SelectAll(selectAll bool) {
+	buffer := t.GetBuffer()
+	if selectAll {
+		start, end := buffer.GetBounds()
+		buffer.SelectRange(end, start)
+	} else {
+		buffer.PlaceCursor(buffer.GetIterAtMark(buffer.GetInsert()))
+	}
+}
+
+// CutClipboard copies the selected text to the clipboard of the Display and
+// deletes it if editable.
+func (t *CTextView) CutClipboard() {
+	t.GetBuffer().CutClipboard(t.getClipboard(), t.GetEditable())
+	t.scrollCursorOnscreen()
+}
+
+// CopyClipboard copies the selected text to the clipboard of the Display.
+func (t *CTextView) CopyClipboard() {
+	t.GetBuffer().CopyClipboard(t.getClipboard())
+}
+
+// PasteClipboard pastes the contents of the clipboard of the Display at the
+// cursor, replacing any selected text.
+func (t *CTextView) PasteClipboard() {
+	t.GetBuffer().PasteClipboard(t.getClipboard(), nil, t.GetEditable())
+	t.scrollCursorOnscreen()
+}
+
+// GetSizeRequest returns the requested size of the TextView. When the
+// TextView is the child of a ScrolledViewport, the size of all the display
+// lines is requested, filling any remaining space within the viewport.
+// Otherwise the size requested by the Widget is returned.
+func (t *CTextView) GetSizeRequest() (width, height int) {
+	sv := t.getScrolledViewport()
+	if sv == nil {
+		return t.CContainer.GetSizeRequest()
+	}
+	lines := t.getLines()
+	width = t.contentWidth() + 1 + t.GetLeftMargin() + t.GetRightMargin()
+	height = len(lines)
+	alloc := sv.GetAllocation()
+	viewW, viewH := alloc.W, alloc.H
+	if height > viewH {
+		viewW -= 1 // vertical scrollbar
+	}
+	if t.GetWrapMode() != cenums.WRAP_NONE {
+		width = viewW
+	}
+	if width > viewW {
+		viewH -= 1 // horizontal scrollbar
+	}
+	if width < viewW {
+		width = viewW
+	}
+	if height < viewH {
+		height = viewH
+	}
+	return
+}
+
+// GetWidgetAt returns the TextView instance if the given point is within the
+// TextView's display space bounds, nil otherwise.
+func (t *CTextView) GetWidgetAt(p *ptypes.Point2I) Widget {
+	if t.HasPoint(p) && t.IsVisible() {
+		return t
+	}
+	return nil
+}
+
+// CancelEvent cancels any pending selection with the mouse.
+func (t *CTextView) CancelEvent() {
+	if f := t.Emit(SignalCancelEvent, t); f == cenums.EVENT_PASS {
+		t.ReleaseEventFocus()
+		t.Invalidate()
+	}
+}
+
+// queueResize is used when the text or layout of the TextView has changed,
+// rebuilding the display lines as needed during the next resize or draw.
+func (t *CTextView) queueResize() {
+	t.Lock()
+	t.linesValid = false
+	t.Unlock()
+	if t.GetWindow() == nil {
+		return
+	}
+	if sv := t.getScrolledViewport(); sv != nil {
+		sv.Resize()
+		return
+	}
+	t.Resize()
+}
+
+func (t *CTextView) getScrolledViewport() ScrolledViewport {
+	if parent := t.GetParent(); parent != nil {
+		if sv, ok := parent.Self().(ScrolledViewport); ok {
+			return sv
+		}
+	}
+	return nil
+}
+
+func (t *CTextView) getClipboard() cdk.Clipboard {
+	if d := t.GetDisplay(); d != nil {
+		return d.GetClipboard()
+	}
+	return nil
+}
+
+// viewHeight returns the number of lines of the TextView actually visible
+func (t *CTextView) viewHeight() int {
+	if sv := t.getScrolledViewport(); sv != nil {
+		height := sv.GetAllocation().H
+		if sv.HorizontalShowByPolicy() {
+			height -= 1
+		}
+		return height
+	}
+	return t.GetAllocation().H
+}
+
+// viewWidth returns the number of columns of the TextView actually visible
+func (t *CTextView) viewWidth() int {
+	if sv := t.getScrolledViewport(); sv != nil {
+		width := sv.GetAllocation().W
+		if sv.VerticalShowByPolicy() {
+			width -= 1
+		}
+		return width
+	}
+	return t.GetAllocation().W
+}
+
+// textWidth returns the number of visible columns available for text
+func (t *CTextView) textWidth() int {
+	return t.viewWidth() - t.GetLeftMargin() - t.GetRightMargin()
+}
+
+// wrapWidth returns the number of columns to wrap lines at, or zero if lines
+// are not wrapped. Within a ScrolledViewport, a column is reserved for the
+// vertical scrollbar unless the policy never shows it, as the scrollbar
+// visibility depends upon the number of wrapped lines.
+func (t *CTextView) wrapWidth() int {
+	if t.GetWrapMode() == cenums.WRAP_NONE {
+		return 0
+	}
+	width := t.GetAllocation().W
+	if sv := t.getScrolledViewport(); sv != nil {
+		width = sv.GetAllocation().W
+		if _, vPolicy := sv.GetPolicy(); vPolicy != enums.PolicyNever {
+			width -= 1
+		}
+	}
+	width -= t.GetLeftMargin() + t.GetRightMargin()
+	if width < 1 {
+		return 0
+	}
+	return width
+}
+
+// getLines returns the display lines of the buffer, wrapping the text when a
+// wrap mode is set
+func (t *CTextView) getLines() []textViewLine {
+	width := t.wrapWidth()
+	t.RLock()
+	if t.linesValid && t.linesWidth == width && len(t.lines) > 0 {
+		lines := t.lines
+		t.RUnlock()
+		return lines
+	}
+	t.RUnlock()
+	buffer, _ := t.GetBuffer().Self().(*CTextBuffer)
+	text := buffer.getRunes()
+	hidden := buffer.hiddenMask(0, len(text))
+	words := t.GetWrapMode() == cenums.WRAP_WORD || t.GetWrapMode() == cenums.WRAP_WORD_CHAR
+	lines := make([]textViewLine, 0)
+	contentW := 0
+	addLine := func(start, end int) {
+		line := textViewLine{start: start, end: end}
+		line.width = textViewMeasure(text, hidden, start, end)
+		if line.width > contentW {
+			contentW = line.width
+		}
+		lines = append(lines, line)
+	}
+	start := 0
+	for start <= len(text) {
+		end := start
+		for end < len(text) && text[end] != '\n' {
+			end++
+		}
+		if width <= 0 {
+			addLine(start, end)
+		} else {
+			segment, col, lastBreak := start, 0, -1
+			for offset := start; offset < end; offset++ {
+				if hidden[offset] {
+					continue
+				}
+				w := textViewRuneWidth(text[offset], col)
+				if col+w > width && col > 0 {
+					brk := offset
+					if words && lastBreak > segment {
+						brk = lastBreak
+					}
+					addLine(segment, brk)
+					segment, lastBreak = brk, -1
+					col = textViewMeasure(text, hidden, brk, offset)
+				}
+				col += textViewRuneWidth(text[offset], col)
+				if words && text[offset] == ' ' {
+					lastBreak = offset + 1
+				}
+			}
+			addLine(segment, end)
+		}
+		start = end + 1
+	}
+	t.Lock()
+	t.lines, t.linesWidth, t.linesValid, t.contentW = lines, width, true, contentW
+	t.Unlock()
+	return lines
+}
+
+func (t *CTextView) contentWidth() int {
+	t.getLines()
+	t.RLock()
+	defer t.RUnlock()
+	return t.contentW
+}
+
+// lineIndexOf returns the index of the display line containing the offset
+func (t *CTextView) lineIndexOf(offset int) int {
+	lines := t.getLines()
+	idx := sort.Search(len(lines), func(i int) bool { return lines[i].start > offset }) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return idx
+}
+
+// columnOf returns the display column of the offset within the line
+func (t *CTextView) columnOf(line textViewLine, offset int) int {
+	buffer, _ := t.GetBuffer().Self().(*CTextBuffer)
+	text := buffer.getRunes()
+	if offset > line.end {
+		offset = line.end
+	}
+	return textViewMeasure(text, buffer.hiddenMask(0, len(text)), line.start, offset)
+}
+
+// offsetAtColumn returns the offset of the character displayed at the column
+// within the line, or the end of the line if the column is past it
+func (t *CTextView) offsetAtColumn(line textViewLine, column int) int {
+	buffer, _ := t.GetBuffer().Self().(*CTextBuffer)
+	text := buffer.getRunes()
+	hidden := buffer.hiddenMask(line.start, line.end)
+	col := 0
+	for offset := line.start; offset < line.end; offset++ {
+		if hidden[offset-line.start] {
+			continue
+		}
+		w := textViewRuneWidth(text[offset], col)
+		if col+w > column {
+			return offset
+		}
+		col += w
+	}
+	return line.end
+}
+
+// scrollValues returns the current values of the horizontal and vertical
+// adjustments and whether the TextView is the child of a ScrolledViewport
+func (t *CTextView) scrollValues() (hValue, vValue int, inViewport bool) {
+	inViewport = t.getScrolledViewport() != nil
+	if adjustment := t.GetHAdjustment(); adjustment != nil {
+		hValue = adjustment.GetValue()
+	}
+	if adjustment := t.GetVAdjustment(); adjustment != nil {
+		vValue = adjustment.GetValue()
+	}
+	return
+}
+
+// visibleLineRange returns the indices of the first and last display lines
+// scrolled into view
+func (t *CTextView) visibleLineRange(count int) (first, last int) {
+	_, first, _ = t.scrollValues()
+	first = textViewClamp(first, count-1)
+	last = first + t.viewHeight() - 1
+	if last >= count {
+		last = count - 1
+	}
+	if last < first {
+		last = first
+	}
+	return
+}
+
+// localToBuffer translates widget local coordinates into buffer coordinates
+func (t *CTextView) localToBuffer(x, y int) (bx, by int) {
+	if hValue, vValue, inViewport := t.scrollValues(); !inViewport {
+		return x + hValue, y + vValue
+	}
+	return x, y
+}
+
+// bufferToLocal translates buffer coordinates into widget local coordinates
+func (t *CTextView) bufferToLocal(x, y int) (lx, ly int) {
+	if hValue, vValue, inViewport := t.scrollValues(); !inViewport {
+		return x - hValue, y - vValue
+	}
+	return x, y
+}
+
+func (t *CTextView) scrollCursorOnscreen() {
+	if buffer := t.GetBuffer(); buffer != nil {
+		t.ScrollMarkOnscreen(buffer.GetInsert())
+	}
+}
+
+func (t *CTextView) insertMarkOffsets() (insert, bound int) {
+	buffer := t.GetBuffer()
+	return buffer.GetIterAtMark(buffer.GetInsert()).offset, buffer.GetIterAtMark(buffer.GetSelectionBound()).offset
+}
+
+// moveCursorTo places the cursor at the offset, extending the selection when
+// extend is TRUE, and scrolls the cursor onscreen
+func (t *CTextView) moveCursorTo(offset int, extend, vertical bool) {
+	buffer := t.GetBuffer()
+	iter := buffer.GetIterAtOffset(offset)
+	if extend {
+		buffer.MoveMark(buffer.GetInsert(), iter)
+	} else {
+		buffer.PlaceCursor(iter)
+	}
+	if !vertical {
+		t.Lock()
+		t.preferredX = -1
+		t.Unlock()
+	}
+	t.scrollCursorOnscreen()
+	t.Invalidate()
+}
+
+// moveCursorLines moves the cursor by the number of display lines given,
+// keeping to the same column where possible
+func (t *CTextView) moveCursorLines(delta int, extend bool) {
+	insert, _ := t.insertMarkOffsets()
+	lines := t.getLines()
+	idx := t.lineIndexOf(insert)
+	t.RLock()
+	x := t.preferredX
+	t.RUnlock()
+	if x < 0 {
+		x = t.columnOf(lines[idx], insert)
+	}
+	target := textViewClamp(idx+delta, len(lines)-1)
+	offset := t.offsetAtColumn(lines[target], x)
+	if target == idx {
+		if delta < 0 {
+			offset = lines[idx].start
+		} else if delta > 0 {
+			offset = lines[idx].end
+		}
+	}
+	t.Lock()
+	t.preferredX = x
+	t.Unlock()
+	t.moveCursorTo(offset, extend, true)
+}
+
+// insertText inserts the text at the cursor, replacing any selection, or the
+// next character when in overwrite mode
+func (t *CTextView) insertText(text string) cenums.EventFlag {
+	if !t.GetEditable() {
+		return cenums.EVENT_PASS
+	}
+	buffer := t.GetBuffer()
+	editable := t.GetEditable()
+	if buffer.HasSelection() {
+		buffer.DeleteSelection(true, editable)
+	} else if t.GetOverwrite() && text != "\n" {
+		iter := buffer.GetIterAtMark(buffer.GetInsert())
+		if !iter.EndsLine() {
+			next := iter.Copy()
+			next.ForwardChar()
+			buffer.DeleteInteractive(iter, next, editable)
+		}
+	}
+	buffer.InsertInteractiveAtCursor(text, editable)
+	t.moveCursorTo(buffer.GetIterAtMark(buffer.GetInsert()).offset, false, false)
+	return cenums.EVENT_STOP
+}
+
+func (t *CTextView) deleteBackward() cenums.EventFlag {
+	buffer := t.GetBuffer()
+	if !buffer.DeleteSelection(true, t.GetEditable()) {
+		buffer.Backspace(buffer.GetIterAtMark(buffer.GetInsert()), true, t.GetEditable())
+	}
+	t.moveCursorTo(buffer.GetIterAtMark(buffer.GetInsert()).offset, false, false)
+	return cenums.EVENT_STOP
+}
+
+func (t *CTextView) deleteForward() cenums.EventFlag {
+	buffer := t.GetBuffer()
+	if !buffer.DeleteSelection(true, t.GetEditable()) {
+		iter := buffer.GetIterAtMark(buffer.GetInsert())
+		next := iter.Copy()
+		next.ForwardChar()
+		buffer.DeleteInteractive(iter, next, t.GetEditable())
+	}
+	t.moveCursorTo(buffer.GetIterAtMark(buffer.GetInsert()).offset, false, false)
+	return cenums.EVENT_STOP
+}
+
+func (t *CTextView) bufferChanged(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	t.queueResize()
+	return cenums.EVENT_PASS
+}
+
+func (t *CTextView) markSet(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	t.Invalidate()
+	return cenums.EVENT_PASS
+}
+
+func (t *CTextView) isFocused() bool {
+	if t.HasState(enums.StateSelected) {
+		return true
+	}
+	if sv := t.getScrolledViewport(); sv != nil {
+		return sv.HasState(enums.StateSelected)
+	}
+	return false
+}
+
+func (t *CTextView) lostFocus(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	t.UnsetState(enums.StateSelected)
+	if t.HasEventFocus() {
+		t.ReleaseEventFocus()
+	}
+	t.updateCursor()
+	t.Invalidate()
+	return cenums.EVENT_PASS
+}
+
+func (t *CTextView) gainedFocus(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	t.SetState(enums.StateSelected)
+	t.Invalidate()
+	return cenums.EVENT_PASS
+}
+
+func (t *CTextView) event(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if !t.IsSensitive() {
+		return cenums.EVENT_PASS
+	}
+	if evt, ok := argv[1].(cdk.Event); ok {
+		switch e := evt.(type) {
+		case *cdk.EventPaste:
+			if !t.isFocused() {
+				return cenums.EVENT_PASS
+			}
+			t.PasteClipboard()
+			return cenums.EVENT_STOP
+		case *cdk.EventMouse:
+			return t.processMouseEvent(e)
+		case *cdk.EventKey:
+			if !t.isFocused() {
+				return cenums.EVENT_PASS
+			}
+			return t.processKeyEvent(e)
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (t *CTextView) processKeyEvent(e *cdk.EventKey) cenums.EventFlag {
+	mods := e.Modifiers()
+	shift, ctrl := mods.Has(cdk.ModShift), mods.Has(cdk.ModCtrl)
+	buffer := t.GetBuffer()
+	insert, bound := t.insertMarkOffsets()
+	lines := t.getLines()
+	page := t.viewHeight()
+	if page < 1 {
+		page = 1
+	}
+
+	switch e.Key() {
+	case cdk.KeyUp:
+		t.moveCursorLines(-1, shift)
+		return cenums.EVENT_STOP
+	case cdk.KeyDown:
+		t.moveCursorLines(1, shift)
+		return cenums.EVENT_STOP
+	case cdk.KeyPgUp:
+		t.moveCursorLines(-page, shift)
+		return cenums.EVENT_STOP
+	case cdk.KeyPgDn:
+		t.moveCursorLines(page, shift)
+		return cenums.EVENT_STOP
+	case cdk.KeyLeft:
+		iter := buffer.GetIterAtOffset(insert)
+		switch {
+		case !shift && insert != bound:
+			iter.offset = min(insert, bound)
+		case ctrl:
+			iter.BackwardWordStart()
+		default:
+			iter.BackwardChar()
+		}
+		t.moveCursorTo(iter.offset, shift, false)
+		return cenums.EVENT_STOP
+	case cdk.KeyRight:
+		iter := buffer.GetIterAtOffset(insert)
+		switch {
+		case !shift && insert != bound:
+			iter.offset = max(insert, bound)
+		case ctrl:
+			iter.ForwardWordEnd()
+		default:
+			iter.ForwardChar()
+		}
+		t.moveCursorTo(iter.offset, shift, false)
+		return cenums.EVENT_STOP
+	case cdk.KeyHome:
+		if ctrl {
+			t.moveCursorTo(0, shift, false)
+		} else {
+			t.moveCursorTo(lines[t.lineIndexOf(insert)].start, shift, false)
+		}
+		return cenums.EVENT_STOP
+	case cdk.KeyEnd:
+		if ctrl {
+			t.moveCursorTo(buffer.GetCharCount(), shift, false)
+		} else {
+			t.moveCursorTo(lines[t.lineIndexOf(insert)].end, shift, false)
+		}
+		return cenums.EVENT_STOP
+	case cdk.KeyInsert:
+		t.SetOverwrite(!t.GetOverwrite())
+		return cenums.EVENT_STOP
+	case cdk.KeyDelete:
+		return t.deleteForward()
+	}
+
+	switch r := e.Rune(); r {
+	case 10, 13:
+		return t.insertText("\n")
+	case 9:
+		if t.GetAcceptsTab() && t.GetEditable() {
+			return t.insertText("\t")
+		}
+		return cenums.EVENT_PASS
+	case 8, 127:
+		return t.deleteBackward()
+	case 1: // 'a'
+		if ctrl {
+			// ctrl + a
+			t.SelectAll(!buffer.HasSelection())
+			return cenums.EVENT_STOP
+		}
+	case 3: // 'c'
+		if ctrl {
+			// ctrl + c
+			t.CopyClipboard()
+			return cenums.EVENT_STOP
+		}
+	case 22: // 'v'
+		if ctrl {
+			// ctrl + v
+			t.PasteClipboard()
+			return cenums.EVENT_STOP
+		}
+	case 24: // 'x'
+		if ctrl {
+			// ctrl + x
+			t.CutClipboard()
+			return cenums.EVENT_STOP
+		}
+	default:
+		if e.Key() == cdk.KeyRune && r >= ' ' && !ctrl {
+			return t.insertText(string(r))
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (t *CTextView) processMouseEvent(e *cdk.EventMouse) cenums.EventFlag {
+	pos := ptypes.NewPoint2I(e.Position())
+	origin := t.GetOrigin()
+	bx, by := t.localToBuffer(pos.X-origin.X, pos.Y-origin.Y)
+	if e.IsWheelImpulse() {
+		if t.getScrolledViewport() != nil || !t.HasPoint(pos) {
+			return cenums.EVENT_PASS
+		}
+		if adjustment := t.GetVAdjustment(); adjustment != nil {
+			value := adjustment.GetValue()
+			switch e.WheelImpulse() {
+			case cdk.WheelUp:
+				value -= 1
+			case cdk.WheelDown:
+				value += 1
+			default:
+				return cenums.EVENT_PASS
+			}
+			adjustment.SetValue(textViewClamp(value, len(t.getLines())-t.viewHeight()))
+			t.Invalidate()
+			return cenums.EVENT_STOP
+		}
+		return cenums.EVENT_PASS
+	}
+	switch e.State() {
+	case cdk.BUTTON_PRESS, cdk.DRAG_START:
+		if !t.HasPoint(pos) {
+			return cenums.EVENT_PASS
+		}
+		if !t.HasState(enums.StateSelected) && t.CanFocus() {
+			t.GrabFocus()
+		}
+		iter := t.GetIterAtLocation(bx, by)
+		t.moveCursorTo(iter.offset, e.Modifiers().Has(cdk.ModShift), false)
+		t.GrabEventFocus()
+		return cenums.EVENT_STOP
+	case cdk.MOUSE_MOVE, cdk.DRAG_MOVE:
+		if t.HasEventFocus() {
+			iter := t.GetIterAtLocation(bx, by)
+			t.moveCursorTo(iter.offset, true, false)
+			return cenums.EVENT_STOP
+		}
+	case cdk.BUTTON_RELEASE, cdk.DRAG_STOP:
+		if t.HasEventFocus() {
+			t.ReleaseEventFocus()
+			t.Invalidate()
+			return cenums.EVENT_STOP
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (t *CTextView) updateCursor() {
+	w := t.GetWindow()
+	if w == nil {
+		return
+	}
+	d := w.GetDisplay()
+	if d == nil {
+		return
+	}
+	s := d.Screen()
+	if s == nil {
+		return
+	}
+	if t.isFocused() && t.GetCursorVisible() {
+		buffer := t.GetBuffer()
+		bx, by := t.GetIterLocation(buffer.GetIterAtMark(buffer.GetInsert()))
+		lx, ly := t.bufferToLocal(bx, by)
+		o := t.GetOrigin()
+		x, y := o.X+lx, o.Y+ly
+		if found := w.FindWidgetAt(ptypes.NewPoint2I(x, y)); found != nil && found.ObjectID() == t.ObjectID() {
+			s.ShowCursor(x, y)
+			return
+		}
+	}
+	s.HideCursor()
+}
+
+func (t *CTextView) resize(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	alloc := t.GetAllocation()
+	lines := t.getLines()
+	if t.getScrolledViewport() == nil {
+		if adjustment := t.GetVAdjustment(); adjustment != nil {
+			upper := textViewClamp(len(lines)-alloc.H, len(lines))
+			value, _, _, step, _, _ := adjustment.Settings()
+			adjustment.Configure(textViewClamp(value, upper), 0, upper, step, alloc.H, alloc.H)
+		}
+		if adjustment := t.GetHAdjustment(); adjustment != nil {
+			width := t.textWidth()
+			upper := 0
+			if t.GetWrapMode() == cenums.WRAP_NONE {
+				upper = textViewClamp(t.contentWidth()+1-width, t.contentWidth()+1)
+			}
+			value, _, _, step, _, _ := adjustment.Settings()
+			adjustment.Configure(textViewClamp(value, upper), 0, upper, step, width, width)
+		}
+	}
+	t.Invalidate()
+	return cenums.EVENT_STOP
+}
+
+func (t *CTextView) draw(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if surface, ok := argv[1].(*memphis.CSurface); ok {
+		alloc := t.GetAllocation()
+		if !t.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+			t.LogTrace("not visible, zero width or zero height")
+			return cenums.EVENT_PASS
+		}
+		theme := t.GetThemeRequest()
+		surface.Fill(theme)
+
+		buffer, _ := t.GetBuffer().Self().(*CTextBuffer)
+		lines := t.getLines()
+		first, last := t.visibleLineRange(len(lines))
+		text := buffer.getRunes()
+		start, end := lines[first].start, lines[last].end
+		hidden := buffer.hiddenMask(start, end)
+		selStart, selEnd, _ := buffer.GetSelectionBounds()
+		margin := t.GetLeftMargin()
+		for idx := first; idx <= last; idx++ {
+			line := lines[idx]
+			col := 0
+			for offset := line.start; offset < line.end; offset++ {
+				if hidden[offset-start] {
+					continue
+				}
+				r := text[offset]
+				w := textViewRuneWidth(r, col)
+				style := t.styleAt(buffer, theme, offset)
+				if offset >= selStart.offset && offset < selEnd.offset {
+					style = theme.Content.Active
+				}
+				x, y := t.bufferToLocal(margin+col, idx)
+				if r == '\t' {
+					for n := 0; n < w; n++ {
+						_ = surface.SetRune(x+n, y, ' ', style)
+					}
+				} else if w > 0 {
+					_ = surface.SetRune(x, y, r, style)
+				}
+				col += w
+			}
+		}
+		t.updateCursor()
+
+		if debug, _ := t.GetBoolProperty(cdk.PropertyDebug); debug {
+			surface.DebugBox(paint.ColorSilver, t.ObjectInfo())
+		}
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+// styleAt returns the theme content style with the styles of the tags applied
+// at the offset merged in order of priority, the foreground, background and
+// attributes of higher priority tags taking precedence
+func (t *CTextView) styleAt(buffer *CTextBuffer, theme paint.Theme, offset int) (style paint.Style) {
+	style = theme.Content.Normal
+	for _, tag := range buffer.tagsAt(offset) {
+		fg, bg, attrs := tag.GetStyle().Decompose()
+		if fg != paint.ColorDefault {
+			style = style.Foreground(fg)
+		}
+		if bg != paint.ColorDefault {
+			style = style.Background(bg)
+		}
+		_, _, current := style.Decompose()
+		style = style.Attributes(current | attrs)
+	}
+	return
+}
+
+// textViewRuneWidth returns the number of columns used to display the rune
+// at the column given, tabs align to the next multiple of TextViewTabWidth
+func textViewRuneWidth(r rune, col int) int {
+	if r == '\t' {
+		return TextViewTabWidth - col%TextViewTabWidth
+	}
+	return runewidth.RuneWidth(r)
+}
+
+// textViewMeasure returns the number of columns used to display the visible
+// characters within [start, end)
+func textViewMeasure(text []rune, hidden []bool, start, end int) (width int) {
+	for offset := start; offset < end; offset++ {
+		if !hidden[offset] {
+			width += textViewRuneWidth(text[offset], width)
+		}
+	}
+	return
+}
+
+// textViewClamp limits the value to the range [0, upper]
+func textViewClamp(value, upper int) int {
+	if value > upper {
+		value = upper
+	}
+	if value < 0 {
+		value = 0
+	}
+	return value
+}
+
+// textViewWrapModeFromString parses GtkWrapMode names
+func textViewWrapModeFromString(value string) cenums.WrapMode {
+	value = strings.ToLower(value)
+	value = strings.TrimPrefix(value, "gtk_wrap_")
+	switch value {
+	case "char", "1":
+		return cenums.WRAP_CHAR
+	case "word", "2":
+		return cenums.WRAP_WORD
+	case "word-char", "word_char", "3":
+		return cenums.WRAP_WORD_CHAR
+	}
+	return cenums.WRAP_NONE
+}
+
+// TextViewTabWidth is the number of columns between tab stops.
+var TextViewTabWidth = 8
+
+// The buffer which is displayed.
+// Flags: Read / Write
+const PropertyBuffer cdk.Property = "buffer"
+
+// If the insertion cursor is shown.
+// Flags: Read / Write
+// Default value: TRUE
+const PropertyCursorVisible cdk.Property = "cursor-visible"
+
+// Whether entered text overwrites existing contents.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyOverwrite cdk.Property = "overwrite"
+
+// Whether Tab will result in a tab character being entered.
+// Flags: Read / Write
+// Default value: TRUE
+const PropertyAcceptsTab cdk.Property = "accepts-tab"
+
+// Width of the left margin in columns.
+// Flags: Read / Write
+// Allowed values: >= 0
+// Default value: 0
+const PropertyLeftMargin cdk.Property = "left-margin"
+
+// Width of the right margin in columns.
+// Flags: Read / Write
+// Allowed values: >= 0
+// Default value: 0
+const PropertyRightMargin cdk.Property = "right-margin"
+
+// Whether the text can be modified by the user.
+// Flags: Read / Write
+// Default value: TRUE
+// const PropertyEditable cdk.Property = "editable"
+
+// Whether to wrap lines never, at word boundaries, or at character
+// boundaries.
+// Flags: Read / Write
+// Default value: WRAP_NONE
+// const PropertyWrapMode cdk.Property = "wrap-mode"
+
+const TextViewBufferHandle = "text-view-buffer-handler"
+const TextViewEventHandle = "text-view-event-handler"
+const TextViewLostFocusHandle = "text-view-lost-focus-handler"
+const TextViewGainedFocusHandle = "text-view-gained-focus-handler"
+const TextViewResizeHandle = "text-view-resize-handler"
+const TextViewDrawHandle = "text-view-draw-handler"
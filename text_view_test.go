@@ -0,0 +1,287 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"testing"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/go-curses/cdk/lib/ptypes"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+func TestTextView(t *testing.T) {
+	Convey("Testing Text Views", t, func() {
+		Convey("Text Buffer", func() {
+			buffer := NewTextBuffer(nil)
+			So(buffer.GetLineCount(), ShouldEqual, 1)
+			So(buffer.GetCharCount(), ShouldEqual, 0)
+			inserted := 0
+			buffer.Connect(SignalInsertText, "test-insert", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				inserted += 1
+				return cenums.EVENT_PASS
+			})
+			buffer.SetText("one\ntwo\nthree")
+			So(inserted, ShouldEqual, 1)
+			So(buffer.GetModified(), ShouldEqual, true)
+			So(buffer.GetLineCount(), ShouldEqual, 3)
+			So(buffer.GetCharCount(), ShouldEqual, 13)
+			iter := buffer.GetIterAtLineOffset(1, 1)
+			So(iter.GetOffset(), ShouldEqual, 5)
+			So(iter.GetLine(), ShouldEqual, 1)
+			So(iter.GetLineOffset(), ShouldEqual, 1)
+			So(iter.GetChar(), ShouldEqual, 'w')
+			So(iter.GetCharsInLine(), ShouldEqual, 4)
+			So(buffer.GetIterAtLineOffset(0, 10).GetOffset(), ShouldEqual, 3)
+			iter.ForwardToLineEnd()
+			So(iter.EndsLine(), ShouldEqual, true)
+			So(iter.ForwardLine(), ShouldEqual, true)
+			So(iter.GetLine(), ShouldEqual, 2)
+			So(iter.ForwardLine(), ShouldEqual, false)
+			So(iter.IsEnd(), ShouldEqual, true)
+			So(iter.BackwardWordStart(), ShouldEqual, true)
+			So(iter.StartsWord(), ShouldEqual, true)
+			So(iter.GetOffset(), ShouldEqual, 8)
+			buffer.Insert(iter, "big ")
+			So(iter.GetOffset(), ShouldEqual, 12)
+			start, end := buffer.GetBounds()
+			So(buffer.GetText(start, end, true), ShouldEqual, "one\ntwo\nbig three")
+			buffer.Delete(buffer.GetIterAtOffset(3), buffer.GetIterAtOffset(8))
+			So(buffer.GetText(buffer.GetStartIter(), buffer.GetEndIter(), true), ShouldEqual, "onebig three")
+			So(buffer.GetLineCount(), ShouldEqual, 1)
+			buffer.Connect(SignalDeleteRange, "test-delete", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				return cenums.EVENT_STOP
+			})
+			buffer.Delete(buffer.GetStartIter(), buffer.GetEndIter())
+			So(buffer.GetCharCount(), ShouldEqual, 12)
+			buffer.SetModified(false)
+			So(buffer.GetModified(), ShouldEqual, false)
+		})
+		Convey("Marks and Selection", func() {
+			buffer := NewTextBuffer(nil)
+			buffer.SetText("hello world")
+			left := buffer.CreateMark("left", buffer.GetIterAtOffset(5), true)
+			right := buffer.CreateMark("right", buffer.GetIterAtOffset(5), false)
+			So(buffer.CreateMark("left", buffer.GetStartIter(), false), ShouldBeNil)
+			So(buffer.GetMark("left"), ShouldEqual, left)
+			buffer.Insert(buffer.GetIterAtOffset(5), ",")
+			So(buffer.GetIterAtMark(left).GetOffset(), ShouldEqual, 5)
+			So(buffer.GetIterAtMark(right).GetOffset(), ShouldEqual, 6)
+			buffer.Delete(buffer.GetIterAtOffset(2), buffer.GetIterAtOffset(8))
+			So(buffer.GetIterAtMark(left).GetOffset(), ShouldEqual, 2)
+			So(buffer.GetIterAtMark(right).GetOffset(), ShouldEqual, 2)
+			buffer.DeleteMark(left)
+			So(left.GetDeleted(), ShouldEqual, true)
+			So(buffer.GetMark("left"), ShouldBeNil)
+			buffer.DeleteMark(buffer.GetInsert())
+			So(buffer.GetInsert().GetDeleted(), ShouldEqual, false)
+			buffer.SelectRange(buffer.GetIterAtOffset(4), buffer.GetIterAtOffset(1))
+			So(buffer.HasSelection(), ShouldEqual, true)
+			start, end, ok := buffer.GetSelectionBounds()
+			So(ok, ShouldEqual, true)
+			So(start.GetOffset(), ShouldEqual, 1)
+			So(end.GetOffset(), ShouldEqual, 4)
+			So(buffer.DeleteSelection(true, true), ShouldEqual, true)
+			So(buffer.GetText(buffer.GetStartIter(), buffer.GetEndIter(), true), ShouldEqual, "hld")
+			So(buffer.HasSelection(), ShouldEqual, false)
+		})
+		Convey("Tags and Search", func() {
+			buffer := NewTextBuffer(nil)
+			buffer.SetText("find the needle in the haystack")
+			bold := buffer.CreateTag("bold", paint.StyleDefault.Bold(true))
+			So(bold, ShouldNotBeNil)
+			So(buffer.CreateTag("bold", paint.StyleDefault), ShouldBeNil)
+			hidden := buffer.CreateTag("hidden", paint.StyleDefault)
+			hidden.SetInvisible(true)
+			So(buffer.GetTagTable().GetSize(), ShouldEqual, 2)
+			So(hidden.GetPriority(), ShouldEqual, 1)
+			buffer.ApplyTagByName("bold", buffer.GetIterAtOffset(9), buffer.GetIterAtOffset(15))
+			So(buffer.GetIterAtOffset(9).HasTag(bold), ShouldEqual, true)
+			So(buffer.GetIterAtOffset(9).BeginsTag(bold), ShouldEqual, true)
+			So(buffer.GetIterAtOffset(15).EndsTag(bold), ShouldEqual, true)
+			So(buffer.GetIterAtOffset(15).HasTag(bold), ShouldEqual, false)
+			buffer.Insert(buffer.GetIterAtOffset(15), "s")
+			So(buffer.GetIterAtOffset(15).HasTag(bold), ShouldEqual, true)
+			buffer.RemoveTag(bold, buffer.GetIterAtOffset(11), buffer.GetIterAtOffset(13))
+			So(buffer.GetIterAtOffset(10).HasTag(bold), ShouldEqual, true)
+			So(buffer.GetIterAtOffset(12).HasTag(bold), ShouldEqual, false)
+			So(buffer.GetIterAtOffset(13).HasTag(bold), ShouldEqual, true)
+
+			start, end, ok := buffer.GetStartIter().ForwardSearch("the", 0, nil)
+			So(ok, ShouldEqual, true)
+			So(start.GetOffset(), ShouldEqual, 5)
+			So(end.GetOffset(), ShouldEqual, 8)
+			start, _, ok = buffer.GetEndIter().BackwardSearch("the", 0, nil)
+			So(ok, ShouldEqual, true)
+			So(start.GetOffset(), ShouldEqual, 20)
+			_, _, ok = buffer.GetStartIter().ForwardSearch("the", 0, buffer.GetIterAtOffset(7))
+			So(ok, ShouldEqual, false)
+
+			// hide "needles " so "the in" is only found within visible text
+			buffer.ApplyTag(hidden, buffer.GetIterAtOffset(9), buffer.GetIterAtOffset(17))
+			So(buffer.GetText(buffer.GetStartIter(), buffer.GetEndIter(), false), ShouldEqual, "find the in the haystack")
+			_, _, ok = buffer.GetStartIter().ForwardSearch("the in", 0, nil)
+			So(ok, ShouldEqual, false)
+			start, end, ok = buffer.GetStartIter().ForwardSearch("the in", enums.TEXT_SEARCH_VISIBLE_ONLY, nil)
+			So(ok, ShouldEqual, true)
+			So(start.GetOffset(), ShouldEqual, 5)
+			So(end.GetOffset(), ShouldEqual, 19)
+			buffer.GetTagTable().Remove(hidden)
+			So(buffer.GetText(buffer.GetStartIter(), buffer.GetEndIter(), false), ShouldEqual, "find the needles in the haystack")
+		})
+		Convey("Wrapping and Scrolling", func() {
+			view := NewTextView()
+			buffer := view.GetBuffer()
+			buffer.SetText("the quick brown fox jumps\nover\nthe lazy dog")
+			view.SetAllocation(ptypes.MakeRectangle(10, 2))
+			view.Resize()
+			tv := view.(*CTextView)
+			So(tv.getLines(), ShouldHaveLength, 3)
+			view.SetWrapMode(cenums.WRAP_WORD)
+			So(tv.getLines(), ShouldHaveLength, 6)
+			x, y := view.GetIterLocation(buffer.GetIterAtOffset(10))
+			So(x, ShouldEqual, 0)
+			So(y, ShouldEqual, 1)
+			So(view.GetIterAtLocation(3, 1).GetOffset(), ShouldEqual, 13)
+			iter := buffer.GetStartIter()
+			So(view.ForwardDisplayLine(iter), ShouldEqual, true)
+			So(iter.GetOffset(), ShouldEqual, 10)
+			So(view.StartsDisplayLine(iter), ShouldEqual, true)
+			view.SetWrapMode(cenums.WRAP_CHAR)
+			So(tv.getLines(), ShouldHaveLength, 6)
+			x, y = view.GetIterLocation(buffer.GetIterAtOffset(10))
+			So(x, ShouldEqual, 0)
+			So(y, ShouldEqual, 1)
+
+			view.SetWrapMode(cenums.WRAP_WORD)
+			view.Resize()
+			So(view.GetVAdjustment().GetValue(), ShouldEqual, 0)
+			view.SetState(enums.StateSelected)
+			view.ProcessEvent(cdk.NewEventKey(cdk.KeyHome, 0, cdk.ModCtrl))
+			view.ProcessEvent(cdk.NewEventKey(cdk.KeyDown, 0, cdk.ModNone))
+			view.ProcessEvent(cdk.NewEventKey(cdk.KeyDown, 0, cdk.ModNone))
+			So(view.GetVAdjustment().GetValue(), ShouldEqual, 1)
+			view.ProcessEvent(cdk.NewEventKey(cdk.KeyEnd, 0, cdk.ModCtrl))
+			So(buffer.GetIterAtMark(buffer.GetInsert()).IsEnd(), ShouldEqual, true)
+			So(view.GetVAdjustment().GetValue(), ShouldEqual, 4)
+			view.ProcessEvent(cdk.NewEventKey(cdk.KeyPgUp, 0, cdk.ModNone))
+			So(view.GetVAdjustment().GetValue(), ShouldEqual, 3)
+			start, end := view.GetVisibleRange()
+			So(start.GetOffset(), ShouldEqual, 26)
+			So(end.GetOffset(), ShouldEqual, 40)
+			view.ProcessEvent(cdk.NewEventMouse(0, 0, cdk.WheelUp, cdk.ModNone))
+			So(view.GetVAdjustment().GetValue(), ShouldEqual, 2)
+		})
+		Convey("Editing", func() {
+			view := NewTextView()
+			buffer := view.GetBuffer()
+			view.SetAllocation(ptypes.MakeRectangle(20, 5))
+			view.SetState(enums.StateSelected)
+			for _, r := range "hello" {
+				view.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, r, cdk.ModNone))
+			}
+			view.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, rune(cdk.KeyEnter), cdk.ModNone))
+			view.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 'x', cdk.ModNone))
+			So(buffer.GetText(buffer.GetStartIter(), buffer.GetEndIter(), true), ShouldEqual, "hello\nx")
+			view.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 127, cdk.ModNone))
+			view.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 127, cdk.ModNone))
+			So(buffer.GetText(buffer.GetStartIter(), buffer.GetEndIter(), true), ShouldEqual, "hello")
+			view.ProcessEvent(cdk.NewEventKey(cdk.KeyHome, 0, cdk.ModNone))
+			view.ProcessEvent(cdk.NewEventKey(cdk.KeyRight, 0, cdk.ModShift))
+			view.ProcessEvent(cdk.NewEventKey(cdk.KeyRight, 0, cdk.ModShift))
+			So(buffer.HasSelection(), ShouldEqual, true)
+			view.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 'J', cdk.ModNone))
+			So(buffer.GetText(buffer.GetStartIter(), buffer.GetEndIter(), true), ShouldEqual, "Jllo")
+			view.SetOverwrite(true)
+			view.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 'e', cdk.ModNone))
+			So(buffer.GetText(buffer.GetStartIter(), buffer.GetEndIter(), true), ShouldEqual, "Jelo")
+			view.ProcessEvent(cdk.NewEventKey(cdk.KeyDelete, 0, cdk.ModNone))
+			So(buffer.GetText(buffer.GetStartIter(), buffer.GetEndIter(), true), ShouldEqual, "Jeo")
+			view.SetEditable(false)
+			view.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 'z', cdk.ModNone))
+			So(buffer.GetText(buffer.GetStartIter(), buffer.GetEndIter(), true), ShouldEqual, "Jeo")
+			view.SetEditable(true)
+			locked := buffer.CreateTag("locked", paint.StyleDefault)
+			locked.SetEditable(false)
+			buffer.ApplyTag(locked, buffer.GetStartIter(), buffer.GetIterAtOffset(2))
+			buffer.PlaceCursor(buffer.GetIterAtOffset(1))
+			view.ProcessEvent(cdk.NewEventKey(cdk.KeyDelete, 0, cdk.ModNone))
+			So(buffer.GetText(buffer.GetStartIter(), buffer.GetEndIter(), true), ShouldEqual, "Jeo")
+		})
+		Convey("Clipboard", func() {
+			clipboard := &testTextClipboard{}
+			buffer := NewTextBuffer(nil)
+			buffer.SetText("copy paste")
+			buffer.SelectRange(buffer.GetStartIter(), buffer.GetIterAtOffset(5))
+			buffer.CopyClipboard(clipboard)
+			So(clipboard.GetText(), ShouldEqual, "copy ")
+			buffer.PasteClipboard(clipboard, buffer.GetEndIter(), true)
+			So(buffer.GetText(buffer.GetStartIter(), buffer.GetEndIter(), true), ShouldEqual, "copy pastecopy ")
+			buffer.SelectRange(buffer.GetIterAtOffset(5), buffer.GetIterAtOffset(10))
+			buffer.CutClipboard(clipboard, true)
+			So(clipboard.GetText(), ShouldEqual, "paste")
+			So(buffer.GetText(buffer.GetStartIter(), buffer.GetEndIter(), true), ShouldEqual, "copy copy ")
+			buffer.PasteClipboard(clipboard, nil, true)
+			So(buffer.GetText(buffer.GetStartIter(), buffer.GetEndIter(), true), ShouldEqual, "copy pastecopy ")
+			So(buffer.GetIterAtMark(buffer.GetInsert()).GetOffset(), ShouldEqual, 10)
+		})
+		Convey("Builder", func() {
+			builder := NewBuilder()
+			_, err := builder.LoadFromString(`<interface>
+  <object class="GtkTextBuffer" id="test-text-buffer">
+    <property name="text">builder text</property>
+  </object>
+  <object class="GtkTextView" id="test-text-view">
+    <property name="buffer">test-text-buffer</property>
+    <property name="wrap_mode">GTK_WRAP_WORD_CHAR</property>
+    <property name="editable">False</property>
+  </object>
+</interface>`)
+			So(err, ShouldBeNil)
+			view, ok := builder.GetWidget("test-text-view").(TextView)
+			So(ok, ShouldEqual, true)
+			So(view.GetWrapMode(), ShouldEqual, cenums.WRAP_WORD_CHAR)
+			So(view.GetEditable(), ShouldEqual, false)
+			buffer := view.GetBuffer()
+			So(buffer.GetText(buffer.GetStartIter(), buffer.GetEndIter(), true), ShouldEqual, "builder text")
+		})
+	})
+}
+
+type testTextClipboard struct {
+	cdk.CObject
+
+	text string
+}
+
+func (c *testTextClipboard) GetText() (text string) {
+	return c.text
+}
+
+func (c *testTextClipboard) SetText(text string) {
+	c.text = text
+}
+
+func (c *testTextClipboard) Copy(text string) {
+	c.text = text
+}
+
+func (c *testTextClipboard) Paste(text string) {
+	c.text = text
+}
@@ -58,6 +58,7 @@ func MakeToggleAction() ToggleAction {
 func NewToggleAction(name string, label string, tooltip string, stockId string) (value ToggleAction) {
 	t := new(CToggleAction)
 	t.Init()
+	t.setup(name, label, tooltip, stockId)
 	return t
 }
 
@@ -85,14 +86,31 @@ func (t *CToggleAction) Toggled() {
 	t.Emit(SignalToggled, t)
 }
 
-// SetActive updates the checked state on the toggle action.
+// Activate toggles the checked state of the action and then emits the
+// "activate" signal, if the action is sensitive.
+func (t *CToggleAction) Activate() {
+	if !t.canActivate() {
+		return
+	}
+	t.SetActive(!t.GetActive())
+	t.CAction.Activate()
+}
+
+// SetActive updates the checked state on the toggle action, emitting the
+// "toggled" signal and updating all proxies if the state changed.
 //
 // Parameters:
 // 	isActive	whether the action should be checked or not
 func (t *CToggleAction) SetActive(isActive bool) {
+	if t.GetActive() == isActive {
+		return
+	}
 	if err := t.SetBoolProperty(PropertyActive, isActive); err != nil {
 		t.LogErr(err)
+		return
 	}
+	t.Toggled()
+	t.syncProxies()
 }
 
 // GetActive returns the checked state of the toggle action.
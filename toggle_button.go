@@ -0,0 +1,438 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/go-curses/cdk/lib/ptypes"
+	cstrings "github.com/go-curses/cdk/lib/strings"
+	"github.com/go-curses/cdk/memphis"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeToggleButton cdk.CTypeTag = "ctk-toggle-button"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeToggleButton, func() interface{} { return MakeToggleButton() })
+}
+
+// ToggleButton Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Bin
+//	        +- Button
+//	          +- ToggleButton
+//	            +- CheckButton
+//	              +- RadioButton
+//
+// A ToggleButton is a Button which retains its state. Each click of the
+// ToggleButton flips the active state and emits the "toggled" signal. While
+// active, the ToggleButton is drawn with the active theme. When the
+// draw-indicator property is set, the ToggleButton is instead drawn as a
+// discrete toggle indicator followed by the label, ie: "[x] Label".
+type ToggleButton interface {
+	Button
+
+	GetMode() (value bool)
+	SetMode(drawIndicator bool)
+	GetActive() (value bool)
+	SetActive(isActive bool)
+	GetInconsistent() (value bool)
+	SetInconsistent(setting bool)
+	Toggled()
+}
+
+var _ ToggleButton = (*CToggleButton)(nil)
+
+// The CToggleButton structure implements the ToggleButton interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with ToggleButton objects.
+type CToggleButton struct {
+	CButton
+}
+
+// MakeToggleButton is used by the Buildable system to construct a new
+// ToggleButton with a default label that is empty.
+func MakeToggleButton() ToggleButton {
+	return NewToggleButtonWithLabel("")
+}
+
+// NewToggleButton creates a new ToggleButton without a label Widget.
+func NewToggleButton() ToggleButton {
+	t := new(CToggleButton)
+	t.Init()
+	return t
+}
+
+// NewToggleButtonWithLabel creates a new ToggleButton with a Label containing
+// the given text.
+//
+// Parameters:
+//
+//	label	the text of the button
+func NewToggleButtonWithLabel(label string) ToggleButton {
+	t := NewToggleButton()
+	buttonAddLabel(t, label)
+	return t
+}
+
+// NewToggleButtonWithMnemonic creates a new ToggleButton containing a label.
+// Underscores in the label indicate the mnemonic for the button.
+//
+// Parameters:
+//
+//	label	the text of the button, with an underscore in front of the
+//	        mnemonic character
+func NewToggleButtonWithMnemonic(label string) ToggleButton {
+	t := NewToggleButtonWithLabel(label)
+	t.SetUseUnderline(true)
+	return t
+}
+
+// Init initializes a ToggleButton object. This must be called at least once
+// to set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the ToggleButton instance. Init is used in the
+// NewToggleButton constructor and only necessary when implementing a
+// derivative ToggleButton type.
+func (t *CToggleButton) Init() (already bool) {
+	if t.InitTypeItem(TypeToggleButton, t) {
+		return true
+	}
+	t.CButton.Init()
+	_ = t.InstallBuildableProperty(PropertyActive, cdk.BoolProperty, true, false)
+	_ = t.InstallBuildableProperty(PropertyInconsistent, cdk.BoolProperty, true, false)
+	_ = t.InstallBuildableProperty(PropertyDrawIndicator, cdk.BoolProperty, true, false)
+	t.Connect(SignalClicked, ToggleButtonClickedHandle, t.clicked)
+	t.Connect(SignalGetThemeRequest, ToggleButtonThemeRequestHandle, t.themeRequest)
+	_ = t.Disconnect(SignalResize, ButtonResizeHandle)
+	t.Connect(SignalResize, ToggleButtonResizeHandle, t.resize)
+	_ = t.Disconnect(SignalDraw, ButtonDrawHandle)
+	t.Connect(SignalDraw, ToggleButtonDrawHandle, t.draw)
+	return false
+}
+
+// Build provides customizations to the Buildable system for ToggleButton
+// Widgets. The active property is applied last, after any other properties
+// (ie: the group of a RadioButton) have been applied.
+func (t *CToggleButton) Build(builder Builder, element *CBuilderElement) error {
+	t.Freeze()
+	defer t.Thaw()
+	if name, ok := element.Attributes["id"]; ok {
+		t.SetName(name)
+	}
+	if v, ok := element.Properties[PropertyUseStock.String()]; ok {
+		t.SetUseStock(cstrings.IsTrue(v))
+		t.SetUseUnderline(true)
+	}
+	if v, ok := element.Properties[PropertyUseUnderline.String()]; ok {
+		t.SetUseUnderline(cstrings.IsTrue(v))
+	}
+	if v, ok := element.Properties[PropertyLabel.String()]; ok {
+		t.SetLabel(v)
+	}
+	for k, v := range element.Properties {
+		switch cdk.Property(k) {
+		case PropertyLabel:
+		case PropertyUseStock:
+		case PropertyUseUnderline:
+		case PropertyActive:
+		case PropertyInconsistent:
+			t.SetInconsistent(cstrings.IsTrue(v))
+		case PropertyDrawIndicator:
+			t.SetMode(cstrings.IsTrue(v))
+		default:
+			element.ApplyProperty(k, v)
+		}
+	}
+	if v, ok := element.Properties[PropertyActive.String()]; ok {
+		if tb, ok := t.Self().(ToggleButton); ok {
+			tb.SetActive(cstrings.IsTrue(v))
+		}
+	}
+	element.ApplySignals()
+	return nil
+}
+
+// Activate is used by the mnemonic system to toggle the ToggleButton, as if
+// it had been clicked.
+func (t *CToggleButton) Activate() (value bool) {
+	if !t.IsVisible() || !t.IsSensitive() {
+		return false
+	}
+	t.Clicked()
+	return true
+}
+
+// GetMode retrieves whether the button is displayed as a separate indicator
+// and label. See SetMode.
+func (t *CToggleButton) GetMode() (value bool) {
+	var err error
+	if value, err = t.GetBoolProperty(PropertyDrawIndicator); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetMode updates whether the button is displayed as a separate indicator and
+// label. CheckButton and RadioButton draw the indicator by default, while a
+// plain ToggleButton is drawn like a Button.
+//
+// Parameters:
+//
+//	drawIndicator	if TRUE, draw the button as a separate indicator and label
+func (t *CToggleButton) SetMode(drawIndicator bool) {
+	if err := t.SetBoolProperty(PropertyDrawIndicator, drawIndicator); err != nil {
+		t.LogErr(err)
+	}
+	t.Invalidate()
+}
+
+// GetActive returns TRUE if the ToggleButton is pressed in and FALSE if it is
+// raised.
+func (t *CToggleButton) GetActive() (value bool) {
+	var err error
+	if value, err = t.GetBoolProperty(PropertyActive); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetActive updates the status of the ToggleButton. Set to TRUE if you want
+// the ToggleButton to be pressed in, and FALSE to raise it. This emits the
+// "toggled" signal if the status changes.
+//
+// Parameters:
+//
+//	isActive	TRUE or FALSE
+func (t *CToggleButton) SetActive(isActive bool) {
+	if t.GetActive() == isActive {
+		return
+	}
+	if err := t.SetBoolProperty(PropertyActive, isActive); err != nil {
+		t.LogErr(err)
+		return
+	}
+	t.Toggled()
+}
+
+// GetInconsistent returns the value set by SetInconsistent.
+func (t *CToggleButton) GetInconsistent() (value bool) {
+	var err error
+	if value, err = t.GetBoolProperty(PropertyInconsistent); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetInconsistent updates the inconsistent state. If the user has selected a
+// range of elements (such as some text or spreadsheet cells) that are affected
+// by a ToggleButton, and the current values in that range are inconsistent,
+// you may want to display the ToggleButton in an "in between" state, ie:
+// "[-]". This function turns on "in between" display. Normally you would turn
+// off the inconsistent state again if the user toggles the ToggleButton. This
+// has to be done manually, SetInconsistent only affects visual appearance, it
+// doesn't affect the semantics of the button.
+//
+// Parameters:
+//
+//	setting	TRUE if state is inconsistent
+func (t *CToggleButton) SetInconsistent(setting bool) {
+	if err := t.SetBoolProperty(PropertyInconsistent, setting); err != nil {
+		t.LogErr(err)
+	}
+	t.Invalidate()
+}
+
+// Toggled emits the "toggled" signal on the ToggleButton. There is no good
+// reason for an application ever to call this function.
+func (t *CToggleButton) Toggled() {
+	t.Emit(SignalToggled, t, t.GetActive())
+	t.Invalidate()
+}
+
+// GetSizeRequest returns the requested size of the ToggleButton. When drawing
+// an indicator, the ToggleButton requests a single line wide enough for the
+// indicator and the child Widget.
+func (t *CToggleButton) GetSizeRequest() (width, height int) {
+	if !t.GetMode() {
+		return t.CButton.GetSizeRequest()
+	}
+	width, height = t.CWidget.GetSizeRequest()
+	if width <= -1 {
+		width = toggleButtonIndicatorSize
+		if child := t.GetChild(); child != nil {
+			cw := 0
+			if label, ok := child.Self().(Label); ok {
+				cw, _ = label.GetPlainTextInfo()
+			} else {
+				cw, _ = child.GetSizeRequest()
+			}
+			if cw > 0 {
+				width += 1 + cw
+			}
+		}
+	}
+	if height <= -1 {
+		height = 1
+	}
+	return
+}
+
+// getIndicator returns the text drawn for the toggle indicator, ie: "[x]" for
+// an active CheckButton and "( )" for an inactive RadioButton.
+func (t *CToggleButton) getIndicator() (indicator string) {
+	open, mark, closed := "[", " ", "]"
+	_, isRadio := t.Self().(RadioButton)
+	if isRadio {
+		open, closed = "(", ")"
+	}
+	if t.GetInconsistent() {
+		mark = "-"
+	} else if t.GetActive() {
+		mark = "x"
+		if isRadio {
+			mark = "*"
+		}
+	}
+	indicator = open + mark + closed
+	return
+}
+
+func (t *CToggleButton) clicked(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if tb, ok := t.Self().(ToggleButton); ok {
+		tb.SetActive(!tb.GetActive())
+	}
+	return cenums.EVENT_PASS
+}
+
+func (t *CToggleButton) themeRequest(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if t.GetMode() || !t.GetActive() || t.HasState(enums.StateInsensitive) {
+		return cenums.EVENT_PASS
+	}
+	if theme, ok := argv[0].(*paint.Theme); ok {
+		if modified, ok := argv[1].(paint.Theme); ok {
+			*theme = modified
+			theme.Content.Normal = modified.Content.Active
+			theme.Border.Normal = modified.Border.Active
+			return cenums.EVENT_STOP
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (t *CToggleButton) resize(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if !t.GetMode() {
+		return t.CButton.resize(data, argv...)
+	}
+	child := t.GetChild()
+	if child == nil {
+		t.Invalidate()
+		return cenums.EVENT_STOP
+	}
+	alloc := t.GetAllocation()
+	origin := t.GetOrigin()
+	offset := toggleButtonIndicatorSize + 1
+	if alloc.W <= offset || alloc.H <= 0 {
+		child.SetAllocation(ptypes.MakeRectangle(0, 0))
+		return child.Resize()
+	}
+	if label, ok := child.Self().(Label); ok {
+		label.SetJustify(cenums.JUSTIFY_LEFT)
+		label.SetAlignment(0.0, 0.5)
+	}
+	child.SetOrigin(origin.X+offset, origin.Y+(alloc.H-1)/2)
+	child.SetAllocation(ptypes.MakeRectangle(alloc.W-offset, 1))
+	child.Resize()
+	t.Invalidate()
+	return cenums.EVENT_STOP
+}
+
+func (t *CToggleButton) draw(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if !t.GetMode() {
+		return t.CButton.draw(data, argv...)
+	}
+
+	if surface, ok := argv[1].(*memphis.CSurface); ok {
+		alloc := t.GetAllocation()
+		if !t.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+			t.LogTrace("not visible, zero width or zero height")
+			surface.Fill(t.GetTheme())
+			return cenums.EVENT_STOP
+		}
+
+		theme := t.GetThemeRequest()
+		surface.Fill(theme)
+
+		indicator := t.getIndicator()
+		surface.DrawSingleLineText(ptypes.MakePoint2I(0, (alloc.H-1)/2), alloc.W, false, cenums.JUSTIFY_LEFT, theme.Content.Normal, false, false, indicator)
+
+		if child := t.GetChild(); child != nil && child.IsVisible() {
+			child.SetTheme(theme)
+			child.Draw()
+			child.LockDraw()
+			if err := surface.Composite(child.ObjectID()); err != nil {
+				t.LogError("composite error: %v", err)
+			}
+			child.UnlockDraw()
+		}
+
+		if debug, _ := t.GetBoolProperty(cdk.PropertyDebug); debug {
+			surface.DebugBox(paint.ColorRed, t.ObjectInfo())
+		}
+
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+// toggleButtonIndicatorSize is the width of the toggle indicator, ie: "[x]".
+const toggleButtonIndicatorSize = 3
+
+// If the toggle button should be pressed in or not.
+// Flags: Read / Write
+// Default value: FALSE
+// const PropertyActive cdk.Property = "active"
+
+// If the toggle part of the button is displayed.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyDrawIndicator cdk.Property = "draw-indicator"
+
+// If the toggle button is in an "in between" state.
+// Flags: Read / Write
+// Default value: FALSE
+// const PropertyInconsistent cdk.Property = "inconsistent"
+
+// Should be connected if you wish to perform an action whenever the
+// ToggleButton's state is changed.
+// Listener function arguments:
+//
+//	active bool	the new active state
+// const SignalToggled cdk.Signal = "toggled"
+
+const ToggleButtonClickedHandle = "toggle-button-clicked-handler"
+
+const ToggleButtonThemeRequestHandle = "toggle-button-theme-request-handler"
+
+const ToggleButtonResizeHandle = "toggle-button-resize-handler"
+
+const ToggleButtonDrawHandle = "toggle-button-draw-handler"
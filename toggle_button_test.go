@@ -0,0 +1,136 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"testing"
+
+	cenums "github.com/go-curses/cdk/lib/enums"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestToggleButton(t *testing.T) {
+	Convey("Testing Toggle Buttons", t, func() {
+		Convey("Basics", func() {
+			tb := &CToggleButton{}
+			So(tb.Init(), ShouldEqual, false)
+			So(tb.Init(), ShouldEqual, true)
+			button := NewToggleButtonWithMnemonic("_Bold")
+			button.Show()
+			So(button.GetMode(), ShouldEqual, false)
+			So(button.GetActive(), ShouldEqual, false)
+			toggled := 0
+			button.Connect(SignalToggled, "test-toggled", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				toggled += 1
+				return cenums.EVENT_PASS
+			})
+			button.Clicked()
+			So(button.GetActive(), ShouldEqual, true)
+			So(toggled, ShouldEqual, 1)
+			button.SetActive(true)
+			So(toggled, ShouldEqual, 1)
+			So(button.Activate(), ShouldEqual, true)
+			So(button.GetActive(), ShouldEqual, false)
+			So(toggled, ShouldEqual, 2)
+			button.SetSensitive(false)
+			button.Clicked()
+			So(button.GetActive(), ShouldEqual, false)
+		})
+		Convey("Check Buttons", func() {
+			check := NewCheckButtonWithMnemonic("_Wrap")
+			So(check.GetMode(), ShouldEqual, true)
+			So(check.(*CCheckButton).getIndicator(), ShouldEqual, "[ ]")
+			w, h := check.GetSizeRequest()
+			So(w, ShouldEqual, 8)
+			So(h, ShouldEqual, 1)
+			check.Clicked()
+			So(check.GetActive(), ShouldEqual, true)
+			So(check.(*CCheckButton).getIndicator(), ShouldEqual, "[x]")
+			check.SetInconsistent(true)
+			So(check.GetInconsistent(), ShouldEqual, true)
+			So(check.(*CCheckButton).getIndicator(), ShouldEqual, "[-]")
+			w, _ = NewCheckButton().GetSizeRequest()
+			So(w, ShouldEqual, 3)
+		})
+		Convey("Radio Buttons", func() {
+			one := NewRadioButtonWithLabel(nil, "One")
+			two := NewRadioButtonWithLabelFromWidget(one, "Two")
+			three := NewRadioButtonWithMnemonic(one.GetGroup(), "_Three")
+			So(one.GetGroup(), ShouldHaveLength, 3)
+			So(one.GetActive(), ShouldEqual, true)
+			So(two.GetActive(), ShouldEqual, false)
+			So(one.(*CRadioButton).getIndicator(), ShouldEqual, "(*)")
+			So(two.(*CRadioButton).getIndicator(), ShouldEqual, "( )")
+			toggled := 0
+			one.Connect(SignalToggled, "test-toggled", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				toggled += 1
+				return cenums.EVENT_PASS
+			})
+			three.Clicked()
+			So(three.GetActive(), ShouldEqual, true)
+			So(one.GetActive(), ShouldEqual, false)
+			So(toggled, ShouldEqual, 1)
+			three.Clicked()
+			So(three.GetActive(), ShouldEqual, true)
+			three.SetActive(false)
+			So(three.GetActive(), ShouldEqual, true)
+			two.SetActive(true)
+			So(two.GetActive(), ShouldEqual, true)
+			So(three.GetActive(), ShouldEqual, false)
+			three.SetGroup(nil)
+			So(three.GetActive(), ShouldEqual, true)
+			So(one.GetGroup(), ShouldHaveLength, 2)
+		})
+		Convey("Builder", func() {
+			builder := NewBuilder()
+			_, err := builder.LoadFromString(`<interface>
+  <object class="GtkVBox" id="test-toggle-box">
+    <child>
+      <object class="GtkCheckButton" id="test-toggle-check">
+        <property name="label">_Check</property>
+        <property name="use_underline">True</property>
+        <property name="active">True</property>
+        <property name="draw_indicator">True</property>
+      </object>
+    </child>
+    <child>
+      <object class="GtkRadioButton" id="test-toggle-radio-one">
+        <property name="label">One</property>
+      </object>
+    </child>
+    <child>
+      <object class="GtkRadioButton" id="test-toggle-radio-two">
+        <property name="label">Two</property>
+        <property name="active">True</property>
+        <property name="group">test-toggle-radio-one</property>
+      </object>
+    </child>
+  </object>
+</interface>`)
+			So(err, ShouldBeNil)
+			check, ok := builder.GetWidget("test-toggle-check").(CheckButton)
+			So(ok, ShouldEqual, true)
+			So(check.GetActive(), ShouldEqual, true)
+			So(check.GetUseUnderline(), ShouldEqual, true)
+			one, ok := builder.GetWidget("test-toggle-radio-one").(RadioButton)
+			So(ok, ShouldEqual, true)
+			two, ok := builder.GetWidget("test-toggle-radio-two").(RadioButton)
+			So(ok, ShouldEqual, true)
+			So(two.GetGroup(), ShouldHaveLength, 2)
+			So(two.GetActive(), ShouldEqual, true)
+			So(one.GetActive(), ShouldEqual, false)
+		})
+	})
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/paint"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeToggleToolButton cdk.CTypeTag = "ctk-toggle-tool-button"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeToggleToolButton, func() interface{} { return MakeToggleToolButton() })
+}
+
+// ToggleToolButton Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Bin
+//	        +- ToolItem
+//	          +- ToolButton
+//	            +- ToggleToolButton
+//
+// A ToggleToolButton is a ToolItem that contains a toggle button. Each click
+// of the button flips the active state and the "toggled" signal is emitted.
+// While active, the button is drawn with the active theme. In the toolbar
+// overflow menu, a ToggleToolButton is represented by a CheckMenuItem.
+type ToggleToolButton interface {
+	ToolButton
+
+	GetActive() (value bool)
+	SetActive(isActive bool)
+	Toggled()
+}
+
+var _ ToggleToolButton = (*CToggleToolButton)(nil)
+
+// The CToggleToolButton structure implements the ToggleToolButton interface
+// and is exported to facilitate type embedding with custom implementations. No
+// member variables are exported as the interface methods are the only intended
+// means of interacting with ToggleToolButton objects.
+type CToggleToolButton struct {
+	CToolButton
+}
+
+// MakeToggleToolButton is used by the Buildable system to construct a new
+// ToggleToolButton.
+func MakeToggleToolButton() ToggleToolButton {
+	return NewToggleToolButton("")
+}
+
+// NewToggleToolButton creates a new ToggleToolButton using the given label
+// text.
+//
+// Parameters:
+//
+//	label	a string that will be used as label, or ""
+func NewToggleToolButton(label string) ToggleToolButton {
+	t := new(CToggleToolButton)
+	t.Init()
+	t.SetLabel(label)
+	return t
+}
+
+// NewToggleToolButtonFromStock creates a new ToggleToolButton containing the
+// label and icon of the given stock item.
+//
+// Parameters:
+//
+//	stockId	the name of the stock item
+func NewToggleToolButtonFromStock(stockId StockID) ToggleToolButton {
+	t := new(CToggleToolButton)
+	t.Init()
+	t.SetStockId(stockId)
+	return t
+}
+
+// Init initializes a ToggleToolButton object. This must be called at least
+// once to set up the necessary defaults and allocate any memory structures.
+// Calling this more than once is safe though unnecessary. Only the first call
+// will result in any effect upon the ToggleToolButton instance. Init is used in
+// the NewToggleToolButton constructor and only necessary when implementing a
+// derivative ToggleToolButton type.
+func (t *CToggleToolButton) Init() (already bool) {
+	if t.InitTypeItem(TypeToggleToolButton, t) {
+		return true
+	}
+	t.CToolButton.Init()
+	_ = t.InstallBuildableProperty(PropertyActive, cdk.BoolProperty, true, false)
+	_ = t.Disconnect(SignalCreateMenuProxy, ToolButtonCreateMenuProxyHandle)
+	t.Connect(SignalCreateMenuProxy, ToggleToolButtonCreateMenuProxyHandle, t.createMenuProxy)
+	t.GetButton().Connect(SignalGetThemeRequest, fmt.Sprintf("%v-%v", ToggleToolButtonThemeRequestHandle, t.ObjectID()), t.themeRequest)
+	return false
+}
+
+// Clicked flips the active state of the ToggleToolButton and then emits the
+// "clicked" signal, if the ToggleToolButton is sensitive.
+func (t *CToggleToolButton) Clicked() cenums.EventFlag {
+	if !t.IsSensitive() {
+		return cenums.EVENT_PASS
+	}
+	t.SetActive(!t.GetActive())
+	return t.CToolButton.Clicked()
+}
+
+// Activate is a convenience method for Clicked.
+func (t *CToggleToolButton) Activate() (value bool) {
+	return t.Clicked() == cenums.EVENT_STOP
+}
+
+// GetActive returns the status of the toggle tool button, TRUE if the tool
+// button is pressed in and FALSE if it is raised.
+func (t *CToggleToolButton) GetActive() (value bool) {
+	var err error
+	if value, err = t.GetBoolProperty(PropertyActive); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetActive updates the status of the toggle tool button. Set to TRUE if you
+// want the button to be pressed in, and FALSE to raise it. This emits the
+// "toggled" signal if the status changes.
+//
+// Parameters:
+//
+//	isActive	whether button should be active
+func (t *CToggleToolButton) SetActive(isActive bool) {
+	if t.GetActive() == isActive {
+		return
+	}
+	if err := t.SetBoolProperty(PropertyActive, isActive); err != nil {
+		t.LogErr(err)
+		return
+	}
+	t.Toggled()
+}
+
+// Toggled emits the toggled signal and updates the proxy menu item, if any.
+func (t *CToggleToolButton) Toggled() {
+	active := t.GetActive()
+	if check, ok := t.GetProxyMenuItem(ToolButtonMenuProxyId).(CheckMenuItem); ok {
+		check.SetActive(active)
+	}
+	t.Emit(SignalToggled, t, active)
+	if button := t.GetButton(); button != nil {
+		button.Invalidate()
+	}
+	t.Invalidate()
+}
+
+func (t *CToggleToolButton) createMenuProxy(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	t.RLock()
+	proxyId, proxyItem := t.proxyId, t.proxyItem
+	t.RUnlock()
+	if proxyItem != nil && proxyId != ToolButtonMenuProxyId {
+		return cenums.EVENT_PASS
+	}
+	if proxyItem == nil {
+		label, useUnderline := t.getDisplayLabel()
+		var check CheckMenuItem
+		if useUnderline {
+			check = NewCheckMenuItemWithMnemonic(label)
+		} else {
+			check = NewCheckMenuItemWithLabel(label)
+		}
+		check.SetActive(t.GetActive())
+		check.Show()
+		check.Connect(SignalActivate, fmt.Sprintf("%v-%v", ToolButtonMenuProxyHandle, t.ObjectID()), t.buttonClicked)
+		proxyItem = check
+		t.SetProxyMenuItem(ToolButtonMenuProxyId, proxyItem)
+	}
+	proxyItem.SetSensitive(t.IsSensitive())
+	return cenums.EVENT_PASS
+}
+
+func (t *CToggleToolButton) themeRequest(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if !t.GetActive() {
+		return cenums.EVENT_PASS
+	}
+	if theme, ok := argv[0].(*paint.Theme); ok {
+		if modified, ok := argv[1].(paint.Theme); ok {
+			*theme = modified
+			if button := t.GetButton(); button != nil && !button.HasState(enums.StateInsensitive) {
+				theme.Content.Normal = modified.Content.Active
+				theme.Border.Normal = modified.Border.Active
+			}
+			return cenums.EVENT_STOP
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+// If the toggle tool button should be pressed in or not.
+// Flags: Read / Write
+// Default value: FALSE
+// const PropertyActive cdk.Property = "active"
+
+// Emitted whenever the toggle tool button changes state.
+// Listener function arguments:
+//
+//	active bool	the new active state
+// const SignalToggled cdk.Signal = "toggled"
+
+const ToggleToolButtonCreateMenuProxyHandle = "toggle-tool-button-create-menu-proxy-handler"
+
+const ToggleToolButtonThemeRequestHandle = "toggle-tool-button-theme-request-handler"
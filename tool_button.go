@@ -0,0 +1,399 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeToolButton cdk.CTypeTag = "ctk-tool-button"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeToolButton, func() interface{} { return MakeToolButton() })
+}
+
+// ToolButton Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Bin
+//	        +- ToolItem
+//	          +- ToolButton
+//
+// ToolButtons are ToolItems containing buttons. Use NewToolButton to create a
+// new ToolButton or NewToolButtonFromStock to create a ToolButton showing the
+// label and icon of a stock item. The "clicked" signal is emitted when the
+// button is pressed.
+//
+// Icons are single runes, either set with SetIconRune or looked up from the
+// stock icons with LookupStockIcon. Whether the icon, the label or both are
+// shown depends on the ToolbarStyle of the Toolbar:
+//
+//	TOOLBAR_ICONS        only the icon, or the label if there is no icon
+//	TOOLBAR_TEXT         only the label, or the icon if there is no label
+//	TOOLBAR_BOTH         the icon followed by the label
+//	TOOLBAR_BOTH_HORIZ   the icon, followed by the label if the item is important
+type ToolButton interface {
+	ToolItem
+
+	Clicked() cenums.EventFlag
+	GetLabel() (value string)
+	SetLabel(label string)
+	GetUseUnderline() (value bool)
+	SetUseUnderline(useUnderline bool)
+	GetStockId() (value StockID)
+	SetStockId(stockId StockID)
+	GetIconRune() (value rune)
+	SetIconRune(icon rune)
+	GetButton() (button Button)
+}
+
+var _ ToolButton = (*CToolButton)(nil)
+
+// The CToolButton structure implements the ToolButton interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with ToolButton objects.
+type CToolButton struct {
+	CToolItem
+
+	button Button
+}
+
+// MakeToolButton is used by the Buildable system to construct a new
+// ToolButton.
+func MakeToolButton() ToolButton {
+	return NewToolButton("")
+}
+
+// NewToolButton creates a new ToolButton using the given label text.
+//
+// Parameters:
+//
+//	label	a string that will be used as label, or ""
+func NewToolButton(label string) ToolButton {
+	t := new(CToolButton)
+	t.Init()
+	t.SetLabel(label)
+	return t
+}
+
+// NewToolButtonFromStock creates a new ToolButton containing the label of
+// the given stock item.
+//
+// Parameters:
+//
+//	stockId	the name of the stock item
+func NewToolButtonFromStock(stockId StockID) ToolButton {
+	t := new(CToolButton)
+	t.Init()
+	t.SetStockId(stockId)
+	return t
+}
+
+// Init initializes a ToolButton object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the ToolButton instance. Init is used in the
+// NewToolButton constructor and only necessary when implementing a derivative
+// ToolButton type.
+func (t *CToolButton) Init() (already bool) {
+	if t.InitTypeItem(TypeToolButton, t) {
+		return true
+	}
+	t.CToolItem.Init()
+	_ = t.InstallBuildableProperty(PropertyLabel, cdk.StringProperty, true, "")
+	_ = t.InstallBuildableProperty(PropertyStockId, cdk.StructProperty, true, nil)
+	_ = t.InstallBuildableProperty(PropertyUseUnderline, cdk.BoolProperty, true, false)
+	_ = t.InstallBuildableProperty(PropertyIconRune, cdk.StructProperty, true, nil)
+	t.button = NewButtonWithLabel("")
+	t.button.UnsetFlags(enums.CAN_DEFAULT | enums.RECEIVES_DEFAULT)
+	t.button.Show()
+	t.Add(t.button)
+	t.button.Connect(SignalClicked, fmt.Sprintf("%v-%v", ToolButtonClickedHandle, t.ObjectID()), t.buttonClicked)
+	t.Connect(SignalToolbarReconfigured, ToolButtonReconfiguredHandle, t.reconfigured)
+	t.Connect(SignalCreateMenuProxy, ToolButtonCreateMenuProxyHandle, t.createMenuProxy)
+	return false
+}
+
+// Clicked emits the "clicked" signal, if the ToolButton is sensitive.
+func (t *CToolButton) Clicked() cenums.EventFlag {
+	if t.IsSensitive() {
+		return t.Emit(SignalClicked, t)
+	}
+	return cenums.EVENT_PASS
+}
+
+// Activate emits the "clicked" signal.
+func (t *CToolButton) Activate() (value bool) {
+	return t.Clicked() == cenums.EVENT_STOP
+}
+
+// GetLabel returns the label used by the tool button, or an empty string if
+// the tool button doesn't have a label.
+func (t *CToolButton) GetLabel() (value string) {
+	var err error
+	if value, err = t.GetStringProperty(PropertyLabel); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetLabel updates the label used for the tool button. If label is empty the
+// label of the stock item set with SetStockId is used instead.
+//
+// Parameters:
+//
+//	label	a string that will be used as label, or ""
+func (t *CToolButton) SetLabel(label string) {
+	if err := t.SetStringProperty(PropertyLabel, label); err != nil {
+		t.LogErr(err)
+	}
+	t.updateButton()
+}
+
+// GetUseUnderline returns whether underscores in the label property are used
+// as mnemonics on menu items on the overflow menu. See SetUseUnderline.
+func (t *CToolButton) GetUseUnderline() (value bool) {
+	var err error
+	if value, err = t.GetBoolProperty(PropertyUseUnderline); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetUseUnderline updates whether an underline in the label property
+// indicates that the next character should be used for the mnemonic
+// accelerator key.
+//
+// Parameters:
+//
+//	useUnderline	whether the button label has the form "_Open"
+func (t *CToolButton) SetUseUnderline(useUnderline bool) {
+	if err := t.SetBoolProperty(PropertyUseUnderline, useUnderline); err != nil {
+		t.LogErr(err)
+	}
+	t.updateButton()
+}
+
+// GetStockId returns the name of the stock item. See SetStockId.
+func (t *CToolButton) GetStockId() (value StockID) {
+	if v, err := t.GetStructProperty(PropertyStockId); err != nil {
+		t.LogErr(err)
+	} else if v != nil {
+		value, _ = v.(StockID)
+	}
+	return
+}
+
+// SetStockId updates the name of the stock item, the label of which is used
+// when the label property is empty.
+//
+// Parameters:
+//
+//	stockId	a name of a stock item, or ""
+func (t *CToolButton) SetStockId(stockId StockID) {
+	if err := t.SetStructProperty(PropertyStockId, stockId); err != nil {
+		t.LogErr(err)
+	}
+	t.updateButton()
+}
+
+// GetIconRune returns the rune used as the icon of the tool button, or zero if
+// no icon rune has been set. See SetIconRune.
+func (t *CToolButton) GetIconRune() (value rune) {
+	if v, err := t.GetStructProperty(PropertyIconRune); err != nil {
+		t.LogErr(err)
+	} else if v != nil {
+		value, _ = v.(rune)
+	}
+	return
+}
+
+// SetIconRune updates the rune used as the icon of the tool button. If icon is
+// zero, the stock icon of the stock item set with SetStockId is used instead.
+//
+// Parameters:
+//
+//	icon	the rune to use as the icon, or zero
+func (t *CToolButton) SetIconRune(icon rune) {
+	if err := t.SetStructProperty(PropertyIconRune, icon); err != nil {
+		t.LogErr(err)
+	}
+	t.updateButton()
+}
+
+// GetButton returns the Button widget used by the ToolButton.
+func (t *CToolButton) GetButton() (button Button) {
+	t.RLock()
+	defer t.RUnlock()
+	return t.button
+}
+
+// GetSizeRequest returns the requested size of the ToolButton, which is the
+// width of the text shown for the current toolbar style plus the button
+// bookends and a single line in height.
+func (t *CToolButton) GetSizeRequest() (width, height int) {
+	width, height = t.CBin.GetSizeRequest()
+	if width <= -1 {
+		text, useUnderline := t.getButtonText()
+		if useUnderline {
+			text = rxLabelPlainText.ReplaceAllString(text, "$2")
+		}
+		width = 1 + runewidth.StringWidth(text) + 1
+	}
+	if height <= -1 {
+		height = 1
+	}
+	return
+}
+
+// getDisplayLabel returns the label, falling back to the stock item label.
+func (t *CToolButton) getDisplayLabel() (label string, useUnderline bool) {
+	label, useUnderline = t.GetLabel(), t.GetUseUnderline()
+	if label == "" {
+		if stockId := t.GetStockId(); stockId != "" {
+			if item := LookupStockItem(stockId); item != nil {
+				label, useUnderline = item.Label, true
+			}
+		}
+	}
+	return
+}
+
+// getDisplayIcon returns the icon rune, falling back to the stock icon.
+func (t *CToolButton) getDisplayIcon() (icon rune, ok bool) {
+	if icon = t.GetIconRune(); icon != 0 {
+		return icon, true
+	}
+	if stockId := t.GetStockId(); stockId != "" {
+		return LookupStockIcon(stockId)
+	}
+	return 0, false
+}
+
+// getButtonText returns the text shown by the button for the current toolbar
+// style.
+func (t *CToolButton) getButtonText() (text string, useUnderline bool) {
+	label, useUnderline := t.getDisplayLabel()
+	icon, hasIcon := t.getDisplayIcon()
+	if !hasIcon {
+		return label, useUnderline
+	}
+	iconText := string(icon)
+	if label == "" {
+		return iconText, useUnderline
+	}
+	switch t.GetToolbarStyle() {
+	case enums.TOOLBAR_ICONS:
+		return iconText, useUnderline
+	case enums.TOOLBAR_TEXT:
+		return label, useUnderline
+	case enums.TOOLBAR_BOTH_HORIZ:
+		if !t.GetIsImportant() {
+			return iconText, useUnderline
+		}
+	}
+	return iconText + " " + label, useUnderline
+}
+
+func (t *CToolButton) updateButton() {
+	button := t.GetButton()
+	if button == nil {
+		return
+	}
+	text, useUnderline := t.getButtonText()
+	button.SetUseUnderline(useUnderline)
+	button.SetLabel(text)
+	if t.GetProxyMenuItem(ToolButtonMenuProxyId) != nil {
+		t.SetProxyMenuItem(ToolButtonMenuProxyId, nil)
+	}
+	t.resizeToolbar()
+}
+
+func (t *CToolButton) buttonClicked(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if tb, ok := t.Self().(ToolButton); ok {
+		return tb.Clicked()
+	}
+	return t.Clicked()
+}
+
+func (t *CToolButton) reconfigured(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	t.updateButton()
+	return cenums.EVENT_PASS
+}
+
+func (t *CToolButton) createMenuProxy(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	t.RLock()
+	proxyId, proxyItem := t.proxyId, t.proxyItem
+	t.RUnlock()
+	if proxyItem != nil && proxyId != ToolButtonMenuProxyId {
+		return cenums.EVENT_PASS
+	}
+	if proxyItem == nil {
+		label, useUnderline := t.getDisplayLabel()
+		if useUnderline {
+			proxyItem = NewMenuItemWithMnemonic(label)
+		} else {
+			proxyItem = NewMenuItemWithLabel(label)
+		}
+		proxyItem.Show()
+		proxyItem.Connect(SignalActivate, fmt.Sprintf("%v-%v", ToolButtonMenuProxyHandle, t.ObjectID()), t.buttonClicked)
+		t.SetProxyMenuItem(ToolButtonMenuProxyId, proxyItem)
+	}
+	proxyItem.SetSensitive(t.IsSensitive())
+	return cenums.EVENT_PASS
+}
+
+// Text of the label widget inside the button, if the button contains a label
+// widget.
+// Flags: Read / Write
+// Default value: NULL
+// const PropertyLabel cdk.Property = "label"
+
+// If set, an underline in the label property indicates that the next
+// character should be used for the mnemonic accelerator key in the overflow
+// menu.
+// Flags: Read / Write
+// Default value: FALSE
+// const PropertyUseUnderline cdk.Property = "use-underline"
+
+// This signal is emitted when the tool button is clicked with the mouse or
+// activated with the keyboard.
+// const SignalClicked cdk.Signal = "clicked"
+
+// The rune used as the icon of the tool button, instead of the stock icon.
+// Flags: Read / Write
+// Default value: NULL
+const PropertyIconRune cdk.Property = "icon-rune"
+
+const ToolButtonClickedHandle = "tool-button-clicked-handler"
+
+const ToolButtonReconfiguredHandle = "tool-button-reconfigured-handler"
+
+const ToolButtonCreateMenuProxyHandle = "tool-button-create-menu-proxy-handler"
+
+const ToolButtonMenuProxyHandle = "tool-button-menu-proxy-handler"
+
+// ToolButtonMenuProxyId identifies the overflow menu proxies created by
+// ToolButtons with SetProxyMenuItem.
+const ToolButtonMenuProxyId = "ctk-tool-button-menu-id"
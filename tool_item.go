@@ -0,0 +1,330 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/go-curses/cdk/lib/ptypes"
+	"github.com/go-curses/cdk/memphis"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeToolItem cdk.CTypeTag = "ctk-tool-item"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeToolItem, func() interface{} { return MakeToolItem() })
+}
+
+// ToolItem Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Bin
+//	        +- ToolItem
+//	          +- ToolButton
+//	          +- SeparatorToolItem
+//
+// ToolItems are widgets that can appear on a Toolbar. To create a toolbar
+// item that contain something else than a button, use NewToolItem. Use
+// Container.Add to add a child widget to the tool item.
+//
+// When a Toolbar does not have enough room for all of its items, the items
+// that do not fit are shown in an overflow menu instead, using the MenuItem
+// returned by RetrieveProxyMenuItem. Items without a proxy menu item are
+// omitted from the overflow menu.
+type ToolItem interface {
+	Bin
+
+	Init() (already bool)
+	GetExpand() (value bool)
+	SetExpand(expand bool)
+	GetIsImportant() (value bool)
+	SetIsImportant(isImportant bool)
+	GetToolbar() (toolbar Toolbar)
+	GetOrientation() (orientation cenums.Orientation)
+	GetToolbarStyle() (style enums.ToolbarStyle)
+	ToolbarReconfigured()
+	RetrieveProxyMenuItem() (menuItem MenuItem)
+	GetProxyMenuItem(menuItemId string) (menuItem MenuItem)
+	SetProxyMenuItem(menuItemId string, menuItem MenuItem)
+}
+
+var _ ToolItem = (*CToolItem)(nil)
+
+// The CToolItem structure implements the ToolItem interface and is exported
+// to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with ToolItem objects.
+type CToolItem struct {
+	CBin
+
+	proxyId   string
+	proxyItem MenuItem
+}
+
+// MakeToolItem is used by the Buildable system to construct a new ToolItem.
+func MakeToolItem() ToolItem {
+	return NewToolItem()
+}
+
+// NewToolItem is the constructor for new ToolItem instances.
+func NewToolItem() ToolItem {
+	t := new(CToolItem)
+	t.Init()
+	return t
+}
+
+// Init initializes a ToolItem object. This must be called at least once to set
+// up the necessary defaults and allocate any memory structures. Calling this
+// more than once is safe though unnecessary. Only the first call will result
+// in any effect upon the ToolItem instance. Init is used in the NewToolItem
+// constructor and only necessary when implementing a derivative ToolItem type.
+func (t *CToolItem) Init() (already bool) {
+	if t.InitTypeItem(TypeToolItem, t) {
+		return true
+	}
+	t.CBin.Init()
+	t.proxyId = ""
+	t.proxyItem = nil
+	t.flags = enums.NULL_WIDGET_FLAG
+	t.SetFlags(enums.PARENT_SENSITIVE | enums.APP_PAINTABLE)
+	_ = t.InstallBuildableProperty(PropertyExpand, cdk.BoolProperty, true, false)
+	_ = t.InstallBuildableProperty(PropertyIsImportant, cdk.BoolProperty, true, false)
+	t.Connect(SignalResize, ToolItemResizeHandle, t.resize)
+	t.Connect(SignalDraw, ToolItemDrawHandle, t.draw)
+	return false
+}
+
+// GetExpand returns whether the tool item is allocated extra space. See
+// SetExpand.
+func (t *CToolItem) GetExpand() (value bool) {
+	var err error
+	if value, err = t.GetBoolProperty(PropertyExpand); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetExpand updates whether the tool item is allocated extra space when there
+// is more room on the toolbar then needed for the items. The effect is that
+// the item gets bigger when the toolbar gets bigger and smaller when the
+// toolbar gets smaller.
+//
+// Parameters:
+//
+//	expand	whether the tool item is allocated extra space
+func (t *CToolItem) SetExpand(expand bool) {
+	if err := t.SetBoolProperty(PropertyExpand, expand); err != nil {
+		t.LogErr(err)
+	}
+	t.resizeToolbar()
+}
+
+// GetIsImportant returns whether the tool item is considered important. See
+// SetIsImportant.
+func (t *CToolItem) GetIsImportant() (value bool) {
+	var err error
+	if value, err = t.GetBoolProperty(PropertyIsImportant); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetIsImportant updates whether the tool item should be considered
+// important. ToolButtons with important items show their label in addition to
+// the icon when the toolbar style is TOOLBAR_BOTH_HORIZ.
+//
+// Parameters:
+//
+//	isImportant	whether the tool item should be considered important
+func (t *CToolItem) SetIsImportant(isImportant bool) {
+	if err := t.SetBoolProperty(PropertyIsImportant, isImportant); err != nil {
+		t.LogErr(err)
+	}
+	t.resizeToolbar()
+}
+
+// GetToolbar returns the Toolbar the tool item is on, if any.
+func (t *CToolItem) GetToolbar() (toolbar Toolbar) {
+	if parent := t.GetParent(); parent != nil {
+		toolbar, _ = parent.Self().(Toolbar)
+	}
+	return
+}
+
+// GetSizeRequest returns the requested size of the ToolItem, which is the size
+// requested by the child and a single line in height.
+func (t *CToolItem) GetSizeRequest() (width, height int) {
+	width, height = t.CBin.GetSizeRequest()
+	if width <= -1 {
+		width = 0
+		if child := t.GetChild(); child != nil && child.IsVisible() {
+			if w, _ := child.GetSizeRequest(); w > 0 {
+				width = w
+			}
+		}
+	}
+	if height <= -1 {
+		height = 1
+	}
+	return
+}
+
+// GetOrientation returns the orientation used for the tool item, which is the
+// orientation of the Toolbar the item is on, or ORIENTATION_HORIZONTAL if the
+// item is not on a Toolbar.
+func (t *CToolItem) GetOrientation() (orientation cenums.Orientation) {
+	if toolbar := t.GetToolbar(); toolbar != nil {
+		return toolbar.GetOrientation()
+	}
+	return cenums.ORIENTATION_HORIZONTAL
+}
+
+// GetToolbarStyle returns the toolbar style used for the tool item, which is
+// the style of the Toolbar the item is on, or the default toolbar style if the
+// item is not on a Toolbar. Custom subclasses of ToolItem should call this
+// function to find out in what style the toolbar is displayed and change
+// themselves accordingly.
+func (t *CToolItem) GetToolbarStyle() (style enums.ToolbarStyle) {
+	if toolbar := t.GetToolbar(); toolbar != nil {
+		return toolbar.GetStyle()
+	}
+	return toolbarDefaultStyle()
+}
+
+// ToolbarReconfigured emits the toolbar-reconfigured signal on the tool item.
+// Toolbar calls this when the orientation or style of the toolbar changes and
+// ToolItem subclasses use the signal to update their appearance.
+func (t *CToolItem) ToolbarReconfigured() {
+	t.Emit(SignalToolbarReconfigured, t)
+	t.Invalidate()
+}
+
+// RetrieveProxyMenuItem returns the MenuItem that is used to represent the
+// tool item in the toolbar overflow menu. The create-menu-proxy signal is
+// emitted first, allowing handlers to install a proxy with SetProxyMenuItem.
+// Returns nil if the tool item should not appear in the overflow menu.
+func (t *CToolItem) RetrieveProxyMenuItem() (menuItem MenuItem) {
+	t.Emit(SignalCreateMenuProxy, t)
+	t.RLock()
+	defer t.RUnlock()
+	return t.proxyItem
+}
+
+// GetProxyMenuItem returns the MenuItem set with SetProxyMenuItem if the given
+// menuItemId matches the one used with SetProxyMenuItem, or nil otherwise.
+//
+// Parameters:
+//
+//	menuItemId	a string used to identify the menu item
+func (t *CToolItem) GetProxyMenuItem(menuItemId string) (menuItem MenuItem) {
+	t.RLock()
+	defer t.RUnlock()
+	if t.proxyId == menuItemId {
+		menuItem = t.proxyItem
+	}
+	return
+}
+
+// SetProxyMenuItem updates the MenuItem used in the toolbar overflow menu.
+// The menuItemId is used to identify the caller of this function and should
+// also be used with GetProxyMenuItem. Passing a nil menuItem removes the
+// proxy.
+//
+// Parameters:
+//
+//	menuItemId	a string used to identify menuItem
+//	menuItem	a MenuItem to be used in the overflow menu
+func (t *CToolItem) SetProxyMenuItem(menuItemId string, menuItem MenuItem) {
+	t.Lock()
+	t.proxyId = menuItemId
+	t.proxyItem = menuItem
+	t.Unlock()
+}
+
+func (t *CToolItem) resizeToolbar() {
+	if toolbar := t.GetToolbar(); toolbar != nil {
+		toolbar.Resize()
+	}
+}
+
+func (t *CToolItem) resize(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	child := t.GetChild()
+	if child == nil {
+		return cenums.EVENT_STOP
+	}
+	alloc := t.GetAllocation()
+	origin := t.GetOrigin()
+	if alloc.W <= 0 || alloc.H <= 0 {
+		child.SetAllocation(ptypes.MakeRectangle(0, 0))
+		return child.Resize()
+	}
+	child.SetOrigin(origin.X, origin.Y)
+	child.SetAllocation(alloc)
+	child.Resize()
+	t.Invalidate()
+	return cenums.EVENT_STOP
+}
+
+func (t *CToolItem) draw(data []interface{}, argv ...interface{}) cenums.EventFlag {
+
+	if surface, ok := argv[1].(*memphis.CSurface); ok {
+		alloc := t.GetAllocation()
+		if !t.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+			t.LogTrace("not visible, zero width or zero height")
+			return cenums.EVENT_PASS
+		}
+
+		surface.Fill(t.GetTheme())
+
+		if child := t.GetChild(); child != nil && child.IsVisible() {
+			child.Draw()
+			child.LockDraw()
+			if err := surface.Composite(child.ObjectID()); err != nil {
+				t.LogError("composite error: %v", err)
+			}
+			child.UnlockDraw()
+		}
+
+		if debug, _ := t.GetBoolProperty(cdk.PropertyDebug); debug {
+			surface.DebugBox(paint.ColorSilver, t.ObjectInfo())
+		}
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+// Whether the tool item is allocated extra space when there is more room on
+// the toolbar then needed for the items.
+// Flags: Read / Write
+// Default value: FALSE
+// const PropertyExpand cdk.Property = "expand"
+
+// This signal is emitted when the toolbar needs information from the tool
+// item about whether the item should appear in the toolbar overflow menu.
+// Handlers install a MenuItem with SetProxyMenuItem.
+const SignalCreateMenuProxy cdk.Signal = "create-menu-proxy"
+
+// This signal is emitted when some property of the toolbar that the item is a
+// child of changes, such as the orientation or the toolbar style.
+const SignalToolbarReconfigured cdk.Signal = "toolbar-reconfigured"
+
+const ToolItemResizeHandle = "tool-item-resize-handler"
+
+const ToolItemDrawHandle = "tool-item-draw-handler"
@@ -0,0 +1,643 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/go-curses/cdk/lib/ptypes"
+	"github.com/go-curses/cdk/memphis"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeToolbar cdk.CTypeTag = "ctk-toolbar"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeToolbar, func() interface{} { return MakeToolbar() })
+}
+
+// Toolbar Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- Toolbar
+//
+// A Toolbar is created with a call to NewToolbar. A toolbar can contain
+// instances of a subclass of ToolItem. To add a ToolItem to the toolbar, use
+// Insert. To remove an item from the toolbar use Container.Remove. To add a
+// button to the toolbar, add an instance of ToolButton. Toolbar items can be
+// visually grouped by adding instances of SeparatorToolItem to the toolbar.
+//
+// The items are laid out in a single row or column, depending on the
+// orientation of the Toolbar. When there is not enough room for all of the
+// items and the show-arrow property is TRUE, the items that do not fit are
+// hidden and an arrow is drawn at the end of the Toolbar. Clicking the arrow
+// pops up an overflow Menu containing the proxy menu items of the hidden
+// items, see ToolItem.RetrieveProxyMenuItem.
+type Toolbar interface {
+	Container
+	Buildable
+	Orientable
+
+	Init() (already bool)
+	Build(builder Builder, element *CBuilderElement) error
+	Insert(item ToolItem, pos int)
+	GetItemIndex(item ToolItem) (index int)
+	GetNItems() (count int)
+	GetNthItem(n int) (item ToolItem)
+	GetItems() (items []ToolItem)
+	GetStyle() (style enums.ToolbarStyle)
+	SetStyle(style enums.ToolbarStyle)
+	UnsetStyle()
+	GetShowArrow() (showArrow bool)
+	SetShowArrow(showArrow bool)
+	GetSpaceStyle() (spaceStyle enums.ToolbarSpaceStyle)
+	SetSpaceStyle(spaceStyle enums.ToolbarSpaceStyle)
+}
+
+var _ Toolbar = (*CToolbar)(nil)
+
+// The CToolbar structure implements the Toolbar interface and is exported to
+// facilitate type embedding with custom implementations. No member variables
+// are exported as the interface methods are the only intended means of
+// interacting with Toolbar objects.
+type CToolbar struct {
+	CContainer
+
+	styleSet bool
+	overflow []ToolItem
+	hasArrow bool
+	arrow    ptypes.Region
+	menu     Menu
+}
+
+// MakeToolbar is used by the Buildable system to construct a new Toolbar.
+func MakeToolbar() Toolbar {
+	return NewToolbar()
+}
+
+// NewToolbar is the constructor for new Toolbar instances.
+func NewToolbar() Toolbar {
+	t := new(CToolbar)
+	t.Init()
+	return t
+}
+
+// Init initializes a Toolbar object. This must be called at least once to set
+// up the necessary defaults and allocate any memory structures. Calling this
+// more than once is safe though unnecessary. Only the first call will result
+// in any effect upon the Toolbar instance. Init is used in the NewToolbar
+// constructor and only necessary when implementing a derivative Toolbar type.
+func (t *CToolbar) Init() (already bool) {
+	if t.InitTypeItem(TypeToolbar, t) {
+		return true
+	}
+	t.CContainer.Init()
+	t.flags = enums.NULL_WIDGET_FLAG
+	t.SetFlags(enums.SENSITIVE | enums.PARENT_SENSITIVE | enums.APP_PAINTABLE)
+	t.styleSet = false
+	t.overflow = nil
+	t.hasArrow = false
+	t.menu = nil
+	_ = t.InstallBuildableProperty(PropertyOrientation, cdk.StructProperty, true, cenums.ORIENTATION_HORIZONTAL)
+	_ = t.InstallBuildableProperty(PropertyShowArrow, cdk.BoolProperty, true, true)
+	_ = t.InstallBuildableProperty(PropertyToolbarStyle, cdk.StructProperty, true, enums.TOOLBAR_BOTH)
+	_ = t.InstallBuildableProperty(PropertySpaceStyle, cdk.StructProperty, true, enums.TOOLBAR_SPACE_LINE)
+	t.Connect(SignalCdkEvent, ToolbarEventHandle, t.event)
+	t.Connect(SignalResize, ToolbarResizeHandle, t.resize)
+	t.Connect(SignalDraw, ToolbarDrawHandle, t.draw)
+	return false
+}
+
+// Build provides customizations to the Buildable system for Toolbar Widgets.
+// Each child object must be a ToolItem and is appended in the order given.
+func (t *CToolbar) Build(builder Builder, element *CBuilderElement) error {
+	t.Freeze()
+	defer t.Thaw()
+	if name, ok := element.Attributes["id"]; ok {
+		t.SetName(name)
+	}
+	for k, v := range element.Properties {
+		switch cdk.Property(k) {
+		case PropertyToolbarStyle, "toolbar_style":
+			if style, err := enums.ToolbarStyle(0).FromString(v); err != nil {
+				t.LogErr(err)
+			} else {
+				t.SetStyle(style.(enums.ToolbarStyle))
+			}
+		default:
+			element.ApplyProperty(k, v)
+		}
+	}
+	for _, child := range element.Children {
+		if newChild := builder.Build(child); newChild != nil {
+			child.Instance = newChild
+			if item, ok := newChild.(ToolItem); ok {
+				item.Show()
+				t.Insert(item, -1)
+			} else {
+				t.LogError("new child object is not a ToolItem type: %v (%T)", newChild, newChild)
+			}
+		}
+	}
+	element.ApplySignals()
+	return nil
+}
+
+// Add is a convenience method for appending the given ToolItem to the Toolbar.
+func (t *CToolbar) Add(w Widget) {
+	if item, ok := w.Self().(ToolItem); ok {
+		t.Insert(item, -1)
+		return
+	}
+	t.LogError("toolbar children must be ToolItem widgets: %v (%T)", w, w)
+}
+
+// Remove the given ToolItem from the Toolbar.
+func (t *CToolbar) Remove(w Widget) {
+	t.CContainer.Remove(w)
+	if item, ok := w.Self().(ToolItem); ok {
+		item.ToolbarReconfigured()
+	}
+	t.Resize()
+}
+
+// Insert a ToolItem into the toolbar at position pos. If pos is 0 the item is
+// prepended to the start of the toolbar. If pos is negative, the item is
+// appended to the end of the toolbar.
+//
+// Parameters:
+//
+//	item	a ToolItem
+//	pos	the position of the new item
+func (t *CToolbar) Insert(item ToolItem, pos int) {
+	t.CContainer.Add(item)
+	t.Lock()
+	last := len(t.children) - 1
+	if last >= 0 && t.children[last].ObjectID() == item.ObjectID() {
+		if pos >= 0 && pos < last {
+			copy(t.children[pos+1:], t.children[pos:last])
+			t.children[pos] = item
+		}
+	}
+	t.Unlock()
+	item.ToolbarReconfigured()
+	t.Resize()
+}
+
+// GetItemIndex returns the position of item on the toolbar, starting from 0.
+// It is an error if item is not a child of the toolbar.
+//
+// Parameters:
+//
+//	item	a ToolItem that is a child of toolbar
+func (t *CToolbar) GetItemIndex(item ToolItem) (index int) {
+	for idx, known := range t.GetItems() {
+		if known.ObjectID() == item.ObjectID() {
+			return idx
+		}
+	}
+	t.LogError("tool item is not a child of this toolbar: %v", item.ObjectName())
+	return -1
+}
+
+// GetNItems returns the number of items on the toolbar.
+func (t *CToolbar) GetNItems() (count int) {
+	return len(t.GetItems())
+}
+
+// GetNthItem returns the n'th item on toolbar, or nil if the toolbar does not
+// contain an n'th item.
+//
+// Parameters:
+//
+//	n	a position on the toolbar
+func (t *CToolbar) GetNthItem(n int) (item ToolItem) {
+	if items := t.GetItems(); n >= 0 && n < len(items) {
+		item = items[n]
+	}
+	return
+}
+
+// GetItems returns the ToolItem children of the Toolbar, in order.
+func (t *CToolbar) GetItems() (items []ToolItem) {
+	for _, child := range t.GetChildren() {
+		if item, ok := child.Self().(ToolItem); ok {
+			items = append(items, item)
+		}
+	}
+	return
+}
+
+// GetOrientation returns the current orientation of the toolbar. See
+// SetOrientation.
+func (t *CToolbar) GetOrientation() (orientation cenums.Orientation) {
+	var ok bool
+	if v, err := t.GetStructProperty(PropertyOrientation); err != nil {
+		t.LogErr(err)
+	} else if orientation, ok = v.(cenums.Orientation); !ok && v != nil {
+		t.LogError("invalid value stored in %v: %v (%T)", PropertyOrientation, v, v)
+	}
+	return
+}
+
+// SetOrientation updates whether the toolbar items are laid out horizontally
+// or vertically.
+//
+// Parameters:
+//
+//	orientation	a new Orientation
+func (t *CToolbar) SetOrientation(orientation cenums.Orientation) {
+	if t.GetOrientation() == orientation {
+		return
+	}
+	if err := t.SetStructProperty(PropertyOrientation, orientation); err != nil {
+		t.LogErr(err)
+		return
+	}
+	t.Emit(SignalOrientationChanged, t, orientation)
+	t.reconfigureItems()
+}
+
+// GetStyle returns the style of the toolbar, which is the style set with
+// SetStyle or the toolbar-style of the default Settings if no style has been
+// set.
+func (t *CToolbar) GetStyle() (style enums.ToolbarStyle) {
+	t.RLock()
+	styleSet := t.styleSet
+	t.RUnlock()
+	if !styleSet {
+		return toolbarDefaultStyle()
+	}
+	var ok bool
+	if v, err := t.GetStructProperty(PropertyToolbarStyle); err != nil {
+		t.LogErr(err)
+	} else if style, ok = v.(enums.ToolbarStyle); !ok && v != nil {
+		t.LogError("invalid value stored in %v: %v (%T)", PropertyToolbarStyle, v, v)
+	}
+	return
+}
+
+// SetStyle alters the view of toolbar to display either icons only, text only,
+// or both.
+//
+// Parameters:
+//
+//	style	the new style for toolbar
+func (t *CToolbar) SetStyle(style enums.ToolbarStyle) {
+	if err := t.SetStructProperty(PropertyToolbarStyle, style); err != nil {
+		t.LogErr(err)
+		return
+	}
+	t.Lock()
+	t.styleSet = true
+	t.Unlock()
+	t.Emit(SignalStyleChanged, t, style)
+	t.reconfigureItems()
+}
+
+// UnsetStyle unsets a toolbar style set with SetStyle, so that the toolbar
+// style of the default Settings will be used.
+func (t *CToolbar) UnsetStyle() {
+	t.Lock()
+	t.styleSet = false
+	t.Unlock()
+	t.Emit(SignalStyleChanged, t, t.GetStyle())
+	t.reconfigureItems()
+}
+
+// GetShowArrow returns whether the toolbar has an overflow menu. See
+// SetShowArrow.
+func (t *CToolbar) GetShowArrow() (showArrow bool) {
+	var err error
+	if showArrow, err = t.GetBoolProperty(PropertyShowArrow); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetShowArrow updates whether to show an overflow menu when the toolbar
+// doesn't have room for all items on it. If TRUE, items that there is not room
+// for are available through the overflow menu. If FALSE, the items are cut off
+// at the end of the toolbar.
+//
+// Parameters:
+//
+//	showArrow	whether to show an overflow menu
+func (t *CToolbar) SetShowArrow(showArrow bool) {
+	if err := t.SetBoolProperty(PropertyShowArrow, showArrow); err != nil {
+		t.LogErr(err)
+	}
+	t.Resize()
+}
+
+// GetSpaceStyle returns whether separators are drawn as lines or as empty
+// space. See SetSpaceStyle.
+func (t *CToolbar) GetSpaceStyle() (spaceStyle enums.ToolbarSpaceStyle) {
+	var ok bool
+	if v, err := t.GetStructProperty(PropertySpaceStyle); err != nil {
+		t.LogErr(err)
+	} else if spaceStyle, ok = v.(enums.ToolbarSpaceStyle); !ok && v != nil {
+		t.LogError("invalid value stored in %v: %v (%T)", PropertySpaceStyle, v, v)
+	}
+	return
+}
+
+// SetSpaceStyle updates whether the SeparatorToolItems of the toolbar are drawn
+// as lines, TOOLBAR_SPACE_LINE, or as empty space, TOOLBAR_SPACE_EMPTY.
+//
+// Parameters:
+//
+//	spaceStyle	the new space style for toolbar
+func (t *CToolbar) SetSpaceStyle(spaceStyle enums.ToolbarSpaceStyle) {
+	if err := t.SetStructProperty(PropertySpaceStyle, spaceStyle); err != nil {
+		t.LogErr(err)
+	}
+	t.Invalidate()
+}
+
+// GetSizeRequest returns the requested size of the Toolbar. For horizontal
+// toolbars this is the sum of the widths of the visible items and the height
+// of the tallest item, and the other way around for vertical toolbars.
+func (t *CToolbar) GetSizeRequest() (width, height int) {
+	width, height = t.CContainer.GetSizeRequest()
+	horizontal := t.GetOrientation() != cenums.ORIENTATION_VERTICAL
+	length, breadth := 0, 1
+	for _, item := range t.GetItems() {
+		if item.IsVisible() {
+			w, h := item.GetSizeRequest()
+			if !horizontal {
+				w, h = h, w
+			}
+			length += w
+			if h > breadth {
+				breadth = h
+			}
+		}
+	}
+	if !horizontal {
+		length, breadth = breadth, length
+	}
+	if width <= -1 {
+		width = length
+	}
+	if height <= -1 {
+		height = breadth
+	}
+	return
+}
+
+// reconfigureItems notifies all items that the orientation or style of the
+// toolbar changed and updates the layout.
+func (t *CToolbar) reconfigureItems() {
+	for _, item := range t.GetItems() {
+		item.ToolbarReconfigured()
+	}
+	t.Resize()
+}
+
+// getOverflowItems returns the items that did not fit on the toolbar during
+// the last resize.
+func (t *CToolbar) getOverflowItems() (items []ToolItem) {
+	t.RLock()
+	defer t.RUnlock()
+	items = append(items, t.overflow...)
+	return
+}
+
+// popupOverflow pops up a Menu containing the proxy menu items of the items
+// which did not fit on the toolbar.
+func (t *CToolbar) popupOverflow() {
+	t.Lock()
+	if t.menu == nil {
+		t.menu = NewMenu()
+		t.menu.AttachToWidget(t)
+	}
+	menu := t.menu
+	hasArrow, arrow := t.hasArrow, t.arrow
+	t.Unlock()
+	for _, item := range menu.GetItems() {
+		menu.Remove(item)
+	}
+	count := 0
+	for _, item := range t.getOverflowItems() {
+		if proxy := item.RetrieveProxyMenuItem(); proxy != nil {
+			proxy.Show()
+			menu.Append(proxy)
+			count += 1
+		}
+	}
+	if !hasArrow || count == 0 {
+		return
+	}
+	uiManagerTidySeparators(menu.GetChildren())
+	origin := t.GetOrigin()
+	if t.GetOrientation() == cenums.ORIENTATION_VERTICAL {
+		menu.PopupAt(origin.X+arrow.X+arrow.W, origin.Y+arrow.Y)
+	} else {
+		menu.PopupAt(origin.X+arrow.X, origin.Y+arrow.Y+arrow.H)
+	}
+}
+
+func (t *CToolbar) event(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if !t.IsSensitive() {
+		return cenums.EVENT_PASS
+	}
+	if evt, ok := argv[1].(cdk.Event); ok {
+		if e, ok := evt.(*cdk.EventMouse); ok && e.State() == cdk.BUTTON_PRESS {
+			origin := t.GetOrigin()
+			x, y := e.Position()
+			t.RLock()
+			hasArrow, arrow := t.hasArrow, t.arrow
+			t.RUnlock()
+			local := ptypes.MakePoint2I(x-origin.X, y-origin.Y)
+			if hasArrow && notebookRegionHasPoint(arrow, local) {
+				t.popupOverflow()
+				return cenums.EVENT_STOP
+			}
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (t *CToolbar) resize(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	origin := t.GetOrigin()
+	alloc := t.GetAllocation()
+	horizontal := t.GetOrientation() != cenums.ORIENTATION_VERTICAL
+	length, breadth := alloc.W, alloc.H
+	if !horizontal {
+		length, breadth = alloc.H, alloc.W
+	}
+	var visible []ToolItem
+	var sizes []int
+	used, expanders := 0, 0
+	for _, item := range t.GetItems() {
+		if !item.IsVisible() {
+			item.SetAllocation(ptypes.MakeRectangle(0, 0))
+			item.Resize()
+			continue
+		}
+		w, h := item.GetSizeRequest()
+		if !horizontal {
+			w = h
+		}
+		if w < 0 {
+			w = 0
+		}
+		visible = append(visible, item)
+		sizes = append(sizes, w)
+		used += w
+		if item.GetExpand() {
+			expanders += 1
+		}
+	}
+	hasArrow := used > length && length > 1 && t.GetShowArrow()
+	available := length
+	if hasArrow {
+		available = length - 1
+	}
+	extra := 0
+	if expanders > 0 && used < length {
+		extra = (length - used) / expanders
+	}
+	var overflow []ToolItem
+	pos := 0
+	for idx, item := range visible {
+		size := sizes[idx]
+		if item.GetExpand() {
+			size += extra
+		}
+		if len(overflow) > 0 || (hasArrow && pos+size > available) {
+			overflow = append(overflow, item)
+			item.SetAllocation(ptypes.MakeRectangle(0, 0))
+			item.Resize()
+			continue
+		}
+		if pos+size > length {
+			size = length - pos
+		}
+		if size <= 0 || breadth <= 0 {
+			item.SetAllocation(ptypes.MakeRectangle(0, 0))
+		} else if horizontal {
+			item.SetOrigin(origin.X+pos, origin.Y)
+			item.SetAllocation(ptypes.MakeRectangle(size, breadth))
+		} else {
+			item.SetOrigin(origin.X, origin.Y+pos)
+			item.SetAllocation(ptypes.MakeRectangle(breadth, size))
+		}
+		item.Resize()
+		pos += size
+	}
+	t.Lock()
+	t.overflow = overflow
+	t.hasArrow = hasArrow && len(overflow) > 0
+	if horizontal {
+		t.arrow = ptypes.MakeRegion(length-1, 0, 1, breadth)
+	} else {
+		t.arrow = ptypes.MakeRegion(0, length-1, breadth, 1)
+	}
+	t.Unlock()
+	t.Invalidate()
+	return cenums.EVENT_STOP
+}
+
+func (t *CToolbar) draw(data []interface{}, argv ...interface{}) cenums.EventFlag {
+
+	if surface, ok := argv[1].(*memphis.CSurface); ok {
+		alloc := t.GetAllocation()
+		if !t.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+			t.LogTrace("not visible, zero width or zero height")
+			return cenums.EVENT_PASS
+		}
+
+		theme := t.GetThemeRequest()
+		surface.Fill(theme)
+
+		for _, item := range t.GetItems() {
+			itemAlloc := item.GetAllocation()
+			if !item.IsVisible() || itemAlloc.W <= 0 || itemAlloc.H <= 0 {
+				continue
+			}
+			item.Draw()
+			item.LockDraw()
+			if err := surface.Composite(item.ObjectID()); err != nil {
+				t.LogError("composite error: %v", err)
+			}
+			item.UnlockDraw()
+		}
+
+		t.RLock()
+		hasArrow, arrow := t.hasArrow, t.arrow
+		t.RUnlock()
+		if hasArrow {
+			r := theme.Border.ArrowRunes.Down
+			if t.GetOrientation() == cenums.ORIENTATION_VERTICAL {
+				r = theme.Border.ArrowRunes.Right
+			}
+			_ = surface.SetRune(arrow.X+arrow.W/2, arrow.Y+arrow.H/2, r, theme.Content.Normal)
+		}
+
+		if debug, _ := t.GetBoolProperty(cdk.PropertyDebug); debug {
+			surface.DebugBox(paint.ColorNavy, t.ObjectInfo())
+		}
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+// toolbarDefaultStyle returns the toolbar-style of the default Settings,
+// falling back to TOOLBAR_BOTH.
+func toolbarDefaultStyle() (style enums.ToolbarStyle) {
+	if v, ok := GetDefaultSettings().GetToolbarStyle().(enums.ToolbarStyle); ok {
+		return v
+	}
+	return enums.TOOLBAR_BOTH
+}
+
+// If an arrow should be shown if the toolbar doesn't fit.
+// Flags: Read / Write
+// Default value: TRUE
+const PropertyShowArrow cdk.Property = "show-arrow"
+
+// Whether separators are drawn as lines or as empty space.
+// Flags: Read / Write
+// Default value: TOOLBAR_SPACE_LINE
+const PropertySpaceStyle cdk.Property = "space-style"
+
+// How to draw the toolbar.
+// Flags: Read / Write
+// Default value: TOOLBAR_BOTH
+const PropertyToolbarStyle cdk.Property = "toolbar-style"
+
+// Emitted when the orientation of the toolbar changes.
+// Listener function arguments:
+//
+//	orientation Orientation	the new Orientation of the toolbar
+const SignalOrientationChanged cdk.Signal = "orientation-changed"
+
+// Emitted when the style of the toolbar changes.
+// Listener function arguments:
+//
+//	style ToolbarStyle	the new ToolbarStyle of the toolbar
+const SignalStyleChanged cdk.Signal = "style-changed"
+
+const ToolbarEventHandle = "toolbar-event-handler"
+
+const ToolbarResizeHandle = "toolbar-resize-handler"
+
+const ToolbarDrawHandle = "toolbar-draw-handler"
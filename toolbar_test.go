@@ -0,0 +1,171 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"testing"
+
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/ptypes"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+func testToolButtonText(button ToolButton) (text string) {
+	text, _ = button.Self().(*CToolButton).getButtonText()
+	return
+}
+
+func TestToolbar(t *testing.T) {
+	Convey("Testing Toolbars", t, func() {
+		Convey("Basics", func() {
+			tb := &CToolbar{}
+			So(tb.Init(), ShouldEqual, false)
+			So(tb.Init(), ShouldEqual, true)
+			toolbar := NewToolbar()
+			So(toolbar.GetOrientation(), ShouldEqual, cenums.ORIENTATION_HORIZONTAL)
+			So(toolbar.GetStyle(), ShouldEqual, enums.TOOLBAR_BOTH)
+			So(toolbar.GetShowArrow(), ShouldEqual, true)
+			open := NewToolButtonFromStock(StockOpen)
+			quit := NewToolButton("_Quit")
+			toolbar.Insert(quit, -1)
+			toolbar.Insert(open, 0)
+			toolbar.Insert(NewSeparatorToolItem(), 1)
+			So(toolbar.GetNItems(), ShouldEqual, 3)
+			So(toolbar.GetNthItem(0), ShouldEqual, open)
+			So(toolbar.GetItemIndex(quit), ShouldEqual, 2)
+			So(quit.GetToolbar(), ShouldEqual, toolbar)
+			toolbar.Remove(quit)
+			So(toolbar.GetNItems(), ShouldEqual, 2)
+			So(quit.GetToolbar(), ShouldBeNil)
+		})
+		Convey("Styles", func() {
+			toolbar := NewToolbar()
+			open := NewToolButtonFromStock(StockOpen)
+			save := NewToolButton("_Save")
+			save.SetIconRune('S')
+			save.SetIsImportant(true)
+			plain := NewToolButton("Plain")
+			for _, item := range []ToolItem{open, save, plain} {
+				item.Show()
+				toolbar.Insert(item, -1)
+			}
+			icon, _ := LookupStockIcon(StockOpen)
+			toolbar.SetStyle(enums.TOOLBAR_ICONS)
+			So(open.GetToolbarStyle(), ShouldEqual, enums.TOOLBAR_ICONS)
+			So(testToolButtonText(open), ShouldEqual, string(icon))
+			So(testToolButtonText(plain), ShouldEqual, "Plain")
+			toolbar.SetStyle(enums.TOOLBAR_TEXT)
+			So(testToolButtonText(open), ShouldEqual, "_Open")
+			toolbar.SetStyle(enums.TOOLBAR_BOTH)
+			So(testToolButtonText(open), ShouldEqual, string(icon)+" _Open")
+			toolbar.SetStyle(enums.TOOLBAR_BOTH_HORIZ)
+			So(testToolButtonText(open), ShouldEqual, string(icon))
+			So(testToolButtonText(save), ShouldEqual, "S _Save")
+			toolbar.UnsetStyle()
+			So(toolbar.GetStyle(), ShouldEqual, enums.TOOLBAR_BOTH)
+			So(testToolButtonText(save), ShouldEqual, "S _Save")
+		})
+		Convey("Layout", func() {
+			toolbar := NewToolbar()
+			toolbar.SetStyle(enums.TOOLBAR_TEXT)
+			var buttons []ToolButton
+			for _, label := range []string{"One", "Two", "Six"} {
+				button := NewToolButton(label)
+				button.Show()
+				toolbar.Insert(button, -1)
+				buttons = append(buttons, button)
+			}
+			w, h := toolbar.GetSizeRequest()
+			So(w, ShouldEqual, 15)
+			So(h, ShouldEqual, 1)
+			toolbar.SetOrigin(0, 0)
+			toolbar.SetAllocation(ptypes.MakeRectangle(20, 1))
+			toolbar.Resize()
+			So(buttons[1].GetOrigin().X, ShouldEqual, 5)
+			So(toolbar.(*CToolbar).getOverflowItems(), ShouldBeEmpty)
+			toolbar.SetAllocation(ptypes.MakeRectangle(11, 1))
+			toolbar.Resize()
+			So(toolbar.(*CToolbar).getOverflowItems(), ShouldResemble, []ToolItem{buttons[2]})
+			So(buttons[2].GetAllocation().W, ShouldEqual, 0)
+			proxy := buttons[2].RetrieveProxyMenuItem()
+			So(proxy, ShouldNotBeNil)
+			So(proxy.GetLabel(), ShouldEqual, "Six")
+			clicked := 0
+			buttons[2].Connect(SignalClicked, "test-clicked", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				clicked += 1
+				return cenums.EVENT_PASS
+			})
+			proxy.Activate()
+			So(clicked, ShouldEqual, 1)
+			toolbar.SetShowArrow(false)
+			So(toolbar.(*CToolbar).getOverflowItems(), ShouldBeEmpty)
+			So(buttons[2].GetAllocation().W, ShouldEqual, 1)
+			toolbar.SetShowArrow(true)
+			toolbar.SetOrientation(cenums.ORIENTATION_VERTICAL)
+			w, h = toolbar.GetSizeRequest()
+			So(w, ShouldEqual, 5)
+			So(h, ShouldEqual, 3)
+			toolbar.SetAllocation(ptypes.MakeRectangle(5, 3))
+			toolbar.Resize()
+			So(buttons[2].GetOrigin().Y, ShouldEqual, 2)
+			So(buttons[2].GetAllocation().W, ShouldEqual, 5)
+		})
+		Convey("Toggle Buttons", func() {
+			button := NewToggleToolButton("_Bold")
+			So(button.GetActive(), ShouldEqual, false)
+			toggled := 0
+			button.Connect(SignalToggled, "test-toggled", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				toggled += 1
+				return cenums.EVENT_PASS
+			})
+			button.GetButton().Clicked()
+			So(button.GetActive(), ShouldEqual, true)
+			So(toggled, ShouldEqual, 1)
+			proxy, ok := button.RetrieveProxyMenuItem().(CheckMenuItem)
+			So(ok, ShouldEqual, true)
+			So(proxy.GetActive(), ShouldEqual, true)
+			proxy.Activate()
+			So(button.GetActive(), ShouldEqual, false)
+			So(proxy.GetActive(), ShouldEqual, false)
+			So(toggled, ShouldEqual, 2)
+		})
+		Convey("Action Proxies", func() {
+			group := NewActionGroup("main")
+			bold := NewToggleAction("bold", "_Bold", "", string(StockBold))
+			group.AddAction(bold)
+			item, ok := bold.CreateToolItem().(ToggleToolButton)
+			So(ok, ShouldEqual, true)
+			So(item.GetStockId(), ShouldEqual, StockBold)
+			item.GetButton().Clicked()
+			So(bold.GetActive(), ShouldEqual, true)
+			So(item.GetActive(), ShouldEqual, true)
+			bold.SetActive(false)
+			So(item.GetActive(), ShouldEqual, false)
+			open := NewAction("open", "_Open", "", string(StockOpen))
+			group.AddAction(open)
+			button, ok := open.CreateToolItem().(ToolButton)
+			So(ok, ShouldEqual, true)
+			activated := 0
+			open.Connect(SignalActionActivate, "test-activate", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				activated += 1
+				return cenums.EVENT_PASS
+			})
+			button.GetButton().Clicked()
+			So(activated, ShouldEqual, 1)
+		})
+	})
+}
@@ -0,0 +1,368 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-curses/cdk"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+// TreePath is a list of row indices describing the location of a row within a
+// TreeModel. The first index is the position of the row at the top level of
+// the model, the second is the position within the children of that row, and
+// so on. The string form of a TreePath is the colon separated list of indices,
+// for example "0:2:1".
+type TreePath []int
+
+// NewTreePath returns a new TreePath pointing at the first row of the model.
+func NewTreePath() TreePath {
+	return TreePath{0}
+}
+
+// NewTreePathFromIndices returns a new TreePath for the given indices.
+func NewTreePathFromIndices(indices ...int) TreePath {
+	path := make(TreePath, len(indices))
+	copy(path, indices)
+	return path
+}
+
+// NewTreePathFromString parses the given colon separated list of indices into
+// a TreePath. An error is returned if the string is empty or any index is not
+// a non-negative integer.
+func NewTreePathFromString(path string) (TreePath, error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty tree path")
+	}
+	parts := strings.Split(path, ":")
+	tp := make(TreePath, len(parts))
+	for idx, part := range parts {
+		if v, err := strconv.Atoi(part); err != nil {
+			return nil, fmt.Errorf("invalid tree path %q: %v", path, err)
+		} else if v < 0 {
+			return nil, fmt.Errorf("invalid tree path %q: negative index", path)
+		} else {
+			tp[idx] = v
+		}
+	}
+	return tp, nil
+}
+
+// String returns the colon separated representation of the TreePath.
+func (p TreePath) String() string {
+	parts := make([]string, len(p))
+	for idx, v := range p {
+		parts[idx] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ":")
+}
+
+// GetDepth returns the number of indices in the TreePath.
+func (p TreePath) GetDepth() int {
+	return len(p)
+}
+
+// GetIndices returns a copy of the indices of the TreePath.
+func (p TreePath) GetIndices() []int {
+	return []int(p.Copy())
+}
+
+// Copy returns a new TreePath with the same indices.
+func (p TreePath) Copy() TreePath {
+	return NewTreePathFromIndices(p...)
+}
+
+// AppendIndex returns a new TreePath with the given index appended, moving
+// the path down one level to a child row.
+func (p TreePath) AppendIndex(index int) TreePath {
+	return append(p.Copy(), index)
+}
+
+// PrependIndex returns a new TreePath with the given index prepended.
+func (p TreePath) PrependIndex(index int) TreePath {
+	return append(TreePath{index}, p...)
+}
+
+// Next returns a new TreePath pointing at the next sibling row. The returned
+// path may not point at an existing row.
+func (p TreePath) Next() TreePath {
+	next := p.Copy()
+	if len(next) > 0 {
+		next[len(next)-1] += 1
+	}
+	return next
+}
+
+// Prev returns a new TreePath pointing at the previous sibling row and true,
+// or nil and false if there is no previous sibling.
+func (p TreePath) Prev() (TreePath, bool) {
+	if len(p) == 0 || p[len(p)-1] == 0 {
+		return nil, false
+	}
+	prev := p.Copy()
+	prev[len(prev)-1] -= 1
+	return prev, true
+}
+
+// Up returns a new TreePath pointing at the parent row and true, or nil and
+// false if the path is already at the top level.
+func (p TreePath) Up() (TreePath, bool) {
+	if len(p) <= 1 {
+		return nil, false
+	}
+	return p[:len(p)-1].Copy(), true
+}
+
+// Down returns a new TreePath pointing at the first child row.
+func (p TreePath) Down() TreePath {
+	return p.AppendIndex(0)
+}
+
+// Compare returns -1, 0 or 1 depending on whether the TreePath is before,
+// equal to or after the other TreePath in depth-first order.
+func (p TreePath) Compare(other TreePath) int {
+	for idx := 0; idx < len(p) && idx < len(other); idx++ {
+		if p[idx] < other[idx] {
+			return -1
+		} else if p[idx] > other[idx] {
+			return 1
+		}
+	}
+	if len(p) < len(other) {
+		return -1
+	} else if len(p) > len(other) {
+		return 1
+	}
+	return 0
+}
+
+// IsAncestor returns TRUE if the descendant path is contained within the
+// TreePath.
+func (p TreePath) IsAncestor(descendant TreePath) bool {
+	if len(descendant) <= len(p) {
+		return false
+	}
+	for idx, v := range p {
+		if descendant[idx] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// IsDescendant returns TRUE if the TreePath is contained within the ancestor
+// path.
+func (p TreePath) IsDescendant(ancestor TreePath) bool {
+	return ancestor.IsAncestor(p)
+}
+
+// TreeIter is a reference to a specific row within a TreeModel. A TreeIter is
+// only meaningful to the model which created it and is valid for as long as
+// the model says it is. Models with the TREE_MODEL_ITERS_PERSIST flag keep
+// their iterators valid for as long as the row they reference exists.
+type TreeIter struct {
+	Stamp    int
+	UserData interface{}
+}
+
+// TreeModelForeachFunc is the signature of the callback used by
+// TreeModel.Foreach. Returning TRUE stops the iteration.
+type TreeModelForeachFunc = func(model TreeModel, path TreePath, iter *TreeIter) (stop bool)
+
+// TreeModel is the interface implemented by data sources for the TreeView
+// widget. A TreeModel is a hierarchical list of rows, each of which holds a
+// fixed number of typed columns. The ListStore and TreeStore types are the
+// stock implementations of the TreeModel interface.
+//
+// The model emits the row-changed, row-inserted, row-has-child-toggled,
+// row-deleted and rows-reordered signals to notify any views of changes to the
+// underlying data.
+type TreeModel interface {
+	Object
+
+	GetFlags() (flags enums.TreeModelFlags)
+	GetNColumns() (columns int)
+	GetColumnType(index int) (columnType cdk.PropertyType)
+	GetIter(path TreePath) (iter *TreeIter, ok bool)
+	GetIterFromString(pathString string) (iter *TreeIter, ok bool)
+	GetIterFirst() (iter *TreeIter, ok bool)
+	GetPath(iter *TreeIter) (path TreePath)
+	GetStringFromIter(iter *TreeIter) (pathString string)
+	GetValue(iter *TreeIter, column int) (value interface{})
+	IterNext(iter *TreeIter) (ok bool)
+	IterChildren(parent *TreeIter) (iter *TreeIter, ok bool)
+	IterHasChild(iter *TreeIter) (ok bool)
+	IterNChildren(iter *TreeIter) (count int)
+	IterNthChild(parent *TreeIter, n int) (iter *TreeIter, ok bool)
+	IterParent(child *TreeIter) (iter *TreeIter, ok bool)
+	Foreach(fn TreeModelForeachFunc)
+	RowChanged(path TreePath, iter *TreeIter)
+	RowInserted(path TreePath, iter *TreeIter)
+	RowHasChildToggled(path TreePath, iter *TreeIter)
+	RowDeleted(path TreePath)
+	RowsReordered(path TreePath, iter *TreeIter, newOrder []int)
+}
+
+// TreeModelForeach is a helper for TreeModel implementations, walking the given
+// model depth-first and calling fn for each row until fn returns TRUE.
+func TreeModelForeach(model TreeModel, fn TreeModelForeachFunc) {
+	var walk func(parent *TreeIter) (stop bool)
+	walk = func(parent *TreeIter) (stop bool) {
+		iter, ok := model.IterChildren(parent)
+		for ok {
+			if fn(model, model.GetPath(iter), iter) {
+				return true
+			}
+			if model.IterHasChild(iter) {
+				if walk(iter) {
+					return true
+				}
+			}
+			ok = model.IterNext(iter)
+		}
+		return false
+	}
+	walk(nil)
+}
+
+// TreeModelGetString is a convenience function returning the value of the
+// given column as a string, formatting non-string values with fmt.
+func TreeModelGetString(model TreeModel, iter *TreeIter, column int) (value string) {
+	switch v := model.GetValue(iter, column).(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// TreeModelColumnTypeFromName translates GType names, as used in GtkBuilder
+// files, and the cdk.PropertyType names into a cdk.PropertyType. Unknown type
+// names translate to cdk.StructProperty.
+func TreeModelColumnTypeFromName(name string) (columnType cdk.PropertyType) {
+	switch strings.ToLower(name) {
+	case "gchararray", "string":
+		return cdk.StringProperty
+	case "gint", "guint", "glong", "gulong", "gint64", "guint64", "gchar", "guchar", "int":
+		return cdk.IntProperty
+	case "gboolean", "bool":
+		return cdk.BoolProperty
+	case "gfloat", "gdouble", "float":
+		return cdk.FloatProperty
+	}
+	return cdk.StructProperty
+}
+
+// treePathShiftInsert updates the given path to account for a row being
+// inserted at the inserted path, returning the updated path.
+func treePathShiftInsert(path, inserted TreePath) TreePath {
+	depth := len(inserted) - 1
+	if len(path) <= depth {
+		return path
+	}
+	for idx := 0; idx < depth; idx++ {
+		if path[idx] != inserted[idx] {
+			return path
+		}
+	}
+	if path[depth] >= inserted[depth] {
+		path = path.Copy()
+		path[depth] += 1
+	}
+	return path
+}
+
+// treePathShiftDelete updates the given path to account for the row at the
+// deleted path being removed. If the path was the deleted row, or one of its
+// descendants, nil and false are returned.
+func treePathShiftDelete(path, deleted TreePath) (TreePath, bool) {
+	if path.Compare(deleted) == 0 || deleted.IsAncestor(path) {
+		return nil, false
+	}
+	depth := len(deleted) - 1
+	if len(path) <= depth {
+		return path, true
+	}
+	for idx := 0; idx < depth; idx++ {
+		if path[idx] != deleted[idx] {
+			return path, true
+		}
+	}
+	if path[depth] > deleted[depth] {
+		path = path.Copy()
+		path[depth] -= 1
+	}
+	return path, true
+}
+
+// treePathShiftReorder updates the given path to account for the children of
+// parent being reordered, where newOrder[newPosition] = oldPosition.
+func treePathShiftReorder(path, parent TreePath, newOrder []int) TreePath {
+	depth := len(parent)
+	if len(path) <= depth || (depth > 0 && !parent.IsAncestor(path)) {
+		return path
+	}
+	for newPos, oldPos := range newOrder {
+		if oldPos == path[depth] {
+			path = path.Copy()
+			path[depth] = newPos
+			break
+		}
+	}
+	return path
+}
+
+// The row-changed signal is emitted when a row in the model has changed.
+// Listener function arguments:
+//      path TreePath   the path identifying the changed row
+//      iter *TreeIter  a valid iterator pointing to the changed row
+const SignalRowChanged cdk.Signal = "row-changed"
+
+// This signal is emitted when a row has been deleted. Note that no iterator
+// is passed to the signal handler, since the row is already deleted.
+// Listener function arguments:
+//      path TreePath   the path identifying the row
+const SignalRowDeleted cdk.Signal = "row-deleted"
+
+// The row-has-child-toggled signal is emitted when a row has gotten the first
+// child row or lost its last child row.
+// Listener function arguments:
+//      path TreePath   the path identifying the row
+//      iter *TreeIter  a valid iterator pointing to the row
+const SignalRowHasChildToggled cdk.Signal = "row-has-child-toggled"
+
+// This signal is emitted when a new row has been inserted in the model. Note
+// that the row may still be empty at this point, since it is a common pattern
+// to first insert an empty row, and then fill it with the desired values.
+// Listener function arguments:
+//      path TreePath   the path identifying the new row
+//      iter *TreeIter  a valid iterator pointing to the new row
+const SignalRowInserted cdk.Signal = "row-inserted"
+
+// The rows-reordered signal is emitted when the children of a node in the
+// model have been reordered.
+// Listener function arguments:
+//      path TreePath   the path of the parent, empty for the top level
+//      iter *TreeIter  a valid iterator pointing to the parent, or nil
+//      newOrder []int  where newOrder[newPosition] = oldPosition
+const SignalRowsReordered cdk.Signal = "rows-reordered"
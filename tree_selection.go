@@ -0,0 +1,457 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"sort"
+
+	"github.com/go-curses/cdk"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeTreeSelection cdk.CTypeTag = "ctk-tree-selection"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeTreeSelection, nil)
+}
+
+// TreeSelectionFunc is the signature of the function set with
+// SetSelectFunction. It is called before any node is selected or unselected,
+// giving some control over which nodes are selected. The function should
+// return TRUE if the state of the node may be toggled, and FALSE if the state
+// of the node should be left unchanged.
+type TreeSelectionFunc = func(selection TreeSelection, model TreeModel, path TreePath, pathCurrentlySelected bool) bool
+
+// TreeSelectionForeachFunc is the signature of the function used by
+// SelectedForeach, called once for each selected row.
+type TreeSelectionForeachFunc = func(model TreeModel, path TreePath, iter *TreeIter)
+
+// TreeSelection Hierarchy:
+//	Object
+//	  +- TreeSelection
+//
+// The TreeSelection object is a helper object to manage the selection for a
+// TreeView widget. The TreeSelection object is automatically created when a
+// new TreeView widget is created, and cannot exist independently of this
+// widget. The primary reason the TreeSelection objects exists is for cleanliness
+// of code and API. That is, there is no conceptual reason all these functions
+// could not be methods on the TreeView widget instead of a separate function.
+//
+// One of the important things to remember when monitoring the selection of a
+// view is that the changed signal is mostly a hint. That is, it may only emit
+// one signal when a range of rows is selected. Additionally, it may on
+// occasion emit a changed signal when nothing has happened.
+type TreeSelection interface {
+	Object
+
+	Init() (already bool)
+	SetMode(mode enums.SelectionMode)
+	GetMode() (mode enums.SelectionMode)
+	SetSelectFunction(fn TreeSelectionFunc)
+	GetTreeView() (treeView TreeView)
+	GetSelected() (model TreeModel, iter *TreeIter, ok bool)
+	SelectedForeach(fn TreeSelectionForeachFunc)
+	GetSelectedRows() (model TreeModel, paths []TreePath)
+	CountSelectedRows() (count int)
+	SelectPath(path TreePath)
+	UnselectPath(path TreePath)
+	PathIsSelected(path TreePath) (selected bool)
+	SelectIter(iter *TreeIter)
+	UnselectIter(iter *TreeIter)
+	IterIsSelected(iter *TreeIter) (selected bool)
+	SelectAll()
+	UnselectAll()
+	SelectRange(startPath, endPath TreePath)
+	UnselectRange(startPath, endPath TreePath)
+	Changed()
+}
+
+var _ TreeSelection = (*CTreeSelection)(nil)
+
+// The CTreeSelection structure implements the TreeSelection interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with TreeSelection objects.
+type CTreeSelection struct {
+	CObject
+
+	treeView TreeView
+	mode     enums.SelectionMode
+	selectFn TreeSelectionFunc
+	selected map[string]TreePath
+}
+
+// NewTreeSelection is the constructor for new TreeSelection instances. This is
+// only used by the TreeView widget, use TreeView.GetSelection() to get the
+// selection of a TreeView.
+func NewTreeSelection(treeView TreeView) TreeSelection {
+	s := new(CTreeSelection)
+	s.Init()
+	s.treeView = treeView
+	return s
+}
+
+// Init initializes a TreeSelection object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the TreeSelection instance. Init is used in the
+// NewTreeSelection constructor and only necessary when implementing a
+// derivative TreeSelection type.
+func (s *CTreeSelection) Init() (already bool) {
+	if s.InitTypeItem(TypeTreeSelection, s) {
+		return true
+	}
+	s.CObject.Init()
+	s.mode = enums.SELECTION_SINGLE
+	s.selected = make(map[string]TreePath)
+	return false
+}
+
+// SetMode sets the selection mode of the selection. If the previous type was
+// SELECTION_MULTIPLE, then the anchor is kept selected, if it was previously
+// selected.
+//
+// Parameters:
+// 	mode	the selection mode
+func (s *CTreeSelection) SetMode(mode enums.SelectionMode) {
+	s.Lock()
+	s.mode = mode
+	s.Unlock()
+	switch mode {
+	case enums.SELECTION_NONE:
+		s.UnselectAll()
+	case enums.SELECTION_SINGLE, enums.SELECTION_BROWSE:
+		if s.CountSelectedRows() > 1 {
+			var anchor TreePath
+			if s.treeView != nil {
+				anchor, _ = s.treeView.GetCursor()
+			}
+			keep := anchor != nil && s.PathIsSelected(anchor)
+			s.unselectAll()
+			if keep {
+				s.selectPath(anchor)
+			}
+			s.Changed()
+		}
+	}
+}
+
+// GetMode returns the selection mode for selection.
+// See: SetMode()
+func (s *CTreeSelection) GetMode() (mode enums.SelectionMode) {
+	s.RLock()
+	defer s.RUnlock()
+	return s.mode
+}
+
+// SetSelectFunction sets the selection function. If set, this function is
+// called before any node is selected or unselected, giving some control over
+// which nodes are selected.
+//
+// Parameters:
+// 	fn	the selection function, nil to remove any existing function
+func (s *CTreeSelection) SetSelectFunction(fn TreeSelectionFunc) {
+	s.Lock()
+	defer s.Unlock()
+	s.selectFn = fn
+}
+
+// GetTreeView returns the tree view associated with selection.
+func (s *CTreeSelection) GetTreeView() (treeView TreeView) {
+	return s.treeView
+}
+
+// GetSelected returns an iter pointing at the currently selected row and true,
+// or nil and false if no row is selected. This method only works when the
+// selection mode is SELECTION_SINGLE or SELECTION_BROWSE.
+func (s *CTreeSelection) GetSelected() (model TreeModel, iter *TreeIter, ok bool) {
+	model = s.getModel()
+	if mode := s.GetMode(); mode == enums.SELECTION_MULTIPLE || model == nil {
+		return
+	}
+	if _, paths := s.GetSelectedRows(); len(paths) > 0 {
+		iter, ok = model.GetIter(paths[0])
+	}
+	return
+}
+
+// SelectedForeach calls the given function for each selected node, in the
+// order they appear within the model.
+func (s *CTreeSelection) SelectedForeach(fn TreeSelectionForeachFunc) {
+	model, paths := s.GetSelectedRows()
+	if model == nil {
+		return
+	}
+	for _, path := range paths {
+		if iter, ok := model.GetIter(path); ok {
+			fn(model, path, iter)
+		}
+	}
+}
+
+// GetSelectedRows returns the model and the paths of all selected rows, in the
+// order they appear within the model.
+func (s *CTreeSelection) GetSelectedRows() (model TreeModel, paths []TreePath) {
+	model = s.getModel()
+	s.RLock()
+	for _, path := range s.selected {
+		paths = append(paths, path.Copy())
+	}
+	s.RUnlock()
+	sort.Slice(paths, func(i, j int) bool {
+		return paths[i].Compare(paths[j]) < 0
+	})
+	return
+}
+
+// CountSelectedRows returns the number of rows that have been selected.
+func (s *CTreeSelection) CountSelectedRows() (count int) {
+	s.RLock()
+	defer s.RUnlock()
+	return len(s.selected)
+}
+
+// SelectPath selects the row at path. In SELECTION_SINGLE and
+// SELECTION_BROWSE modes, any previously selected row is unselected.
+//
+// Emits: SignalChanged, Argv=[TreeSelection instance]
+func (s *CTreeSelection) SelectPath(path TreePath) {
+	if path == nil || s.PathIsSelected(path) || !s.canToggle(path, false) {
+		return
+	}
+	switch s.GetMode() {
+	case enums.SELECTION_NONE:
+		return
+	case enums.SELECTION_SINGLE, enums.SELECTION_BROWSE:
+		s.unselectAll()
+	}
+	s.selectPath(path)
+	s.Changed()
+}
+
+// UnselectPath unselects the row at path. In SELECTION_BROWSE mode the
+// selected row cannot be unselected.
+//
+// Emits: SignalChanged, Argv=[TreeSelection instance]
+func (s *CTreeSelection) UnselectPath(path TreePath) {
+	if path == nil || !s.PathIsSelected(path) || s.GetMode() == enums.SELECTION_BROWSE {
+		return
+	}
+	if !s.canToggle(path, true) {
+		return
+	}
+	s.Lock()
+	delete(s.selected, path.String())
+	s.Unlock()
+	s.Changed()
+}
+
+// PathIsSelected returns TRUE if the row pointed to by path is currently
+// selected. If path does not point to a valid location, FALSE is returned.
+func (s *CTreeSelection) PathIsSelected(path TreePath) (selected bool) {
+	if path == nil {
+		return false
+	}
+	s.RLock()
+	defer s.RUnlock()
+	_, selected = s.selected[path.String()]
+	return
+}
+
+// SelectIter selects the row at iter.
+//
+// Emits: SignalChanged, Argv=[TreeSelection instance]
+func (s *CTreeSelection) SelectIter(iter *TreeIter) {
+	if model := s.getModel(); model != nil {
+		s.SelectPath(model.GetPath(iter))
+	}
+}
+
+// UnselectIter unselects the row at iter.
+//
+// Emits: SignalChanged, Argv=[TreeSelection instance]
+func (s *CTreeSelection) UnselectIter(iter *TreeIter) {
+	if model := s.getModel(); model != nil {
+		s.UnselectPath(model.GetPath(iter))
+	}
+}
+
+// IterIsSelected returns TRUE if the row at iter is currently selected.
+func (s *CTreeSelection) IterIsSelected(iter *TreeIter) (selected bool) {
+	if model := s.getModel(); model != nil {
+		return s.PathIsSelected(model.GetPath(iter))
+	}
+	return false
+}
+
+// SelectAll selects all the visible rows of the tree view. The selection mode
+// must be SELECTION_MULTIPLE.
+//
+// Emits: SignalChanged, Argv=[TreeSelection instance]
+func (s *CTreeSelection) SelectAll() {
+	if s.GetMode() != enums.SELECTION_MULTIPLE || s.treeView == nil {
+		return
+	}
+	changed := false
+	for _, path := range s.treeView.GetVisibleRows() {
+		if !s.PathIsSelected(path) && s.canToggle(path, false) {
+			s.selectPath(path)
+			changed = true
+		}
+	}
+	if changed {
+		s.Changed()
+	}
+}
+
+// UnselectAll unselects all the rows.
+//
+// Emits: SignalChanged, Argv=[TreeSelection instance]
+func (s *CTreeSelection) UnselectAll() {
+	if s.CountSelectedRows() > 0 {
+		s.unselectAll()
+		s.Changed()
+	}
+}
+
+// SelectRange selects the visible rows of the tree view from startPath to
+// endPath, inclusive. The selection mode must be SELECTION_MULTIPLE.
+//
+// Emits: SignalChanged, Argv=[TreeSelection instance]
+func (s *CTreeSelection) SelectRange(startPath, endPath TreePath) {
+	if s.GetMode() != enums.SELECTION_MULTIPLE {
+		return
+	}
+	changed := false
+	for _, path := range s.rangeOf(startPath, endPath) {
+		if !s.PathIsSelected(path) && s.canToggle(path, false) {
+			s.selectPath(path)
+			changed = true
+		}
+	}
+	if changed {
+		s.Changed()
+	}
+}
+
+// UnselectRange unselects the visible rows of the tree view from startPath to
+// endPath, inclusive.
+//
+// Emits: SignalChanged, Argv=[TreeSelection instance]
+func (s *CTreeSelection) UnselectRange(startPath, endPath TreePath) {
+	changed := false
+	for _, path := range s.rangeOf(startPath, endPath) {
+		if s.PathIsSelected(path) && s.canToggle(path, true) {
+			s.Lock()
+			delete(s.selected, path.String())
+			s.Unlock()
+			changed = true
+		}
+	}
+	if changed {
+		s.Changed()
+	}
+}
+
+// Changed emits the changed signal.
+//
+// Emits: SignalChanged, Argv=[TreeSelection instance]
+func (s *CTreeSelection) Changed() {
+	s.Emit(SignalChanged, s)
+}
+
+// rowInserted, rowDeleted and rowsReordered keep the selected paths in sync
+// with changes to the model, used by the TreeView model signal handlers
+func (s *CTreeSelection) rowInserted(path TreePath) {
+	s.Lock()
+	defer s.Unlock()
+	selected := make(map[string]TreePath)
+	for _, sp := range s.selected {
+		sp = treePathShiftInsert(sp, path)
+		selected[sp.String()] = sp
+	}
+	s.selected = selected
+}
+
+func (s *CTreeSelection) rowDeleted(path TreePath) (changed bool) {
+	s.Lock()
+	defer s.Unlock()
+	selected := make(map[string]TreePath)
+	for _, sp := range s.selected {
+		if shifted, ok := treePathShiftDelete(sp, path); ok {
+			selected[shifted.String()] = shifted
+		} else {
+			changed = true
+		}
+	}
+	s.selected = selected
+	return
+}
+
+func (s *CTreeSelection) rowsReordered(parent TreePath, newOrder []int) {
+	s.Lock()
+	defer s.Unlock()
+	selected := make(map[string]TreePath)
+	for _, sp := range s.selected {
+		sp = treePathShiftReorder(sp, parent, newOrder)
+		selected[sp.String()] = sp
+	}
+	s.selected = selected
+}
+
+func (s *CTreeSelection) selectPath(path TreePath) {
+	s.Lock()
+	defer s.Unlock()
+	s.selected[path.String()] = path.Copy()
+}
+
+func (s *CTreeSelection) unselectAll() {
+	s.Lock()
+	defer s.Unlock()
+	s.selected = make(map[string]TreePath)
+}
+
+func (s *CTreeSelection) canToggle(path TreePath, currentlySelected bool) bool {
+	s.RLock()
+	fn := s.selectFn
+	s.RUnlock()
+	if fn != nil {
+		return fn(s, s.getModel(), path, currentlySelected)
+	}
+	return true
+}
+
+func (s *CTreeSelection) rangeOf(startPath, endPath TreePath) (paths []TreePath) {
+	if s.treeView == nil || startPath == nil || endPath == nil {
+		return
+	}
+	if startPath.Compare(endPath) > 0 {
+		startPath, endPath = endPath, startPath
+	}
+	for _, path := range s.treeView.GetVisibleRows() {
+		if path.Compare(startPath) >= 0 && path.Compare(endPath) <= 0 {
+			paths = append(paths, path)
+		}
+	}
+	return
+}
+
+func (s *CTreeSelection) getModel() TreeModel {
+	if s.treeView != nil {
+		return s.treeView.GetModel()
+	}
+	return nil
+}
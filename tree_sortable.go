@@ -0,0 +1,106 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-curses/cdk"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+// TreeSortableDefaultSortColumnId is the sort column id used to indicate
+// that the default sort function is to be used.
+const TreeSortableDefaultSortColumnId = -1
+
+// TreeSortableUnsortedSortColumnId is the sort column id used to indicate
+// that no sorting is to be performed.
+const TreeSortableUnsortedSortColumnId = -2
+
+// TreeIterCompareFunc is the signature of the functions used to sort the rows
+// of a TreeSortable model. The function should return a negative integer, zero
+// or a positive integer if a sorts before, with or after b respectively.
+type TreeIterCompareFunc = func(model TreeModel, a, b *TreeIter) int
+
+// TreeSortable is the interface for TreeModel implementations which support
+// sorting. The TreeView uses it to sort the model when a clickable column
+// header is activated.
+type TreeSortable interface {
+	TreeModel
+
+	GetSortColumnId() (sortColumnId int, order enums.SortType, ok bool)
+	SetSortColumnId(sortColumnId int, order enums.SortType)
+	SetSortFunc(sortColumnId int, fn TreeIterCompareFunc)
+	SetDefaultSortFunc(fn TreeIterCompareFunc)
+	HasDefaultSortFunc() (ok bool)
+	SortColumnChanged()
+}
+
+// TreeModelCompareValues is the default comparison used when sorting a column
+// which has no explicit sort function. Strings compare case-insensitively,
+// numbers and booleans compare numerically and any other values compare by
+// their fmt representation.
+func TreeModelCompareValues(a, b interface{}) int {
+	switch av := a.(type) {
+	case string:
+		if bv, ok := b.(string); ok {
+			if c := strings.Compare(strings.ToLower(av), strings.ToLower(bv)); c != 0 {
+				return c
+			}
+			return strings.Compare(av, bv)
+		}
+	case int:
+		if bv, ok := b.(int); ok {
+			return treeModelCompareFloats(float64(av), float64(bv))
+		}
+	case float64:
+		if bv, ok := b.(float64); ok {
+			return treeModelCompareFloats(av, bv)
+		}
+	case bool:
+		if bv, ok := b.(bool); ok {
+			if av == bv {
+				return 0
+			} else if bv {
+				return -1
+			}
+			return 1
+		}
+	}
+	if a == nil && b == nil {
+		return 0
+	} else if a == nil {
+		return -1
+	} else if b == nil {
+		return 1
+	}
+	return strings.Compare(fmt.Sprintf("%v", a), fmt.Sprintf("%v", b))
+}
+
+func treeModelCompareFloats(a, b float64) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+// The sort-column-changed signal is emitted when the sort column or sort order
+// of sortable is changed. The signal is emitted before the contents of sortable
+// are resorted.
+const SignalSortColumnChanged cdk.Signal = "sort-column-changed"
@@ -0,0 +1,1055 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/go-curses/cdk"
+	cstrings "github.com/go-curses/cdk/lib/strings"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeTreeStore cdk.CTypeTag = "ctk-tree-store"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeTreeStore, func() interface{} { return MakeTreeStore() })
+}
+
+// TreeStore Hierarchy:
+//	Object
+//	  +- TreeStore
+//
+// The TreeStore object is a list model for use with a TreeView widget. It
+// implements the TreeModel interface, and consequentially, can use all of the
+// methods available there. It also implements the TreeSortable interface so
+// it can be sorted by the view. Each row holds one value for each of the column
+// types given to the constructor and rows may contain any number of child rows.
+//
+// Values stored in the TreeStore are checked against the column types:
+// StringProperty columns hold string values, IntProperty columns hold int
+// values, FloatProperty columns hold float64 values, BoolProperty columns hold
+// bool values and StructProperty columns hold any value.
+type TreeStore interface {
+	TreeSortable
+
+	Init() (already bool)
+	SetColumnTypes(types ...cdk.PropertyType)
+	Set(iter *TreeIter, columns []int, values []interface{}) (err error)
+	SetValue(iter *TreeIter, column int, value interface{}) (err error)
+	Remove(iter *TreeIter) (ok bool)
+	Insert(parent *TreeIter, position int) (iter *TreeIter)
+	InsertBefore(parent, sibling *TreeIter) (iter *TreeIter)
+	InsertAfter(parent, sibling *TreeIter) (iter *TreeIter)
+	InsertWithValues(parent *TreeIter, position int, columns []int, values []interface{}) (iter *TreeIter, err error)
+	Prepend(parent *TreeIter) (iter *TreeIter)
+	Append(parent *TreeIter) (iter *TreeIter)
+	IsAncestor(iter, descendant *TreeIter) (ok bool)
+	IterDepth(iter *TreeIter) (depth int)
+	Clear()
+	IterIsValid(iter *TreeIter) (ok bool)
+	Reorder(parent *TreeIter, newOrder []int)
+	Swap(a, b *TreeIter)
+	MoveBefore(iter, position *TreeIter)
+	MoveAfter(iter, position *TreeIter)
+}
+
+var _ TreeStore = (*CTreeStore)(nil)
+
+// The CTreeStore structure implements the TreeStore interface and is exported
+// to facilitate type embedding with custom implementations. No member variables
+// are exported as the interface methods are the only intended means of
+// interacting with TreeStore objects.
+type CTreeStore struct {
+	CObject
+
+	types           []cdk.PropertyType
+	root            *treeStoreNode
+	stamp           int
+	modelFlags      enums.TreeModelFlags
+	sortColumnId    int
+	sortOrder       enums.SortType
+	sortFuncs       map[int]TreeIterCompareFunc
+	defaultSortFunc TreeIterCompareFunc
+}
+
+type treeStoreNode struct {
+	parent   *treeStoreNode
+	children []*treeStoreNode
+	values   []interface{}
+}
+
+func (n *treeStoreNode) index() int {
+	if n.parent != nil {
+		for idx, child := range n.parent.children {
+			if child == n {
+				return idx
+			}
+		}
+	}
+	return -1
+}
+
+// MakeTreeStore is used by the Buildable system to construct a new TreeStore.
+func MakeTreeStore() TreeStore {
+	return NewTreeStore()
+}
+
+// NewTreeStore is the constructor for new TreeStore instances. The types given
+// are the column types of the new TreeStore, in column order.
+func NewTreeStore(types ...cdk.PropertyType) TreeStore {
+	s := new(CTreeStore)
+	s.Init()
+	s.SetColumnTypes(types...)
+	return s
+}
+
+// Init initializes a TreeStore object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the TreeStore instance. Init is used in the
+// NewTreeStore constructor and only necessary when implementing a derivative
+// TreeStore type.
+func (s *CTreeStore) Init() (already bool) {
+	if s.InitTypeItem(TypeTreeStore, s) {
+		return true
+	}
+	s.CObject.Init()
+	s.types = make([]cdk.PropertyType, 0)
+	s.root = &treeStoreNode{}
+	s.stamp = 1
+	s.modelFlags = enums.TREE_MODEL_ITERS_PERSIST
+	s.sortColumnId = TreeSortableUnsortedSortColumnId
+	s.sortOrder = enums.SORT_ASCENDING
+	s.sortFuncs = make(map[int]TreeIterCompareFunc)
+	return false
+}
+
+// Build provides customizations to the Buildable system for TreeStore objects.
+// The <columns> and <data> elements of GtkListStore and GtkTreeStore objects
+// are supported, <data> rows being appended to the top level of the model.
+func (s *CTreeStore) Build(builder Builder, element *CBuilderElement) error {
+	if err := s.CObject.Build(builder, element); err != nil {
+		return err
+	}
+	for _, custom := range element.Custom {
+		switch custom.TagName {
+		case "columns":
+			var types []cdk.PropertyType
+			for _, column := range custom.Children {
+				types = append(types, TreeModelColumnTypeFromName(column.Attributes["type"]))
+			}
+			s.SetColumnTypes(types...)
+		case "data":
+			for _, row := range custom.Children {
+				var columns []int
+				var values []interface{}
+				for _, col := range row.Children {
+					id, err := strconv.Atoi(col.Attributes["id"])
+					if err != nil {
+						return fmt.Errorf("invalid column id: %v", err)
+					}
+					value, err := s.parseColumnValue(id, col.Content)
+					if err != nil {
+						return err
+					}
+					columns = append(columns, id)
+					values = append(values, value)
+				}
+				if _, err := s.InsertWithValues(nil, -1, columns, values); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// SetColumnTypes sets the column types of the TreeStore. This is only
+// permitted while the TreeStore has no rows.
+//
+// Locking: write
+func (s *CTreeStore) SetColumnTypes(types ...cdk.PropertyType) {
+	s.Lock()
+	defer s.Unlock()
+	if len(s.root.children) > 0 {
+		s.LogError("cannot change column types of a store with rows")
+		return
+	}
+	s.types = make([]cdk.PropertyType, len(types))
+	copy(s.types, types)
+}
+
+// GetFlags returns the TreeModelFlags supported by the TreeStore.
+func (s *CTreeStore) GetFlags() (flags enums.TreeModelFlags) {
+	s.RLock()
+	defer s.RUnlock()
+	return s.modelFlags
+}
+
+// GetNColumns returns the number of columns supported by the TreeStore.
+//
+// Locking: read
+func (s *CTreeStore) GetNColumns() (columns int) {
+	s.RLock()
+	defer s.RUnlock()
+	return len(s.types)
+}
+
+// GetColumnType returns the type of the column at the given index, or
+// cdk.StructProperty if the index is out of range.
+//
+// Locking: read
+func (s *CTreeStore) GetColumnType(index int) (columnType cdk.PropertyType) {
+	s.RLock()
+	defer s.RUnlock()
+	if index >= 0 && index < len(s.types) {
+		return s.types[index]
+	}
+	return cdk.StructProperty
+}
+
+// GetIter returns a new TreeIter pointing at the row identified by the given
+// path and true, or nil and false if the path does not exist.
+//
+// Locking: read
+func (s *CTreeStore) GetIter(path TreePath) (iter *TreeIter, ok bool) {
+	s.RLock()
+	defer s.RUnlock()
+	if node := s.nodeAtPath(path); node != nil {
+		return s.makeIter(node), true
+	}
+	return nil, false
+}
+
+// GetIterFromString is a convenience wrapper around GetIter accepting the
+// string form of a TreePath.
+//
+// Locking: read
+func (s *CTreeStore) GetIterFromString(pathString string) (iter *TreeIter, ok bool) {
+	if path, err := NewTreePathFromString(pathString); err == nil {
+		return s.GetIter(path)
+	}
+	return nil, false
+}
+
+// GetIterFirst returns a new TreeIter pointing at the first row of the model
+// and true, or nil and false if the model is empty.
+//
+// Locking: read
+func (s *CTreeStore) GetIterFirst() (iter *TreeIter, ok bool) {
+	return s.GetIter(NewTreePath())
+}
+
+// GetPath returns the TreePath of the row the given iter points at, or nil if
+// the iter is not valid.
+//
+// Locking: read
+func (s *CTreeStore) GetPath(iter *TreeIter) (path TreePath) {
+	s.RLock()
+	defer s.RUnlock()
+	if node := s.validNode(iter); node != nil {
+		return s.nodePath(node)
+	}
+	return nil
+}
+
+// GetStringFromIter returns the string form of the path of the row the given
+// iter points at.
+//
+// Locking: read
+func (s *CTreeStore) GetStringFromIter(iter *TreeIter) (pathString string) {
+	return s.GetPath(iter).String()
+}
+
+// GetValue returns the value of the given column for the row the iter points
+// at. Unset values are returned as the zero value of the column type.
+//
+// Locking: read
+func (s *CTreeStore) GetValue(iter *TreeIter, column int) (value interface{}) {
+	s.RLock()
+	defer s.RUnlock()
+	if node := s.validNode(iter); node != nil && column >= 0 && column < len(s.types) {
+		if column < len(node.values) && node.values[column] != nil {
+			return node.values[column]
+		}
+		switch s.types[column] {
+		case cdk.StringProperty:
+			return ""
+		case cdk.IntProperty:
+			return 0
+		case cdk.FloatProperty:
+			return 0.0
+		case cdk.BoolProperty:
+			return false
+		}
+	}
+	return nil
+}
+
+// IterNext moves the given iter to the next sibling row, returning false and
+// invalidating the iter if there is no next sibling.
+//
+// Locking: read
+func (s *CTreeStore) IterNext(iter *TreeIter) (ok bool) {
+	s.RLock()
+	defer s.RUnlock()
+	if node := s.validNode(iter); node != nil {
+		idx := node.index()
+		if idx >= 0 && idx+1 < len(node.parent.children) {
+			iter.UserData = node.parent.children[idx+1]
+			return true
+		}
+	}
+	if iter != nil {
+		iter.Stamp = 0
+		iter.UserData = nil
+	}
+	return false
+}
+
+// IterChildren returns a new TreeIter pointing at the first child of the given
+// parent and true, or nil and false if there are no children. A nil parent
+// returns the first row of the model.
+//
+// Locking: read
+func (s *CTreeStore) IterChildren(parent *TreeIter) (iter *TreeIter, ok bool) {
+	return s.IterNthChild(parent, 0)
+}
+
+// IterHasChild returns TRUE if the row the given iter points at has children.
+//
+// Locking: read
+func (s *CTreeStore) IterHasChild(iter *TreeIter) (ok bool) {
+	return s.IterNChildren(iter) > 0
+}
+
+// IterNChildren returns the number of children of the row the given iter
+// points at. A nil iter returns the number of top level rows.
+//
+// Locking: read
+func (s *CTreeStore) IterNChildren(iter *TreeIter) (count int) {
+	s.RLock()
+	defer s.RUnlock()
+	if node := s.parentNode(iter); node != nil {
+		return len(node.children)
+	}
+	return 0
+}
+
+// IterNthChild returns a new TreeIter pointing at the nth child of the given
+// parent and true, or nil and false if there is no such child. A nil parent
+// refers to the top level of the model.
+//
+// Locking: read
+func (s *CTreeStore) IterNthChild(parent *TreeIter, n int) (iter *TreeIter, ok bool) {
+	s.RLock()
+	defer s.RUnlock()
+	if node := s.parentNode(parent); node != nil {
+		if n >= 0 && n < len(node.children) {
+			return s.makeIter(node.children[n]), true
+		}
+	}
+	return nil, false
+}
+
+// IterParent returns a new TreeIter pointing at the parent of the given child
+// and true, or nil and false if the child is a top level row.
+//
+// Locking: read
+func (s *CTreeStore) IterParent(child *TreeIter) (iter *TreeIter, ok bool) {
+	s.RLock()
+	defer s.RUnlock()
+	if node := s.validNode(child); node != nil && node.parent != s.root {
+		return s.makeIter(node.parent), true
+	}
+	return nil, false
+}
+
+// Foreach calls fn on each row in the model, depth-first, until fn returns
+// TRUE.
+func (s *CTreeStore) Foreach(fn TreeModelForeachFunc) {
+	TreeModelForeach(s.model(), fn)
+}
+
+// RowChanged emits the row-changed signal.
+func (s *CTreeStore) RowChanged(path TreePath, iter *TreeIter) {
+	s.Emit(SignalRowChanged, s, path, iter)
+}
+
+// RowInserted emits the row-inserted signal.
+func (s *CTreeStore) RowInserted(path TreePath, iter *TreeIter) {
+	s.Emit(SignalRowInserted, s, path, iter)
+}
+
+// RowHasChildToggled emits the row-has-child-toggled signal.
+func (s *CTreeStore) RowHasChildToggled(path TreePath, iter *TreeIter) {
+	s.Emit(SignalRowHasChildToggled, s, path, iter)
+}
+
+// RowDeleted emits the row-deleted signal.
+func (s *CTreeStore) RowDeleted(path TreePath) {
+	s.Emit(SignalRowDeleted, s, path)
+}
+
+// RowsReordered emits the rows-reordered signal.
+func (s *CTreeStore) RowsReordered(path TreePath, iter *TreeIter, newOrder []int) {
+	s.Emit(SignalRowsReordered, s, path, iter, newOrder)
+}
+
+// Set updates the values of the given columns for the row the iter points at.
+// The columns and values slices must be of the same length. If the TreeStore
+// is sorted, the row is moved to its sorted position.
+//
+// Emits: SignalRowChanged, Argv=[TreeStore instance, TreePath, *TreeIter]
+func (s *CTreeStore) Set(iter *TreeIter, columns []int, values []interface{}) (err error) {
+	if len(columns) != len(values) {
+		return fmt.Errorf("columns and values are of different lengths: %d != %d", len(columns), len(values))
+	}
+	s.Lock()
+	node := s.validNode(iter)
+	if node == nil {
+		s.Unlock()
+		return fmt.Errorf("invalid tree iter")
+	}
+	coerced := make([]interface{}, len(values))
+	for idx, column := range columns {
+		if column < 0 || column >= len(s.types) {
+			s.Unlock()
+			return fmt.Errorf("invalid column: %d", column)
+		}
+		if coerced[idx], err = treeStoreCoerceValue(s.types[column], values[idx]); err != nil {
+			s.Unlock()
+			return fmt.Errorf("column %d: %v", column, err)
+		}
+	}
+	for idx, column := range columns {
+		node.values[column] = coerced[idx]
+	}
+	path := s.nodePath(node)
+	s.Unlock()
+	s.RowChanged(path, iter)
+	// the row may have been removed by a row-changed handler
+	s.RLock()
+	var parent *treeStoreNode
+	if node = s.validNode(iter); node != nil {
+		parent = node.parent
+	}
+	s.RUnlock()
+	if parent != nil {
+		s.sortLevel(parent)
+	}
+	return nil
+}
+
+// SetValue is a convenience wrapper around Set for updating a single column.
+//
+// Emits: SignalRowChanged, Argv=[TreeStore instance, TreePath, *TreeIter]
+func (s *CTreeStore) SetValue(iter *TreeIter, column int, value interface{}) (err error) {
+	return s.Set(iter, []int{column}, []interface{}{value})
+}
+
+// Remove removes the row the given iter points at, along with all of its
+// children. After being removed, the iter is set to the next sibling row and
+// true is returned, or the iter is invalidated and false returned if there is
+// no next sibling.
+//
+// Emits: SignalRowDeleted, Argv=[TreeStore instance, TreePath]
+func (s *CTreeStore) Remove(iter *TreeIter) (ok bool) {
+	s.Lock()
+	node := s.validNode(iter)
+	if node == nil {
+		s.Unlock()
+		return false
+	}
+	parent := node.parent
+	path := s.nodePath(node)
+	idx := node.index()
+	parent.children = append(parent.children[:idx], parent.children[idx+1:]...)
+	node.parent = nil
+	lastChild := len(parent.children) == 0 && parent != s.root
+	var parentPath TreePath
+	if lastChild {
+		parentPath = s.nodePath(parent)
+	}
+	if idx < len(parent.children) {
+		iter.UserData = parent.children[idx]
+		ok = true
+	} else {
+		iter.Stamp = 0
+		iter.UserData = nil
+	}
+	s.Unlock()
+	s.RowDeleted(path)
+	if lastChild {
+		s.RowHasChildToggled(parentPath, s.makeIter(parent))
+	}
+	return
+}
+
+// Insert creates a new row at the given position within the children of
+// parent, or at the top level if parent is nil. A negative position, or one
+// larger than the number of children, appends the new row.
+//
+// Emits: SignalRowInserted, Argv=[TreeStore instance, TreePath, *TreeIter]
+func (s *CTreeStore) Insert(parent *TreeIter, position int) (iter *TreeIter) {
+	s.Lock()
+	parentNode := s.parentNode(parent)
+	if parentNode == nil {
+		s.Unlock()
+		s.LogError("invalid parent tree iter")
+		return nil
+	}
+	node := &treeStoreNode{
+		parent: parentNode,
+		values: make([]interface{}, len(s.types)),
+	}
+	if position < 0 || position > len(parentNode.children) {
+		position = len(parentNode.children)
+	}
+	parentNode.children = append(parentNode.children, nil)
+	copy(parentNode.children[position+1:], parentNode.children[position:])
+	parentNode.children[position] = node
+	firstChild := len(parentNode.children) == 1 && parentNode != s.root
+	path := s.nodePath(node)
+	iter = s.makeIter(node)
+	s.Unlock()
+	s.RowInserted(path, iter)
+	if firstChild {
+		s.RowHasChildToggled(path[:len(path)-1].Copy(), s.makeIter(parentNode))
+	}
+	return
+}
+
+// InsertBefore creates a new row before the given sibling. If sibling is nil
+// the row is appended to the children of parent.
+//
+// Emits: SignalRowInserted, Argv=[TreeStore instance, TreePath, *TreeIter]
+func (s *CTreeStore) InsertBefore(parent, sibling *TreeIter) (iter *TreeIter) {
+	if sibling == nil {
+		return s.Insert(parent, -1)
+	}
+	parent, position := s.siblingPosition(sibling)
+	return s.Insert(parent, position)
+}
+
+// InsertAfter creates a new row after the given sibling. If sibling is nil the
+// row is prepended to the children of parent.
+//
+// Emits: SignalRowInserted, Argv=[TreeStore instance, TreePath, *TreeIter]
+func (s *CTreeStore) InsertAfter(parent, sibling *TreeIter) (iter *TreeIter) {
+	if sibling == nil {
+		return s.Insert(parent, 0)
+	}
+	parent, position := s.siblingPosition(sibling)
+	if position < 0 {
+		return s.Insert(parent, position)
+	}
+	return s.Insert(parent, position+1)
+}
+
+// InsertWithValues creates a new row at the given position and sets the given
+// column values in one call.
+//
+// Emits: SignalRowInserted, Argv=[TreeStore instance, TreePath, *TreeIter]
+// Emits: SignalRowChanged, Argv=[TreeStore instance, TreePath, *TreeIter]
+func (s *CTreeStore) InsertWithValues(parent *TreeIter, position int, columns []int, values []interface{}) (iter *TreeIter, err error) {
+	if iter = s.Insert(parent, position); iter == nil {
+		return nil, fmt.Errorf("invalid parent tree iter")
+	}
+	if len(columns) > 0 {
+		err = s.Set(iter, columns, values)
+	}
+	return
+}
+
+// Prepend creates a new row as the first child of parent, or the first top
+// level row if parent is nil.
+//
+// Emits: SignalRowInserted, Argv=[TreeStore instance, TreePath, *TreeIter]
+func (s *CTreeStore) Prepend(parent *TreeIter) (iter *TreeIter) {
+	return s.Insert(parent, 0)
+}
+
+// Append creates a new row as the last child of parent, or the last top level
+// row if parent is nil.
+//
+// Emits: SignalRowInserted, Argv=[TreeStore instance, TreePath, *TreeIter]
+func (s *CTreeStore) Append(parent *TreeIter) (iter *TreeIter) {
+	return s.Insert(parent, -1)
+}
+
+// IsAncestor returns TRUE if iter is an ancestor of descendant.
+//
+// Locking: read
+func (s *CTreeStore) IsAncestor(iter, descendant *TreeIter) (ok bool) {
+	s.RLock()
+	defer s.RUnlock()
+	if node, other := s.validNode(iter), s.validNode(descendant); node != nil && other != nil {
+		for p := other.parent; p != nil; p = p.parent {
+			if p == node {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IterDepth returns the depth of the given iter, top level rows having a
+// depth of zero.
+//
+// Locking: read
+func (s *CTreeStore) IterDepth(iter *TreeIter) (depth int) {
+	s.RLock()
+	defer s.RUnlock()
+	if node := s.validNode(iter); node != nil {
+		return len(s.nodePath(node)) - 1
+	}
+	return -1
+}
+
+// Clear removes all rows from the TreeStore, invalidating all iters.
+//
+// Emits: SignalRowDeleted, Argv=[TreeStore instance, TreePath]
+func (s *CTreeStore) Clear() {
+	for {
+		iter, ok := s.GetIterFirst()
+		if !ok {
+			break
+		}
+		s.Remove(iter)
+	}
+	s.Lock()
+	s.stamp += 1
+	s.Unlock()
+}
+
+// IterIsValid returns TRUE if the given iter points at a row of this
+// TreeStore. This is a slow operation and intended for debugging purposes.
+//
+// Locking: read
+func (s *CTreeStore) IterIsValid(iter *TreeIter) (ok bool) {
+	s.RLock()
+	defer s.RUnlock()
+	return s.validNode(iter) != nil
+}
+
+// Reorder reorders the children of parent, or the top level rows if parent is
+// nil, such that newOrder[newPosition] = oldPosition. This method does nothing
+// if the TreeStore is sorted.
+//
+// Emits: SignalRowsReordered, Argv=[TreeStore instance, TreePath, *TreeIter, []int]
+func (s *CTreeStore) Reorder(parent *TreeIter, newOrder []int) {
+	s.Lock()
+	parentNode := s.parentNode(parent)
+	if parentNode == nil || len(newOrder) != len(parentNode.children) || s.isSorted() {
+		s.Unlock()
+		return
+	}
+	seen := make(map[int]bool)
+	children := make([]*treeStoreNode, len(newOrder))
+	for newPos, oldPos := range newOrder {
+		if oldPos < 0 || oldPos >= len(parentNode.children) || seen[oldPos] {
+			s.Unlock()
+			s.LogError("invalid reorder: %v", newOrder)
+			return
+		}
+		seen[oldPos] = true
+		children[newPos] = parentNode.children[oldPos]
+	}
+	parentNode.children = children
+	s.Unlock()
+	s.emitReordered(parentNode, newOrder)
+}
+
+// Swap swaps the positions of a and b, which must share the same parent. This
+// method does nothing if the TreeStore is sorted.
+//
+// Emits: SignalRowsReordered, Argv=[TreeStore instance, TreePath, *TreeIter, []int]
+func (s *CTreeStore) Swap(a, b *TreeIter) {
+	s.RLock()
+	an, bn := s.validNode(a), s.validNode(b)
+	if an == nil || bn == nil || an.parent != bn.parent {
+		s.RUnlock()
+		return
+	}
+	parent, ai, bi := an.parent, an.index(), bn.index()
+	newOrder := treeStoreIdentityOrder(len(parent.children))
+	s.RUnlock()
+	newOrder[ai], newOrder[bi] = bi, ai
+	s.Reorder(s.makeParentIter(parent), newOrder)
+}
+
+// MoveBefore moves iter to the position before position, which must share the
+// same parent. If position is nil, iter is moved to the end of the level.
+// This method does nothing if the TreeStore is sorted.
+//
+// Emits: SignalRowsReordered, Argv=[TreeStore instance, TreePath, *TreeIter, []int]
+func (s *CTreeStore) MoveBefore(iter, position *TreeIter) {
+	s.move(iter, position, true)
+}
+
+// MoveAfter moves iter to the position after position, which must share the
+// same parent. If position is nil, iter is moved to the start of the level.
+// This method does nothing if the TreeStore is sorted.
+//
+// Emits: SignalRowsReordered, Argv=[TreeStore instance, TreePath, *TreeIter, []int]
+func (s *CTreeStore) MoveAfter(iter, position *TreeIter) {
+	s.move(iter, position, false)
+}
+
+// GetSortColumnId returns the current sort column id and order, and true if
+// the sort column is not one of the special default or unsorted ids.
+//
+// Locking: read
+func (s *CTreeStore) GetSortColumnId() (sortColumnId int, order enums.SortType, ok bool) {
+	s.RLock()
+	defer s.RUnlock()
+	sortColumnId, order = s.sortColumnId, s.sortOrder
+	ok = sortColumnId != TreeSortableDefaultSortColumnId && sortColumnId != TreeSortableUnsortedSortColumnId
+	return
+}
+
+// SetSortColumnId sets the current sort column and order, sorting the
+// TreeStore accordingly. Use TreeSortableUnsortedSortColumnId to disable
+// sorting and TreeSortableDefaultSortColumnId to use the default sort func.
+//
+// Emits: SignalSortColumnChanged, Argv=[TreeStore instance]
+func (s *CTreeStore) SetSortColumnId(sortColumnId int, order enums.SortType) {
+	s.Lock()
+	if sortColumnId == TreeSortableDefaultSortColumnId && s.defaultSortFunc == nil {
+		s.Unlock()
+		s.LogError("cannot use default sort column without a default sort func")
+		return
+	}
+	if s.sortColumnId == sortColumnId && s.sortOrder == order {
+		s.Unlock()
+		return
+	}
+	s.sortColumnId, s.sortOrder = sortColumnId, order
+	s.Unlock()
+	s.SortColumnChanged()
+	s.sortAll()
+}
+
+// SetSortFunc sets the comparison function used when sorting by the given
+// column. Without a sort func, columns are sorted with
+// TreeModelCompareValues.
+func (s *CTreeStore) SetSortFunc(sortColumnId int, fn TreeIterCompareFunc) {
+	s.Lock()
+	s.sortFuncs[sortColumnId] = fn
+	resort := s.sortColumnId == sortColumnId
+	s.Unlock()
+	if resort {
+		s.sortAll()
+	}
+}
+
+// SetDefaultSortFunc sets the comparison function used when the sort column
+// is TreeSortableDefaultSortColumnId.
+func (s *CTreeStore) SetDefaultSortFunc(fn TreeIterCompareFunc) {
+	s.Lock()
+	s.defaultSortFunc = fn
+	resort := s.sortColumnId == TreeSortableDefaultSortColumnId
+	s.Unlock()
+	if resort {
+		s.sortAll()
+	}
+}
+
+// HasDefaultSortFunc returns TRUE if a default sort func has been set.
+//
+// Locking: read
+func (s *CTreeStore) HasDefaultSortFunc() (ok bool) {
+	s.RLock()
+	defer s.RUnlock()
+	return s.defaultSortFunc != nil
+}
+
+// SortColumnChanged emits the sort-column-changed signal.
+func (s *CTreeStore) SortColumnChanged() {
+	s.Emit(SignalSortColumnChanged, s)
+}
+
+func (s *CTreeStore) move(iter, position *TreeIter, before bool) {
+	s.RLock()
+	node := s.validNode(iter)
+	if node == nil {
+		s.RUnlock()
+		return
+	}
+	parent := node.parent
+	from, to := node.index(), 0
+	if position != nil {
+		other := s.validNode(position)
+		if other == nil || other.parent != parent {
+			s.RUnlock()
+			return
+		}
+		to = other.index()
+		if !before {
+			to += 1
+		}
+		if from < to {
+			to -= 1
+		}
+	} else if before {
+		to = len(parent.children) - 1
+	}
+	s.RUnlock()
+	if from == to {
+		return
+	}
+	newOrder := treeStoreIdentityOrder(len(parent.children))
+	newOrder = append(newOrder[:from], newOrder[from+1:]...)
+	newOrder = append(newOrder[:to], append([]int{from}, newOrder[to:]...)...)
+	s.Reorder(s.makeParentIter(parent), newOrder)
+}
+
+func (s *CTreeStore) model() TreeModel {
+	if model, ok := s.Self().(TreeModel); ok {
+		return model
+	}
+	return s
+}
+
+func (s *CTreeStore) isSorted() bool {
+	return s.sortColumnId != TreeSortableUnsortedSortColumnId
+}
+
+func (s *CTreeStore) sortAll() {
+	var nodes []*treeStoreNode
+	var walk func(node *treeStoreNode)
+	walk = func(node *treeStoreNode) {
+		nodes = append(nodes, node)
+		for _, child := range node.children {
+			if len(child.children) > 0 {
+				walk(child)
+			}
+		}
+	}
+	s.RLock()
+	walk(s.root)
+	s.RUnlock()
+	for _, node := range nodes {
+		s.sortLevel(node)
+	}
+}
+
+func (s *CTreeStore) sortLevel(parent *treeStoreNode) {
+	s.RLock()
+	if !s.isSorted() || parent == nil || len(parent.children) < 2 {
+		s.RUnlock()
+		return
+	}
+	sortColumnId, order := s.sortColumnId, s.sortOrder
+	compare := s.defaultSortFunc
+	if sortColumnId != TreeSortableDefaultSortColumnId {
+		compare = s.sortFuncs[sortColumnId]
+	}
+	iters := make([]*TreeIter, len(parent.children))
+	for idx, child := range parent.children {
+		iters[idx] = s.makeIter(child)
+	}
+	s.RUnlock()
+	newOrder := treeStoreIdentityOrder(len(iters))
+	sort.SliceStable(newOrder, func(i, j int) bool {
+		a, b := iters[newOrder[i]], iters[newOrder[j]]
+		var c int
+		if compare != nil {
+			c = compare(s.model(), a, b)
+		} else {
+			c = TreeModelCompareValues(s.GetValue(a, sortColumnId), s.GetValue(b, sortColumnId))
+		}
+		if order == enums.SORT_DESCENDING {
+			return c > 0
+		}
+		return c < 0
+	})
+	changed := false
+	for newPos, oldPos := range newOrder {
+		if newPos != oldPos {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return
+	}
+	s.Lock()
+	if len(parent.children) != len(newOrder) {
+		s.Unlock()
+		return
+	}
+	children := make([]*treeStoreNode, len(newOrder))
+	for newPos, oldPos := range newOrder {
+		children[newPos] = parent.children[oldPos]
+	}
+	parent.children = children
+	s.Unlock()
+	s.emitReordered(parent, newOrder)
+}
+
+func (s *CTreeStore) emitReordered(parent *treeStoreNode, newOrder []int) {
+	s.RLock()
+	var path TreePath
+	var iter *TreeIter
+	if parent != s.root {
+		path = s.nodePath(parent)
+		iter = s.makeIter(parent)
+	} else {
+		path = TreePath{}
+	}
+	s.RUnlock()
+	s.RowsReordered(path, iter, newOrder)
+}
+
+func (s *CTreeStore) siblingPosition(sibling *TreeIter) (parent *TreeIter, position int) {
+	s.RLock()
+	defer s.RUnlock()
+	if node := s.validNode(sibling); node != nil {
+		return s.makeParentIter(node.parent), node.index()
+	}
+	return nil, -1
+}
+
+func (s *CTreeStore) parseColumnValue(column int, value string) (interface{}, error) {
+	switch s.GetColumnType(column) {
+	case cdk.StringProperty:
+		return value, nil
+	case cdk.IntProperty:
+		return strconv.Atoi(value)
+	case cdk.FloatProperty:
+		return strconv.ParseFloat(value, 64)
+	case cdk.BoolProperty:
+		return cstrings.IsTrue(value), nil
+	}
+	return value, nil
+}
+
+func (s *CTreeStore) makeIter(node *treeStoreNode) *TreeIter {
+	return &TreeIter{Stamp: s.stamp, UserData: node}
+}
+
+func (s *CTreeStore) makeParentIter(node *treeStoreNode) *TreeIter {
+	if node == nil || node == s.root {
+		return nil
+	}
+	return s.makeIter(node)
+}
+
+func (s *CTreeStore) validNode(iter *TreeIter) *treeStoreNode {
+	if iter == nil || iter.Stamp != s.stamp {
+		return nil
+	}
+	if node, ok := iter.UserData.(*treeStoreNode); ok && node != nil {
+		for p := node; p != nil; p = p.parent {
+			if p == s.root {
+				return node
+			}
+		}
+	}
+	return nil
+}
+
+func (s *CTreeStore) parentNode(iter *TreeIter) *treeStoreNode {
+	if iter == nil {
+		return s.root
+	}
+	return s.validNode(iter)
+}
+
+func (s *CTreeStore) nodeAtPath(path TreePath) *treeStoreNode {
+	if len(path) == 0 {
+		return nil
+	}
+	node := s.root
+	for _, idx := range path {
+		if idx < 0 || idx >= len(node.children) {
+			return nil
+		}
+		node = node.children[idx]
+	}
+	return node
+}
+
+func (s *CTreeStore) nodePath(node *treeStoreNode) (path TreePath) {
+	for n := node; n != nil && n != s.root; n = n.parent {
+		path = append(TreePath{n.index()}, path...)
+	}
+	return
+}
+
+func treeStoreIdentityOrder(length int) (order []int) {
+	order = make([]int, length)
+	for idx := range order {
+		order[idx] = idx
+	}
+	return
+}
+
+func treeStoreCoerceValue(columnType cdk.PropertyType, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	switch columnType {
+	case cdk.StringProperty:
+		if v, ok := value.(string); ok {
+			return v, nil
+		}
+	case cdk.IntProperty:
+		switch v := value.(type) {
+		case int:
+			return v, nil
+		case int8:
+			return int(v), nil
+		case int16:
+			return int(v), nil
+		case int32:
+			return int(v), nil
+		case int64:
+			return int(v), nil
+		case uint:
+			return int(v), nil
+		case uint8:
+			return int(v), nil
+		case uint16:
+			return int(v), nil
+		case uint32:
+			return int(v), nil
+		case uint64:
+			return int(v), nil
+		}
+	case cdk.FloatProperty:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case float32:
+			return float64(v), nil
+		case int:
+			return float64(v), nil
+		}
+	case cdk.BoolProperty:
+		if v, ok := value.(bool); ok {
+			return v, nil
+		}
+	default:
+		return value, nil
+	}
+	return nil, fmt.Errorf("value %v (%T) is not of type %v", value, value, columnType)
+}
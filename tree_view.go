@@ -0,0 +1,2476 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/paint"
+	"github.com/go-curses/cdk/lib/ptypes"
+	cstrings "github.com/go-curses/cdk/lib/strings"
+	"github.com/go-curses/cdk/memphis"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeTreeView cdk.CTypeTag = "ctk-tree-view"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeTreeView, func() interface{} { return MakeTreeView() })
+}
+
+// TreeViewMappingFunc is the function signature used by MapExpandedRows.
+type TreeViewMappingFunc = func(treeView TreeView, path TreePath)
+
+// TreeView Hierarchy:
+//	Object
+//	  +- Widget
+//	    +- Container
+//	      +- TreeView
+//
+// The TreeView Widget displays the rows of a TreeModel, such as a ListStore or
+// a TreeStore, in one or more TreeViewColumn instances. Rows with children can
+// be expanded and collapsed, the cursor is moved with the keyboard or mouse,
+// column headers can be clicked to sort a TreeSortable model and the selected
+// rows are tracked by the TreeSelection returned from GetSelection.
+//
+// When placed within a ScrolledViewport, the TreeView requests the size of all
+// of its rows and follows the ScrolledViewport Adjustments, keeping the column
+// headers visible at the top of the viewport. Otherwise, the TreeView scrolls
+// its rows within its own allocation using its own vertical Adjustment.
+type TreeView interface {
+	Container
+	Buildable
+	Sensitive
+
+	Init() (already bool)
+	Build(builder Builder, element *CBuilderElement) error
+	GetModel() (model TreeModel)
+	SetModel(model TreeModel)
+	GetSelection() (selection TreeSelection)
+	GetHAdjustment() (adjustment Adjustment)
+	SetHAdjustment(adjustment Adjustment)
+	GetVAdjustment() (adjustment Adjustment)
+	SetVAdjustment(adjustment Adjustment)
+	GetHeadersVisible() (visible bool)
+	SetHeadersVisible(headersVisible bool)
+	GetHeadersClickable() (clickable bool)
+	SetHeadersClickable(setting bool)
+	GetRulesHint() (value bool)
+	SetRulesHint(setting bool)
+	GetShowExpanders() (value bool)
+	SetShowExpanders(enabled bool)
+	GetLevelIndentation() (value int)
+	SetLevelIndentation(indentation int)
+	GetGridLines() (value enums.TreeViewGridLines)
+	SetGridLines(gridLines enums.TreeViewGridLines)
+	GetEnableSearch() (value bool)
+	SetEnableSearch(enableSearch bool)
+	GetSearchColumn() (value int)
+	SetSearchColumn(column int)
+	AppendColumn(column TreeViewColumn) (count int)
+	RemoveColumn(column TreeViewColumn) (count int)
+	InsertColumn(column TreeViewColumn, position int) (count int)
+	InsertColumnWithAttributes(position int, title string, attributes ...interface{}) (count int)
+	GetColumn(n int) (column TreeViewColumn)
+	GetColumns() (columns []TreeViewColumn)
+	MoveColumnAfter(column TreeViewColumn, baseColumn TreeViewColumn)
+	SetExpanderColumn(column TreeViewColumn)
+	GetExpanderColumn() (column TreeViewColumn)
+	ColumnsAutosize()
+	SetCursor(path TreePath, focusColumn TreeViewColumn)
+	GetCursor() (path TreePath, focusColumn TreeViewColumn)
+	RowActivated(path TreePath, column TreeViewColumn)
+	ExpandAll()
+	CollapseAll()
+	ExpandToPath(path TreePath)
+	ExpandRow(path TreePath, openAll bool) (ok bool)
+	CollapseRow(path TreePath) (ok bool)
+	RowExpanded(path TreePath) (expanded bool)
+	MapExpandedRows(fn TreeViewMappingFunc)
+	GetPathAtPos(x, y int) (path TreePath, column TreeViewColumn, ok bool)
+	GetVisibleRange() (startPath, endPath TreePath, ok bool)
+	GetVisibleRows() (paths []TreePath)
+	ScrollToCell(path TreePath, column TreeViewColumn)
+	GetSizeRequest() (width, height int)
+	GetWidgetAt(p *ptypes.Point2I) Widget
+	CancelEvent()
+
+	queueResize()
+}
+
+var _ TreeView = (*CTreeView)(nil)
+
+// treeViewColumnInternal is implemented by CTreeViewColumn and any derivative
+// types embedding it, allowing the TreeView to track the columns it contains.
+type treeViewColumnInternal interface {
+	setTreeView(treeView TreeView)
+	setWidth(width int)
+}
+
+// treeViewRow is a single visible row of the TreeView, in display order
+type treeViewRow struct {
+	path        TreePath
+	iter        *TreeIter
+	depth       int
+	hasChildren bool
+	expanded    bool
+}
+
+// treeViewColumnLayout is the position and width of a visible column
+type treeViewColumnLayout struct {
+	column TreeViewColumn
+	x      int
+	width  int
+	border int
+}
+
+// The CTreeView structure implements the TreeView interface and is exported
+// to facilitate type embedding with custom implementations. No member variables
+// are exported as the interface methods are the only intended means of
+// interacting with TreeView objects.
+type CTreeView struct {
+	CContainer
+
+	model       TreeModel
+	modelHandle string
+	columns     []TreeViewColumn
+	selection   *CTreeSelection
+	expanded    map[string]TreePath
+	rows        []*treeViewRow
+	rowsValid   bool
+	naturals    map[TreeViewColumn]int
+	grown       map[TreeViewColumn]int
+	layout      []*treeViewColumnLayout
+	contentW    int
+	cursor      TreePath
+	focusColumn TreeViewColumn
+	anchor      TreePath
+	search      string
+	searchTime  time.Time
+	lastClick   TreePath
+	clickTime   time.Time
+	pressColumn int
+	sizeColumn  int
+	dragStart   int
+	dragWidth   int
+	dragMoved   bool
+}
+
+// MakeTreeView is used by the Buildable system to construct a new TreeView.
+func MakeTreeView() TreeView {
+	return NewTreeView()
+}
+
+// NewTreeView is the constructor for new TreeView instances, without a model.
+func NewTreeView() TreeView {
+	t := new(CTreeView)
+	t.Init()
+	return t
+}
+
+// NewTreeViewWithModel is a convenience constructor for new TreeView instances
+// with the model initialized to the one given.
+func NewTreeViewWithModel(model TreeModel) TreeView {
+	t := NewTreeView()
+	t.SetModel(model)
+	return t
+}
+
+// Init initializes a TreeView object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the TreeView instance. Init is used in the
+// NewTreeView constructor and only necessary when implementing a derivative
+// TreeView type.
+func (t *CTreeView) Init() (already bool) {
+	if t.InitTypeItem(TypeTreeView, t) {
+		return true
+	}
+	t.CContainer.Init()
+	t.flags = enums.NULL_WIDGET_FLAG
+	t.SetFlags(enums.SENSITIVE | enums.PARENT_SENSITIVE | enums.CAN_FOCUS | enums.APP_PAINTABLE)
+	_ = t.InstallBuildableProperty(PropertyModel, cdk.StructProperty, true, nil)
+	_ = t.InstallBuildableProperty(PropertyViewportHAdjustment, cdk.StructProperty, true, nil)
+	_ = t.InstallBuildableProperty(PropertyViewportVAdjustment, cdk.StructProperty, true, nil)
+	_ = t.InstallBuildableProperty(PropertyHeadersVisible, cdk.BoolProperty, true, true)
+	_ = t.InstallBuildableProperty(PropertyHeadersClickable, cdk.BoolProperty, true, false)
+	_ = t.InstallBuildableProperty(PropertyExpanderColumn, cdk.StructProperty, true, nil)
+	_ = t.InstallBuildableProperty(PropertyRulesHint, cdk.BoolProperty, true, false)
+	_ = t.InstallBuildableProperty(PropertyEnableSearch, cdk.BoolProperty, true, true)
+	_ = t.InstallBuildableProperty(PropertySearchColumn, cdk.IntProperty, true, -1)
+	_ = t.InstallBuildableProperty(PropertyShowExpanders, cdk.BoolProperty, true, true)
+	_ = t.InstallBuildableProperty(PropertyLevelIndentation, cdk.IntProperty, true, 2)
+	_ = t.InstallBuildableProperty(PropertyEnableGridLines, cdk.StructProperty, true, enums.TREE_VIEW_GRID_LINES_NONE)
+	t.columns = make([]TreeViewColumn, 0)
+	t.expanded = make(map[string]TreePath)
+	t.naturals = make(map[TreeViewColumn]int)
+	t.grown = make(map[TreeViewColumn]int)
+	t.modelHandle = fmt.Sprintf("%v-%v", TreeViewModelHandle, t.ObjectID())
+	t.pressColumn = -1
+	t.sizeColumn = -1
+	t.selection, _ = NewTreeSelection(t).(*CTreeSelection)
+	t.selection.Connect(SignalChanged, t.modelHandle, t.selectionChanged)
+	t.SetHAdjustment(NewAdjustment(0, 0, 0, 1, 0, 0))
+	t.SetVAdjustment(NewAdjustment(0, 0, 0, 1, 0, 0))
+	t.Connect(SignalCdkEvent, TreeViewEventHandle, t.event)
+	t.Connect(SignalLostFocus, TreeViewLostFocusHandle, t.lostFocus)
+	t.Connect(SignalGainedFocus, TreeViewGainedFocusHandle, t.gainedFocus)
+	t.Connect(SignalResize, TreeViewResizeHandle, t.resize)
+	t.Connect(SignalDraw, TreeViewDrawHandle, t.draw)
+	return false
+}
+
+// Build provides customizations to the Buildable system for TreeView Widgets.
+// The "model" property is resolved by name to a previously built TreeModel,
+// GtkTreeViewColumn children are appended as columns and the "mode" property of
+// a GtkTreeSelection child is applied to the TreeSelection.
+func (t *CTreeView) Build(builder Builder, element *CBuilderElement) error {
+	t.Freeze()
+	defer t.Thaw()
+	if name, ok := element.Attributes["id"]; ok {
+		t.SetName(name)
+	}
+	for k, v := range element.Properties {
+		switch cdk.Property(k) {
+		case PropertyModel:
+			if model, ok := builder.GetWidget(v).(TreeModel); ok {
+				t.SetModel(model)
+			} else {
+				t.LogError("model not found or not a TreeModel: %v", v)
+			}
+		case PropertyEnableGridLines:
+			t.SetGridLines(treeViewGridLinesFromString(v))
+		case PropertyExpanderColumn:
+		default:
+			element.ApplyProperty(k, v)
+		}
+	}
+	for _, child := range element.Children {
+		switch child.Attributes["class"] {
+		case "GtkTreeSelection":
+			if v, ok := child.Properties["mode"]; ok {
+				t.selection.SetMode(treeViewSelectionModeFromString(v))
+			}
+		default:
+			if newChild := builder.Build(child); newChild != nil {
+				child.Instance = newChild
+				if column, ok := newChild.(TreeViewColumn); ok {
+					t.AppendColumn(column)
+				} else {
+					t.LogError("new child object is not a TreeViewColumn type: %v (%T)", newChild, newChild)
+				}
+			}
+		}
+	}
+	if v, ok := element.Properties[PropertyExpanderColumn.String()]; ok {
+		if column, ok := builder.GetWidget(v).(TreeViewColumn); ok {
+			t.SetExpanderColumn(column)
+		}
+	}
+	element.ApplySignals()
+	return nil
+}
+
+// GetModel returns the model the TreeView is based on. Returns nil if the model
+// is unset.
+func (t *CTreeView) GetModel() (model TreeModel) {
+	t.RLock()
+	defer t.RUnlock()
+	return t.model
+}
+
+// SetModel sets the model for a TreeView. If the TreeView already has a model
+// set, it will remove it before setting the new model. If model is nil, then
+// it will unset the old model. The expanded rows, cursor and selection are
+// reset.
+//
+// Parameters:
+// 	model	the model, or nil
+func (t *CTreeView) SetModel(model TreeModel) {
+	if previous := t.GetModel(); previous != nil {
+		_ = previous.Disconnect(SignalRowChanged, t.modelHandle)
+		_ = previous.Disconnect(SignalRowInserted, t.modelHandle)
+		_ = previous.Disconnect(SignalRowHasChildToggled, t.modelHandle)
+		_ = previous.Disconnect(SignalRowDeleted, t.modelHandle)
+		_ = previous.Disconnect(SignalRowsReordered, t.modelHandle)
+	}
+	if err := t.SetStructProperty(PropertyModel, model); err != nil {
+		t.LogErr(err)
+		return
+	}
+	t.Lock()
+	t.model = model
+	t.expanded = make(map[string]TreePath)
+	t.cursor, t.anchor, t.lastClick = nil, nil, nil
+	t.Unlock()
+	t.selection.UnselectAll()
+	if model != nil {
+		model.Connect(SignalRowChanged, t.modelHandle, t.rowChanged)
+		model.Connect(SignalRowInserted, t.modelHandle, t.rowInserted)
+		model.Connect(SignalRowHasChildToggled, t.modelHandle, t.rowHasChildToggled)
+		model.Connect(SignalRowDeleted, t.modelHandle, t.rowDeleted)
+		model.Connect(SignalRowsReordered, t.modelHandle, t.rowsReordered)
+		if _, ok := model.GetIterFirst(); ok && t.selection.GetMode() == enums.SELECTION_BROWSE {
+			t.SetCursor(NewTreePath(), nil)
+		}
+	}
+	if v := t.GetVAdjustment(); v != nil {
+		v.SetValue(0)
+	}
+	t.queueResize()
+}
+
+// GetSelection returns the TreeSelection associated with the TreeView.
+func (t *CTreeView) GetSelection() (selection TreeSelection) {
+	return t.selection
+}
+
+// GetHAdjustment returns the Adjustment currently being used for the
+// horizontal aspect. When the TreeView is the child of a ScrolledViewport, the
+// ScrolledViewport's horizontal Adjustment is returned.
+func (t *CTreeView) GetHAdjustment() (adjustment Adjustment) {
+	if sv := t.getScrolledViewport(); sv != nil {
+		return sv.GetHAdjustment()
+	}
+	if v, err := t.GetStructProperty(PropertyViewportHAdjustment); err != nil {
+		t.LogErr(err)
+	} else if adjustment, _ = v.(Adjustment); adjustment == nil && v != nil {
+		t.LogError("value stored in %v property is not of Adjustment type: %v (%T)", PropertyViewportHAdjustment, v, v)
+	}
+	return
+}
+
+// SetHAdjustment sets the Adjustment for the current horizontal aspect.
+//
+// Parameters:
+// 	adjustment	the Adjustment to set, or nil
+func (t *CTreeView) SetHAdjustment(adjustment Adjustment) {
+	if err := t.SetStructProperty(PropertyViewportHAdjustment, adjustment); err != nil {
+		t.LogErr(err)
+	}
+}
+
+// GetVAdjustment returns the Adjustment currently being used for the vertical
+// aspect. When the TreeView is the child of a ScrolledViewport, the
+// ScrolledViewport's vertical Adjustment is returned.
+func (t *CTreeView) GetVAdjustment() (adjustment Adjustment) {
+	if sv := t.getScrolledViewport(); sv != nil {
+		return sv.GetVAdjustment()
+	}
+	if v, err := t.GetStructProperty(PropertyViewportVAdjustment); err != nil {
+		t.LogErr(err)
+	} else if adjustment, _ = v.(Adjustment); adjustment == nil && v != nil {
+		t.LogError("value stored in %v property is not of Adjustment type: %v (%T)", PropertyViewportVAdjustment, v, v)
+	}
+	return
+}
+
+// SetVAdjustment sets the Adjustment for the current vertical aspect.
+//
+// Parameters:
+// 	adjustment	the Adjustment to set, or nil
+func (t *CTreeView) SetVAdjustment(adjustment Adjustment) {
+	if err := t.SetStructProperty(PropertyViewportVAdjustment, adjustment); err != nil {
+		t.LogErr(err)
+	}
+}
+
+// GetHeadersVisible returns TRUE if the headers on the TreeView are visible.
+func (t *CTreeView) GetHeadersVisible() (visible bool) {
+	var err error
+	if visible, err = t.GetBoolProperty(PropertyHeadersVisible); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetHeadersVisible sets the visibility state of the headers.
+//
+// Parameters:
+// 	headersVisible	TRUE if the headers are visible
+func (t *CTreeView) SetHeadersVisible(headersVisible bool) {
+	if err := t.SetBoolProperty(PropertyHeadersVisible, headersVisible); err != nil {
+		t.LogErr(err)
+	} else {
+		t.queueResize()
+	}
+}
+
+// GetHeadersClickable returns whether all header columns are clickable.
+func (t *CTreeView) GetHeadersClickable() (clickable bool) {
+	var err error
+	if clickable, err = t.GetBoolProperty(PropertyHeadersClickable); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetHeadersClickable allows the column title buttons to be clicked.
+//
+// Parameters:
+// 	setting	TRUE if the columns are clickable
+func (t *CTreeView) SetHeadersClickable(setting bool) {
+	if err := t.SetBoolProperty(PropertyHeadersClickable, setting); err != nil {
+		t.LogErr(err)
+		return
+	}
+	for _, column := range t.GetColumns() {
+		column.SetClickable(setting)
+	}
+}
+
+// GetRulesHint returns whether rules hint is set.
+// See: SetRulesHint()
+func (t *CTreeView) GetRulesHint() (value bool) {
+	var err error
+	if value, err = t.GetBoolProperty(PropertyRulesHint); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetRulesHint sets a hint for the theme to draw even and odd rows with
+// different styles, making it easier to follow the rows of wide tables.
+//
+// Parameters:
+// 	setting	TRUE if the tree requires reading across rows
+func (t *CTreeView) SetRulesHint(setting bool) {
+	if err := t.SetBoolProperty(PropertyRulesHint, setting); err != nil {
+		t.LogErr(err)
+	} else {
+		t.Invalidate()
+	}
+}
+
+// GetShowExpanders returns whether or not expanders are drawn in the TreeView.
+func (t *CTreeView) GetShowExpanders() (value bool) {
+	var err error
+	if value, err = t.GetBoolProperty(PropertyShowExpanders); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetShowExpanders sets whether to draw and enable expanders and indent child
+// rows in the TreeView. When disabled there will be no expanders visible and
+// there will be no way to expand and collapse rows by mouse; the keyboard
+// bindings continue to work.
+//
+// Parameters:
+// 	enabled	TRUE to enable expander drawing, FALSE otherwise
+func (t *CTreeView) SetShowExpanders(enabled bool) {
+	if err := t.SetBoolProperty(PropertyShowExpanders, enabled); err != nil {
+		t.LogErr(err)
+	} else {
+		t.queueResize()
+	}
+}
+
+// GetLevelIndentation returns the amount, in cells, of extra indentation for
+// child levels in the TreeView.
+func (t *CTreeView) GetLevelIndentation() (value int) {
+	var err error
+	if value, err = t.GetIntProperty(PropertyLevelIndentation); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetLevelIndentation sets the amount of extra indentation for child levels to
+// use in the TreeView in addition to the default indentation.
+//
+// Parameters:
+// 	indentation	the amount, in cells, of extra indentation
+func (t *CTreeView) SetLevelIndentation(indentation int) {
+	if err := t.SetIntProperty(PropertyLevelIndentation, indentation); err != nil {
+		t.LogErr(err)
+	} else {
+		t.queueResize()
+	}
+}
+
+// GetGridLines returns which grid lines are enabled in the TreeView.
+func (t *CTreeView) GetGridLines() (value enums.TreeViewGridLines) {
+	var ok bool
+	if v, err := t.GetStructProperty(PropertyEnableGridLines); err != nil {
+		t.LogErr(err)
+	} else if value, ok = v.(enums.TreeViewGridLines); !ok {
+		t.LogError("value stored in %v property is not of TreeViewGridLines type: %v (%T)", PropertyEnableGridLines, v, v)
+	}
+	return
+}
+
+// SetGridLines sets which grid lines to draw in the TreeView. Horizontal grid
+// lines are drawn between each row and vertical grid lines are drawn between
+// each column.
+//
+// Parameters:
+// 	gridLines	a TreeViewGridLines value indicating which grid lines to enable
+func (t *CTreeView) SetGridLines(gridLines enums.TreeViewGridLines) {
+	if err := t.SetStructProperty(PropertyEnableGridLines, gridLines); err != nil {
+		t.LogErr(err)
+	} else {
+		t.queueResize()
+	}
+}
+
+// GetEnableSearch returns whether or not the TreeView allows the user to
+// interactively search through the rows by typing.
+func (t *CTreeView) GetEnableSearch() (value bool) {
+	var err error
+	if value, err = t.GetBoolProperty(PropertyEnableSearch); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetEnableSearch sets whether the user can search interactively through the
+// rows by typing, moving the cursor to the first visible row with a value in
+// the search column that starts with the typed characters.
+//
+// Parameters:
+// 	enableSearch	TRUE if the user can search interactively
+func (t *CTreeView) SetEnableSearch(enableSearch bool) {
+	if err := t.SetBoolProperty(PropertyEnableSearch, enableSearch); err != nil {
+		t.LogErr(err)
+	}
+}
+
+// GetSearchColumn returns the column searched on by the interactive search
+// code, -1 indicating the first string column of the model.
+func (t *CTreeView) GetSearchColumn() (value int) {
+	var err error
+	if value, err = t.GetIntProperty(PropertySearchColumn); err != nil {
+		t.LogErr(err)
+	}
+	return
+}
+
+// SetSearchColumn sets the model column which the interactive search code
+// will search through.
+//
+// Parameters:
+// 	column	the column of the model to search in, or -1 to use the first
+// 	        string column
+func (t *CTreeView) SetSearchColumn(column int) {
+	if err := t.SetIntProperty(PropertySearchColumn, column); err != nil {
+		t.LogErr(err)
+	}
+}
+
+// AppendColumn appends column to the list of columns and returns the number
+// of columns in the TreeView after appending.
+//
+// Emits: SignalColumnsChanged, Argv=[TreeView instance]
+func (t *CTreeView) AppendColumn(column TreeViewColumn) (count int) {
+	return t.InsertColumn(column, -1)
+}
+
+// RemoveColumn removes column from the TreeView and returns the number of
+// columns in the TreeView after removing.
+//
+// Emits: SignalColumnsChanged, Argv=[TreeView instance]
+func (t *CTreeView) RemoveColumn(column TreeViewColumn) (count int) {
+	found := false
+	t.Lock()
+	for idx, c := range t.columns {
+		if c.ObjectID() == column.ObjectID() {
+			t.columns = append(t.columns[:idx], t.columns[idx+1:]...)
+			delete(t.grown, c)
+			delete(t.naturals, c)
+			found = true
+			break
+		}
+	}
+	if found && t.focusColumn != nil && t.focusColumn.ObjectID() == column.ObjectID() {
+		t.focusColumn = nil
+	}
+	count = len(t.columns)
+	t.Unlock()
+	if found {
+		if ci, ok := column.(treeViewColumnInternal); ok {
+			ci.setTreeView(nil)
+		}
+		if expander := t.getExpanderColumnProperty(); expander != nil && expander.ObjectID() == column.ObjectID() {
+			t.SetExpanderColumn(nil)
+		}
+		t.Emit(SignalColumnsChanged, t)
+		t.queueResize()
+	}
+	return
+}
+
+// InsertColumn inserts the column into the TreeView at position. If position is
+// -1, then the column is inserted at the end. Returns the number of columns in
+// the TreeView after insertion.
+//
+// Emits: SignalColumnsChanged, Argv=[TreeView instance]
+func (t *CTreeView) InsertColumn(column TreeViewColumn, position int) (count int) {
+	if column == nil {
+		return len(t.GetColumns())
+	}
+	if tv := column.GetTreeView(); tv != nil {
+		t.LogError("column already belongs to a TreeView: %v", tv.ObjectName())
+		return len(t.GetColumns())
+	}
+	if ci, ok := column.(treeViewColumnInternal); ok {
+		ci.setTreeView(t)
+	}
+	if t.GetHeadersClickable() {
+		column.SetClickable(true)
+	}
+	t.Lock()
+	if position < 0 || position >= len(t.columns) {
+		t.columns = append(t.columns, column)
+	} else {
+		t.columns = append(t.columns[:position], append([]TreeViewColumn{column}, t.columns[position:]...)...)
+	}
+	count = len(t.columns)
+	t.Unlock()
+	t.Emit(SignalColumnsChanged, t)
+	t.queueResize()
+	return
+}
+
+// InsertColumnWithAttributes creates a new TreeViewColumn and inserts it into
+// the TreeView at position. If position is -1, then the newly created column
+// is inserted at the end. The column is initialized with the attributes given,
+// which are pairs of attribute names and model column numbers.
+// See: NewTreeViewColumnWithAttributes()
+func (t *CTreeView) InsertColumnWithAttributes(position int, title string, attributes ...interface{}) (count int) {
+	return t.InsertColumn(NewTreeViewColumnWithAttributes(title, attributes...), position)
+}
+
+// GetColumn returns the TreeViewColumn at the given position in the TreeView,
+// or nil if the position is outside the range of columns.
+func (t *CTreeView) GetColumn(n int) (column TreeViewColumn) {
+	t.RLock()
+	defer t.RUnlock()
+	if n >= 0 && n < len(t.columns) {
+		column = t.columns[n]
+	}
+	return
+}
+
+// GetColumns returns a list of all the TreeViewColumn instances currently in
+// the TreeView.
+func (t *CTreeView) GetColumns() (columns []TreeViewColumn) {
+	t.RLock()
+	defer t.RUnlock()
+	columns = append(columns, t.columns...)
+	return
+}
+
+// MoveColumnAfter moves column to be after baseColumn. If baseColumn is nil,
+// then column is placed in the first position.
+//
+// Emits: SignalColumnsChanged, Argv=[TreeView instance]
+func (t *CTreeView) MoveColumnAfter(column TreeViewColumn, baseColumn TreeViewColumn) {
+	t.Lock()
+	from := -1
+	for idx, c := range t.columns {
+		if c.ObjectID() == column.ObjectID() {
+			from = idx
+			break
+		}
+	}
+	if from < 0 {
+		t.Unlock()
+		return
+	}
+	columns := append([]TreeViewColumn{}, t.columns[:from]...)
+	columns = append(columns, t.columns[from+1:]...)
+	to := 0
+	if baseColumn != nil {
+		for idx, c := range columns {
+			if c.ObjectID() == baseColumn.ObjectID() {
+				to = idx + 1
+				break
+			}
+		}
+	}
+	t.columns = append(columns[:to], append([]TreeViewColumn{column}, columns[to:]...)...)
+	t.Unlock()
+	t.Emit(SignalColumnsChanged, t)
+	t.queueResize()
+}
+
+// SetExpanderColumn sets the column to draw the expander arrow at. It must be
+// in the TreeView. If column is nil, then the expander arrow is always at the
+// first visible column.
+func (t *CTreeView) SetExpanderColumn(column TreeViewColumn) {
+	if err := t.SetStructProperty(PropertyExpanderColumn, column); err != nil {
+		t.LogErr(err)
+	} else {
+		t.queueResize()
+	}
+}
+
+// GetExpanderColumn returns the column that is the current expander column.
+// This column has the expander arrow drawn next to it.
+func (t *CTreeView) GetExpanderColumn() (column TreeViewColumn) {
+	if column = t.getExpanderColumnProperty(); column != nil {
+		return
+	}
+	for _, c := range t.GetColumns() {
+		if c.GetVisible() {
+			return c
+		}
+	}
+	return
+}
+
+// ColumnsAutosize resizes all columns to their optimal width. Only works after
+// the TreeView has been realized.
+func (t *CTreeView) ColumnsAutosize() {
+	t.Lock()
+	t.grown = make(map[TreeViewColumn]int)
+	t.Unlock()
+	for _, column := range t.GetColumns() {
+		if column.GetSizing() == enums.TREE_VIEW_COLUMN_FIXED {
+			column.SetSizing(enums.TREE_VIEW_COLUMN_GROW_ONLY)
+		}
+	}
+	t.queueResize()
+}
+
+// SetCursor sets the current keyboard focus to be at path, and selects it.
+// This is useful when you want to focus the user's attention on a particular
+// row. If focusColumn is not nil, then focus is given to the column specified
+// by it. The parents of path are expanded as necessary and the row is
+// scrolled into view.
+//
+// Emits: SignalCursorChanged, Argv=[TreeView instance]
+func (t *CTreeView) SetCursor(path TreePath, focusColumn TreeViewColumn) {
+	if model := t.GetModel(); model == nil || path == nil {
+		return
+	} else if _, ok := model.GetIter(path); !ok {
+		return
+	}
+	if parent, ok := path.Up(); ok && parent.GetDepth() > 0 {
+		t.ExpandToPath(parent)
+	}
+	t.setCursorRow(path, focusColumn)
+	t.selectCursor(false, false)
+}
+
+// GetCursor returns the current path and focus column. If the cursor isn't
+// currently set, then path will be nil. If no column currently has focus, then
+// focusColumn will be nil.
+func (t *CTreeView) GetCursor() (path TreePath, focusColumn TreeViewColumn) {
+	t.RLock()
+	defer t.RUnlock()
+	if t.cursor != nil {
+		path = t.cursor.Copy()
+	}
+	return path, t.focusColumn
+}
+
+// RowActivated activates the cell determined by path and column.
+//
+// Emits: SignalRowActivated, Argv=[TreeView instance, TreePath, TreeViewColumn]
+func (t *CTreeView) RowActivated(path TreePath, column TreeViewColumn) {
+	t.Emit(SignalRowActivated, t, path, column)
+}
+
+// ExpandAll recursively expands all nodes in the TreeView.
+func (t *CTreeView) ExpandAll() {
+	if model := t.GetModel(); model != nil {
+		iter, ok := model.GetIterFirst()
+		for ok {
+			t.ExpandRow(model.GetPath(iter), true)
+			ok = model.IterNext(iter)
+		}
+	}
+}
+
+// CollapseAll recursively collapses all visible, expanded nodes in the
+// TreeView.
+func (t *CTreeView) CollapseAll() {
+	var paths []TreePath
+	t.MapExpandedRows(func(_ TreeView, path TreePath) {
+		if path.GetDepth() == 1 {
+			paths = append(paths, path)
+		}
+	})
+	for _, path := range paths {
+		t.CollapseRow(path)
+	}
+}
+
+// ExpandToPath expands the row at path. This will also expand all parent rows
+// of path as necessary.
+func (t *CTreeView) ExpandToPath(path TreePath) {
+	for depth := 1; depth <= path.GetDepth(); depth++ {
+		t.ExpandRow(NewTreePathFromIndices(path.GetIndices()[:depth]...), false)
+	}
+}
+
+// ExpandRow opens the row so its children are visible, returning TRUE if the
+// row existed and had children. If openAll is TRUE, all descendants of the row
+// are expanded as well.
+//
+// Emits: SignalTestExpandRow, Argv=[TreeView instance, *TreeIter, TreePath]
+// Emits: SignalRowExpanded, Argv=[TreeView instance, *TreeIter, TreePath]
+func (t *CTreeView) ExpandRow(path TreePath, openAll bool) (ok bool) {
+	model := t.GetModel()
+	if model == nil || path == nil {
+		return false
+	}
+	var iter *TreeIter
+	if iter, ok = model.GetIter(path); !ok || !model.IterHasChild(iter) {
+		return false
+	}
+	if !t.RowExpanded(path) {
+		if f := t.Emit(SignalTestExpandRow, t, iter, path); f == cenums.EVENT_STOP {
+			return false
+		}
+		t.Lock()
+		t.expanded[path.String()] = path.Copy()
+		t.Unlock()
+		t.queueResize()
+		t.Emit(SignalRowExpanded, t, iter, path)
+	}
+	if openAll {
+		child, cok := model.IterChildren(iter)
+		for cok {
+			t.ExpandRow(model.GetPath(child), true)
+			cok = model.IterNext(child)
+		}
+	}
+	return true
+}
+
+// CollapseRow collapses a row (hides its child rows, if they exist), returning
+// TRUE if the row was collapsed. The expanded state of the descendants of the
+// row is forgotten and any selected descendants are unselected. If the cursor
+// was on a descendant of the row, the cursor moves to the row.
+//
+// Emits: SignalTestCollapseRow, Argv=[TreeView instance, *TreeIter, TreePath]
+// Emits: SignalRowCollapsed, Argv=[TreeView instance, *TreeIter, TreePath]
+func (t *CTreeView) CollapseRow(path TreePath) (ok bool) {
+	model := t.GetModel()
+	if model == nil || path == nil || !t.RowExpanded(path) {
+		return false
+	}
+	var iter *TreeIter
+	if iter, ok = model.GetIter(path); !ok {
+		return false
+	}
+	if f := t.Emit(SignalTestCollapseRow, t, iter, path); f == cenums.EVENT_STOP {
+		return false
+	}
+	cursorMoved := false
+	t.Lock()
+	for key, expanded := range t.expanded {
+		if expanded.Compare(path) == 0 || path.IsAncestor(expanded) {
+			delete(t.expanded, key)
+		}
+	}
+	if t.cursor != nil && path.IsAncestor(t.cursor) {
+		t.cursor = path.Copy()
+		cursorMoved = true
+	}
+	if t.anchor != nil && path.IsAncestor(t.anchor) {
+		t.anchor = path.Copy()
+	}
+	t.Unlock()
+	_, selected := t.selection.GetSelectedRows()
+	for _, sp := range selected {
+		if path.IsAncestor(sp) {
+			t.selection.UnselectPath(sp)
+		}
+	}
+	t.queueResize()
+	t.Emit(SignalRowCollapsed, t, iter, path)
+	if cursorMoved {
+		if t.selection.GetMode() == enums.SELECTION_BROWSE {
+			t.selection.SelectPath(path)
+		}
+		t.Emit(SignalCursorChanged, t)
+	}
+	return true
+}
+
+// RowExpanded returns TRUE if the node pointed to by path is expanded in the
+// TreeView.
+func (t *CTreeView) RowExpanded(path TreePath) (expanded bool) {
+	if path == nil {
+		return false
+	}
+	t.RLock()
+	defer t.RUnlock()
+	_, expanded = t.expanded[path.String()]
+	return
+}
+
+// MapExpandedRows calls fn on all expanded rows, in the order they appear
+// within the model.
+func (t *CTreeView) MapExpandedRows(fn TreeViewMappingFunc) {
+	t.RLock()
+	var paths []TreePath
+	for _, path := range t.expanded {
+		paths = append(paths, path.Copy())
+	}
+	t.RUnlock()
+	sort.Slice(paths, func(i, j int) bool {
+		return paths[i].Compare(paths[j]) < 0
+	})
+	for _, path := range paths {
+		fn(t, path)
+	}
+}
+
+// GetPathAtPos finds the path and column at the point (x, y), relative to the
+// origin of the TreeView. If there is no row at the given position, ok is
+// FALSE. The column is nil when the point is not within a visible column.
+func (t *CTreeView) GetPathAtPos(x, y int) (path TreePath, column TreeViewColumn, ok bool) {
+	rows := t.getRows()
+	if idx := t.rowIndexAt(y); idx >= 0 && idx < len(rows) {
+		path, ok = rows[idx].path.Copy(), true
+		if cl := t.columnLayoutAt(x); cl != nil {
+			column = cl.column
+		}
+	}
+	return
+}
+
+// GetVisibleRange returns the first and last visible paths. Note that there
+// may be invisible paths in between.
+func (t *CTreeView) GetVisibleRange() (startPath, endPath TreePath, ok bool) {
+	rows := t.getRows()
+	if len(rows) == 0 {
+		return
+	}
+	first, last := t.visibleRowRange(len(rows))
+	if first > last {
+		return
+	}
+	return rows[first].path.Copy(), rows[last].path.Copy(), true
+}
+
+// GetVisibleRows returns the paths of all the rows that are not hidden within
+// a collapsed parent, in display order. This is not limited to the rows
+// currently scrolled into view.
+func (t *CTreeView) GetVisibleRows() (paths []TreePath) {
+	for _, row := range t.getRows() {
+		paths = append(paths, row.path.Copy())
+	}
+	return
+}
+
+// ScrollToCell moves the alignments of the TreeView to the position specified
+// by path and column, scrolling as little as necessary for the row to be
+// visible. If column is nil, no horizontal scrolling occurs. The parents of
+// path are expanded as necessary.
+func (t *CTreeView) ScrollToCell(path TreePath, column TreeViewColumn) {
+	if path == nil {
+		return
+	}
+	if parent, ok := path.Up(); ok && parent.GetDepth() > 0 && !t.isRowVisible(path) {
+		t.ExpandToPath(parent)
+	}
+	for idx, row := range t.getRows() {
+		if row.path.Compare(path) == 0 {
+			t.scrollToRow(idx, column)
+			return
+		}
+	}
+}
+
+// GetSizeRequest returns the requested size of the TreeView. When the TreeView
+// is the child of a ScrolledViewport, the size of all the rows and columns is
+// requested, filling any remaining space within the viewport. Otherwise the
+// size requested by the Widget is returned.
+func (t *CTreeView) GetSizeRequest() (width, height int) {
+	sv := t.getScrolledViewport()
+	if sv == nil {
+		return t.CContainer.GetSizeRequest()
+	}
+	rows := t.getRows()
+	width = t.measureColumns()
+	height = t.headerHeight() + len(rows)*t.rowHeight()
+	alloc := sv.GetAllocation()
+	viewW, viewH := alloc.W, alloc.H
+	if height > viewH {
+		viewW -= 1 // vertical scrollbar
+	}
+	if width > viewW {
+		viewH -= 1 // horizontal scrollbar
+	}
+	if width < viewW {
+		width = viewW
+	}
+	if height < viewH {
+		height = viewH
+	}
+	return
+}
+
+// GetWidgetAt returns the TreeView instance if the given point is within the
+// TreeView's display space bounds, nil otherwise.
+func (t *CTreeView) GetWidgetAt(p *ptypes.Point2I) Widget {
+	if t.HasPoint(p) && t.IsVisible() {
+		return t
+	}
+	return nil
+}
+
+// CancelEvent cancels any pending header interaction with the mouse.
+func (t *CTreeView) CancelEvent() {
+	if f := t.Emit(SignalCancelEvent, t); f == cenums.EVENT_PASS {
+		t.Lock()
+		t.pressColumn, t.sizeColumn, t.dragMoved = -1, -1, false
+		t.Unlock()
+		t.ReleaseEventFocus()
+		t.Invalidate()
+	}
+}
+
+// queueResize is used when the rows or columns of the TreeView have changed,
+// rebuilding the visible rows and column widths as needed during the next
+// resize or draw.
+func (t *CTreeView) queueResize() {
+	t.Lock()
+	t.rowsValid = false
+	t.Unlock()
+	if t.GetWindow() == nil {
+		return
+	}
+	if sv := t.getScrolledViewport(); sv != nil {
+		sv.Resize()
+		return
+	}
+	t.Resize()
+}
+
+func (t *CTreeView) getExpanderColumnProperty() (column TreeViewColumn) {
+	if v, err := t.GetStructProperty(PropertyExpanderColumn); err != nil {
+		t.LogErr(err)
+	} else if v != nil {
+		column, _ = v.(TreeViewColumn)
+	}
+	return
+}
+
+func (t *CTreeView) getScrolledViewport() ScrolledViewport {
+	if parent := t.GetParent(); parent != nil {
+		if sv, ok := parent.Self().(ScrolledViewport); ok {
+			return sv
+		}
+	}
+	return nil
+}
+
+func (t *CTreeView) hasExpanders() bool {
+	if model := t.GetModel(); model == nil || model.GetFlags().Has(enums.TREE_MODEL_LIST_ONLY) {
+		return false
+	}
+	return t.GetShowExpanders()
+}
+
+func (t *CTreeView) hasGridLines(gridLines enums.TreeViewGridLines) bool {
+	v := t.GetGridLines()
+	return v == gridLines || v == enums.TREE_VIEW_GRID_LINES_BOTH
+}
+
+func (t *CTreeView) headerHeight() int {
+	if t.GetHeadersVisible() {
+		return 2 // titles and a horizontal rule
+	}
+	return 0
+}
+
+func (t *CTreeView) rowHeight() int {
+	if t.hasGridLines(enums.TREE_VIEW_GRID_LINES_HORIZONTAL) {
+		return 2
+	}
+	return 1
+}
+
+// getRows returns the visible rows, rebuilding them and the natural widths of
+// the columns if the model has changed
+func (t *CTreeView) getRows() (rows []*treeViewRow) {
+	t.RLock()
+	if t.rowsValid {
+		rows = t.rows
+		t.RUnlock()
+		return
+	}
+	t.RUnlock()
+	model := t.GetModel()
+	if model != nil {
+		t.RLock()
+		expanded := make(map[string]bool, len(t.expanded))
+		for key := range t.expanded {
+			expanded[key] = true
+		}
+		t.RUnlock()
+		var walk func(parent *TreeIter, depth int)
+		walk = func(parent *TreeIter, depth int) {
+			iter, ok := model.IterChildren(parent)
+			for ok {
+				it := *iter
+				row := &treeViewRow{
+					path:        model.GetPath(&it),
+					iter:        &it,
+					depth:       depth,
+					hasChildren: model.IterHasChild(&it),
+				}
+				row.expanded = row.hasChildren && expanded[row.path.String()]
+				rows = append(rows, row)
+				if row.expanded {
+					walk(&it, depth+1)
+				}
+				ok = model.IterNext(iter)
+			}
+		}
+		walk(nil, 0)
+	}
+	naturals := t.measureNaturals(model, rows)
+	t.Lock()
+	t.rows, t.naturals, t.rowsValid = rows, naturals, true
+	t.Unlock()
+	return
+}
+
+func (t *CTreeView) measureNaturals(model TreeModel, rows []*treeViewRow) (naturals map[TreeViewColumn]int) {
+	naturals = make(map[TreeViewColumn]int)
+	expander := t.GetExpanderColumn()
+	indent := t.GetLevelIndentation()
+	expanders := t.hasExpanders()
+	for _, column := range t.GetColumns() {
+		if !column.GetVisible() {
+			continue
+		}
+		width := runewidth.StringWidth(column.GetTitle())
+		if column.GetSortIndicator() {
+			width += 2
+		}
+		isExpander := expander != nil && expander.ObjectID() == column.ObjectID()
+		if model != nil {
+			for _, row := range rows {
+				text, markup, visible := column.GetCellText(model, row.iter)
+				w := 0
+				if visible {
+					w = treeViewTextWidth(text, markup)
+				}
+				if isExpander {
+					w += row.depth * indent
+					if expanders {
+						w += 2
+					}
+				}
+				if w > width {
+					width = w
+				}
+			}
+		}
+		naturals[column] = width
+	}
+	return
+}
+
+// columnWidths returns the width of each visible column, before any extra
+// space is distributed
+func (t *CTreeView) columnWidths() (columns []TreeViewColumn, widths []int) {
+	t.getRows()
+	t.Lock()
+	defer t.Unlock()
+	for _, column := range t.columns {
+		if !column.GetVisible() {
+			continue
+		}
+		natural := t.naturals[column]
+		width := natural
+		switch column.GetSizing() {
+		case enums.TREE_VIEW_COLUMN_FIXED:
+			width = column.GetFixedWidth()
+		case enums.TREE_VIEW_COLUMN_GROW_ONLY:
+			if grown, ok := t.grown[column]; ok && grown > width {
+				width = grown
+			}
+			t.grown[column] = width
+		}
+		if minWidth := column.GetMinWidth(); minWidth > 0 && width < minWidth {
+			width = minWidth
+		}
+		if maxWidth := column.GetMaxWidth(); maxWidth > 0 && width > maxWidth {
+			width = maxWidth
+		}
+		if width < 1 {
+			width = 1
+		}
+		columns = append(columns, column)
+		widths = append(widths, width)
+	}
+	return
+}
+
+// measureColumns returns the total width required by the visible columns
+func (t *CTreeView) measureColumns() (total int) {
+	columns, widths := t.columnWidths()
+	for idx, column := range columns {
+		total += widths[idx] + column.GetSpacing()
+		if idx < len(columns)-1 {
+			total += 1 // column separator
+		}
+	}
+	return
+}
+
+// layoutColumns positions the visible columns within the available width,
+// giving any extra space to the expanding columns, or the last column if none
+// are set to expand
+func (t *CTreeView) layoutColumns(available int) {
+	columns, widths := t.columnWidths()
+	total := 0
+	var expanding []int
+	for idx, column := range columns {
+		total += widths[idx] + column.GetSpacing()
+		if idx < len(columns)-1 {
+			total += 1
+		}
+		if column.GetExpand() {
+			expanding = append(expanding, idx)
+		}
+	}
+	if extra := available - total; extra > 0 && len(columns) > 0 {
+		if len(expanding) == 0 {
+			widths[len(widths)-1] += extra
+		} else {
+			each := extra / len(expanding)
+			for n, idx := range expanding {
+				widths[idx] += each
+				if n == len(expanding)-1 {
+					widths[idx] += extra - each*len(expanding)
+				}
+			}
+		}
+	}
+	var layout []*treeViewColumnLayout
+	x := 0
+	for idx, column := range columns {
+		cl := &treeViewColumnLayout{
+			column: column,
+			x:      x,
+			width:  widths[idx],
+			border: -1,
+		}
+		x += widths[idx] + column.GetSpacing()
+		if idx < len(columns)-1 {
+			cl.border = x
+			x += 1
+		}
+		if ci, ok := column.(treeViewColumnInternal); ok {
+			ci.setWidth(cl.width)
+		}
+		layout = append(layout, cl)
+	}
+	t.Lock()
+	t.layout, t.contentW = layout, x
+	t.Unlock()
+}
+
+func (t *CTreeView) getLayout() (layout []*treeViewColumnLayout) {
+	t.RLock()
+	layout = t.layout
+	t.RUnlock()
+	return
+}
+
+func (t *CTreeView) columnLayoutAt(x int) *treeViewColumnLayout {
+	for _, cl := range t.getLayout() {
+		end := cl.border
+		if end < 0 {
+			end = cl.x + cl.width + cl.column.GetSpacing()
+		}
+		if x >= cl.x && x < end {
+			return cl
+		}
+	}
+	return nil
+}
+
+// scrollOffset returns the vertical adjustment value and whether the TreeView
+// is the child of a ScrolledViewport
+func (t *CTreeView) scrollOffset() (offset int, inViewport bool) {
+	inViewport = t.getScrolledViewport() != nil
+	if adjustment := t.GetVAdjustment(); adjustment != nil {
+		offset = adjustment.GetValue()
+	}
+	return
+}
+
+// viewHeight returns the number of lines of the TreeView actually visible
+func (t *CTreeView) viewHeight() int {
+	if sv := t.getScrolledViewport(); sv != nil {
+		height := sv.GetAllocation().H
+		if sv.HorizontalShowByPolicy() {
+			height -= 1
+		}
+		return height
+	}
+	return t.GetAllocation().H
+}
+
+// rowsTop returns the local Y coordinate of the first row
+func (t *CTreeView) rowsTop() int {
+	offset, inViewport := t.scrollOffset()
+	if inViewport {
+		return t.headerHeight()
+	}
+	return t.headerHeight() - offset
+}
+
+// headerTop returns the local Y coordinate of the column headers, kept at the
+// top of the visible area within a ScrolledViewport
+func (t *CTreeView) headerTop() int {
+	if offset, inViewport := t.scrollOffset(); inViewport {
+		return offset
+	}
+	return 0
+}
+
+func (t *CTreeView) isHeaderAt(y int) bool {
+	top := t.headerTop()
+	return t.GetHeadersVisible() && y >= top && y < top+t.headerHeight()
+}
+
+// rowIndexAt returns the index of the visible row at the local Y coordinate,
+// or -1 if there is none
+func (t *CTreeView) rowIndexAt(y int) int {
+	if t.isHeaderAt(y) {
+		return -1
+	}
+	line := y - t.rowsTop()
+	if line < 0 {
+		return -1
+	}
+	return line / t.rowHeight()
+}
+
+// visibleRowRange returns the indices of the first and last rows scrolled into
+// view
+func (t *CTreeView) visibleRowRange(count int) (first, last int) {
+	offset, _ := t.scrollOffset()
+	rowH := t.rowHeight()
+	lines := t.viewHeight() - t.headerHeight()
+	first = offset / rowH
+	if first < 0 {
+		first = 0
+	}
+	last = (offset+lines+rowH-1)/rowH - 1
+	if last >= count {
+		last = count - 1
+	}
+	return
+}
+
+func (t *CTreeView) isRowVisible(path TreePath) bool {
+	for _, row := range t.getRows() {
+		if row.path.Compare(path) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *CTreeView) rowIndexOf(path TreePath) int {
+	if path != nil {
+		for idx, row := range t.getRows() {
+			if row.path.Compare(path) == 0 {
+				return idx
+			}
+		}
+	}
+	return -1
+}
+
+// scrollToRow updates the vertical adjustment, scrolling as little as possible
+// for the row at the given index to be visible
+func (t *CTreeView) scrollToRow(idx int, column TreeViewColumn) {
+	adjustment := t.GetVAdjustment()
+	if adjustment == nil {
+		return
+	}
+	rowH := t.rowHeight()
+	lines := t.viewHeight() - t.headerHeight()
+	if lines <= 0 {
+		return
+	}
+	offset := adjustment.GetValue()
+	top := idx * rowH
+	value := offset
+	if top < offset {
+		value = top
+	} else if top+rowH > offset+lines {
+		value = top + rowH - lines
+	}
+	changed := value != offset
+	if value != offset {
+		adjustment.SetValue(value)
+	}
+	if sv := t.getScrolledViewport(); sv != nil {
+		if column != nil {
+			if h := sv.GetHAdjustment(); h != nil {
+				for _, cl := range t.getLayout() {
+					if cl.column.ObjectID() == column.ObjectID() {
+						width := sv.GetAllocation().W
+						hOffset := h.GetValue()
+						if cl.x < hOffset {
+							h.SetValue(cl.x)
+							changed = true
+						} else if cl.x+cl.width > hOffset+width && width > 0 {
+							h.SetValue(cl.x + cl.width - width)
+							changed = true
+						}
+						break
+					}
+				}
+			}
+		}
+		if changed {
+			sv.Resize()
+		}
+		return
+	}
+	if changed {
+		t.Invalidate()
+	}
+}
+
+// setCursorRow updates the cursor, and the anchor for range selections,
+// without changing the selection
+func (t *CTreeView) setCursorRow(path TreePath, focusColumn TreeViewColumn) {
+	t.Lock()
+	t.cursor = path.Copy()
+	t.anchor = path.Copy()
+	if focusColumn != nil {
+		t.focusColumn = focusColumn
+	}
+	t.Unlock()
+	if idx := t.rowIndexOf(path); idx >= 0 {
+		t.scrollToRow(idx, focusColumn)
+	}
+	t.Emit(SignalCursorChanged, t)
+	t.Invalidate()
+}
+
+// moveCursor moves the cursor by the given number of visible rows, updating
+// the selection with respect to the modifier keys held
+func (t *CTreeView) moveCursor(delta int, extend, keep bool) cenums.EventFlag {
+	rows := t.getRows()
+	if len(rows) == 0 {
+		return cenums.EVENT_PASS
+	}
+	cursor, _ := t.GetCursor()
+	idx := t.rowIndexOf(cursor)
+	if idx < 0 {
+		idx = 0
+	} else {
+		idx += delta
+	}
+	if idx < 0 {
+		idx = 0
+	} else if idx >= len(rows) {
+		idx = len(rows) - 1
+	}
+	t.moveCursorTo(rows[idx].path, extend, keep)
+	return cenums.EVENT_STOP
+}
+
+// moveCursorTo sets the cursor to the given path and updates the selection
+func (t *CTreeView) moveCursorTo(path TreePath, extend, keep bool) {
+	t.RLock()
+	anchor := t.anchor
+	t.RUnlock()
+	t.Lock()
+	t.cursor = path.Copy()
+	if !extend || anchor == nil {
+		t.anchor = path.Copy()
+	}
+	t.Unlock()
+	if idx := t.rowIndexOf(path); idx >= 0 {
+		t.scrollToRow(idx, nil)
+	}
+	t.selectCursor(extend, keep)
+	t.Emit(SignalCursorChanged, t)
+	t.Invalidate()
+}
+
+// selectCursor applies the selection mode to the cursor row; extend selects
+// the range from the anchor to the cursor and keep leaves the selection as-is
+func (t *CTreeView) selectCursor(extend, keep bool) {
+	cursor, _ := t.GetCursor()
+	if cursor == nil {
+		return
+	}
+	switch t.selection.GetMode() {
+	case enums.SELECTION_NONE:
+	case enums.SELECTION_MULTIPLE:
+		if keep {
+			return
+		}
+		t.RLock()
+		anchor := t.anchor
+		t.RUnlock()
+		t.selection.unselectAll()
+		if extend && anchor != nil {
+			for _, path := range t.selection.rangeOf(anchor, cursor) {
+				if t.selection.canToggle(path, false) {
+					t.selection.selectPath(path)
+				}
+			}
+		} else if t.selection.canToggle(cursor, false) {
+			t.selection.selectPath(cursor)
+		}
+		t.selection.Changed()
+	default:
+		t.selection.SelectPath(cursor)
+	}
+}
+
+func (t *CTreeView) toggleCursor() cenums.EventFlag {
+	cursor, _ := t.GetCursor()
+	if cursor == nil {
+		return cenums.EVENT_PASS
+	}
+	switch t.selection.GetMode() {
+	case enums.SELECTION_MULTIPLE, enums.SELECTION_SINGLE:
+		if t.selection.PathIsSelected(cursor) {
+			t.selection.UnselectPath(cursor)
+		} else {
+			t.selection.SelectPath(cursor)
+		}
+		t.Lock()
+		t.anchor = cursor.Copy()
+		t.Unlock()
+	case enums.SELECTION_BROWSE:
+		t.selection.SelectPath(cursor)
+	}
+	return cenums.EVENT_STOP
+}
+
+// searchColumn returns the model column used by the interactive search
+func (t *CTreeView) searchColumn(model TreeModel) int {
+	if column := t.GetSearchColumn(); column >= 0 {
+		return column
+	}
+	for idx := 0; idx < model.GetNColumns(); idx++ {
+		if model.GetColumnType(idx) == cdk.StringProperty {
+			return idx
+		}
+	}
+	return -1
+}
+
+// searchFor moves the cursor to the first visible row, starting from the
+// cursor, with a search column value starting with the typed characters
+func (t *CTreeView) searchFor(r rune) cenums.EventFlag {
+	model := t.GetModel()
+	if model == nil {
+		return cenums.EVENT_PASS
+	}
+	column := t.searchColumn(model)
+	if column < 0 {
+		return cenums.EVENT_PASS
+	}
+	t.Lock()
+	if time.Since(t.searchTime) > TreeViewSearchTimeout {
+		t.search = ""
+	}
+	t.search += string(unicode.ToLower(r))
+	t.searchTime = time.Now()
+	search := t.search
+	t.Unlock()
+	rows := t.getRows()
+	cursor, _ := t.GetCursor()
+	start := t.rowIndexOf(cursor)
+	if start < 0 {
+		start = 0
+	}
+	for n := 0; n < len(rows); n++ {
+		row := rows[(start+n)%len(rows)]
+		value := strings.ToLower(TreeModelGetString(model, row.iter, column))
+		if strings.HasPrefix(value, search) {
+			t.moveCursorTo(row.path, false, false)
+			break
+		}
+	}
+	return cenums.EVENT_STOP
+}
+
+func (t *CTreeView) expandCursor(openAll bool) cenums.EventFlag {
+	if cursor, _ := t.GetCursor(); cursor != nil {
+		if t.ExpandRow(cursor, openAll) {
+			return cenums.EVENT_STOP
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (t *CTreeView) collapseCursor() cenums.EventFlag {
+	if cursor, _ := t.GetCursor(); cursor != nil {
+		if t.CollapseRow(cursor) {
+			return cenums.EVENT_STOP
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+// headerClicked emits the clicked signal for the column and, if the column has
+// a sort column id and the model is a TreeSortable, sorts the model by it,
+// toggling the sort order when already sorted by the column
+func (t *CTreeView) headerClicked(column TreeViewColumn) {
+	if !column.GetClickable() {
+		return
+	}
+	column.Clicked()
+	sortColumnId := column.GetSortColumnID()
+	sortable, ok := t.GetModel().(TreeSortable)
+	if sortColumnId < 0 || !ok {
+		return
+	}
+	order := enums.SORT_ASCENDING
+	if id, current, ok := sortable.GetSortColumnId(); ok && id == sortColumnId && current == enums.SORT_ASCENDING {
+		order = enums.SORT_DESCENDING
+	}
+	for _, c := range t.GetColumns() {
+		c.SetSortIndicator(c.ObjectID() == column.ObjectID())
+	}
+	column.SetSortOrder(order)
+	sortable.SetSortColumnId(sortColumnId, order)
+}
+
+func (t *CTreeView) selectionChanged(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	t.Invalidate()
+	return cenums.EVENT_PASS
+}
+
+func (t *CTreeView) rowChanged(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	t.queueResize()
+	return cenums.EVENT_PASS
+}
+
+func (t *CTreeView) rowInserted(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if len(argv) >= 2 {
+		if path, ok := argv[1].(TreePath); ok {
+			t.Lock()
+			expanded := make(map[string]TreePath)
+			for _, ep := range t.expanded {
+				ep = treePathShiftInsert(ep, path)
+				expanded[ep.String()] = ep
+			}
+			t.expanded = expanded
+			if t.cursor != nil {
+				t.cursor = treePathShiftInsert(t.cursor, path)
+			}
+			if t.anchor != nil {
+				t.anchor = treePathShiftInsert(t.anchor, path)
+			}
+			t.lastClick = nil
+			t.Unlock()
+			t.selection.rowInserted(path)
+		}
+	}
+	t.queueResize()
+	return cenums.EVENT_PASS
+}
+
+func (t *CTreeView) rowHasChildToggled(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if len(argv) >= 3 {
+		model, _ := argv[0].(TreeModel)
+		path, _ := argv[1].(TreePath)
+		iter, _ := argv[2].(*TreeIter)
+		if model != nil && path != nil && iter != nil && !model.IterHasChild(iter) {
+			t.Lock()
+			delete(t.expanded, path.String())
+			t.Unlock()
+		}
+	}
+	t.queueResize()
+	return cenums.EVENT_PASS
+}
+
+func (t *CTreeView) rowDeleted(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if len(argv) >= 2 {
+		if path, ok := argv[1].(TreePath); ok {
+			cursorDeleted := false
+			t.Lock()
+			expanded := make(map[string]TreePath)
+			for _, ep := range t.expanded {
+				if shifted, ok := treePathShiftDelete(ep, path); ok {
+					expanded[shifted.String()] = shifted
+				}
+			}
+			t.expanded = expanded
+			if t.cursor != nil {
+				if shifted, ok := treePathShiftDelete(t.cursor, path); ok {
+					t.cursor = shifted
+				} else {
+					t.cursor, cursorDeleted = nil, true
+				}
+			}
+			if t.anchor != nil {
+				if shifted, ok := treePathShiftDelete(t.anchor, path); ok {
+					t.anchor = shifted
+				} else {
+					t.anchor = nil
+				}
+			}
+			t.lastClick = nil
+			t.Unlock()
+			changed := t.selection.rowDeleted(path)
+			t.queueResize()
+			if cursorDeleted {
+				// move the cursor to the row now at the deleted path, or the
+				// previous sibling, or the parent
+				if model := t.GetModel(); model != nil {
+					target := path
+					if _, ok := model.GetIter(target); !ok {
+						if prev, ok := path.Prev(); ok {
+							target = prev
+						} else if up, ok := path.Up(); ok && up.GetDepth() > 0 {
+							target = up
+						} else {
+							target = nil
+						}
+					}
+					if target != nil {
+						t.Lock()
+						t.cursor, t.anchor = target.Copy(), target.Copy()
+						t.Unlock()
+						if t.selection.GetMode() == enums.SELECTION_BROWSE {
+							t.selection.SelectPath(target)
+							changed = false
+						}
+					}
+				}
+				t.Emit(SignalCursorChanged, t)
+			}
+			if changed {
+				t.selection.Changed()
+			}
+			return cenums.EVENT_PASS
+		}
+	}
+	t.queueResize()
+	return cenums.EVENT_PASS
+}
+
+func (t *CTreeView) rowsReordered(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if len(argv) >= 4 {
+		parent, _ := argv[1].(TreePath)
+		if newOrder, ok := argv[3].([]int); ok {
+			t.Lock()
+			expanded := make(map[string]TreePath)
+			for _, ep := range t.expanded {
+				ep = treePathShiftReorder(ep, parent, newOrder)
+				expanded[ep.String()] = ep
+			}
+			t.expanded = expanded
+			if t.cursor != nil {
+				t.cursor = treePathShiftReorder(t.cursor, parent, newOrder)
+			}
+			if t.anchor != nil {
+				t.anchor = treePathShiftReorder(t.anchor, parent, newOrder)
+			}
+			t.lastClick = nil
+			t.Unlock()
+			t.selection.rowsReordered(parent, newOrder)
+		}
+	}
+	t.queueResize()
+	return cenums.EVENT_PASS
+}
+
+func (t *CTreeView) isFocused() bool {
+	if t.HasState(enums.StateSelected) {
+		return true
+	}
+	if sv := t.getScrolledViewport(); sv != nil {
+		return sv.HasState(enums.StateSelected)
+	}
+	return false
+}
+
+func (t *CTreeView) lostFocus(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	t.Lock()
+	t.search = ""
+	t.Unlock()
+	t.Invalidate()
+	return cenums.EVENT_PASS
+}
+
+func (t *CTreeView) gainedFocus(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if cursor, _ := t.GetCursor(); cursor == nil {
+		if rows := t.getRows(); len(rows) > 0 {
+			t.Lock()
+			t.cursor, t.anchor = rows[0].path.Copy(), rows[0].path.Copy()
+			t.Unlock()
+			if t.selection.GetMode() == enums.SELECTION_BROWSE {
+				t.selection.SelectPath(rows[0].path)
+			}
+		}
+	}
+	t.Invalidate()
+	return cenums.EVENT_PASS
+}
+
+func (t *CTreeView) event(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if !t.IsSensitive() {
+		return cenums.EVENT_PASS
+	}
+	if evt, ok := argv[1].(cdk.Event); ok {
+		switch e := evt.(type) {
+		case *cdk.EventMouse:
+			return t.processMouseEvent(e)
+		case *cdk.EventKey:
+			return t.processKeyEvent(e)
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (t *CTreeView) processKeyEvent(e *cdk.EventKey) cenums.EventFlag {
+	mods := e.Modifiers()
+	shift, ctrl := mods.Has(cdk.ModShift), mods.Has(cdk.ModCtrl)
+	rowH := t.rowHeight()
+	page := (t.viewHeight() - t.headerHeight()) / rowH
+	if page < 1 {
+		page = 1
+	}
+	switch cdk.Key(e.Rune()) {
+	case cdk.KeyEnter:
+		if cursor, column := t.GetCursor(); cursor != nil {
+			t.RowActivated(cursor, column)
+			return cenums.EVENT_STOP
+		}
+		return cenums.EVENT_PASS
+	case cdk.KeyBackspace, cdk.KeyBackspace2:
+		t.Lock()
+		if len(t.search) > 0 {
+			t.search = t.search[:len(t.search)-1]
+		}
+		t.Unlock()
+		return cenums.EVENT_PASS
+	}
+	switch e.Key() {
+	case cdk.KeyUp:
+		return t.moveCursor(-1, shift, ctrl)
+	case cdk.KeyDown:
+		return t.moveCursor(1, shift, ctrl)
+	case cdk.KeyPgUp:
+		return t.moveCursor(-page, shift, ctrl)
+	case cdk.KeyPgDn:
+		return t.moveCursor(page, shift, ctrl)
+	case cdk.KeyHome:
+		return t.moveCursor(-len(t.getRows()), shift, ctrl)
+	case cdk.KeyEnd:
+		return t.moveCursor(len(t.getRows()), shift, ctrl)
+	case cdk.KeyLeft:
+		cursor, _ := t.GetCursor()
+		if cursor == nil {
+			return cenums.EVENT_PASS
+		}
+		if t.RowExpanded(cursor) {
+			return t.collapseCursor()
+		}
+		if parent, ok := cursor.Up(); ok && parent.GetDepth() > 0 {
+			t.moveCursorTo(parent, false, false)
+			return cenums.EVENT_STOP
+		}
+		return cenums.EVENT_PASS
+	case cdk.KeyRight:
+		cursor, _ := t.GetCursor()
+		if cursor == nil {
+			return cenums.EVENT_PASS
+		}
+		if !t.RowExpanded(cursor) {
+			return t.expandCursor(false)
+		}
+		if idx := t.rowIndexOf(cursor); idx >= 0 {
+			if rows := t.getRows(); idx+1 < len(rows) {
+				t.moveCursorTo(rows[idx+1].path, false, false)
+				return cenums.EVENT_STOP
+			}
+		}
+		return cenums.EVENT_PASS
+	case cdk.KeySmallA:
+		if ctrl && t.selection.GetMode() == enums.SELECTION_MULTIPLE {
+			t.selection.SelectAll()
+			return cenums.EVENT_STOP
+		}
+		return cenums.EVENT_PASS
+	case cdk.KeyRune:
+		r := e.Rune()
+		switch {
+		case r == ' ':
+			return t.toggleCursor()
+		case r == '+':
+			return t.expandCursor(false)
+		case r == '*':
+			return t.expandCursor(true)
+		case r == '-':
+			return t.collapseCursor()
+		case t.GetEnableSearch() && unicode.IsPrint(r) && !ctrl:
+			return t.searchFor(r)
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (t *CTreeView) processMouseEvent(e *cdk.EventMouse) cenums.EventFlag {
+	pos := ptypes.NewPoint2I(e.Position())
+	origin := t.GetOrigin()
+	local := ptypes.MakePoint2I(pos.X-origin.X, pos.Y-origin.Y)
+	if e.IsWheelImpulse() {
+		if t.getScrolledViewport() != nil || !t.HasPoint(pos) {
+			return cenums.EVENT_PASS
+		}
+		if adjustment := t.GetVAdjustment(); adjustment != nil {
+			value, lower, upper, _, _, _ := adjustment.Settings()
+			switch e.WheelImpulse() {
+			case cdk.WheelUp:
+				value -= 1
+			case cdk.WheelDown:
+				value += 1
+			default:
+				return cenums.EVENT_PASS
+			}
+			if value > upper {
+				value = upper
+			}
+			if value < lower {
+				value = lower
+			}
+			adjustment.SetValue(value)
+			t.Invalidate()
+			return cenums.EVENT_STOP
+		}
+		return cenums.EVENT_PASS
+	}
+	switch e.State() {
+	case cdk.BUTTON_PRESS, cdk.DRAG_START:
+		if !t.HasPoint(pos) {
+			return cenums.EVENT_PASS
+		}
+		if !t.HasState(enums.StateSelected) && t.CanFocus() {
+			t.GrabFocus()
+		}
+		if t.isHeaderAt(local.Y) {
+			return t.pressHeader(local)
+		}
+		return t.pressRow(local, e.Modifiers())
+	case cdk.MOUSE_MOVE, cdk.DRAG_MOVE:
+		if t.HasEventFocus() {
+			return t.dragHeader(local)
+		}
+	case cdk.BUTTON_RELEASE, cdk.DRAG_STOP:
+		if t.HasEventFocus() {
+			t.Lock()
+			pressed, moved := t.pressColumn, t.dragMoved
+			t.pressColumn, t.sizeColumn, t.dragMoved = -1, -1, false
+			t.Unlock()
+			t.ReleaseEventFocus()
+			if pressed >= 0 && !moved && t.isHeaderAt(local.Y) {
+				if cl := t.columnLayoutAt(local.X); cl != nil {
+					t.headerClicked(cl.column)
+				}
+			}
+			t.Invalidate()
+			return cenums.EVENT_STOP
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
+func (t *CTreeView) pressHeader(local ptypes.Point2I) cenums.EventFlag {
+	layout := t.getLayout()
+	for idx, cl := range layout {
+		if cl.border >= 0 && local.X == cl.border && cl.column.GetResizable() {
+			t.Lock()
+			t.sizeColumn, t.dragStart, t.dragWidth = idx, local.X, cl.width
+			t.Unlock()
+			t.GrabEventFocus()
+			return cenums.EVENT_STOP
+		}
+	}
+	for idx, cl := range layout {
+		if cl == t.columnLayoutAt(local.X) {
+			t.Lock()
+			t.pressColumn, t.dragStart, t.dragMoved = idx, local.X, false
+			t.Unlock()
+			t.GrabEventFocus()
+			t.Invalidate()
+			return cenums.EVENT_STOP
+		}
+	}
+	return cenums.EVENT_STOP
+}
+
+func (t *CTreeView) dragHeader(local ptypes.Point2I) cenums.EventFlag {
+	t.RLock()
+	sizeColumn, pressColumn, start, width := t.sizeColumn, t.pressColumn, t.dragStart, t.dragWidth
+	t.RUnlock()
+	layout := t.getLayout()
+	if sizeColumn >= 0 && sizeColumn < len(layout) {
+		column := layout[sizeColumn].column
+		width += local.X - start
+		if width < 1 {
+			width = 1
+		}
+		column.SetSizing(enums.TREE_VIEW_COLUMN_FIXED)
+		column.SetFixedWidth(width)
+		return cenums.EVENT_STOP
+	}
+	if pressColumn >= 0 && pressColumn < len(layout) && local.X != start {
+		column := layout[pressColumn].column
+		if !column.GetReorderable() {
+			return cenums.EVENT_STOP
+		}
+		t.Lock()
+		t.dragMoved = true
+		t.Unlock()
+		if target := t.columnLayoutAt(local.X); target != nil && target.column.ObjectID() != column.ObjectID() {
+			targetIdx := -1
+			for idx, cl := range layout {
+				if cl == target {
+					targetIdx = idx
+				}
+			}
+			if targetIdx > pressColumn {
+				t.MoveColumnAfter(column, target.column)
+			} else if targetIdx > 0 {
+				t.MoveColumnAfter(column, layout[targetIdx-1].column)
+			} else {
+				t.MoveColumnAfter(column, nil)
+			}
+			t.Lock()
+			t.pressColumn, t.dragStart = targetIdx, local.X
+			t.Unlock()
+		}
+	}
+	return cenums.EVENT_STOP
+}
+
+func (t *CTreeView) pressRow(local ptypes.Point2I, mods cdk.ModMask) cenums.EventFlag {
+	rows := t.getRows()
+	idx := t.rowIndexAt(local.Y)
+	if idx < 0 || idx >= len(rows) {
+		return cenums.EVENT_STOP
+	}
+	row := rows[idx]
+	var column TreeViewColumn
+	if cl := t.columnLayoutAt(local.X); cl != nil {
+		column = cl.column
+		if expander := t.GetExpanderColumn(); row.hasChildren && t.hasExpanders() && expander != nil && expander.ObjectID() == column.ObjectID() {
+			glyph := cl.x + row.depth*t.GetLevelIndentation()
+			if local.X == glyph {
+				if row.expanded {
+					t.CollapseRow(row.path)
+				} else {
+					t.ExpandRow(row.path, false)
+				}
+				return cenums.EVENT_STOP
+			}
+		}
+	}
+	shift, ctrl := mods.Has(cdk.ModShift), mods.Has(cdk.ModCtrl)
+	if column != nil {
+		t.Lock()
+		t.focusColumn = column
+		t.Unlock()
+	}
+	if ctrl && !shift {
+		t.moveCursorTo(row.path, false, true)
+		t.toggleCursor()
+	} else {
+		t.moveCursorTo(row.path, shift, false)
+	}
+	t.RLock()
+	lastClick, clickTime := t.lastClick, t.clickTime
+	t.RUnlock()
+	doubleClick := time.Duration(GetDefaultSettings().GetDoubleClickTime()) * time.Millisecond
+	if lastClick != nil && lastClick.Compare(row.path) == 0 && time.Since(clickTime) <= doubleClick {
+		t.Lock()
+		t.lastClick = nil
+		t.Unlock()
+		t.RowActivated(row.path, column)
+	} else {
+		t.Lock()
+		t.lastClick, t.clickTime = row.path.Copy(), time.Now()
+		t.Unlock()
+	}
+	return cenums.EVENT_STOP
+}
+
+func (t *CTreeView) resize(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	alloc := t.GetAllocation()
+	rows := t.getRows()
+	t.layoutColumns(alloc.W)
+	if t.getScrolledViewport() == nil {
+		if adjustment := t.GetVAdjustment(); adjustment != nil {
+			lines := alloc.H - t.headerHeight()
+			if lines < 0 {
+				lines = 0
+			}
+			upper := len(rows)*t.rowHeight() - lines
+			if upper < 0 {
+				upper = 0
+			}
+			value, lower, _, step, _, _ := adjustment.Settings()
+			if value > upper {
+				value = upper
+			}
+			if value < lower {
+				value = lower
+			}
+			adjustment.Configure(value, 0, upper, step, lines, lines)
+		}
+	}
+	t.Invalidate()
+	return cenums.EVENT_STOP
+}
+
+func (t *CTreeView) draw(data []interface{}, argv ...interface{}) cenums.EventFlag {
+	if surface, ok := argv[1].(*memphis.CSurface); ok {
+		alloc := t.GetAllocation()
+		if !t.IsVisible() || alloc.W <= 0 || alloc.H <= 0 {
+			t.LogTrace("not visible, zero width or zero height")
+			return cenums.EVENT_PASS
+		}
+		rows := t.getRows()
+		t.RLock()
+		needsLayout := len(t.layout) == 0 && len(t.columns) > 0
+		t.RUnlock()
+		if needsLayout {
+			t.layoutColumns(alloc.W)
+		}
+
+		theme := t.GetThemeRequest()
+		surface.Fill(theme)
+		origin := t.GetOrigin()
+		focused := t.isFocused()
+		cursor, _ := t.GetCursor()
+		first, last := t.visibleRowRange(len(rows))
+		rowsTop := t.rowsTop()
+		rowH := t.rowHeight()
+		for idx := first; idx <= last; idx++ {
+			t.drawRow(surface, origin, theme, rows[idx], idx, rowsTop+idx*rowH, cursor, focused)
+		}
+		if t.GetHeadersVisible() {
+			t.drawHeader(surface, origin, theme, t.headerTop())
+		}
+
+		if debug, _ := t.GetBoolProperty(cdk.PropertyDebug); debug {
+			surface.DebugBox(paint.ColorSilver, t.ObjectInfo())
+		}
+		return cenums.EVENT_STOP
+	}
+	return cenums.EVENT_PASS
+}
+
+func (t *CTreeView) drawHeader(surface *memphis.CSurface, origin ptypes.Point2I, theme paint.Theme, y int) {
+	alloc := t.GetAllocation()
+	style := theme.Content.Normal.Bold(true)
+	t.RLock()
+	pressed := t.pressColumn
+	t.RUnlock()
+	vertical := t.hasGridLines(enums.TREE_VIEW_GRID_LINES_VERTICAL)
+	for x := 0; x < alloc.W; x++ {
+		_ = surface.SetRune(x, y, theme.Content.FillRune, style)
+		_ = surface.SetRune(x, y+1, paint.RuneHLine, theme.Content.Normal)
+	}
+	for idx, cl := range t.getLayout() {
+		cellStyle := style
+		if idx == pressed {
+			cellStyle = theme.Content.Active.Bold(true)
+			for x := cl.x; x < cl.x+cl.width; x++ {
+				_ = surface.SetRune(x, y, theme.Content.FillRune, cellStyle)
+			}
+		}
+		width := cl.width
+		if cl.column.GetSortIndicator() && width > 2 {
+			width -= 2
+			indicator := paint.RuneTriangleUp
+			if cl.column.GetSortOrder() == enums.SORT_DESCENDING {
+				indicator = paint.RuneTriangleDown
+			}
+			_ = surface.SetRune(cl.x+cl.width-1, y, indicator, cellStyle)
+		}
+		justify := cenums.JUSTIFY_LEFT
+		if align := cl.column.GetAlignment(); align >= 0.66 {
+			justify = cenums.JUSTIFY_RIGHT
+		} else if align >= 0.33 {
+			justify = cenums.JUSTIFY_CENTER
+		}
+		t.drawText(surface, origin, cl.x, y, width, justify, cellStyle, cl.column.GetTitle(), false)
+		if cl.border >= 0 {
+			_ = surface.SetRune(cl.border, y, paint.RuneVLine, theme.Content.Normal)
+			if vertical {
+				_ = surface.SetRune(cl.border, y+1, paint.RunePlus, theme.Content.Normal)
+			} else {
+				_ = surface.SetRune(cl.border, y+1, paint.RuneHLine, theme.Content.Normal)
+			}
+		}
+	}
+}
+
+func (t *CTreeView) drawRow(surface *memphis.CSurface, origin ptypes.Point2I, theme paint.Theme, row *treeViewRow, idx, y int, cursor TreePath, focused bool) {
+	model := t.GetModel()
+	alloc := t.GetAllocation()
+	style := theme.Content.Normal
+	if t.GetRulesHint() && idx%2 == 1 {
+		style = style.Dim(true)
+	}
+	if t.selection.PathIsSelected(row.path) {
+		style = theme.Content.Active
+	}
+	if focused && cursor != nil && cursor.Compare(row.path) == 0 {
+		style = style.Underline(true)
+	}
+	width := alloc.W
+	if t.contentW > width {
+		width = t.contentW
+	}
+	for x := 0; x < width; x++ {
+		_ = surface.SetRune(x, y, theme.Content.FillRune, style)
+	}
+	vertical := t.hasGridLines(enums.TREE_VIEW_GRID_LINES_VERTICAL)
+	horizontal := t.hasGridLines(enums.TREE_VIEW_GRID_LINES_HORIZONTAL)
+	if horizontal {
+		for x := 0; x < width; x++ {
+			_ = surface.SetRune(x, y+1, paint.RuneHLine, theme.Content.Normal)
+		}
+	}
+	expander := t.GetExpanderColumn()
+	expanders := t.hasExpanders()
+	indent := t.GetLevelIndentation()
+	for _, cl := range t.getLayout() {
+		x, cellWidth := cl.x, cl.width
+		if expander != nil && expander.ObjectID() == cl.column.ObjectID() {
+			offset := row.depth * indent
+			if expanders {
+				if row.hasChildren && offset < cellWidth {
+					glyph := paint.RuneFilledRightPointingSmallTriangle
+					if row.expanded {
+						glyph = paint.RuneFilledDownPointingSmallTriangle
+					}
+					_ = surface.SetRune(x+offset, y, glyph, style)
+				}
+				offset += 2
+			}
+			x, cellWidth = x+offset, cellWidth-offset
+		}
+		if text, markup, visible := cl.column.GetCellText(model, row.iter); visible {
+			justify := cenums.JUSTIFY_LEFT
+			if align := cl.column.GetAlignment(); align >= 0.66 {
+				justify = cenums.JUSTIFY_RIGHT
+			} else if align >= 0.33 {
+				justify = cenums.JUSTIFY_CENTER
+			}
+			t.drawText(surface, origin, x, y, cellWidth, justify, style, text, markup)
+		}
+		if cl.border >= 0 && vertical {
+			_ = surface.SetRune(cl.border, y, paint.RuneVLine, theme.Content.Normal)
+			if horizontal {
+				_ = surface.SetRune(cl.border, y+1, paint.RunePlus, theme.Content.Normal)
+			}
+		}
+	}
+}
+
+// drawText renders a single line of text at the local coordinates given,
+// falling back to plain text if the markup is invalid
+func (t *CTreeView) drawText(surface *memphis.CSurface, origin ptypes.Point2I, x, y, width int, justify cenums.Justification, style paint.Style, text string, markup bool) {
+	if width <= 0 || text == "" {
+		return
+	}
+	if idx := strings.IndexRune(text, '\n'); idx >= 0 && !markup {
+		text = text[:idx]
+	}
+	if markup {
+		if _, err := memphis.NewMarkup(text, style); err != nil {
+			t.LogErr(err)
+			markup = false
+		}
+	}
+	surface.DrawSingleLineText(ptypes.MakePoint2I(origin.X+x, origin.Y+y), width, true, justify, style, markup, false, text)
+}
+
+// treeViewTextWidth returns the number of cells needed to display the first
+// line of the given text
+func treeViewTextWidth(text string, markup bool) int {
+	if markup {
+		if m, err := memphis.NewMarkup(text, paint.StyleDefault); err == nil {
+			width, _ := m.TextBuffer(false).PlainTextInfo(cenums.WRAP_NONE, false, cenums.JUSTIFY_LEFT, -1)
+			return width
+		}
+	}
+	if idx := strings.IndexRune(text, '\n'); idx >= 0 {
+		text = text[:idx]
+	}
+	return runewidth.StringWidth(text)
+}
+
+// treeViewGridLinesFromString parses GtkTreeViewGridLines names
+func treeViewGridLinesFromString(value string) enums.TreeViewGridLines {
+	value = strings.ToLower(value)
+	value = strings.TrimPrefix(value, "gtk_tree_view_grid_lines_")
+	switch value {
+	case "horizontal", "1":
+		return enums.TREE_VIEW_GRID_LINES_HORIZONTAL
+	case "vertical", "2":
+		return enums.TREE_VIEW_GRID_LINES_VERTICAL
+	case "both", "3":
+		return enums.TREE_VIEW_GRID_LINES_BOTH
+	}
+	if cstrings.IsTrue(value) {
+		return enums.TREE_VIEW_GRID_LINES_BOTH
+	}
+	return enums.TREE_VIEW_GRID_LINES_NONE
+}
+
+// treeViewSelectionModeFromString parses GtkSelectionMode names
+func treeViewSelectionModeFromString(value string) enums.SelectionMode {
+	value = strings.ToLower(value)
+	value = strings.TrimPrefix(value, "gtk_selection_")
+	switch value {
+	case "none":
+		return enums.SELECTION_NONE
+	case "browse":
+		return enums.SELECTION_BROWSE
+	case "multiple", "extended":
+		return enums.SELECTION_MULTIPLE
+	}
+	if v, err := strconv.Atoi(value); err == nil {
+		return enums.SelectionMode(v)
+	}
+	return enums.SELECTION_SINGLE
+}
+
+// TreeViewSearchTimeout is the delay after which the interactive search text
+// is reset.
+var TreeViewSearchTimeout = time.Second
+
+// The model for the tree view.
+// Flags: Read / Write
+const PropertyModel cdk.Property = "model"
+
+// Show the column header buttons.
+// Flags: Read / Write
+// Default value: TRUE
+const PropertyHeadersVisible cdk.Property = "headers-visible"
+
+// Column headers respond to click events.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyHeadersClickable cdk.Property = "headers-clickable"
+
+// Set the column for the expander column.
+// Flags: Read / Write
+const PropertyExpanderColumn cdk.Property = "expander-column"
+
+// Set a hint to the theme engine to draw rows in alternating colors.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyRulesHint cdk.Property = "rules-hint"
+
+// View allows user to search through columns interactively.
+// Flags: Read / Write
+// Default value: TRUE
+const PropertyEnableSearch cdk.Property = "enable-search"
+
+// Model column to search through during interactive search.
+// Flags: Read / Write
+// Allowed values: >= -1
+// Default value: -1
+const PropertySearchColumn cdk.Property = "search-column"
+
+// View has expanders.
+// Flags: Read / Write
+// Default value: TRUE
+const PropertyShowExpanders cdk.Property = "show-expanders"
+
+// Extra indentation for each level.
+// Flags: Read / Write
+// Allowed values: >= 0
+// Default value: 2
+const PropertyLevelIndentation cdk.Property = "level-indentation"
+
+// Whether grid lines should be drawn in the tree view.
+// Flags: Read / Write
+// Default value: GTK_TREE_VIEW_GRID_LINES_NONE
+const PropertyEnableGridLines cdk.Property = "enable-grid-lines"
+
+// The number of columns of the treeview has changed.
+const SignalColumnsChanged cdk.Signal = "columns-changed"
+
+// The position of the cursor (focused cell) has changed.
+const SignalCursorChanged cdk.Signal = "cursor-changed"
+
+// The "row-activated" signal is emitted when the method RowActivated is
+// called or the user double clicks a treeview row. It is also emitted when
+// the Enter key is pressed on the cursor row.
+// Listener function arguments:
+//      path TreePath   the TreePath for the activated row
+//      column TreeViewColumn   the TreeViewColumn in which the activation occurred
+const SignalRowActivated cdk.Signal = "row-activated"
+
+// The given row has been collapsed (child nodes are hidden).
+// Listener function arguments:
+//      iter *TreeIter  the tree iter of the collapsed row
+//      path TreePath   a tree path that points to the row
+const SignalRowCollapsed cdk.Signal = "row-collapsed"
+
+// The given row has been expanded (child nodes are shown).
+// Listener function arguments:
+//      iter *TreeIter  the tree iter of the expanded row
+//      path TreePath   a tree path that points to the row
+const SignalRowExpanded cdk.Signal = "row-expanded"
+
+// The given row is about to be collapsed (hide its children nodes). Use this
+// signal if you need to control the collapsibility of individual rows, a
+// listener returning EVENT_STOP prevents the row from collapsing.
+// Listener function arguments:
+//      iter *TreeIter  the tree iter of the row to collapse
+//      path TreePath   a tree path that points to the row
+const SignalTestCollapseRow cdk.Signal = "test-collapse-row"
+
+// The given row is about to be expanded (show its children nodes). Use this
+// signal if you need to control the expandability of individual rows, a
+// listener returning EVENT_STOP prevents the row from expanding.
+// Listener function arguments:
+//      iter *TreeIter  the tree iter of the row to expand
+//      path TreePath   a tree path that points to the row
+const SignalTestExpandRow cdk.Signal = "test-expand-row"
+
+const TreeViewModelHandle = "tree-view-model-handler"
+const TreeViewEventHandle = "tree-view-event-handler"
+const TreeViewLostFocusHandle = "tree-view-lost-focus-handler"
+const TreeViewGainedFocusHandle = "tree-view-gained-focus-handler"
+const TreeViewResizeHandle = "tree-view-resize-handler"
+const TreeViewDrawHandle = "tree-view-draw-handler"
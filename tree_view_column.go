@@ -0,0 +1,751 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-curses/cdk"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeTreeViewColumn cdk.CTypeTag = "ctk-tree-view-column"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeTreeViewColumn, func() interface{} { return MakeTreeViewColumn() })
+}
+
+// TreeCellDataFunc is the signature of the function set with
+// TreeViewColumn.SetCellDataFunc, used to provide the text to render for a
+// cell instead of the text, markup or active attributes.
+type TreeCellDataFunc = func(column TreeViewColumn, model TreeModel, iter *TreeIter) (text string)
+
+// TreeViewColumn cell attribute names, used with AddAttribute.
+const (
+	// TreeViewColumnAttributeText renders the model value as plain text
+	TreeViewColumnAttributeText = "text"
+	// TreeViewColumnAttributeMarkup renders the model value as Tango markup
+	TreeViewColumnAttributeMarkup = "markup"
+	// TreeViewColumnAttributeActive renders the boolean model value as a
+	// checkbox
+	TreeViewColumnAttributeActive = "active"
+	// TreeViewColumnAttributeVisible hides the cell when the boolean model
+	// value is FALSE
+	TreeViewColumnAttributeVisible = "visible"
+)
+
+// TreeViewColumn Hierarchy:
+//	Object
+//	  +- TreeViewColumn
+//
+// The TreeViewColumn object represents a visible column in a TreeView widget.
+// It allows to set properties of the column header, and functions as a
+// holding pen for the cell attributes which determine how the data of the
+// TreeModel is displayed within the column.
+type TreeViewColumn interface {
+	Object
+
+	Init() (already bool)
+	Build(builder Builder, element *CBuilderElement) error
+	AddAttribute(attribute string, column int)
+	SetAttributes(attributes map[string]int)
+	ClearAttributes()
+	GetAttributes() (attributes map[string]int)
+	SetCellDataFunc(fn TreeCellDataFunc)
+	GetCellText(model TreeModel, iter *TreeIter) (text string, markup, visible bool)
+	SetSpacing(spacing int)
+	GetSpacing() (value int)
+	SetVisible(visible bool)
+	GetVisible() (value bool)
+	SetResizable(resizable bool)
+	GetResizable() (value bool)
+	SetSizing(sizing enums.TreeViewColumnSizing)
+	GetSizing() (value enums.TreeViewColumnSizing)
+	GetWidth() (value int)
+	GetFixedWidth() (value int)
+	SetFixedWidth(fixedWidth int)
+	SetMinWidth(minWidth int)
+	GetMinWidth() (value int)
+	SetMaxWidth(maxWidth int)
+	GetMaxWidth() (value int)
+	Clicked()
+	SetTitle(title string)
+	GetTitle() (value string)
+	SetExpand(expand bool)
+	GetExpand() (value bool)
+	SetClickable(clickable bool)
+	GetClickable() (value bool)
+	SetAlignment(xAlign float64)
+	GetAlignment() (value float64)
+	SetReorderable(reorderable bool)
+	GetReorderable() (value bool)
+	SetSortColumnID(sortColumnId int)
+	GetSortColumnID() (value int)
+	SetSortIndicator(setting bool)
+	GetSortIndicator() (value bool)
+	SetSortOrder(order enums.SortType)
+	GetSortOrder() (value enums.SortType)
+	GetTreeView() (value TreeView)
+}
+
+var _ TreeViewColumn = (*CTreeViewColumn)(nil)
+
+// The CTreeViewColumn structure implements the TreeViewColumn interface and is
+// exported to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with TreeViewColumn objects.
+type CTreeViewColumn struct {
+	CObject
+
+	treeView   TreeView
+	attributes map[string]int
+	dataFunc   TreeCellDataFunc
+	width      int
+}
+
+// MakeTreeViewColumn is used by the Buildable system to construct a new
+// TreeViewColumn.
+func MakeTreeViewColumn() TreeViewColumn {
+	return NewTreeViewColumn()
+}
+
+// NewTreeViewColumn is the constructor for new TreeViewColumn instances.
+func NewTreeViewColumn() TreeViewColumn {
+	c := new(CTreeViewColumn)
+	c.Init()
+	return c
+}
+
+// NewTreeViewColumnWithAttributes is a convenience constructor for a new
+// TreeViewColumn with the given title and cell attributes. The attributes are
+// given as a list of attribute name and model column number pairs, for
+// example: "text", 0, "visible", 2.
+//
+// Parameters:
+// 	title	the header text of the column
+// 	attributes	pairs of attribute names and model column numbers
+func NewTreeViewColumnWithAttributes(title string, attributes ...interface{}) TreeViewColumn {
+	c := NewTreeViewColumn()
+	c.SetTitle(title)
+	for idx := 0; idx+1 < len(attributes); idx += 2 {
+		if name, ok := attributes[idx].(string); ok {
+			if column, ok := attributes[idx+1].(int); ok {
+				c.AddAttribute(name, column)
+				continue
+			}
+		}
+		c.LogError("invalid attribute pair: %v, %v", attributes[idx], attributes[idx+1])
+	}
+	return c
+}
+
+// Init initializes a TreeViewColumn object. This must be called at least once
+// to set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the TreeViewColumn instance. Init is used in the
+// NewTreeViewColumn constructor and only necessary when implementing a
+// derivative TreeViewColumn type.
+func (c *CTreeViewColumn) Init() (already bool) {
+	if c.InitTypeItem(TypeTreeViewColumn, c) {
+		return true
+	}
+	c.CObject.Init()
+	c.attributes = make(map[string]int)
+	c.width = 0
+	_ = c.InstallBuildableProperty(PropertyTitle, cdk.StringProperty, true, "")
+	_ = c.InstallBuildableProperty(PropertyVisible, cdk.BoolProperty, true, true)
+	_ = c.InstallBuildableProperty(PropertyResizable, cdk.BoolProperty, true, false)
+	_ = c.InstallBuildableProperty(PropertySizing, cdk.StructProperty, true, enums.TREE_VIEW_COLUMN_GROW_ONLY)
+	_ = c.InstallBuildableProperty(PropertyFixedWidth, cdk.IntProperty, true, 1)
+	_ = c.InstallBuildableProperty(PropertyMinWidth, cdk.IntProperty, true, -1)
+	_ = c.InstallBuildableProperty(PropertyMaxWidth, cdk.IntProperty, true, -1)
+	_ = c.InstallBuildableProperty(PropertySpacing, cdk.IntProperty, true, 0)
+	_ = c.InstallBuildableProperty(PropertyExpand, cdk.BoolProperty, true, false)
+	_ = c.InstallBuildableProperty(PropertyClickable, cdk.BoolProperty, true, false)
+	_ = c.InstallBuildableProperty(PropertyAlignment, cdk.FloatProperty, true, 0.0)
+	_ = c.InstallBuildableProperty(PropertyReorderable, cdk.BoolProperty, true, false)
+	_ = c.InstallBuildableProperty(PropertySortColumnId, cdk.IntProperty, true, -1)
+	_ = c.InstallBuildableProperty(PropertySortIndicator, cdk.BoolProperty, true, false)
+	_ = c.InstallBuildableProperty(PropertySortOrder, cdk.StructProperty, true, enums.SORT_ASCENDING)
+	return false
+}
+
+// Build provides customizations to the Buildable system for TreeViewColumn
+// objects. The <attributes> of each cell renderer child are added to the
+// column, the renderer objects themselves are not built.
+func (c *CTreeViewColumn) Build(builder Builder, element *CBuilderElement) error {
+	c.Freeze()
+	defer c.Thaw()
+	if name, ok := element.Attributes["id"]; ok {
+		c.SetName(name)
+	}
+	for k, v := range element.Properties {
+		switch cdk.Property(k) {
+		case PropertySizing:
+			switch strings.ToLower(strings.TrimPrefix(strings.ToLower(v), "gtk_tree_view_column_")) {
+			case "grow_only", "grow-only":
+				c.SetSizing(enums.TREE_VIEW_COLUMN_GROW_ONLY)
+			case "autosize":
+				c.SetSizing(enums.TREE_VIEW_COLUMN_AUTOSIZE)
+			case "fixed":
+				c.SetSizing(enums.TREE_VIEW_COLUMN_FIXED)
+			default:
+				c.LogError("invalid sizing value: %v", v)
+			}
+		case PropertySortOrder:
+			if strings.Contains(strings.ToLower(v), "descending") {
+				c.SetSortOrder(enums.SORT_DESCENDING)
+			} else {
+				c.SetSortOrder(enums.SORT_ASCENDING)
+			}
+		default:
+			element.ApplyProperty(k, v)
+		}
+	}
+	for _, renderer := range element.Children {
+		for _, custom := range renderer.Custom {
+			if custom.TagName != "attributes" {
+				continue
+			}
+			for _, attribute := range custom.Children {
+				name := attribute.Attributes["name"]
+				column, err := strconv.Atoi(strings.TrimSpace(attribute.Content))
+				if err != nil {
+					return fmt.Errorf("invalid %v attribute column: %v", name, err)
+				}
+				if name == TreeViewColumnAttributeText && strings.Contains(renderer.Attributes["class"], "Toggle") {
+					name = TreeViewColumnAttributeActive
+				}
+				c.AddAttribute(name, column)
+			}
+		}
+	}
+	element.ApplySignals()
+	return nil
+}
+
+// AddAttribute adds an attribute mapping to the list in the column. The column
+// is the column of the model to get a value from, and the attribute is the
+// name of the cell attribute to set: "text", "markup", "active" or "visible".
+//
+// Parameters:
+// 	attribute	an attribute on the cell
+// 	column	the column position on the model to get the attribute from
+func (c *CTreeViewColumn) AddAttribute(attribute string, column int) {
+	c.Lock()
+	c.attributes[attribute] = column
+	c.Unlock()
+	c.queueResize()
+}
+
+// SetAttributes sets the attributes in the list as the attributes of the
+// column, replacing any existing attributes.
+func (c *CTreeViewColumn) SetAttributes(attributes map[string]int) {
+	c.Lock()
+	c.attributes = make(map[string]int)
+	for k, v := range attributes {
+		c.attributes[k] = v
+	}
+	c.Unlock()
+	c.queueResize()
+}
+
+// ClearAttributes clears all existing attributes previously set.
+func (c *CTreeViewColumn) ClearAttributes() {
+	c.SetAttributes(nil)
+}
+
+// GetAttributes returns a copy of the attribute mappings of the column.
+func (c *CTreeViewColumn) GetAttributes() (attributes map[string]int) {
+	c.RLock()
+	defer c.RUnlock()
+	attributes = make(map[string]int)
+	for k, v := range c.attributes {
+		attributes[k] = v
+	}
+	return
+}
+
+// SetCellDataFunc sets the function to use for the column. This function is
+// used instead of the standard attributes mapping for setting the column
+// value, and should return the text to render for the given row.
+//
+// Parameters:
+// 	fn	the function to use, nil to remove any existing function
+func (c *CTreeViewColumn) SetCellDataFunc(fn TreeCellDataFunc) {
+	c.Lock()
+	c.dataFunc = fn
+	c.Unlock()
+	c.queueResize()
+}
+
+// GetCellText returns the text to render for the given row, whether the text is
+// markup and whether the cell is visible at all.
+func (c *CTreeViewColumn) GetCellText(model TreeModel, iter *TreeIter) (text string, markup, visible bool) {
+	c.RLock()
+	fn := c.dataFunc
+	attributes := c.attributes
+	c.RUnlock()
+	visible = true
+	if column, ok := attributes[TreeViewColumnAttributeVisible]; ok {
+		if v, ok := model.GetValue(iter, column).(bool); ok {
+			visible = v
+		}
+	}
+	if !visible {
+		return
+	}
+	if fn != nil {
+		text = fn(c, model, iter)
+		return
+	}
+	if column, ok := attributes[TreeViewColumnAttributeMarkup]; ok {
+		return TreeModelGetString(model, iter, column), true, true
+	}
+	if column, ok := attributes[TreeViewColumnAttributeText]; ok {
+		return TreeModelGetString(model, iter, column), false, true
+	}
+	if column, ok := attributes[TreeViewColumnAttributeActive]; ok {
+		if active, _ := model.GetValue(iter, column).(bool); active {
+			return "[x]", false, true
+		}
+		return "[ ]", false, true
+	}
+	return
+}
+
+// SetSpacing sets the number of blank cells to insert between the column
+// content and the next column.
+func (c *CTreeViewColumn) SetSpacing(spacing int) {
+	if err := c.SetIntProperty(PropertySpacing, spacing); err != nil {
+		c.LogErr(err)
+	} else {
+		c.queueResize()
+	}
+}
+
+// GetSpacing returns the spacing of the column.
+// See: SetSpacing()
+func (c *CTreeViewColumn) GetSpacing() (value int) {
+	var err error
+	if value, err = c.GetIntProperty(PropertySpacing); err != nil {
+		c.LogErr(err)
+	}
+	return
+}
+
+// SetVisible sets the visibility of the column.
+func (c *CTreeViewColumn) SetVisible(visible bool) {
+	if err := c.SetBoolProperty(PropertyVisible, visible); err != nil {
+		c.LogErr(err)
+	} else {
+		c.queueResize()
+	}
+}
+
+// GetVisible returns TRUE if the column is visible.
+// See: SetVisible()
+func (c *CTreeViewColumn) GetVisible() (value bool) {
+	var err error
+	if value, err = c.GetBoolProperty(PropertyVisible); err != nil {
+		c.LogErr(err)
+	}
+	return
+}
+
+// SetResizable sets whether the user may resize the column by dragging the
+// separator to the right of the column header with the mouse. This forces the
+// sizing of the column to TREE_VIEW_COLUMN_FIXED when resized.
+func (c *CTreeViewColumn) SetResizable(resizable bool) {
+	if err := c.SetBoolProperty(PropertyResizable, resizable); err != nil {
+		c.LogErr(err)
+	}
+}
+
+// GetResizable returns TRUE if the column can be resized by the end user.
+// See: SetResizable()
+func (c *CTreeViewColumn) GetResizable() (value bool) {
+	var err error
+	if value, err = c.GetBoolProperty(PropertyResizable); err != nil {
+		c.LogErr(err)
+	}
+	return
+}
+
+// SetSizing sets the growth behavior of the column.
+func (c *CTreeViewColumn) SetSizing(sizing enums.TreeViewColumnSizing) {
+	if err := c.SetStructProperty(PropertySizing, sizing); err != nil {
+		c.LogErr(err)
+	} else {
+		c.queueResize()
+	}
+}
+
+// GetSizing returns the current sizing type of the column.
+// See: SetSizing()
+func (c *CTreeViewColumn) GetSizing() (value enums.TreeViewColumnSizing) {
+	if v, err := c.GetStructProperty(PropertySizing); err != nil {
+		c.LogErr(err)
+	} else if sizing, ok := v.(enums.TreeViewColumnSizing); ok {
+		value = sizing
+	}
+	return
+}
+
+// GetWidth returns the current size of the column in cells, as allocated by
+// the TreeView.
+func (c *CTreeViewColumn) GetWidth() (value int) {
+	c.RLock()
+	defer c.RUnlock()
+	return c.width
+}
+
+// GetFixedWidth returns the fixed width of the column.
+// See: SetFixedWidth()
+func (c *CTreeViewColumn) GetFixedWidth() (value int) {
+	var err error
+	if value, err = c.GetIntProperty(PropertyFixedWidth); err != nil {
+		c.LogErr(err)
+	}
+	return
+}
+
+// SetFixedWidth sets the size of the column in cells. This is meaningful only
+// if the sizing type is TREE_VIEW_COLUMN_FIXED.
+func (c *CTreeViewColumn) SetFixedWidth(fixedWidth int) {
+	if fixedWidth < 1 {
+		fixedWidth = 1
+	}
+	if err := c.SetIntProperty(PropertyFixedWidth, fixedWidth); err != nil {
+		c.LogErr(err)
+	} else {
+		c.queueResize()
+	}
+}
+
+// SetMinWidth sets the minimum width of the column. If minWidth is -1, then
+// the minimum width is unset.
+func (c *CTreeViewColumn) SetMinWidth(minWidth int) {
+	if err := c.SetIntProperty(PropertyMinWidth, minWidth); err != nil {
+		c.LogErr(err)
+	} else {
+		c.queueResize()
+	}
+}
+
+// GetMinWidth returns the minimum width in cells of the column, or -1 if no
+// minimum width is set.
+func (c *CTreeViewColumn) GetMinWidth() (value int) {
+	var err error
+	if value, err = c.GetIntProperty(PropertyMinWidth); err != nil {
+		c.LogErr(err)
+	}
+	return
+}
+
+// SetMaxWidth sets the maximum width of the column. If maxWidth is -1, then
+// the maximum width is unset. Note, the column can actually be wider than max
+// width if it's the last column in a view, or an expanding column.
+func (c *CTreeViewColumn) SetMaxWidth(maxWidth int) {
+	if err := c.SetIntProperty(PropertyMaxWidth, maxWidth); err != nil {
+		c.LogErr(err)
+	} else {
+		c.queueResize()
+	}
+}
+
+// GetMaxWidth returns the maximum width in cells of the column, or -1 if no
+// maximum width is set.
+func (c *CTreeViewColumn) GetMaxWidth() (value int) {
+	var err error
+	if value, err = c.GetIntProperty(PropertyMaxWidth); err != nil {
+		c.LogErr(err)
+	}
+	return
+}
+
+// Clicked emits the clicked signal on the column. This function will only work
+// if the column is clickable.
+//
+// Emits: SignalClicked, Argv=[TreeViewColumn instance]
+func (c *CTreeViewColumn) Clicked() {
+	if c.GetClickable() {
+		c.Emit(SignalClicked, c)
+	}
+}
+
+// SetTitle sets the title of the column.
+func (c *CTreeViewColumn) SetTitle(title string) {
+	if err := c.SetStringProperty(PropertyTitle, title); err != nil {
+		c.LogErr(err)
+	} else {
+		c.queueResize()
+	}
+}
+
+// GetTitle returns the title of the column.
+// See: SetTitle()
+func (c *CTreeViewColumn) GetTitle() (value string) {
+	var err error
+	if value, err = c.GetStringProperty(PropertyTitle); err != nil {
+		c.LogErr(err)
+	}
+	return
+}
+
+// SetExpand sets the column to take available extra space. This space is
+// shared equally amongst all columns that have the expand set to TRUE. If no
+// column has this option set, then the last column gets all extra space.
+func (c *CTreeViewColumn) SetExpand(expand bool) {
+	if err := c.SetBoolProperty(PropertyExpand, expand); err != nil {
+		c.LogErr(err)
+	} else {
+		c.queueResize()
+	}
+}
+
+// GetExpand returns TRUE if the column expands to take any available space.
+// See: SetExpand()
+func (c *CTreeViewColumn) GetExpand() (value bool) {
+	var err error
+	if value, err = c.GetBoolProperty(PropertyExpand); err != nil {
+		c.LogErr(err)
+	}
+	return
+}
+
+// SetClickable sets the header to be active if clickable is TRUE. When the
+// header is active, then it can take keyboard focus, and can be clicked.
+func (c *CTreeViewColumn) SetClickable(clickable bool) {
+	if err := c.SetBoolProperty(PropertyClickable, clickable); err != nil {
+		c.LogErr(err)
+	} else {
+		c.queueResize()
+	}
+}
+
+// GetClickable returns TRUE if the user can click on the header for the
+// column.
+// See: SetClickable()
+func (c *CTreeViewColumn) GetClickable() (value bool) {
+	var err error
+	if value, err = c.GetBoolProperty(PropertyClickable); err != nil {
+		c.LogErr(err)
+	}
+	return
+}
+
+// SetAlignment sets the alignment of the title within the column header. The
+// alignment determines its location inside the button -- 0.0 for left, 0.5
+// for center, 1.0 for right.
+func (c *CTreeViewColumn) SetAlignment(xAlign float64) {
+	if err := c.SetFloatProperty(PropertyAlignment, xAlign); err != nil {
+		c.LogErr(err)
+	} else {
+		c.queueResize()
+	}
+}
+
+// GetAlignment returns the current x alignment of the column header title.
+// See: SetAlignment()
+func (c *CTreeViewColumn) GetAlignment() (value float64) {
+	var err error
+	if value, err = c.GetFloatProperty(PropertyAlignment); err != nil {
+		c.LogErr(err)
+	}
+	return
+}
+
+// SetReorderable sets whether the column can be moved by the end user by
+// dragging the column header with the mouse.
+func (c *CTreeViewColumn) SetReorderable(reorderable bool) {
+	if err := c.SetBoolProperty(PropertyReorderable, reorderable); err != nil {
+		c.LogErr(err)
+	}
+}
+
+// GetReorderable returns TRUE if the column can be reordered by the user.
+// See: SetReorderable()
+func (c *CTreeViewColumn) GetReorderable() (value bool) {
+	var err error
+	if value, err = c.GetBoolProperty(PropertyReorderable); err != nil {
+		c.LogErr(err)
+	}
+	return
+}
+
+// SetSortColumnID sets the logical sortColumnId that this column sorts on when
+// this column is selected for sorting. Doing so makes the column header
+// clickable.
+//
+// Parameters:
+// 	sortColumnId	the sortColumnId of the model to sort on, -1 to unset
+func (c *CTreeViewColumn) SetSortColumnID(sortColumnId int) {
+	if err := c.SetIntProperty(PropertySortColumnId, sortColumnId); err != nil {
+		c.LogErr(err)
+	}
+	if sortColumnId >= 0 {
+		c.SetClickable(true)
+	}
+}
+
+// GetSortColumnID returns the logical sortColumnId that the model sorts on
+// when this column is selected for sorting, or -1 if unset.
+// See: SetSortColumnID()
+func (c *CTreeViewColumn) GetSortColumnID() (value int) {
+	var err error
+	if value, err = c.GetIntProperty(PropertySortColumnId); err != nil {
+		c.LogErr(err)
+	}
+	return
+}
+
+// SetSortIndicator is a call to set the sort indicator of the column header,
+// an arrow in the direction of the sort order.
+func (c *CTreeViewColumn) SetSortIndicator(setting bool) {
+	if err := c.SetBoolProperty(PropertySortIndicator, setting); err != nil {
+		c.LogErr(err)
+	} else {
+		c.queueResize()
+	}
+}
+
+// GetSortIndicator returns TRUE if the sort indicator is shown.
+// See: SetSortIndicator()
+func (c *CTreeViewColumn) GetSortIndicator() (value bool) {
+	var err error
+	if value, err = c.GetBoolProperty(PropertySortIndicator); err != nil {
+		c.LogErr(err)
+	}
+	return
+}
+
+// SetSortOrder changes the appearance of the sort indicator. This does not
+// actually sort the model. Use SetSortColumnID if you want automatic sorting
+// support.
+func (c *CTreeViewColumn) SetSortOrder(order enums.SortType) {
+	if err := c.SetStructProperty(PropertySortOrder, order); err != nil {
+		c.LogErr(err)
+	} else {
+		c.queueResize()
+	}
+}
+
+// GetSortOrder returns the sort order of the column.
+// See: SetSortOrder()
+func (c *CTreeViewColumn) GetSortOrder() (value enums.SortType) {
+	if v, err := c.GetStructProperty(PropertySortOrder); err != nil {
+		c.LogErr(err)
+	} else if order, ok := v.(enums.SortType); ok {
+		value = order
+	}
+	return
+}
+
+// GetTreeView returns the TreeView wherein the column has been inserted, or
+// nil if the column is currently not inserted into any tree view.
+func (c *CTreeViewColumn) GetTreeView() (value TreeView) {
+	c.RLock()
+	defer c.RUnlock()
+	return c.treeView
+}
+
+func (c *CTreeViewColumn) setTreeView(treeView TreeView) {
+	c.Lock()
+	defer c.Unlock()
+	c.treeView = treeView
+}
+
+func (c *CTreeViewColumn) setWidth(width int) {
+	c.Lock()
+	defer c.Unlock()
+	c.width = width
+}
+
+func (c *CTreeViewColumn) queueResize() {
+	if treeView := c.GetTreeView(); treeView != nil {
+		treeView.queueResize()
+	}
+}
+
+// Number of blank cells between the column content and the next column.
+// Flags: Read / Write
+// Allowed values: >= 0
+// Default value: 0
+// const PropertySpacing cdk.Property = "spacing"
+
+// Resize mode of the column.
+// Flags: Read / Write
+// Default value: GTK_TREE_VIEW_COLUMN_GROW_ONLY
+const PropertySizing cdk.Property = "sizing"
+
+// Current fixed width of the column.
+// Flags: Read / Write
+// Allowed values: >= 1
+// Default value: 1
+const PropertyFixedWidth cdk.Property = "fixed-width"
+
+// Minimum allowed width of the column.
+// Flags: Read / Write
+// Allowed values: >= -1
+// Default value: -1
+const PropertyMinWidth cdk.Property = "min-width"
+
+// Maximum allowed width of the column.
+// Flags: Read / Write
+// Allowed values: >= -1
+// Default value: -1
+const PropertyMaxWidth cdk.Property = "max-width"
+
+// Column gets share of extra width allocated to the widget.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyExpand cdk.Property = "expand"
+
+// Whether the header can be clicked.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyClickable cdk.Property = "clickable"
+
+// X Alignment of the column header text or widget.
+// Flags: Read / Write
+// Allowed values: [0,1]
+// Default value: 0
+const PropertyAlignment cdk.Property = "alignment"
+
+// Whether the column can be reordered around the headers.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertyReorderable cdk.Property = "reorderable"
+
+// Logical sort column ID this column sorts on when selected for sorting.
+// Setting the sort column ID makes the column header clickable.
+// Flags: Read / Write
+// Allowed values: >= -1
+// Default value: -1
+const PropertySortColumnId cdk.Property = "sort-column-id"
+
+// Whether to show a sort indicator.
+// Flags: Read / Write
+// Default value: FALSE
+const PropertySortIndicator cdk.Property = "sort-indicator"
+
+// Sort direction the sort indicator should indicate.
+// Flags: Read / Write
+// Default value: GTK_SORT_ASCENDING
+const PropertySortOrder cdk.Property = "sort-order"
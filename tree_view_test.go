@@ -0,0 +1,279 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+	"github.com/go-curses/cdk/lib/ptypes"
+	"github.com/go-curses/cdk/memphis"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+func TestTreeView(t *testing.T) {
+	Convey("Testing TreeViews", t, func() {
+		Convey("TreePath", func() {
+			p, err := NewTreePathFromString("1:2:3")
+			So(err, ShouldBeNil)
+			So(p.String(), ShouldEqual, "1:2:3")
+			So(p.GetDepth(), ShouldEqual, 3)
+			_, err = NewTreePathFromString("1:a")
+			So(err, ShouldNotBeNil)
+			up, ok := p.Up()
+			So(ok, ShouldEqual, true)
+			So(up.String(), ShouldEqual, "1:2")
+			So(up.IsAncestor(p), ShouldEqual, true)
+			So(p.IsDescendant(up), ShouldEqual, true)
+			So(p.Next().String(), ShouldEqual, "1:2:4")
+			So(NewTreePathFromIndices(0, 9).Compare(NewTreePathFromIndices(1)), ShouldEqual, -1)
+			So(NewTreePathFromIndices(1).Compare(NewTreePathFromIndices(1, 0)), ShouldEqual, -1)
+		})
+
+		Convey("ListStore", func() {
+			l := &CListStore{}
+			So(l.Init(), ShouldEqual, false)
+			So(l.Init(), ShouldEqual, true)
+			ls := NewListStore(cdk.StringProperty, cdk.IntProperty)
+			So(ls.GetNColumns(), ShouldEqual, 2)
+			So(ls.GetFlags().Has(enums.TREE_MODEL_LIST_ONLY), ShouldEqual, true)
+			inserted := 0
+			ls.Connect(SignalRowInserted, "test-row-inserted", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				inserted += 1
+				return cenums.EVENT_PASS
+			})
+			for idx, name := range []string{"charlie", "alpha", "bravo"} {
+				_, err := ls.InsertWithValues(-1, []int{0, 1}, []interface{}{name, idx})
+				So(err, ShouldBeNil)
+			}
+			So(inserted, ShouldEqual, 3)
+			So(ls.IterNChildren(nil), ShouldEqual, 3)
+			iter, ok := ls.GetIterFromString("1")
+			So(ok, ShouldEqual, true)
+			So(ls.GetValue(iter, 0), ShouldEqual, "alpha")
+			So(ls.GetValue(iter, 1), ShouldEqual, 1)
+			So(ls.SetValue(iter, 1, "nope"), ShouldNotBeNil)
+			ls.SetSortColumnId(0, enums.SORT_ASCENDING)
+			first, _ := ls.GetIterFirst()
+			So(TreeModelGetString(ls, first, 0), ShouldEqual, "alpha")
+			So(ls.Remove(first), ShouldEqual, true)
+			So(ls.IterNChildren(nil), ShouldEqual, 2)
+			ls.Clear()
+			So(ls.IterNChildren(nil), ShouldEqual, 0)
+		})
+
+		Convey("TreeStore", func() {
+			ts := NewTreeStore(cdk.StringProperty)
+			parent := ts.Append(nil)
+			So(ts.SetValue(parent, 0, "parent"), ShouldBeNil)
+			child := ts.Append(parent)
+			So(ts.SetValue(child, 0, "child"), ShouldBeNil)
+			So(ts.IterHasChild(parent), ShouldEqual, true)
+			So(ts.GetPath(child).String(), ShouldEqual, "0:0")
+			So(ts.IterDepth(child), ShouldEqual, 1)
+			So(ts.IsAncestor(parent, child), ShouldEqual, true)
+			p, ok := ts.IterParent(child)
+			So(ok, ShouldEqual, true)
+			So(ts.GetPath(p).String(), ShouldEqual, "0")
+			So(ts.Remove(parent), ShouldEqual, false)
+			So(ts.IterNChildren(nil), ShouldEqual, 0)
+		})
+
+		Convey("Basics", func() {
+			tv := &CTreeView{}
+			So(tv.Init(), ShouldEqual, false)
+			So(tv.Init(), ShouldEqual, true)
+			ts := NewTreeStore(cdk.StringProperty)
+			for _, name := range []string{"one", "two", "three"} {
+				iter := ts.Append(nil)
+				So(ts.SetValue(iter, 0, name), ShouldBeNil)
+				for _, childName := range []string{"a", "b"} {
+					child := ts.Append(iter)
+					So(ts.SetValue(child, 0, name+"-"+childName), ShouldBeNil)
+				}
+			}
+			v := NewTreeViewWithModel(ts)
+			So(v.GetModel(), ShouldEqual, ts)
+			So(v.AppendColumn(NewTreeViewColumnWithAttributes("Name", TreeViewColumnAttributeText, 0)), ShouldEqual, 1)
+			So(v.GetColumn(0).GetTreeView(), ShouldEqual, v)
+			v.SetAllocation(ptypes.MakeRectangle(20, 10))
+			So(v.GetVisibleRows(), ShouldHaveLength, 3)
+			So(v.ExpandRow(NewTreePathFromIndices(1), false), ShouldEqual, true)
+			So(v.RowExpanded(NewTreePathFromIndices(1)), ShouldEqual, true)
+			So(v.GetVisibleRows(), ShouldHaveLength, 5)
+			So(v.CollapseRow(NewTreePathFromIndices(1)), ShouldEqual, true)
+			So(v.GetVisibleRows(), ShouldHaveLength, 3)
+			v.ExpandAll()
+			So(v.GetVisibleRows(), ShouldHaveLength, 9)
+			v.CollapseAll()
+			So(v.GetVisibleRows(), ShouldHaveLength, 3)
+
+			Convey("Cursor and Selection", func() {
+				selection := v.GetSelection()
+				So(selection.GetMode(), ShouldEqual, enums.SELECTION_SINGLE)
+				v.SetCursor(NewTreePathFromIndices(0), nil)
+				So(selection.PathIsSelected(NewTreePathFromIndices(0)), ShouldEqual, true)
+				v.ProcessEvent(cdk.NewEventKey(cdk.KeyDown, 0, cdk.ModNone))
+				cursor, _ := v.GetCursor()
+				So(cursor.String(), ShouldEqual, "1")
+				So(selection.CountSelectedRows(), ShouldEqual, 1)
+				v.ProcessEvent(cdk.NewEventKey(cdk.KeyRight, 0, cdk.ModNone))
+				So(v.RowExpanded(cursor), ShouldEqual, true)
+				v.ProcessEvent(cdk.NewEventKey(cdk.KeyRight, 0, cdk.ModNone))
+				cursor, _ = v.GetCursor()
+				So(cursor.String(), ShouldEqual, "1:0")
+				v.ProcessEvent(cdk.NewEventKey(cdk.KeyLeft, 0, cdk.ModNone))
+				cursor, _ = v.GetCursor()
+				So(cursor.String(), ShouldEqual, "1")
+				v.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 't', cdk.ModNone))
+				v.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, 'h', cdk.ModNone))
+				cursor, _ = v.GetCursor()
+				So(cursor.String(), ShouldEqual, "2")
+				activated := false
+				v.Connect(SignalRowActivated, "test-row-activated", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+					activated = true
+					return cenums.EVENT_PASS
+				})
+				v.ProcessEvent(cdk.NewEventKey(cdk.KeyRune, rune(cdk.KeyEnter), cdk.ModNone))
+				So(activated, ShouldEqual, true)
+
+				selection.SetMode(enums.SELECTION_MULTIPLE)
+				v.SetCursor(NewTreePathFromIndices(0), nil)
+				v.ProcessEvent(cdk.NewEventKey(cdk.KeyDown, 0, cdk.ModShift))
+				v.ProcessEvent(cdk.NewEventKey(cdk.KeyDown, 0, cdk.ModShift))
+				So(selection.CountSelectedRows(), ShouldEqual, 3)
+				selection.UnselectAll()
+				So(selection.CountSelectedRows(), ShouldEqual, 0)
+				selection.SelectAll()
+				So(selection.CountSelectedRows(), ShouldEqual, len(v.GetVisibleRows()))
+			})
+
+			Convey("Model Changes", func() {
+				v.ExpandRow(NewTreePathFromIndices(2), false)
+				v.SetCursor(NewTreePathFromIndices(2, 1), nil)
+				first, _ := ts.GetIterFirst()
+				So(ts.Remove(first), ShouldEqual, true)
+				So(v.RowExpanded(NewTreePathFromIndices(1)), ShouldEqual, true)
+				cursor, _ := v.GetCursor()
+				So(cursor.String(), ShouldEqual, "1:1")
+				So(v.GetSelection().PathIsSelected(cursor), ShouldEqual, true)
+			})
+		})
+
+		render := func(v TreeView, w, h int) (lines []string) {
+			v.Show()
+			v.SetOrigin(0, 0)
+			v.SetAllocation(ptypes.MakeRectangle(w, h))
+			v.Resize()
+			So(v.Draw(), ShouldEqual, cenums.EVENT_STOP)
+			surface, err := memphis.GetSurface(v.ObjectID())
+			So(err, ShouldBeNil)
+			for y := 0; y < h; y++ {
+				line := ""
+				for x := 0; x < w; x++ {
+					line += string(surface.GetContent(x, y).Value())
+				}
+				lines = append(lines, strings.TrimRight(line, " "))
+			}
+			return
+		}
+
+		Convey("Wheel Scrolling", func() {
+			v := NewTreeView()
+			render(v, 40, 10)
+			So(v.ProcessEvent(cdk.NewEventMouse(1, 1, cdk.WheelUp, cdk.ModNone)), ShouldEqual, cenums.EVENT_STOP)
+			So(v.GetVAdjustment().GetValue(), ShouldEqual, 0)
+			So(func() { render(v, 40, 10) }, ShouldNotPanic)
+
+			ls := NewListStore(cdk.StringProperty)
+			for _, name := range []string{"one", "two", "three"} {
+				So(ls.SetValue(ls.Append(), 0, name), ShouldBeNil)
+			}
+			v = NewTreeViewWithModel(ls)
+			v.AppendColumn(NewTreeViewColumnWithAttributes("Name", TreeViewColumnAttributeText, 0))
+			render(v, 40, 10)
+			v.ProcessEvent(cdk.NewEventMouse(1, 1, cdk.WheelUp, cdk.ModNone))
+			v.ProcessEvent(cdk.NewEventMouse(1, 1, cdk.WheelDown, cdk.ModNone))
+			So(v.GetVAdjustment().GetValue(), ShouldEqual, 0)
+			lines := render(v, 40, 10)
+			So(lines[:5], ShouldResemble, []string{"Name", strings.Repeat("─", 40), "one", "two", "three"})
+		})
+
+		Convey("Builder", func() {
+			builder := NewBuilder()
+			_, err := builder.LoadFromString(`<interface>
+  <object class="GtkListStore" id="test-list-store">
+    <columns>
+      <column type="gchararray"/>
+      <column type="gint"/>
+    </columns>
+    <data>
+      <row>
+        <col id="0">apples</col>
+        <col id="1">3</col>
+      </row>
+      <row>
+        <col id="0">pears</col>
+        <col id="1">12</col>
+      </row>
+    </data>
+  </object>
+  <object class="GtkTreeView" id="test-tree-view">
+    <property name="model">test-list-store</property>
+    <child>
+      <object class="GtkTreeViewColumn" id="test-name-column">
+        <property name="title">Fruit</property>
+        <child>
+          <object class="GtkCellRendererText" id="test-name-renderer"/>
+          <attributes>
+            <attribute name="text">0</attribute>
+          </attributes>
+        </child>
+      </object>
+    </child>
+    <child>
+      <object class="GtkTreeViewColumn" id="test-count-column">
+        <property name="title">Count</property>
+        <child>
+          <object class="GtkCellRendererText" id="test-count-renderer"/>
+          <attributes>
+            <attribute name="text">1</attribute>
+          </attributes>
+        </child>
+      </object>
+    </child>
+  </object>
+</interface>`)
+			So(err, ShouldBeNil)
+			v, ok := builder.GetWidget("test-tree-view").(TreeView)
+			So(ok, ShouldEqual, true)
+			ls, ok := v.GetModel().(ListStore)
+			So(ok, ShouldEqual, true)
+			So(ls.GetNColumns(), ShouldEqual, 2)
+			So(ls.IterNChildren(nil), ShouldEqual, 2)
+			iter, _ := ls.GetIterFirst()
+			So(ls.GetValue(iter, 0), ShouldEqual, "apples")
+			So(ls.GetValue(iter, 1), ShouldEqual, 3)
+			So(v.GetColumns(), ShouldHaveLength, 2)
+			So(v.GetColumn(0).GetTitle(), ShouldEqual, "Fruit")
+			lines := render(v, 20, 4)
+			So(lines, ShouldResemble, []string{"Fruit │Count", strings.Repeat("─", 20), "apples 3", "pears  12"})
+		})
+	})
+}
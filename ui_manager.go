@@ -0,0 +1,868 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"strings"
+
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const TypeUIManager cdk.CTypeTag = "ctk-ui-manager"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeUIManager, func() interface{} { return MakeUIManager() })
+}
+
+// UIManager Hierarchy:
+//
+//	Object
+//	  +- UIManager
+//
+// A UIManager constructs a user interface (menus and toolbars) from one or
+// more UI definitions, which reference actions from one or more action
+// groups. The UI definitions are XML descriptions in the same format used by
+// GTK, for example:
+//
+//	<ui>
+//	  <menubar name="MenuBar">
+//	    <menu action="FileMenu">
+//	      <menuitem action="Open"/>
+//	      <placeholder name="FileOps"/>
+//	      <separator/>
+//	      <menuitem action="Quit"/>
+//	    </menu>
+//	  </menubar>
+//	  <toolbar name="ToolBar">
+//	    <toolitem action="Open"/>
+//	  </toolbar>
+//	  <popup name="Popup">
+//	    <menuitem action="Quit"/>
+//	  </popup>
+//	  <accelerator action="Save"/>
+//	</ui>
+//
+// Each UI definition added is assigned a merge ID. Elements with the same
+// name and path are merged together, so that later definitions can extend
+// the menus and toolbars of earlier ones, typically by adding items within
+// placeholders. RemoveUi removes all elements added with a given merge ID and
+// the widgets are updated accordingly. The widgets are available through
+// GetWidget, using paths like "/MenuBar/FileMenu/Open", and GetToplevels.
+type UIManager interface {
+	Object
+
+	Init() (already bool)
+	InsertActionGroup(actionGroup ActionGroup, pos int)
+	RemoveActionGroup(actionGroup ActionGroup)
+	GetActionGroups() (value []ActionGroup)
+	GetAccelGroup() (value AccelGroup)
+	GetWidget(path string) (value Widget)
+	GetToplevels(types enums.UIManagerItemType) (toplevels []Widget)
+	GetAction(path string) (value Action)
+	AddUiFromString(buffer string) (mergeId int, err error)
+	AddUiFromFile(filename string) (mergeId int, err error)
+	NewMergeId() (mergeId int)
+	AddUi(mergeId int, path string, name string, action string, uiType enums.UIManagerItemType, top bool)
+	RemoveUi(mergeId int)
+	GetUi() (value string)
+	EnsureUpdate()
+}
+
+var _ UIManager = (*CUIManager)(nil)
+
+// The CUIManager structure implements the UIManager interface and is exported
+// to facilitate type embedding with custom implementations. No member
+// variables are exported as the interface methods are the only intended means
+// of interacting with UIManager objects.
+type CUIManager struct {
+	CObject
+
+	builder      Builder
+	actionGroups []ActionGroup
+	accelGroup   AccelGroup
+	root         *cUIManagerNode
+	lastMergeId  int
+	dirty        bool
+}
+
+// cUIManagerNode is an element of the merged UI definition tree.
+type cUIManagerNode struct {
+	name     string
+	kind     enums.UIManagerItemType
+	action   string
+	parent   *cUIManagerNode
+	children []*cUIManagerNode
+	uis      []int
+	widget   Widget
+	proxy    Action
+	accel    bool
+}
+
+// MakeUIManager is used by the Buildable system to construct a new UIManager.
+func MakeUIManager() UIManager {
+	return NewUIManager()
+}
+
+// NewUIManager is the constructor for new UIManager instances.
+func NewUIManager() UIManager {
+	m := new(CUIManager)
+	m.Init()
+	return m
+}
+
+// Init initializes a UIManager object. This must be called at least once to
+// set up the necessary defaults and allocate any memory structures. Calling
+// this more than once is safe though unnecessary. Only the first call will
+// result in any effect upon the UIManager instance. Init is used in the
+// NewUIManager constructor and only necessary when implementing a derivative
+// UIManager type.
+func (m *CUIManager) Init() (already bool) {
+	if m.InitTypeItem(TypeUIManager, m) {
+		return true
+	}
+	m.CObject.Init()
+	m.builder = NewBuilder()
+	m.actionGroups = make([]ActionGroup, 0)
+	m.accelGroup = NewAccelGroup()
+	m.root = &cUIManagerNode{name: "ui", kind: enums.UI_MANAGER_AUTO}
+	m.lastMergeId = 0
+	m.dirty = false
+	return false
+}
+
+// InsertActionGroup inserts an action group into the list of action groups
+// associated with the UIManager. Actions in earlier groups hide actions with
+// the same name in later groups.
+//
+// Parameters:
+//
+//	actionGroup	the action group to be inserted
+//	pos	the position at which the group will be inserted, or -1 to append
+func (m *CUIManager) InsertActionGroup(actionGroup ActionGroup, pos int) {
+	m.Lock()
+	for _, known := range m.actionGroups {
+		if known.ObjectID() == actionGroup.ObjectID() {
+			m.Unlock()
+			m.LogError("action group already inserted: %v", actionGroup.GetName())
+			return
+		}
+	}
+	if pos < 0 || pos >= len(m.actionGroups) {
+		m.actionGroups = append(m.actionGroups, actionGroup)
+	} else {
+		m.actionGroups = append(m.actionGroups[:pos+1], m.actionGroups[pos:]...)
+		m.actionGroups[pos] = actionGroup
+	}
+	m.dirty = true
+	m.Unlock()
+	m.connectActionGroup(actionGroup)
+	m.Emit(SignalActionsChanged, m)
+	m.EnsureUpdate()
+}
+
+// RemoveActionGroup removes an action group from the list of action groups
+// associated with the UIManager.
+//
+// Parameters:
+//
+//	actionGroup	the action group to be removed
+func (m *CUIManager) RemoveActionGroup(actionGroup ActionGroup) {
+	removed := false
+	m.Lock()
+	for idx, known := range m.actionGroups {
+		if known.ObjectID() == actionGroup.ObjectID() {
+			m.actionGroups = append(m.actionGroups[:idx], m.actionGroups[idx+1:]...)
+			m.dirty = true
+			removed = true
+			break
+		}
+	}
+	m.Unlock()
+	if !removed {
+		m.LogError("action group not found: %v", actionGroup.GetName())
+		return
+	}
+	m.disconnectActionGroup(actionGroup)
+	m.Emit(SignalActionsChanged, m)
+	m.EnsureUpdate()
+}
+
+// GetActionGroups returns the list of action groups associated with the
+// UIManager.
+func (m *CUIManager) GetActionGroups() (value []ActionGroup) {
+	m.RLock()
+	defer m.RUnlock()
+	value = append(value, m.actionGroups...)
+	return
+}
+
+// GetAccelGroup returns the AccelGroup associated with the UIManager. Add it
+// to the Window with Window.AddAccelGroup to enable the accelerators of the
+// menu items and accelerator elements.
+func (m *CUIManager) GetAccelGroup() (value AccelGroup) {
+	m.RLock()
+	defer m.RUnlock()
+	return m.accelGroup
+}
+
+// GetWidget looks up a widget by following a path. The path consists of the
+// names specified in the XML description of the UI separated by '/'. Elements
+// which don't have a name or action attribute in the XML (e.g. <popup>) can
+// be addressed by their XML element name (e.g. "popup"). The root element
+// ("/ui") can be omitted in the path. Note that the widget found by following
+// a path that ends in a <menu> element is the menu item to which the menu is
+// attached, not the menu itself.
+//
+// Parameters:
+//
+//	path	a path
+func (m *CUIManager) GetWidget(path string) (value Widget) {
+	m.EnsureUpdate()
+	if node := m.getNode(path); node != nil {
+		value = node.widget
+	}
+	return
+}
+
+// GetToplevels obtains a list of all toplevel widgets of the requested types.
+//
+// Parameters:
+//
+//	types	specifies the types of toplevel widgets to include. Allowed
+//	        types are UI_MANAGER_MENUBAR, UI_MANAGER_TOOLBAR and
+//	        UI_MANAGER_POPUP.
+func (m *CUIManager) GetToplevels(types enums.UIManagerItemType) (toplevels []Widget) {
+	m.EnsureUpdate()
+	for _, node := range m.root.children {
+		if node.kind&types != 0 && node.widget != nil {
+			toplevels = append(toplevels, node.widget)
+		}
+	}
+	return
+}
+
+// GetAction looks up an action by following a path. See GetWidget for more
+// information about paths.
+//
+// Parameters:
+//
+//	path	a path
+func (m *CUIManager) GetAction(path string) (value Action) {
+	if node := m.getNode(path); node != nil && node.action != "" {
+		value = m.lookupAction(node.action)
+	}
+	return
+}
+
+// AddUiFromString parses a string containing a UI definition and merges it
+// with the current contents of the UIManager. An enclosing <ui> element is
+// required.
+//
+// Parameters:
+//
+//	buffer	the string to parse
+//
+// Returns:
+//
+//	the merge ID for the merged UI, which can be used to unmerge the UI
+//	with RemoveUi, or 0 if an error occurred
+func (m *CUIManager) AddUiFromString(buffer string) (mergeId int, err error) {
+	var top *CBuilderElement
+	if top, err = m.builder.ParseString(buffer); err != nil {
+		return 0, err
+	}
+	if top.TagName != "ui" {
+		return 0, fmt.Errorf("expected <ui> root element, found <%v>", top.TagName)
+	}
+	mergeId = m.NewMergeId()
+	m.Lock()
+	err = m.mergeElements(m.root, m.root, top, mergeId)
+	m.dirty = true
+	m.Unlock()
+	if err != nil {
+		m.RemoveUi(mergeId)
+		return 0, err
+	}
+	m.EnsureUpdate()
+	return
+}
+
+// AddUiFromFile parses a file containing a UI definition and merges it with
+// the current contents of the UIManager.
+//
+// Parameters:
+//
+//	filename	the name of the file to parse
+func (m *CUIManager) AddUiFromFile(filename string) (mergeId int, err error) {
+	var data []byte
+	if data, err = os.ReadFile(filename); err != nil {
+		return 0, err
+	}
+	return m.AddUiFromString(string(data))
+}
+
+// NewMergeId returns an unused merge ID, suitable for use with AddUi.
+func (m *CUIManager) NewMergeId() (mergeId int) {
+	m.Lock()
+	defer m.Unlock()
+	m.lastMergeId += 1
+	return m.lastMergeId
+}
+
+// AddUi adds a UI element to the current contents of the UIManager. If
+// uiType is UI_MANAGER_AUTO, the type is inferred from the parent at path:
+// menu items within menus and tool items within toolbars. Separators are
+// added if action is empty and uiType is UI_MANAGER_AUTO.
+//
+// Parameters:
+//
+//	mergeId	the merge id for the merged UI, see NewMergeId
+//	path	a path
+//	name	the name for the added UI element
+//	action	the name of the action to be proxied, or "" to add a separator
+//	uiType	the type of UI element to add
+//	top	if TRUE, the UI element is added before its siblings, otherwise it
+//	    is added after its siblings
+func (m *CUIManager) AddUi(mergeId int, path string, name string, action string, uiType enums.UIManagerItemType, top bool) {
+	parent := m.getNode(path)
+	if parent == nil {
+		m.LogError("path not found: %v", path)
+		return
+	}
+	container := uiManagerContainerKind(parent)
+	if uiType == enums.UI_MANAGER_AUTO {
+		switch {
+		case action == "":
+			uiType = enums.UI_MANAGER_SEPARATOR
+		case container == enums.UI_MANAGER_TOOLBAR:
+			uiType = enums.UI_MANAGER_TOOLITEM
+		case container == enums.UI_MANAGER_AUTO:
+			uiType = enums.UI_MANAGER_ACCELERATOR
+		default:
+			uiType = enums.UI_MANAGER_MENUITEM
+		}
+	}
+	if !uiManagerKindAllowed(container, uiType) {
+		m.LogError("ui element type %v not allowed within %v", uiType, path)
+		return
+	}
+	if name == "" {
+		name = action
+	}
+	m.Lock()
+	m.mergeNode(parent, name, uiType, action, top, mergeId)
+	m.dirty = true
+	m.Unlock()
+	m.EnsureUpdate()
+}
+
+// RemoveUi unmerges the part of the UIManager content identified by mergeId.
+//
+// Parameters:
+//
+//	mergeId	a merge id as returned by AddUiFromString
+func (m *CUIManager) RemoveUi(mergeId int) {
+	m.Lock()
+	removed := m.removeMergeId(m.root, mergeId)
+	m.dirty = true
+	m.Unlock()
+	for _, node := range removed {
+		m.releaseNode(node)
+		if node.parent == m.root && node.widget != nil {
+			if parent := node.widget.GetParent(); parent != nil {
+				if container, ok := parent.Self().(Container); ok {
+					container.Remove(node.widget)
+				}
+			}
+		}
+	}
+	m.EnsureUpdate()
+}
+
+// GetUi creates a UI definition of the merged UI.
+func (m *CUIManager) GetUi() (value string) {
+	m.RLock()
+	defer m.RUnlock()
+	var sb strings.Builder
+	uiManagerWriteNode(&sb, m.root, 0)
+	return sb.String()
+}
+
+// EnsureUpdate makes sure that all pending updates to the UI have been
+// completed. The UIManager updates the widgets whenever the UI definitions or
+// the action groups change, so there should be no need to call this directly.
+func (m *CUIManager) EnsureUpdate() {
+	m.Lock()
+	dirty := m.dirty
+	m.dirty = false
+	m.Unlock()
+	if !dirty {
+		return
+	}
+	for _, node := range m.root.children {
+		m.updateToplevel(node)
+	}
+}
+
+func (m *CUIManager) connectActionGroup(group ActionGroup) {
+	for _, signal := range []cdk.Signal{SignalPreActivate, SignalPostActivate, SignalConnectProxy, SignalDisconnectProxy} {
+		signal := signal
+		group.Connect(signal, fmt.Sprintf("%v-%v", UIManagerActionGroupHandle, m.ObjectID()), func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+			if len(argv) > 1 {
+				return m.Emit(signal, append([]interface{}{m}, argv[1:]...)...)
+			}
+			return cenums.EVENT_PASS
+		})
+	}
+}
+
+func (m *CUIManager) disconnectActionGroup(group ActionGroup) {
+	for _, signal := range []cdk.Signal{SignalPreActivate, SignalPostActivate, SignalConnectProxy, SignalDisconnectProxy} {
+		_ = group.Disconnect(signal, fmt.Sprintf("%v-%v", UIManagerActionGroupHandle, m.ObjectID()))
+	}
+}
+
+// lookupAction returns the first action with the given name found in the
+// action groups of the UIManager.
+func (m *CUIManager) lookupAction(name string) (action Action) {
+	for _, group := range m.GetActionGroups() {
+		if action = group.GetAction(name); action != nil {
+			return
+		}
+	}
+	return nil
+}
+
+// getNode follows the given path from the root of the UI definition tree.
+// Placeholders may be omitted from the path.
+func (m *CUIManager) getNode(path string) (node *cUIManagerNode) {
+	m.RLock()
+	defer m.RUnlock()
+	node = m.root
+	for idx, name := range strings.Split(strings.Trim(path, "/"), "/") {
+		if name == "" || (idx == 0 && name == "ui") {
+			continue
+		}
+		if node = uiManagerFindChild(node, name, true); node == nil {
+			return nil
+		}
+	}
+	return
+}
+
+// uiManagerFindChild returns the named child of the node, optionally
+// descending into placeholders.
+func uiManagerFindChild(node *cUIManagerNode, name string, placeholders bool) *cUIManagerNode {
+	for _, child := range node.children {
+		if child.name == name {
+			return child
+		}
+	}
+	if placeholders {
+		for _, child := range node.children {
+			if child.kind == enums.UI_MANAGER_PLACEHOLDER {
+				if found := uiManagerFindChild(child, name, true); found != nil {
+					return found
+				}
+			}
+		}
+	}
+	return nil
+}
+
+var uiManagerElementKinds = map[string]enums.UIManagerItemType{
+	"menubar":     enums.UI_MANAGER_MENUBAR,
+	"menu":        enums.UI_MANAGER_MENU,
+	"toolbar":     enums.UI_MANAGER_TOOLBAR,
+	"placeholder": enums.UI_MANAGER_PLACEHOLDER,
+	"popup":       enums.UI_MANAGER_POPUP,
+	"menuitem":    enums.UI_MANAGER_MENUITEM,
+	"toolitem":    enums.UI_MANAGER_TOOLITEM,
+	"separator":   enums.UI_MANAGER_SEPARATOR,
+	"accelerator": enums.UI_MANAGER_ACCELERATOR,
+}
+
+// uiManagerElementName returns the XML element name of the given kind.
+func uiManagerElementName(kind enums.UIManagerItemType) string {
+	for name, known := range uiManagerElementKinds {
+		if known == kind {
+			return name
+		}
+	}
+	return "ui"
+}
+
+// uiManagerContainerKind returns the kind of the nearest ancestor (or the node
+// itself) which is not a placeholder.
+func uiManagerContainerKind(node *cUIManagerNode) enums.UIManagerItemType {
+	for node != nil && node.kind == enums.UI_MANAGER_PLACEHOLDER {
+		node = node.parent
+	}
+	if node == nil {
+		return enums.UI_MANAGER_AUTO
+	}
+	if node.kind == enums.UI_MANAGER_POPUP || node.kind == enums.UI_MANAGER_MENUBAR {
+		return enums.UI_MANAGER_MENU
+	}
+	return node.kind
+}
+
+// uiManagerKindAllowed returns TRUE if an element of the given kind may be a
+// child of an element of the container kind.
+func uiManagerKindAllowed(container, kind enums.UIManagerItemType) bool {
+	switch container {
+	case enums.UI_MANAGER_AUTO:
+		switch kind {
+		case enums.UI_MANAGER_MENUBAR, enums.UI_MANAGER_TOOLBAR, enums.UI_MANAGER_POPUP, enums.UI_MANAGER_ACCELERATOR:
+			return true
+		}
+	case enums.UI_MANAGER_MENU:
+		switch kind {
+		case enums.UI_MANAGER_MENU, enums.UI_MANAGER_MENUITEM, enums.UI_MANAGER_SEPARATOR, enums.UI_MANAGER_PLACEHOLDER:
+			return true
+		}
+	case enums.UI_MANAGER_TOOLBAR:
+		switch kind {
+		case enums.UI_MANAGER_TOOLITEM, enums.UI_MANAGER_SEPARATOR, enums.UI_MANAGER_PLACEHOLDER:
+			return true
+		}
+	}
+	return false
+}
+
+// mergeElements merges the children of the given element into the node.
+func (m *CUIManager) mergeElements(parent, container *cUIManagerNode, element *CBuilderElement, mergeId int) error {
+	for _, child := range element.Children {
+		kind, ok := uiManagerElementKinds[child.TagName]
+		if !ok {
+			return fmt.Errorf("unexpected element <%v>", child.TagName)
+		}
+		if !uiManagerKindAllowed(uiManagerContainerKind(parent), kind) {
+			return fmt.Errorf("element <%v> not allowed within <%v>", child.TagName, uiManagerElementName(parent.kind))
+		}
+		action := child.Attributes["action"]
+		name := child.Attributes["name"]
+		if name == "" {
+			name = action
+		}
+		if name == "" && kind != enums.UI_MANAGER_SEPARATOR {
+			name = child.TagName
+		}
+		switch kind {
+		case enums.UI_MANAGER_MENUITEM, enums.UI_MANAGER_TOOLITEM, enums.UI_MANAGER_ACCELERATOR:
+			if action == "" {
+				return fmt.Errorf("element <%v> requires an action attribute", child.TagName)
+			}
+		}
+		node := m.mergeNode(parent, name, kind, action, child.Attributes["position"] == "top", mergeId)
+		if node == nil {
+			return fmt.Errorf("element <%v> named %q conflicts with an existing element", child.TagName, name)
+		}
+		switch kind {
+		case enums.UI_MANAGER_MENUBAR, enums.UI_MANAGER_MENU, enums.UI_MANAGER_TOOLBAR, enums.UI_MANAGER_PLACEHOLDER, enums.UI_MANAGER_POPUP:
+			if err := m.mergeElements(node, container, child, mergeId); err != nil {
+				return err
+			}
+		default:
+			if len(child.Children) > 0 {
+				return fmt.Errorf("element <%v> cannot have children", child.TagName)
+			}
+		}
+	}
+	return nil
+}
+
+// mergeNode finds or creates the named child of the parent node and records
+// the merge id on it. Unnamed separators are always created.
+func (m *CUIManager) mergeNode(parent *cUIManagerNode, name string, kind enums.UIManagerItemType, action string, top bool, mergeId int) (node *cUIManagerNode) {
+	if name != "" {
+		if node = uiManagerFindChild(parent, name, false); node != nil && node.kind != kind {
+			return nil
+		}
+	}
+	if node == nil {
+		node = &cUIManagerNode{name: name, kind: kind, parent: parent}
+		if top {
+			parent.children = append([]*cUIManagerNode{node}, parent.children...)
+		} else {
+			parent.children = append(parent.children, node)
+		}
+	}
+	if action != "" {
+		node.action = action
+	}
+	node.uis = append(node.uis, mergeId)
+	return
+}
+
+// removeMergeId removes the merge id from all nodes, returning the nodes which
+// are no longer referenced by any merge id after removing them from the tree.
+func (m *CUIManager) removeMergeId(node *cUIManagerNode, mergeId int) (removed []*cUIManagerNode) {
+	var children []*cUIManagerNode
+	for _, child := range node.children {
+		for idx, id := range child.uis {
+			if id == mergeId {
+				child.uis = append(child.uis[:idx], child.uis[idx+1:]...)
+				break
+			}
+		}
+		if len(child.uis) == 0 {
+			removed = append(removed, child)
+			continue
+		}
+		removed = append(removed, m.removeMergeId(child, mergeId)...)
+		children = append(children, child)
+	}
+	node.children = children
+	return
+}
+
+// releaseNode disconnects the proxies and accelerators of the node and all of
+// its children.
+func (m *CUIManager) releaseNode(node *cUIManagerNode) {
+	for _, child := range node.children {
+		m.releaseNode(child)
+	}
+	if node.proxy != nil {
+		if node.accel {
+			node.proxy.DisconnectAccelerator()
+			node.accel = false
+		}
+		if node.widget != nil {
+			if disconnect, ok := node.proxy.(actionProxyDisconnect); ok {
+				disconnect.disconnectProxy(node.widget)
+			}
+		}
+		node.proxy = nil
+	}
+	switch node.kind {
+	case enums.UI_MANAGER_MENUBAR, enums.UI_MANAGER_TOOLBAR, enums.UI_MANAGER_POPUP:
+	default:
+		node.widget = nil
+	}
+}
+
+// connectAccelerator installs the accelerator of the action in the AccelGroup
+// of the UIManager, recording this on the node for releaseNode.
+func (m *CUIManager) connectAccelerator(node *cUIManagerNode, action Action) {
+	if action.GetAccelPath() == "" {
+		return
+	}
+	action.SetAccelGroup(m.GetAccelGroup())
+	action.ConnectAccelerator()
+	node.accel = true
+}
+
+// updateToplevel rebuilds the widgets of a toplevel node. The toplevel
+// widgets are kept across updates, so that they can remain packed within the
+// application, while the items within them are recreated.
+func (m *CUIManager) updateToplevel(node *cUIManagerNode) {
+	for _, child := range node.children {
+		m.releaseNode(child)
+	}
+	switch node.kind {
+	case enums.UI_MANAGER_MENUBAR, enums.UI_MANAGER_POPUP:
+		var shell MenuShell
+		if node.widget == nil {
+			if node.kind == enums.UI_MANAGER_MENUBAR {
+				shell = NewMenuBar()
+			} else {
+				shell = NewMenu()
+			}
+			shell.Show()
+			node.widget = shell
+			m.Emit(SignalAddWidget, m, shell)
+		} else {
+			shell, _ = node.widget.Self().(MenuShell)
+			for _, item := range shell.GetItems() {
+				shell.Remove(item)
+			}
+		}
+		m.fillMenuShell(shell, node)
+		uiManagerTidySeparators(shell.GetChildren())
+		shell.Resize()
+	case enums.UI_MANAGER_TOOLBAR:
+		var toolbar Toolbar
+		if node.widget == nil {
+			toolbar = NewToolbar()
+			toolbar.Show()
+			node.widget = toolbar
+			m.Emit(SignalAddWidget, m, toolbar)
+		} else {
+			toolbar, _ = node.widget.Self().(Toolbar)
+			for _, item := range toolbar.GetItems() {
+				toolbar.Remove(item)
+			}
+		}
+		m.fillToolbar(toolbar, node)
+		uiManagerTidySeparators(toolbar.GetChildren())
+		toolbar.Resize()
+	case enums.UI_MANAGER_ACCELERATOR:
+		if action := m.lookupAction(node.action); action != nil {
+			node.proxy = action
+			m.connectAccelerator(node, action)
+		} else {
+			m.LogError("accelerator action not found: %v", node.action)
+		}
+	}
+}
+
+func (m *CUIManager) fillMenuShell(shell MenuShell, node *cUIManagerNode) {
+	for _, child := range node.children {
+		switch child.kind {
+		case enums.UI_MANAGER_PLACEHOLDER:
+			m.fillMenuShell(shell, child)
+		case enums.UI_MANAGER_SEPARATOR:
+			separator := NewSeparatorMenuItem()
+			separator.Show()
+			shell.Append(separator)
+			child.widget = separator
+		case enums.UI_MANAGER_MENUITEM:
+			action := m.lookupAction(child.action)
+			if action == nil {
+				m.LogError("menu item action not found: %v", child.action)
+				continue
+			}
+			if item, ok := action.CreateMenuItem().(MenuItem); ok {
+				shell.Append(item)
+				child.widget = item
+				child.proxy = action
+				m.connectAccelerator(child, action)
+			}
+		case enums.UI_MANAGER_MENU:
+			var item MenuItem
+			if child.action != "" {
+				action := m.lookupAction(child.action)
+				if action == nil {
+					m.LogError("menu action not found: %v", child.action)
+					continue
+				}
+				if item, _ = action.CreateMenuItem().(MenuItem); item == nil {
+					continue
+				}
+				child.proxy = action
+			} else {
+				item = NewMenuItemWithMnemonic(child.name)
+				item.Show()
+			}
+			submenu := NewMenu()
+			item.SetSubmenu(submenu)
+			m.fillMenuShell(submenu, child)
+			uiManagerTidySeparators(submenu.GetChildren())
+			shell.Append(item)
+			child.widget = item
+		}
+	}
+}
+
+func (m *CUIManager) fillToolbar(toolbar Toolbar, node *cUIManagerNode) {
+	for _, child := range node.children {
+		switch child.kind {
+		case enums.UI_MANAGER_PLACEHOLDER:
+			m.fillToolbar(toolbar, child)
+		case enums.UI_MANAGER_SEPARATOR:
+			separator := NewSeparatorToolItem()
+			separator.Show()
+			toolbar.Insert(separator, -1)
+			child.widget = separator
+		case enums.UI_MANAGER_TOOLITEM:
+			action := m.lookupAction(child.action)
+			if action == nil {
+				m.LogError("tool item action not found: %v", child.action)
+				continue
+			}
+			if item, ok := action.CreateToolItem().(ToolItem); ok {
+				toolbar.Insert(item, -1)
+				child.widget = item
+				child.proxy = action
+			}
+		}
+	}
+}
+
+// uiManagerTidySeparators hides separators at the start and end of the list
+// of widgets as well as separators following other separators, only taking
+// visible widgets into account.
+func uiManagerTidySeparators(widgets []Widget) {
+	isSeparator := func(w Widget) bool {
+		switch w.Self().(type) {
+		case *CSeparatorMenuItem, *CSeparatorToolItem:
+			return true
+		}
+		return false
+	}
+	var pending Widget
+	seenItem := false
+	for _, w := range widgets {
+		if isSeparator(w) {
+			w.Hide()
+			if seenItem && pending == nil {
+				pending = w
+			}
+			continue
+		}
+		if !w.IsVisible() {
+			continue
+		}
+		if pending != nil {
+			pending.Show()
+			pending = nil
+		}
+		seenItem = true
+	}
+}
+
+// uiManagerWriteNode writes the XML description of the node and its children.
+func uiManagerWriteNode(sb *strings.Builder, node *cUIManagerNode, depth int) {
+	pad := strings.Repeat("  ", depth)
+	tag := uiManagerElementName(node.kind)
+	sb.WriteString(pad + "<" + tag)
+	if node.kind != enums.UI_MANAGER_AUTO {
+		if node.name != "" {
+			sb.WriteString(fmt.Sprintf(` name="%v"`, html.EscapeString(node.name)))
+		}
+		if node.action != "" {
+			sb.WriteString(fmt.Sprintf(` action="%v"`, html.EscapeString(node.action)))
+		}
+	}
+	if len(node.children) == 0 {
+		sb.WriteString("/>\n")
+		return
+	}
+	sb.WriteString(">\n")
+	for _, child := range node.children {
+		uiManagerWriteNode(sb, child, depth+1)
+	}
+	sb.WriteString(pad + "</" + tag + ">\n")
+}
+
+// The "actions-changed" signal is emitted whenever the set of actions
+// changes.
+const SignalActionsChanged cdk.Signal = "actions-changed"
+
+// The "add-widget" signal is emitted for each generated menubar, popup menu
+// and toolbar. It is not emitted for generated menu items or tool items.
+// Listener function arguments:
+//
+//	widget Widget	the added widget
+const SignalAddWidget cdk.Signal = "add-widget"
+
+const UIManagerActionGroupHandle = "ui-manager-action-group-handler"
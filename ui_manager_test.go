@@ -0,0 +1,157 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"testing"
+
+	cenums "github.com/go-curses/cdk/lib/enums"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/go-curses/ctk/lib/enums"
+)
+
+const testUIManagerXML = `<ui>
+  <menubar name="MenuBar">
+    <menu action="FileMenu">
+      <menuitem action="Open"/>
+      <placeholder name="FileOps"/>
+      <separator/>
+      <menuitem action="Quit"/>
+    </menu>
+  </menubar>
+  <toolbar name="ToolBar">
+    <toolitem action="Open"/>
+    <separator/>
+    <toolitem action="Quit"/>
+  </toolbar>
+  <popup name="Popup">
+    <menuitem action="Quit"/>
+  </popup>
+</ui>`
+
+const testUIManagerMergeXML = `<ui>
+  <menubar name="MenuBar">
+    <menu action="FileMenu">
+      <placeholder name="FileOps">
+        <menuitem action="Save"/>
+      </placeholder>
+    </menu>
+  </menubar>
+</ui>`
+
+func newTestUIManager() (UIManager, ActionGroup) {
+	group := NewActionGroup("main")
+	group.AddActions([]ActionEntry{
+		{Name: "FileMenu", Label: "_File"},
+		{Name: "Open", Label: "_Open"},
+		{Name: "Save", Label: "_Save"},
+		{Name: "Quit", Label: "_Quit"},
+	}, -1, nil)
+	manager := NewUIManager()
+	manager.InsertActionGroup(group, 0)
+	return manager, group
+}
+
+func TestUIManager(t *testing.T) {
+	Convey("Testing UI Managers", t, func() {
+		Convey("Basics", func() {
+			m := &CUIManager{}
+			So(m.Init(), ShouldEqual, false)
+			So(m.Init(), ShouldEqual, true)
+			manager, group := newTestUIManager()
+			So(manager.GetActionGroups(), ShouldHaveLength, 1)
+			So(manager.GetAccelGroup(), ShouldNotBeNil)
+			_, err := manager.AddUiFromString(`<interface/>`)
+			So(err, ShouldNotBeNil)
+			_, err = manager.AddUiFromString(`<ui><toolbar><menu action="Open"/></toolbar></ui>`)
+			So(err, ShouldNotBeNil)
+			So(manager.GetToplevels(enums.UI_MANAGER_TOOLBAR), ShouldBeEmpty)
+			manager.RemoveActionGroup(group)
+			So(manager.GetActionGroups(), ShouldHaveLength, 0)
+		})
+		Convey("Building", func() {
+			manager, group := newTestUIManager()
+			var added []interface{}
+			manager.Connect(SignalAddWidget, "test-add-widget", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				added = append(added, argv[1])
+				return cenums.EVENT_PASS
+			})
+			mergeId, err := manager.AddUiFromString(testUIManagerXML)
+			So(err, ShouldBeNil)
+			So(mergeId, ShouldBeGreaterThan, 0)
+			So(added, ShouldHaveLength, 3)
+			So(manager.GetToplevels(enums.UI_MANAGER_MENUBAR|enums.UI_MANAGER_TOOLBAR|enums.UI_MANAGER_POPUP), ShouldHaveLength, 3)
+			menubar, ok := manager.GetWidget("/MenuBar").(*CMenuBar)
+			So(ok, ShouldEqual, true)
+			So(menubar.GetItems(), ShouldHaveLength, 1)
+			fileItem, ok := manager.GetWidget("/MenuBar/FileMenu").(*CMenuItem)
+			So(ok, ShouldEqual, true)
+			So(fileItem.GetSubmenu(), ShouldNotBeNil)
+			So(fileItem.GetSubmenu().GetItems(), ShouldHaveLength, 3)
+			So(manager.GetAction("/MenuBar/FileMenu/Quit"), ShouldEqual, group.GetAction("Quit"))
+			toolbar, ok := manager.GetWidget("/ToolBar").(*CToolbar)
+			So(ok, ShouldEqual, true)
+			So(toolbar.GetNItems(), ShouldEqual, 3)
+			_, ok = manager.GetWidget("/Popup").(*CMenu)
+			So(ok, ShouldEqual, true)
+			activated := 0
+			group.GetAction("Quit").Connect(SignalActionActivate, "test-activate", func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+				activated += 1
+				return cenums.EVENT_PASS
+			})
+			quitItem := manager.GetWidget("/MenuBar/FileMenu/Quit")
+			So(quitItem, ShouldNotBeNil)
+			quitItem.Activate()
+			So(activated, ShouldEqual, 1)
+			quitButton, ok := manager.GetWidget("/ToolBar/Quit").(ToolButton)
+			So(ok, ShouldEqual, true)
+			quitButton.GetButton().Clicked()
+			So(activated, ShouldEqual, 2)
+			group.GetAction("Quit").SetSensitive(false)
+			So(quitItem.IsSensitive(), ShouldEqual, false)
+			So(quitButton.IsSensitive(), ShouldEqual, false)
+		})
+		Convey("Merging", func() {
+			manager, group := newTestUIManager()
+			_, err := manager.AddUiFromString(testUIManagerXML)
+			So(err, ShouldBeNil)
+			menubar := manager.GetWidget("/MenuBar")
+			mergeId, err := manager.AddUiFromString(testUIManagerMergeXML)
+			So(err, ShouldBeNil)
+			So(manager.GetWidget("/MenuBar"), ShouldEqual, menubar)
+			fileItem := manager.GetWidget("/MenuBar/FileMenu").(*CMenuItem)
+			So(fileItem.GetSubmenu().GetItems(), ShouldHaveLength, 4)
+			So(manager.GetWidget("/MenuBar/FileMenu/FileOps/Save"), ShouldNotBeNil)
+			So(manager.GetWidget("/MenuBar/FileMenu/Save"), ShouldNotBeNil)
+			So(manager.GetUi(), ShouldContainSubstring, `<menuitem name="Save" action="Save"/>`)
+			manager.RemoveUi(mergeId)
+			fileItem = manager.GetWidget("/MenuBar/FileMenu").(*CMenuItem)
+			So(fileItem.GetSubmenu().GetItems(), ShouldHaveLength, 3)
+			So(manager.GetWidget("/MenuBar/FileMenu/Save"), ShouldBeNil)
+			So(manager.GetUi(), ShouldNotContainSubstring, `Save`)
+			mergeId = manager.NewMergeId()
+			manager.AddUi(mergeId, "/ToolBar", "Save", "Save", enums.UI_MANAGER_AUTO, true)
+			toolbar := manager.GetWidget("/ToolBar").(*CToolbar)
+			So(toolbar.GetNItems(), ShouldEqual, 4)
+			save, ok := toolbar.GetNthItem(0).(ToolButton)
+			So(ok, ShouldEqual, true)
+			So(save.GetLabel(), ShouldEqual, "_Save")
+			So(manager.GetAction("/ToolBar/Save"), ShouldEqual, group.GetAction("Save"))
+			manager.RemoveUi(mergeId)
+			So(toolbar.GetNItems(), ShouldEqual, 3)
+		})
+	})
+}
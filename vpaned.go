@@ -0,0 +1,58 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+)
+
+const (
+	TypeVPaned cdk.CTypeTag = "ctk-v-paned"
+)
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeVPaned, func() interface{} { return MakeVPaned() })
+}
+
+// VPaned is a Paned with the two panes arranged one above the other, with a horizontal divider between them.
+type VPaned interface {
+	Paned
+}
+
+var _ VPaned = (*CVPaned)(nil)
+
+type CVPaned struct {
+	CPaned
+}
+
+func MakeVPaned() VPaned {
+	return NewVPaned()
+}
+
+func NewVPaned() VPaned {
+	p := new(CVPaned)
+	p.Init()
+	return p
+}
+
+func (p *CVPaned) Init() bool {
+	if p.InitTypeItem(TypeVPaned, p) {
+		return true
+	}
+	p.CPaned.Init()
+	p.SetOrientation(cenums.ORIENTATION_VERTICAL)
+	return false
+}
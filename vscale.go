@@ -0,0 +1,98 @@
+// Copyright (c) 2023  The Go-Curses Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctk
+
+import (
+	"github.com/go-curses/cdk"
+	cenums "github.com/go-curses/cdk/lib/enums"
+)
+
+const TypeVScale cdk.CTypeTag = "ctk-v-scale"
+
+func init() {
+	_ = cdk.TypesManager.AddType(TypeVScale, func() interface{} { return MakeVScale() })
+}
+
+// VScale Hierarchy:
+//
+//	Object
+//	  +- Widget
+//	    +- Range
+//	      +- Scale
+//	        +- VScale
+//
+// The VScale Widget is used to allow the user to select a value using a
+// vertical slider.
+type VScale interface {
+	Scale
+}
+
+var _ VScale = (*CVScale)(nil)
+
+// The CVScale structure implements the VScale interface and is exported to
+// facilitate type embedding with custom implementations. No member variables
+// are exported as the interface methods are the only intended means of
+// interacting with VScale objects.
+type CVScale struct {
+	CScale
+}
+
+// MakeVScale is used by the Buildable system to construct a new VScale.
+func MakeVScale() VScale {
+	return NewVScale(nil)
+}
+
+// NewVScale is the constructor for new VScale instances.
+//
+// Parameters:
+//
+//	adjustment	the Adjustment which sets the range of the scale, or nil to create a new one
+func NewVScale(adjustment *CAdjustment) VScale {
+	s := &CVScale{}
+	s.orientation = cenums.ORIENTATION_VERTICAL
+	s.Init()
+	s.SetAdjustment(adjustment)
+	return s
+}
+
+// NewVScaleWithRange creates a new VScale with an Adjustment that ranges from
+// min to max, moving by step with the arrow keys and by ten steps with the PgUp
+// and PgDn keys.
+//
+// Parameters:
+//
+//	min	minimum value
+//	max	maximum value
+//	step	step increment (tick size) used with keyboard shortcuts
+func NewVScaleWithRange(min, max, step int) VScale {
+	s := NewVScale(nil)
+	s.SetRange(min, max)
+	s.SetIncrements(step, step*10)
+	s.SetValue(min)
+	return s
+}
+
+// Init initializes a VScale object. This must be called at least once to set
+// up the necessary defaults and allocate any memory structures. Calling this
+// more than once is safe though unnecessary. Only the first call will result in
+// any effect upon the VScale instance. Init is used in the NewVScale
+// constructor and only necessary when implementing a derivative VScale type.
+func (s *CVScale) Init() (already bool) {
+	if s.InitTypeItem(TypeVScale, s) {
+		return true
+	}
+	s.CScale.Init()
+	return false
+}
@@ -109,6 +109,10 @@ type Widget interface {
 	SetSensitive(sensitive bool)
 	CssFullPath() (selector string)
 	CssState() (state enums.StateType)
+	AddStyleClass(name string)
+	RemoveStyleClass(name string)
+	HasStyleClass(name string) (value bool)
+	ListStyleClasses() (classes []string)
 	SetParent(parent Widget)
 	GetParentWindow() (value Window)
 	SetEvents(events cdk.EventMask)
@@ -237,6 +241,9 @@ type CWidget struct {
 	tooltipWindow      Window
 	tooltipTimer       uuid.UUID
 	tooltipBrowseTimer uuid.UUID
+
+	sizeGroups   []SizeGroup
+	styleClasses []string
 }
 
 // Init initializes a Widget object. This must be called at least once to
@@ -583,6 +590,11 @@ func (w *CWidget) tooltipDraw(data []interface{}, argv ...interface{}) cenums.Ev
 func (w *CWidget) Destroy() {
 	w.closeTooltip()
 	w.Emit(SignalDestroyEvent, w)
+	for _, group := range w.getSizeGroups() {
+		if self, ok := w.Self().(Widget); ok {
+			group.RemoveWidget(self)
+		}
+	}
 	w.DisconnectAll()
 	w.Hide()
 	if w.tooltipWindow != nil {
@@ -928,10 +940,20 @@ func (w *CWidget) SetSensitive(sensitive bool) {
 	}
 }
 
+// CssSelector returns a selector string identifying this exact Widget
+// instance, including any style classes added to the Widget.
+func (w *CWidget) CssSelector() (selector string) {
+	selector = w.CObject.CssSelector()
+	for _, class := range w.ListStyleClasses() {
+		selector += "." + class
+	}
+	return
+}
+
 // CssFullPath returns a CSS selector rule for the Widget which includes the
 // parent hierarchy in type form.
 func (w *CWidget) CssFullPath() (selector string) {
-	selector = w.CObject.CssSelector()
+	selector = w.CssSelector()
 	p := w.GetParent()
 	for {
 		if p != nil && p.ObjectID() != w.ObjectID() {
@@ -948,6 +970,61 @@ func (w *CWidget) CssFullPath() (selector string) {
 	return
 }
 
+// AddStyleClass adds the named style class to the Widget, if not already
+// present, and re-applies the window styles to the Widget and its children.
+func (w *CWidget) AddStyleClass(name string) {
+	if name == "" || w.HasStyleClass(name) {
+		return
+	}
+	w.Lock()
+	w.styleClasses = append(w.styleClasses, name)
+	w.Unlock()
+	w.restyle()
+}
+
+// RemoveStyleClass removes the named style class from the Widget, if present,
+// and re-applies the window styles to the Widget and its children.
+func (w *CWidget) RemoveStyleClass(name string) {
+	w.Lock()
+	for idx, class := range w.styleClasses {
+		if class == name {
+			w.styleClasses = append(w.styleClasses[:idx], w.styleClasses[idx+1:]...)
+			w.Unlock()
+			w.restyle()
+			return
+		}
+	}
+	w.Unlock()
+}
+
+// HasStyleClass returns TRUE if the Widget has the named style class.
+func (w *CWidget) HasStyleClass(name string) (value bool) {
+	w.RLock()
+	defer w.RUnlock()
+	for _, class := range w.styleClasses {
+		if class == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ListStyleClasses returns a copy of the style classes added to the Widget,
+// in the order they were added.
+func (w *CWidget) ListStyleClasses() (classes []string) {
+	w.RLock()
+	defer w.RUnlock()
+	classes = append(classes, w.styleClasses...)
+	return
+}
+
+func (w *CWidget) restyle() {
+	if window := w.GetWindow(); window != nil {
+		WidgetRecurseApplyStyles(window, w)
+	}
+	w.Invalidate()
+}
+
 func (w *CWidget) CssState() (state enums.StateType) {
 	if w.IsDrawable() && w.IsVisible() {
 		if w.IsSensitive() {
@@ -1098,6 +1175,14 @@ func (w *CWidget) GetPointer(x int, y int) {}
 // 	TRUE if ancestor contains widget as a child, grandchild, great
 // 	grandchild, etc.
 func (w *CWidget) IsAncestor(ancestor Widget) (value bool) {
+	if ancestor == nil {
+		return false
+	}
+	for parent := w.GetParent(); parent != nil; parent = parent.GetParent() {
+		if parent.ObjectID() == ancestor.ObjectID() {
+			return true
+		}
+	}
 	return false
 }
 
@@ -1599,6 +1684,35 @@ func (w *CWidget) SizeRequest() ptypes.Rectangle {
 	return ptypes.MakeRectangle(w.GetSizeRequest())
 }
 
+func (w *CWidget) addSizeGroup(group SizeGroup) {
+	w.Lock()
+	defer w.Unlock()
+	for _, g := range w.sizeGroups {
+		if g.ObjectID() == group.ObjectID() {
+			return
+		}
+	}
+	w.sizeGroups = append(w.sizeGroups, group)
+}
+
+func (w *CWidget) removeSizeGroup(group SizeGroup) {
+	w.Lock()
+	defer w.Unlock()
+	for idx, g := range w.sizeGroups {
+		if g.ObjectID() == group.ObjectID() {
+			w.sizeGroups = append(w.sizeGroups[:idx], w.sizeGroups[idx+1:]...)
+			return
+		}
+	}
+}
+
+func (w *CWidget) getSizeGroups() (groups []SizeGroup) {
+	w.RLock()
+	defer w.RUnlock()
+	groups = append(groups, w.sizeGroups...)
+	return
+}
+
 // Sets the minimum size of a widget; that is, the widget's size request will
 // be width by height . You can use this function to force a widget to be
 // either larger or smaller than it normally would be. In most cases,
@@ -81,4 +81,20 @@ func WidgetRecurseInvalidate(widget Widget) {
 			return cenums.EVENT_PASS
 		})
 	}
-}
\ No newline at end of file
+}
+
+// WidgetRecurseApplyStyles applies the styles of the given Window to the
+// widget and all of its composite and container children.
+func WidgetRecurseApplyStyles(window Window, widget Widget) {
+	if window != nil && widget != nil {
+		window.ApplyStylesTo(widget)
+		for _, composite := range widget.GetCompositeChildren() {
+			WidgetRecurseApplyStyles(window, composite)
+		}
+		if container, ok := widget.Self().(Container); ok {
+			for _, child := range container.GetChildren() {
+				WidgetRecurseApplyStyles(window, child)
+			}
+		}
+	}
+}
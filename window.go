@@ -17,6 +17,10 @@ package ctk
 import (
 	_ "embed"
 	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/gofrs/uuid"
 
 	"github.com/go-curses/cdk"
 	cenums "github.com/go-curses/cdk/lib/enums"
@@ -72,8 +76,13 @@ type Window interface {
 	Bin
 
 	ImportStylesFromString(css string) (err error)
+	ImportStylesFromFile(path string) (err error)
+	ImportStylesFromFS(fsys fs.FS, path string) (err error)
 	ReplaceStylesFromString(css string) (err error)
 	ExportStylesToString() (css string)
+	ReloadStyles() (err error)
+	WatchStyles(interval time.Duration)
+	UnwatchStyles()
 	ApplyStylesTo(widget Widget)
 	ReApplyStyles()
 	SetTitle(title string)
@@ -186,7 +195,8 @@ type CWindow struct {
 	receivingPaste bool
 	pasteBuffer    *string
 
-	styleSheet *cStyleSheet
+	styleSheet   *cStyleSheet
+	styleWatcher uuid.UUID
 }
 
 type mnemonicEntry struct {
@@ -341,38 +351,144 @@ func (w *CWindow) ImportStylesFromString(css string) (err error) {
 	return
 }
 
+// ImportStylesFromFile parses the named CSS file into the styles of the
+// Window. Any @import rules within the file are resolved relative to the
+// directory of the file. See WatchStyles for reloading the styles when the file
+// changes.
+func (w *CWindow) ImportStylesFromFile(path string) (err error) {
+	w.Lock()
+	if err = w.styleSheet.ParseFile(path); err != nil {
+		w.LogErr(err)
+	}
+	w.Unlock()
+	return
+}
+
+// ImportStylesFromFS is the same as ImportStylesFromFile except that the named
+// file, and any files it imports, are read from the given filesystem. This
+// is useful for styles embedded within the application with an embed.FS.
+func (w *CWindow) ImportStylesFromFS(fsys fs.FS, path string) (err error) {
+	w.Lock()
+	if err = w.styleSheet.ParseFS(fsys, path); err != nil {
+		w.LogErr(err)
+	}
+	w.Unlock()
+	return
+}
+
+// ReplaceStylesFromString replaces the styles of the Window with those parsed
+// from the given CSS source and re-applies the styles to all widgets. If there
+// are any errors parsing the styles, the current styles are left in place.
 func (w *CWindow) ReplaceStylesFromString(css string) (err error) {
+	var ss *cStyleSheet
+	if ss, err = newStyleSheetFromString(css); err != nil {
+		w.LogErr(err)
+		return
+	}
+	w.Lock()
+	ss.features = w.styleSheet.GetMediaFeatures()
+	ss.applied = w.styleSheet.appliedStyles()
+	w.styleSheet = ss
+	w.Unlock()
+	w.ReApplyStyles()
+	w.Invalidate()
+	return
+}
+
+// ReloadStyles re-parses all the strings and files imported into the styles of
+// the Window and re-applies the styles to all widgets. If there are any errors
+// parsing the styles, the current styles are left in place.
+func (w *CWindow) ReloadStyles() (err error) {
 	w.Lock()
 	var ss *cStyleSheet
-	if ss, err = newStyleSheetFromString(DefaultStyles); err != nil {
+	if ss, err = w.styleSheet.Reload(); err != nil {
+		w.Unlock()
 		w.LogErr(err)
-	} else {
-		w.styleSheet = ss
+		return
 	}
+	w.styleSheet = ss
 	w.Unlock()
+	w.ReApplyStyles()
+	w.Invalidate()
 	return
 }
 
+// WatchStyles starts checking the files imported into the styles of the
+// Window for changes, every interval, and reloads the styles when any of them
+// are modified. This is intended for iterating on themes while the application
+// is running. Only one watcher runs per Window, calling WatchStyles again
+// replaces the interval of the current watcher.
+func (w *CWindow) WatchStyles(interval time.Duration) {
+	w.UnwatchStyles()
+	w.Lock()
+	w.styleWatcher = cdk.AddTimeout(interval, w.watchStylesHandler)
+	w.Unlock()
+}
+
+// UnwatchStyles stops any watcher started with WatchStyles.
+func (w *CWindow) UnwatchStyles() {
+	w.Lock()
+	if w.styleWatcher != uuid.Nil {
+		cdk.StopTimeout(w.styleWatcher)
+		w.styleWatcher = uuid.Nil
+	}
+	w.Unlock()
+}
+
+func (w *CWindow) watchStylesHandler() cenums.EventFlag {
+	if w.getStyleSheet().Modified() {
+		w.LogDebug("style sheet files modified, reloading")
+		if err := w.ReloadStyles(); err != nil {
+			// keep the current styles until the files are modified again
+			w.getStyleSheet().updateFiles()
+		} else if display := w.GetDisplay(); display != nil {
+			display.RequestDraw()
+			display.RequestShow()
+		}
+	}
+	return cenums.EVENT_PASS
+}
+
 func (w *CWindow) ExportStylesToString() (css string) {
-	css = w.styleSheet.String()
+	css = w.getStyleSheet().String()
 	return
 }
 
 func (w *CWindow) ApplyStylesTo(widget Widget) {
-	w.styleSheet.ApplyStylesTo(widget)
+	w.getStyleSheet().ApplyStylesTo(widget)
+	// stop tracking the styles applied once the widget is destroyed
+	widget.Connect(
+		SignalDestroyEvent,
+		fmt.Sprintf("%v-%v", WindowStylesDestroyHandle, w.ObjectID()),
+		func(data []interface{}, argv ...interface{}) cenums.EventFlag {
+			w.getStyleSheet().forgetStylesOf(widget.ObjectID())
+			return cenums.EVENT_PASS
+		},
+	)
 }
 
 func (w *CWindow) ReApplyStyles() {
-	var recurse func(top Widget)
-	recurse = func(top Widget) {
-		w.styleSheet.ApplyStylesTo(top)
-		if container, ok := top.Self().(Container); ok {
-			for _, child := range container.GetChildren() {
-				recurse(child)
-			}
-		}
+	WidgetRecurseApplyStyles(w, w)
+}
+
+// getStyleSheet returns the current style sheet of the Window, which may be
+// replaced at any time by ReplaceStylesFromString or ReloadStyles.
+func (w *CWindow) getStyleSheet() (ss *cStyleSheet) {
+	w.RLock()
+	ss = w.styleSheet
+	w.RUnlock()
+	return
+}
+
+// refreshStyleMedia updates the media features of the style sheet from the
+// Display, falling back to the given allocation for the terminal size, and
+// re-applies all styles if any @media rules are affected by the change.
+func (w *CWindow) refreshStyleMedia(alloc ptypes.Rectangle) {
+	features := newStyleSheetMediaFeatures(w.GetDisplay(), alloc)
+	if ss := w.getStyleSheet(); ss.SetMediaFeatures(features) && ss.HasMediaRules() {
+		w.LogDebug("style media changed: %+v", features)
+		w.ReApplyStyles()
 	}
-	recurse(w)
 }
 
 func (w *CWindow) Show() {
@@ -1938,6 +2054,8 @@ func (w *CWindow) resize(data []interface{}, argv ...interface{}) cenums.EventFl
 		w.SetAllocation(alloc)
 	}
 
+	w.refreshStyleMedia(alloc)
+
 	title := w.GetTitle()
 	decorated := w.GetDecorated()
 
@@ -2246,4 +2364,6 @@ const WindowInvalidateHandle = "window-invalidate-handler"
 
 const WindowResizeHandle = "window-resize-handler"
 
-const WindowDrawHandle = "window-draw-handler"
\ No newline at end of file
+const WindowDrawHandle = "window-draw-handler"
+
+const WindowStylesDestroyHandle = "window-styles-destroy-handler"